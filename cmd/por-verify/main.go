@@ -0,0 +1,86 @@
+// Command por-verify confere, sem acesso à plataforma, a prova de inclusão da prova de reservas
+// baixada de GET /v2/me/reserves/proof. Recalcula o id da folha a partir de user_id e nonce, refaz o
+// caminho até a raiz e, com -root, confere com a raiz publicada em GET /v2/reserves.
+//
+// Uso:
+//
+//	por-verify [-root <hash>] [-balance <saldo>] [proof.json]
+//
+// Sem arquivo a prova é lida da entrada padrão.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"financial-system-pro/internal/shared/merkle"
+
+	"github.com/shopspring/decimal"
+)
+
+// accountProof corpo da resposta de GET /v2/me/reserves/proof
+type accountProof struct {
+	SnapshotID string       `json:"snapshot_id"`
+	Asset      string       `json:"asset"`
+	UserID     string       `json:"user_id"`
+	Nonce      string       `json:"nonce"`
+	Proof      merkle.Proof `json:"proof"`
+}
+
+func main() {
+	root := flag.String("root", "", "raiz publicada do ativo (hex); se vazia, usa a raiz contida na prova")
+	balance := flag.String("balance", "", "saldo esperado da conta; se vazio, não confere o valor")
+	flag.Parse()
+
+	in := io.Reader(os.Stdin)
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var p accountProof
+	if err := json.NewDecoder(in).Decode(&p); err != nil {
+		fail(fmt.Errorf("decode proof: %w", err))
+	}
+	if err := verify(p, *root, *balance); err != nil {
+		fail(err)
+	}
+	fmt.Printf("OK snapshot=%s asset=%s balance=%s root=%s liabilities=%s\n",
+		p.SnapshotID, p.Asset, p.Proof.Leaf.Sum.String(), p.Proof.Root.Hash, p.Proof.Root.Sum.String())
+}
+
+// verify confere o id da folha, o caminho até a raiz e, se informados, a raiz publicada e o saldo
+func verify(p accountProof, root, balance string) error {
+	if p.UserID == "" || p.Nonce == "" {
+		return errors.New("proof without user_id or nonce")
+	}
+	if id := merkle.AccountLeafID(p.UserID, p.Nonce); id != p.Proof.Leaf.ID {
+		return fmt.Errorf("leaf id %s does not belong to user %s", p.Proof.Leaf.ID, p.UserID)
+	}
+	if root != "" && root != p.Proof.Root.Hash {
+		return fmt.Errorf("proof root %s differs from published root %s", p.Proof.Root.Hash, root)
+	}
+	if balance != "" {
+		expected, err := decimal.NewFromString(balance)
+		if err != nil {
+			return fmt.Errorf("invalid -balance: %w", err)
+		}
+		if !expected.Equal(p.Proof.Leaf.Sum) {
+			return fmt.Errorf("leaf balance %s differs from expected %s", p.Proof.Leaf.Sum, expected)
+		}
+	}
+	return merkle.Verify(p.Proof)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "FAIL:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,18 @@
+-- Contadores de falhas de login por conta e por IP (proteção contra força bruta)
+-- Compartilhado entre instâncias para o bloqueio sobreviver a restarts
+CREATE TABLE IF NOT EXISTS user_context.login_attempts (
+    scope TEXT NOT NULL CHECK (scope IN ('account', 'ip')),
+    key TEXT NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ,
+    locked_until TIMESTAMPTZ,
+    unlock_token_hash TEXT,
+    unlock_token_expires_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, key)
+);
+
+-- Índice para limpeza de bloqueios expirados
+CREATE INDEX IF NOT EXISTS idx_login_attempts_locked_until ON user_context.login_attempts(locked_until);
+
+COMMENT ON TABLE user_context.login_attempts IS 'Falhas de login e bloqueios temporários por conta (email) e por IP';
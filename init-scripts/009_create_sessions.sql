@@ -0,0 +1,20 @@
+-- Sessões de login por dispositivo; o id é o `jti` do JWT emitido no login
+CREATE TABLE IF NOT EXISTS user_context.sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    device_fingerprint TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+-- Listagem de sessões ativas do usuário
+CREATE INDEX IF NOT EXISTS idx_sessions_user_active ON user_context.sessions(user_id, last_seen_at DESC) WHERE revoked_at IS NULL;
+
+-- Limpeza de sessões expiradas
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON user_context.sessions(expires_at);
+
+COMMENT ON TABLE user_context.sessions IS 'Sessões de login (dispositivo, IP, user agent, último acesso) com revogação imediata via jti';
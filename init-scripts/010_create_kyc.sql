@@ -0,0 +1,24 @@
+-- Nível de verificação KYC no agregado User (0=none, 1=basic, 2=verified, 3=enhanced)
+ALTER TABLE user_context.users ADD COLUMN IF NOT EXISTS kyc_level SMALLINT NOT NULL DEFAULT 0;
+
+-- Documentos enviados para elevar o nível KYC e o resultado da revisão
+CREATE TABLE IF NOT EXISTS user_context.kyc_submissions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES user_context.users(id) ON DELETE CASCADE,
+    requested_level SMALLINT NOT NULL CHECK (requested_level BETWEEN 1 AND 3),
+    document_type TEXT NOT NULL,
+    document_number TEXT NOT NULL DEFAULT '',
+    document_ref TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewer_id UUID,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_kyc_submissions_user_id ON user_context.kyc_submissions(user_id);
+
+-- Fila de revisão dos operadores
+CREATE INDEX IF NOT EXISTS idx_kyc_submissions_pending ON user_context.kyc_submissions(submitted_at) WHERE status = 'pending';
+
+COMMENT ON TABLE user_context.kyc_submissions IS 'Submissões de documentos KYC com estado de revisão (pending, approved, rejected)';
@@ -0,0 +1,60 @@
+-- Organizações (contas empresariais), membros com papéis e contas nomeadas por tenant.
+-- O tenant de uma conta é o ID do usuário (conta pessoal) ou da organização.
+
+CREATE TABLE IF NOT EXISTS user_context.organizations (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    owner_id UUID NOT NULL REFERENCES user_context.users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_context.organization_members (
+    organization_id UUID NOT NULL REFERENCES user_context.organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES user_context.users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'admin', 'finance', 'viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON user_context.organization_members(user_id);
+
+CREATE TABLE IF NOT EXISTS user_context.accounts (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    balance NUMERIC(20,8) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS user_context.account_transfers (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    from_account_id UUID NOT NULL REFERENCES user_context.accounts(id),
+    to_account_id UUID NOT NULL REFERENCES user_context.accounts(id),
+    amount NUMERIC(20,8) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_transfers_from ON user_context.account_transfers(tenant_id, from_account_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_account_transfers_to ON user_context.account_transfers(tenant_id, to_account_id, created_at DESC);
+
+-- Importa as contas nomeadas da tabela legada (public.accounts) como contas pessoais
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = 'public' AND table_name = 'accounts') THEN
+        INSERT INTO user_context.accounts (id, tenant_id, name, created_at, updated_at)
+        SELECT id, user_id, name, COALESCE(created_at, NOW()), COALESCE(created_at, NOW())
+        FROM public.accounts
+        ON CONFLICT DO NOTHING;
+    END IF;
+END $$;
+
+COMMENT ON TABLE user_context.accounts IS 'Contas nomeadas por tenant (usuário ou organização); toda consulta filtra por tenant_id';
@@ -0,0 +1,51 @@
+-- Monitoramento de fraude/AML: score por transação, avaliações das regras e casos para analistas
+
+CREATE SCHEMA IF NOT EXISTS compliance_context;
+GRANT ALL PRIVILEGES ON SCHEMA compliance_context TO postgres;
+COMMENT ON SCHEMA compliance_context IS 'Bounded Context: Monitoramento de fraude/AML, screening e casos de compliance';
+
+-- Score (0-100) atribuído pelas regras síncronas antes da execução
+ALTER TABLE IF EXISTS transaction_context.transactions ADD COLUMN IF NOT EXISTS risk_score SMALLINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS compliance_context.risk_assessments (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    phase TEXT NOT NULL CHECK (phase IN ('pre_execution', 'post_execution')),
+    score SMALLINT NOT NULL CHECK (score BETWEEN 0 AND 100),
+    blocked BOOLEAN NOT NULL DEFAULT false,
+    hits JSONB NOT NULL DEFAULT '[]'::jsonb,
+    evaluated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_transaction ON compliance_context.risk_assessments(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_user ON compliance_context.risk_assessments(user_id, evaluated_at DESC);
+
+CREATE TABLE IF NOT EXISTS compliance_context.cases (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    transaction_id UUID NOT NULL,
+    assessment_id UUID NOT NULL,
+    source TEXT NOT NULL DEFAULT 'rules',
+    score SMALLINT NOT NULL,
+    hits JSONB NOT NULL DEFAULT '[]'::jsonb,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'investigating', 'closed')),
+    assignee_id UUID,
+    resolution TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_cases_status ON compliance_context.cases(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_cases_user ON compliance_context.cases(user_id);
+
+CREATE TABLE IF NOT EXISTS compliance_context.case_notes (
+    id UUID PRIMARY KEY,
+    case_id UUID NOT NULL REFERENCES compliance_context.cases(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_case_notes_case ON compliance_context.case_notes(case_id, created_at);
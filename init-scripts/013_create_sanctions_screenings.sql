@@ -0,0 +1,20 @@
+-- Triagem de sanções/bloqueio: cada decisão guarda a versão da lista usada
+
+CREATE SCHEMA IF NOT EXISTS compliance_context;
+
+CREATE TABLE IF NOT EXISTS compliance_context.sanctions_screenings (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    direction TEXT NOT NULL CHECK (direction IN ('outbound', 'inbound')),
+    chain TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    reference TEXT NOT NULL DEFAULT '',
+    decision TEXT NOT NULL CHECK (decision IN ('clear', 'flag', 'block')),
+    matches JSONB NOT NULL DEFAULT '[]'::jsonb,
+    list_version TEXT NOT NULL,
+    screened_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sanctions_screenings_decision ON compliance_context.sanctions_screenings(decision, screened_at DESC);
+CREATE INDEX IF NOT EXISTS idx_sanctions_screenings_address ON compliance_context.sanctions_screenings(address);
@@ -0,0 +1,26 @@
+-- Catálogo de endereços de saque com confirmação por código, cool-down e modo allowlist
+
+CREATE TABLE IF NOT EXISTS user_context.withdrawal_addresses (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES user_context.users(id) ON DELETE CASCADE,
+    label VARCHAR(64) NOT NULL,
+    chain TEXT NOT NULL,
+    address TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending_confirmation' CHECK (status IN ('pending_confirmation', 'active')),
+    confirmation_hash TEXT NOT NULL DEFAULT '',
+    confirmation_expires_at TIMESTAMPTZ NOT NULL,
+    confirmation_attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    confirmed_at TIMESTAMPTZ,
+    available_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_withdrawal_addresses_unique ON user_context.withdrawal_addresses(user_id, chain, address);
+CREATE INDEX IF NOT EXISTS idx_withdrawal_addresses_user ON user_context.withdrawal_addresses(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS user_context.withdrawal_settings (
+    user_id UUID PRIMARY KEY REFERENCES user_context.users(id) ON DELETE CASCADE,
+    allowlist_only BOOLEAN NOT NULL DEFAULT false,
+    protected_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,36 @@
+-- Aprovação maker-checker de saques acima dos limites das políticas, com trilha de auditoria
+
+CREATE TABLE IF NOT EXISTS transaction_context.approval_requests (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL UNIQUE,
+    requester_id UUID NOT NULL,
+    policy_id TEXT NOT NULL,
+    amount NUMERIC(36, 18) NOT NULL,
+    currency TEXT NOT NULL,
+    chain TEXT NOT NULL DEFAULT '',
+    to_address TEXT NOT NULL DEFAULT '',
+    required_approvals SMALLINT NOT NULL CHECK (required_approvals > 0),
+    approver_roles JSONB NOT NULL DEFAULT '[]'::jsonb,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_approval_requests_status ON transaction_context.approval_requests(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_approval_requests_expiry ON transaction_context.approval_requests(expires_at) WHERE status = 'pending';
+
+-- Trilha append-only: quem solicitou, aprovou, recusou ou se o prazo expirou (actor_id nulo = sistema)
+CREATE TABLE IF NOT EXISTS transaction_context.approval_actions (
+    id UUID PRIMARY KEY,
+    request_id UUID NOT NULL REFERENCES transaction_context.approval_requests(id),
+    action TEXT NOT NULL CHECK (action IN ('requested', 'approved', 'rejected', 'expired')),
+    actor_id UUID,
+    roles JSONB NOT NULL DEFAULT '[]'::jsonb,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_approval_actions_request ON transaction_context.approval_actions(request_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_actions_one_approval
+    ON transaction_context.approval_actions(request_id, actor_id) WHERE action = 'approved';
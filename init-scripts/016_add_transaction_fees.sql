@@ -0,0 +1,11 @@
+-- Tabela de taxas: taxa total cobrada por transação e transações de taxa vinculadas à origem
+
+ALTER TABLE IF EXISTS transaction_context.transactions ADD COLUMN IF NOT EXISTS fee NUMERIC(36, 18) NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS transaction_context.transactions ADD COLUMN IF NOT EXISTS parent_id UUID;
+
+-- O tipo 'fee' não existia na restrição original de tipos
+ALTER TABLE IF EXISTS transaction_context.transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_parent_id
+    ON transaction_context.transactions (parent_id)
+    WHERE parent_id IS NOT NULL;
@@ -0,0 +1,34 @@
+-- Saldos por moeda e conversões de câmbio com cotação firme.
+-- A moeda base (BRL) continua em user_context.wallet_info.balance; as demais ficam em wallet_balances.
+
+CREATE TABLE IF NOT EXISTS user_context.wallet_balances (
+    user_id UUID NOT NULL,
+    currency TEXT NOT NULL,
+    balance NUMERIC(36, 18) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, currency)
+);
+
+CREATE SCHEMA IF NOT EXISTS fx_context;
+
+-- Cotações emitidas; as executadas são o histórico de conversões do usuário
+CREATE TABLE IF NOT EXISTS fx_context.quotes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    from_currency TEXT NOT NULL,
+    to_currency TEXT NOT NULL,
+    sell_amount NUMERIC(36, 18) NOT NULL CHECK (sell_amount > 0),
+    buy_amount NUMERIC(36, 18) NOT NULL CHECK (buy_amount > 0),
+    mid_rate NUMERIC(36, 18) NOT NULL,
+    rate NUMERIC(36, 18) NOT NULL,
+    spread_bps INTEGER NOT NULL CHECK (spread_bps >= 0 AND spread_bps < 10000),
+    source TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'executed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    executed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_quotes_user_executed
+    ON fx_context.quotes (user_id, executed_at DESC)
+    WHERE status = 'executed';
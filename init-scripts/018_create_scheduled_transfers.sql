@@ -0,0 +1,46 @@
+-- Transferências e saques agendados (únicos ou recorrentes) e histórico de execuções
+
+CREATE TABLE IF NOT EXISTS transaction_context.scheduled_transfers (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('transfer', 'withdraw')),
+    amount NUMERIC(36, 18) NOT NULL CHECK (amount > 0),
+    to_user_id UUID,
+    chain TEXT NOT NULL DEFAULT '',
+    to_address TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    recurrence TEXT NOT NULL DEFAULT '', -- RRULE ou cron; vazio = execução única
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ,
+    max_runs INTEGER NOT NULL DEFAULT 0,
+    run_count INTEGER NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMPTZ,
+    retry_at TIMESTAMPTZ,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_retries INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'cancelled', 'completed')),
+    version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_user ON transaction_context.scheduled_transfers(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_due
+    ON transaction_context.scheduled_transfers ((COALESCE(retry_at, next_run_at)))
+    WHERE status = 'active';
+
+-- Uma linha por tentativa; a chave única garante execução única mesmo com tarefas duplicadas na fila
+CREATE TABLE IF NOT EXISTS transaction_context.schedule_executions (
+    id UUID PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES transaction_context.scheduled_transfers(id),
+    occurrence TIMESTAMPTZ NOT NULL,
+    attempt INTEGER NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('running', 'succeeded', 'retrying', 'failed')),
+    transaction_id UUID,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    UNIQUE (schedule_id, occurrence, attempt)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_executions_schedule ON transaction_context.schedule_executions(schedule_id, started_at DESC);
@@ -0,0 +1,16 @@
+-- Estornos totais ou parciais de transações concluídas; a movimentação de saldo é a transação
+-- de tipo 'reversal' vinculada (parent_id = original_id)
+
+CREATE TABLE IF NOT EXISTS transaction_context.transaction_reversals (
+    id UUID PRIMARY KEY,
+    original_id UUID NOT NULL,
+    transaction_id UUID NOT NULL UNIQUE,
+    amount NUMERIC(36, 18) NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL CHECK (reason IN ('duplicate', 'operational_error', 'fraud', 'customer_request', 'other')),
+    note TEXT NOT NULL DEFAULT '',
+    operator_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_reversals_original
+    ON transaction_context.transaction_reversals (original_id, created_at);
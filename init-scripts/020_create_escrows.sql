@@ -0,0 +1,34 @@
+-- Custódias (escrow) entre comprador e vendedor com liberação condicionada, disputa e prazo
+
+CREATE TABLE IF NOT EXISTS transaction_context.escrows (
+    id UUID PRIMARY KEY,
+    buyer_id UUID NOT NULL,
+    seller_id UUID NOT NULL,
+    arbiter_id UUID,
+    amount NUMERIC(36, 18) NOT NULL CHECK (amount > 0),
+    description TEXT NOT NULL DEFAULT '',
+    conditions JSONB NOT NULL DEFAULT '[]',
+    deadline TIMESTAMPTZ NOT NULL,
+    timeout_action TEXT NOT NULL CHECK (timeout_action IN ('release', 'refund')),
+    status TEXT NOT NULL CHECK (status IN ('awaiting_funding', 'funded', 'disputed', 'released', 'refunded', 'resolved', 'cancelled')),
+    funding_tx_id UUID,
+    released_amount NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    refunded_amount NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    disputed_by UUID,
+    dispute_reason TEXT NOT NULL DEFAULT '',
+    resolved_by UUID,
+    resolution TEXT NOT NULL DEFAULT '',
+    version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    funded_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    CHECK (buyer_id <> seller_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_escrows_buyer ON transaction_context.escrows(buyer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_escrows_seller ON transaction_context.escrows(seller_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_escrows_arbiter ON transaction_context.escrows(arbiter_id) WHERE arbiter_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_escrows_deadline
+    ON transaction_context.escrows (deadline)
+    WHERE status IN ('awaiting_funding', 'funded');
@@ -0,0 +1,42 @@
+-- Lotes de pagamentos enviados por arquivo CSV/JSON e o resultado de cada item
+
+CREATE TABLE IF NOT EXISTS transaction_context.payout_batches (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('csv', 'json')),
+    status TEXT NOT NULL CHECK (status IN ('draft', 'rejected', 'processing', 'completed', 'completed_with_errors', 'cancelled')),
+    item_count INTEGER NOT NULL,
+    invalid_rows INTEGER NOT NULL DEFAULT 0,
+    total_amount NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    total_fee NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    confirmed_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_batches_user ON transaction_context.payout_batches(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS transaction_context.payout_items (
+    id UUID PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES transaction_context.payout_batches(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    kind TEXT NOT NULL DEFAULT '',
+    recipient_user_id UUID,
+    email TEXT NOT NULL DEFAULT '',
+    chain TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    amount NUMERIC(36, 18) NOT NULL,
+    fee NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    reference TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('valid', 'invalid', 'queued', 'processing', 'succeeded', 'failed', 'cancelled')),
+    transaction_id UUID,
+    error TEXT NOT NULL DEFAULT '',
+    processed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_items_batch ON transaction_context.payout_items(batch_id, line);
+CREATE INDEX IF NOT EXISTS idx_payout_items_queued
+    ON transaction_context.payout_items (batch_id)
+    WHERE status = 'queued';
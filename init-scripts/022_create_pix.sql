@@ -0,0 +1,60 @@
+-- Cobranças Pix de depósito (BR Code), créditos recebidos do PSP e pagamentos de BR Codes
+
+CREATE TABLE IF NOT EXISTS transaction_context.pix_charges (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    txid TEXT NOT NULL UNIQUE,
+    dynamic BOOLEAN NOT NULL DEFAULT FALSE,
+    amount NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('active', 'paid', 'expired')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    paid_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_pix_charges_user ON transaction_context.pix_charges(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pix_charges_expiring
+    ON transaction_context.pix_charges (expires_at)
+    WHERE status = 'active' AND expires_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS transaction_context.pix_credits (
+    id UUID PRIMARY KEY,
+    end_to_end_id TEXT NOT NULL UNIQUE,
+    txid TEXT NOT NULL DEFAULT '',
+    amount NUMERIC(36, 18) NOT NULL,
+    payer_name TEXT NOT NULL DEFAULT '',
+    payer_document TEXT NOT NULL DEFAULT '',
+    charge_id UUID REFERENCES transaction_context.pix_charges(id),
+    user_id UUID,
+    status TEXT NOT NULL CHECK (status IN ('received', 'credited', 'unmatched', 'failed')),
+    transaction_id UUID,
+    reason TEXT NOT NULL DEFAULT '',
+    paid_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pix_credits_status ON transaction_context.pix_credits(status, received_at DESC);
+
+CREATE TABLE IF NOT EXISTS transaction_context.pix_payments (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    transaction_id UUID NOT NULL,
+    pix_key TEXT NOT NULL,
+    txid TEXT NOT NULL DEFAULT '',
+    amount NUMERIC(36, 18) NOT NULL,
+    merchant_name TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('pending', 'sent', 'failed', 'cancelled')),
+    end_to_end_id TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_pix_payments_user ON transaction_context.pix_payments(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pix_payments_pending
+    ON transaction_context.pix_payments (created_at)
+    WHERE status = 'pending';
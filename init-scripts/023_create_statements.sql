@@ -0,0 +1,25 @@
+-- Extratos mensais gerados por carteira e índices das consultas de movimentações por endereço
+
+CREATE TABLE IF NOT EXISTS transaction_context.statements (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    account TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    opening_balance NUMERIC(36, 18) NOT NULL,
+    closing_balance NUMERIC(36, 18) NOT NULL,
+    total_credits NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    total_debits NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    line_count INTEGER NOT NULL DEFAULT 0,
+    lines JSONB NOT NULL DEFAULT '[]',
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, period_start, period_end)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statements_user ON transaction_context.statements(user_id, period_start DESC);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_from_address_created
+    ON transaction_context.transactions (from_address, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_to_address_created
+    ON transaction_context.transactions (to_address, created_at);
@@ -0,0 +1,5 @@
+-- Lotes de pagamento importados de arquivos ISO 20022 pain.001
+
+ALTER TABLE transaction_context.payout_batches DROP CONSTRAINT IF EXISTS payout_batches_format_check;
+ALTER TABLE transaction_context.payout_batches
+    ADD CONSTRAINT payout_batches_format_check CHECK (format IN ('csv', 'json', 'pain.001'));
@@ -0,0 +1,21 @@
+-- Busca de transações: rede da transação e índices da paginação por cursor (keyset) e dos filtros
+
+ALTER TABLE IF EXISTS transaction_context.transactions ADD COLUMN IF NOT EXISTS chain TEXT NOT NULL DEFAULT '';
+
+UPDATE transaction_context.transactions SET chain = 'pix'
+WHERE chain = '' AND (from_address LIKE 'pix:%' OR to_address LIKE 'pix:%');
+
+CREATE INDEX IF NOT EXISTS idx_transactions_user_created_id
+    ON transaction_context.transactions (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_amount_id
+    ON transaction_context.transactions (user_id, amount, id);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_status_created
+    ON transaction_context.transactions (user_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_type_created
+    ON transaction_context.transactions (user_id, type, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_chain_created
+    ON transaction_context.transactions (user_id, chain, created_at DESC)
+    WHERE chain <> '';
+CREATE INDEX IF NOT EXISTS idx_transactions_hash
+    ON transaction_context.transactions (transaction_hash)
+    WHERE transaction_hash <> '';
@@ -0,0 +1,53 @@
+-- Conciliação diária entre o passivo interno e os saldos/transações on-chain
+
+-- Transações registradas na rede pelo contexto blockchain (não havia migração para a tabela)
+CREATE TABLE IF NOT EXISTS blockchain_context.blockchain_transactions (
+    id UUID PRIMARY KEY,
+    network TEXT NOT NULL,
+    transaction_hash TEXT,
+    from_address TEXT NOT NULL,
+    to_address TEXT NOT NULL,
+    amount NUMERIC(36, 18) NOT NULL,
+    confirmations INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'pending',
+    block_number BIGINT NOT NULL DEFAULT 0,
+    gas_used BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    confirmed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_blockchain_transactions_hash
+    ON blockchain_context.blockchain_transactions (transaction_hash);
+CREATE INDEX IF NOT EXISTS idx_blockchain_transactions_network_created
+    ON blockchain_context.blockchain_transactions (network, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_chain_created
+    ON transaction_context.transactions (chain, created_at)
+    WHERE chain <> '';
+
+CREATE TABLE IF NOT EXISTS transaction_context.reconciliation_runs (
+    id UUID PRIMARY KEY,
+    run_date TIMESTAMPTZ NOT NULL UNIQUE,
+    status TEXT NOT NULL CHECK (status IN ('balanced', 'breaks')),
+    break_count INTEGER NOT NULL DEFAULT 0,
+    by_category JSONB NOT NULL DEFAULT '{}',
+    assets JSONB NOT NULL DEFAULT '[]',
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS transaction_context.reconciliation_breaks (
+    id UUID PRIMARY KEY,
+    run_id UUID NOT NULL REFERENCES transaction_context.reconciliation_runs(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    chain TEXT NOT NULL,
+    asset TEXT NOT NULL DEFAULT '',
+    transaction_id UUID,
+    tx_hash TEXT NOT NULL DEFAULT '',
+    expected NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    actual NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    detail TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_breaks_run ON transaction_context.reconciliation_breaks(run_id);
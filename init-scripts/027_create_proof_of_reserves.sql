@@ -0,0 +1,21 @@
+-- Prova de reservas: raízes das árvores de Merkle de somas por ativo e as folhas de cada conta
+
+CREATE TABLE IF NOT EXISTS transaction_context.reserves_snapshots (
+    id UUID PRIMARY KEY,
+    assets JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reserves_snapshots_created ON transaction_context.reserves_snapshots(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS transaction_context.reserves_leaves (
+    snapshot_id UUID NOT NULL REFERENCES transaction_context.reserves_snapshots(id) ON DELETE CASCADE,
+    asset TEXT NOT NULL,
+    user_id UUID NOT NULL,
+    nonce TEXT NOT NULL,
+    leaf_id TEXT NOT NULL,
+    balance NUMERIC(36, 18) NOT NULL CHECK (balance >= 0),
+    position INTEGER NOT NULL,
+    PRIMARY KEY (snapshot_id, asset, user_id),
+    UNIQUE (snapshot_id, asset, position)
+);
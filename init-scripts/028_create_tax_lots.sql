@@ -0,0 +1,34 @@
+-- Lotes de aquisição e alienações de ativos cripto para apuração de ganho de capital
+
+CREATE SCHEMA IF NOT EXISTS tax_context;
+
+CREATE TABLE IF NOT EXISTS tax_context.lots (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    asset TEXT NOT NULL,
+    quantity NUMERIC(36, 18) NOT NULL CHECK (quantity > 0),
+    cost_basis NUMERIC(36, 18) NOT NULL CHECK (cost_basis >= 0),
+    source TEXT NOT NULL CHECK (source IN ('conversion', 'deposit')),
+    source_id TEXT NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, source, source_id, asset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lots_user_acquired ON tax_context.lots(user_id, acquired_at);
+
+CREATE TABLE IF NOT EXISTS tax_context.disposals (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    asset TEXT NOT NULL,
+    quantity NUMERIC(36, 18) NOT NULL CHECK (quantity > 0),
+    proceeds NUMERIC(36, 18) NOT NULL CHECK (proceeds >= 0),
+    source TEXT NOT NULL CHECK (source IN ('conversion', 'deposit')),
+    source_id TEXT NOT NULL,
+    disposed_at TIMESTAMPTZ NOT NULL,
+    designations JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, source, source_id, asset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_disposals_user_disposed ON tax_context.disposals(user_id, disposed_at);
@@ -0,0 +1,21 @@
+-- Moeda de referência e retratos diários do portfólio
+
+CREATE SCHEMA IF NOT EXISTS portfolio_context;
+
+CREATE TABLE IF NOT EXISTS portfolio_context.preferences (
+    user_id UUID PRIMARY KEY,
+    reporting_currency TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_context.snapshots (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    snapshot_date DATE NOT NULL,
+    currency TEXT NOT NULL,
+    total NUMERIC(36, 18) NOT NULL,
+    by_asset JSONB NOT NULL DEFAULT '[]',
+    complete BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, snapshot_date)
+);
@@ -0,0 +1,75 @@
+-- Contas remuneradas: tipos com tabela de taxas, apropriação diária e capitalização mensal
+
+CREATE TABLE IF NOT EXISTS transaction_context.interest_account_types (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    day_count TEXT NOT NULL CHECK (day_count IN ('ACT/365', 'ACT/360', 'BUS/252')),
+    schedule JSONB NOT NULL DEFAULT '[]',
+    min_balance NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    withholding_rate NUMERIC(10, 6) NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS transaction_context.interest_accounts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    type_code TEXT NOT NULL REFERENCES transaction_context.interest_account_types(code),
+    status TEXT NOT NULL CHECK (status IN ('active', 'closed')),
+    accrued_unpaid NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    last_accrual_date TIMESTAMPTZ,
+    opened_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ
+);
+
+-- uma conta ativa por usuário
+CREATE UNIQUE INDEX IF NOT EXISTS idx_interest_accounts_active_user
+    ON transaction_context.interest_accounts (user_id)
+    WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS transaction_context.interest_capitalizations (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES transaction_context.interest_accounts(id),
+    user_id UUID NOT NULL,
+    period TEXT NOT NULL,
+    gross NUMERIC(36, 18) NOT NULL,
+    withholding_rate NUMERIC(10, 6) NOT NULL DEFAULT 0,
+    withholding NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    net NUMERIC(36, 18) NOT NULL,
+    accrual_count INTEGER NOT NULL DEFAULT 0,
+    interest_tx_id UUID,
+    tax_tx_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (account_id, period)
+);
+
+CREATE TABLE IF NOT EXISTS transaction_context.interest_accruals (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL REFERENCES transaction_context.interest_accounts(id),
+    user_id UUID NOT NULL,
+    accrual_date TIMESTAMPTZ NOT NULL,
+    balance NUMERIC(36, 18) NOT NULL,
+    annual_rate NUMERIC(20, 10) NOT NULL,
+    day_count TEXT NOT NULL,
+    daily_rate NUMERIC(36, 18) NOT NULL,
+    amount NUMERIC(36, 18) NOT NULL,
+    capitalization_id UUID REFERENCES transaction_context.interest_capitalizations(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (account_id, accrual_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interest_accruals_unpaid
+    ON transaction_context.interest_accruals (account_id, accrual_date)
+    WHERE capitalization_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS transaction_context.interest_accrual_runs (
+    run_date TIMESTAMPTZ PRIMARY KEY,
+    accounts INTEGER NOT NULL DEFAULT 0,
+    accrued INTEGER NOT NULL DEFAULT 0,
+    total NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    capitalizations INTEGER NOT NULL DEFAULT 0,
+    failures INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,70 @@
+-- Linhas de crédito (cheque especial): limite aprovado, encargos em aberto e pagamentos alocados
+
+CREATE TABLE IF NOT EXISTS transaction_context.credit_lines (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    credit_limit NUMERIC(36, 18) NOT NULL CHECK (credit_limit > 0),
+    annual_rate NUMERIC(20, 10) NOT NULL DEFAULT 0,
+    day_count TEXT NOT NULL CHECK (day_count IN ('ACT/365', 'ACT/360', 'BUS/252')),
+    usage_fee NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    late_fee NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    grace_days INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL CHECK (status IN ('active', 'frozen', 'closed')),
+    accrued_interest NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    interest_due NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    fees_due NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    due_date TIMESTAMPTZ,
+    used_in_cycle BOOLEAN NOT NULL DEFAULT FALSE,
+    late_fee_charged BOOLEAN NOT NULL DEFAULT FALSE,
+    last_accrual_date TIMESTAMPTZ,
+    version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ
+);
+
+-- uma linha não encerrada por usuário
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_lines_open_user
+    ON transaction_context.credit_lines (user_id)
+    WHERE status <> 'closed';
+
+CREATE TABLE IF NOT EXISTS transaction_context.credit_charges (
+    id UUID PRIMARY KEY,
+    line_id UUID NOT NULL REFERENCES transaction_context.credit_lines(id),
+    user_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('interest', 'usage_fee', 'late_fee')),
+    amount NUMERIC(36, 18) NOT NULL,
+    period TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_charges_line
+    ON transaction_context.credit_charges (line_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS transaction_context.credit_repayments (
+    id UUID PRIMARY KEY,
+    line_id UUID NOT NULL REFERENCES transaction_context.credit_lines(id),
+    user_id UUID NOT NULL,
+    source_tx_id UUID,
+    amount NUMERIC(36, 18) NOT NULL,
+    fees NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    interest NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    principal NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    fee_tx_id UUID,
+    interest_tx_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_repayments_line
+    ON transaction_context.credit_repayments (line_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS transaction_context.credit_accrual_runs (
+    run_date TIMESTAMPTZ PRIMARY KEY,
+    lines INTEGER NOT NULL DEFAULT 0,
+    accrued NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    charges INTEGER NOT NULL DEFAULT 0,
+    repayments INTEGER NOT NULL DEFAULT 0,
+    failures INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
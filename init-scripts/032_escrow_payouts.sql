@@ -0,0 +1,24 @@
+-- Pagamentos das custódias encerradas: a transação creditada de cada lado fica registrada e o que
+-- faltar é retomado pela varredura
+
+ALTER TABLE IF EXISTS transaction_context.escrows ADD COLUMN IF NOT EXISTS release_tx_id UUID;
+ALTER TABLE IF EXISTS transaction_context.escrows ADD COLUMN IF NOT EXISTS refund_tx_id UUID;
+
+-- Custódias já encerradas: vincula os pagamentos concluídos antes desta migração
+UPDATE transaction_context.escrows e
+SET release_tx_id = t.id
+FROM transaction_context.transactions t
+WHERE e.release_tx_id IS NULL AND e.released_amount > 0
+    AND t.type = 'escrow_payout' AND t.status = 'completed'
+    AND t.parent_id = e.funding_tx_id AND t.user_id = e.seller_id;
+
+UPDATE transaction_context.escrows e
+SET refund_tx_id = t.id
+FROM transaction_context.transactions t
+WHERE e.refund_tx_id IS NULL AND e.refunded_amount > 0
+    AND t.type = 'escrow_payout' AND t.status = 'completed'
+    AND t.parent_id = e.funding_tx_id AND t.user_id = e.buyer_id;
+
+CREATE INDEX IF NOT EXISTS idx_escrows_pending_payouts
+    ON transaction_context.escrows (closed_at)
+    WHERE (released_amount > 0 AND release_tx_id IS NULL) OR (refunded_amount > 0 AND refund_tx_id IS NULL);
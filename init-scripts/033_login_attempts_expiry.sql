@@ -0,0 +1,3 @@
+-- Expiração dos contadores de login no PostgreSQL (equivalente ao TTL do Redis)
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_updated_at ON user_context.login_attempts(updated_at);
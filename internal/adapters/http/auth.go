@@ -1,11 +1,15 @@
 package http
 
 import (
+	"errors"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
 	"financial-system-pro/internal/shared/utils"
+	"os"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
 func VerifyJWTMiddleware() fiber.Handler {
@@ -39,7 +43,106 @@ func VerifyJWTMiddleware() fiber.Handler {
 		}
 
 		c.Locals("user_id", userID)
+		if jti, ok := claims["jti"].(string); ok && jti != "" {
+			c.Locals("session_id", jti)
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireActiveSession exige que o `jti` do token corresponda a uma sessão ativa.
+// Deve ser usado após VerifyJWTMiddleware; sem SessionService configurado não faz nada.
+func RequireActiveSession(sessions *userSvc.SessionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if sessions == nil {
+			return c.Next()
+		}
+
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		sessionID, err := extractSessionID(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token without session"})
+		}
+
+		if err := sessions.Validate(c.UserContext(), userID, sessionID, safeIP(c)); err != nil {
+			if errors.Is(err, userSvc.ErrSessionNotFound) || errors.Is(err, userSvc.ErrSessionRevoked) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Session revoked or expired"})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "session validation failed"})
+		}
 
 		return c.Next()
 	}
 }
+
+// extractSessionID retorna o jti do token presente em contexto
+func extractSessionID(c *fiber.Ctx) (uuid.UUID, error) {
+	sessionID, ok := c.Locals("session_id").(string)
+	if !ok || sessionID == "" {
+		return uuid.UUID{}, fiber.ErrUnauthorized
+	}
+	return uuid.Parse(sessionID)
+}
+
+// RequireOperator restringe a rota aos usuários listados em OPERATOR_USER_IDS (separados por vírgula).
+// Deve ser usado após VerifyJWTMiddleware; o ID do operador fica em c.Locals("operator_id") e os
+// papéis configurados em OPERATOR_ROLES em c.Locals("operator_roles").
+func RequireOperator() fiber.Handler {
+	operators := make(map[string]struct{})
+	for _, id := range strings.Split(os.Getenv("OPERATOR_USER_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			operators[strings.ToLower(id)] = struct{}{}
+		}
+	}
+	roles := parseOperatorRoles(os.Getenv("OPERATOR_ROLES"))
+
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("user_id").(string)
+		if !ok || userID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		if _, ok := operators[strings.ToLower(userID)]; !ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+		}
+		c.Locals("operator_id", userID)
+		c.Locals("operator_roles", roles[strings.ToLower(userID)])
+		return c.Next()
+	}
+}
+
+// parseOperatorRoles lê OPERATOR_ROLES no formato "id=papel1|papel2,id2=papel3"
+func parseOperatorRoles(spec string) map[string][]string {
+	roles := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ",") {
+		id, list, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || strings.TrimSpace(id) == "" {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(id))
+		for _, role := range strings.Split(list, "|") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles[key] = append(roles[key], role)
+			}
+		}
+	}
+	return roles
+}
+
+// extractOperatorRoles retorna os papéis do operador autenticado (OPERATOR_ROLES)
+func extractOperatorRoles(c *fiber.Ctx) []string {
+	roles, _ := c.Locals("operator_roles").([]string)
+	return roles
+}
+
+// extractOperatorID retorna o ID do operador autenticado
+func extractOperatorID(c *fiber.Ctx) (uuid.UUID, error) {
+	operatorID, ok := c.Locals("operator_id").(string)
+	if !ok || operatorID == "" {
+		return uuid.UUID{}, fiber.ErrForbidden
+	}
+	return uuid.Parse(operatorID)
+}
@@ -16,11 +16,12 @@ func RegisterRoutes(
 	app *fiber.App,
 	dddUserService *userDDD.UserService,
 	dddTransactionService *txnDDD.TransactionService,
+	services container.RouteServices,
 	logger *zap.Logger,
 	breakerManager *breaker.BreakerManager,
 ) {
 	// Apenas rotas DDD v2
-	registerV2DDDRoutes(app, dddUserService, dddTransactionService, logger, breakerManager)
+	registerV2DDDRoutes(app, dddUserService, dddTransactionService, services, logger, breakerManager)
 }
 
 // RegisterDDDRoutes é a função para registrar apenas rotas DDD
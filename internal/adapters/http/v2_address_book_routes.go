@@ -0,0 +1,167 @@
+package http
+
+import (
+	"context"
+	"errors"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2AddressBookRoutes registra o catálogo de endereços de saque e o modo allowlist
+func registerV2AddressBookRoutes(me fiber.Router, addresses *userSvc.AddressBookService) {
+	me.Get("/withdrawal-addresses", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := addresses.List(context.Background(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, a := range list {
+			out = append(out, withdrawalAddressJSON(a))
+		}
+		return c.JSON(fiber.Map{"addresses": out})
+	})
+
+	me.Post("/withdrawal-addresses", func(c *fiber.Ctx) error {
+		var body struct {
+			Label   string `json:"label"`
+			Chain   string `json:"chain"`
+			Address string `json:"address"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		entry, err := addresses.Add(context.Background(), userID, body.Label, body.Chain, body.Address)
+		if err != nil {
+			return addressBookErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(withdrawalAddressJSON(entry))
+	})
+
+	me.Post("/withdrawal-addresses/:id/confirm", func(c *fiber.Ctx) error {
+		addressID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		var body struct {
+			Code string `json:"code"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Code == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "code is required"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		entry, err := addresses.Confirm(context.Background(), userID, addressID, body.Code)
+		if err != nil {
+			return addressBookErrorResponse(c, err)
+		}
+		return c.JSON(withdrawalAddressJSON(entry))
+	})
+
+	me.Delete("/withdrawal-addresses/:id", func(c *fiber.Ctx) error {
+		addressID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		if err := addresses.Remove(context.Background(), userID, addressID); err != nil {
+			return addressBookErrorResponse(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	me.Get("/withdrawal-settings", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		settings, err := addresses.Settings(context.Background(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(withdrawalSettingsJSON(settings))
+	})
+
+	me.Put("/withdrawal-settings", func(c *fiber.Ctx) error {
+		var body struct {
+			AllowlistOnly *bool `json:"allowlist_only"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.AllowlistOnly == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "allowlist_only is required"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		settings, err := addresses.SetAllowlistOnly(context.Background(), userID, *body.AllowlistOnly)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(withdrawalSettingsJSON(settings))
+	})
+}
+
+// destinationErrorResponse converte recusas de destino de saque (allowlist, cool-down, endereço inválido)
+func destinationErrorResponse(err error) (int, fiber.Map, bool) {
+	switch {
+	case errors.Is(err, userSvc.ErrDestinationNotAllowlisted),
+		errors.Is(err, userEntity.ErrAddressNotConfirmed),
+		errors.Is(err, userEntity.ErrAddressCoolingDown):
+		return fiber.StatusForbidden, fiber.Map{"error": err.Error()}, true
+	case errors.Is(err, userSvc.ErrInvalidWithdrawalAddress), errors.Is(err, userSvc.ErrUnsupportedChain):
+		return fiber.StatusBadRequest, fiber.Map{"error": err.Error()}, true
+	}
+	return 0, nil, false
+}
+
+func addressBookErrorResponse(c *fiber.Ctx, err error) error {
+	if status, resp, ok := destinationErrorResponse(err); ok {
+		return c.Status(status).JSON(resp)
+	}
+	switch {
+	case errors.Is(err, userSvc.ErrWithdrawalAddressNotFound), errors.Is(err, userSvc.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userSvc.ErrWithdrawalAddressExists), errors.Is(err, userEntity.ErrAddressAlreadyConfirmed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userEntity.ErrAddressConfirmationFailed):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userEntity.ErrInvalidAddressLabel):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
+
+func withdrawalAddressJSON(a *userEntity.WithdrawalAddress) fiber.Map {
+	return fiber.Map{
+		"id":           a.ID,
+		"label":        a.Label,
+		"chain":        a.Chain,
+		"address":      a.Address,
+		"status":       a.Status,
+		"created_at":   a.CreatedAt,
+		"confirmed_at": a.ConfirmedAt,
+		"available_at": a.AvailableAt,
+	}
+}
+
+func withdrawalSettingsJSON(s *userEntity.WithdrawalSettings) fiber.Map {
+	return fiber.Map{
+		"allowlist_only":  s.AllowlistOnly,
+		"protected_until": s.ProtectedUntil,
+	}
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2ApprovalRoutes registra a fila de aprovação de saques retidos (operador)
+func registerV2ApprovalRoutes(operator fiber.Router, approvals *txnSvc.ApprovalService) {
+	operator.Get("/approvals/policies", func(c *fiber.Ctx) error {
+		policies := approvals.Policies()
+		if policies == nil {
+			policies = []txnEntity.ApprovalPolicy{}
+		}
+		return c.JSON(fiber.Map{"policies": policies})
+	})
+
+	operator.Get("/approvals", func(c *fiber.Ctx) error {
+		list, err := approvals.List(context.Background(), txnEntity.ApprovalStatus(c.Query("status")), c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, req := range list {
+			out = append(out, approvalJSON(req))
+		}
+		return c.JSON(fiber.Map{"approvals": out})
+	})
+
+	operator.Get("/approvals/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		req, err := approvals.Get(context.Background(), id)
+		if err != nil {
+			return approvalErrorResponse(c, err)
+		}
+		return c.JSON(approvalJSON(req))
+	})
+
+	decide := func(approve bool) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+			}
+			var body struct {
+				Comment string `json:"comment"`
+			}
+			if err := c.BodyParser(&body); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+			}
+			if !approve && body.Comment == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "comment is required"})
+			}
+			operatorID, err := extractOperatorID(c)
+			if err != nil {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+			}
+
+			var req *txnEntity.ApprovalRequest
+			if approve {
+				req, err = approvals.Approve(context.Background(), id, operatorID, extractOperatorRoles(c), body.Comment)
+			} else {
+				req, err = approvals.Reject(context.Background(), id, operatorID, extractOperatorRoles(c), body.Comment)
+			}
+			if err != nil {
+				return approvalErrorResponse(c, err)
+			}
+			return c.JSON(approvalJSON(req))
+		}
+	}
+	operator.Post("/approvals/:id/approve", decide(true))
+	operator.Post("/approvals/:id/reject", decide(false))
+}
+
+func approvalErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrApprovalNotFound), errors.Is(err, txnSvc.ErrTransactionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrSelfApproval), errors.Is(err, txnEntity.ErrApproverRoleNotAllowed):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrApprovalResolved), errors.Is(err, txnEntity.ErrApprovalExpired), errors.Is(err, txnEntity.ErrDuplicateApproval):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
+
+// approvalJSON inclui a contagem de aprovações e a trilha apenas quando a trilha foi carregada (detalhe)
+func approvalJSON(req *txnEntity.ApprovalRequest) fiber.Map {
+	out := fiber.Map{
+		"id":                 req.ID,
+		"transaction_id":     req.TransactionID,
+		"requester_id":       req.RequesterID,
+		"policy_id":          req.PolicyID,
+		"amount":             req.Amount.String(),
+		"currency":           req.Currency,
+		"chain":              req.Chain,
+		"to_address":         req.ToAddress,
+		"required_approvals": req.RequiredApprovals,
+		"approver_roles":     req.ApproverRoles,
+		"status":             req.Status,
+		"created_at":         req.CreatedAt,
+		"expires_at":         req.ExpiresAt,
+		"resolved_at":        req.ResolvedAt,
+	}
+	if req.Trail != nil {
+		out["approvals"] = req.Approvals()
+		out["trail"] = req.Trail
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"errors"
+	fxSvc "financial-system-pro/internal/contexts/fx/application/service"
+	fxEntity "financial-system-pro/internal/contexts/fx/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2ConversionRoutes registra saldos por moeda, cotações e conversões de câmbio
+func registerV2ConversionRoutes(api, me fiber.Router, sessions *userSvc.SessionService, conversions *fxSvc.ConversionService) {
+	me.Get("/balances", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		balances, err := conversions.Balances(context.Background(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(fiber.Map{"balances": balances})
+	})
+
+	group := api.Group("/conversions", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	group.Post("/quotes", func(c *fiber.Ctx) error {
+		var body struct {
+			From   string `json:"from"`
+			To     string `json:"to"`
+			Amount string `json:"amount"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amt, err := decimal.NewFromString(body.Amount)
+		if err != nil || amt.LessThanOrEqual(decimal.Zero) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		quote, err := conversions.Quote(context.Background(), userID, body.From, body.To, amt)
+		if err != nil {
+			return conversionErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(quote)
+	})
+
+	// Executa a troca pela cotação firme: débito e crédito acontecem na mesma transação
+	group.Post("/", func(c *fiber.Ctx) error {
+		var body struct {
+			QuoteID string `json:"quote_id"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		quoteID, err := uuid.Parse(body.QuoteID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid quote_id"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		conversion, err := conversions.Execute(context.Background(), userID, quoteID)
+		if err != nil {
+			return conversionErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(conversion)
+	})
+
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := conversions.Conversions(context.Background(), userID, c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*fxEntity.Quote{}
+		}
+		return c.JSON(fiber.Map{"conversions": list})
+	})
+}
+
+func conversionErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, fxSvc.ErrQuoteNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, fxEntity.ErrQuoteExpired), errors.Is(err, fxEntity.ErrQuoteExecuted):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, fxEntity.ErrInsufficientFunds):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, fxEntity.ErrRateUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, fxEntity.ErrSameCurrency), errors.Is(err, fxEntity.ErrAmountTooSmall),
+		errors.Is(err, fxSvc.ErrInvalidPrecision), errors.Is(err, sharedVO.ErrUnsupportedCurrency):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2CreditRoutes registra a aprovação e manutenção das linhas de crédito (operador), a
+// execução manual da apropriação de juros e a posição da linha do usuário
+func registerV2CreditRoutes(api, operator fiber.Router, sessions *userSvc.SessionService, credit *txnSvc.CreditService) {
+	// Aprova a linha ou altera os termos; annual_rate em fração (0.12 = 12% a.a.)
+	operator.Put("/credit/lines/:user_id", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("user_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user_id"})
+		}
+		var terms txnEntity.CreditTerms
+		if err := c.BodyParser(&terms); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		line, err := credit.Approve(context.Background(), userID, terms)
+		if err != nil {
+			return creditErrorResponse(c, err)
+		}
+		return c.JSON(line)
+	})
+
+	operator.Post("/credit/lines/:user_id/freeze", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("user_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user_id"})
+		}
+		var body struct {
+			Frozen *bool `json:"frozen"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Frozen == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		line, err := credit.Freeze(context.Background(), userID, *body.Frozen)
+		if err != nil {
+			return creditErrorResponse(c, err)
+		}
+		return c.JSON(line)
+	})
+
+	operator.Get("/credit/lines/:user_id", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("user_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user_id"})
+		}
+		summary, err := credit.Summary(context.Background(), userID)
+		if err != nil {
+			return creditErrorResponse(c, err)
+		}
+		return c.JSON(summary)
+	})
+
+	// Encerra a linha; exige a carteira sem saldo negativo e os encargos pagos
+	operator.Delete("/credit/lines/:user_id", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("user_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user_id"})
+		}
+		line, err := credit.Close(context.Background(), userID)
+		if err != nil {
+			return creditErrorResponse(c, err)
+		}
+		return c.JSON(line)
+	})
+
+	// Executa (ou completa) a apropriação até ?date=AAAA-MM-DD; padrão o dia anterior
+	operator.Post("/credit/accruals", func(c *fiber.Ctx) error {
+		day := credit.Yesterday()
+		if date := c.Query("date"); date != "" {
+			var err error
+			if day, err = credit.ParseDate(date); err != nil {
+				return creditErrorResponse(c, err)
+			}
+		}
+		run, err := credit.AccrueDay(context.Background(), day)
+		if err != nil {
+			return creditErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(run)
+	})
+
+	group := api.Group("/credit", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	// Limite, utilizado, encargos em aberto e últimos lançamentos da linha
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		summary, err := credit.Summary(context.Background(), userID)
+		if err != nil {
+			return creditErrorResponse(c, err)
+		}
+		return c.JSON(summary)
+	})
+}
+
+func creditErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrCreditLineNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrCreditLineExists), errors.Is(err, txnEntity.ErrCreditLineClosed),
+		errors.Is(err, txnEntity.ErrCreditLineInUse), errors.Is(err, txnEntity.ErrCreditLineConflict):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrInvalidCreditDate), errors.Is(err, txnEntity.ErrInvalidCreditTerms),
+		errors.Is(err, txnEntity.ErrUnknownDayCount):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
+
+// creditBlockedResponse converte o bloqueio de saídas pela linha de crédito (encargos em atraso ou
+// uso acima do limite) no corpo de resposta
+func creditBlockedResponse(err error) (fiber.Map, bool) {
+	if !errors.Is(err, txnEntity.ErrCreditLinePastDue) && !errors.Is(err, txnEntity.ErrCreditLimitExceeded) {
+		return nil, false
+	}
+	return fiber.Map{"error": err.Error(), "reason": "credit_line_blocked"}, true
+}
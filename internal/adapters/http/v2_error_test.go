@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"financial-system-pro/internal/infrastructure/config/container"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -35,7 +36,7 @@ func TestV2Routes_ErrorCases(t *testing.T) {
 
 	// App
 	app := fiber.New()
-	registerV2DDDRoutes(app, dddUserSvc, dddTxnSvc, logger, breakerManager)
+	registerV2DDDRoutes(app, dddUserSvc, dddTxnSvc, container.RouteServices{}, logger, breakerManager)
 
 	t.Run("CreateUser_InvalidBody", func(t *testing.T) {
 		req := httptest.NewRequest("POST", "/v2/users", strings.NewReader(`{invalid}`))
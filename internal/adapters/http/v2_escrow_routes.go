@@ -0,0 +1,213 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2EscrowRoutes registra as custódias entre comprador e vendedor e a decisão de disputas pelos operadores
+func registerV2EscrowRoutes(api, operator fiber.Router, sessions *userSvc.SessionService, escrows *txnSvc.EscrowService) {
+	group := api.Group("/escrows", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	group.Post("/", func(c *fiber.Ctx) error {
+		var body struct {
+			SellerID      string    `json:"seller_id"`
+			ArbiterID     string    `json:"arbiter_id"`
+			Amount        string    `json:"amount"`
+			Description   string    `json:"description"`
+			Conditions    []string  `json:"conditions"`
+			Deadline      time.Time `json:"deadline"`
+			TimeoutAction string    `json:"timeout_action"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amt, err := decimal.NewFromString(body.Amount)
+		if err != nil || amt.LessThanOrEqual(decimal.Zero) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+		}
+		sellerID, err := uuid.Parse(body.SellerID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid seller_id"})
+		}
+		buyerID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		spec := txnEntity.EscrowSpec{
+			BuyerID:       buyerID,
+			SellerID:      sellerID,
+			Amount:        amt,
+			Description:   body.Description,
+			Conditions:    body.Conditions,
+			Deadline:      body.Deadline,
+			TimeoutAction: txnEntity.EscrowTimeoutAction(body.TimeoutAction),
+		}
+		if body.ArbiterID != "" {
+			arbiterID, err := uuid.Parse(body.ArbiterID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid arbiter_id"})
+			}
+			spec.ArbiterID = &arbiterID
+		}
+
+		escrow, err := escrows.Create(context.Background(), spec)
+		if err != nil {
+			return escrowErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(escrow)
+	})
+
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := escrows.List(context.Background(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.Escrow{}
+		}
+		return c.JSON(fiber.Map{"escrows": list})
+	})
+
+	// action executa a operação da parte autenticada sobre a custódia :id
+	action := func(apply func(ctx context.Context, c *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error)) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+			}
+			userID, err := extractUserIDFromJWT(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			escrow, err := apply(context.Background(), c, userID, id)
+			if err != nil {
+				return escrowErrorResponse(c, err)
+			}
+			return c.JSON(escrow)
+		}
+	}
+
+	group.Get("/:id", action(func(ctx context.Context, _ *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		return escrows.Get(ctx, userID, id)
+	}))
+	group.Post("/:id/fund", action(func(ctx context.Context, _ *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		return escrows.Fund(ctx, userID, id)
+	}))
+	group.Post("/:id/conditions/:index/confirm", action(func(ctx context.Context, c *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		idx, err := c.ParamsInt("index")
+		if err != nil {
+			return nil, txnEntity.ErrInvalidEscrow
+		}
+		return escrows.ConfirmCondition(ctx, userID, id, idx)
+	}))
+	group.Post("/:id/release", action(func(ctx context.Context, _ *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		return escrows.Release(ctx, userID, id)
+	}))
+	group.Post("/:id/refund", action(func(ctx context.Context, _ *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		return escrows.Refund(ctx, userID, id)
+	}))
+	group.Post("/:id/cancel", action(func(ctx context.Context, _ *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		return escrows.Cancel(ctx, userID, id)
+	}))
+	group.Post("/:id/dispute", action(func(ctx context.Context, c *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return nil, txnEntity.ErrInvalidEscrow
+		}
+		return escrows.Dispute(ctx, userID, id, body.Reason)
+	}))
+	group.Post("/:id/resolve", action(func(ctx context.Context, c *fiber.Ctx, userID, id uuid.UUID) (*txnEntity.Escrow, error) {
+		sellerAmount, note, err := parseEscrowResolution(c)
+		if err != nil {
+			return nil, err
+		}
+		return escrows.Resolve(ctx, userID, id, sellerAmount, note)
+	}))
+
+	operator.Get("/escrows", func(c *fiber.Ctx) error {
+		status := txnEntity.EscrowStatus(c.Query("status", string(txnEntity.EscrowDisputed)))
+		list, err := escrows.ListByStatus(context.Background(), status, c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.Escrow{}
+		}
+		return c.JSON(fiber.Map{"escrows": list})
+	})
+
+	operator.Post("/escrows/:id/resolve", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		sellerAmount, note, err := parseEscrowResolution(c)
+		if err != nil {
+			return escrowErrorResponse(c, err)
+		}
+		operatorID, err := extractOperatorID(c)
+		if err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+		}
+		escrow, err := escrows.ResolveAsOperator(context.Background(), operatorID, id, sellerAmount, note)
+		if err != nil {
+			return escrowErrorResponse(c, err)
+		}
+		return c.JSON(escrow)
+	})
+}
+
+// parseEscrowResolution lê o valor destinado ao vendedor e a justificativa da decisão
+func parseEscrowResolution(c *fiber.Ctx) (decimal.Decimal, string, error) {
+	var body struct {
+		SellerAmount string `json:"seller_amount"`
+		Note         string `json:"note"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return decimal.Zero, "", txnEntity.ErrInvalidEscrow
+	}
+	sellerAmount, err := decimal.NewFromString(body.SellerAmount)
+	if err != nil {
+		return decimal.Zero, "", txnEntity.ErrInvalidEscrow
+	}
+	return sellerAmount, body.Note, nil
+}
+
+func escrowErrorResponse(c *fiber.Ctx, err error) error {
+	if body, ok := limitExceededResponse(err); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(body)
+	}
+	if body, ok := creditBlockedResponse(err); ok {
+		return c.Status(fiber.StatusForbidden).JSON(body)
+	}
+	switch {
+	case errors.Is(err, txnSvc.ErrEscrowNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrEscrowNotParty):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrEscrowInvalidState), errors.Is(err, txnEntity.ErrEscrowConditionsPending),
+		errors.Is(err, txnEntity.ErrEscrowDeadlinePassed), errors.Is(err, txnEntity.ErrEscrowConflict):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrInvalidEscrow):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrInsufficientBalance):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
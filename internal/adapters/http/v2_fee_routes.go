@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2FeeRoutes registra a cotação de taxas do usuário e a consulta da tabela (operador)
+func registerV2FeeRoutes(me, operator fiber.Router, fees *txnSvc.FeeService) {
+	me.Post("/fees/quote", func(c *fiber.Ctx) error {
+		var body struct {
+			Type      string `json:"type"`
+			Amount    string `json:"amount"`
+			Chain     string `json:"chain"`
+			ToAddress string `json:"to_address"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		txType := txnEntity.TransactionType(body.Type)
+		switch txType {
+		case txnEntity.TransactionTypeDeposit, txnEntity.TransactionTypeWithdraw:
+		case txnEntity.TransactionTypeTransfer:
+			body.Chain = txnSvc.InternalChain
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "type must be deposit, withdraw or transfer"})
+		}
+		amt, err := decimal.NewFromString(body.Amount)
+		if err != nil || amt.LessThanOrEqual(decimal.Zero) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		quote, err := fees.Quote(context.Background(), txnSvc.FeeQuoteRequest{
+			UserID:    userID,
+			Type:      txType,
+			Chain:     body.Chain,
+			ToAddress: body.ToAddress,
+			Amount:    amt,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, txnSvc.ErrFeeExceedsAmount):
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+			case errors.Is(err, txnSvc.ErrNetworkFeeUnavailable), errors.Is(err, txnSvc.ErrNetworkFeeUnpriced):
+				return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(quote)
+	})
+
+	operator.Get("/fees/schedule", func(c *fiber.Ctx) error {
+		schedule := fees.Schedule()
+		if schedule == nil {
+			schedule = txnEntity.FeeSchedule{}
+		}
+		return c.JSON(fiber.Map{"rules": schedule})
+	})
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2InterestRoutes registra os tipos de conta remunerada (operador), a execução manual da
+// apropriação e a conta remunerada do usuário com o histórico de rendimentos
+func registerV2InterestRoutes(api, operator fiber.Router, sessions *userSvc.SessionService, interest *txnSvc.InterestService) {
+	operator.Get("/interest/types", func(c *fiber.Ctx) error {
+		types, err := interest.ListTypes(context.Background())
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		if types == nil {
+			types = []*txnEntity.InterestAccountType{}
+		}
+		return c.JSON(fiber.Map{"types": types})
+	})
+
+	// Cria ou altera o tipo; schedule traz as taxas anuais (0.1 = 10% a.a.) por data de vigência
+	operator.Put("/interest/types/:code", func(c *fiber.Ctx) error {
+		var body struct {
+			Name     string `json:"name"`
+			DayCount string `json:"day_count"`
+			Schedule []struct {
+				EffectiveFrom string          `json:"effective_from"` // AAAA-MM-DD
+				AnnualRate    decimal.Decimal `json:"annual_rate"`
+			} `json:"schedule"`
+			MinBalance      decimal.Decimal `json:"min_balance"`
+			WithholdingRate decimal.Decimal `json:"withholding_rate"`
+			Active          *bool           `json:"active"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		schedule := make([]txnEntity.RateStep, 0, len(body.Schedule))
+		for _, step := range body.Schedule {
+			from, err := time.Parse("2006-01-02", step.EffectiveFrom)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid effective_from: expected YYYY-MM-DD"})
+			}
+			schedule = append(schedule, txnEntity.RateStep{EffectiveFrom: from, AnnualRate: step.AnnualRate})
+		}
+		t, err := txnEntity.NewInterestAccountType(c.Params("code"), body.Name, txnEntity.DayCount(body.DayCount),
+			schedule, body.MinBalance, body.WithholdingRate, time.Now())
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		if body.Active != nil {
+			t.Active = *body.Active
+		}
+		t, err = interest.DefineType(context.Background(), t)
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		return c.JSON(t)
+	})
+
+	// Executa (ou completa) a apropriação até ?date=AAAA-MM-DD; padrão o dia anterior
+	operator.Post("/interest/accruals", func(c *fiber.Ctx) error {
+		day := interest.Yesterday()
+		if date := c.Query("date"); date != "" {
+			var err error
+			if day, err = interest.ParseDate(date); err != nil {
+				return interestErrorResponse(c, err)
+			}
+		}
+		run, err := interest.AccrueDay(context.Background(), day)
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(run)
+	})
+
+	group := api.Group("/interest", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	group.Get("/types", func(c *fiber.Ctx) error {
+		types, err := interest.ListTypes(context.Background())
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		active := []*txnEntity.InterestAccountType{}
+		for _, t := range types {
+			if t.Active {
+				active = append(active, t)
+			}
+		}
+		return c.JSON(fiber.Map{"types": active})
+	})
+
+	group.Post("/account", func(c *fiber.Ctx) error {
+		var body struct {
+			Type string `json:"type"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Type == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		account, err := interest.Open(context.Background(), userID, body.Type)
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(account)
+	})
+
+	group.Get("/account", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		account, err := interest.Account(context.Background(), userID)
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		return c.JSON(account)
+	})
+
+	// Encerra a conta creditando o rendimento pendente
+	group.Delete("/account", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		account, err := interest.Close(context.Background(), userID)
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		return c.JSON(account)
+	})
+
+	// Rendimentos diários em ?from=AAAA-MM-DD&to=AAAA-MM-DD (padrão: mês corrente) e capitalizações
+	group.Get("/accruals", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		to := interest.Yesterday()
+		if raw := c.Query("to"); raw != "" {
+			if to, err = interest.ParseDate(raw); err != nil {
+				return interestErrorResponse(c, err)
+			}
+		}
+		from := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, to.Location())
+		if raw := c.Query("from"); raw != "" {
+			if from, err = interest.ParseDate(raw); err != nil {
+				return interestErrorResponse(c, err)
+			}
+		}
+		history, err := interest.History(context.Background(), userID, from, to)
+		if err != nil {
+			return interestErrorResponse(c, err)
+		}
+		return c.JSON(history)
+	})
+}
+
+func interestErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrInterestTypeNotFound), errors.Is(err, txnSvc.ErrInterestAccountNotFound),
+		errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrInterestAccountExists), errors.Is(err, txnEntity.ErrInterestAccountClosed),
+		errors.Is(err, txnEntity.ErrInterestTypeInactive):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrInvalidInterestDate), errors.Is(err, txnEntity.ErrUnknownDayCount),
+		errors.Is(err, txnEntity.ErrInvalidInterestType), errors.Is(err, txnEntity.ErrInvalidRateSchedule),
+		errors.Is(err, txnEntity.ErrInvalidWithholdingRate):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
@@ -2,9 +2,11 @@ package http
 
 import (
 	"context"
+	"financial-system-pro/internal/infrastructure/config/container"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	txnService "financial-system-pro/internal/contexts/transaction/application/service"
 	"financial-system-pro/internal/contexts/transaction/domain/entity"
@@ -20,6 +22,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +42,10 @@ func (f *failingWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID,
 	return errors.New("update not supported")
 }
 
+func (f *failingWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	return errors.New("update not supported")
+}
+
 // Ensure interface compliance
 var _ userRepo.WalletRepository = (*failingWalletRepo)(nil)
 
@@ -85,6 +92,25 @@ func (r *simpleTxRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status en
 	return nil
 }
 
+func (r *simpleTxRepo) SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, tx := range r.txs {
+		if tx.UserID != userID || tx.Status == entity.TransactionStatusFailed || tx.CreatedAt.Before(since) {
+			continue
+		}
+		for _, t := range types {
+			if tx.Type == t {
+				total = total.Add(tx.Amount)
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *simpleTxRepo) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error) {
+	return nil, nil
+}
+
 var _ txnRepo.TransactionRepository = (*simpleTxRepo)(nil)
 
 // helperCreateAuthedApp sets up app with user + wallet + JWT token
@@ -105,7 +131,7 @@ func helperCreateAuthedApp(t *testing.T, walletBalance float64) (*fiber.App, str
 	txnSvc := txnService.NewTransactionService(tr, ur, wr, eventBus, breakerManager, logger)
 
 	app := fiber.New()
-	registerV2DDDRoutes(app, userSvc, txnSvc, logger, breakerManager)
+	registerV2DDDRoutes(app, userSvc, txnSvc, container.RouteServices{}, logger, breakerManager)
 
 	uid := uuid.New()
 	_ = ur.Create(context.Background(), &userEntity.User{ID: uid, Email: "jwt@test.com", Password: "hashed"})
@@ -217,7 +243,7 @@ func TestV2CircuitBreaker_OpenAfterFailures(t *testing.T) {
 	userSvc := userService.NewUserService(ur, failingWR, eventBus, logger)
 	txnSvc := txnService.NewTransactionService(tr, ur, failingWR, eventBus, breakerManager, logger)
 	app := fiber.New()
-	registerV2DDDRoutes(app, userSvc, txnSvc, logger, breakerManager)
+	registerV2DDDRoutes(app, userSvc, txnSvc, container.RouteServices{}, logger, breakerManager)
 
 	uid := uuid.New()
 	_ = ur.Create(context.Background(), &userEntity.User{ID: uid, Email: "breaker@test.com", Password: "hashed"})
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"errors"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2KYCRoutes registra submissão de documentos (usuário) e revisão (operador)
+func registerV2KYCRoutes(me, operator fiber.Router, kyc *userSvc.KYCService) {
+	me.Get("/kyc", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		level, submissions, err := kyc.Status(context.Background(), userID)
+		if err != nil {
+			if errors.Is(err, userSvc.ErrUserNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		out := make([]fiber.Map, 0, len(submissions))
+		for _, s := range submissions {
+			out = append(out, kycSubmissionJSON(s))
+		}
+		return c.JSON(fiber.Map{"level": level.String(), "submissions": out})
+	})
+
+	me.Post("/kyc/submissions", func(c *fiber.Ctx) error {
+		var body struct {
+			Level          string `json:"level"`
+			DocumentType   string `json:"document_type"`
+			DocumentNumber string `json:"document_number"`
+			DocumentRef    string `json:"document_ref"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		level, err := userEntity.ParseKYCLevel(body.Level)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		submission, err := kyc.Submit(context.Background(), userID, userSvc.KYCSubmissionInput{
+			RequestedLevel: level,
+			DocumentType:   userEntity.KYCDocumentType(body.DocumentType),
+			DocumentNumber: body.DocumentNumber,
+			DocumentRef:    body.DocumentRef,
+		})
+		if err != nil {
+			if errors.Is(err, userEntity.ErrInvalidKYCLevel) || errors.Is(err, userEntity.ErrInvalidKYCDocument) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusCreated).JSON(kycSubmissionJSON(submission))
+	})
+
+	// Revisão por operadores
+	operator.Get("/kyc/submissions", func(c *fiber.Ctx) error {
+		list, err := kyc.ListPending(context.Background(), c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, s := range list {
+			out = append(out, kycSubmissionJSON(s))
+		}
+		return c.JSON(fiber.Map{"submissions": out})
+	})
+
+	operator.Post("/kyc/submissions/:id/approve", func(c *fiber.Ctx) error {
+		return reviewKYC(c, func(id, reviewer uuid.UUID) (*userEntity.KYCSubmission, error) {
+			return kyc.Approve(context.Background(), id, reviewer)
+		})
+	})
+
+	operator.Post("/kyc/submissions/:id/reject", func(c *fiber.Ctx) error {
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		return reviewKYC(c, func(id, reviewer uuid.UUID) (*userEntity.KYCSubmission, error) {
+			return kyc.Reject(context.Background(), id, reviewer, body.Reason)
+		})
+	})
+}
+
+func reviewKYC(c *fiber.Ctx, review func(id, reviewer uuid.UUID) (*userEntity.KYCSubmission, error)) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+	}
+	reviewer, err := extractOperatorID(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+	}
+	submission, err := review(id, reviewer)
+	if err != nil {
+		switch {
+		case errors.Is(err, userSvc.ErrKYCNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, userEntity.ErrKYCAlreadyReviewed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, userEntity.ErrKYCReasonRequired):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(kycSubmissionJSON(submission))
+}
+
+func kycSubmissionJSON(s *userEntity.KYCSubmission) fiber.Map {
+	return fiber.Map{
+		"id":               s.ID,
+		"user_id":          s.UserID,
+		"requested_level":  s.RequestedLevel.String(),
+		"document_type":    s.DocumentType,
+		"status":           s.Status,
+		"rejection_reason": s.RejectionReason,
+		"submitted_at":     s.SubmittedAt,
+		"reviewed_at":      s.ReviewedAt,
+	}
+}
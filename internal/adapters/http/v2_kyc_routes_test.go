@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	txnService "financial-system-pro/internal/contexts/transaction/application/service"
+	userService "financial-system-pro/internal/contexts/user/application/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/infrastructure/config/container"
+	"financial-system-pro/internal/shared/breaker"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/utils"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type inMemoryKYCRepo struct {
+	subs map[uuid.UUID]*userEntity.KYCSubmission
+}
+
+func (r *inMemoryKYCRepo) Create(ctx context.Context, s *userEntity.KYCSubmission) error {
+	r.subs[s.ID] = s
+	return nil
+}
+func (r *inMemoryKYCRepo) FindByID(ctx context.Context, id uuid.UUID) (*userEntity.KYCSubmission, error) {
+	return r.subs[id], nil
+}
+func (r *inMemoryKYCRepo) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*userEntity.KYCSubmission, error) {
+	var out []*userEntity.KYCSubmission
+	for _, s := range r.subs {
+		if s.UserID == userID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (r *inMemoryKYCRepo) FindByStatus(ctx context.Context, status userEntity.KYCStatus, limit int) ([]*userEntity.KYCSubmission, error) {
+	var out []*userEntity.KYCSubmission
+	for _, s := range r.subs {
+		if s.Status == status {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (r *inMemoryKYCRepo) Update(ctx context.Context, s *userEntity.KYCSubmission) error {
+	r.subs[s.ID] = s
+	return nil
+}
+
+func TestV2Routes_KYCReviewRaisesWithdrawLimit(t *testing.T) {
+	t.Setenv("SECRET_KEY", "test-secret")
+	t.Setenv("EXPIRATION_TIME", "3600")
+	operatorID := uuid.New()
+	t.Setenv("OPERATOR_USER_IDS", operatorID.String())
+
+	logger := zap.NewNop()
+	eventBus := events.NewInMemoryBus(logger)
+	breakerManager := breaker.NewBreakerManager(logger)
+	ur := newInMemoryUserRepo()
+	wr := newInMemoryWalletRepo()
+
+	userSvc := userService.NewUserService(ur, wr, eventBus, logger).
+		WithKYC(userService.NewKYCService(ur, &inMemoryKYCRepo{subs: map[uuid.UUID]*userEntity.KYCSubmission{}}, eventBus, logger))
+	txnSvc := txnService.NewTransactionService(newInMemoryTxRepo(), ur, wr, eventBus, breakerManager, logger).
+		WithLimits(txnService.LimitPolicy{
+			userEntity.KYCLevelNone:  {Daily: decimal.NewFromInt(10), Monthly: decimal.NewFromInt(10)},
+			userEntity.KYCLevelBasic: {Daily: decimal.NewFromInt(100), Monthly: decimal.NewFromInt(100)},
+		})
+
+	app := fiber.New()
+	registerV2DDDRoutes(app, userSvc, txnSvc, container.RouteServices{}, logger, breakerManager)
+
+	user, err := userSvc.CreateUser(context.Background(), "kyc@example.com", "secret")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: user.ID, Address: "W1", Balance: 500})
+	userToken, _ := utils.CreateJWTToken(map[string]interface{}{"ID": user.ID.String()})
+	operatorToken, _ := utils.CreateJWTToken(map[string]interface{}{"ID": operatorID.String()})
+
+	do := func(method, path, token, body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		var out map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp.StatusCode, out
+	}
+
+	// Sem KYC: saque acima do limite é recusado com detalhes
+	code, out := do("POST", "/v2/transactions/withdraw", userToken, `{"amount":"50"}`)
+	if code != fiber.StatusUnprocessableEntity || out["period"] != "daily" || out["kyc_level"] != "none" {
+		t.Fatalf("esperado 422 de limite, obtido %d %v", code, out)
+	}
+
+	code, out = do("POST", "/v2/me/kyc/submissions", userToken, `{"level":"basic","document_type":"passport","document_number":"X1"}`)
+	if code != fiber.StatusCreated {
+		t.Fatalf("esperado 201 na submissão, obtido %d %v", code, out)
+	}
+	submissionID := out["id"].(string)
+
+	// Usuário comum não acessa a API de operador
+	if code, _ := do("POST", "/v2/operator/kyc/submissions/"+submissionID+"/approve", userToken, ``); code != fiber.StatusForbidden {
+		t.Fatalf("esperado 403 para não operador, obtido %d", code)
+	}
+	if code, _ := do("POST", "/v2/operator/kyc/submissions/"+submissionID+"/reject", operatorToken, `{"reason":""}`); code != fiber.StatusBadRequest {
+		t.Fatalf("esperado 400 sem motivo, obtido %d", code)
+	}
+	if code, _ := do("POST", "/v2/operator/kyc/submissions/"+submissionID+"/approve", operatorToken, ``); code != fiber.StatusOK {
+		t.Fatalf("esperado 200 na aprovação, obtido %d", code)
+	}
+	if code, _ := do("POST", "/v2/operator/kyc/submissions/"+submissionID+"/approve", operatorToken, ``); code != fiber.StatusConflict {
+		t.Fatalf("esperado 409 em revisão repetida, obtido %d", code)
+	}
+
+	code, out = do("GET", "/v2/me/kyc", userToken, ``)
+	if code != fiber.StatusOK || out["level"] != "basic" {
+		t.Fatalf("nível esperado basic, obtido %d %v", code, out)
+	}
+	if code, out := do("POST", "/v2/transactions/withdraw", userToken, `{"amount":"50"}`); code != fiber.StatusAccepted {
+		t.Fatalf("saque deveria passar após KYC, obtido %d %v", code, out)
+	}
+}
@@ -0,0 +1,304 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/tenant"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// HeaderOrganizationID seleciona a organização em nome da qual a requisição age
+const HeaderOrganizationID = "X-Organization-ID"
+
+// TenantScope resolve o tenant da requisição a partir do header X-Organization-ID
+// (ou do parâmetro :orgId, quando presente). Sem organização o tenant é o próprio usuário.
+// Deve ser usado após VerifyJWTMiddleware; o escopo fica em c.Locals("access_scope").
+func TenantScope(orgs *userSvc.OrganizationService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		orgID := uuid.Nil
+		raw := c.Params("orgId", c.Get(HeaderOrganizationID))
+		if raw != "" {
+			if orgID, err = uuid.Parse(raw); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid organization id"})
+			}
+		}
+
+		scope, err := orgs.ResolveScope(c.UserContext(), userID, orgID)
+		if err != nil {
+			if errors.Is(err, userSvc.ErrNotOrgMember) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		c.Locals("access_scope", scope)
+		c.SetUserContext(tenant.WithID(c.UserContext(), scope.TenantID))
+		return c.Next()
+	}
+}
+
+// extractAccessScope retorna o escopo resolvido por TenantScope
+func extractAccessScope(c *fiber.Ctx) (userSvc.AccessScope, bool) {
+	scope, ok := c.Locals("access_scope").(userSvc.AccessScope)
+	return scope, ok
+}
+
+// registerV2OrganizationRoutes registra organizações, membros e contas nomeadas
+func registerV2OrganizationRoutes(api fiber.Router, sessions *userSvc.SessionService, orgs *userSvc.OrganizationService) {
+	organizations := api.Group("/organizations", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	organizations.Post("/", func(c *fiber.Ctx) error {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		org, err := orgs.CreateOrganization(context.Background(), userID, body.Name)
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(organizationJSON(org))
+	})
+
+	organizations.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := orgs.ListOrganizations(context.Background(), userID)
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, org := range list {
+			out = append(out, organizationJSON(org))
+		}
+		return c.JSON(fiber.Map{"organizations": out})
+	})
+
+	members := organizations.Group("/:orgId/members", TenantScope(orgs))
+
+	members.Get("/", func(c *fiber.Ctx) error {
+		scope, _ := extractAccessScope(c)
+		list, err := orgs.ListMembers(c.UserContext(), scope)
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, m := range list {
+			out = append(out, membershipJSON(m))
+		}
+		return c.JSON(fiber.Map{"members": out})
+	})
+
+	members.Put("/", func(c *fiber.Ctx) error {
+		var body struct {
+			Email string `json:"email"`
+			Role  string `json:"role"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		scope, _ := extractAccessScope(c)
+		m, err := orgs.SetMember(c.UserContext(), scope, body.Email, userEntity.OrgRole(body.Role))
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		return c.JSON(membershipJSON(m))
+	})
+
+	members.Delete("/:userId", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("userId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user id"})
+		}
+		scope, _ := extractAccessScope(c)
+		if err := orgs.RemoveMember(c.UserContext(), scope, userID); err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// Contas nomeadas do tenant (pessoal ou organização via X-Organization-ID)
+	accounts := api.Group("/accounts", VerifyJWTMiddleware(), RequireActiveSession(sessions), TenantScope(orgs))
+
+	accounts.Get("/", func(c *fiber.Ctx) error {
+		scope, _ := extractAccessScope(c)
+		list, err := orgs.ListAccounts(c.UserContext(), scope)
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, a := range list {
+			out = append(out, accountJSON(a))
+		}
+		return c.JSON(fiber.Map{"tenant_id": scope.TenantID, "accounts": out})
+	})
+
+	accounts.Post("/", func(c *fiber.Ctx) error {
+		var body struct {
+			Name     string `json:"name"`
+			Currency string `json:"currency"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		scope, _ := extractAccessScope(c)
+		account, err := orgs.CreateAccount(c.UserContext(), scope, body.Name, body.Currency)
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(accountJSON(account))
+	})
+
+	accounts.Post("/transfers", func(c *fiber.Ctx) error {
+		var body struct {
+			FromAccountID string `json:"from_account_id"`
+			ToAccountID   string `json:"to_account_id"`
+			Amount        string `json:"amount"`
+			Reference     string `json:"reference"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		fromID, err1 := uuid.Parse(body.FromAccountID)
+		toID, err2 := uuid.Parse(body.ToAccountID)
+		amount, err3 := decimal.NewFromString(body.Amount)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid account id or amount"})
+		}
+		scope, _ := extractAccessScope(c)
+		transfer, err := orgs.Transfer(c.UserContext(), scope, fromID, toID, amount, body.Reference)
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(accountTransferJSON(transfer))
+	})
+
+	// Aporte da wallet pessoal do ator na conta
+	accounts.Post("/:id/fund", func(c *fiber.Ctx) error {
+		return accountWalletMovement(c, orgs.FundAccount)
+	})
+
+	// Resgate da conta para a wallet pessoal do ator
+	accounts.Post("/:id/withdraw", func(c *fiber.Ctx) error {
+		return accountWalletMovement(c, orgs.WithdrawAccount)
+	})
+
+	accounts.Get("/:id/transfers", func(c *fiber.Ctx) error {
+		accountID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid account id"})
+		}
+		scope, _ := extractAccessScope(c)
+		list, err := orgs.ListTransfers(c.UserContext(), scope, accountID, c.QueryInt("limit", 50))
+		if err != nil {
+			return organizationErrorResponse(c, err)
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, t := range list {
+			out = append(out, accountTransferJSON(t))
+		}
+		return c.JSON(fiber.Map{"transfers": out})
+	})
+}
+
+// accountWalletMovement lê o valor e executa o aporte ou resgate entre a wallet e a conta :id
+func accountWalletMovement(c *fiber.Ctx, move func(context.Context, userSvc.AccessScope, uuid.UUID, decimal.Decimal) (*userEntity.Account, error)) error {
+	var body struct {
+		Amount string `json:"amount"`
+	}
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+	}
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid account id"})
+	}
+	amount, err := decimal.NewFromString(body.Amount)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+	}
+	scope, _ := extractAccessScope(c)
+	account, err := move(c.UserContext(), scope, accountID, amount)
+	if err != nil {
+		return organizationErrorResponse(c, err)
+	}
+	return c.JSON(accountJSON(account))
+}
+
+func organizationErrorResponse(c *fiber.Ctx, err error) error {
+	if body, ok := limitExceededResponse(err); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(body)
+	}
+	if body, ok := riskBlockedResponse(err); ok {
+		return c.Status(fiber.StatusForbidden).JSON(body)
+	}
+	if body, ok := creditBlockedResponse(err); ok {
+		return c.Status(fiber.StatusForbidden).JSON(body)
+	}
+	switch {
+	case errors.Is(err, userSvc.ErrOrgPermissionDenied), errors.Is(err, userSvc.ErrNotOrgMember):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userSvc.ErrAccountNotFound), errors.Is(err, userSvc.ErrUserNotFound), errors.Is(err, userSvc.ErrWalletNotFound),
+		errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userEntity.ErrInsufficientFunds), errors.Is(err, userSvc.ErrInsufficientBalance),
+		errors.Is(err, txnSvc.ErrInsufficientBalance):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userSvc.ErrOwnerRoleReserved):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userSvc.ErrOrganizationScope),
+		errors.Is(err, userEntity.ErrInvalidOrgName),
+		errors.Is(err, userEntity.ErrInvalidOrgRole),
+		errors.Is(err, userEntity.ErrInvalidAccountName),
+		errors.Is(err, userEntity.ErrInvalidAmount),
+		errors.Is(err, userEntity.ErrSameAccount),
+		errors.Is(err, userEntity.ErrCurrencyMismatch),
+		errors.Is(err, userEntity.ErrUnsupportedCurrency):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, userSvc.ErrAccountLedgerUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
+
+func organizationJSON(o *userEntity.Organization) fiber.Map {
+	return fiber.Map{"id": o.ID, "name": o.Name, "owner_id": o.OwnerID, "created_at": o.CreatedAt}
+}
+
+func membershipJSON(m *userEntity.Membership) fiber.Map {
+	return fiber.Map{"organization_id": m.OrganizationID, "user_id": m.UserID, "role": m.Role, "created_at": m.CreatedAt}
+}
+
+func accountJSON(a *userEntity.Account) fiber.Map {
+	return fiber.Map{"id": a.ID, "tenant_id": a.TenantID, "name": a.Name, "currency": a.Currency, "balance": a.Balance.String(), "created_at": a.CreatedAt}
+}
+
+func accountTransferJSON(t *userEntity.AccountTransfer) fiber.Map {
+	return fiber.Map{
+		"id":              t.ID,
+		"from_account_id": t.FromAccountID,
+		"to_account_id":   t.ToAccountID,
+		"amount":          t.Amount.String(),
+		"currency":        t.Currency,
+		"reference":       t.Reference,
+		"created_by":      t.CreatedBy,
+		"created_at":      t.CreatedAt,
+	}
+}
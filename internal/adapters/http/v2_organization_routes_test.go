@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	txnService "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userService "financial-system-pro/internal/contexts/user/application/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/infrastructure/config/container"
+	"financial-system-pro/internal/shared/breaker"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/tenant"
+	"financial-system-pro/internal/shared/utils"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type inMemoryOrgRepo struct {
+	orgs    map[uuid.UUID]*userEntity.Organization
+	members map[uuid.UUID]map[uuid.UUID]*userEntity.Membership
+}
+
+func newInMemoryOrgRepo() *inMemoryOrgRepo {
+	return &inMemoryOrgRepo{orgs: map[uuid.UUID]*userEntity.Organization{}, members: map[uuid.UUID]map[uuid.UUID]*userEntity.Membership{}}
+}
+func (r *inMemoryOrgRepo) Create(ctx context.Context, o *userEntity.Organization, owner *userEntity.Membership) error {
+	r.orgs[o.ID] = o
+	r.members[o.ID] = map[uuid.UUID]*userEntity.Membership{owner.UserID: owner}
+	return nil
+}
+func (r *inMemoryOrgRepo) FindByID(ctx context.Context, id uuid.UUID) (*userEntity.Organization, error) {
+	return r.orgs[id], nil
+}
+func (r *inMemoryOrgRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*userEntity.Organization, error) {
+	var out []*userEntity.Organization
+	for id, ms := range r.members {
+		if ms[userID] != nil {
+			out = append(out, r.orgs[id])
+		}
+	}
+	return out, nil
+}
+func (r *inMemoryOrgRepo) FindMembership(ctx context.Context, orgID, userID uuid.UUID) (*userEntity.Membership, error) {
+	return r.members[orgID][userID], nil
+}
+func (r *inMemoryOrgRepo) ListMembers(ctx context.Context) ([]*userEntity.Membership, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []*userEntity.Membership
+	for _, m := range r.members[tenantID] {
+		out = append(out, m)
+	}
+	return out, nil
+}
+func (r *inMemoryOrgRepo) SaveMembership(ctx context.Context, m *userEntity.Membership) error {
+	r.members[m.OrganizationID][m.UserID] = m
+	return nil
+}
+func (r *inMemoryOrgRepo) DeleteMembership(ctx context.Context, userID uuid.UUID) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	delete(r.members[tenantID], userID)
+	return nil
+}
+
+type inMemoryAccountRepo struct {
+	accounts  map[uuid.UUID]*userEntity.Account
+	transfers []*userEntity.AccountTransfer
+}
+
+func (r *inMemoryAccountRepo) scoped(ctx context.Context, id uuid.UUID) (*userEntity.Account, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if a := r.accounts[id]; a != nil && a.TenantID == tenantID {
+		return a, nil
+	}
+	return nil, nil
+}
+func (r *inMemoryAccountRepo) Create(ctx context.Context, a *userEntity.Account) error {
+	r.accounts[a.ID] = a
+	return nil
+}
+func (r *inMemoryAccountRepo) FindByID(ctx context.Context, id uuid.UUID) (*userEntity.Account, error) {
+	a, err := r.scoped(ctx, id)
+	if a == nil || err != nil {
+		return nil, err
+	}
+	cp := *a
+	return &cp, nil
+}
+func (r *inMemoryAccountRepo) List(ctx context.Context) ([]*userEntity.Account, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []*userEntity.Account
+	for _, a := range r.accounts {
+		if a.TenantID == tenantID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (r *inMemoryAccountRepo) Credit(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
+	a, err := r.scoped(ctx, id)
+	if err != nil {
+		return err
+	}
+	a.Balance = a.Balance.Add(amount)
+	return nil
+}
+func (r *inMemoryAccountRepo) Debit(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
+	a, err := r.scoped(ctx, id)
+	if err != nil {
+		return err
+	}
+	if a.Balance.LessThan(amount) {
+		return userEntity.ErrInsufficientFunds
+	}
+	a.Balance = a.Balance.Sub(amount)
+	return nil
+}
+func (r *inMemoryAccountRepo) ApplyTransfer(ctx context.Context, from, to *userEntity.Account, t *userEntity.AccountTransfer) error {
+	r.accounts[from.ID].Balance = from.Balance
+	r.accounts[to.ID].Balance = to.Balance
+	r.transfers = append(r.transfers, t)
+	return nil
+}
+func (r *inMemoryAccountRepo) ListTransfers(ctx context.Context, accountID uuid.UUID, limit int) ([]*userEntity.AccountTransfer, error) {
+	var out []*userEntity.AccountTransfer
+	for _, t := range r.transfers {
+		if t.FromAccountID == accountID || t.ToAccountID == accountID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func TestV2Routes_OrganizationAccountsAreTenantScoped(t *testing.T) {
+	t.Setenv("SECRET_KEY", "test-secret")
+	t.Setenv("EXPIRATION_TIME", "3600")
+
+	logger := zap.NewNop()
+	eventBus := events.NewInMemoryBus(logger)
+	breakerManager := breaker.NewBreakerManager(logger)
+	ur := newInMemoryUserRepo()
+	wr := newInMemoryWalletRepo()
+	orgs := userService.NewOrganizationService(newInMemoryOrgRepo(), &inMemoryAccountRepo{accounts: map[uuid.UUID]*userEntity.Account{}}, ur, eventBus, logger)
+
+	userSvc := userService.NewUserService(ur, wr, eventBus, logger).WithOrganizations(orgs)
+	txRepo := newInMemoryTxRepo()
+	txnSvc := txnService.NewTransactionService(txRepo, ur, wr, eventBus, breakerManager, logger)
+	orgs.WithLedger(txnSvc)
+
+	app := fiber.New()
+	registerV2DDDRoutes(app, userSvc, txnSvc, container.RouteServices{}, logger, breakerManager)
+
+	owner, _ := userSvc.CreateUser(context.Background(), "owner@example.com", "secret")
+	finance, _ := userSvc.CreateUser(context.Background(), "finance@example.com", "secret")
+	outsider, _ := userSvc.CreateUser(context.Background(), "outsider@example.com", "secret")
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: owner.ID, Address: "W1", Balance: 1000})
+	ownerToken, _ := utils.CreateJWTToken(map[string]interface{}{"ID": owner.ID.String()})
+	financeToken, _ := utils.CreateJWTToken(map[string]interface{}{"ID": finance.ID.String()})
+	outsiderToken, _ := utils.CreateJWTToken(map[string]interface{}{"ID": outsider.ID.String()})
+
+	do := func(method, path, token, orgID, body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if orgID != "" {
+			req.Header.Set(HeaderOrganizationID, orgID)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		var out map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp.StatusCode, out
+	}
+
+	code, out := do("POST", "/v2/organizations", ownerToken, "", `{"name":"Acme"}`)
+	if code != fiber.StatusCreated {
+		t.Fatalf("esperado 201 ao criar organização, obtido %d %v", code, out)
+	}
+	orgID := out["id"].(string)
+
+	if code, out := do("PUT", "/v2/organizations/"+orgID+"/members", ownerToken, "", `{"email":"finance@example.com","role":"finance"}`); code != fiber.StatusOK {
+		t.Fatalf("esperado 200 ao adicionar membro, obtido %d %v", code, out)
+	}
+	if code, _ := do("GET", "/v2/organizations/"+orgID+"/members", outsiderToken, "", ``); code != fiber.StatusForbidden {
+		t.Fatalf("não membro deveria receber 403, obtido %d", code)
+	}
+
+	_, ops := do("POST", "/v2/accounts", ownerToken, orgID, `{"name":"operations"}`)
+	_, payroll := do("POST", "/v2/accounts", ownerToken, orgID, `{"name":"payroll"}`)
+	opsID, payrollID := ops["id"].(string), payroll["id"].(string)
+
+	// Papel finance transfere mas não cria contas
+	if code, _ := do("POST", "/v2/accounts", financeToken, orgID, `{"name":"treasury"}`); code != fiber.StatusForbidden {
+		t.Fatalf("finance não deveria criar contas, obtido %d", code)
+	}
+	if code, out := do("POST", "/v2/accounts/"+opsID+"/fund", ownerToken, orgID, `{"amount":"300"}`); code != fiber.StatusOK || out["balance"] != "300" {
+		t.Fatalf("aporte falhou: %d %v", code, out)
+	}
+	if w, _ := wr.FindByUserID(context.Background(), owner.ID); w.Balance != 700 {
+		t.Fatalf("aporte deveria debitar a wallet, saldo %v", w.Balance)
+	}
+	if code, _ := do("POST", "/v2/accounts/"+opsID+"/fund", ownerToken, orgID, `{"amount":"5000"}`); code != fiber.StatusUnprocessableEntity {
+		t.Fatalf("aporte acima do saldo da wallet deveria falhar, obtido %d", code)
+	}
+	if code, _ := do("POST", "/v2/accounts", ownerToken, orgID, `{"name":"usd","currency":"USD"}`); code != fiber.StatusBadRequest {
+		t.Fatalf("conta em outra moeda deveria ser recusada, obtido %d", code)
+	}
+	transfer := `{"from_account_id":"` + opsID + `","to_account_id":"` + payrollID + `","amount":"120","reference":"folha"}`
+	if code, out := do("POST", "/v2/accounts/transfers", financeToken, orgID, transfer); code != fiber.StatusCreated {
+		t.Fatalf("transferência falhou: %d %v", code, out)
+	}
+
+	if code, out := do("POST", "/v2/accounts/"+opsID+"/withdraw", financeToken, orgID, `{"amount":"200"}`); code != fiber.StatusUnprocessableEntity {
+		t.Fatalf("resgate acima do saldo da conta deveria falhar: %d %v", code, out)
+	}
+	if code, out := do("POST", "/v2/accounts/"+opsID+"/withdraw", ownerToken, orgID, `{"amount":"80"}`); code != fiber.StatusOK || out["balance"] != "100" {
+		t.Fatalf("resgate falhou: %d %v", code, out)
+	}
+	if w, _ := wr.FindByUserID(context.Background(), owner.ID); w.Balance != 780 {
+		t.Fatalf("resgate deveria creditar a wallet, saldo %v", w.Balance)
+	}
+	history, _ := txRepo.FindByUserID(context.Background(), owner.ID)
+	var funded, withdrawn int
+	for _, tx := range history {
+		switch tx.Type {
+		case txnEntity.TransactionTypeAccountFunding:
+			funded++
+		case txnEntity.TransactionTypeAccountWithdrawal:
+			withdrawn++
+		}
+	}
+	if funded != 1 || withdrawn != 1 {
+		t.Fatalf("aporte e resgate deveriam ser registrados como transações: %d/%d", funded, withdrawn)
+	}
+
+	// Sem o header, as contas da organização não são visíveis no escopo pessoal do dono
+	if code, out := do("GET", "/v2/accounts", ownerToken, "", ``); code != fiber.StatusOK || len(out["accounts"].([]interface{})) != 0 {
+		t.Fatalf("escopo pessoal não deveria ver contas da organização: %d %v", code, out)
+	}
+	if code, _ := do("GET", "/v2/accounts/"+payrollID+"/transfers", ownerToken, "", ``); code != fiber.StatusNotFound {
+		t.Fatalf("conta de outro tenant deveria retornar 404, obtido %d", code)
+	}
+	if code, _ := do("GET", "/v2/accounts", outsiderToken, orgID, ``); code != fiber.StatusForbidden {
+		t.Fatalf("não membro deveria receber 403, obtido %d", code)
+	}
+
+	code, out = do("GET", "/v2/accounts/"+payrollID+"/transfers", financeToken, orgID, ``)
+	if code != fiber.StatusOK || len(out["transfers"].([]interface{})) != 1 {
+		t.Fatalf("histórico inesperado: %d %v", code, out)
+	}
+}
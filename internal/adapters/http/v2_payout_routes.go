@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2PayoutRoutes registra os pagamentos em lote por arquivo CSV, JSON ou ISO 20022 pain.001
+func registerV2PayoutRoutes(api fiber.Router, sessions *userSvc.SessionService, payouts *txnSvc.PayoutService) {
+	group := api.Group("/payouts", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	// Aceita o arquivo no campo "file" (multipart) ou no corpo da requisição; o formato vem de
+	// ?format=, da extensão do arquivo ou do Content-Type
+	group.Post("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		format := strings.ToLower(c.Query("format"))
+		var file io.Reader = bytes.NewReader(c.Body())
+		if header, err := c.FormFile("file"); err == nil {
+			f, err := header.Open()
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid file"})
+			}
+			defer f.Close()
+			file = f
+			if format == "" {
+				format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+			}
+		}
+		if format == "" {
+			switch contentType := string(c.Request().Header.ContentType()); {
+			case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+				format = txnEntity.PayoutFormatJSON
+			case strings.HasPrefix(contentType, "text/csv"):
+				format = txnEntity.PayoutFormatCSV
+			case strings.HasPrefix(contentType, fiber.MIMEApplicationXML), strings.HasPrefix(contentType, fiber.MIMETextXML):
+				format = txnEntity.PayoutFormatPain001
+			}
+		}
+		if format == "xml" || format == "pain001" {
+			format = txnEntity.PayoutFormatPain001
+		}
+
+		preview, err := payouts.Upload(context.Background(), userID, format, file)
+		if err != nil {
+			return payoutErrorResponse(c, err)
+		}
+		status := fiber.StatusCreated
+		if preview.Batch.Status == txnEntity.PayoutBatchRejected {
+			status = fiber.StatusUnprocessableEntity
+		}
+		return c.Status(status).JSON(preview)
+	})
+
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := payouts.List(context.Background(), userID, c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.PayoutBatch{}
+		}
+		return c.JSON(fiber.Map{"batches": list})
+	})
+
+	// withBatch resolve o usuário autenticado e o lote :id
+	withBatch := func(handle func(c *fiber.Ctx, userID, id uuid.UUID) error) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+			}
+			userID, err := extractUserIDFromJWT(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			return handle(c, userID, id)
+		}
+	}
+
+	group.Get("/:id", withBatch(func(c *fiber.Ctx, userID, id uuid.UUID) error {
+		batch, items, err := payouts.Get(context.Background(), userID, id)
+		if err != nil {
+			return payoutErrorResponse(c, err)
+		}
+		if items == nil {
+			items = []*txnEntity.PayoutItem{}
+		}
+		return c.JSON(fiber.Map{"batch": batch, "items": items})
+	}))
+
+	group.Post("/:id/confirm", withBatch(func(c *fiber.Ctx, userID, id uuid.UUID) error {
+		batch, err := payouts.Confirm(context.Background(), userID, id)
+		if err != nil {
+			return payoutErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(batch)
+	}))
+
+	group.Post("/:id/cancel", withBatch(func(c *fiber.Ctx, userID, id uuid.UUID) error {
+		batch, err := payouts.Cancel(context.Background(), userID, id)
+		if err != nil {
+			return payoutErrorResponse(c, err)
+		}
+		return c.JSON(batch)
+	}))
+
+	group.Get("/:id/report", withBatch(func(c *fiber.Ctx, userID, id uuid.UUID) error {
+		report, err := payouts.Report(context.Background(), userID, id)
+		if err != nil {
+			return payoutErrorResponse(c, err)
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="payout-`+id.String()+`.csv"`)
+		return c.Send(report)
+	}))
+}
+
+func payoutErrorResponse(c *fiber.Ctx, err error) error {
+	if body, ok := creditBlockedResponse(err); ok {
+		return c.Status(fiber.StatusForbidden).JSON(body)
+	}
+	switch {
+	case errors.Is(err, txnSvc.ErrPayoutBatchNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrInvalidPayoutFile):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrPayoutBatchState):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrPayoutBatchInvalid), errors.Is(err, txnSvc.ErrInsufficientBalance):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// pixCreditSimulator PSP que gera créditos sob demanda (simulador local)
+type pixCreditSimulator interface {
+	SimulateCredit(txid string, amount decimal.Decimal, payerName string) txnEntity.PixCredit
+}
+
+// registerV2PixRoutes registra depósitos por BR Code, pagamentos de "copia e cola" e o webhook do PSP
+func registerV2PixRoutes(api, operator fiber.Router, sessions *userSvc.SessionService, pix *txnSvc.PixService) {
+	// Webhook do PSP: autenticado pela assinatura do corpo, não por JWT
+	api.Post("/pix/webhook", func(c *fiber.Ctx) error {
+		received, err := pix.HandleNotification(context.Background(), c.Body(), c.Get("X-Pix-Signature"))
+		if err != nil {
+			if errors.Is(err, txnSvc.ErrPixNotificationRejected) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(fiber.Map{"received": received})
+	})
+
+	group := api.Group("/pix", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	group.Post("/charges", func(c *fiber.Ctx) error {
+		var body struct {
+			Dynamic     bool   `json:"dynamic"`
+			Amount      string `json:"amount"`
+			Description string `json:"description"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amount := decimal.Zero
+		if body.Amount != "" {
+			amt, err := decimal.NewFromString(body.Amount)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+			}
+			amount = amt
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		charge, err := pix.CreateCharge(context.Background(), userID, txnSvc.PixChargeRequest{
+			Dynamic:     body.Dynamic,
+			Amount:      amount,
+			Description: body.Description,
+		})
+		if err != nil {
+			return pixErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(charge)
+	})
+
+	group.Get("/charges", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := pix.ListCharges(context.Background(), userID, c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.PixCharge{}
+		}
+		return c.JSON(fiber.Map{"charges": list})
+	})
+
+	group.Get("/charges/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		charge, err := pix.GetCharge(context.Background(), userID, id)
+		if err != nil {
+			return pixErrorResponse(c, err)
+		}
+		return c.JSON(charge)
+	})
+
+	// Decodifica o "copia e cola" para conferência antes do pagamento
+	group.Post("/brcode/parse", func(c *fiber.Ctx) error {
+		var body struct {
+			Payload string `json:"payload"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		code, err := pix.Decode(context.Background(), body.Payload)
+		if err != nil {
+			return pixErrorResponse(c, err)
+		}
+		return c.JSON(code)
+	})
+
+	// Paga o BR Code com saldo; amount só é necessário quando o código não fixa o valor
+	group.Post("/payments", func(c *fiber.Ctx) error {
+		var body struct {
+			Payload string `json:"payload"`
+			Amount  string `json:"amount"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amount := decimal.Zero
+		if body.Amount != "" {
+			amt, err := decimal.NewFromString(body.Amount)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+			}
+			amount = amt
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		payment, err := pix.Pay(context.Background(), userID, body.Payload, amount)
+		if err != nil {
+			if resp, ok := limitExceededResponse(err); ok {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(resp)
+			}
+			if status, resp, ok := destinationErrorResponse(err); ok {
+				return c.Status(status).JSON(resp)
+			}
+			if status, resp, ok := sanctionsBlockedResponse(err); ok {
+				return c.Status(status).JSON(resp)
+			}
+			if resp, ok := riskBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
+			if resp, ok := creditBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
+			return pixErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusAccepted).JSON(payment)
+	})
+
+	group.Get("/payments", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := pix.ListPayments(context.Background(), userID, c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.PixPayment{}
+		}
+		return c.JSON(fiber.Map{"payments": list})
+	})
+
+	// Créditos a devolver ou conciliar manualmente (?status=unmatched|failed)
+	operator.Get("/pix/credits", func(c *fiber.Ctx) error {
+		status := txnEntity.PixCreditStatus(c.Query("status", string(txnEntity.PixCreditUnmatched)))
+		list, err := pix.ListCredits(context.Background(), status, c.QueryInt("limit", 100))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.PixCredit{}
+		}
+		return c.JSON(fiber.Map{"credits": list})
+	})
+
+	// Com o simulador local, operadores podem gerar créditos para testar depósitos offline
+	if simulator, ok := pix.Provider().(pixCreditSimulator); ok {
+		operator.Post("/pix/simulate-credit", func(c *fiber.Ctx) error {
+			var body struct {
+				TxID      string `json:"txid"`
+				Amount    string `json:"amount"`
+				PayerName string `json:"payer_name"`
+			}
+			if err := c.BodyParser(&body); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+			}
+			amount, err := decimal.NewFromString(body.Amount)
+			if err != nil || !amount.IsPositive() {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+			}
+			credit, err := pix.ReceiveCredit(context.Background(), simulator.SimulateCredit(body.TxID, amount, body.PayerName))
+			if err != nil {
+				return pixErrorResponse(c, err)
+			}
+			return c.Status(fiber.StatusCreated).JSON(credit)
+		})
+	}
+}
+
+func pixErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrPixChargeNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrInvalidBRCode), errors.Is(err, txnEntity.ErrBRCodeCRC),
+		errors.Is(err, txnEntity.ErrInvalidPixCharge), errors.Is(err, txnSvc.ErrInvalidAmount):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrPixAmountMismatch), errors.Is(err, txnSvc.ErrInsufficientBalance):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"errors"
+	portfolioSvc "financial-system-pro/internal/contexts/portfolio/application/service"
+	portfolioEntity "financial-system-pro/internal/contexts/portfolio/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// registerV2PortfolioRoutes registra a avaliação do portfólio, a moeda de referência e o histórico
+// de retratos diários
+func registerV2PortfolioRoutes(api fiber.Router, sessions *userSvc.SessionService, portfolio *portfolioSvc.PortfolioService) {
+	group := api.Group("/portfolio", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	// Saldos da conta e das carteiras on-chain avaliados na moeda de referência (ou em ?currency=)
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		valuation, err := portfolio.Value(context.Background(), userID, c.Query("currency"))
+		if err != nil {
+			return portfolioErrorResponse(c, err)
+		}
+		return c.JSON(valuation)
+	})
+
+	group.Get("/currency", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		currency, err := portfolio.ReportingCurrency(context.Background(), userID)
+		if err != nil {
+			return portfolioErrorResponse(c, err)
+		}
+		return c.JSON(fiber.Map{"currency": currency})
+	})
+
+	group.Put("/currency", func(c *fiber.Ctx) error {
+		var body struct {
+			Currency string `json:"currency"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Currency == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		currency, err := portfolio.SetReportingCurrency(context.Background(), userID, body.Currency)
+		if err != nil {
+			return portfolioErrorResponse(c, err)
+		}
+		return c.JSON(fiber.Map{"currency": currency})
+	})
+
+	// Retratos diários em ?from=AAAA-MM-DD&to=AAAA-MM-DD (padrão: últimos 30 dias)
+	group.Get("/history", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		to := time.Now().UTC().Truncate(24 * time.Hour)
+		if raw := c.Query("to"); raw != "" {
+			if to, err = time.Parse("2006-01-02", raw); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid to: expected YYYY-MM-DD"})
+			}
+		}
+		from := to.AddDate(0, 0, -30)
+		if raw := c.Query("from"); raw != "" {
+			if from, err = time.Parse("2006-01-02", raw); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid from: expected YYYY-MM-DD"})
+			}
+		}
+		snapshots, err := portfolio.History(context.Background(), userID, from, to)
+		if err != nil {
+			return portfolioErrorResponse(c, err)
+		}
+		if snapshots == nil {
+			snapshots = []*portfolioEntity.Snapshot{}
+		}
+		return c.JSON(fiber.Map{"snapshots": snapshots})
+	})
+}
+
+func portfolioErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, sharedVO.ErrUnsupportedCurrency), errors.Is(err, portfolioSvc.ErrInvalidHistoryRange):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
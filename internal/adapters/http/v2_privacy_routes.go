@@ -0,0 +1,92 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2PrivacyRoutes registra os direitos do titular (LGPD): exportação pelo próprio usuário,
+// exportação e eliminação pelo operador
+func registerV2PrivacyRoutes(me, operator fiber.Router, privacy *userSvc.PrivacyService) {
+	me.Get("/data-export", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		return exportPersonalData(c, privacy, userID, userID)
+	})
+
+	operator.Get("/privacy/users/:id/export", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		operatorID, err := extractOperatorID(c)
+		if err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+		}
+		return exportPersonalData(c, privacy, userID, operatorID)
+	})
+
+	operator.Post("/privacy/users/:id/erase", func(c *fiber.Ctx) error {
+		userID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Reason == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "reason is required"})
+		}
+		operatorID, err := extractOperatorID(c)
+		if err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+		}
+
+		report, err := privacy.Erase(context.Background(), userID, operatorID, body.Reason, safeIP(c))
+		if err != nil {
+			switch {
+			case errors.Is(err, userSvc.ErrUserNotFound):
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			case errors.Is(err, userSvc.ErrUserAlreadyErased), errors.Is(err, userSvc.ErrErasureHasBalance):
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(report)
+	})
+}
+
+// exportPersonalData responde com JSON (padrão) ou ZIP quando format=zip
+func exportPersonalData(c *fiber.Ctx, privacy *userSvc.PrivacyService, userID, requestedBy uuid.UUID) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "zip" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format must be json or zip"})
+	}
+
+	export, err := privacy.Export(context.Background(), userID, requestedBy, safeIP(c))
+	if err != nil {
+		if errors.Is(err, userSvc.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if format == "json" {
+		return c.JSON(export)
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteZIP(&buf); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="personal-data-`+userID.String()+`.zip"`)
+	return c.Send(buf.Bytes())
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2ReconciliationRoutes registra os relatórios da conciliação diária com a rede (operador)
+func registerV2ReconciliationRoutes(operator fiber.Router, reconciliation *txnSvc.ReconciliationService) {
+	operator.Get("/reconciliations", func(c *fiber.Ctx) error {
+		runs, err := reconciliation.List(context.Background(), c.QueryInt("limit", 30))
+		if err != nil {
+			return reconciliationErrorResponse(c, err)
+		}
+		if runs == nil {
+			runs = []*txnEntity.ReconciliationRun{}
+		}
+		return c.JSON(fiber.Map{"reconciliations": runs})
+	})
+
+	operator.Get("/reconciliations/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		run, err := reconciliation.Get(context.Background(), id)
+		if err != nil {
+			return reconciliationErrorResponse(c, err)
+		}
+		return c.JSON(run)
+	})
+
+	// Executa (ou refaz) a conciliação de ?date=AAAA-MM-DD; padrão o dia anterior
+	operator.Post("/reconciliations", func(c *fiber.Ctx) error {
+		day := reconciliation.Yesterday()
+		if date := c.Query("date"); date != "" {
+			var err error
+			if day, err = reconciliation.ParseDate(date); err != nil {
+				return reconciliationErrorResponse(c, err)
+			}
+		}
+		run, err := reconciliation.Reconcile(context.Background(), day)
+		if err != nil {
+			return reconciliationErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(run)
+	})
+}
+
+func reconciliationErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrReconciliationNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrInvalidReconciliationDate):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
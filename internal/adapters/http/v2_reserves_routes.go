@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2ReservesRoutes registra a prova de reservas: raízes e passivo publicados sem
+// autenticação, prova de inclusão do usuário e geração de snapshots pelo operador
+func registerV2ReservesRoutes(api, me, operator fiber.Router, reserves *txnSvc.ReservesService) {
+	api.Get("/reserves", func(c *fiber.Ctx) error {
+		snapshot, err := reserves.Latest(context.Background())
+		if err != nil {
+			return reservesErrorResponse(c, err)
+		}
+		return c.JSON(reservesSummary(snapshot))
+	})
+
+	api.Get("/reserves/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		snapshot, err := reserves.Get(context.Background(), id)
+		if err != nil {
+			return reservesErrorResponse(c, err)
+		}
+		return c.JSON(reservesSummary(snapshot))
+	})
+
+	// Prova de inclusão: ?asset=ETH&snapshot=<id> (padrão o snapshot mais recente)
+	me.Get("/reserves/proof", func(c *fiber.Ctx) error {
+		asset := strings.ToUpper(strings.TrimSpace(c.Query("asset")))
+		if asset == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "asset is required"})
+		}
+		var snapshotID *uuid.UUID
+		if raw := c.Query("snapshot"); raw != "" {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid snapshot"})
+			}
+			snapshotID = &id
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		proof, err := reserves.Proof(context.Background(), userID, asset, snapshotID)
+		if err != nil {
+			return reservesErrorResponse(c, err)
+		}
+		return c.JSON(proof)
+	})
+
+	operator.Post("/reserves", func(c *fiber.Ctx) error {
+		snapshot, err := reserves.Generate(context.Background())
+		if err != nil {
+			return reservesErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(reservesSummary(snapshot))
+	})
+
+	operator.Get("/reserves", func(c *fiber.Ctx) error {
+		list, err := reserves.List(context.Background(), c.QueryInt("limit", 30))
+		if err != nil {
+			return reservesErrorResponse(c, err)
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, snapshot := range list {
+			out = append(out, reservesSummary(snapshot))
+		}
+		return c.JSON(fiber.Map{"snapshots": out})
+	})
+}
+
+// reservesSummary raízes, passivo e reservas de cada ativo com a indicação de solvência
+func reservesSummary(snapshot *txnEntity.ReservesSnapshot) fiber.Map {
+	assets := make([]fiber.Map, 0, len(snapshot.Assets))
+	for _, a := range snapshot.Assets {
+		item := fiber.Map{
+			"asset":              a.Asset,
+			"root":               a.Root,
+			"liabilities":        a.Liabilities.String(),
+			"accounts":           a.Accounts,
+			"reserves_available": a.ReservesAvailable,
+		}
+		if a.ReservesAvailable {
+			item["reserves"] = a.Reserves.String()
+			item["solvent"] = a.Solvent()
+		}
+		assets = append(assets, item)
+	}
+	return fiber.Map{"id": snapshot.ID, "created_at": snapshot.CreatedAt, "assets": assets}
+}
+
+func reservesErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrReservesSnapshotNotFound), errors.Is(err, txnSvc.ErrAccountNotInSnapshot):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
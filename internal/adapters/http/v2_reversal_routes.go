@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2ReversalRoutes registra o estorno total ou parcial de transações concluídas (operador)
+func registerV2ReversalRoutes(operator fiber.Router, reversals *txnSvc.ReversalService) {
+	operator.Post("/transactions/:id/reversals", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		var body struct {
+			Amount string `json:"amount"` // vazio = todo o valor ainda não estornado
+			Reason string `json:"reason"`
+			Note   string `json:"note"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amount := decimal.Zero
+		if body.Amount != "" {
+			if amount, err = decimal.NewFromString(body.Amount); err != nil || !amount.IsPositive() {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+			}
+		}
+		operatorID, err := extractOperatorID(c)
+		if err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+		}
+
+		rev, tx, err := reversals.Reverse(context.Background(), txnSvc.ReversalRequest{
+			OriginalID: id,
+			Amount:     amount,
+			Reason:     txnEntity.ReversalReason(body.Reason),
+			Note:       body.Note,
+			OperatorID: operatorID,
+		})
+		if err != nil {
+			return reversalErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"reversal":    rev,
+			"transaction": fiber.Map{"id": tx.ID, "type": tx.Type, "status": tx.Status, "amount": tx.Amount.String(), "parent_id": tx.ParentID},
+		})
+	})
+
+	operator.Get("/transactions/:id/reversals", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		summary, err := reversals.List(context.Background(), id)
+		if err != nil {
+			return reversalErrorResponse(c, err)
+		}
+		return c.JSON(fiber.Map{
+			"transaction_id": id,
+			"reversals":      summary.Reversals,
+			"total_reversed": summary.TotalReversed.String(),
+			"remaining":      summary.Remaining.String(),
+		})
+	})
+}
+
+func reversalErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrTransactionNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrInvalidReversalReason), errors.Is(err, txnSvc.ErrInvalidAmount):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrNotReversible), errors.Is(err, txnEntity.ErrReversalExceedsOriginal):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnSvc.ErrInsufficientBalance):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"errors"
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// registerV2RiskRoutes registra a consulta de regras e a gestão de casos de fraude/AML (operador)
+func registerV2RiskRoutes(operator fiber.Router, monitor *complianceSvc.MonitoringService) {
+	operator.Get("/risk/rules", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"rules": monitor.Rules()})
+	})
+
+	operator.Get("/risk/transactions/:id/assessments", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		list, err := monitor.Assessments(context.Background(), id)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, a := range list {
+			out = append(out, riskAssessmentJSON(a))
+		}
+		return c.JSON(fiber.Map{"assessments": out})
+	})
+
+	operator.Get("/risk/cases", func(c *fiber.Ctx) error {
+		list, err := monitor.ListCases(context.Background(), complianceEntity.CaseStatus(c.Query("status")), c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		out := make([]fiber.Map, 0, len(list))
+		for _, rc := range list {
+			out = append(out, riskCaseJSON(rc))
+		}
+		return c.JSON(fiber.Map{"cases": out})
+	})
+
+	operator.Get("/risk/cases/:id", func(c *fiber.Ctx) error {
+		return handleRiskCase(c, func(id, _ uuid.UUID) (*complianceEntity.Case, error) {
+			return monitor.GetCase(context.Background(), id)
+		})
+	})
+
+	operator.Post("/risk/cases/:id/assign", func(c *fiber.Ctx) error {
+		return handleRiskCase(c, func(id, analyst uuid.UUID) (*complianceEntity.Case, error) {
+			return monitor.AssignCase(context.Background(), id, analyst)
+		})
+	})
+
+	operator.Post("/risk/cases/:id/notes", func(c *fiber.Ctx) error {
+		var body struct {
+			Body string `json:"body"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		return handleRiskCase(c, func(id, analyst uuid.UUID) (*complianceEntity.Case, error) {
+			if _, err := monitor.AddCaseNote(context.Background(), id, analyst, body.Body); err != nil {
+				return nil, err
+			}
+			return monitor.GetCase(context.Background(), id)
+		})
+	})
+
+	operator.Post("/risk/cases/:id/close", func(c *fiber.Ctx) error {
+		var body struct {
+			Resolution string `json:"resolution"`
+			Note       string `json:"note"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		return handleRiskCase(c, func(id, analyst uuid.UUID) (*complianceEntity.Case, error) {
+			return monitor.CloseCase(context.Background(), id, analyst, complianceEntity.CaseResolution(body.Resolution), body.Note)
+		})
+	})
+}
+
+func handleRiskCase(c *fiber.Ctx, action func(id, analyst uuid.UUID) (*complianceEntity.Case, error)) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+	}
+	analyst, err := extractOperatorID(c)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "operator access required"})
+	}
+	rc, err := action(id, analyst)
+	if err != nil {
+		switch {
+		case errors.Is(err, complianceSvc.ErrCaseNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, complianceEntity.ErrCaseClosed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, complianceEntity.ErrInvalidResolution), errors.Is(err, complianceEntity.ErrEmptyNote):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(riskCaseJSON(rc))
+}
+
+// riskBlockedResponse converte BlockedError no corpo de resposta com os motivos do bloqueio
+func riskBlockedResponse(err error) (fiber.Map, bool) {
+	var blocked *complianceSvc.BlockedError
+	if !errors.As(err, &blocked) {
+		return nil, false
+	}
+	return fiber.Map{
+		"error":      complianceSvc.ErrTransactionBlocked.Error(),
+		"risk_score": blocked.Assessment.Score,
+		"reasons":    blocked.Assessment.Reasons(),
+	}, true
+}
+
+func riskAssessmentJSON(a *complianceEntity.Assessment) fiber.Map {
+	return fiber.Map{
+		"id":             a.ID,
+		"transaction_id": a.TransactionID,
+		"user_id":        a.UserID,
+		"phase":          a.Phase,
+		"score":          a.Score,
+		"blocked":        a.Blocked,
+		"hits":           a.Hits,
+		"evaluated_at":   a.EvaluatedAt,
+	}
+}
+
+func riskCaseJSON(rc *complianceEntity.Case) fiber.Map {
+	notes := rc.Notes
+	if notes == nil {
+		notes = []complianceEntity.CaseNote{}
+	}
+	return fiber.Map{
+		"id":             rc.ID,
+		"user_id":        rc.UserID,
+		"transaction_id": rc.TransactionID,
+		"assessment_id":  rc.AssessmentID,
+		"source":         rc.Source,
+		"score":          rc.Score,
+		"hits":           rc.Hits,
+		"status":         rc.Status,
+		"assignee_id":    rc.AssigneeID,
+		"resolution":     rc.Resolution,
+		"notes":          notes,
+		"created_at":     rc.CreatedAt,
+		"updated_at":     rc.UpdatedAt,
+		"closed_at":      rc.ClosedAt,
+	}
+}
@@ -2,10 +2,15 @@ package http
 
 import (
 	"context"
+	"errors"
 	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
 	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	"financial-system-pro/internal/infrastructure/config/container"
 	"financial-system-pro/internal/shared/breaker"
 	"financial-system-pro/internal/shared/utils"
+	"math"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -14,7 +19,7 @@ import (
 )
 
 // registerV2DDDRoutes registra rotas v2 usando serviços DDD diretamente.
-func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnService *txnSvc.TransactionService, _ *zap.Logger, _ *breaker.BreakerManager) {
+func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnService *txnSvc.TransactionService, services container.RouteServices, _ *zap.Logger, _ *breaker.BreakerManager) {
 	api := app.Group("/v2")
 
 	// Users
@@ -41,19 +46,240 @@ func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnSe
 		if err := c.BodyParser(&body); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
 		}
-		user, err := userService.Authenticate(context.Background(), body.Email, body.Password)
+		lc := userSvc.LoginContext{IPAddress: safeIP(c), UserAgent: c.Get(fiber.HeaderUserAgent), DeviceID: c.Get("X-Device-ID")}
+		user, err := userService.AuthenticateFrom(context.Background(), body.Email, body.Password, lc)
 		if err != nil {
+			var blocked *userSvc.LoginBlockedError
+			if errors.As(err, &blocked) {
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(blocked.RetryAfter.Seconds()))))
+				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": blocked.Err.Error()})
+			}
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid credentials"})
 		}
-		token, tErr := utils.CreateJWTToken(map[string]interface{}{"ID": user.ID})
+		claims := map[string]interface{}{"ID": user.ID}
+		resp := fiber.Map{}
+		if sessions := userService.Sessions(); sessions != nil {
+			session, sErr := sessions.Start(context.Background(), user.ID, lc)
+			if sErr != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "session creation failed"})
+			}
+			claims["jti"] = session.ID.String()
+			resp["session_id"] = session.ID
+		}
+		token, tErr := utils.CreateJWTToken(claims)
 		if tErr != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "token generation failed"})
 		}
-		return c.JSON(fiber.Map{"token": token})
+		resp["token"] = token
+		return c.JSON(resp)
+	})
+
+	api.Post("/auth/unlock/request", func(c *fiber.Ctx) error {
+		var body struct {
+			Email string `json:"email"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Email == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		if err := userService.RequestUnlock(context.Background(), body.Email); err != nil && !errors.Is(err, userSvc.ErrInvalidEmail) {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "unlock request failed"})
+		}
+		// Mesma resposta para contas bloqueadas ou não, evitando enumeração
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "unlock_requested"})
 	})
 
+	api.Post("/auth/unlock", func(c *fiber.Ctx) error {
+		var body struct {
+			Email string `json:"email"`
+			Token string `json:"token"`
+		}
+		if err := c.BodyParser(&body); err != nil || body.Email == "" || body.Token == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		if err := userService.ConfirmUnlock(context.Background(), body.Email, body.Token); err != nil {
+			if errors.Is(err, userSvc.ErrInvalidUnlockToken) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "unlock failed"})
+		}
+		return c.JSON(fiber.Map{"status": "unlocked"})
+	})
+
+	me := api.Group("/me", VerifyJWTMiddleware(), RequireActiveSession(userService.Sessions()))
+	operator := api.Group("/operator", VerifyJWTMiddleware(), RequireActiveSession(userService.Sessions()), RequireOperator())
+
+	// Sessions
+	if sessions := userService.Sessions(); sessions != nil {
+
+		me.Get("/sessions", func(c *fiber.Ctx) error {
+			userID, err := extractUserIDFromJWT(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			currentID, _ := extractSessionID(c)
+			list, err := sessions.List(context.Background(), userID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+			out := make([]fiber.Map, 0, len(list))
+			for _, s := range list {
+				out = append(out, fiber.Map{
+					"id":                 s.ID,
+					"device_fingerprint": s.DeviceFingerprint,
+					"ip_address":         s.IPAddress,
+					"user_agent":         s.UserAgent,
+					"created_at":         s.CreatedAt,
+					"last_seen_at":       s.LastSeenAt,
+					"expires_at":         s.ExpiresAt,
+					"current":            s.ID == currentID,
+				})
+			}
+			return c.JSON(fiber.Map{"sessions": out})
+		})
+
+		me.Delete("/sessions/:id", func(c *fiber.Ctx) error {
+			userID, err := extractUserIDFromJWT(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			sessionID, err := uuid.Parse(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+			}
+			if err := sessions.Revoke(context.Background(), userID, sessionID); err != nil {
+				if errors.Is(err, userSvc.ErrSessionNotFound) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.SendStatus(fiber.StatusNoContent)
+		})
+
+		// Revoga todas as sessões exceto a do token atual
+		me.Delete("/sessions", func(c *fiber.Ctx) error {
+			userID, err := extractUserIDFromJWT(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			currentID, err := extractSessionID(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			n, err := sessions.RevokeOthers(context.Background(), userID, currentID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.JSON(fiber.Map{"revoked": n})
+		})
+	}
+
+	// KYC
+	if kyc := userService.KYC(); kyc != nil {
+		registerV2KYCRoutes(me, operator, kyc)
+	}
+
+	// LGPD
+	if privacy := userService.Privacy(); privacy != nil {
+		registerV2PrivacyRoutes(me, operator, privacy)
+	}
+
+	// Catálogo de endereços de saque
+	if addresses := userService.AddressBook(); addresses != nil {
+		registerV2AddressBookRoutes(me, addresses)
+	}
+
+	// Organizações e contas nomeadas
+	if orgs := userService.Organizations(); orgs != nil {
+		registerV2OrganizationRoutes(api, userService.Sessions(), orgs)
+	}
+
+	// Monitoramento de fraude/AML
+	if monitor := txnService.Monitoring(); monitor != nil {
+		registerV2RiskRoutes(operator, monitor)
+	}
+
+	// Triagem de sanções
+	if screener := txnService.Sanctions(); screener != nil {
+		registerV2SanctionsRoutes(operator, screener)
+	}
+
+	// Aprovação maker-checker de saques
+	if approvals := txnService.Approvals(); approvals != nil {
+		registerV2ApprovalRoutes(operator, approvals)
+	}
+
+	// Tabela de taxas
+	if fees := txnService.Fees(); fees != nil {
+		registerV2FeeRoutes(me, operator, fees)
+	}
+
+	// Saldos por moeda e câmbio
+	if services.Conversions != nil {
+		registerV2ConversionRoutes(api, me, userService.Sessions(), services.Conversions)
+	}
+
+	// Transferências e saques agendados
+	if schedules := txnService.Schedules(); schedules != nil {
+		registerV2ScheduleRoutes(api, userService.Sessions(), userService.AddressBook(), schedules)
+	}
+
+	// Custódias entre comprador e vendedor
+	if escrows := txnService.Escrows(); escrows != nil {
+		registerV2EscrowRoutes(api, operator, userService.Sessions(), escrows)
+	}
+
+	// Depósitos e pagamentos Pix por BR Code
+	if pix := txnService.Pix(); pix != nil {
+		registerV2PixRoutes(api, operator, userService.Sessions(), pix)
+	}
+
+	// Extratos com saldo inicial, corrente e final (CSV, OFX e JSON)
+	if statements := txnService.Statements(); statements != nil {
+		registerV2StatementRoutes(api, userService.Sessions(), statements)
+	}
+
+	// Pagamentos em lote por arquivo
+	if payouts := txnService.Payouts(); payouts != nil {
+		registerV2PayoutRoutes(api, userService.Sessions(), payouts)
+	}
+
+	// Estornos de transações concluídas
+	if reversals := txnService.Reversals(); reversals != nil {
+		registerV2ReversalRoutes(operator, reversals)
+	}
+
+	// Conciliação diária com os saldos e transações on-chain
+	if reconciliation := txnService.Reconciliation(); reconciliation != nil {
+		registerV2ReconciliationRoutes(operator, reconciliation)
+	}
+
+	// Prova de reservas com árvore de Merkle de somas do passivo
+	if reserves := txnService.Reserves(); reserves != nil {
+		registerV2ReservesRoutes(api, me, operator, reserves)
+	}
+
+	// Lotes de aquisição e ganhos realizados para o imposto de renda
+	if services.TaxLots != nil {
+		registerV2TaxRoutes(api, userService.Sessions(), services.TaxLots)
+	}
+
+	// Avaliação do portfólio com preços de mercado
+	if services.Portfolio != nil {
+		registerV2PortfolioRoutes(api, userService.Sessions(), services.Portfolio)
+	}
+
+	// Contas remuneradas com apropriação diária e capitalização mensal
+	if interest := txnService.Interest(); interest != nil {
+		registerV2InterestRoutes(api, operator, userService.Sessions(), interest)
+	}
+
+	// Linhas de crédito (cheque especial) com juros e tarifas sobre o utilizado
+	if credit := txnService.Credit(); credit != nil {
+		registerV2CreditRoutes(api, operator, userService.Sessions(), credit)
+	}
+
 	// Transactions
-	txGroup := api.Group("/transactions", VerifyJWTMiddleware())
+	txGroup := api.Group("/transactions", VerifyJWTMiddleware(), RequireActiveSession(userService.Sessions()))
 
 	txGroup.Post("/deposit", func(c *fiber.Ctx) error {
 		var body struct {
@@ -71,6 +297,9 @@ func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnSe
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
 		}
 		if err := txnService.ProcessDeposit(context.Background(), userID, amt, ""); err != nil {
+			if resp, ok := riskBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 		}
 		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "deposit_queued"})
@@ -78,11 +307,73 @@ func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnSe
 
 	txGroup.Post("/withdraw", func(c *fiber.Ctx) error {
 		var body struct {
-			Amount string `json:"amount"`
+			Amount    string `json:"amount"`
+			AddressID string `json:"address_id"`
+			Chain     string `json:"chain"`
+			ToAddress string `json:"to_address"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amt, err := decimal.NewFromString(body.Amount)
+		if err != nil || amt.LessThanOrEqual(decimal.Zero) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		// Destino pelo catálogo (address_id) ou informado diretamente (chain + to_address)
+		chain, toAddress := body.Chain, body.ToAddress
+		if body.AddressID != "" {
+			addresses := userService.AddressBook()
+			addressID, err := uuid.Parse(body.AddressID)
+			if err != nil || addresses == nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid address_id"})
+			}
+			entry, err := addresses.Get(context.Background(), userID, addressID)
+			if err != nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			chain, toAddress = entry.Chain, entry.Address
+		}
+		tx, err := txnService.ProcessWithdrawTo(context.Background(), userID, amt, chain, toAddress)
+		if err != nil {
+			if resp, ok := limitExceededResponse(err); ok {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(resp)
+			}
+			if status, resp, ok := destinationErrorResponse(err); ok {
+				return c.Status(status).JSON(resp)
+			}
+			if status, resp, ok := sanctionsBlockedResponse(err); ok {
+				return c.Status(status).JSON(resp)
+			}
+			if resp, ok := riskBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
+			if resp, ok := creditBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if tx.Status == txnEntity.TransactionStatusAwaitingApproval {
+			return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": string(tx.Status), "transaction_id": tx.ID, "fee": tx.Fee.String()})
+		}
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "withdraw_processed", "fee": tx.Fee.String()})
+	})
+
+	txGroup.Post("/transfer", func(c *fiber.Ctx) error {
+		var body struct {
+			ToUserID string `json:"to_user_id"`
+			Amount   string `json:"amount"`
 		}
 		if err := c.BodyParser(&body); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
 		}
+		toUserID, err := uuid.Parse(body.ToUserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid to_user_id"})
+		}
 		amt, err := decimal.NewFromString(body.Amount)
 		if err != nil || amt.LessThanOrEqual(decimal.Zero) {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
@@ -91,10 +382,20 @@ func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnSe
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
 		}
-		if err := txnService.ProcessWithdraw(context.Background(), userID, amt); err != nil {
+		tx, err := txnService.ProcessTransfer(context.Background(), userID, toUserID, amt)
+		if err != nil {
+			if resp, ok := limitExceededResponse(err); ok {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(resp)
+			}
+			if resp, ok := riskBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
+			if resp, ok := creditBlockedResponse(err); ok {
+				return c.Status(fiber.StatusForbidden).JSON(resp)
+			}
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 		}
-		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "withdraw_processed"})
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "transfer_processed", "transaction_id": tx.ID, "fee": tx.Fee.String()})
 	})
 
 	txGroup.Get("/history", func(c *fiber.Ctx) error {
@@ -109,6 +410,11 @@ func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnSe
 		return c.JSON(fiber.Map{"transactions": list})
 	})
 
+	// Busca com filtros e paginação por cursor
+	if search := txnService.Search(); search != nil {
+		registerV2TransactionSearchRoutes(txGroup, search)
+	}
+
 	api.Get("/users/:id/wallet", func(c *fiber.Ctx) error {
 		idParam := c.Params("id")
 		id, err := uuid.Parse(idParam)
@@ -123,6 +429,22 @@ func registerV2DDDRoutes(app *fiber.App, userService *userSvc.UserService, txnSe
 	})
 }
 
+// limitExceededResponse converte LimitExceededError no corpo de resposta com os detalhes do limite
+func limitExceededResponse(err error) (fiber.Map, bool) {
+	var limitErr *txnSvc.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		return nil, false
+	}
+	return fiber.Map{
+		"error":     txnSvc.ErrLimitExceeded.Error(),
+		"kyc_level": limitErr.Level.String(),
+		"period":    limitErr.Period,
+		"limit":     limitErr.Limit.String(),
+		"used":      limitErr.Used.String(),
+		"requested": limitErr.Requested.String(),
+	}, true
+}
+
 // extractUserIDFromJWT retorna userID do token presente em contexto
 func extractUserIDFromJWT(c *fiber.Ctx) (uuid.UUID, error) {
 	userIDStr := c.Locals("user_id")
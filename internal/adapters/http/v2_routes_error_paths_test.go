@@ -2,21 +2,25 @@ package http
 
 import (
 	"context"
+	"errors"
 	txnService "financial-system-pro/internal/contexts/transaction/application/service"
 	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
 	txnRepo "financial-system-pro/internal/contexts/transaction/domain/repository"
 	userService "financial-system-pro/internal/contexts/user/application/service"
 	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
 	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/infrastructure/config/container"
 	"financial-system-pro/internal/shared/breaker"
 	"financial-system-pro/internal/shared/events"
 	"financial-system-pro/internal/shared/utils"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -83,6 +87,15 @@ func (r *epWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID, bala
 	return nil
 }
 
+func (r *epWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	w := r.wallets[userID]
+	if w == nil || (delta < 0 && w.Balance+delta < -overdraftLimit) {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	w.Balance += delta
+	return nil
+}
+
 var _ userRepo.WalletRepository = (*epWalletRepo)(nil)
 
 func (r *epTxRepo) Create(ctx context.Context, tx *txnEntity.Transaction) error {
@@ -120,6 +133,25 @@ func (r *epTxRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status txnEnt
 	return nil
 }
 
+func (r *epTxRepo) SumAmountSince(ctx context.Context, userID uuid.UUID, types []txnEntity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, tx := range r.txs {
+		if tx.UserID != userID || tx.Status == txnEntity.TransactionStatusFailed || tx.CreatedAt.Before(since) {
+			continue
+		}
+		for _, t := range types {
+			if tx.Type == t {
+				total = total.Add(tx.Amount)
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *epTxRepo) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*txnEntity.Transaction, error) {
+	return nil, nil
+}
+
 var _ txnRepo.TransactionRepository = (*epTxRepo)(nil)
 
 // Helper para criar app e retornar token
@@ -133,7 +165,7 @@ func setupAppForErrors(_ *testing.T) (*fiber.App, string) {
 	svcUser := userService.NewUserService(ur, wr, bus, logger)
 	svcTxn := txnService.NewTransactionService(tr, ur, wr, bus, br, logger)
 	app := fiber.New()
-	registerV2DDDRoutes(app, svcUser, svcTxn, logger, br)
+	registerV2DDDRoutes(app, svcUser, svcTxn, container.RouteServices{}, logger, br)
 	// criar token diretamente para evitar dependências do endpoint de login
 	token, _ := utils.CreateJWTToken(map[string]any{"ID": uuid.New().String()})
 	return app, token
@@ -237,7 +269,7 @@ func TestV2Routes_LoginInvalidCredentials(t *testing.T) {
 	svcUser := userService.NewUserService(ur, wr, bus, logger)
 	svcTxn := txnService.NewTransactionService(tr, ur, wr, bus, br, logger)
 	app := fiber.New()
-	registerV2DDDRoutes(app, svcUser, svcTxn, logger, br)
+	registerV2DDDRoutes(app, svcUser, svcTxn, container.RouteServices{}, logger, br)
 	// tentativa de login com usuário inexistente
 	req := httptest.NewRequest("POST", "/v2/auth/login", strings.NewReader(`{"email":"x@y.com","password":"pw"}`))
 	req.Header.Set("Content-Type", "application/json")
@@ -261,7 +293,7 @@ func TestV2Routes_CreateUserConflict(t *testing.T) {
 	svcUser := userService.NewUserService(ur, wr, bus, logger)
 	svcTxn := txnService.NewTransactionService(tr, ur, wr, bus, br, logger)
 	app := fiber.New()
-	registerV2DDDRoutes(app, svcUser, svcTxn, logger, br)
+	registerV2DDDRoutes(app, svcUser, svcTxn, container.RouteServices{}, logger, br)
 	// criar
 	req1 := httptest.NewRequest("POST", "/v2/users", strings.NewReader(`{"email":"a@b.com","password":"password"}`))
 	req1.Header.Set("Content-Type", "application/json")
@@ -283,3 +315,50 @@ func TestV2Routes_CreateUserConflict(t *testing.T) {
 		t.Fatalf("esperado 409 conflito obtido %d", resp2.StatusCode)
 	}
 }
+
+type failingLoginAttemptRepo struct{}
+
+func (failingLoginAttemptRepo) Find(ctx context.Context, scope userEntity.LoginAttemptScope, key string) (*userEntity.LoginAttempts, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func (failingLoginAttemptRepo) Save(ctx context.Context, attempts *userEntity.LoginAttempts) error {
+	return errors.New("store unavailable")
+}
+
+func (failingLoginAttemptRepo) Delete(ctx context.Context, scope userEntity.LoginAttemptScope, key string) error {
+	return errors.New("store unavailable")
+}
+
+var _ userRepo.LoginAttemptRepository = failingLoginAttemptRepo{}
+
+func TestV2Routes_UnlockErrors(t *testing.T) {
+	logger := zap.NewNop()
+	bus := events.NewInMemoryBus(logger)
+	br := breaker.NewBreakerManager(logger)
+	ur := newEpUserRepo()
+	wr := newEpWalletRepo()
+	svcUser := userService.NewUserService(ur, wr, bus, logger)
+	svcTxn := txnService.NewTransactionService(newEpTxRepo(), ur, wr, bus, br, logger)
+	app := fiber.New()
+	registerV2DDDRoutes(app, svcUser, svcTxn, container.RouteServices{}, logger, br)
+
+	unlock := func() int {
+		req := httptest.NewRequest("POST", "/v2/auth/unlock", strings.NewReader(`{"email":"a@b.com","token":"tok"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("unlock req err: %v", err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := unlock(); status != fiber.StatusBadRequest {
+		t.Fatalf("token inválido deveria dar 400, obtido %d", status)
+	}
+	svcUser.WithLoginGuard(userService.NewLoginGuard(failingLoginAttemptRepo{}, nil, bus, logger))
+	if status := unlock(); status != fiber.StatusInternalServerError {
+		t.Fatalf("falha do repositório deveria dar 500, obtido %d", status)
+	}
+}
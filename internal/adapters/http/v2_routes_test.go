@@ -9,14 +9,17 @@ import (
 	userService "financial-system-pro/internal/contexts/user/application/service"
 	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
 	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/infrastructure/config/container"
 	"financial-system-pro/internal/shared/breaker"
 	"financial-system-pro/internal/shared/events"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -90,6 +93,15 @@ func (r *inMemoryWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID
 	return nil
 }
 
+func (r *inMemoryWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	w := r.wallets[userID]
+	if w == nil || (delta < 0 && w.Balance+delta < -overdraftLimit) {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	w.Balance += delta
+	return nil
+}
+
 // TransactionRepository
 func (r *inMemoryTxRepo) Create(ctx context.Context, tx *entity.Transaction) error {
 	r.txs[tx.ID] = tx
@@ -127,6 +139,25 @@ func (r *inMemoryTxRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status
 	return nil
 }
 
+func (r *inMemoryTxRepo) SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, tx := range r.txs {
+		if tx.UserID != userID || tx.Status == entity.TransactionStatusFailed || tx.CreatedAt.Before(since) {
+			continue
+		}
+		for _, t := range types {
+			if tx.Type == t {
+				total = total.Add(tx.Amount)
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *inMemoryTxRepo) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error) {
+	return nil, nil
+}
+
 // Compile-time checks
 var _ userRepo.UserRepository = (*inMemoryUserRepo)(nil)
 var _ userRepo.WalletRepository = (*inMemoryWalletRepo)(nil)
@@ -149,7 +180,7 @@ func TestV2Routes_UserLifecycleAndTransactions(t *testing.T) {
 
 	// App
 	app := fiber.New()
-	registerV2DDDRoutes(app, dddUserSvc, dddTxnSvc, logger, breakerManager)
+	registerV2DDDRoutes(app, dddUserSvc, dddTxnSvc, container.RouteServices{}, logger, breakerManager)
 
 	// 1. Create user
 	req := httptest.NewRequest("POST", "/v2/users", strings.NewReader(`{"email":"test@example.com","password":"secret"}`))
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"errors"
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// registerV2SanctionsRoutes registra consulta e recarga das listas de sanções (operador)
+func registerV2SanctionsRoutes(operator fiber.Router, screener *complianceSvc.SanctionsScreener) {
+	operator.Get("/sanctions/lists", func(c *fiber.Ctx) error {
+		list := screener.List()
+		if list == nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": complianceSvc.ErrSanctionsListUnavailable.Error()})
+		}
+		return c.JSON(sanctionsListJSON(list))
+	})
+
+	operator.Post("/sanctions/reload", func(c *fiber.Ctx) error {
+		if err := screener.Reload(context.Background()); err != nil {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(sanctionsListJSON(screener.List()))
+	})
+
+	operator.Post("/sanctions/check", func(c *fiber.Ctx) error {
+		var body struct {
+			Address string `json:"address"`
+			Chain   string `json:"chain"`
+			Name    string `json:"name"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		if strings.TrimSpace(body.Address) == "" && strings.TrimSpace(body.Name) == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "address or name is required"})
+		}
+		result, err := screener.Check(complianceSvc.ScreeningRequest{
+			Direction: complianceEntity.DirectionOutbound,
+			Chain:     body.Chain,
+			Address:   body.Address,
+			Name:      body.Name,
+		})
+		if err != nil {
+			if errors.Is(err, complianceSvc.ErrSanctionsListUnavailable) {
+				return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(result)
+	})
+
+	operator.Get("/sanctions/screenings", func(c *fiber.Ctx) error {
+		list, err := screener.Screenings(context.Background(), complianceEntity.ScreeningDecision(c.Query("decision")), c.QueryInt("limit", 50))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*complianceEntity.ScreeningResult{}
+		}
+		return c.JSON(fiber.Map{"screenings": list})
+	})
+}
+
+// sanctionsBlockedResponse converte a recusa do destino pela triagem de sanções (ou a lista
+// indisponível) no status e corpo de resposta
+func sanctionsBlockedResponse(err error) (int, fiber.Map, bool) {
+	var hit *complianceSvc.SanctionsHitError
+	switch {
+	case errors.As(err, &hit):
+		return fiber.StatusForbidden, fiber.Map{"error": err.Error(), "reason": "sanctions_hit", "screening_id": hit.Result.ID}, true
+	case errors.Is(err, complianceSvc.ErrSanctionsListUnavailable):
+		return fiber.StatusServiceUnavailable, fiber.Map{"error": err.Error()}, true
+	}
+	return 0, nil, false
+}
+
+func sanctionsListJSON(list *complianceEntity.SanctionsList) fiber.Map {
+	return fiber.Map{
+		"version":   list.Version,
+		"loaded_at": list.LoadedAt,
+		"sources":   list.Sources,
+		"addresses": list.AddressCount(),
+		"names":     list.NameCount(),
+	}
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2ScheduleRoutes registra transferências e saques agendados (únicos ou recorrentes)
+func registerV2ScheduleRoutes(api fiber.Router, sessions *userSvc.SessionService, addresses *userSvc.AddressBookService, schedules *txnSvc.ScheduleService) {
+	group := api.Group("/schedules", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	group.Post("/", func(c *fiber.Ctx) error {
+		var body struct {
+			Type        string     `json:"type"`
+			Amount      string     `json:"amount"`
+			ToUserID    string     `json:"to_user_id"`
+			AddressID   string     `json:"address_id"`
+			Chain       string     `json:"chain"`
+			ToAddress   string     `json:"to_address"`
+			Description string     `json:"description"`
+			Recurrence  string     `json:"recurrence"`
+			StartAt     time.Time  `json:"start_at"`
+			EndAt       *time.Time `json:"end_at"`
+			MaxRuns     int        `json:"max_runs"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		amt, err := decimal.NewFromString(body.Amount)
+		if err != nil || amt.LessThanOrEqual(decimal.Zero) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid amount"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		spec := txnEntity.ScheduleSpec{
+			UserID:      userID,
+			Kind:        txnEntity.ScheduleKind(body.Type),
+			Amount:      amt,
+			Chain:       body.Chain,
+			ToAddress:   body.ToAddress,
+			Description: body.Description,
+			Recurrence:  body.Recurrence,
+			StartAt:     body.StartAt,
+			EndAt:       body.EndAt,
+			MaxRuns:     body.MaxRuns,
+		}
+		if body.ToUserID != "" {
+			toUserID, err := uuid.Parse(body.ToUserID)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid to_user_id"})
+			}
+			spec.ToUserID = &toUserID
+		}
+		// Destino pelo catálogo (address_id) ou informado diretamente (chain + to_address)
+		if body.AddressID != "" {
+			addressID, err := uuid.Parse(body.AddressID)
+			if err != nil || addresses == nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid address_id"})
+			}
+			entry, err := addresses.Get(context.Background(), userID, addressID)
+			if err != nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			spec.Chain, spec.ToAddress = entry.Chain, entry.Address
+		}
+
+		sched, err := schedules.Create(context.Background(), spec)
+		if err != nil {
+			return scheduleErrorResponse(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(sched)
+	})
+
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := schedules.List(context.Background(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.ScheduledTransfer{}
+		}
+		return c.JSON(fiber.Map{"schedules": list})
+	})
+
+	group.Get("/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		sched, err := schedules.Get(context.Background(), userID, id)
+		if err != nil {
+			return scheduleErrorResponse(c, err)
+		}
+		return c.JSON(sched)
+	})
+
+	group.Get("/:id/executions", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := schedules.History(context.Background(), userID, id, c.QueryInt("limit", 50))
+		if err != nil {
+			return scheduleErrorResponse(c, err)
+		}
+		if list == nil {
+			list = []*txnEntity.ScheduleExecution{}
+		}
+		return c.JSON(fiber.Map{"executions": list})
+	})
+
+	transition := func(apply func(context.Context, uuid.UUID, uuid.UUID) (*txnEntity.ScheduledTransfer, error)) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+			}
+			userID, err := extractUserIDFromJWT(c)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			}
+			sched, err := apply(context.Background(), userID, id)
+			if err != nil {
+				return scheduleErrorResponse(c, err)
+			}
+			return c.JSON(sched)
+		}
+	}
+	group.Post("/:id/pause", transition(schedules.Pause))
+	group.Post("/:id/resume", transition(schedules.Resume))
+	group.Post("/:id/cancel", transition(schedules.Cancel))
+}
+
+func scheduleErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrScheduleNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrScheduleNotActive), errors.Is(err, txnEntity.ErrScheduleNotPaused),
+		errors.Is(err, txnEntity.ErrScheduleFinished), errors.Is(err, txnEntity.ErrScheduleConflict):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrInvalidSchedule), errors.Is(err, txnEntity.ErrInvalidRecurrence):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	"financial-system-pro/internal/shared/cqrs"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// registerV2TransactionSearchRoutes registra a busca de transações com filtros e paginação por cursor
+func registerV2TransactionSearchRoutes(txGroup fiber.Router, search *txnSvc.TransactionSearchService) {
+	// ?type, status, chain, hash, counterparty, min_amount, max_amount, from, to (AAAA-MM-DD ou RFC3339;
+	// to exclusivo), sort=created_at|amount, order=asc|desc, limit e cursor (next_cursor da página anterior)
+	txGroup.Get("/search", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		query, err := transactionSearchQuery(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		page, err := search.Search(context.Background(), userID, query)
+		if err != nil {
+			if errors.Is(err, txnSvc.ErrInvalidSearchQuery) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(page)
+	})
+}
+
+func transactionSearchQuery(c *fiber.Ctx) (cqrs.TransactionQuery, error) {
+	query := cqrs.TransactionQuery{
+		SortBy:    strings.ToLower(c.Query("sort")),
+		SortOrder: strings.ToLower(c.Query("order")),
+		Cursor:    c.Query("cursor"),
+		Limit:     c.QueryInt("limit"),
+	}
+	text := func(key string) *string {
+		if v := strings.TrimSpace(c.Query(key)); v != "" {
+			return &v
+		}
+		return nil
+	}
+	query.Type = text("type")
+	query.Status = text("status")
+	query.Chain = text("chain")
+	query.TxHash = text("hash")
+	query.Counterparty = text("counterparty")
+
+	for key, dst := range map[string]**decimal.Decimal{"min_amount": &query.MinAmount, "max_amount": &query.MaxAmount} {
+		if v := text(key); v != nil {
+			amount, err := decimal.NewFromString(*v)
+			if err != nil {
+				return query, errors.New(key + " must be a number")
+			}
+			*dst = &amount
+		}
+	}
+	for key, dst := range map[string]**time.Time{"from": &query.FromDate, "to": &query.ToDate} {
+		if v := text(key); v != nil {
+			t, err := time.Parse(time.RFC3339, *v)
+			if err != nil {
+				if t, err = time.Parse("2006-01-02", *v); err != nil {
+					return query, errors.New(key + " must be YYYY-MM-DD or RFC3339")
+				}
+			}
+			*dst = &t
+		}
+	}
+	return query, nil
+}
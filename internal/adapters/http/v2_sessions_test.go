@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	txnService "financial-system-pro/internal/contexts/transaction/application/service"
+	userService "financial-system-pro/internal/contexts/user/application/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/infrastructure/config/container"
+	"financial-system-pro/internal/shared/breaker"
+	"financial-system-pro/internal/shared/events"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type inMemorySessionRepo struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*userEntity.Session
+}
+
+func newInMemorySessionRepo() *inMemorySessionRepo {
+	return &inMemorySessionRepo{sessions: make(map[uuid.UUID]*userEntity.Session)}
+}
+
+func (r *inMemorySessionRepo) Create(ctx context.Context, s *userEntity.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *s
+	r.sessions[s.ID] = &cp
+	return nil
+}
+func (r *inMemorySessionRepo) FindByID(ctx context.Context, id uuid.UUID) (*userEntity.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *s
+	return &cp, nil
+}
+func (r *inMemorySessionRepo) ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*userEntity.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*userEntity.Session
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.IsActive(now) {
+			cp := *s
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+func (r *inMemorySessionRepo) Update(ctx context.Context, s *userEntity.Session) error {
+	return r.Create(ctx, s)
+}
+func (r *inMemorySessionRepo) RevokeAllExcept(ctx context.Context, userID, keepID uuid.UUID, at time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.ID != keepID && s.RevokedAt == nil {
+			s.Revoke(at)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *inMemorySessionRepo) PseudonymizeSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, s := range r.sessions {
+		if s.UserID == userID {
+			s.IPAddress, s.UserAgent, s.DeviceFingerprint = "", "", ""
+			n++
+		}
+	}
+	return n, nil
+}
+
+var _ userRepo.SessionRepository = (*inMemorySessionRepo)(nil)
+
+func TestV2Routes_SessionsListAndRevoke(t *testing.T) {
+	t.Setenv("SECRET_KEY", "test-secret")
+	t.Setenv("EXPIRATION_TIME", "3600")
+	logger := zap.NewNop()
+	eventBus := events.NewInMemoryBus(logger)
+	breakerManager := breaker.NewBreakerManager(logger)
+	ur := newInMemoryUserRepo()
+	wr := newInMemoryWalletRepo()
+
+	sessions := userService.NewSessionService(newInMemorySessionRepo(), time.Hour, logger)
+	userSvc := userService.NewUserService(ur, wr, eventBus, logger).WithSessions(sessions)
+	txnSvc := txnService.NewTransactionService(newInMemoryTxRepo(), ur, wr, eventBus, breakerManager, logger)
+
+	app := fiber.New()
+	registerV2DDDRoutes(app, userSvc, txnSvc, container.RouteServices{}, logger, breakerManager)
+
+	req := httptest.NewRequest("POST", "/v2/users", strings.NewReader(`{"email":"sess@example.com","password":"secret"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if resp, err := app.Test(req); err != nil || resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("create user failed: %v", err)
+	}
+
+	login := func(device string) (string, string) {
+		r := httptest.NewRequest("POST", "/v2/auth/login", strings.NewReader(`{"email":"sess@example.com","password":"secret"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("User-Agent", "test-agent")
+		r.Header.Set("X-Device-ID", device)
+		resp, err := app.Test(r)
+		if err != nil || resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("login failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return body["token"].(string), body["session_id"].(string)
+	}
+	call := func(method, path, token string) int {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		resp, err := app.Test(r)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	phoneToken, phoneSession := login("phone")
+	laptopToken, _ := login("laptop")
+	tabletToken, tabletSession := login("tablet")
+
+	// Listagem marca a sessão atual
+	r := httptest.NewRequest("GET", "/v2/me/sessions", nil)
+	r.Header.Set("Authorization", "Bearer "+laptopToken)
+	resp, err := app.Test(r)
+	if err != nil || resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("list sessions failed: %v", err)
+	}
+	var list struct {
+		Sessions []struct {
+			ID      string `json:"id"`
+			Current bool   `json:"current"`
+		} `json:"sessions"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	if len(list.Sessions) != 3 {
+		t.Fatalf("esperado 3 sessões, obtido %d", len(list.Sessions))
+	}
+	current := 0
+	for _, s := range list.Sessions {
+		if s.Current {
+			current++
+		}
+	}
+	if current != 1 {
+		t.Fatalf("exatamente uma sessão deveria ser marcada como atual, obtido %d", current)
+	}
+
+	// Revogar sessão específica invalida o token imediatamente
+	if code := call("DELETE", "/v2/me/sessions/"+tabletSession, laptopToken); code != fiber.StatusNoContent {
+		t.Fatalf("esperado 204 ao revogar, obtido %d", code)
+	}
+	if code := call("GET", "/v2/transactions/history", tabletToken); code != fiber.StatusUnauthorized {
+		t.Fatalf("token revogado deveria ser recusado, obtido %d", code)
+	}
+
+	// Revogar as demais mantém apenas a sessão atual
+	if code := call("DELETE", "/v2/me/sessions", laptopToken); code != fiber.StatusOK {
+		t.Fatalf("esperado 200 ao revogar demais, obtido %d", code)
+	}
+	if code := call("GET", "/v2/me/sessions", phoneToken); code != fiber.StatusUnauthorized {
+		t.Fatalf("sessão %s deveria estar revogada, obtido %d", phoneSession, code)
+	}
+	if code := call("GET", "/v2/me/sessions", laptopToken); code != fiber.StatusOK {
+		t.Fatalf("sessão atual deveria continuar ativa, obtido %d", code)
+	}
+
+	// Sessão de outro usuário / inexistente
+	if code := call("DELETE", "/v2/me/sessions/"+uuid.NewString(), laptopToken); code != fiber.StatusNotFound {
+		t.Fatalf("esperado 404 para sessão desconhecida, obtido %d", code)
+	}
+}
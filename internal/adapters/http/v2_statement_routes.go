@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"errors"
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// statementContentTypes Content-Type e extensão de cada formato de extrato
+var statementContentTypes = map[string][2]string{
+	txnEntity.StatementFormatCSV:  {"text/csv; charset=utf-8", "csv"},
+	txnEntity.StatementFormatOFX:  {"application/x-ofx", "ofx"},
+	txnEntity.StatementFormatJSON: {fiber.MIMEApplicationJSONCharsetUTF8, "json"},
+	// ISO 20022 camt.053 para a tesouraria
+	txnEntity.StatementFormatCamt053: {fiber.MIMEApplicationXMLCharsetUTF8, "xml"},
+}
+
+// registerV2StatementRoutes registra os extratos sob demanda e os mensais gerados pela fila
+func registerV2StatementRoutes(api fiber.Router, sessions *userSvc.SessionService, statements *txnSvc.StatementService) {
+	group := api.Group("/statements", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	// Extrato sob demanda: ?month=AAAA-MM ou ?from=AAAA-MM-DD&to=AAAA-MM-DD (inclusive);
+	// ?format=csv|ofx|camt053|json (padrão json)
+	group.Get("/", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		from, to, err := statementPeriod(c, statements)
+		if err != nil {
+			return statementErrorResponse(c, err)
+		}
+		st, err := statements.Generate(context.Background(), userID, from, to)
+		if err != nil {
+			return statementErrorResponse(c, err)
+		}
+		return sendStatement(c, statements, st)
+	})
+
+	group.Get("/monthly", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		list, err := statements.List(context.Background(), userID, c.QueryInt("limit", 24))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if list == nil {
+			list = []*txnEntity.Statement{}
+		}
+		return c.JSON(fiber.Map{"statements": list})
+	})
+
+	group.Get("/monthly/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		st, err := statements.Get(context.Background(), userID, id)
+		if err != nil {
+			return statementErrorResponse(c, err)
+		}
+		return sendStatement(c, statements, st)
+	})
+}
+
+func statementPeriod(c *fiber.Ctx, statements *txnSvc.StatementService) (time.Time, time.Time, error) {
+	if month := c.Query("month"); month != "" {
+		return statements.MonthPeriod(month)
+	}
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Join(txnEntity.ErrInvalidStatementPeriod, errors.New("from must be YYYY-MM-DD"))
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Join(txnEntity.ErrInvalidStatementPeriod, errors.New("to must be YYYY-MM-DD"))
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func sendStatement(c *fiber.Ctx, statements *txnSvc.StatementService, st *txnEntity.Statement) error {
+	format := strings.ToLower(c.Query("format", txnEntity.StatementFormatJSON))
+	contentType, ok := statementContentTypes[format]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format must be csv, ofx, camt053 or json"})
+	}
+	body, err := statements.Render(st, format)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	c.Set(fiber.HeaderContentType, contentType[0])
+	if format != txnEntity.StatementFormatJSON {
+		name := "statement-" + st.PeriodStart.Format("20060102") + "-" + st.PeriodEnd.AddDate(0, 0, -1).Format("20060102")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+name+`.`+contentType[1]+`"`)
+	}
+	return c.Send(body)
+}
+
+func statementErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, txnSvc.ErrStatementNotFound), errors.Is(err, txnSvc.ErrWalletNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, txnEntity.ErrInvalidStatementPeriod):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
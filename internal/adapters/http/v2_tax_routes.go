@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"errors"
+	taxSvc "financial-system-pro/internal/contexts/tax/application/service"
+	taxEntity "financial-system-pro/internal/contexts/tax/domain/entity"
+	userSvc "financial-system-pro/internal/contexts/user/application/service"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// taxReportContentTypes Content-Type e extensão de cada formato do relatório anual
+var taxReportContentTypes = map[string][2]string{
+	taxEntity.ReportFormatCSV:  {"text/csv; charset=utf-8", "csv"},
+	taxEntity.ReportFormatJSON: {fiber.MIMEApplicationJSONCharsetUTF8, "json"},
+}
+
+// registerV2TaxRoutes registra os lotes de aquisição, a designação de lotes nas alienações e o
+// relatório anual de ganhos e perdas
+func registerV2TaxRoutes(api fiber.Router, sessions *userSvc.SessionService, taxLots *taxSvc.TaxLotService) {
+	group := api.Group("/tax", VerifyJWTMiddleware(), RequireActiveSession(sessions))
+
+	group.Get("/lots", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		lots, err := taxLots.Lots(context.Background(), userID)
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		if lots == nil {
+			lots = []*taxEntity.Lot{}
+		}
+		return c.JSON(fiber.Map{"lots": lots})
+	})
+
+	// Custo de aquisição de um lote de depósito on-chain, para ativos adquiridos fora da plataforma
+	group.Put("/lots/:id/cost-basis", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		var body struct {
+			CostBasis  string     `json:"cost_basis"` // BRL
+			AcquiredAt *time.Time `json:"acquired_at"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		if body.CostBasis == "" {
+			return taxErrorResponse(c, taxSvc.ErrCostBasisRequired)
+		}
+		cost, err := decimal.NewFromString(body.CostBasis)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid cost_basis"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		lot, err := taxLots.SetDepositCostBasis(context.Background(), userID, id, taxSvc.DepositCostBasis{CostBasis: cost, AcquiredAt: body.AcquiredAt})
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		return c.JSON(lot)
+	})
+
+	group.Get("/disposals", func(c *fiber.Ctx) error {
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		disposals, err := taxLots.Disposals(context.Background(), userID)
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		if disposals == nil {
+			disposals = []*taxEntity.Disposal{}
+		}
+		return c.JSON(fiber.Map{"disposals": disposals})
+	})
+
+	// Identificação específica: lotes consumidos pela alienação
+	group.Put("/disposals/:id/lots", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		}
+		var body struct {
+			Lots []taxEntity.LotDesignation `json:"lots"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid body"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		d, err := taxLots.Designate(context.Background(), userID, id, body.Lots)
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		return c.JSON(d)
+	})
+
+	// Relatório anual: ?year=AAAA&method=fifo|average|specific (padrão average)&format=csv|json
+	group.Get("/report", func(c *fiber.Ctx) error {
+		year, err := strconv.Atoi(c.Query("year"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "year is required"})
+		}
+		method, err := taxEntity.ParseCostMethod(c.Query("method"))
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		format := strings.ToLower(c.Query("format", taxEntity.ReportFormatJSON))
+		contentType, ok := taxReportContentTypes[format]
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format must be csv or json"})
+		}
+		userID, err := extractUserIDFromJWT(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+		report, err := taxLots.Report(context.Background(), userID, year, method)
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		body, err := taxLots.Render(report, format)
+		if err != nil {
+			return taxErrorResponse(c, err)
+		}
+		c.Set(fiber.HeaderContentType, contentType[0])
+		if format != taxEntity.ReportFormatJSON {
+			c.Set(fiber.HeaderContentDisposition, `attachment; filename="gains-`+strconv.Itoa(year)+`-`+string(method)+`.`+contentType[1]+`"`)
+		}
+		return c.Send(body)
+	})
+}
+
+func taxErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, taxSvc.ErrDisposalNotFound), errors.Is(err, taxSvc.ErrLotNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, taxSvc.ErrNotDepositLot):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, taxEntity.ErrUnknownCostMethod), errors.Is(err, taxEntity.ErrInvalidQuantity),
+		errors.Is(err, taxEntity.ErrInvalidCostBasis), errors.Is(err, taxEntity.ErrInvalidDesignation),
+		errors.Is(err, taxSvc.ErrNotCryptoAsset), errors.Is(err, taxSvc.ErrCostBasisRequired),
+		errors.Is(err, taxSvc.ErrAcquisitionAfterDeposit), errors.Is(err, taxSvc.ErrInvalidYear),
+		errors.Is(err, sharedVO.ErrUnsupportedCurrency):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
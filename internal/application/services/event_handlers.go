@@ -29,10 +29,35 @@ func SetupEventSubscribers(bus events.Bus, logger *zap.Logger) {
 	bus.Subscribe("withdraw.completed", handlers.OnWithdrawCompleted)
 	bus.Subscribe("transfer.completed", handlers.OnTransferCompleted)
 	bus.Subscribe("transaction.failed", handlers.OnTransactionFailed)
+	bus.Subscribe("withdraw.approval_requested", handlers.OnWithdrawalApprovalRequested)
+	bus.Subscribe("withdraw.approval_resolved", handlers.OnWithdrawalApprovalResolved)
+	bus.Subscribe("fee.charged", handlers.OnFeeCharged)
+	bus.Subscribe("fx.converted", handlers.OnCurrencyConverted)
+	bus.Subscribe("schedule.executed", handlers.OnScheduledTransferExecuted)
+	bus.Subscribe("schedule.failed", handlers.OnScheduledTransferFailed)
+	bus.Subscribe("transaction.reversed", handlers.OnTransactionReversed)
+	bus.Subscribe("escrow.funded", handlers.OnEscrowFunded)
+	bus.Subscribe("escrow.disputed", handlers.OnEscrowDisputed)
+	bus.Subscribe("escrow.settled", handlers.OnEscrowSettled)
+	bus.Subscribe("payout.batch_completed", handlers.OnPayoutBatchCompleted)
+	bus.Subscribe("pix.credit_received", handlers.OnPixCreditReceived)
+	bus.Subscribe("statement.generated", handlers.OnStatementGenerated)
+	bus.Subscribe("reconciliation.breaks_detected", handlers.OnReconciliationBreaksDetected)
 
 	// Eventos de User
 	bus.Subscribe("user.created", handlers.OnUserCreated)
 	bus.Subscribe("user.authenticated", handlers.OnUserAuthenticated)
+	bus.Subscribe("user.locked", handlers.OnUserLocked)
+	bus.Subscribe("user.kyc_reviewed", handlers.OnKYCReviewed)
+	bus.Subscribe("user.erased", handlers.OnUserErased)
+	bus.Subscribe("user.withdrawal_address_confirmed", handlers.OnWithdrawalAddressConfirmed)
+	bus.Subscribe("account.transfer_completed", handlers.OnAccountTransferCompleted)
+	bus.Subscribe("account.funded", handlers.OnAccountWalletMovement)
+	bus.Subscribe("account.withdrawn", handlers.OnAccountWalletMovement)
+
+	// Eventos de Compliance
+	bus.Subscribe("risk.case_opened", handlers.OnRiskCaseOpened)
+	bus.Subscribe("compliance.hit", handlers.OnComplianceHit)
 
 	// Eventos de Blockchain
 	bus.Subscribe("wallet.created", handlers.OnWalletCreated)
@@ -117,6 +142,248 @@ func (h *EventHandlers) OnTransactionFailed(ctx context.Context, e events.Event)
 	return nil
 }
 
+// OnWithdrawalApprovalRequested processa saques retidos aguardando aprovação
+func (h *EventHandlers) OnWithdrawalApprovalRequested(ctx context.Context, e events.Event) error {
+	event := e.(events.WithdrawalApprovalRequestedEvent)
+
+	h.logger.Info("⏳ withdrawal approval requested event received",
+		zap.String("approval_id", event.ApprovalID.String()),
+		zap.String("transaction_id", event.TransactionID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("amount", event.Amount.String()),
+		zap.String("policy_id", event.PolicyID),
+		zap.Int("required_approvals", event.RequiredApprovals),
+		zap.Time("expires_at", event.ExpiresAt),
+	)
+
+	// Lógica adicional:
+	// - Notificar a fila de aprovadores
+
+	return nil
+}
+
+// OnWithdrawalApprovalResolved processa a conclusão, recusa ou expiração de uma aprovação
+func (h *EventHandlers) OnWithdrawalApprovalResolved(ctx context.Context, e events.Event) error {
+	event := e.(events.WithdrawalApprovalResolvedEvent)
+
+	resolvedBy := "system"
+	if event.ResolvedBy != nil {
+		resolvedBy = event.ResolvedBy.String()
+	}
+	h.logger.Info("✅ withdrawal approval resolved event received",
+		zap.String("approval_id", event.ApprovalID.String()),
+		zap.String("transaction_id", event.TransactionID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("status", event.Status),
+		zap.String("resolved_by", resolvedBy),
+	)
+
+	return nil
+}
+
+// OnFeeCharged processa a cobrança de taxas creditadas na carteira de receita
+func (h *EventHandlers) OnFeeCharged(ctx context.Context, e events.Event) error {
+	event := e.(events.FeeChargedEvent)
+
+	h.logger.Info("🧾 fee charged event received",
+		zap.String("fee_transaction_id", event.FeeTransactionID.String()),
+		zap.String("parent_id", event.ParentID.String()),
+		zap.String("parent_type", event.ParentType),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("amount", event.Amount.String()),
+	)
+
+	// Lógica de receita: relatórios e conciliação da carteira da plataforma
+
+	return nil
+}
+
+// OnCurrencyConverted processa conversões de câmbio executadas
+func (h *EventHandlers) OnCurrencyConverted(ctx context.Context, e events.Event) error {
+	event := e.(events.CurrencyConvertedEvent)
+
+	h.logger.Info("💱 currency converted event received",
+		zap.String("quote_id", event.QuoteID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("from", event.From),
+		zap.String("to", event.To),
+		zap.String("sell_amount", event.SellAmount.String()),
+		zap.String("buy_amount", event.BuyAmount.String()),
+	)
+
+	// Lógica de tesouraria: acompanhar a exposição por moeda após as conversões
+
+	return nil
+}
+
+// OnScheduledTransferExecuted processa ocorrências agendadas executadas
+func (h *EventHandlers) OnScheduledTransferExecuted(ctx context.Context, e events.Event) error {
+	event := e.(events.ScheduledTransferExecutedEvent)
+
+	h.logger.Info("📅 scheduled transfer executed event received",
+		zap.String("schedule_id", event.ScheduleID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("transaction_id", event.TransactionID.String()),
+		zap.String("kind", event.Kind),
+		zap.String("amount", event.Amount.String()),
+	)
+
+	// Lógica de notificação: comprovante da execução agendada para o cliente
+
+	return nil
+}
+
+// OnScheduledTransferFailed processa falhas de execuções agendadas
+func (h *EventHandlers) OnScheduledTransferFailed(ctx context.Context, e events.Event) error {
+	event := e.(events.ScheduledTransferFailedEvent)
+
+	h.logger.Warn("📅 scheduled transfer failed event received",
+		zap.String("schedule_id", event.ScheduleID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("kind", event.Kind),
+		zap.Int("attempt", event.Attempt),
+		zap.String("reason", event.Reason),
+		zap.Bool("will_retry", event.RetryAt != nil),
+	)
+
+	// Lógica de suporte: acompanhar agendamentos que falham com frequência
+
+	return nil
+}
+
+// OnTransactionReversed processa estornos de transações
+func (h *EventHandlers) OnTransactionReversed(ctx context.Context, e events.Event) error {
+	event := e.(events.TransactionReversedEvent)
+
+	h.logger.Info("↩️ transaction reversed event received",
+		zap.String("original_id", event.OriginalID.String()),
+		zap.String("reversal_tx_id", event.TransactionID.String()),
+		zap.String("original_type", event.OriginalType),
+		zap.String("amount", event.Amount.String()),
+		zap.String("reason", event.Reason),
+		zap.Bool("full", event.Full),
+	)
+
+	// Lógica de notificação: avisar o cliente sobre o estorno e atualizar a conciliação
+
+	return nil
+}
+
+// OnEscrowFunded processa depósitos em custódia
+func (h *EventHandlers) OnEscrowFunded(ctx context.Context, e events.Event) error {
+	event := e.(events.EscrowFundedEvent)
+
+	h.logger.Info("🔒 escrow funded event received",
+		zap.String("escrow_id", event.EscrowID.String()),
+		zap.String("buyer_id", event.BuyerID.String()),
+		zap.String("seller_id", event.SellerID.String()),
+		zap.String("amount", event.Amount.String()),
+		zap.Time("deadline", event.Deadline),
+	)
+
+	// Lógica de notificação: avisar o vendedor que o valor está garantido
+
+	return nil
+}
+
+// OnEscrowDisputed processa disputas de custódia
+func (h *EventHandlers) OnEscrowDisputed(ctx context.Context, e events.Event) error {
+	event := e.(events.EscrowDisputedEvent)
+
+	h.logger.Warn("⚖️ escrow disputed event received",
+		zap.String("escrow_id", event.EscrowID.String()),
+		zap.String("disputed_by", event.DisputedBy.String()),
+		zap.Bool("has_arbiter", event.ArbiterID != nil),
+		zap.String("reason", event.Reason),
+	)
+
+	// Lógica de suporte: acionar o árbitro ou a fila de operadores
+
+	return nil
+}
+
+// OnEscrowSettled processa o encerramento de custódias
+func (h *EventHandlers) OnEscrowSettled(ctx context.Context, e events.Event) error {
+	event := e.(events.EscrowSettledEvent)
+
+	h.logger.Info("🔓 escrow settled event received",
+		zap.String("escrow_id", event.EscrowID.String()),
+		zap.String("status", event.Status),
+		zap.String("trigger", event.Trigger),
+		zap.String("released_amount", event.ReleasedAmount.String()),
+		zap.String("refunded_amount", event.RefundedAmount.String()),
+	)
+
+	// Lógica de notificação: avisar comprador e vendedor do resultado
+
+	return nil
+}
+
+// OnPayoutBatchCompleted processa a conclusão de lotes de pagamentos
+func (h *EventHandlers) OnPayoutBatchCompleted(ctx context.Context, e events.Event) error {
+	event := e.(events.PayoutBatchCompletedEvent)
+
+	h.logger.Info("📦 payout batch completed event received",
+		zap.String("batch_id", event.BatchID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("status", event.Status),
+		zap.Int("succeeded", event.Succeeded),
+		zap.Int("failed", event.Failed),
+	)
+
+	// Lógica de notificação: avisar o usuário de que o relatório do lote está disponível
+
+	return nil
+}
+
+// OnPixCreditReceived processa depósitos recebidos por Pix
+func (h *EventHandlers) OnPixCreditReceived(ctx context.Context, e events.Event) error {
+	event := e.(events.PixCreditReceivedEvent)
+
+	h.logger.Info("💸 pix credit received event received",
+		zap.String("credit_id", event.CreditID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("end_to_end_id", event.EndToEndID),
+		zap.String("amount", event.Amount.String()),
+	)
+
+	// Lógica de notificação: avisar o usuário de que o depósito Pix foi creditado
+
+	return nil
+}
+
+// OnStatementGenerated processa a geração de extratos mensais
+func (h *EventHandlers) OnStatementGenerated(ctx context.Context, e events.Event) error {
+	event := e.(events.StatementGeneratedEvent)
+
+	h.logger.Info("🧾 statement generated event received",
+		zap.String("statement_id", event.StatementID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("period", event.Period),
+		zap.Int("lines", event.LineCount),
+	)
+
+	// Lógica de notificação: avisar o usuário de que o extrato do mês está disponível
+
+	return nil
+}
+
+// OnReconciliationBreaksDetected processa as quebras encontradas na conciliação diária
+func (h *EventHandlers) OnReconciliationBreaksDetected(ctx context.Context, e events.Event) error {
+	event := e.(events.ReconciliationBreaksDetectedEvent)
+
+	h.logger.Warn("🚨 reconciliation breaks detected",
+		zap.String("run_id", event.RunID.String()),
+		zap.String("run_date", event.RunDate),
+		zap.Int("breaks", event.BreakCount),
+		zap.Any("by_category", event.ByCategory),
+	)
+
+	// Lógica de notificação: alertar tesouraria e operações para investigar as quebras
+
+	return nil
+}
+
 // OnUserCreated processa eventos de criação de usuário
 func (h *EventHandlers) OnUserCreated(ctx context.Context, e events.Event) error {
 	event := e.(events.UserCreatedEvent)
@@ -155,6 +422,141 @@ func (h *EventHandlers) OnUserAuthenticated(ctx context.Context, e events.Event)
 	return nil
 }
 
+// OnUserLocked processa bloqueios de login por excesso de falhas
+func (h *EventHandlers) OnUserLocked(ctx context.Context, e events.Event) error {
+	event := e.(events.UserLockedEvent)
+
+	h.logger.Warn("🔒 user locked event received",
+		zap.String("user_id", event.UserID.String()),
+		zap.String("scope", event.Scope),
+		zap.String("ip_address", event.IPAddress),
+		zap.Int("failures", event.Failures),
+		zap.Time("locked_until", event.LockedUntil),
+	)
+
+	// Registrar métrica
+	metrics.RecordUserLockout(event.Scope)
+
+	return nil
+}
+
+// OnKYCReviewed processa a revisão de submissões KYC pelos operadores
+func (h *EventHandlers) OnKYCReviewed(ctx context.Context, e events.Event) error {
+	event := e.(events.KYCReviewedEvent)
+
+	h.logger.Info("🪪 kyc reviewed event received",
+		zap.String("user_id", event.UserID.String()),
+		zap.String("submission_id", event.SubmissionID.String()),
+		zap.String("reviewer_id", event.ReviewerID.String()),
+		zap.String("status", event.Status),
+		zap.String("previous_tier", event.PreviousTier),
+		zap.String("current_tier", event.CurrentTier),
+	)
+
+	// Lógica pós-revisão:
+	// - Notificar usuário do resultado
+	// - Registrar para auditoria de compliance
+
+	return nil
+}
+
+// OnUserErased processa a eliminação de dados pessoais (LGPD)
+func (h *EventHandlers) OnUserErased(ctx context.Context, e events.Event) error {
+	event := e.(events.UserErasedEvent)
+
+	h.logger.Info("🧹 user erased event received",
+		zap.String("user_id", event.UserID.String()),
+		zap.String("requested_by", event.RequestedBy.String()),
+		zap.Time("erased_at", event.ErasedAt),
+	)
+
+	// Lógica pós-eliminação:
+	// - Remover dados pessoais de caches e sistemas externos (CRM, email marketing)
+
+	return nil
+}
+
+// OnWithdrawalAddressConfirmed processa a confirmação de um novo endereço de saque
+func (h *EventHandlers) OnWithdrawalAddressConfirmed(ctx context.Context, e events.Event) error {
+	event := e.(events.WithdrawalAddressConfirmedEvent)
+
+	h.logger.Info("📒 withdrawal address confirmed event received",
+		zap.String("user_id", event.UserID.String()),
+		zap.String("address_id", event.AddressID.String()),
+		zap.String("chain", event.Chain),
+		zap.Time("available_at", event.AvailableAt),
+	)
+
+	// Lógica pós-confirmação:
+	// - Avisar o titular por outro canal que um endereço foi adicionado
+
+	return nil
+}
+
+// OnAccountTransferCompleted processa transferências entre contas nomeadas de um tenant
+func (h *EventHandlers) OnAccountTransferCompleted(ctx context.Context, e events.Event) error {
+	event := e.(events.AccountTransferCompletedEvent)
+
+	h.logger.Info("🔁 account transfer completed event received",
+		zap.String("tenant_id", event.TenantID.String()),
+		zap.String("transfer_id", event.TransferID.String()),
+		zap.String("from_account_id", event.FromAccountID.String()),
+		zap.String("to_account_id", event.ToAccountID.String()),
+		zap.String("amount", event.Amount.String()),
+		zap.String("currency", event.Currency),
+	)
+
+	return nil
+}
+
+// OnAccountWalletMovement processa aportes e resgates entre a wallet e uma conta nomeada
+func (h *EventHandlers) OnAccountWalletMovement(ctx context.Context, e events.Event) error {
+	event := e.(events.AccountWalletMovementEvent)
+
+	h.logger.Info("🏦 account wallet movement event received",
+		zap.String("tenant_id", event.TenantID.String()),
+		zap.String("account_id", event.AccountID.String()),
+		zap.String("direction", event.Direction),
+		zap.String("transaction_id", event.TransactionID.String()),
+		zap.String("amount", event.Amount.String()),
+	)
+
+	return nil
+}
+
+// OnRiskCaseOpened processa eventos de abertura de caso de fraude/AML
+func (h *EventHandlers) OnRiskCaseOpened(ctx context.Context, e events.Event) error {
+	event := e.(events.RiskCaseOpenedEvent)
+
+	h.logger.Warn("🚨 risk case opened event received",
+		zap.String("case_id", event.CaseID.String()),
+		zap.String("user_id", event.UserID.String()),
+		zap.String("transaction_id", event.TransactionID.String()),
+		zap.Int("score", event.Score),
+		zap.Bool("blocked", event.Blocked),
+		zap.Strings("rules", event.RuleIDs),
+	)
+
+	return nil
+}
+
+// OnComplianceHit processa eventos de coincidência com listas de sanções/bloqueio
+func (h *EventHandlers) OnComplianceHit(ctx context.Context, e events.Event) error {
+	event := e.(events.ComplianceHitEvent)
+
+	h.logger.Warn("⛔ compliance hit event received",
+		zap.String("screening_id", event.ScreeningID.String()),
+		zap.String("direction", event.Direction),
+		zap.String("chain", event.Chain),
+		zap.String("address", event.Address),
+		zap.String("decision", event.Decision),
+		zap.String("list_version", event.ListVersion),
+		zap.Strings("matches", event.Matches),
+	)
+
+	return nil
+}
+
 // OnWalletCreated processa eventos de criação de carteira
 func (h *EventHandlers) OnWalletCreated(ctx context.Context, e events.Event) error {
 	event := e.(events.WalletCreatedEvent)
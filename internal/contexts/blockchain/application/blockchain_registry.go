@@ -1,11 +1,31 @@
 package application
 
 import (
+	"context"
 	"financial-system-pro/internal/contexts/blockchain/domain"
 	entity "financial-system-pro/internal/contexts/blockchain/domain/entity"
 	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
+// nativeDecimals casas decimais da unidade base de cada ativo nativo (wei, satoshi, sun, lamport)
+var nativeDecimals = map[string]int32{
+	"ETH": 18,
+	"BTC": 8,
+	"TRX": 6,
+	"SOL": 9,
+}
+
+// nativeAssets ativo nativo de cada chain, em que os saldos dos endereços são denominados
+var nativeAssets = map[entity.BlockchainType]string{
+	entity.BlockchainEthereum: "ETH",
+	entity.BlockchainBitcoin:  "BTC",
+	entity.BlockchainTron:     "TRX",
+	entity.BlockchainSolana:   "SOL",
+}
+
 // BlockchainRegistry provê lookup de gateways multi-chain.
 // Mantido simples (in-memory) para evolução posterior com carregamento dinâmico de config.
 type BlockchainRegistry struct {
@@ -49,3 +69,56 @@ func (r *BlockchainRegistry) Has(chain entity.BlockchainType) bool {
 	_, ok := r.gateways[chain]
 	return ok
 }
+
+// ValidateAddress valida o endereço com o gateway da chain; erro se a chain não tiver gateway.
+func (r *BlockchainRegistry) ValidateAddress(chain, address string) (bool, error) {
+	gw, err := r.Get(entity.BlockchainType(chain))
+	if err != nil {
+		return false, err
+	}
+	return gw.ValidateAddress(address), nil
+}
+
+// EstimateNetworkFee estima a taxa de rede do envio e converte da unidade base para o ativo nativo.
+func (r *BlockchainRegistry) EstimateNetworkFee(ctx context.Context, chain, fromAddress, toAddress string) (decimal.Decimal, string, error) {
+	gw, err := r.Get(entity.BlockchainType(chain))
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+	quote, err := gw.EstimateFee(ctx, fromAddress, toAddress, 0)
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+	asset := strings.ToUpper(quote.FeeAsset)
+	decimals, ok := nativeDecimals[asset]
+	if !ok {
+		return decimal.Zero, "", fmt.Errorf("casas decimais desconhecidas para o ativo: %s", quote.FeeAsset)
+	}
+	return decimal.New(quote.EstimatedFee, -decimals), asset, nil
+}
+
+// NativeAsset retorna o ativo nativo da chain (ex.: ethereum -> ETH).
+func (r *BlockchainRegistry) NativeAsset(chain string) (string, error) {
+	asset, ok := nativeAssets[entity.BlockchainType(chain)]
+	if !ok {
+		return "", fmt.Errorf("ativo nativo desconhecido para chain: %s", chain)
+	}
+	return asset, nil
+}
+
+// GetBalance consulta o saldo do endereço e converte da unidade base para o ativo nativo.
+func (r *BlockchainRegistry) GetBalance(ctx context.Context, chain, address string) (decimal.Decimal, error) {
+	asset, err := r.NativeAsset(chain)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	gw, err := r.Get(entity.BlockchainType(chain))
+	if err != nil {
+		return decimal.Zero, err
+	}
+	balance, err := gw.GetBalance(ctx, address)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.New(balance, -nativeDecimals[asset]), nil
+}
@@ -67,3 +67,14 @@ func TestBlockchainRegistry_Override(t *testing.T) {
 	require.NoError(t, sErr)
 	require.Equal(t, domain.TxStatusConfirmed, status.Status)
 }
+
+func TestBlockchainRegistry_EstimateNetworkFee(t *testing.T) {
+	reg := NewBlockchainRegistry(&mockGateway{chain: entity.BlockchainTron})
+	fee, asset, err := reg.EstimateNetworkFee(context.Background(), "tron", "TFrom", "TTo")
+	require.NoError(t, err)
+	require.Equal(t, "TRX", asset)
+	require.Equal(t, "0.025", fee.String())
+
+	_, _, err = reg.EstimateNetworkFee(context.Background(), "bitcoin", "a", "b")
+	require.Error(t, err)
+}
@@ -9,6 +9,8 @@ import (
 	bcdom "financial-system-pro/internal/contexts/blockchain/domain"
 	entity "financial-system-pro/internal/contexts/blockchain/domain/entity"
 	repo "financial-system-pro/internal/contexts/blockchain/domain/repository"
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
 	"financial-system-pro/internal/shared/events"
 
 	"github.com/shopspring/decimal"
@@ -19,12 +21,19 @@ type UseCases struct {
 	registry *app.BlockchainRegistry
 	repo     repo.BlockchainTransactionRepository
 	bus      events.Bus
+	screener *complianceSvc.SanctionsScreener
 }
 
 func NewUseCases(reg *app.BlockchainRegistry, r repo.BlockchainTransactionRepository, bus events.Bus) *UseCases {
 	return &UseCases{registry: reg, repo: r, bus: bus}
 }
 
+// WithScreening enables sanctions screening of withdrawal destinations and deposit sources.
+func (u *UseCases) WithScreening(screener *complianceSvc.SanctionsScreener) *UseCases {
+	u.screener = screener
+	return u
+}
+
 // FetchBalance returns the balance for address on chain in base units.
 func (u *UseCases) FetchBalance(ctx context.Context, chain entity.BlockchainType, address string) (int64, error) {
 	gw, err := u.registry.Get(chain)
@@ -48,6 +57,16 @@ func (u *UseCases) SendTransaction(ctx context.Context, chain entity.BlockchainT
 	if amountBaseUnit <= 0 {
 		return "", errors.New("amount must be positive")
 	}
+	if u.screener != nil {
+		// Blocked destinations (or an unavailable list) never reach the network
+		if _, err := u.screener.Screen(ctx, complianceSvc.ScreeningRequest{
+			Direction: complianceEntity.DirectionOutbound,
+			Chain:     string(chain),
+			Address:   to,
+		}); err != nil {
+			return "", err
+		}
+	}
 	hash, err := gw.Broadcast(ctx, from, to, amountBaseUnit, privateKey)
 	if err != nil {
 		return "", err
@@ -65,6 +84,34 @@ func (u *UseCases) SendTransaction(ctx context.Context, chain entity.BlockchainT
 	return string(hash), nil
 }
 
+// MonitorDeposits watches incoming transactions to address, screening each source before
+// persisting it and publishing tx.new. Inbound funds cannot be refused on-chain, so hits are
+// recorded and surfaced via compliance.hit for the credit to be held. Blocks until ctx ends.
+func (u *UseCases) MonitorDeposits(ctx context.Context, chain entity.BlockchainType, address string) error {
+	gw, err := u.registry.Get(chain)
+	if err != nil {
+		return err
+	}
+	return gw.SubscribeNewTransactions(ctx, address, func(tx *entity.BlockchainTransaction) error {
+		if tx.ToAddress != address || tx.FromAddress == address {
+			return nil
+		}
+		if u.screener != nil {
+			// Errors are already logged and recorded by the screener; detection must go on
+			_, _ = u.screener.Screen(ctx, complianceSvc.ScreeningRequest{
+				Direction: complianceEntity.DirectionInbound,
+				Chain:     string(chain),
+				Address:   tx.FromAddress,
+				Reference: tx.TransactionHash,
+			})
+		}
+		_ = u.repo.Create(ctx, tx)
+		amount := tx.Amount.IntPart()
+		_ = u.bus.Publish(ctx, events.NewNewTransactionDetectedEvent(string(chain), tx.TransactionHash, tx.FromAddress, tx.ToAddress, amount, tx.BlockNumber))
+		return nil
+	})
+}
+
 // GetTransactionStatus queries gateway and updates repository, publishing confirmation event when confirmed.
 func (u *UseCases) GetTransactionStatus(ctx context.Context, chain entity.BlockchainType, txHash string) (*bcdom.TxStatusInfo, error) {
 	gw, err := u.registry.Get(chain)
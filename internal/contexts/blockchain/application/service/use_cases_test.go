@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	app "financial-system-pro/internal/contexts/blockchain/application"
 	entity "financial-system-pro/internal/contexts/blockchain/domain/entity"
 	repo "financial-system-pro/internal/contexts/blockchain/domain/repository"
 	"financial-system-pro/internal/contexts/blockchain/infrastructure/gateway"
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
 	"financial-system-pro/internal/shared/events"
 
 	"github.com/google/uuid"
@@ -73,3 +77,49 @@ func TestUseCases_Flow(t *testing.T) {
 		t.Fatalf("expected block.new event")
 	}
 }
+
+type stubSanctionsSource struct {
+	list *complianceEntity.SanctionsList
+}
+
+func (s stubSanctionsSource) Load(ctx context.Context) (*complianceEntity.SanctionsList, error) {
+	return s.list, nil
+}
+
+type nopScreeningRepo struct{}
+
+func (nopScreeningRepo) Save(ctx context.Context, _ *complianceEntity.ScreeningResult) error {
+	return nil
+}
+func (nopScreeningRepo) List(ctx context.Context, _ complianceEntity.ScreeningDecision, _ int) ([]*complianceEntity.ScreeningResult, error) {
+	return nil, nil
+}
+
+func TestUseCases_SendTransactionBlocksSanctionedDestination(t *testing.T) {
+	reg := app.NewBlockchainRegistry(gateway.NewETHGatewayFromEnv())
+	bus := events.NewInMemoryBus(zap.NewNop())
+	var newTxCount int32
+	bus.Subscribe("tx.new", func(ctx context.Context, e events.Event) error { atomic.AddInt32(&newTxCount, 1); return nil })
+
+	w, _ := gateway.NewETHGatewayFromEnv().GenerateWallet(context.Background())
+	sanctioned, _ := gateway.NewETHGatewayFromEnv().GenerateWallet(context.Background())
+	screener := complianceSvc.NewSanctionsScreener(stubSanctionsSource{list: complianceEntity.NewSanctionsList("v1", time.Now(), nil, []complianceEntity.SanctionsEntry{
+		{Source: "ofac_sdn", Name: "Sanctioned", Chain: "ETH", Address: sanctioned.Address},
+	})}, nopScreeningRepo{}, bus, zap.NewNop())
+	if err := screener.Reload(context.Background()); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	uc := NewUseCases(reg, &fakeRepo{}, bus).WithScreening(screener)
+
+	_, err := uc.SendTransaction(context.Background(), entity.BlockchainEthereum, w.Address, sanctioned.Address, 100, w.PrivateKey)
+	if !errors.Is(err, complianceSvc.ErrSanctionedAddress) {
+		t.Fatalf("expected sanctions block, got %v", err)
+	}
+	if atomic.LoadInt32(&newTxCount) != 0 {
+		t.Fatal("blocked transaction must not be broadcast")
+	}
+
+	if _, err := uc.SendTransaction(context.Background(), entity.BlockchainEthereum, w.Address, w.Address, 100, w.PrivateKey); err != nil {
+		t.Fatalf("clean destination should pass: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+)
+
+var (
+	ErrTransactionBlocked = errors.New("transaction blocked by risk rules")
+	ErrCaseNotFound       = errors.New("case not found")
+
+	ErrSanctionedAddress        = errors.New("address matches sanctions list")
+	ErrSanctionsListUnavailable = errors.New("sanctions list not loaded")
+)
+
+// BlockedError detalha as regras que bloquearam a transação
+type BlockedError struct {
+	Assessment *entity.Assessment
+}
+
+func (e *BlockedError) Error() string {
+	return ErrTransactionBlocked.Error() + ": " + strings.Join(e.Assessment.Reasons(), "; ")
+}
+
+func (e *BlockedError) Unwrap() error { return ErrTransactionBlocked }
+
+// SanctionsHitError detalha a triagem que bloqueou o endereço
+type SanctionsHitError struct {
+	Result *entity.ScreeningResult
+}
+
+func (e *SanctionsHitError) Error() string {
+	return ErrSanctionedAddress.Error() + ": " + strings.Join(e.Result.MatchLabels(), "; ") + " (list " + e.Result.ListVersion + ")"
+}
+
+func (e *SanctionsHitError) Unwrap() error { return ErrSanctionedAddress }
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/contexts/compliance/domain/repository"
+	domainSvc "financial-system-pro/internal/contexts/compliance/domain/service"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/metrics"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultAlertThreshold score a partir do qual um caso é aberto para análise
+const DefaultAlertThreshold = 60
+
+// SignalProvider fornece o histórico e os sinais comportamentais do usuário
+type SignalProvider interface {
+	// Activity lista as movimentações do usuário desde o instante informado
+	Activity(ctx context.Context, userID uuid.UUID, since time.Time) ([]entity.Activity, error)
+	// NewDeviceAt retorna quando o dispositivo mais recente foi visto pela primeira vez, se ele for novo
+	NewDeviceAt(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	// SubjectByHash reconstrói o sujeito a partir do hash publicado nos eventos de conclusão
+	SubjectByHash(ctx context.Context, txHash string) (*entity.Subject, error)
+}
+
+// MonitoringService aplica as regras de fraude/AML: síncronas antes da execução
+// (podem bloquear) e assíncronas após a conclusão via event bus (geram casos)
+type MonitoringService struct {
+	engine         *domainSvc.RuleEngine
+	signals        SignalProvider
+	assessments    repository.AssessmentRepository
+	cases          repository.CaseRepository
+	eventBus       events.Bus
+	logger         *zap.Logger
+	alertThreshold int
+	now            func() time.Time
+}
+
+// NewMonitoringService cria o serviço de monitoramento
+func NewMonitoringService(
+	engine *domainSvc.RuleEngine,
+	signals SignalProvider,
+	assessments repository.AssessmentRepository,
+	cases repository.CaseRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *MonitoringService {
+	return &MonitoringService{
+		engine:         engine,
+		signals:        signals,
+		assessments:    assessments,
+		cases:          cases,
+		eventBus:       eventBus,
+		logger:         logger,
+		alertThreshold: DefaultAlertThreshold,
+		now:            time.Now,
+	}
+}
+
+// WithAlertThreshold define o score que abre caso para análise
+func (s *MonitoringService) WithAlertThreshold(score int) *MonitoringService {
+	if score > 0 {
+		s.alertThreshold = score
+	}
+	return s
+}
+
+// Rules retorna as regras ativas
+func (s *MonitoringService) Rules() []entity.RuleDefinition {
+	return s.engine.Rules()
+}
+
+// Screen avalia as regras síncronas antes da execução. Retorna a avaliação (com o score)
+// e *BlockedError quando alguma regra bloqueante disparou. Falhas ao carregar sinais não bloqueiam.
+func (s *MonitoringService) Screen(ctx context.Context, subject entity.Subject) (*entity.Assessment, error) {
+	assessment := s.evaluate(ctx, entity.RuleModeSync, entity.PhasePreExecution, subject)
+
+	if assessment.Blocked {
+		metrics.RecordRiskBlocked(subject.Type)
+		s.logger.Warn("transaction blocked by risk rules",
+			zap.String("transaction_id", subject.TransactionID.String()),
+			zap.String("user_id", subject.UserID.String()),
+			zap.Strings("reasons", assessment.Reasons()),
+		)
+		return assessment, &BlockedError{Assessment: assessment}
+	}
+	return assessment, nil
+}
+
+// Subscribe registra a avaliação assíncrona nos eventos de conclusão de transações
+func (s *MonitoringService) Subscribe(bus events.Bus) {
+	for _, eventType := range []string{"deposit.completed", "withdraw.completed", "transfer.completed"} {
+		bus.Subscribe(eventType, s.onTransactionCompleted)
+	}
+}
+
+func (s *MonitoringService) onTransactionCompleted(ctx context.Context, e events.Event) error {
+	var txHash string
+	switch ev := e.(type) {
+	case events.DepositCompletedEvent:
+		txHash = ev.TxHash
+	case events.WithdrawCompletedEvent:
+		txHash = ev.TxHash
+	case events.TransferCompletedEvent:
+		txHash = ev.TxHash
+	default:
+		return nil
+	}
+
+	subject, err := s.signals.SubjectByHash(ctx, txHash)
+	if err != nil || subject == nil {
+		s.logger.Warn("risk monitoring: transaction not found for event", zap.String("tx_hash", txHash), zap.Error(err))
+		return err
+	}
+	s.EvaluateCompleted(ctx, *subject)
+	return nil
+}
+
+// EvaluateCompleted aplica as regras assíncronas a uma transação concluída
+func (s *MonitoringService) EvaluateCompleted(ctx context.Context, subject entity.Subject) *entity.Assessment {
+	return s.evaluate(ctx, entity.RuleModeAsync, entity.PhasePostExecution, subject)
+}
+
+func (s *MonitoringService) evaluate(ctx context.Context, mode entity.RuleMode, phase entity.AssessmentPhase, subject entity.Subject) *entity.Assessment {
+	if subject.OccurredAt.IsZero() {
+		subject.OccurredAt = s.now()
+	}
+
+	signals := entity.Signals{}
+	if lookback := s.engine.Lookback(mode); lookback > 0 {
+		activity, err := s.signals.Activity(ctx, subject.UserID, subject.OccurredAt.Add(-lookback))
+		if err != nil {
+			s.logger.Error("risk monitoring: failed to load activity", zap.String("user_id", subject.UserID.String()), zap.Error(err))
+		}
+		signals.Activity = activity
+	}
+	if subject.IsOutflow() {
+		newDeviceAt, err := s.signals.NewDeviceAt(ctx, subject.UserID)
+		if err != nil {
+			s.logger.Error("risk monitoring: failed to load device signal", zap.String("user_id", subject.UserID.String()), zap.Error(err))
+		}
+		signals.NewDeviceAt = newDeviceAt
+	}
+
+	hits := s.engine.Evaluate(mode, subject, signals)
+	assessment := entity.NewAssessment(subject, phase, hits, s.now())
+	for _, h := range hits {
+		metrics.RecordRiskRuleHit(h.RuleID, string(mode))
+	}
+	if len(hits) == 0 {
+		return assessment
+	}
+
+	if err := s.assessments.Save(ctx, assessment); err != nil {
+		s.logger.Error("risk monitoring: failed to save assessment", zap.String("transaction_id", subject.TransactionID.String()), zap.Error(err))
+	}
+	if assessment.Blocked || assessment.Score >= s.alertThreshold {
+		s.openCase(ctx, assessment)
+	}
+	return assessment
+}
+
+func (s *MonitoringService) openCase(ctx context.Context, assessment *entity.Assessment) {
+	c := entity.NewCaseFromAssessment(assessment)
+	if err := s.cases.Create(ctx, c); err != nil {
+		s.logger.Error("risk monitoring: failed to open case", zap.String("transaction_id", assessment.TransactionID.String()), zap.Error(err))
+		return
+	}
+
+	ruleIDs := make([]string, 0, len(assessment.Hits))
+	for _, h := range assessment.Hits {
+		ruleIDs = append(ruleIDs, h.RuleID)
+	}
+	s.eventBus.PublishAsync(ctx, events.NewRiskCaseOpenedEvent(c.ID, c.UserID, c.TransactionID, c.Score, assessment.Blocked, ruleIDs))
+	s.logger.Warn("risk case opened",
+		zap.String("case_id", c.ID.String()),
+		zap.String("user_id", c.UserID.String()),
+		zap.Int("score", c.Score),
+		zap.Strings("rules", ruleIDs),
+	)
+}
+
+// Assessments lista as avaliações de uma transação
+func (s *MonitoringService) Assessments(ctx context.Context, transactionID uuid.UUID) ([]*entity.Assessment, error) {
+	return s.assessments.FindByTransactionID(ctx, transactionID)
+}
+
+// ListCases lista casos por status (vazio = todos)
+func (s *MonitoringService) ListCases(ctx context.Context, status entity.CaseStatus, limit int) ([]*entity.Case, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.cases.List(ctx, status, limit)
+}
+
+// GetCase retorna o caso com suas anotações
+func (s *MonitoringService) GetCase(ctx context.Context, id uuid.UUID) (*entity.Case, error) {
+	c, err := s.cases.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCaseNotFound
+	}
+	return c, nil
+}
+
+// AssignCase atribui o caso ao analista
+func (s *MonitoringService) AssignCase(ctx context.Context, id, analystID uuid.UUID) (*entity.Case, error) {
+	c, err := s.GetCase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Assign(analystID, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.cases.Update(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddCaseNote registra uma anotação do analista
+func (s *MonitoringService) AddCaseNote(ctx context.Context, id, authorID uuid.UUID, body string) (*entity.CaseNote, error) {
+	c, err := s.GetCase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	note, err := c.AddNote(authorID, body, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cases.AddNote(ctx, c.ID, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// CloseCase encerra o caso; a nota, se informada, é registrada antes do fechamento
+func (s *MonitoringService) CloseCase(ctx context.Context, id, analystID uuid.UUID, resolution entity.CaseResolution, note string) (*entity.Case, error) {
+	c, err := s.GetCase(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status == entity.CaseStatusClosed {
+		return nil, entity.ErrCaseClosed
+	}
+	if note != "" {
+		if _, err := s.AddCaseNote(ctx, id, analystID, note); err != nil && !errors.Is(err, entity.ErrEmptyNote) {
+			return nil, err
+		}
+	}
+	if c.AssigneeID == nil {
+		c.AssigneeID = &analystID
+	}
+	if err := c.Close(resolution, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.cases.Update(ctx, c); err != nil {
+		return nil, err
+	}
+	s.logger.Info("risk case closed", zap.String("case_id", c.ID.String()), zap.String("resolution", string(resolution)))
+	return c, nil
+}
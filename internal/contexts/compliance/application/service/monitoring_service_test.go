@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	domainSvc "financial-system-pro/internal/contexts/compliance/domain/service"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type stubSignals struct {
+	activity    []entity.Activity
+	newDeviceAt *time.Time
+	subjects    map[string]*entity.Subject
+}
+
+func (s *stubSignals) Activity(ctx context.Context, userID uuid.UUID, since time.Time) ([]entity.Activity, error) {
+	return s.activity, nil
+}
+func (s *stubSignals) NewDeviceAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	return s.newDeviceAt, nil
+}
+func (s *stubSignals) SubjectByHash(ctx context.Context, txHash string) (*entity.Subject, error) {
+	return s.subjects[txHash], nil
+}
+
+type inMemoryAssessments struct {
+	mu   sync.Mutex
+	list []*entity.Assessment
+}
+
+func (r *inMemoryAssessments) Save(ctx context.Context, a *entity.Assessment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.list = append(r.list, a)
+	return nil
+}
+func (r *inMemoryAssessments) FindByTransactionID(ctx context.Context, id uuid.UUID) ([]*entity.Assessment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*entity.Assessment
+	for _, a := range r.list {
+		if a.TransactionID == id {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type inMemoryCases struct {
+	mu    sync.Mutex
+	cases map[uuid.UUID]*entity.Case
+}
+
+func (r *inMemoryCases) Create(ctx context.Context, c *entity.Case) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cases[c.ID] = c
+	return nil
+}
+func (r *inMemoryCases) FindByID(ctx context.Context, id uuid.UUID) (*entity.Case, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cases[id], nil
+}
+func (r *inMemoryCases) List(ctx context.Context, status entity.CaseStatus, limit int) ([]*entity.Case, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*entity.Case
+	for _, c := range r.cases {
+		if status == "" || c.Status == status {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (r *inMemoryCases) Update(ctx context.Context, c *entity.Case) error { return nil }
+func (r *inMemoryCases) AddNote(ctx context.Context, caseID uuid.UUID, n *entity.CaseNote) error {
+	return nil
+}
+
+func newTestMonitor(signals *stubSignals, rules ...entity.RuleDefinition) (*MonitoringService, *inMemoryCases, events.Bus) {
+	bus := events.NewInMemoryBus(zap.NewNop())
+	cases := &inMemoryCases{cases: map[uuid.UUID]*entity.Case{}}
+	svc := NewMonitoringService(domainSvc.NewRuleEngine(rules), signals, &inMemoryAssessments{}, cases, bus, zap.NewNop())
+	return svc, cases, bus
+}
+
+func defaultRule(id string) entity.RuleDefinition {
+	for _, r := range entity.DefaultRuleDefinitions() {
+		if r.ID == id {
+			return r
+		}
+	}
+	panic("rule not found: " + id)
+}
+
+func TestMonitoringService_ScreenBlocksAndOpensCase(t *testing.T) {
+	now := time.Now()
+	velocity := defaultRule("outflow-velocity")
+	velocity.MaxCount = 1
+	signals := &stubSignals{activity: []entity.Activity{
+		{TransactionID: uuid.New(), Type: "withdraw", Amount: decimal.NewFromInt(10), OccurredAt: now.Add(-time.Minute)},
+	}}
+	svc, cases, bus := newTestMonitor(signals, velocity)
+
+	opened := make(chan events.RiskCaseOpenedEvent, 1)
+	bus.Subscribe("risk.case_opened", func(ctx context.Context, e events.Event) error {
+		opened <- e.(events.RiskCaseOpenedEvent)
+		return nil
+	})
+
+	subject := entity.Subject{TransactionID: uuid.New(), UserID: uuid.New(), Type: "withdraw", Amount: decimal.NewFromInt(10), OccurredAt: now}
+	assessment, err := svc.Screen(context.Background(), subject)
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, ErrTransactionBlocked) {
+		t.Fatalf("esperado BlockedError, obtido %v", err)
+	}
+	if assessment.Score != velocity.Score || len(cases.cases) != 1 {
+		t.Fatalf("score %d, casos %d", assessment.Score, len(cases.cases))
+	}
+
+	select {
+	case ev := <-opened:
+		if !ev.Blocked || ev.TransactionID != subject.TransactionID {
+			t.Fatalf("evento inesperado: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("evento risk.case_opened não publicado")
+	}
+
+	// Depósitos não são afetados pela regra de saída
+	subject.Type = "deposit"
+	if _, err := svc.Screen(context.Background(), subject); err != nil {
+		t.Fatalf("depósito não deveria ser bloqueado: %v", err)
+	}
+}
+
+func TestMonitoringService_AsyncEvaluationFromEvents(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+	subject := &entity.Subject{TransactionID: uuid.New(), UserID: userID, Type: "withdraw", Amount: decimal.NewFromInt(950), OccurredAt: now}
+	signals := &stubSignals{
+		activity: []entity.Activity{
+			{TransactionID: uuid.New(), Type: "deposit", Amount: decimal.NewFromInt(1000), OccurredAt: now.Add(-time.Hour)},
+		},
+		subjects: map[string]*entity.Subject{"withdraw-1": subject},
+	}
+	svc, cases, bus := newTestMonitor(signals, defaultRule("rapid-in-out"))
+	svc.WithAlertThreshold(30)
+	svc.Subscribe(bus)
+
+	if err := bus.Publish(context.Background(), events.NewWithdrawCompletedEvent(userID, subject.Amount, "withdraw-1")); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	list, _ := svc.Assessments(context.Background(), subject.TransactionID)
+	if len(list) != 1 || list[0].Phase != entity.PhasePostExecution || list[0].Blocked {
+		t.Fatalf("avaliação assíncrona inesperada: %+v", list)
+	}
+	open, _ := svc.ListCases(context.Background(), entity.CaseStatusOpen, 0)
+	if len(open) != 1 || len(cases.cases) != 1 {
+		t.Fatalf("esperado 1 caso aberto, obtido %d", len(open))
+	}
+
+	closed, err := svc.CloseCase(context.Background(), open[0].ID, uuid.New(), entity.ResolutionFalsePositive, "cliente conhecido")
+	if err != nil || closed.Status != entity.CaseStatusClosed || closed.AssigneeID == nil {
+		t.Fatalf("close: %v %+v", err, closed)
+	}
+	if _, err := svc.CloseCase(context.Background(), open[0].ID, uuid.New(), entity.ResolutionConfirmed, ""); !errors.Is(err, entity.ErrCaseClosed) {
+		t.Fatalf("esperado ErrCaseClosed, obtido %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/contexts/compliance/domain/repository"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/metrics"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultSanctionsReloadInterval intervalo padrão de recarga das listas
+const DefaultSanctionsReloadInterval = 6 * time.Hour
+
+// SanctionsListSource carrega a versão atual das listas de sanções/bloqueio
+type SanctionsListSource interface {
+	Load(ctx context.Context) (*entity.SanctionsList, error)
+}
+
+// ScreeningRequest endereço (e opcionalmente nome) a triar
+type ScreeningRequest struct {
+	UserID    *uuid.UUID
+	Direction entity.ScreeningDirection
+	Chain     string
+	Address   string
+	Name      string
+	Reference string
+}
+
+// SanctionsScreener triagem de endereços contra listas locais recarregadas periodicamente.
+// Cada decisão é registrada com a versão da lista usada.
+type SanctionsScreener struct {
+	source     SanctionsListSource
+	screenings repository.ScreeningRepository
+	eventBus   events.Bus
+	logger     *zap.Logger
+	list       atomic.Pointer[entity.SanctionsList]
+	now        func() time.Time
+}
+
+// NewSanctionsScreener cria o serviço de triagem; as listas são carregadas em Reload
+func NewSanctionsScreener(source SanctionsListSource, screenings repository.ScreeningRepository, eventBus events.Bus, logger *zap.Logger) *SanctionsScreener {
+	return &SanctionsScreener{
+		source:     source,
+		screenings: screenings,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Reload carrega as listas; em caso de falha a versão anterior continua em uso
+func (s *SanctionsScreener) Reload(ctx context.Context) error {
+	list, err := s.source.Load(ctx)
+	if err != nil {
+		s.logger.Error("sanctions list reload failed", zap.Error(err))
+		return err
+	}
+
+	previous := s.list.Swap(list)
+	metrics.SanctionsListEntries.Set(float64(list.AddressCount()))
+	if previous == nil || previous.Version != list.Version {
+		s.logger.Info("sanctions list loaded",
+			zap.String("version", list.Version),
+			zap.Strings("sources", list.Sources),
+			zap.Int("addresses", list.AddressCount()),
+			zap.Int("names", list.NameCount()),
+		)
+	}
+	return nil
+}
+
+// Run recarrega as listas a cada intervalo até o contexto ser cancelado
+func (s *SanctionsScreener) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSanctionsReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.Reload(ctx)
+		}
+	}
+}
+
+// List retorna a lista em uso (nil se nenhuma carga teve sucesso)
+func (s *SanctionsScreener) List() *entity.SanctionsList {
+	return s.list.Load()
+}
+
+// Check avalia endereço/nome sem registrar a decisão (consulta de operador)
+func (s *SanctionsScreener) Check(req ScreeningRequest) (*entity.ScreeningResult, error) {
+	list := s.list.Load()
+	if list == nil {
+		return nil, ErrSanctionsListUnavailable
+	}
+
+	addressMatches := list.MatchAddress(req.Address)
+	var nameMatches []entity.SanctionsEntry
+	if req.Name != "" {
+		nameMatches = list.MatchName(req.Name)
+	}
+
+	return &entity.ScreeningResult{
+		ID:          uuid.New(),
+		UserID:      req.UserID,
+		Direction:   req.Direction,
+		Chain:       req.Chain,
+		Address:     req.Address,
+		Name:        req.Name,
+		Reference:   req.Reference,
+		Decision:    entity.Decide(addressMatches, nameMatches),
+		Matches:     append(append([]entity.SanctionsEntry{}, addressMatches...), nameMatches...),
+		ListVersion: list.Version,
+		ScreenedAt:  s.now(),
+	}, nil
+}
+
+// Screen avalia e registra a decisão. Coincidências publicam compliance.hit; decisão de bloqueio
+// retorna *SanctionsHitError. Sem lista carregada retorna ErrSanctionsListUnavailable (fail-closed).
+func (s *SanctionsScreener) Screen(ctx context.Context, req ScreeningRequest) (*entity.ScreeningResult, error) {
+	result, err := s.Check(req)
+	if err != nil {
+		s.logger.Error("sanctions screening unavailable",
+			zap.String("direction", string(req.Direction)),
+			zap.String("address", req.Address),
+		)
+		return nil, err
+	}
+
+	metrics.RecordSanctionsScreening(string(result.Direction), string(result.Decision))
+	if err := s.screenings.Save(ctx, result); err != nil {
+		s.logger.Error("failed to record sanctions screening", zap.String("address", req.Address), zap.Error(err))
+	}
+
+	if !result.IsHit() {
+		return result, nil
+	}
+
+	userID := uuid.Nil
+	if result.UserID != nil {
+		userID = *result.UserID
+	}
+	s.eventBus.PublishAsync(ctx, events.NewComplianceHitEvent(
+		result.ID,
+		userID,
+		string(result.Direction),
+		result.Chain,
+		result.Address,
+		string(result.Decision),
+		result.ListVersion,
+		result.Reference,
+		result.MatchLabels(),
+	))
+	s.logger.Warn("sanctions screening hit",
+		zap.String("screening_id", result.ID.String()),
+		zap.String("direction", string(result.Direction)),
+		zap.String("address", result.Address),
+		zap.String("decision", string(result.Decision)),
+		zap.String("list_version", result.ListVersion),
+	)
+
+	if result.Decision == entity.DecisionBlock {
+		return result, &SanctionsHitError{Result: result}
+	}
+	return result, nil
+}
+
+// Screenings lista as decisões registradas
+func (s *SanctionsScreener) Screenings(ctx context.Context, decision entity.ScreeningDecision, limit int) ([]*entity.ScreeningResult, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.screenings.List(ctx, decision, limit)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"go.uber.org/zap"
+)
+
+type stubListSource struct {
+	list *entity.SanctionsList
+	err  error
+}
+
+func (s *stubListSource) Load(ctx context.Context) (*entity.SanctionsList, error) {
+	return s.list, s.err
+}
+
+type inMemoryScreenings struct {
+	mu   sync.Mutex
+	list []*entity.ScreeningResult
+}
+
+func (r *inMemoryScreenings) Save(ctx context.Context, s *entity.ScreeningResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.list = append(r.list, s)
+	return nil
+}
+func (r *inMemoryScreenings) List(ctx context.Context, decision entity.ScreeningDecision, limit int) ([]*entity.ScreeningResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*entity.ScreeningResult
+	for _, s := range r.list {
+		if decision == "" || s.Decision == decision {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func TestSanctionsScreener_BlocksListedAddress(t *testing.T) {
+	source := &stubListSource{list: entity.NewSanctionsList("v1", time.Now(), []string{"blocklist:test.csv"}, []entity.SanctionsEntry{
+		{Source: "ofac_sdn", Name: "Mixer", Chain: "ETH", Address: "0xAbC0000000000000000000000000000000000001", Action: entity.ActionBlock},
+		{Source: "blocklist", Name: "Exchange suspeita", Chain: "TRX", Address: "TFlagged000000000000000000000001", Action: entity.ActionFlag},
+	})}
+	repo := &inMemoryScreenings{}
+	bus := events.NewInMemoryBus(zap.NewNop())
+	hits := make(chan events.ComplianceHitEvent, 2)
+	bus.Subscribe("compliance.hit", func(ctx context.Context, e events.Event) error {
+		hits <- e.(events.ComplianceHitEvent)
+		return nil
+	})
+	screener := NewSanctionsScreener(source, repo, bus, zap.NewNop())
+	ctx := context.Background()
+
+	// Sem lista carregada a triagem falha fechada
+	if _, err := screener.Screen(ctx, ScreeningRequest{Direction: entity.DirectionOutbound, Address: "x"}); !errors.Is(err, ErrSanctionsListUnavailable) {
+		t.Fatalf("esperado ErrSanctionsListUnavailable, obtido %v", err)
+	}
+	if err := screener.Reload(ctx); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	result, err := screener.Screen(ctx, ScreeningRequest{Direction: entity.DirectionOutbound, Chain: "ethereum", Address: "0xabc0000000000000000000000000000000000001"})
+	var hitErr *SanctionsHitError
+	if !errors.As(err, &hitErr) || !errors.Is(err, ErrSanctionedAddress) || result.Decision != entity.DecisionBlock {
+		t.Fatalf("esperado bloqueio, obtido %v %+v", err, result)
+	}
+
+	result, err = screener.Screen(ctx, ScreeningRequest{Direction: entity.DirectionInbound, Address: "TFlagged000000000000000000000001", Reference: "0xhash"})
+	if err != nil || result.Decision != entity.DecisionFlag {
+		t.Fatalf("esperado flag sem erro, obtido %v %+v", err, result)
+	}
+	if result, err := screener.Screen(ctx, ScreeningRequest{Direction: entity.DirectionOutbound, Address: "TClean"}); err != nil || result.IsHit() {
+		t.Fatalf("endereço limpo: %v %+v", err, result)
+	}
+
+	// Toda decisão, inclusive clear, registra a versão da lista
+	if len(repo.list) != 3 {
+		t.Fatalf("esperado 3 triagens registradas, obtido %d", len(repo.list))
+	}
+	for _, s := range repo.list {
+		if s.ListVersion != "v1" {
+			t.Fatalf("versão da lista não registrada: %+v", s)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case ev := <-hits:
+			if ev.ListVersion != "v1" || ev.Decision == string(entity.DecisionClear) {
+				t.Fatalf("evento inesperado: %+v", ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("evento compliance.hit não publicado")
+		}
+	}
+
+	// Falha de recarga mantém a versão anterior
+	source.list, source.err = nil, errors.New("arquivo corrompido")
+	if err := screener.Reload(ctx); err == nil {
+		t.Fatal("reload deveria falhar")
+	}
+	if screener.List() == nil || screener.List().Version != "v1" {
+		t.Fatal("lista anterior deveria continuar em uso")
+	}
+}
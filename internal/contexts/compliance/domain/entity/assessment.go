@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MaxRiskScore teto do score de risco de uma transação
+const MaxRiskScore = 100
+
+// Subject transação sob avaliação
+type Subject struct {
+	TransactionID uuid.UUID
+	UserID        uuid.UUID
+	Type          string
+	Amount        decimal.Decimal
+	Destination   string
+	OccurredAt    time.Time
+}
+
+// IsOutflow indica saída de recursos (saque ou transferência)
+func (s Subject) IsOutflow() bool {
+	return s.Type == "withdraw" || s.Type == "transfer"
+}
+
+// Activity movimentação anterior do usuário usada como sinal pelas regras
+type Activity struct {
+	TransactionID uuid.UUID
+	Type          string
+	Amount        decimal.Decimal
+	Destination   string
+	Failed        bool
+	OccurredAt    time.Time
+}
+
+// Signals contexto comportamental do usuário no momento da avaliação
+type Signals struct {
+	Activity []Activity
+	// NewDeviceAt instante do primeiro uso do dispositivo mais recente, se ele for novo
+	NewDeviceAt *time.Time
+}
+
+// Hit regra disparada
+type Hit struct {
+	RuleID   string   `json:"rule_id"`
+	Kind     RuleKind `json:"kind"`
+	Score    int      `json:"score"`
+	Blocking bool     `json:"blocking"`
+	Reason   string   `json:"reason"`
+}
+
+// AssessmentPhase momento da avaliação
+type AssessmentPhase string
+
+const (
+	PhasePreExecution  AssessmentPhase = "pre_execution"
+	PhasePostExecution AssessmentPhase = "post_execution"
+)
+
+// Assessment resultado da avaliação de uma transação
+type Assessment struct {
+	ID            uuid.UUID
+	TransactionID uuid.UUID
+	UserID        uuid.UUID
+	Phase         AssessmentPhase
+	Score         int
+	Blocked       bool
+	Hits          []Hit
+	EvaluatedAt   time.Time
+}
+
+// NewAssessment consolida os hits: score somado com teto MaxRiskScore, bloqueio se algum hit bloqueia
+func NewAssessment(subject Subject, phase AssessmentPhase, hits []Hit, now time.Time) *Assessment {
+	a := &Assessment{
+		ID:            uuid.New(),
+		TransactionID: subject.TransactionID,
+		UserID:        subject.UserID,
+		Phase:         phase,
+		Hits:          hits,
+		EvaluatedAt:   now,
+	}
+	for _, h := range hits {
+		a.Score += h.Score
+		if h.Blocking {
+			a.Blocked = true
+		}
+	}
+	if a.Score > MaxRiskScore {
+		a.Score = MaxRiskScore
+	}
+	return a
+}
+
+// Reasons descrições dos hits, para mensagens de erro e alertas
+func (a *Assessment) Reasons() []string {
+	out := make([]string, 0, len(a.Hits))
+	for _, h := range a.Hits {
+		out = append(out, h.Reason)
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CaseStatus estado do caso de análise
+type CaseStatus string
+
+const (
+	CaseStatusOpen          CaseStatus = "open"
+	CaseStatusInvestigating CaseStatus = "investigating"
+	CaseStatusClosed        CaseStatus = "closed"
+)
+
+// CaseResolution conclusão do analista ao fechar o caso
+type CaseResolution string
+
+const (
+	ResolutionFalsePositive CaseResolution = "false_positive"
+	ResolutionConfirmed     CaseResolution = "confirmed_fraud"
+	ResolutionReported      CaseResolution = "reported" // comunicado ao COAF
+)
+
+var (
+	ErrCaseClosed        = errors.New("case already closed")
+	ErrInvalidResolution = errors.New("invalid case resolution")
+	ErrEmptyNote         = errors.New("note body is required")
+)
+
+// CaseNote anotação do analista
+type CaseNote struct {
+	ID        uuid.UUID `json:"id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Case caso aberto para análise a partir de uma avaliação de risco
+type Case struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	TransactionID uuid.UUID
+	AssessmentID  uuid.UUID
+	Source        string // ex: "rules", "sanctions"
+	Score         int
+	Hits          []Hit
+	Status        CaseStatus
+	AssigneeID    *uuid.UUID
+	Resolution    CaseResolution
+	Notes         []CaseNote
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ClosedAt      *time.Time
+}
+
+// NewCaseFromAssessment abre um caso para a avaliação
+func NewCaseFromAssessment(a *Assessment) *Case {
+	now := time.Now()
+	return &Case{
+		ID:            uuid.New(),
+		UserID:        a.UserID,
+		TransactionID: a.TransactionID,
+		AssessmentID:  a.ID,
+		Source:        "rules",
+		Score:         a.Score,
+		Hits:          a.Hits,
+		Status:        CaseStatusOpen,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Assign atribui o caso a um analista e o coloca em investigação
+func (c *Case) Assign(analystID uuid.UUID, now time.Time) error {
+	if c.Status == CaseStatusClosed {
+		return ErrCaseClosed
+	}
+	c.AssigneeID = &analystID
+	c.Status = CaseStatusInvestigating
+	c.UpdatedAt = now
+	return nil
+}
+
+// AddNote registra uma anotação; casos fechados continuam aceitando notas
+func (c *Case) AddNote(authorID uuid.UUID, body string, now time.Time) (*CaseNote, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyNote
+	}
+	note := CaseNote{ID: uuid.New(), AuthorID: authorID, Body: body, CreatedAt: now}
+	c.Notes = append(c.Notes, note)
+	c.UpdatedAt = now
+	return &note, nil
+}
+
+// Close encerra o caso com a conclusão do analista
+func (c *Case) Close(resolution CaseResolution, now time.Time) error {
+	if c.Status == CaseStatusClosed {
+		return ErrCaseClosed
+	}
+	switch resolution {
+	case ResolutionFalsePositive, ResolutionConfirmed, ResolutionReported:
+	default:
+		return ErrInvalidResolution
+	}
+	c.Status = CaseStatusClosed
+	c.Resolution = resolution
+	c.ClosedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
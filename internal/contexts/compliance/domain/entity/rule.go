@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleKind tipo de regra de monitoramento
+type RuleKind string
+
+const (
+	// RuleVelocity muitas movimentações do mesmo tipo em uma janela curta
+	RuleVelocity RuleKind = "velocity"
+	// RuleStructuring valores fracionados logo abaixo de um limite de reporte
+	RuleStructuring RuleKind = "structuring"
+	// RuleRapidInOut saída de quase tudo que entrou há pouco tempo
+	RuleRapidInOut RuleKind = "rapid_in_out"
+	// RuleNewDeviceWithdraw saída logo após login em dispositivo novo
+	RuleNewDeviceWithdraw RuleKind = "new_device_withdraw"
+	// RuleUnusualDestination valor relevante para destino nunca usado
+	RuleUnusualDestination RuleKind = "unusual_destination"
+)
+
+// RuleMode define quando a regra é avaliada
+type RuleMode string
+
+const (
+	// RuleModeSync avaliada antes da execução; pode bloquear a transação
+	RuleModeSync RuleMode = "sync"
+	// RuleModeAsync avaliada após a conclusão via event bus; apenas gera alertas
+	RuleModeAsync RuleMode = "async"
+)
+
+var ErrInvalidRule = errors.New("invalid rule definition")
+
+// Window duração serializada como texto ("15m", "24h")
+type Window time.Duration
+
+// UnmarshalJSON aceita durações no formato de time.ParseDuration
+func (w *Window) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*w = Window(d)
+	return nil
+}
+
+// MarshalJSON serializa no formato de time.Duration
+func (w Window) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(w).String())
+}
+
+// RuleDefinition configuração de uma regra. Os parâmetros usados dependem do tipo:
+//   - velocity: Window, MaxCount
+//   - structuring: Window, Threshold, Margin (fração abaixo do limite), MinCount
+//   - rapid_in_out: Window, Ratio (fração dos depósitos da janela)
+//   - new_device_withdraw: Window
+//   - unusual_destination: Window (histórico consultado), Threshold
+type RuleDefinition struct {
+	ID        string          `json:"id"`
+	Kind      RuleKind        `json:"kind"`
+	Mode      RuleMode        `json:"mode"`
+	Enabled   bool            `json:"enabled"`
+	Blocking  bool            `json:"blocking"`
+	Score     int             `json:"score"`
+	Types     []string        `json:"types,omitempty"` // vazio = todos os tipos
+	Window    Window          `json:"window"`
+	MaxCount  int             `json:"max_count,omitempty"`
+	MinCount  int             `json:"min_count,omitempty"`
+	Threshold decimal.Decimal `json:"threshold,omitempty"`
+	Margin    decimal.Decimal `json:"margin,omitempty"`
+	Ratio     decimal.Decimal `json:"ratio,omitempty"`
+}
+
+// AppliesTo indica se a regra se aplica ao tipo de transação
+func (d RuleDefinition) AppliesTo(txType string) bool {
+	if len(d.Types) == 0 {
+		return true
+	}
+	for _, t := range d.Types {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate verifica os parâmetros obrigatórios do tipo
+func (d RuleDefinition) Validate() error {
+	if d.ID == "" {
+		return fmt.Errorf("%w: id is required", ErrInvalidRule)
+	}
+	if d.Mode != RuleModeSync && d.Mode != RuleModeAsync {
+		return fmt.Errorf("%w: %s: mode must be sync or async", ErrInvalidRule, d.ID)
+	}
+	if d.Blocking && d.Mode != RuleModeSync {
+		return fmt.Errorf("%w: %s: only sync rules can block", ErrInvalidRule, d.ID)
+	}
+	if d.Score < 0 || d.Score > MaxRiskScore {
+		return fmt.Errorf("%w: %s: score must be between 0 and %d", ErrInvalidRule, d.ID, MaxRiskScore)
+	}
+	if d.Window <= 0 {
+		return fmt.Errorf("%w: %s: window is required", ErrInvalidRule, d.ID)
+	}
+
+	switch d.Kind {
+	case RuleVelocity:
+		if d.MaxCount <= 0 {
+			return fmt.Errorf("%w: %s: max_count is required", ErrInvalidRule, d.ID)
+		}
+	case RuleStructuring:
+		if !d.Threshold.IsPositive() || !d.Margin.IsPositive() || d.Margin.GreaterThanOrEqual(decimal.NewFromInt(1)) || d.MinCount <= 0 {
+			return fmt.Errorf("%w: %s: threshold, margin (0-1) and min_count are required", ErrInvalidRule, d.ID)
+		}
+	case RuleRapidInOut:
+		if !d.Ratio.IsPositive() {
+			return fmt.Errorf("%w: %s: ratio is required", ErrInvalidRule, d.ID)
+		}
+	case RuleNewDeviceWithdraw:
+	case RuleUnusualDestination:
+		if d.Threshold.IsNegative() {
+			return fmt.Errorf("%w: %s: threshold cannot be negative", ErrInvalidRule, d.ID)
+		}
+	default:
+		return fmt.Errorf("%w: %s: unknown kind %q", ErrInvalidRule, d.ID, d.Kind)
+	}
+	return nil
+}
+
+// LoadRuleDefinitions lê uma lista JSON de regras e valida cada uma
+func LoadRuleDefinitions(r io.Reader) ([]RuleDefinition, error) {
+	var defs []RuleDefinition
+	if err := json.NewDecoder(r).Decode(&defs); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	seen := make(map[string]struct{}, len(defs))
+	for _, d := range defs {
+		if err := d.Validate(); err != nil {
+			return nil, err
+		}
+		if _, dup := seen[d.ID]; dup {
+			return nil, fmt.Errorf("%w: duplicated id %s", ErrInvalidRule, d.ID)
+		}
+		seen[d.ID] = struct{}{}
+	}
+	return defs, nil
+}
+
+// DefaultRuleDefinitions regras padrão usadas quando nenhum arquivo é configurado (valores em BRL)
+func DefaultRuleDefinitions() []RuleDefinition {
+	outflows := []string{"withdraw", "transfer"}
+	return []RuleDefinition{
+		{ID: "outflow-velocity", Kind: RuleVelocity, Mode: RuleModeSync, Enabled: true, Blocking: true, Score: 50,
+			Types: outflows, Window: Window(time.Hour), MaxCount: 10},
+		{ID: "deposit-structuring", Kind: RuleStructuring, Mode: RuleModeAsync, Enabled: true, Score: 40,
+			Types: []string{"deposit"}, Window: Window(24 * time.Hour), Threshold: decimal.NewFromInt(10000), Margin: decimal.NewFromFloat(0.1), MinCount: 3},
+		{ID: "rapid-in-out", Kind: RuleRapidInOut, Mode: RuleModeAsync, Enabled: true, Score: 35,
+			Types: outflows, Window: Window(24 * time.Hour), Ratio: decimal.NewFromFloat(0.9)},
+		{ID: "new-device-withdraw", Kind: RuleNewDeviceWithdraw, Mode: RuleModeSync, Enabled: true, Score: 30,
+			Types: outflows, Window: Window(time.Hour)},
+		{ID: "unusual-destination", Kind: RuleUnusualDestination, Mode: RuleModeSync, Enabled: true, Score: 25,
+			Types: outflows, Window: Window(90 * 24 * time.Hour), Threshold: decimal.NewFromInt(5000)},
+	}
+}
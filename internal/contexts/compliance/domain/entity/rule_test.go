@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLoadRuleDefinitions(t *testing.T) {
+	rules, err := LoadRuleDefinitions(strings.NewReader(`[
+		{"id":"v1","kind":"velocity","mode":"sync","enabled":true,"blocking":true,"score":50,"window":"30m","max_count":5},
+		{"id":"s1","kind":"structuring","mode":"async","enabled":true,"score":40,"window":"24h","threshold":"10000","margin":"0.1","min_count":3}
+	]`))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(rules) != 2 || time.Duration(rules[0].Window) != 30*time.Minute || rules[1].Threshold.String() != "10000" {
+		t.Fatalf("regras carregadas incorretamente: %+v", rules)
+	}
+
+	invalid := []string{
+		`[{"id":"a","kind":"velocity","mode":"async","blocking":true,"score":10,"window":"1h","max_count":1}]`, // assíncrona bloqueante
+		`[{"id":"b","kind":"velocity","mode":"sync","score":10,"window":"1h"}]`,                                // sem max_count
+		`[{"id":"c","kind":"unknown","mode":"sync","score":10,"window":"1h"}]`,
+		`[{"id":"d","kind":"new_device_withdraw","mode":"sync","score":10,"window":"1h"},{"id":"d","kind":"new_device_withdraw","mode":"sync","score":10,"window":"1h"}]`,
+	}
+	for _, in := range invalid {
+		if _, err := LoadRuleDefinitions(strings.NewReader(in)); !errors.Is(err, ErrInvalidRule) {
+			t.Fatalf("esperado ErrInvalidRule para %s, obtido %v", in, err)
+		}
+	}
+
+	for _, r := range DefaultRuleDefinitions() {
+		if err := r.Validate(); err != nil {
+			t.Fatalf("regra padrão inválida: %v", err)
+		}
+	}
+}
+
+func TestCase_Lifecycle(t *testing.T) {
+	now := time.Now()
+	c := NewCaseFromAssessment(NewAssessment(Subject{TransactionID: uuid.New(), UserID: uuid.New(), Type: "withdraw"},
+		PhasePreExecution, []Hit{{RuleID: "r", Score: 80, Blocking: true}}, now))
+	if c.Status != CaseStatusOpen || c.Score != 80 {
+		t.Fatalf("caso inicial inesperado: %+v", c)
+	}
+
+	analyst := uuid.New()
+	if err := c.Assign(analyst, now); err != nil || c.Status != CaseStatusInvestigating {
+		t.Fatalf("assign: %v %s", err, c.Status)
+	}
+	if _, err := c.AddNote(analyst, "  ", now); !errors.Is(err, ErrEmptyNote) {
+		t.Fatalf("esperado ErrEmptyNote, obtido %v", err)
+	}
+	if err := c.Close("whatever", now); !errors.Is(err, ErrInvalidResolution) {
+		t.Fatalf("esperado ErrInvalidResolution, obtido %v", err)
+	}
+	if err := c.Close(ResolutionFalsePositive, now); err != nil || c.ClosedAt == nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := c.Assign(analyst, now); !errors.Is(err, ErrCaseClosed) {
+		t.Fatalf("esperado ErrCaseClosed, obtido %v", err)
+	}
+}
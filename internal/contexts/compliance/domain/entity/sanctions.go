@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// ScreeningAction ação aplicada quando um endereço casa com a lista
+type ScreeningAction string
+
+const (
+	ActionBlock ScreeningAction = "block"
+	ActionFlag  ScreeningAction = "flag"
+)
+
+// ScreeningDecision resultado da triagem
+type ScreeningDecision string
+
+const (
+	DecisionClear ScreeningDecision = "clear"
+	DecisionFlag  ScreeningDecision = "flag"
+	DecisionBlock ScreeningDecision = "block"
+)
+
+// ScreeningDirection sentido da movimentação triada
+type ScreeningDirection string
+
+const (
+	DirectionOutbound ScreeningDirection = "outbound" // destino de saque
+	DirectionInbound  ScreeningDirection = "inbound"  // origem de depósito detectado
+)
+
+// SanctionsEntry endereço ou nome listado
+type SanctionsEntry struct {
+	Source  string          `json:"source"` // ex: ofac_sdn, blocklist
+	EntryID string          `json:"entry_id,omitempty"`
+	Name    string          `json:"name"`
+	Program string          `json:"program,omitempty"`
+	Chain   string          `json:"chain,omitempty"` // código da lista (XBT, ETH, TRX...)
+	Address string          `json:"address,omitempty"`
+	Action  ScreeningAction `json:"action"`
+}
+
+// SanctionsList snapshot imutável das listas carregadas; Version identifica o conteúdo
+// exato usado em cada decisão
+type SanctionsList struct {
+	Version   string
+	LoadedAt  time.Time
+	Sources   []string
+	addresses map[string][]SanctionsEntry
+	names     map[string][]SanctionsEntry
+}
+
+// NewSanctionsList indexa as entradas por endereço normalizado e por nome normalizado
+func NewSanctionsList(version string, loadedAt time.Time, sources []string, entries []SanctionsEntry) *SanctionsList {
+	l := &SanctionsList{
+		Version:   version,
+		LoadedAt:  loadedAt,
+		Sources:   sources,
+		addresses: make(map[string][]SanctionsEntry),
+		names:     make(map[string][]SanctionsEntry),
+	}
+	for _, e := range entries {
+		if e.Action == "" {
+			e.Action = ActionBlock
+		}
+		if key := NormalizeAddress(e.Address); key != "" {
+			l.addresses[key] = append(l.addresses[key], e)
+		}
+		if key := NormalizeName(e.Name); key != "" {
+			l.names[key] = append(l.names[key], e)
+		}
+	}
+	return l
+}
+
+// AddressCount quantidade de endereços distintos listados
+func (l *SanctionsList) AddressCount() int { return len(l.addresses) }
+
+// NameCount quantidade de nomes distintos listados
+func (l *SanctionsList) NameCount() int { return len(l.names) }
+
+// MatchAddress retorna as entradas listadas para o endereço
+func (l *SanctionsList) MatchAddress(address string) []SanctionsEntry {
+	if l == nil {
+		return nil
+	}
+	return l.addresses[NormalizeAddress(address)]
+}
+
+// MatchName retorna as entradas cujo nome normalizado é idêntico ao informado
+func (l *SanctionsList) MatchName(name string) []SanctionsEntry {
+	if l == nil {
+		return nil
+	}
+	return l.names[NormalizeName(name)]
+}
+
+// NormalizeAddress remove espaços e ignora caixa em endereços EVM (0x) e bech32,
+// que não diferenciam maiúsculas; endereços base58 mantêm a caixa original
+func NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "0x") || strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") || strings.HasPrefix(lower, "ltc1") {
+		return lower
+	}
+	return address
+}
+
+// NormalizeName reduz o nome a palavras minúsculas separadas por um espaço, sem pontuação
+func NormalizeName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// ScreeningResult decisão registrada para um endereço, com a versão da lista usada
+type ScreeningResult struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      *uuid.UUID         `json:"user_id,omitempty"`
+	Direction   ScreeningDirection `json:"direction"`
+	Chain       string             `json:"chain"`
+	Address     string             `json:"address"`
+	Name        string             `json:"name,omitempty"`
+	Reference   string             `json:"reference,omitempty"` // hash ou id da transação
+	Decision    ScreeningDecision  `json:"decision"`
+	Matches     []SanctionsEntry   `json:"matches"`
+	ListVersion string             `json:"list_version"`
+	ScreenedAt  time.Time          `json:"screened_at"`
+}
+
+// Decide consolida as entradas encontradas: endereço com ação block bloqueia; demais casos
+// (incluindo coincidência apenas de nome) são sinalizados para revisão
+func Decide(addressMatches, nameMatches []SanctionsEntry) ScreeningDecision {
+	decision := DecisionClear
+	for _, e := range addressMatches {
+		if e.Action == ActionBlock {
+			return DecisionBlock
+		}
+		decision = DecisionFlag
+	}
+	if len(nameMatches) > 0 {
+		decision = DecisionFlag
+	}
+	return decision
+}
+
+// IsHit indica que a triagem encontrou alguma coincidência
+func (r *ScreeningResult) IsHit() bool {
+	return r.Decision != DecisionClear
+}
+
+// MatchLabels descreve as coincidências como "fonte:nome"
+func (r *ScreeningResult) MatchLabels() []string {
+	out := make([]string, 0, len(r.Matches))
+	for _, m := range r.Matches {
+		out = append(out, m.Source+":"+m.Name)
+	}
+	return out
+}
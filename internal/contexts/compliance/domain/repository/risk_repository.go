@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// AssessmentRepository persiste as avaliações de risco das transações
+type AssessmentRepository interface {
+	Save(ctx context.Context, assessment *entity.Assessment) error
+	FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*entity.Assessment, error)
+}
+
+// CaseRepository persiste os casos de análise e suas anotações
+type CaseRepository interface {
+	Create(ctx context.Context, c *entity.Case) error
+	// FindByID retorna nil, nil quando o caso não existe; inclui as anotações
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Case, error)
+	// List lista casos por status (vazio = todos), mais recentes primeiro
+	List(ctx context.Context, status entity.CaseStatus, limit int) ([]*entity.Case, error)
+	Update(ctx context.Context, c *entity.Case) error
+	AddNote(ctx context.Context, caseID uuid.UUID, note *entity.CaseNote) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+)
+
+// ScreeningRepository registra as decisões de triagem de sanções
+type ScreeningRepository interface {
+	Save(ctx context.Context, r *entity.ScreeningResult) error
+	// List retorna as triagens mais recentes; decisão vazia lista todas
+	List(ctx context.Context, decision entity.ScreeningDecision, limit int) ([]*entity.ScreeningResult, error)
+}
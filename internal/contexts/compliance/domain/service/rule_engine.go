@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleEngine avalia transações contra as regras configuradas.
+// É puro: os sinais (histórico, dispositivos) são fornecidos por quem chama.
+type RuleEngine struct {
+	rules []entity.RuleDefinition
+}
+
+// NewRuleEngine cria o motor com as regras informadas; regras desabilitadas são ignoradas
+func NewRuleEngine(rules []entity.RuleDefinition) *RuleEngine {
+	enabled := make([]entity.RuleDefinition, 0, len(rules))
+	for _, r := range rules {
+		if r.Enabled {
+			enabled = append(enabled, r)
+		}
+	}
+	return &RuleEngine{rules: enabled}
+}
+
+// Rules retorna as regras ativas
+func (e *RuleEngine) Rules() []entity.RuleDefinition {
+	return e.rules
+}
+
+// Lookback maior janela entre as regras do modo, para o chamador limitar o histórico carregado
+func (e *RuleEngine) Lookback(mode entity.RuleMode) time.Duration {
+	var max time.Duration
+	for _, r := range e.rules {
+		if r.Mode == mode && time.Duration(r.Window) > max {
+			max = time.Duration(r.Window)
+		}
+	}
+	return max
+}
+
+// Evaluate aplica as regras do modo ao sujeito e retorna os hits
+func (e *RuleEngine) Evaluate(mode entity.RuleMode, subject entity.Subject, signals entity.Signals) []entity.Hit {
+	var hits []entity.Hit
+	for _, r := range e.rules {
+		if r.Mode != mode || !r.AppliesTo(subject.Type) {
+			continue
+		}
+		history := activityWithin(signals.Activity, subject, time.Duration(r.Window))
+
+		var reason string
+		switch r.Kind {
+		case entity.RuleVelocity:
+			reason = velocity(r, subject, history)
+		case entity.RuleStructuring:
+			reason = structuring(r, subject, history)
+		case entity.RuleRapidInOut:
+			reason = rapidInOut(r, subject, history)
+		case entity.RuleNewDeviceWithdraw:
+			reason = newDeviceWithdraw(r, subject, signals.NewDeviceAt)
+		case entity.RuleUnusualDestination:
+			reason = unusualDestination(r, subject, history)
+		}
+		if reason != "" {
+			hits = append(hits, entity.Hit{RuleID: r.ID, Kind: r.Kind, Score: r.Score, Blocking: r.Blocking, Reason: reason})
+		}
+	}
+	return hits
+}
+
+// activityWithin filtra movimentações bem-sucedidas da janela, excluindo a própria transação avaliada
+func activityWithin(all []entity.Activity, subject entity.Subject, window time.Duration) []entity.Activity {
+	since := subject.OccurredAt.Add(-window)
+	out := make([]entity.Activity, 0, len(all))
+	for _, a := range all {
+		if a.Failed || a.TransactionID == subject.TransactionID || a.OccurredAt.Before(since) || a.OccurredAt.After(subject.OccurredAt) {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+func velocity(r entity.RuleDefinition, s entity.Subject, history []entity.Activity) string {
+	count := 1 // a transação atual
+	for _, a := range history {
+		if r.AppliesTo(a.Type) {
+			count++
+		}
+	}
+	if count > r.MaxCount {
+		return fmt.Sprintf("%d %s transactions within %s (max %d)", count, s.Type, time.Duration(r.Window), r.MaxCount)
+	}
+	return ""
+}
+
+func structuring(r entity.RuleDefinition, s entity.Subject, history []entity.Activity) string {
+	floor := r.Threshold.Mul(decimal.NewFromInt(1).Sub(r.Margin))
+	justBelow := func(amount decimal.Decimal) bool {
+		return amount.GreaterThanOrEqual(floor) && amount.LessThan(r.Threshold)
+	}
+	if !justBelow(s.Amount) {
+		return ""
+	}
+	count := 1
+	for _, a := range history {
+		if r.AppliesTo(a.Type) && justBelow(a.Amount) {
+			count++
+		}
+	}
+	if count >= r.MinCount {
+		return fmt.Sprintf("%d transactions between %s and %s within %s", count, floor.StringFixed(2), r.Threshold.StringFixed(2), time.Duration(r.Window))
+	}
+	return ""
+}
+
+func rapidInOut(r entity.RuleDefinition, s entity.Subject, history []entity.Activity) string {
+	if !s.IsOutflow() {
+		return ""
+	}
+	deposits := decimal.Zero
+	for _, a := range history {
+		if a.Type == "deposit" {
+			deposits = deposits.Add(a.Amount)
+		}
+	}
+	if deposits.IsPositive() && s.Amount.GreaterThanOrEqual(deposits.Mul(r.Ratio)) {
+		return fmt.Sprintf("outflow of %s after deposits of %s within %s", s.Amount.StringFixed(2), deposits.StringFixed(2), time.Duration(r.Window))
+	}
+	return ""
+}
+
+func newDeviceWithdraw(r entity.RuleDefinition, s entity.Subject, newDeviceAt *time.Time) string {
+	if !s.IsOutflow() || newDeviceAt == nil {
+		return ""
+	}
+	if s.OccurredAt.Sub(*newDeviceAt) <= time.Duration(r.Window) {
+		return fmt.Sprintf("outflow %s after first login from a new device", s.OccurredAt.Sub(*newDeviceAt).Round(time.Second))
+	}
+	return ""
+}
+
+func unusualDestination(r entity.RuleDefinition, s entity.Subject, history []entity.Activity) string {
+	if s.Destination == "" || s.Amount.LessThan(r.Threshold) {
+		return ""
+	}
+	for _, a := range history {
+		if a.Destination == s.Destination {
+			return ""
+		}
+	}
+	return fmt.Sprintf("first transaction of %s to destination %s", s.Amount.StringFixed(2), s.Destination)
+}
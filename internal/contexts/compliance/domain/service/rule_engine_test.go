@@ -0,0 +1,104 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func ruleByID(id string) entity.RuleDefinition {
+	for _, r := range entity.DefaultRuleDefinitions() {
+		if r.ID == id {
+			return r
+		}
+	}
+	panic("rule not found: " + id)
+}
+
+func activity(txType string, amount int64, ago time.Duration, now time.Time) entity.Activity {
+	return entity.Activity{TransactionID: uuid.New(), Type: txType, Amount: decimal.NewFromInt(amount), OccurredAt: now.Add(-ago)}
+}
+
+func TestRuleEngine_Velocity(t *testing.T) {
+	now := time.Now()
+	rule := ruleByID("outflow-velocity")
+	rule.MaxCount = 3
+	engine := NewRuleEngine([]entity.RuleDefinition{rule})
+	subject := entity.Subject{TransactionID: uuid.New(), Type: "withdraw", Amount: decimal.NewFromInt(10), OccurredAt: now}
+
+	signals := entity.Signals{Activity: []entity.Activity{
+		activity("withdraw", 10, time.Minute, now),
+		activity("transfer", 10, 2*time.Minute, now),
+		activity("deposit", 10, 3*time.Minute, now), // tipo fora da regra
+		activity("withdraw", 10, 2*time.Hour, now),  // fora da janela
+	}}
+	if hits := engine.Evaluate(entity.RuleModeSync, subject, signals); len(hits) != 0 {
+		t.Fatalf("3 saídas na janela não deveriam disparar: %+v", hits)
+	}
+
+	signals.Activity = append(signals.Activity, activity("withdraw", 10, 4*time.Minute, now))
+	hits := engine.Evaluate(entity.RuleModeSync, subject, signals)
+	if len(hits) != 1 || !hits[0].Blocking {
+		t.Fatalf("esperado hit bloqueante de velocidade, obtido %+v", hits)
+	}
+}
+
+func TestRuleEngine_Structuring(t *testing.T) {
+	now := time.Now()
+	engine := NewRuleEngine([]entity.RuleDefinition{ruleByID("deposit-structuring")})
+	subject := entity.Subject{TransactionID: uuid.New(), Type: "deposit", Amount: decimal.NewFromInt(9800), OccurredAt: now}
+
+	signals := entity.Signals{Activity: []entity.Activity{
+		activity("deposit", 9500, time.Hour, now),
+		activity("deposit", 12000, 2*time.Hour, now), // acima do limite, não conta
+	}}
+	if hits := engine.Evaluate(entity.RuleModeAsync, subject, signals); len(hits) != 0 {
+		t.Fatalf("apenas 2 depósitos logo abaixo do limite: %+v", hits)
+	}
+	signals.Activity = append(signals.Activity, activity("deposit", 9990, 3*time.Hour, now))
+	if hits := engine.Evaluate(entity.RuleModeAsync, subject, signals); len(hits) != 1 {
+		t.Fatalf("esperado hit de fracionamento, obtido %+v", hits)
+	}
+}
+
+func TestRuleEngine_RapidInOutAndUnusualDestination(t *testing.T) {
+	now := time.Now()
+	engine := NewRuleEngine([]entity.RuleDefinition{ruleByID("rapid-in-out"), ruleByID("unusual-destination")})
+	subject := entity.Subject{TransactionID: uuid.New(), Type: "transfer", Amount: decimal.NewFromInt(9500), Destination: "addr-new", OccurredAt: now}
+	signals := entity.Signals{Activity: []entity.Activity{
+		activity("deposit", 10000, 2*time.Hour, now),
+		{TransactionID: uuid.New(), Type: "transfer", Amount: decimal.NewFromInt(10), Destination: "addr-old", OccurredAt: now.Add(-48 * time.Hour)},
+	}}
+
+	if hits := engine.Evaluate(entity.RuleModeAsync, subject, signals); len(hits) != 1 || hits[0].Kind != entity.RuleRapidInOut {
+		t.Fatalf("esperado rapid_in_out, obtido %+v", hits)
+	}
+	hits := engine.Evaluate(entity.RuleModeSync, subject, signals)
+	if len(hits) != 1 || !strings.Contains(hits[0].Reason, "addr-new") {
+		t.Fatalf("esperado destino incomum, obtido %+v", hits)
+	}
+
+	subject.Destination = "addr-old"
+	if hits := engine.Evaluate(entity.RuleModeSync, subject, signals); len(hits) != 0 {
+		t.Fatalf("destino conhecido não deveria disparar: %+v", hits)
+	}
+}
+
+func TestRuleEngine_NewDeviceAndScore(t *testing.T) {
+	now := time.Now()
+	rules := []entity.RuleDefinition{ruleByID("new-device-withdraw"), ruleByID("unusual-destination")}
+	engine := NewRuleEngine(rules)
+	seen := now.Add(-10 * time.Minute)
+	subject := entity.Subject{TransactionID: uuid.New(), Type: "withdraw", Amount: decimal.NewFromInt(6000), Destination: "x", OccurredAt: now}
+
+	hits := engine.Evaluate(entity.RuleModeSync, subject, entity.Signals{NewDeviceAt: &seen})
+	a := entity.NewAssessment(subject, entity.PhasePreExecution, hits, now)
+	if len(hits) != 2 || a.Score != 55 || a.Blocked {
+		t.Fatalf("avaliação inesperada: score=%d blocked=%v hits=%+v", a.Score, a.Blocked, hits)
+	}
+}
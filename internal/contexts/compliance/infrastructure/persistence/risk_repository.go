@@ -0,0 +1,253 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresAssessmentRepository implementa AssessmentRepository usando PostgreSQL
+type PostgresAssessmentRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresAssessmentRepository cria um novo repositório de avaliações de risco
+func NewPostgresAssessmentRepository(conn database.Connection) *PostgresAssessmentRepository {
+	return &PostgresAssessmentRepository{
+		conn:   conn,
+		schema: "compliance_context",
+	}
+}
+
+// Save grava a avaliação
+func (r *PostgresAssessmentRepository) Save(ctx context.Context, a *entity.Assessment) error {
+	hits, err := json.Marshal(a.Hits)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ` + r.schema + `.risk_assessments
+		(id, transaction_id, user_id, phase, score, blocked, hits, evaluated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8)
+	`
+
+	_, err = r.conn.Exec(ctx, query, a.ID, a.TransactionID, a.UserID, string(a.Phase), a.Score, a.Blocked, string(hits), a.EvaluatedAt)
+	return err
+}
+
+// FindByTransactionID lista as avaliações da transação em ordem cronológica
+func (r *PostgresAssessmentRepository) FindByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*entity.Assessment, error) {
+	query := `
+		SELECT id, transaction_id, user_id, phase, score, blocked, hits, evaluated_at
+		FROM ` + r.schema + `.risk_assessments
+		WHERE transaction_id = $1
+		ORDER BY evaluated_at
+	`
+
+	rows, err := r.conn.Query(ctx, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Assessment
+	for rows.Next() {
+		a := &entity.Assessment{}
+		var phase string
+		var hits []byte
+		if err := rows.Scan(&a.ID, &a.TransactionID, &a.UserID, &phase, &a.Score, &a.Blocked, &hits, &a.EvaluatedAt); err != nil {
+			return nil, err
+		}
+		a.Phase = entity.AssessmentPhase(phase)
+		if err := json.Unmarshal(hits, &a.Hits); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+
+	return out, rows.Err()
+}
+
+// PostgresCaseRepository implementa CaseRepository usando PostgreSQL
+type PostgresCaseRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresCaseRepository cria um novo repositório de casos
+func NewPostgresCaseRepository(conn database.Connection) *PostgresCaseRepository {
+	return &PostgresCaseRepository{
+		conn:   conn,
+		schema: "compliance_context",
+	}
+}
+
+const caseColumns = `id, user_id, transaction_id, assessment_id, source, score, hits, status,
+	assignee_id, resolution, created_at, updated_at, closed_at`
+
+// Create insere um novo caso
+func (r *PostgresCaseRepository) Create(ctx context.Context, c *entity.Case) error {
+	hits, err := json.Marshal(c.Hits)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ` + r.schema + `.cases (` + caseColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err = r.conn.Exec(ctx, query,
+		c.ID,
+		c.UserID,
+		c.TransactionID,
+		c.AssessmentID,
+		c.Source,
+		c.Score,
+		string(hits),
+		string(c.Status),
+		c.AssigneeID,
+		string(c.Resolution),
+		c.CreatedAt,
+		c.UpdatedAt,
+		c.ClosedAt,
+	)
+
+	return err
+}
+
+// FindByID busca um caso com suas anotações
+func (r *PostgresCaseRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Case, error) {
+	query := `SELECT ` + caseColumns + ` FROM ` + r.schema + `.cases WHERE id = $1`
+
+	c, err := scanCase(r.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	notes, err := r.conn.Query(ctx, `
+		SELECT id, author_id, body, created_at
+		FROM `+r.schema+`.case_notes
+		WHERE case_id = $1
+		ORDER BY created_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer notes.Close()
+
+	for notes.Next() {
+		var n entity.CaseNote
+		if err := notes.Scan(&n.ID, &n.AuthorID, &n.Body, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.Notes = append(c.Notes, n)
+	}
+
+	return c, notes.Err()
+}
+
+// List lista casos por status, mais recentes primeiro
+func (r *PostgresCaseRepository) List(ctx context.Context, status entity.CaseStatus, limit int) ([]*entity.Case, error) {
+	query := `
+		SELECT ` + caseColumns + `
+		FROM ` + r.schema + `.cases
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.conn.Query(ctx, query, string(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Case
+	for rows.Next() {
+		c, err := scanCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+
+	return out, rows.Err()
+}
+
+// Update atualiza estado, responsável e resolução do caso
+func (r *PostgresCaseRepository) Update(ctx context.Context, c *entity.Case) error {
+	query := `
+		UPDATE ` + r.schema + `.cases
+		SET status = $2, assignee_id = $3, resolution = $4, updated_at = $5, closed_at = $6
+		WHERE id = $1
+	`
+
+	_, err := r.conn.Exec(ctx, query, c.ID, string(c.Status), c.AssigneeID, string(c.Resolution), c.UpdatedAt, c.ClosedAt)
+	return err
+}
+
+// AddNote grava uma anotação do caso
+func (r *PostgresCaseRepository) AddNote(ctx context.Context, caseID uuid.UUID, n *entity.CaseNote) error {
+	query := `
+		INSERT INTO ` + r.schema + `.case_notes (id, case_id, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.conn.Exec(ctx, query, n.ID, caseID, n.AuthorID, n.Body, n.CreatedAt)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCase(row rowScanner) (*entity.Case, error) {
+	c := &entity.Case{}
+	var (
+		hits               []byte
+		status, resolution string
+		assigneeID         uuid.NullUUID
+		closedAt           sql.NullTime
+	)
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.TransactionID,
+		&c.AssessmentID,
+		&c.Source,
+		&c.Score,
+		&hits,
+		&status,
+		&assigneeID,
+		&resolution,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&closedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(hits, &c.Hits); err != nil {
+		return nil, err
+	}
+	c.Status = entity.CaseStatus(status)
+	c.Resolution = entity.CaseResolution(resolution)
+	if assigneeID.Valid {
+		c.AssigneeID = &assigneeID.UUID
+	}
+	if closedAt.Valid {
+		c.ClosedAt = &closedAt.Time
+	}
+	return c, nil
+}
@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresScreeningRepository implementa ScreeningRepository usando PostgreSQL
+type PostgresScreeningRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresScreeningRepository cria um novo repositório de triagens de sanções
+func NewPostgresScreeningRepository(conn database.Connection) *PostgresScreeningRepository {
+	return &PostgresScreeningRepository{
+		conn:   conn,
+		schema: "compliance_context",
+	}
+}
+
+// Save grava a decisão com a versão da lista usada
+func (r *PostgresScreeningRepository) Save(ctx context.Context, s *entity.ScreeningResult) error {
+	matches, err := json.Marshal(s.Matches)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ` + r.schema + `.sanctions_screenings
+		(id, user_id, direction, chain, address, name, reference, decision, matches, list_version, screened_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb, $10, $11)
+	`
+
+	_, err = r.conn.Exec(ctx, query,
+		s.ID,
+		s.UserID,
+		string(s.Direction),
+		s.Chain,
+		s.Address,
+		s.Name,
+		s.Reference,
+		string(s.Decision),
+		string(matches),
+		s.ListVersion,
+		s.ScreenedAt,
+	)
+
+	return err
+}
+
+// List lista as triagens mais recentes, opcionalmente filtradas pela decisão
+func (r *PostgresScreeningRepository) List(ctx context.Context, decision entity.ScreeningDecision, limit int) ([]*entity.ScreeningResult, error) {
+	query := `
+		SELECT id, user_id, direction, chain, address, name, reference, decision, matches, list_version, screened_at
+		FROM ` + r.schema + `.sanctions_screenings
+		WHERE ($1 = '' OR decision = $1)
+		ORDER BY screened_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.conn.Query(ctx, query, string(decision), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ScreeningResult
+	for rows.Next() {
+		s := &entity.ScreeningResult{}
+		var (
+			userID              uuid.NullUUID
+			direction, decision string
+			matches             []byte
+		)
+		if err := rows.Scan(&s.ID, &userID, &direction, &s.Chain, &s.Address, &s.Name, &s.Reference, &decision, &matches, &s.ListVersion, &s.ScreenedAt); err != nil {
+			return nil, err
+		}
+		if userID.Valid {
+			s.UserID = &userID.UUID
+		}
+		s.Direction = entity.ScreeningDirection(direction)
+		s.Decision = entity.ScreeningDecision(decision)
+		if err := json.Unmarshal(matches, &s.Matches); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+
+	return out, rows.Err()
+}
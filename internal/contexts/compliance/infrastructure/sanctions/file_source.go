@@ -0,0 +1,85 @@
+package sanctions
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+)
+
+// ListFile arquivo de lista local e seu formato
+type ListFile struct {
+	Format string
+	Path   string
+}
+
+// ParseListFiles interpreta a configuração "formato:caminho" separada por vírgulas,
+// ex: "ofac_csv:/data/sdn.csv,blocklist:/data/blocklist.csv"
+func ParseListFiles(spec string) ([]ListFile, error) {
+	var files []ListFile
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		format, path, ok := strings.Cut(item, ":")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("invalid sanctions list %q: expected format:path", item)
+		}
+		switch format {
+		case FormatOFACCSV, FormatOFACXML, FormatBlocklist:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+		}
+		files = append(files, ListFile{Format: format, Path: path})
+	}
+	return files, nil
+}
+
+// FileListSource carrega as listas de arquivos locais. A versão é o SHA-256 do conteúdo
+// de todos os arquivos, então qualquer alteração gera uma nova versão.
+type FileListSource struct {
+	files []ListFile
+	now   func() time.Time
+}
+
+// NewFileListSource cria a fonte de listas a partir dos arquivos configurados
+func NewFileListSource(files []ListFile) *FileListSource {
+	return &FileListSource{files: files, now: time.Now}
+}
+
+// Load lê e indexa todos os arquivos; qualquer falha invalida a carga inteira
+func (s *FileListSource) Load(ctx context.Context) (*entity.SanctionsList, error) {
+	hash := sha256.New()
+	var (
+		entries []entity.SanctionsEntry
+		sources []string
+	)
+	for _, f := range s.files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Path, err)
+		}
+		parsed, err := Parse(f.Format, bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", f.Path, err)
+		}
+		hash.Write([]byte(f.Format))
+		hash.Write(data)
+		entries = append(entries, parsed...)
+		sources = append(sources, f.Format+":"+filepath.Base(f.Path))
+	}
+
+	version := "sha256:" + hex.EncodeToString(hash.Sum(nil))[:16]
+	return entity.NewSanctionsList(version, s.now(), sources, entries), nil
+}
@@ -0,0 +1,128 @@
+package sanctions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+)
+
+const sdnCSV = `36,"AEROCARIBBEAN AIRLINES","-0- ","CUBA","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "
+27304,"KHOROSHEV, Dmitry Yuryevich","individual","CYBER2","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","DOB 17 Apr 1993; Digital Currency Address - XBT bc1qvhnfknw852ephxyc5hm4q520zmvf9maphetc9z; Digital Currency Address - ETH 0xf3701f445b6bdafedbca97d1e477357839e4120d; Gender Male."
+`
+
+const sdnXML = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/SDN.XSD">
+  <publshInformation><Publish_Date>10/15/2026</Publish_Date><Record_Count>1</Record_Count></publshInformation>
+  <sdnEntry>
+    <uid>30521</uid>
+    <lastName>GARANTEX EUROPE OU</lastName>
+    <sdnType>Entity</sdnType>
+    <programList><program>CYBER2</program><program>RUSSIA-EO14024</program></programList>
+    <idList>
+      <id><uid>1</uid><idType>Digital Currency Address - TRX</idType><idNumber>TUUUxx3s5ZXfXYTRJ1CCr6mwhvYGjXPkcS</idNumber></id>
+      <id><uid>2</uid><idType>Registration Number</idType><idNumber>14812282</idNumber></id>
+    </idList>
+    <akaList><aka><uid>3</uid><type>a.k.a.</type><lastName>GARANTEX</lastName></aka></akaList>
+  </sdnEntry>
+</sdnList>`
+
+const blocklistCSV = `# bloqueios internos
+address,chain,name,action
+0xABCDEF0000000000000000000000000000000001,ETH,Mixer Interno,
+TXYZexample00000000000000000000001,TRX,Exchange suspeita,flag
+`
+
+func TestParse_OFACFormats(t *testing.T) {
+	entries, err := Parse(FormatOFACCSV, strings.NewReader(sdnCSV))
+	if err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	list := entity.NewSanctionsList("v1", time.Now(), nil, entries)
+	if list.AddressCount() != 2 || list.NameCount() != 2 {
+		t.Fatalf("csv: %d endereços, %d nomes", list.AddressCount(), list.NameCount())
+	}
+	// Endereços EVM e bech32 ignoram caixa
+	if m := list.MatchAddress("0xF3701F445B6BDAFEDBCA97D1E477357839E4120D"); len(m) != 1 || m[0].Chain != "ETH" || m[0].Program != "CYBER2" {
+		t.Fatalf("endereço ETH não encontrado: %+v", m)
+	}
+	if m := list.MatchName("Khoroshev Dmitry Yuryevich"); len(m) == 0 {
+		t.Fatal("nome normalizado deveria casar")
+	}
+
+	entries, err = Parse(FormatOFACXML, strings.NewReader(sdnXML))
+	if err != nil {
+		t.Fatalf("xml: %v", err)
+	}
+	list = entity.NewSanctionsList("v2", time.Now(), nil, entries)
+	if m := list.MatchAddress("TUUUxx3s5ZXfXYTRJ1CCr6mwhvYGjXPkcS"); len(m) != 1 || m[0].EntryID != "30521" {
+		t.Fatalf("endereço TRX não encontrado: %+v", m)
+	}
+	// Base58 diferencia maiúsculas
+	if m := list.MatchAddress("tuuuxx3s5zxfxytrj1ccr6mwhvygjxpkcs"); len(m) != 0 {
+		t.Fatalf("base58 não deveria casar em minúsculas: %+v", m)
+	}
+	if m := list.MatchName("garantex"); len(m) != 1 {
+		t.Fatalf("aka deveria casar por nome: %+v", m)
+	}
+}
+
+func TestParse_Blocklist(t *testing.T) {
+	entries, err := Parse(FormatBlocklist, strings.NewReader(blocklistCSV))
+	if err != nil {
+		t.Fatalf("blocklist: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != entity.ActionBlock || entries[1].Action != entity.ActionFlag {
+		t.Fatalf("entradas inesperadas: %+v", entries)
+	}
+
+	if _, err := Parse(FormatBlocklist, strings.NewReader("address,action\nX,ignore\n")); err == nil {
+		t.Fatal("ação inválida deveria falhar")
+	}
+	if _, err := Parse("unknown", strings.NewReader("")); err == nil {
+		t.Fatal("formato desconhecido deveria falhar")
+	}
+}
+
+func TestFileListSource_VersionTracksContent(t *testing.T) {
+	dir := t.TempDir()
+	sdn := filepath.Join(dir, "sdn.csv")
+	block := filepath.Join(dir, "blocklist.csv")
+	if err := os.WriteFile(sdn, []byte(sdnCSV), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(block, []byte(blocklistCSV), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ParseListFiles("ofac_csv:" + sdn + ", blocklist:" + block)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("spec: %v %+v", err, files)
+	}
+	source := NewFileListSource(files)
+
+	first, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	again, _ := source.Load(context.Background())
+	if first.Version != again.Version || !strings.HasPrefix(first.Version, "sha256:") {
+		t.Fatalf("mesma entrada deveria gerar mesma versão: %s %s", first.Version, again.Version)
+	}
+
+	if err := os.WriteFile(block, []byte(blocklistCSV+"TNEW000000000000000000000000000001,TRX,Novo,\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := source.Load(context.Background())
+	if err != nil || changed.Version == first.Version || changed.AddressCount() != first.AddressCount()+1 {
+		t.Fatalf("alteração deveria gerar nova versão: %v %s", err, changed.Version)
+	}
+
+	if _, err := ParseListFiles("ofac_csv"); err == nil {
+		t.Fatal("spec sem caminho deveria falhar")
+	}
+}
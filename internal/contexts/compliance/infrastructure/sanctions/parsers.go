@@ -0,0 +1,207 @@
+package sanctions
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+)
+
+// Formatos de arquivo suportados
+const (
+	FormatOFACCSV   = "ofac_csv"  // sdn.csv da OFAC (endereços no campo Remarks)
+	FormatOFACXML   = "ofac_xml"  // sdn.xml da OFAC (endereços em idList)
+	FormatBlocklist = "blocklist" // CSV interno: address,chain,name,action
+)
+
+var ErrUnknownFormat = errors.New("unknown sanctions list format")
+
+// ofacAddressPattern extrai "Digital Currency Address - XBT 1abc..." do campo Remarks
+var ofacAddressPattern = regexp.MustCompile(`Digital Currency Address - ([A-Za-z0-9]+)\s+([A-Za-z0-9]+)`)
+
+const ofacNull = "-0-"
+
+// Parse lê as entradas de uma lista no formato informado
+func Parse(format string, r io.Reader) ([]entity.SanctionsEntry, error) {
+	switch format {
+	case FormatOFACCSV:
+		return parseOFACCSV(r)
+	case FormatOFACXML:
+		return parseOFACXML(r)
+	case FormatBlocklist:
+		return parseBlocklist(r)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// parseOFACCSV lê o sdn.csv (sem cabeçalho): ent_num, SDN_Name, SDN_Type, Program, ..., Remarks (12ª coluna)
+func parseOFACCSV(r io.Reader) ([]entity.SanctionsEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var out []entity.SanctionsEntry
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) < 4 {
+			continue
+		}
+		base := entity.SanctionsEntry{
+			Source:  "ofac_sdn",
+			EntryID: ofacValue(rec[0]),
+			Name:    ofacValue(rec[1]),
+			Program: ofacValue(rec[3]),
+			Action:  entity.ActionBlock,
+		}
+		if base.Name == "" {
+			continue
+		}
+		out = append(out, base)
+
+		if len(rec) < 12 {
+			continue
+		}
+		for _, m := range ofacAddressPattern.FindAllStringSubmatch(rec[11], -1) {
+			e := base
+			e.Chain, e.Address = m[1], m[2]
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func ofacValue(v string) string {
+	v = strings.TrimSpace(v)
+	if v == ofacNull {
+		return ""
+	}
+	return v
+}
+
+type ofacName struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func (n ofacName) full() string {
+	return strings.TrimSpace(n.FirstName + " " + n.LastName)
+}
+
+type ofacXMLList struct {
+	Entries []struct {
+		UID string `xml:"uid"`
+		ofacName
+		Programs []string `xml:"programList>program"`
+		IDs      []struct {
+			Type   string `xml:"idType"`
+			Number string `xml:"idNumber"`
+		} `xml:"idList>id"`
+		AKAs []ofacName `xml:"akaList>aka"`
+	} `xml:"sdnEntry"`
+}
+
+// parseOFACXML lê o sdn.xml; nomes alternativos (aka) entram apenas para triagem por nome
+func parseOFACXML(r io.Reader) ([]entity.SanctionsEntry, error) {
+	var list ofacXMLList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	var out []entity.SanctionsEntry
+	for _, sdn := range list.Entries {
+		base := entity.SanctionsEntry{
+			Source:  "ofac_sdn",
+			EntryID: sdn.UID,
+			Name:    sdn.full(),
+			Program: strings.Join(sdn.Programs, ","),
+			Action:  entity.ActionBlock,
+		}
+		out = append(out, base)
+		for _, aka := range sdn.AKAs {
+			if name := aka.full(); name != "" {
+				e := base
+				e.Name = name
+				out = append(out, e)
+			}
+		}
+		for _, id := range sdn.IDs {
+			chain, ok := strings.CutPrefix(id.Type, "Digital Currency Address - ")
+			if !ok || strings.TrimSpace(id.Number) == "" {
+				continue
+			}
+			e := base
+			e.Chain, e.Address = strings.TrimSpace(chain), strings.TrimSpace(id.Number)
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// parseBlocklist lê o CSV interno com cabeçalho address,chain,name,action (action padrão: block).
+// Linhas iniciadas por # são ignoradas.
+func parseBlocklist(r io.Reader) ([]entity.SanctionsEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := col["address"]; !ok {
+		return nil, errors.New("blocklist: address column is required")
+	}
+	get := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var out []entity.SanctionsEntry
+	for line := 2; ; line++ {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		e := entity.SanctionsEntry{
+			Source:  "blocklist",
+			Address: get(rec, "address"),
+			Chain:   get(rec, "chain"),
+			Name:    get(rec, "name"),
+			Action:  entity.ScreeningAction(strings.ToLower(get(rec, "action"))),
+		}
+		switch e.Action {
+		case "":
+			e.Action = entity.ActionBlock
+		case entity.ActionBlock, entity.ActionFlag:
+		default:
+			return nil, fmt.Errorf("blocklist line %d: invalid action %q", line, e.Action)
+		}
+		if e.Address == "" && e.Name == "" {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package signals
+
+import (
+	"context"
+	"time"
+
+	"financial-system-pro/internal/contexts/compliance/domain/entity"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	txnRepo "financial-system-pro/internal/contexts/transaction/domain/repository"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// RepositorySignalProvider deriva os sinais de risco das transações e sessões já persistidas
+type RepositorySignalProvider struct {
+	txRepo      txnRepo.TransactionRepository
+	sessionRepo userRepo.SessionRepository
+}
+
+// NewRepositorySignalProvider cria o provedor; sessionRepo é opcional (sem ele não há sinal de dispositivo)
+func NewRepositorySignalProvider(txRepo txnRepo.TransactionRepository, sessionRepo userRepo.SessionRepository) *RepositorySignalProvider {
+	return &RepositorySignalProvider{txRepo: txRepo, sessionRepo: sessionRepo}
+}
+
+// Activity lista as transações do usuário desde o instante informado
+func (p *RepositorySignalProvider) Activity(ctx context.Context, userID uuid.UUID, since time.Time) ([]entity.Activity, error) {
+	txs, err := p.txRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entity.Activity, 0, len(txs))
+	for _, tx := range txs {
+		if tx.CreatedAt.Before(since) {
+			continue
+		}
+		out = append(out, toActivity(tx))
+	}
+	return out, nil
+}
+
+// NewDeviceAt considera novo o dispositivo da sessão mais recente quando nenhuma outra
+// sessão ativa usa a mesma impressão digital; sem histórico (primeiro acesso) não há sinal
+func (p *RepositorySignalProvider) NewDeviceAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	if p.sessionRepo == nil {
+		return nil, nil
+	}
+	sessions, err := p.sessionRepo.ListActiveByUserID(ctx, userID, time.Now())
+	if err != nil || len(sessions) < 2 {
+		return nil, err
+	}
+
+	latest := sessions[0]
+	for _, s := range sessions[1:] {
+		if s.CreatedAt.After(latest.CreatedAt) {
+			latest = s
+		}
+	}
+	for _, s := range sessions {
+		if s.ID != latest.ID && s.DeviceFingerprint == latest.DeviceFingerprint {
+			return nil, nil
+		}
+	}
+	firstSeen := latest.CreatedAt
+	return &firstSeen, nil
+}
+
+// SubjectByHash busca a transação pelo hash publicado nos eventos de conclusão
+func (p *RepositorySignalProvider) SubjectByHash(ctx context.Context, txHash string) (*entity.Subject, error) {
+	tx, err := p.txRepo.FindByHash(ctx, txHash)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return &entity.Subject{
+		TransactionID: tx.ID,
+		UserID:        tx.UserID,
+		Type:          string(tx.Type),
+		Amount:        tx.Amount,
+		Destination:   tx.ToAddress,
+		OccurredAt:    tx.CreatedAt,
+	}, nil
+}
+
+func toActivity(tx *txnEntity.Transaction) entity.Activity {
+	return entity.Activity{
+		TransactionID: tx.ID,
+		Type:          string(tx.Type),
+		Amount:        tx.Amount,
+		Destination:   tx.ToAddress,
+		Failed:        tx.Status == txnEntity.TransactionStatusFailed,
+		OccurredAt:    tx.CreatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/fx/domain/repository"
+	fxDomain "financial-system-pro/internal/contexts/fx/domain/service"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSpreadBps spread padrão aplicado sobre a cotação de mercado (0,5%)
+	DefaultSpreadBps = 50
+	// DefaultQuoteTTL validade padrão de uma cotação firme
+	DefaultQuoteTTL = 30 * time.Second
+)
+
+var (
+	ErrQuoteNotFound = errors.New("fx quote not found")
+	// ErrInvalidPrecision valor com mais casas decimais do que a moeda de origem admite
+	ErrInvalidPrecision = errors.New("amount has more decimals than the currency allows")
+)
+
+// ConversionService emite cotações firmes com spread e executa as conversões entre os saldos
+// por moeda da carteira
+type ConversionService struct {
+	quotes    repository.QuoteRepository
+	balances  userRepo.BalanceRepository
+	rates     fxDomain.RateProvider
+	spreadBps int
+	ttl       time.Duration
+	eventBus  events.Bus
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// NewConversionService cria o serviço de câmbio com spread e validade padrão
+func NewConversionService(
+	quotes repository.QuoteRepository,
+	balances userRepo.BalanceRepository,
+	rates fxDomain.RateProvider,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *ConversionService {
+	return &ConversionService{
+		quotes:    quotes,
+		balances:  balances,
+		rates:     rates,
+		spreadBps: DefaultSpreadBps,
+		ttl:       DefaultQuoteTTL,
+		eventBus:  eventBus,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// WithSpread define o spread em pontos-base
+func (s *ConversionService) WithSpread(bps int) *ConversionService {
+	s.spreadBps = bps
+	return s
+}
+
+// WithQuoteTTL define a validade das cotações
+func (s *ConversionService) WithQuoteTTL(ttl time.Duration) *ConversionService {
+	if ttl > 0 {
+		s.ttl = ttl
+	}
+	return s
+}
+
+// Quote emite uma cotação firme para vender sellAmount de from em troca de to
+func (s *ConversionService) Quote(ctx context.Context, userID uuid.UUID, from, to string, sellAmount decimal.Decimal) (*entity.Quote, error) {
+	fromCur, err := sharedVO.ParseCurrency(from)
+	if err != nil {
+		return nil, err
+	}
+	toCur, err := sharedVO.ParseCurrency(to)
+	if err != nil {
+		return nil, err
+	}
+	if fromCur == toCur {
+		return nil, entity.ErrSameCurrency
+	}
+	if !sellAmount.Equal(sellAmount.Round(fromCur.Decimals())) {
+		return nil, ErrInvalidPrecision
+	}
+
+	rate, err := s.rates.Rate(ctx, string(fromCur), string(toCur))
+	if err != nil {
+		return nil, err
+	}
+	q, err := entity.NewQuote(userID, rate, sellAmount, s.spreadBps, toCur.Decimals(), s.ttl, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quotes.Create(ctx, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Execute converte os saldos pela cotação, que precisa ser do usuário, estar aberta e dentro da validade
+func (s *ConversionService) Execute(ctx context.Context, userID, quoteID uuid.UUID) (*entity.Quote, error) {
+	q, err := s.quotes.FindByID(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil || q.UserID != userID {
+		return nil, ErrQuoteNotFound
+	}
+	if err := q.Execute(s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.quotes.Execute(ctx, q); err != nil {
+		return nil, err
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewCurrencyConvertedEvent(q.ID, q.UserID, q.From, q.To, q.SellAmount, q.BuyAmount, q.Rate))
+	s.logger.Info("fx conversion executed",
+		zap.String("quote_id", q.ID.String()),
+		zap.String("user_id", userID.String()),
+		zap.String("pair", entity.Pair(q.From, q.To)),
+		zap.String("sell", q.SellAmount.String()),
+		zap.String("buy", q.BuyAmount.String()),
+	)
+	return q, nil
+}
+
+// Balances lista os saldos por moeda do usuário
+func (s *ConversionService) Balances(ctx context.Context, userID uuid.UUID) ([]*userEntity.Balance, error) {
+	return s.balances.List(ctx, userID)
+}
+
+// Conversions lista as conversões executadas do usuário
+func (s *ConversionService) Conversions(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Quote, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.quotes.ListExecuted(ctx, userID, limit)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/fx/infrastructure/rates"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// memLedger guarda cotações e saldos juntos para que Execute seja atômico como no Postgres
+type memLedger struct {
+	mu       sync.Mutex
+	quotes   map[uuid.UUID]*entity.Quote
+	balances map[uuid.UUID]map[string]decimal.Decimal
+}
+
+func newMemLedger() *memLedger {
+	return &memLedger{
+		quotes:   make(map[uuid.UUID]*entity.Quote),
+		balances: make(map[uuid.UUID]map[string]decimal.Decimal),
+	}
+}
+
+func (m *memLedger) credit(userID uuid.UUID, currency string, amount decimal.Decimal) {
+	if m.balances[userID] == nil {
+		m.balances[userID] = make(map[string]decimal.Decimal)
+	}
+	m.balances[userID][currency] = m.balances[userID][currency].Add(amount)
+}
+
+func (m *memLedger) Create(ctx context.Context, q *entity.Quote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *q
+	m.quotes[q.ID] = &cp
+	return nil
+}
+
+func (m *memLedger) FindByID(ctx context.Context, id uuid.UUID) (*entity.Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q, ok := m.quotes[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *q
+	return &cp, nil
+}
+
+func (m *memLedger) Execute(ctx context.Context, q *entity.Quote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := m.quotes[q.ID]
+	if stored == nil || stored.Status != entity.QuoteOpen {
+		return entity.ErrQuoteExecuted
+	}
+	if m.balances[q.UserID][q.From].LessThan(q.SellAmount) {
+		return entity.ErrInsufficientFunds
+	}
+	m.credit(q.UserID, q.From, q.SellAmount.Neg())
+	m.credit(q.UserID, q.To, q.BuyAmount)
+	cp := *q
+	m.quotes[q.ID] = &cp
+	return nil
+}
+
+func (m *memLedger) ListExecuted(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Quote
+	for _, q := range m.quotes {
+		if q.UserID == userID && q.Status == entity.QuoteExecuted {
+			cp := *q
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ExecutedAt.After(*out[j].ExecutedAt) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memLedger) List(ctx context.Context, userID uuid.UUID) ([]*userEntity.Balance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*userEntity.Balance
+	for currency, amount := range m.balances[userID] {
+		out = append(out, &userEntity.Balance{UserID: userID, Currency: currency, Amount: amount})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Currency < out[j].Currency })
+	return out, nil
+}
+
+func (m *memLedger) Find(ctx context.Context, userID uuid.UUID, currency string) (*userEntity.Balance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &userEntity.Balance{UserID: userID, Currency: currency, Amount: m.balances[userID][currency]}, nil
+}
+
+func setupConversions(t *testing.T) (*ConversionService, *memLedger, uuid.UUID) {
+	t.Helper()
+	provider, err := rates.LoadStaticProvider(strings.NewReader(`{"USD/BRL": "5", "BTC/BRL": "300000"}`), string(sharedVO.BaseCurrency))
+	if err != nil {
+		t.Fatalf("tabela inválida: %v", err)
+	}
+	ledger := newMemLedger()
+	uid := uuid.New()
+	ledger.credit(uid, "BRL", decimal.NewFromInt(1000))
+	svc := NewConversionService(ledger, ledger, provider, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()).WithSpread(100)
+	return svc, ledger, uid
+}
+
+func TestConversionService_QuoteAndExecute(t *testing.T) {
+	svc, _, uid := setupConversions(t)
+	ctx := context.Background()
+
+	q, err := svc.Quote(ctx, uid, "brl", "usd", decimal.NewFromInt(500))
+	if err != nil {
+		t.Fatalf("cotação falhou: %v", err)
+	}
+	// 500 * 0.2 * 0.99
+	if !q.BuyAmount.Equal(decimal.NewFromInt(99)) || q.SpreadBps != 100 {
+		t.Fatalf("cotação inesperada: %+v", q)
+	}
+
+	if _, err := svc.Execute(ctx, uuid.New(), q.ID); !errors.Is(err, ErrQuoteNotFound) {
+		t.Fatalf("cotação de outro usuário deveria ser ErrQuoteNotFound, obtido %v", err)
+	}
+	if _, err := svc.Execute(ctx, uid, q.ID); err != nil {
+		t.Fatalf("execução falhou: %v", err)
+	}
+	if _, err := svc.Execute(ctx, uid, q.ID); !errors.Is(err, entity.ErrQuoteExecuted) {
+		t.Fatalf("esperado ErrQuoteExecuted, obtido %v", err)
+	}
+
+	balances, _ := svc.Balances(ctx, uid)
+	if len(balances) != 2 || !balances[0].Amount.Equal(decimal.NewFromInt(500)) || !balances[1].Amount.Equal(decimal.NewFromInt(99)) {
+		t.Fatalf("saldos inesperados: %+v %+v", balances[0], balances[1])
+	}
+	history, _ := svc.Conversions(ctx, uid, 0)
+	if len(history) != 1 || history[0].ID != q.ID {
+		t.Fatalf("histórico inesperado: %+v", history)
+	}
+}
+
+func TestConversionService_QuoteValidation(t *testing.T) {
+	svc, _, uid := setupConversions(t)
+	ctx := context.Background()
+
+	if _, err := svc.Quote(ctx, uid, "BRL", "XYZ", decimal.NewFromInt(1)); !errors.Is(err, sharedVO.ErrUnsupportedCurrency) {
+		t.Fatalf("esperado ErrUnsupportedCurrency, obtido %v", err)
+	}
+	if _, err := svc.Quote(ctx, uid, "BRL", "brl", decimal.NewFromInt(1)); !errors.Is(err, entity.ErrSameCurrency) {
+		t.Fatalf("esperado ErrSameCurrency, obtido %v", err)
+	}
+	if _, err := svc.Quote(ctx, uid, "BRL", "USD", decimal.RequireFromString("1.001")); !errors.Is(err, ErrInvalidPrecision) {
+		t.Fatalf("esperado ErrInvalidPrecision, obtido %v", err)
+	}
+	if _, err := svc.Quote(ctx, uid, "USD", "SOL", decimal.NewFromInt(1)); !errors.Is(err, entity.ErrRateUnavailable) {
+		t.Fatalf("esperado ErrRateUnavailable, obtido %v", err)
+	}
+	// Cruzado pelo BRL com a precisão de 8 casas do BTC
+	q, err := svc.Quote(ctx, uid, "USD", "BTC", decimal.NewFromInt(100))
+	if err != nil || !q.BuyAmount.Equal(decimal.RequireFromString("0.00165")) {
+		t.Fatalf("cotação cruzada inesperada: %+v %v", q, err)
+	}
+}
+
+func TestConversionService_ExpiredAndInsufficient(t *testing.T) {
+	svc, _, uid := setupConversions(t)
+	ctx := context.Background()
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	q, err := svc.Quote(ctx, uid, "BRL", "USD", decimal.NewFromInt(100))
+	if err != nil {
+		t.Fatalf("cotação falhou: %v", err)
+	}
+	svc.now = func() time.Time { return now.Add(DefaultQuoteTTL) }
+	if _, err := svc.Execute(ctx, uid, q.ID); !errors.Is(err, entity.ErrQuoteExpired) {
+		t.Fatalf("esperado ErrQuoteExpired, obtido %v", err)
+	}
+
+	q, _ = svc.Quote(ctx, uid, "BRL", "USD", decimal.NewFromInt(5000))
+	if _, err := svc.Execute(ctx, uid, q.ID); !errors.Is(err, entity.ErrInsufficientFunds) {
+		t.Fatalf("esperado ErrInsufficientFunds, obtido %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+	fxDomain "financial-system-pro/internal/contexts/fx/domain/service"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultPriceTTL tempo em que um preço obtido das fontes é reutilizado sem nova consulta
+	DefaultPriceTTL = time.Minute
+	// DefaultPriceMaxAge idade máxima de um preço: acima dela a fonte é ignorada e o cache deixa de
+	// ser servido quando as fontes falham
+	DefaultPriceMaxAge = 15 * time.Minute
+)
+
+type cachedPrice struct {
+	price     entity.Price
+	fetchedAt time.Time
+}
+
+// PriceStore consulta as fontes de preço em ordem de prioridade, completando com as seguintes os
+// ativos que a anterior não cotou, e guarda os preços em cache. Se todas as fontes falham, o último
+// preço ainda dentro da idade máxima é servido marcado como desatualizado.
+type PriceStore struct {
+	feeds  []fxDomain.PriceFeed
+	ttl    time.Duration
+	maxAge time.Duration
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedPrice
+}
+
+// NewPriceStore cria o cache de preços sobre as fontes, da mais para a menos prioritária
+func NewPriceStore(logger *zap.Logger, feeds ...fxDomain.PriceFeed) *PriceStore {
+	return &PriceStore{
+		feeds:  feeds,
+		ttl:    DefaultPriceTTL,
+		maxAge: DefaultPriceMaxAge,
+		logger: logger,
+		now:    time.Now,
+		cache:  make(map[string]cachedPrice),
+	}
+}
+
+// WithTTL define por quanto tempo um preço é reutilizado sem consultar as fontes
+func (s *PriceStore) WithTTL(ttl time.Duration) *PriceStore {
+	if ttl > 0 {
+		s.ttl = ttl
+	}
+	return s
+}
+
+// WithMaxAge define a idade máxima aceita para um preço
+func (s *PriceStore) WithMaxAge(maxAge time.Duration) *PriceStore {
+	if maxAge > 0 {
+		s.maxAge = maxAge
+	}
+	return s
+}
+
+// Prices retorna os preços dos ativos na moeda de cotação e a lista dos ativos sem preço
+// disponível. O preço de um ativo na própria moeda de cotação é 1.
+func (s *PriceStore) Prices(ctx context.Context, quote string, assets []string) (map[string]entity.Price, []string) {
+	quote = strings.ToUpper(quote)
+	now := s.now()
+	out := make(map[string]entity.Price, len(assets))
+	var pending []string
+
+	s.mu.Lock()
+	for _, asset := range assets {
+		asset = strings.ToUpper(asset)
+		if _, done := out[asset]; done {
+			continue
+		}
+		if asset == quote {
+			out[asset] = entity.Price{Asset: asset, Quote: quote, Value: decimal.NewFromInt(1), Source: "identity", AsOf: now}
+			continue
+		}
+		if cached, ok := s.cache[entity.Pair(asset, quote)]; ok && now.Sub(cached.fetchedAt) < s.ttl {
+			out[asset] = cached.price
+			continue
+		}
+		pending = append(pending, asset)
+	}
+	s.mu.Unlock()
+
+	for _, feed := range s.feeds {
+		if len(pending) == 0 {
+			break
+		}
+		prices, err := feed.Prices(ctx, quote, pending)
+		if err != nil {
+			s.logger.Warn("price feed failed", zap.String("quote", quote), zap.Strings("assets", pending), zap.Error(err))
+			continue
+		}
+		remaining := pending[:0:0]
+		s.mu.Lock()
+		for _, asset := range pending {
+			price, ok := prices[asset]
+			if !ok || now.Sub(price.AsOf) > s.maxAge {
+				remaining = append(remaining, asset)
+				continue
+			}
+			out[asset] = price
+			s.cache[entity.Pair(asset, quote)] = cachedPrice{price: price, fetchedAt: now}
+		}
+		s.mu.Unlock()
+		pending = remaining
+	}
+
+	var missing []string
+	s.mu.Lock()
+	for _, asset := range pending {
+		cached, ok := s.cache[entity.Pair(asset, quote)]
+		if !ok || now.Sub(cached.price.AsOf) > s.maxAge {
+			missing = append(missing, asset)
+			continue
+		}
+		price := cached.price
+		price.Stale = true
+		out[asset] = price
+	}
+	s.mu.Unlock()
+	sort.Strings(missing)
+	return out, missing
+}
+
+// Price retorna o preço de um ativo ou ErrPriceUnavailable
+func (s *PriceStore) Price(ctx context.Context, asset, quote string) (entity.Price, error) {
+	prices, _ := s.Prices(ctx, quote, []string{asset})
+	price, ok := prices[strings.ToUpper(asset)]
+	if !ok {
+		return entity.Price{}, fmt.Errorf("%w: %s", entity.ErrPriceUnavailable, entity.Pair(asset, quote))
+	}
+	return price, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// fakeFeed fonte de preços controlável: conta as consultas e pode falhar
+type fakeFeed struct {
+	prices map[string]string
+	asOf   time.Time
+	fail   bool
+	calls  int
+}
+
+func (f *fakeFeed) Prices(_ context.Context, quote string, assets []string) (map[string]entity.Price, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("feed down")
+	}
+	out := map[string]entity.Price{}
+	for _, asset := range assets {
+		if v, ok := f.prices[asset]; ok {
+			out[asset] = entity.Price{Asset: asset, Quote: quote, Value: decimal.RequireFromString(v), Source: "fake", AsOf: f.asOf}
+		}
+	}
+	return out, nil
+}
+
+func TestPriceStore_FallsBackAcrossFeedsAndCaches(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	crypto := &fakeFeed{prices: map[string]string{"ETH": "20000", "BTC": "350000"}, asOf: now}
+	fiat := &fakeFeed{prices: map[string]string{"USD": "5", "ETH": "1"}, asOf: now}
+	store := NewPriceStore(zap.NewNop(), crypto, fiat)
+	store.now = func() time.Time { return now }
+
+	prices, missing := store.Prices(context.Background(), "brl", []string{"eth", "USD", "BRL", "SOL"})
+	if !prices["ETH"].Value.Equal(decimal.NewFromInt(20000)) {
+		t.Fatalf("ETH deveria vir da primeira fonte, obtido %s", prices["ETH"].Value)
+	}
+	if !prices["USD"].Value.Equal(decimal.NewFromInt(5)) || !prices["BRL"].Value.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("USD pela segunda fonte e BRL = 1, obtido %+v", prices)
+	}
+	if len(missing) != 1 || missing[0] != "SOL" {
+		t.Fatalf("esperado SOL sem preço, obtido %v", missing)
+	}
+
+	now = now.Add(30 * time.Second)
+	store.Prices(context.Background(), "BRL", []string{"ETH", "USD"})
+	if crypto.calls != 1 || fiat.calls != 1 {
+		t.Fatalf("dentro do TTL os preços deveriam vir do cache, consultas %d/%d", crypto.calls, fiat.calls)
+	}
+}
+
+func TestPriceStore_ServesStaleWithinMaxAge(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	feed := &fakeFeed{prices: map[string]string{"ETH": "20000"}, asOf: now}
+	store := NewPriceStore(zap.NewNop(), feed).WithTTL(time.Minute).WithMaxAge(10 * time.Minute)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := store.Price(ctx, "ETH", "BRL"); err != nil {
+		t.Fatalf("preço inicial: %v", err)
+	}
+
+	feed.fail = true
+	now = now.Add(5 * time.Minute)
+	price, err := store.Price(ctx, "ETH", "BRL")
+	if err != nil || !price.Stale {
+		t.Fatalf("com a fonte fora, o cache deveria ser servido como desatualizado: %+v, %v", price, err)
+	}
+
+	now = now.Add(6 * time.Minute)
+	if _, err := store.Price(ctx, "ETH", "BRL"); !errors.Is(err, entity.ErrPriceUnavailable) {
+		t.Fatalf("acima da idade máxima esperado ErrPriceUnavailable, obtido %v", err)
+	}
+
+	// preço antigo vindo da fonte também é recusado
+	feed.fail = false
+	feed.asOf = now.Add(-time.Hour)
+	if _, err := store.Price(ctx, "ETH", "BRL"); !errors.Is(err, entity.ErrPriceUnavailable) {
+		t.Fatalf("preço da fonte acima da idade máxima deveria ser recusado, obtido %v", err)
+	}
+}
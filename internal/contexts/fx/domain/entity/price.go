@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrPriceUnavailable nenhuma fonte tem preço recente o bastante para o ativo
+var ErrPriceUnavailable = errors.New("price unavailable")
+
+// Price preço de 1 unidade do ativo na moeda de cotação
+type Price struct {
+	Asset  string          `json:"asset"`
+	Quote  string          `json:"quote"`
+	Value  decimal.Decimal `json:"value"`
+	Source string          `json:"source"`
+	AsOf   time.Time       `json:"as_of"`
+	Stale  bool            `json:"stale,omitempty"` // servido do cache após falha das fontes
+}
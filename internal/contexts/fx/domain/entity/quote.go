@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrSameCurrency      = errors.New("source and target currencies must differ")
+	ErrInvalidAmount     = errors.New("amount must be positive")
+	ErrAmountTooSmall    = errors.New("amount too small to convert")
+	ErrInvalidSpread     = errors.New("spread must be between 0 and 10000 bps")
+	ErrRateUnavailable   = errors.New("exchange rate unavailable")
+	ErrQuoteExpired      = errors.New("fx quote expired")
+	ErrQuoteExecuted     = errors.New("fx quote already executed")
+	ErrInsufficientFunds = errors.New("insufficient balance in source currency")
+)
+
+// Rate cotação de mercado (preço médio): 1 Base = Mid Quote
+type Rate struct {
+	Base   string          `json:"base"`
+	Quote  string          `json:"quote"`
+	Mid    decimal.Decimal `json:"mid"`
+	Source string          `json:"source"`
+	AsOf   time.Time       `json:"as_of"`
+}
+
+// Pair código do par no formato BASE/QUOTE
+func Pair(base, quote string) string {
+	return strings.ToUpper(base) + "/" + strings.ToUpper(quote)
+}
+
+// QuoteStatus estado de uma cotação
+type QuoteStatus string
+
+const (
+	QuoteOpen     QuoteStatus = "open"
+	QuoteExecuted QuoteStatus = "executed"
+)
+
+// Quote cotação firme de conversão para um usuário, válida até ExpiresAt.
+// Rate já inclui o spread da plataforma: BuyAmount = SellAmount * Rate (arredondado para baixo).
+type Quote struct {
+	ID         uuid.UUID       `json:"id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	SellAmount decimal.Decimal `json:"sell_amount"`
+	BuyAmount  decimal.Decimal `json:"buy_amount"`
+	MidRate    decimal.Decimal `json:"mid_rate"`
+	Rate       decimal.Decimal `json:"rate"`
+	SpreadBps  int             `json:"spread_bps"`
+	Source     string          `json:"source"`
+	Status     QuoteStatus     `json:"status"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ExpiresAt  time.Time       `json:"expires_at"`
+	ExecutedAt *time.Time      `json:"executed_at,omitempty"`
+}
+
+// NewQuote aplica o spread sobre a cotação de mercado e calcula o valor recebido com a precisão
+// da moeda de destino
+func NewQuote(userID uuid.UUID, rate Rate, sellAmount decimal.Decimal, spreadBps int, buyDecimals int32, ttl time.Duration, now time.Time) (*Quote, error) {
+	if strings.EqualFold(rate.Base, rate.Quote) {
+		return nil, ErrSameCurrency
+	}
+	if !sellAmount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	if spreadBps < 0 || spreadBps >= 10000 {
+		return nil, ErrInvalidSpread
+	}
+	if !rate.Mid.IsPositive() {
+		return nil, ErrRateUnavailable
+	}
+
+	applied := rate.Mid.Mul(decimal.NewFromInt(int64(10000 - spreadBps))).Div(decimal.NewFromInt(10000))
+	buy := sellAmount.Mul(applied).RoundDown(buyDecimals)
+	if !buy.IsPositive() {
+		return nil, ErrAmountTooSmall
+	}
+	return &Quote{
+		ID:         uuid.New(),
+		UserID:     userID,
+		From:       strings.ToUpper(rate.Base),
+		To:         strings.ToUpper(rate.Quote),
+		SellAmount: sellAmount,
+		BuyAmount:  buy,
+		MidRate:    rate.Mid,
+		Rate:       applied,
+		SpreadBps:  spreadBps,
+		Source:     rate.Source,
+		Status:     QuoteOpen,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	}, nil
+}
+
+// Execute marca a cotação como executada se ainda estiver aberta e dentro da validade
+func (q *Quote) Execute(now time.Time) error {
+	if q.Status != QuoteOpen {
+		return ErrQuoteExecuted
+	}
+	if !now.Before(q.ExpiresAt) {
+		return ErrQuoteExpired
+	}
+	q.Status = QuoteExecuted
+	q.ExecutedAt = &now
+	return nil
+}
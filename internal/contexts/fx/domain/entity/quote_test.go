@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewQuote_AppliesSpreadAndRoundsDown(t *testing.T) {
+	now := time.Now()
+	rate := Rate{Base: "usd", Quote: "brl", Mid: decimal.RequireFromString("5.1234")}
+
+	q, err := NewQuote(uuid.New(), rate, decimal.NewFromInt(100), 50, 2, 30*time.Second, now)
+	if err != nil {
+		t.Fatalf("cotação falhou: %v", err)
+	}
+	// 5.1234 * 0.995 = 5.097783 -> 509.7783 arredondado para baixo
+	if !q.Rate.Equal(decimal.RequireFromString("5.097783")) || !q.BuyAmount.Equal(decimal.RequireFromString("509.77")) {
+		t.Fatalf("cotação inesperada: rate=%s buy=%s", q.Rate, q.BuyAmount)
+	}
+	if q.From != "USD" || q.To != "BRL" || q.Status != QuoteOpen || !q.ExpiresAt.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("campos inesperados: %+v", q)
+	}
+}
+
+func TestNewQuote_Validation(t *testing.T) {
+	now := time.Now()
+	mid := Rate{Base: "USD", Quote: "BRL", Mid: decimal.NewFromInt(5)}
+	cases := []struct {
+		name   string
+		rate   Rate
+		amount decimal.Decimal
+		spread int
+		want   error
+	}{
+		{"mesma moeda", Rate{Base: "BRL", Quote: "brl", Mid: decimal.NewFromInt(1)}, decimal.NewFromInt(1), 0, ErrSameCurrency},
+		{"valor zero", mid, decimal.Zero, 0, ErrInvalidAmount},
+		{"spread inválido", mid, decimal.NewFromInt(1), 10000, ErrInvalidSpread},
+		{"sem cotação", Rate{Base: "USD", Quote: "BRL"}, decimal.NewFromInt(1), 0, ErrRateUnavailable},
+		{"valor pequeno demais", mid, decimal.RequireFromString("0.001"), 0, ErrAmountTooSmall},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewQuote(uuid.New(), tc.rate, tc.amount, tc.spread, 2, time.Minute, now); !errors.Is(err, tc.want) {
+				t.Fatalf("esperado %v, obtido %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestQuote_Execute(t *testing.T) {
+	now := time.Now()
+	q, _ := NewQuote(uuid.New(), Rate{Base: "USD", Quote: "BRL", Mid: decimal.NewFromInt(5)}, decimal.NewFromInt(10), 0, 2, time.Minute, now)
+
+	if err := q.Execute(now.Add(time.Minute)); !errors.Is(err, ErrQuoteExpired) {
+		t.Fatalf("esperado ErrQuoteExpired, obtido %v", err)
+	}
+	if err := q.Execute(now.Add(time.Second)); err != nil {
+		t.Fatalf("execução falhou: %v", err)
+	}
+	if q.Status != QuoteExecuted || q.ExecutedAt == nil {
+		t.Fatalf("cotação deveria estar executada: %+v", q)
+	}
+	if err := q.Execute(now.Add(2 * time.Second)); !errors.Is(err, ErrQuoteExecuted) {
+		t.Fatalf("esperado ErrQuoteExecuted, obtido %v", err)
+	}
+}
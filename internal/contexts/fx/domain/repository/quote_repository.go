@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// QuoteRepository persiste as cotações e executa as conversões
+type QuoteRepository interface {
+	Create(ctx context.Context, q *entity.Quote) error
+	// FindByID retorna nil, nil quando a cotação não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Quote, error)
+	// Execute grava a cotação como executada (se ainda aberta e válida), debita SellAmount em From e
+	// credita BuyAmount em To numa única transação. Saldo insuficiente retorna ErrInsufficientFunds.
+	Execute(ctx context.Context, q *entity.Quote) error
+	// ListExecuted lista as conversões do usuário, mais recentes primeiro
+	ListExecuted(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Quote, error)
+}
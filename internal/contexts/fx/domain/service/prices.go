@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+)
+
+// PriceFeed fonte de preços de ativos. Retorna apenas os ativos que conhece, indexados pelo código
+// em maiúsculas; o erro indica falha da própria fonte.
+type PriceFeed interface {
+	Prices(ctx context.Context, quote string, assets []string) (map[string]entity.Price, error)
+}
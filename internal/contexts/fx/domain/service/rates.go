@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// RateProvider fornece a cotação de mercado de um par (porta para fontes estáticas ou HTTP)
+type RateProvider interface {
+	Rate(ctx context.Context, base, quote string) (entity.Rate, error)
+}
+
+// ResolveRate encontra o par na tabela "BASE/QUOTE" diretamente, pelo inverso ou cruzando pela
+// moeda pivot (ex.: USD/EUR = USD/BRL * BRL/EUR)
+func ResolveRate(table map[string]decimal.Decimal, base, quote, pivot string) (decimal.Decimal, bool) {
+	base, quote, pivot = strings.ToUpper(base), strings.ToUpper(quote), strings.ToUpper(pivot)
+	direct := func(b, q string) (decimal.Decimal, bool) {
+		if b == q {
+			return decimal.NewFromInt(1), true
+		}
+		if mid, ok := table[entity.Pair(b, q)]; ok && mid.IsPositive() {
+			return mid, true
+		}
+		if inv, ok := table[entity.Pair(q, b)]; ok && inv.IsPositive() {
+			return decimal.NewFromInt(1).DivRound(inv, 18), true
+		}
+		return decimal.Zero, false
+	}
+
+	if mid, ok := direct(base, quote); ok {
+		return mid, true
+	}
+	if pivot == "" {
+		return decimal.Zero, false
+	}
+	toPivot, ok := direct(base, pivot)
+	if !ok {
+		return decimal.Zero, false
+	}
+	fromPivot, ok := direct(pivot, quote)
+	if !ok {
+		return decimal.Zero, false
+	}
+	return toPivot.Mul(fromPivot), true
+}
@@ -0,0 +1,249 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+	txEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresQuoteRepository implementa QuoteRepository usando PostgreSQL. A execução também
+// movimenta os saldos da carteira e lança a conversão no razão de transações.
+type PostgresQuoteRepository struct {
+	conn         database.Connection
+	schema       string
+	walletSchema string
+	ledgerSchema string
+}
+
+// NewPostgresQuoteRepository cria um novo repositório de cotações de câmbio
+func NewPostgresQuoteRepository(conn database.Connection) *PostgresQuoteRepository {
+	return &PostgresQuoteRepository{
+		conn:         conn,
+		schema:       "fx_context",
+		walletSchema: "user_context",
+		ledgerSchema: "transaction_context",
+	}
+}
+
+const quoteColumns = `id, user_id, from_currency, to_currency, sell_amount, buy_amount, mid_rate, rate,
+	spread_bps, source, status, created_at, expires_at, executed_at`
+
+type execer interface {
+	Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Create insere uma nova cotação
+func (r *PostgresQuoteRepository) Create(ctx context.Context, q *entity.Quote) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.quotes (`+quoteColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`,
+		q.ID,
+		q.UserID,
+		q.From,
+		q.To,
+		q.SellAmount,
+		q.BuyAmount,
+		q.MidRate,
+		q.Rate,
+		q.SpreadBps,
+		q.Source,
+		string(q.Status),
+		q.CreatedAt,
+		q.ExpiresAt,
+		q.ExecutedAt,
+	)
+	return err
+}
+
+// FindByID busca uma cotação por ID
+func (r *PostgresQuoteRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Quote, error) {
+	q, err := scanQuote(r.conn.QueryRow(ctx, `SELECT `+quoteColumns+` FROM `+r.schema+`.quotes WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return q, nil
+}
+
+// Execute consome a cotação, movimenta os dois saldos e lança a conversão no razão na mesma transação
+func (r *PostgresQuoteRepository) Execute(ctx context.Context, q *entity.Quote) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(ctx, `
+		UPDATE `+r.schema+`.quotes
+		SET status = $2, executed_at = $3
+		WHERE id = $1 AND status = 'open' AND expires_at > $3
+	`, q.ID, string(q.Status), q.ExecutedAt)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return entity.ErrQuoteExecuted
+	}
+
+	if err := r.adjustBalance(ctx, tx, q.UserID, q.From, q.SellAmount.Neg()); err != nil {
+		return err
+	}
+	if err := r.adjustBalance(ctx, tx, q.UserID, q.To, q.BuyAmount); err != nil {
+		return err
+	}
+	if err := r.recordConversion(ctx, tx, q); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListExecuted lista as conversões executadas do usuário
+func (r *PostgresQuoteRepository) ListExecuted(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Quote, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+quoteColumns+`
+		FROM `+r.schema+`.quotes
+		WHERE user_id = $1 AND status = 'executed'
+		ORDER BY executed_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Quote
+	for rows.Next() {
+		q, err := scanQuote(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, q)
+	}
+	return out, rows.Err()
+}
+
+// adjustBalance soma delta ao saldo da moeda; débitos só passam se o saldo cobrir o valor.
+// A moeda base fica em wallet_info.balance e as demais em wallet_balances.
+func (r *PostgresQuoteRepository) adjustBalance(ctx context.Context, db execer, userID uuid.UUID, currency string, delta decimal.Decimal) error {
+	var (
+		result database.Result
+		err    error
+	)
+	now := time.Now()
+	switch {
+	case currency == string(sharedVO.BaseCurrency):
+		result, err = db.Exec(ctx, `
+			UPDATE `+r.walletSchema+`.wallet_info
+			SET balance = balance + $2, updated_at = $3
+			WHERE user_id = $1 AND balance + $2 >= 0
+		`, userID, delta, now)
+	case delta.IsNegative():
+		result, err = db.Exec(ctx, `
+			UPDATE `+r.walletSchema+`.wallet_balances
+			SET balance = balance + $3, updated_at = $4
+			WHERE user_id = $1 AND currency = $2 AND balance + $3 >= 0
+		`, userID, currency, delta, now)
+	default:
+		result, err = db.Exec(ctx, `
+			INSERT INTO `+r.walletSchema+`.wallet_balances AS b (user_id, currency, balance, updated_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, currency) DO UPDATE
+			SET balance = b.balance + EXCLUDED.balance, updated_at = EXCLUDED.updated_at
+		`, userID, currency, delta, now)
+	}
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return entity.ErrInsufficientFunds
+	}
+	return nil
+}
+
+// recordConversion lança no razão a perna em moeda base da conversão (débito na venda, crédito na
+// compra), que é a moeda dos extratos e dos saldos históricos da carteira
+func (r *PostgresQuoteRepository) recordConversion(ctx context.Context, db execer, q *entity.Quote) error {
+	var (
+		amount decimal.Decimal
+		sold   bool
+	)
+	switch string(sharedVO.BaseCurrency) {
+	case q.From:
+		amount, sold = q.SellAmount, true
+	case q.To:
+		amount = q.BuyAmount
+	default:
+		return nil
+	}
+	at := time.Now()
+	if q.ExecutedAt != nil {
+		at = *q.ExecutedAt
+	}
+	_, err := db.Exec(ctx, `
+		INSERT INTO `+r.ledgerSchema+`.transactions (id, user_id, type, amount, status, transaction_hash,
+			from_address, to_address, callback_url, error_message, created_at, updated_at, completed_at, risk_score, fee, chain)
+		SELECT $1, w.user_id, $3, $4, $5, $6,
+			CASE WHEN $7 THEN w.address ELSE $8 END, CASE WHEN $7 THEN $8 ELSE w.address END,
+			'', '', $9, $9, $9, 0, 0, ''
+		FROM `+r.walletSchema+`.wallet_info w
+		WHERE w.user_id = $2
+	`, uuid.New(), q.UserID, string(txEntity.TransactionTypeConversion), amount, string(txEntity.TransactionStatusCompleted),
+		"fx-"+q.ID.String(), sold, conversionAddress(q.ID), at)
+	return err
+}
+
+// conversionAddress endereço interno que representa a contraparte de câmbio no razão
+func conversionAddress(quoteID uuid.UUID) string {
+	return "fx:" + quoteID.String()
+}
+
+func scanQuote(row rowScanner) (*entity.Quote, error) {
+	q := &entity.Quote{}
+	var (
+		status     string
+		executedAt sql.NullTime
+	)
+	err := row.Scan(
+		&q.ID,
+		&q.UserID,
+		&q.From,
+		&q.To,
+		&q.SellAmount,
+		&q.BuyAmount,
+		&q.MidRate,
+		&q.Rate,
+		&q.SpreadBps,
+		&q.Source,
+		&status,
+		&q.CreatedAt,
+		&q.ExpiresAt,
+		&executedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q.Status = entity.QuoteStatus(status)
+	if executedAt.Valid {
+		q.ExecutedAt = &executedAt.Time
+	}
+	return q, nil
+}
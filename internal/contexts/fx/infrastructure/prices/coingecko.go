@@ -0,0 +1,92 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCoinGeckoIDs identificadores CoinGecko dos ativos cripto suportados
+var DefaultCoinGeckoIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"USDT": "tether",
+	"TRX":  "tron",
+	"SOL":  "solana",
+}
+
+// CoinGeckoFeed consulta a API simple/price no formato da CoinGecko:
+// GET {endpoint}/simple/price?ids=bitcoin,ethereum&vs_currencies=brl&include_last_updated_at=true
+type CoinGeckoFeed struct {
+	endpoint string
+	apiKey   string
+	ids      map[string]string
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewCoinGeckoFeed cria a fonte de preços; apiKey vazia usa a API pública
+func NewCoinGeckoFeed(endpoint, apiKey string) *CoinGeckoFeed {
+	return &CoinGeckoFeed{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		apiKey:   apiKey,
+		ids:      DefaultCoinGeckoIDs,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+	}
+}
+
+// Prices busca os preços dos ativos com identificador conhecido
+func (f *CoinGeckoFeed) Prices(ctx context.Context, quote string, assets []string) (map[string]entity.Price, error) {
+	bySymbol := make(map[string]string, len(assets))
+	var ids []string
+	for _, asset := range assets {
+		symbol := strings.ToUpper(asset)
+		if id, ok := f.ids[symbol]; ok {
+			bySymbol[symbol] = id
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return map[string]entity.Price{}, nil
+	}
+
+	u, err := url.Parse(f.endpoint + "/simple/price")
+	if err != nil {
+		return nil, err
+	}
+	vs := strings.ToLower(quote)
+	q := u.Query()
+	q.Set("ids", strings.Join(ids, ","))
+	q.Set("vs_currencies", vs)
+	q.Set("include_last_updated_at", "true")
+	if f.apiKey != "" {
+		q.Set("x_cg_demo_api_key", f.apiKey)
+	}
+	u.RawQuery = q.Encode()
+
+	var body map[string]map[string]decimal.Decimal
+	if err := getJSON(ctx, f.client, u.String(), &body); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]entity.Price, len(bySymbol))
+	for symbol, id := range bySymbol {
+		value, ok := body[id][vs]
+		if !ok || !value.IsPositive() {
+			continue
+		}
+		asOf := f.now()
+		if updated, ok := body[id]["last_updated_at"]; ok {
+			asOf = time.Unix(updated.IntPart(), 0).UTC()
+		}
+		out[symbol] = entity.Price{Asset: symbol, Quote: strings.ToUpper(quote), Value: value, Source: "coingecko", AsOf: asOf}
+	}
+	return out, nil
+}
@@ -0,0 +1,90 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// HTTPFeed consulta uma API de preços no formato GET {endpoint}?symbols=ETH,BTC&quote=BRL
+// respondendo {"quote": "BRL", "prices": {"ETH": 20000.5}, "as_of": "2025-01-01T00:00:00Z"}.
+// Sem as_of os preços valem pelo horário da resposta.
+type HTTPFeed struct {
+	endpoint string
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewHTTPFeed cria a fonte de preços HTTP
+func NewHTTPFeed(endpoint string) *HTTPFeed {
+	return &HTTPFeed{endpoint: endpoint, client: &http.Client{Timeout: 10 * time.Second}, now: time.Now}
+}
+
+// Prices busca os preços dos ativos em uma única requisição
+func (f *HTTPFeed) Prices(ctx context.Context, quote string, assets []string) (map[string]entity.Price, error) {
+	quote = strings.ToUpper(quote)
+	symbols := make([]string, len(assets))
+	for i, asset := range assets {
+		symbols[i] = strings.ToUpper(asset)
+	}
+	u, err := url.Parse(f.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("symbols", strings.Join(symbols, ","))
+	q.Set("quote", quote)
+	u.RawQuery = q.Encode()
+
+	var body struct {
+		Prices map[string]decimal.Decimal `json:"prices"`
+		AsOf   *time.Time                 `json:"as_of"`
+	}
+	if err := getJSON(ctx, f.client, u.String(), &body); err != nil {
+		return nil, err
+	}
+	asOf := f.now()
+	if body.AsOf != nil {
+		asOf = *body.AsOf
+	}
+
+	out := make(map[string]entity.Price, len(symbols))
+	for _, symbol := range symbols {
+		value, ok := body.Prices[symbol]
+		if !ok || !value.IsPositive() {
+			continue
+		}
+		out[symbol] = entity.Price{Asset: symbol, Quote: quote, Value: value, Source: u.Host, AsOf: asOf}
+	}
+	return out, nil
+}
+
+// getJSON faz o GET e decodifica a resposta; falhas de rede ou status diferente de 200 viram
+// ErrPriceUnavailable
+func getJSON(ctx context.Context, client *http.Client, rawURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrPriceUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: provider returned %d", entity.ErrPriceUnavailable, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrPriceUnavailable, err)
+	}
+	return nil
+}
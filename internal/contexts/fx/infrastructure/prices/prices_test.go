@@ -0,0 +1,86 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestStaticFeed_ResolvesPairsAndSkipsUnknown(t *testing.T) {
+	feed := NewStaticFeed(map[string]decimal.Decimal{
+		"ETH/BRL": decimal.NewFromInt(20000),
+		"USD/BRL": decimal.NewFromInt(5),
+	}, "BRL")
+	prices, err := feed.Prices(context.Background(), "USD", []string{"eth", "SOL"})
+	if err != nil {
+		t.Fatalf("static: %v", err)
+	}
+	if len(prices) != 1 || !prices["ETH"].Value.Equal(decimal.NewFromInt(4000)) {
+		t.Fatalf("esperado ETH = 4000 USD pela pivot, obtido %+v", prices)
+	}
+	if time.Since(prices["ETH"].AsOf) > time.Minute {
+		t.Fatalf("preço fixo deveria ser atual, obtido %s", prices["ETH"].AsOf)
+	}
+}
+
+func TestHTTPFeed_BatchRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbols") != "ETH,BTC" || r.URL.Query().Get("quote") != "BRL" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"quote": "BRL", "prices": {"ETH": 20000.5, "BTC": 0}, "as_of": "2025-05-01T12:00:00Z"}`))
+	}))
+	defer srv.Close()
+
+	prices, err := NewHTTPFeed(srv.URL).Prices(context.Background(), "brl", []string{"eth", "btc"})
+	if err != nil {
+		t.Fatalf("http: %v", err)
+	}
+	if len(prices) != 1 || !prices["ETH"].Value.Equal(decimal.RequireFromString("20000.5")) {
+		t.Fatalf("esperado apenas ETH, obtido %+v", prices)
+	}
+	if !prices["ETH"].AsOf.Equal(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("as_of da resposta não aplicado: %s", prices["ETH"].AsOf)
+	}
+}
+
+func TestCoinGeckoFeed_MapsIDsAndFailures(t *testing.T) {
+	down := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.URL.Path != "/simple/price" || r.URL.Query().Get("ids") != "ethereum,solana" || r.URL.Query().Get("vs_currencies") != "brl" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ethereum": {"brl": 20000, "last_updated_at": 1746100800}, "solana": {"brl": 900}}`))
+	}))
+	defer srv.Close()
+	feed := NewCoinGeckoFeed(srv.URL+"/", "")
+
+	prices, err := feed.Prices(context.Background(), "BRL", []string{"ETH", "SOL", "USD"})
+	if err != nil {
+		t.Fatalf("coingecko: %v", err)
+	}
+	if len(prices) != 2 || !prices["SOL"].Value.Equal(decimal.NewFromInt(900)) {
+		t.Fatalf("esperado ETH e SOL, obtido %+v", prices)
+	}
+	if prices["ETH"].AsOf.Unix() != 1746100800 {
+		t.Fatalf("last_updated_at não aplicado: %s", prices["ETH"].AsOf)
+	}
+
+	down = true
+	if _, err := feed.Prices(context.Background(), "BRL", []string{"ETH"}); !errors.Is(err, entity.ErrPriceUnavailable) {
+		t.Fatalf("esperado ErrPriceUnavailable, obtido %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/fx/domain/service"
+	"financial-system-pro/internal/contexts/fx/infrastructure/rates"
+
+	"github.com/shopspring/decimal"
+)
+
+// RateFeed usa as cotações de um RateProvider como preços (ex.: USD/BRL para saldos em dólar)
+type RateFeed struct {
+	rates  service.RateProvider
+	static bool
+	now    func() time.Time
+}
+
+// NewRateFeed cria a fonte de preços sobre o provedor de cotações
+func NewRateFeed(provider service.RateProvider) *RateFeed {
+	return &RateFeed{rates: provider, now: time.Now}
+}
+
+// NewStaticFeed preços fixos por par ("ETH/BRL": "20000"), resolvidos pelo inverso ou pela moeda
+// pivot; por serem configurados, são sempre considerados atuais
+func NewStaticFeed(table map[string]decimal.Decimal, pivot string) *RateFeed {
+	return &RateFeed{rates: rates.NewStaticProvider(table, pivot), static: true, now: time.Now}
+}
+
+// LoadStaticFeedFile lê os preços fixos de um arquivo JSON no formato da tabela de câmbio
+func LoadStaticFeedFile(path, pivot string) (*RateFeed, error) {
+	provider, err := rates.LoadStaticProviderFile(path, pivot)
+	if err != nil {
+		return nil, err
+	}
+	return &RateFeed{rates: provider, static: true, now: time.Now}, nil
+}
+
+// Prices consulta o par de cada ativo; pares sem cotação ficam de fora
+func (f *RateFeed) Prices(ctx context.Context, quote string, assets []string) (map[string]entity.Price, error) {
+	out := make(map[string]entity.Price, len(assets))
+	for _, asset := range assets {
+		rate, err := f.rates.Rate(ctx, asset, quote)
+		if errors.Is(err, entity.ErrRateUnavailable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		asOf := rate.AsOf
+		if f.static {
+			asOf = f.now()
+		}
+		out[strings.ToUpper(asset)] = entity.Price{
+			Asset:  rate.Base,
+			Quote:  rate.Quote,
+			Value:  rate.Mid,
+			Source: rate.Source,
+			AsOf:   asOf,
+		}
+	}
+	return out, nil
+}
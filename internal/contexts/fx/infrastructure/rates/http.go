@@ -0,0 +1,103 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultHTTPCacheTTL tempo padrão em que uma cotação obtida por HTTP é reutilizada
+const DefaultHTTPCacheTTL = 30 * time.Second
+
+// HTTPProvider consulta uma API de câmbio no formato GET {endpoint}?base=USD&symbols=BRL
+// respondendo {"base": "USD", "rates": {"BRL": 5.1}}, com cache por par
+type HTTPProvider struct {
+	endpoint string
+	client   *http.Client
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]entity.Rate
+}
+
+// NewHTTPProvider cria o provedor HTTP; ttl <= 0 usa DefaultHTTPCacheTTL
+func NewHTTPProvider(endpoint string, ttl time.Duration) *HTTPProvider {
+	if ttl <= 0 {
+		ttl = DefaultHTTPCacheTTL
+	}
+	return &HTTPProvider{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    make(map[string]entity.Rate),
+	}
+}
+
+// Rate retorna a cotação do par, do cache enquanto estiver dentro do TTL
+func (p *HTTPProvider) Rate(ctx context.Context, base, quote string) (entity.Rate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	pair := entity.Pair(base, quote)
+
+	p.mu.Lock()
+	cached, ok := p.cache[pair]
+	p.mu.Unlock()
+	if ok && p.now().Sub(cached.AsOf) < p.ttl {
+		return cached, nil
+	}
+
+	rate, err := p.fetch(ctx, base, quote)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+	p.mu.Lock()
+	p.cache[pair] = rate
+	p.mu.Unlock()
+	return rate, nil
+}
+
+func (p *HTTPProvider) fetch(ctx context.Context, base, quote string) (entity.Rate, error) {
+	u, err := url.Parse(p.endpoint)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+	q := u.Query()
+	q.Set("base", base)
+	q.Set("symbols", quote)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return entity.Rate{}, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return entity.Rate{}, fmt.Errorf("%w: %v", entity.ErrRateUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return entity.Rate{}, fmt.Errorf("%w: provider returned %d", entity.ErrRateUnavailable, resp.StatusCode)
+	}
+
+	var body struct {
+		Rates map[string]decimal.Decimal `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return entity.Rate{}, fmt.Errorf("%w: %v", entity.ErrRateUnavailable, err)
+	}
+	mid, ok := body.Rates[quote]
+	if !ok || !mid.IsPositive() {
+		return entity.Rate{}, fmt.Errorf("%w: %s", entity.ErrRateUnavailable, entity.Pair(base, quote))
+	}
+	return entity.Rate{Base: base, Quote: quote, Mid: mid, Source: u.Host, AsOf: p.now()}, nil
+}
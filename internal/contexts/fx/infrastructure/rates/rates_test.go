@@ -0,0 +1,82 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestStaticProvider_DirectInverseAndCross(t *testing.T) {
+	p, err := LoadStaticProvider(strings.NewReader(`{"USD/BRL": "5", "eur/brl": "5.5"}`), "BRL")
+	if err != nil {
+		t.Fatalf("tabela inválida: %v", err)
+	}
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"USD/BRL": "5",
+		"BRL/USD": "0.2",
+		"USD/EUR": "0.90909090909090909",
+	}
+	for pair, want := range cases {
+		base, quote, _ := strings.Cut(pair, "/")
+		rate, err := p.Rate(ctx, base, quote)
+		if err != nil {
+			t.Fatalf("%s: %v", pair, err)
+		}
+		if !rate.Mid.Equal(decimal.RequireFromString(want)) {
+			t.Fatalf("%s: esperado %s, obtido %s", pair, want, rate.Mid)
+		}
+	}
+	if _, err := p.Rate(ctx, "BTC", "USD"); !errors.Is(err, entity.ErrRateUnavailable) {
+		t.Fatalf("esperado ErrRateUnavailable, obtido %v", err)
+	}
+}
+
+func TestLoadStaticProvider_RejectsInvalidPairs(t *testing.T) {
+	for _, body := range []string{`{"USDBRL": "5"}`, `{"USD/BRL": "0"}`, `[]`} {
+		if _, err := LoadStaticProvider(strings.NewReader(body), "BRL"); err == nil {
+			t.Fatalf("tabela %s deveria ser rejeitada", body)
+		}
+	}
+}
+
+func TestHTTPProvider_CachesPerPair(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Query().Get("base") != "USD" || r.URL.Query().Get("symbols") != "BRL" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"base": "USD", "rates": {"BRL": 5.25}}`))
+	}))
+	defer srv.Close()
+
+	p := NewHTTPProvider(srv.URL, 0)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		rate, err := p.Rate(ctx, "usd", "brl")
+		if err != nil {
+			t.Fatalf("cotação falhou: %v", err)
+		}
+		if !rate.Mid.Equal(decimal.RequireFromString("5.25")) {
+			t.Fatalf("cotação inesperada: %s", rate.Mid)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("segunda consulta deveria vir do cache, chamadas: %d", calls)
+	}
+
+	if _, err := p.Rate(ctx, "EUR", "BRL"); !errors.Is(err, entity.ErrRateUnavailable) {
+		t.Fatalf("esperado ErrRateUnavailable, obtido %v", err)
+	}
+}
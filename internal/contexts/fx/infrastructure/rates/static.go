@@ -0,0 +1,81 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/fx/domain/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// StaticProvider cotações fixas por par ("USD/BRL": "5.10"); pares ausentes são resolvidos pelo
+// inverso ou cruzando pela moeda pivot
+type StaticProvider struct {
+	table  map[string]decimal.Decimal
+	pivot  string
+	source string
+	asOf   time.Time
+}
+
+// NewStaticProvider cria o provedor a partir da tabela de pares
+func NewStaticProvider(table map[string]decimal.Decimal, pivot string) *StaticProvider {
+	normalized := make(map[string]decimal.Decimal, len(table))
+	for pair, mid := range table {
+		normalized[strings.ToUpper(pair)] = mid
+	}
+	return &StaticProvider{table: normalized, pivot: pivot, source: "static", asOf: time.Now()}
+}
+
+// LoadStaticProvider lê a tabela de pares de um objeto JSON
+func LoadStaticProvider(r io.Reader, pivot string) (*StaticProvider, error) {
+	var table map[string]decimal.Decimal
+	if err := json.NewDecoder(r).Decode(&table); err != nil {
+		return nil, fmt.Errorf("decode fx rates: %w", err)
+	}
+	for pair, mid := range table {
+		if base, quote, ok := strings.Cut(pair, "/"); !ok || base == "" || quote == "" {
+			return nil, fmt.Errorf("invalid fx pair %q: expected BASE/QUOTE", pair)
+		}
+		if !mid.IsPositive() {
+			return nil, fmt.Errorf("invalid fx rate for %s: must be positive", pair)
+		}
+	}
+	return NewStaticProvider(table, pivot), nil
+}
+
+// LoadStaticProviderFile lê a tabela de pares de um arquivo JSON
+func LoadStaticProviderFile(path, pivot string) (*StaticProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := LoadStaticProvider(f, pivot)
+	if err != nil {
+		return nil, err
+	}
+	p.source = "file"
+	return p, nil
+}
+
+// Rate retorna a cotação do par
+func (p *StaticProvider) Rate(ctx context.Context, base, quote string) (entity.Rate, error) {
+	mid, ok := service.ResolveRate(p.table, base, quote, p.pivot)
+	if !ok {
+		return entity.Rate{}, fmt.Errorf("%w: %s", entity.ErrRateUnavailable, entity.Pair(base, quote))
+	}
+	return entity.Rate{
+		Base:   strings.ToUpper(base),
+		Quote:  strings.ToUpper(quote),
+		Mid:    mid,
+		Source: p.source,
+		AsOf:   p.asOf,
+	}, nil
+}
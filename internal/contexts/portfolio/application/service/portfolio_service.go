@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	fxEntity "financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/portfolio/domain/entity"
+	"financial-system-pro/internal/contexts/portfolio/domain/repository"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSnapshotSweepInterval intervalo da varredura que grava o retrato diário dos portfólios
+	DefaultSnapshotSweepInterval = time.Hour
+	// MaxHistoryDays maior intervalo aceito na consulta do histórico
+	MaxHistoryDays = 366
+)
+
+// ErrInvalidHistoryRange intervalo do histórico invertido ou longo demais
+var ErrInvalidHistoryRange = errors.New("invalid portfolio history range")
+
+// PriceSource preços dos ativos na moeda de cotação e lista dos ativos sem preço (fxSvc.PriceStore)
+type PriceSource interface {
+	Prices(ctx context.Context, quote string, assets []string) (map[string]fxEntity.Price, []string)
+}
+
+// OnChainBalanceSource saldo dos endereços na rede já convertido para o ativo nativo
+// (BlockchainRegistry)
+type OnChainBalanceSource interface {
+	NativeAsset(chain string) (string, error)
+	GetBalance(ctx context.Context, chain, address string) (decimal.Decimal, error)
+}
+
+// PortfolioService avalia os saldos da conta em cada moeda e os das carteiras on-chain do usuário
+// na moeda de referência escolhida e guarda um retrato diário para o histórico
+type PortfolioService struct {
+	repo     repository.PortfolioRepository
+	balances userRepo.BalanceRepository
+	onchain  OnChainBalanceSource
+	prices   PriceSource
+	location *time.Location
+	logger   *zap.Logger
+	now      func() time.Time
+
+	lastSweep time.Time
+}
+
+// NewPortfolioService cria o serviço de portfólio; sem onchain só os saldos da conta são avaliados
+func NewPortfolioService(repo repository.PortfolioRepository, balances userRepo.BalanceRepository, onchain OnChainBalanceSource, prices PriceSource, logger *zap.Logger) *PortfolioService {
+	return &PortfolioService{
+		repo:     repo,
+		balances: balances,
+		onchain:  onchain,
+		prices:   prices,
+		location: time.UTC,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// WithLocation define o fuso que delimita o dia dos retratos
+func (s *PortfolioService) WithLocation(location *time.Location) *PortfolioService {
+	if location != nil {
+		s.location = location
+	}
+	return s
+}
+
+// ReportingCurrency moeda de referência do usuário; a moeda base quando não escolhida
+func (s *PortfolioService) ReportingCurrency(ctx context.Context, userID uuid.UUID) (string, error) {
+	currency, err := s.repo.ReportingCurrency(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if currency == "" {
+		return string(sharedVO.BaseCurrency), nil
+	}
+	return currency, nil
+}
+
+// SetReportingCurrency escolhe a moeda de referência do usuário
+func (s *PortfolioService) SetReportingCurrency(ctx context.Context, userID uuid.UUID, currency string) (string, error) {
+	c, err := sharedVO.ParseCurrency(currency)
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.SetReportingCurrency(ctx, userID, string(c)); err != nil {
+		return "", err
+	}
+	return string(c), nil
+}
+
+// Value avalia o portfólio na moeda informada ou, vazia, na moeda de referência do usuário.
+// Carteiras cujo saldo não pôde ser consultado e ativos sem preço ficam fora do total e tornam a
+// avaliação incompleta.
+func (s *PortfolioService) Value(ctx context.Context, userID uuid.UUID, currency string) (*entity.Valuation, error) {
+	if currency == "" {
+		var err error
+		if currency, err = s.ReportingCurrency(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	quote, err := sharedVO.ParseCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []string
+	seen := map[string]bool{}
+	for _, p := range positions {
+		if p.Error == "" && !seen[p.Asset] {
+			seen[p.Asset] = true
+			assets = append(assets, p.Asset)
+		}
+	}
+	prices, missing := s.prices.Prices(ctx, string(quote), assets)
+	if len(missing) > 0 {
+		s.logger.Warn("portfolio: assets without price", zap.String("quote", string(quote)), zap.Strings("assets", missing))
+	}
+	for i := range positions {
+		price, ok := prices[positions[i].Asset]
+		if !ok || positions[i].Error != "" {
+			continue
+		}
+		positions[i].ApplyPrice(price.Value, price.AsOf, price.Stale, quote.Decimals())
+	}
+	return entity.NewValuation(userID, string(quote), positions, s.now()), nil
+}
+
+// positions saldos diferentes de zero da conta seguidos das carteiras on-chain
+func (s *PortfolioService) positions(ctx context.Context, userID uuid.UUID) ([]entity.Position, error) {
+	balances, err := s.balances.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var positions []entity.Position
+	for _, b := range balances {
+		if b.Amount.IsZero() {
+			continue
+		}
+		positions = append(positions, entity.Position{Asset: b.Currency, Source: entity.PositionAccount, Quantity: b.Amount})
+	}
+	if s.onchain == nil {
+		return positions, nil
+	}
+
+	wallets, err := s.repo.ListWallets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].Blockchain < wallets[j].Blockchain })
+	for _, w := range wallets {
+		asset, err := s.onchain.NativeAsset(w.Blockchain)
+		if err != nil {
+			s.logger.Debug("portfolio: wallet on unsupported chain", zap.String("chain", w.Blockchain))
+			continue
+		}
+		p := entity.Position{Asset: asset, Source: entity.PositionOnChain, Chain: w.Blockchain, Address: w.Address, Quantity: decimal.Zero}
+		balance, err := s.onchain.GetBalance(ctx, w.Blockchain, w.Address)
+		if err != nil {
+			p.Error = fmt.Sprintf("balance unavailable: %v", err)
+			positions = append(positions, p)
+			continue
+		}
+		if balance.IsZero() {
+			continue
+		}
+		p.Quantity = balance
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+// Snapshot grava o retrato do dia do portfólio do usuário na moeda de referência; retorna false se
+// o dia já tinha retrato
+func (s *PortfolioService) Snapshot(ctx context.Context, userID uuid.UUID) (*entity.Snapshot, bool, error) {
+	valuation, err := s.Value(ctx, userID, "")
+	if err != nil {
+		return nil, false, err
+	}
+	snapshot := entity.NewSnapshot(valuation, s.today())
+	saved, err := s.repo.SaveSnapshot(ctx, snapshot)
+	if err != nil {
+		return nil, false, err
+	}
+	return snapshot, saved, nil
+}
+
+// SnapshotAll grava o retrato do dia de todos os usuários com conta ou carteira e retorna quantos
+// foram gravados; falhas de um usuário não interrompem os demais
+func (s *PortfolioService) SnapshotAll(ctx context.Context) (int, error) {
+	holders, err := s.repo.ListHolders(ctx)
+	if err != nil {
+		return 0, err
+	}
+	saved := 0
+	for _, userID := range holders {
+		_, ok, err := s.Snapshot(ctx, userID)
+		if err != nil {
+			s.logger.Error("portfolio snapshot failed", zap.String("user_id", userID.String()), zap.Error(err))
+			continue
+		}
+		if ok {
+			saved++
+		}
+	}
+	return saved, nil
+}
+
+// History retorna os retratos diários do usuário com data em [from, to]
+func (s *PortfolioService) History(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*entity.Snapshot, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidHistoryRange)
+	}
+	if to.Sub(from) > MaxHistoryDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: at most %d days", ErrInvalidHistoryRange, MaxHistoryDays)
+	}
+	return s.repo.ListSnapshots(ctx, userID, from, to)
+}
+
+// Run grava os retratos do dia na primeira varredura de cada dia até o contexto ser cancelado
+func (s *PortfolioService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSnapshotSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			today := s.today()
+			if s.lastSweep.Equal(today) {
+				continue
+			}
+			saved, err := s.SnapshotAll(ctx)
+			if err != nil {
+				s.logger.Error("portfolio snapshot sweep failed", zap.Error(err))
+				continue
+			}
+			s.lastSweep = today
+			s.logger.Info("portfolio snapshots recorded", zap.Int("saved", saved))
+		}
+	}
+}
+
+// today início do dia corrente no fuso configurado
+func (s *PortfolioService) today() time.Time {
+	current := s.now().In(s.location)
+	return time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, s.location)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	fxEntity "financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/portfolio/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memPortfolioRepo struct {
+	wallets    map[uuid.UUID][]entity.Wallet
+	currencies map[uuid.UUID]string
+	snapshots  []*entity.Snapshot
+}
+
+func newMemPortfolioRepo() *memPortfolioRepo {
+	return &memPortfolioRepo{wallets: map[uuid.UUID][]entity.Wallet{}, currencies: map[uuid.UUID]string{}}
+}
+
+func (r *memPortfolioRepo) ListWallets(_ context.Context, userID uuid.UUID) ([]entity.Wallet, error) {
+	return r.wallets[userID], nil
+}
+
+func (r *memPortfolioRepo) ReportingCurrency(_ context.Context, userID uuid.UUID) (string, error) {
+	return r.currencies[userID], nil
+}
+
+func (r *memPortfolioRepo) SetReportingCurrency(_ context.Context, userID uuid.UUID, currency string) error {
+	r.currencies[userID] = currency
+	return nil
+}
+
+func (r *memPortfolioRepo) SaveSnapshot(_ context.Context, s *entity.Snapshot) (bool, error) {
+	for _, x := range r.snapshots {
+		if x.UserID == s.UserID && x.SnapshotDate.Equal(s.SnapshotDate) {
+			return false, nil
+		}
+	}
+	r.snapshots = append(r.snapshots, s)
+	return true, nil
+}
+
+func (r *memPortfolioRepo) ListSnapshots(_ context.Context, userID uuid.UUID, from, to time.Time) ([]*entity.Snapshot, error) {
+	var out []*entity.Snapshot
+	for _, s := range r.snapshots {
+		if s.UserID == userID && !s.SnapshotDate.Before(from) && !s.SnapshotDate.After(to) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (r *memPortfolioRepo) ListHolders(_ context.Context) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for id := range r.wallets {
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// memBalances saldos da conta por usuário e moeda
+type memBalances map[uuid.UUID]map[string]string
+
+func (m memBalances) List(_ context.Context, userID uuid.UUID) ([]*userEntity.Balance, error) {
+	var out []*userEntity.Balance
+	for currency, amount := range m[userID] {
+		out = append(out, &userEntity.Balance{UserID: userID, Currency: currency, Amount: decimal.RequireFromString(amount)})
+	}
+	return out, nil
+}
+
+func (m memBalances) Find(_ context.Context, userID uuid.UUID, currency string) (*userEntity.Balance, error) {
+	amount, ok := m[userID][currency]
+	if !ok {
+		amount = "0"
+	}
+	return &userEntity.Balance{UserID: userID, Currency: currency, Amount: decimal.RequireFromString(amount)}, nil
+}
+
+// fakeChain saldos por endereço; endereços ausentes falham
+type fakeChain map[string]string
+
+func (f fakeChain) NativeAsset(chain string) (string, error) {
+	switch chain {
+	case "ethereum":
+		return "ETH", nil
+	case "solana":
+		return "SOL", nil
+	}
+	return "", errors.New("unsupported chain")
+}
+
+func (f fakeChain) GetBalance(_ context.Context, _ string, address string) (decimal.Decimal, error) {
+	balance, ok := f[address]
+	if !ok {
+		return decimal.Zero, errors.New("gateway timeout")
+	}
+	return decimal.RequireFromString(balance), nil
+}
+
+// fakePrices preços em BRL; outras cotações dividem pelo preço da moeda em BRL
+type fakePrices map[string]string
+
+func (f fakePrices) Prices(_ context.Context, quote string, assets []string) (map[string]fxEntity.Price, []string) {
+	out := map[string]fxEntity.Price{}
+	var missing []string
+	quoteInBRL := decimal.NewFromInt(1)
+	if quote != "BRL" {
+		quoteInBRL = decimal.RequireFromString(f[quote])
+	}
+	for _, asset := range assets {
+		inBRL := decimal.NewFromInt(1)
+		if asset != "BRL" {
+			v, ok := f[asset]
+			if !ok {
+				missing = append(missing, asset)
+				continue
+			}
+			inBRL = decimal.RequireFromString(v)
+		}
+		out[asset] = fxEntity.Price{Asset: asset, Quote: quote, Value: inBRL.Div(quoteInBRL), AsOf: time.Now()}
+	}
+	return out, missing
+}
+
+var portfolioNow = time.Date(2025, 5, 1, 15, 0, 0, 0, time.UTC)
+
+func setupPortfolio() (*PortfolioService, *memPortfolioRepo, uuid.UUID) {
+	user := uuid.New()
+	repo := newMemPortfolioRepo()
+	repo.wallets[user] = []entity.Wallet{
+		{Blockchain: "ethereum", Address: "0xabc"},
+		{Blockchain: "solana", Address: "So1"},
+		{Blockchain: "dogecoin", Address: "D1"},
+	}
+	balances := memBalances{user: {"BRL": "1000", "USD": "100", "ETH": "0"}}
+	chain := fakeChain{"0xabc": "0.5", "So1": "2"}
+	prices := fakePrices{"ETH": "20000", "USD": "5", "SOL": "800"}
+	svc := NewPortfolioService(repo, balances, chain, prices, zap.NewNop())
+	svc.now = func() time.Time { return portfolioNow }
+	return svc, repo, user
+}
+
+func TestPortfolioService_ValueAggregatesAccountAndOnChain(t *testing.T) {
+	svc, _, user := setupPortfolio()
+	v, err := svc.Value(context.Background(), user, "")
+	if err != nil {
+		t.Fatalf("avaliação: %v", err)
+	}
+	// 1000 BRL + 100 USD (500) + 0,5 ETH (10000) + 2 SOL (1600); saldo zero e chain sem suporte ficam de fora
+	if v.Currency != "BRL" || !v.Total.Equal(decimal.NewFromInt(13100)) {
+		t.Fatalf("esperado 13100 BRL, obtido %s %s", v.Total, v.Currency)
+	}
+	if len(v.Positions) != 4 || !v.Complete {
+		t.Fatalf("esperadas 4 posições avaliadas, obtido %+v", v.Positions)
+	}
+
+	usd, err := svc.Value(context.Background(), user, "usd")
+	if err != nil || usd.Currency != "USD" || !usd.Total.Equal(decimal.NewFromInt(2620)) {
+		t.Fatalf("esperado 2620 USD, obtido %+v, %v", usd, err)
+	}
+	if _, err := svc.Value(context.Background(), user, "XYZ"); !errors.Is(err, sharedVO.ErrUnsupportedCurrency) {
+		t.Fatalf("esperado ErrUnsupportedCurrency, obtido %v", err)
+	}
+}
+
+func TestPortfolioService_GatewayFailureAndMissingPrice(t *testing.T) {
+	svc, repo, user := setupPortfolio()
+	repo.wallets[user][0].Address = "0xdead" // saldo indisponível
+	svc.prices = fakePrices{"USD": "5", "ETH": "20000"}
+
+	v, err := svc.Value(context.Background(), user, "BRL")
+	if err != nil {
+		t.Fatalf("avaliação: %v", err)
+	}
+	if !v.Total.Equal(decimal.NewFromInt(1500)) || v.Complete {
+		t.Fatalf("esperado total parcial de 1500, obtido %s (completo %v)", v.Total, v.Complete)
+	}
+	if len(v.Unpriced) != 1 || v.Unpriced[0] != "SOL" {
+		t.Fatalf("esperado SOL sem preço, obtido %v", v.Unpriced)
+	}
+	for _, p := range v.Positions {
+		if p.Chain == "ethereum" && p.Error == "" {
+			t.Fatalf("carteira com falha no gateway deveria trazer o erro: %+v", p)
+		}
+	}
+}
+
+func TestPortfolioService_ReportingCurrencyAndSnapshots(t *testing.T) {
+	svc, repo, user := setupPortfolio()
+	ctx := context.Background()
+
+	if currency, err := svc.SetReportingCurrency(ctx, user, "usd"); err != nil || currency != "USD" {
+		t.Fatalf("moeda de referência: %q, %v", currency, err)
+	}
+	saved, err := svc.SnapshotAll(ctx)
+	if err != nil || saved != 1 {
+		t.Fatalf("esperado 1 retrato, obtido %d, %v", saved, err)
+	}
+	if saved, _ = svc.SnapshotAll(ctx); saved != 0 {
+		t.Fatalf("segundo retrato do dia não deveria ser gravado, obtido %d", saved)
+	}
+	s := repo.snapshots[0]
+	if s.Currency != "USD" || !s.Total.Equal(decimal.NewFromInt(2620)) || !s.SnapshotDate.Equal(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("retrato inesperado: %+v", s)
+	}
+
+	history, err := svc.History(ctx, user, portfolioNow.AddDate(0, 0, -7), portfolioNow)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("esperado 1 retrato no histórico, obtido %d, %v", len(history), err)
+	}
+	if _, err := svc.History(ctx, user, portfolioNow, portfolioNow.AddDate(0, 0, -1)); !errors.Is(err, ErrInvalidHistoryRange) {
+		t.Fatalf("intervalo invertido: esperado ErrInvalidHistoryRange, obtido %v", err)
+	}
+	if _, err := svc.History(ctx, user, portfolioNow.AddDate(-2, 0, 0), portfolioNow); !errors.Is(err, ErrInvalidHistoryRange) {
+		t.Fatalf("intervalo longo: esperado ErrInvalidHistoryRange, obtido %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PositionSource origem do saldo valorizado
+type PositionSource string
+
+const (
+	PositionAccount PositionSource = "account" // saldo da conta na plataforma
+	PositionOnChain PositionSource = "onchain" // saldo do endereço do usuário na rede
+)
+
+// Wallet carteira on-chain do usuário
+type Wallet struct {
+	Blockchain string `json:"blockchain"`
+	Address    string `json:"address"`
+}
+
+// Position saldo de um ativo e seu valor na moeda de referência. Sem preço (ou com o saldo
+// indisponível na rede) a posição fica fora do total.
+type Position struct {
+	Asset     string           `json:"asset"`
+	Source    PositionSource   `json:"source"`
+	Chain     string           `json:"chain,omitempty"`
+	Address   string           `json:"address,omitempty"`
+	Quantity  decimal.Decimal  `json:"quantity"`
+	Price     *decimal.Decimal `json:"price,omitempty"`
+	Value     *decimal.Decimal `json:"value,omitempty"`
+	PriceAsOf *time.Time       `json:"price_as_of,omitempty"`
+	Stale     bool             `json:"stale,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// Valued indica se a posição entra no total
+func (p Position) Valued() bool {
+	return p.Value != nil
+}
+
+// ApplyPrice valoriza a posição pelo preço, arredondando nas casas da moeda de referência
+func (p *Position) ApplyPrice(price decimal.Decimal, asOf time.Time, stale bool, decimals int32) {
+	value := p.Quantity.Mul(price).Round(decimals)
+	p.Price = &price
+	p.Value = &value
+	p.PriceAsOf = &asOf
+	p.Stale = stale
+}
+
+// AssetAllocation total do ativo somando as origens e seu peso no portfólio
+type AssetAllocation struct {
+	Asset    string          `json:"asset"`
+	Quantity decimal.Decimal `json:"quantity"`
+	Value    decimal.Decimal `json:"value"`
+	Weight   decimal.Decimal `json:"weight"` // percentual do total
+}
+
+// Valuation avaliação do portfólio do usuário na moeda de referência
+type Valuation struct {
+	UserID    uuid.UUID         `json:"user_id"`
+	Currency  string            `json:"currency"`
+	Total     decimal.Decimal   `json:"total"`
+	Positions []Position        `json:"positions"`
+	ByAsset   []AssetAllocation `json:"by_asset"`
+	Unpriced  []string          `json:"unpriced,omitempty"` // ativos sem preço disponível
+	Complete  bool              `json:"complete"`           // todas as posições avaliadas
+	Stale     bool              `json:"stale"`              // algum preço veio do cache após falha das fontes
+	ValuedAt  time.Time         `json:"valued_at"`
+}
+
+// NewValuation soma as posições avaliadas e calcula a alocação por ativo
+func NewValuation(userID uuid.UUID, currency string, positions []Position, now time.Time) *Valuation {
+	v := &Valuation{
+		UserID:    userID,
+		Currency:  currency,
+		Total:     decimal.Zero,
+		Positions: positions,
+		ByAsset:   []AssetAllocation{},
+		Complete:  true,
+		ValuedAt:  now,
+	}
+	if v.Positions == nil {
+		v.Positions = []Position{}
+	}
+
+	byAsset := map[string]*AssetAllocation{}
+	unpriced := map[string]bool{}
+	for _, p := range positions {
+		if p.Stale {
+			v.Stale = true
+		}
+		if !p.Valued() {
+			v.Complete = false
+			if p.Error == "" {
+				unpriced[p.Asset] = true
+			}
+			continue
+		}
+		v.Total = v.Total.Add(*p.Value)
+		a, ok := byAsset[p.Asset]
+		if !ok {
+			a = &AssetAllocation{Asset: p.Asset, Quantity: decimal.Zero, Value: decimal.Zero}
+			byAsset[p.Asset] = a
+		}
+		a.Quantity = a.Quantity.Add(p.Quantity)
+		a.Value = a.Value.Add(*p.Value)
+	}
+
+	for _, a := range byAsset {
+		a.Weight = decimal.Zero
+		if v.Total.IsPositive() {
+			a.Weight = a.Value.Div(v.Total).Mul(decimal.NewFromInt(100)).Round(2)
+		}
+		v.ByAsset = append(v.ByAsset, *a)
+	}
+	sort.Slice(v.ByAsset, func(i, j int) bool {
+		if !v.ByAsset[i].Value.Equal(v.ByAsset[j].Value) {
+			return v.ByAsset[i].Value.GreaterThan(v.ByAsset[j].Value)
+		}
+		return v.ByAsset[i].Asset < v.ByAsset[j].Asset
+	})
+	for asset := range unpriced {
+		v.Unpriced = append(v.Unpriced, asset)
+	}
+	sort.Strings(v.Unpriced)
+	return v
+}
+
+// Snapshot avaliação diária do portfólio guardada para o histórico
+type Snapshot struct {
+	ID           uuid.UUID         `json:"id"`
+	UserID       uuid.UUID         `json:"user_id"`
+	SnapshotDate time.Time         `json:"snapshot_date"`
+	Currency     string            `json:"currency"`
+	Total        decimal.Decimal   `json:"total"`
+	ByAsset      []AssetAllocation `json:"by_asset"`
+	Complete     bool              `json:"complete"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// NewSnapshot registra a avaliação como o retrato do dia
+func NewSnapshot(v *Valuation, day time.Time) *Snapshot {
+	return &Snapshot{
+		ID:           uuid.New(),
+		UserID:       v.UserID,
+		SnapshotDate: day,
+		Currency:     v.Currency,
+		Total:        v.Total,
+		ByAsset:      v.ByAsset,
+		Complete:     v.Complete,
+		CreatedAt:    v.ValuedAt,
+	}
+}
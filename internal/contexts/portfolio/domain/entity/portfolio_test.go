@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func position(asset string, source PositionSource, qty, price string) Position {
+	p := Position{Asset: asset, Source: source, Quantity: decimal.RequireFromString(qty)}
+	if price != "" {
+		p.ApplyPrice(decimal.RequireFromString(price), time.Now(), false, 2)
+	}
+	return p
+}
+
+func TestNewValuation_TotalsAndAllocation(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	positions := []Position{
+		position("BRL", PositionAccount, "1000", "1"),
+		position("ETH", PositionAccount, "0.1", "20000"),
+		position("ETH", PositionOnChain, "0.0500001", "20000"),
+		position("SOL", PositionOnChain, "3", ""),
+	}
+	v := NewValuation(uuid.New(), "BRL", positions, now)
+
+	assert.Equal(t, "4000", v.Total.String(), "1000 + 2000 + 1000 (arredondado)")
+	require.Len(t, v.ByAsset, 2)
+	assert.Equal(t, "ETH", v.ByAsset[0].Asset, "maior valor primeiro")
+	assert.Equal(t, "0.1500001", v.ByAsset[0].Quantity.String())
+	assert.Equal(t, "75", v.ByAsset[0].Weight.String())
+	assert.Equal(t, []string{"SOL"}, v.Unpriced)
+	assert.False(t, v.Complete)
+	assert.False(t, v.Stale)
+}
+
+func TestNewValuation_UnavailableBalanceAndStalePrice(t *testing.T) {
+	stale := position("BTC", PositionAccount, "0.01", "")
+	stale.ApplyPrice(decimal.NewFromInt(300000), time.Now(), true, 2)
+	unavailable := Position{Asset: "ETH", Source: PositionOnChain, Chain: "ethereum", Quantity: decimal.Zero, Error: "balance unavailable"}
+
+	v := NewValuation(uuid.New(), "BRL", []Position{stale, unavailable}, time.Now())
+	assert.Equal(t, "3000", v.Total.String())
+	assert.True(t, v.Stale)
+	assert.False(t, v.Complete)
+	assert.Empty(t, v.Unpriced, "saldo indisponível não é falta de preço")
+
+	empty := NewValuation(uuid.New(), "USD", nil, time.Now())
+	assert.True(t, empty.Complete)
+	assert.NotNil(t, empty.Positions)
+	assert.True(t, empty.Total.IsZero())
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"financial-system-pro/internal/contexts/portfolio/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PortfolioRepository carteiras on-chain, moeda de referência e histórico do portfólio
+type PortfolioRepository interface {
+	// ListWallets lista as carteiras on-chain do usuário
+	ListWallets(ctx context.Context, userID uuid.UUID) ([]entity.Wallet, error)
+	// ReportingCurrency retorna "" quando o usuário não escolheu a moeda de referência
+	ReportingCurrency(ctx context.Context, userID uuid.UUID) (string, error)
+	SetReportingCurrency(ctx context.Context, userID uuid.UUID, currency string) error
+	// SaveSnapshot grava o retrato do dia; se o usuário já tem retrato na data retorna false
+	SaveSnapshot(ctx context.Context, s *entity.Snapshot) (bool, error)
+	// ListSnapshots lista os retratos com data em [from, to], em ordem cronológica
+	ListSnapshots(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*entity.Snapshot, error)
+	// ListHolders lista os usuários com conta ou carteira on-chain
+	ListHolders(ctx context.Context) ([]uuid.UUID, error)
+}
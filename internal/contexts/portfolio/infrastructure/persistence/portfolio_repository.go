@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"financial-system-pro/internal/contexts/portfolio/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresPortfolioRepository implementa PortfolioRepository usando PostgreSQL. As carteiras vêm de
+// onchain_wallets e os titulares de user_context.wallet_info.
+type PostgresPortfolioRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresPortfolioRepository cria um novo repositório de portfólio
+func NewPostgresPortfolioRepository(conn database.Connection) *PostgresPortfolioRepository {
+	return &PostgresPortfolioRepository{
+		conn:   conn,
+		schema: "portfolio_context",
+	}
+}
+
+const snapshotColumns = `id, user_id, snapshot_date, currency, total, by_asset, complete, created_at`
+
+// ListWallets lista as carteiras on-chain do usuário
+func (r *PostgresPortfolioRepository) ListWallets(ctx context.Context, userID uuid.UUID) ([]entity.Wallet, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT blockchain, address
+		FROM onchain_wallets
+		WHERE user_id = $1
+		ORDER BY blockchain
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.Wallet
+	for rows.Next() {
+		var w entity.Wallet
+		if err := rows.Scan(&w.Blockchain, &w.Address); err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+// ReportingCurrency busca a moeda de referência escolhida pelo usuário
+func (r *PostgresPortfolioRepository) ReportingCurrency(ctx context.Context, userID uuid.UUID) (string, error) {
+	var currency string
+	err := r.conn.QueryRow(ctx, `
+		SELECT reporting_currency FROM `+r.schema+`.preferences WHERE user_id = $1
+	`, userID).Scan(&currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return currency, err
+}
+
+// SetReportingCurrency grava a moeda de referência do usuário
+func (r *PostgresPortfolioRepository) SetReportingCurrency(ctx context.Context, userID uuid.UUID, currency string) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.preferences (user_id, reporting_currency, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET reporting_currency = EXCLUDED.reporting_currency, updated_at = NOW()
+	`, userID, currency)
+	return err
+}
+
+// SaveSnapshot grava o retrato do dia, mantendo o primeiro retrato de cada data
+func (r *PostgresPortfolioRepository) SaveSnapshot(ctx context.Context, s *entity.Snapshot) (bool, error) {
+	byAsset, err := json.Marshal(s.ByAsset)
+	if err != nil {
+		return false, err
+	}
+	res, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.snapshots (`+snapshotColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8)
+		ON CONFLICT (user_id, snapshot_date) DO NOTHING
+	`,
+		s.ID,
+		s.UserID,
+		s.SnapshotDate,
+		s.Currency,
+		s.Total,
+		string(byAsset),
+		s.Complete,
+		s.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ListSnapshots lista os retratos do usuário no intervalo de datas
+func (r *PostgresPortfolioRepository) ListSnapshots(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*entity.Snapshot, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+snapshotColumns+`
+		FROM `+r.schema+`.snapshots
+		WHERE user_id = $1 AND snapshot_date BETWEEN $2 AND $3
+		ORDER BY snapshot_date
+	`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Snapshot
+	for rows.Next() {
+		s := &entity.Snapshot{}
+		var byAsset []byte
+		if err := rows.Scan(&s.ID, &s.UserID, &s.SnapshotDate, &s.Currency, &s.Total, &byAsset, &s.Complete, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(byAsset, &s.ByAsset); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// ListHolders lista os usuários com carteira na plataforma ou on-chain
+func (r *PostgresPortfolioRepository) ListHolders(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT user_id FROM user_context.wallet_info
+		UNION
+		SELECT user_id FROM onchain_wallets
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	fxDomain "financial-system-pro/internal/contexts/fx/domain/service"
+	"financial-system-pro/internal/contexts/tax/domain/entity"
+	"financial-system-pro/internal/contexts/tax/domain/repository"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrDisposalNotFound alienação inexistente ou de outro usuário
+	ErrDisposalNotFound = errors.New("disposal not found")
+	// ErrLotNotFound lote inexistente ou de outro usuário
+	ErrLotNotFound = errors.New("lot not found")
+	// ErrNotDepositLot só lotes de depósito aceitam custo informado pelo usuário
+	ErrNotDepositLot = errors.New("cost basis can only be set on deposit lots")
+	// ErrNotCryptoAsset lotes só existem para ativos cripto
+	ErrNotCryptoAsset = errors.New("tax lots are only tracked for crypto assets")
+	// ErrCostBasisRequired custo do lote não informado
+	ErrCostBasisRequired = errors.New("cost_basis is required")
+	// ErrAcquisitionAfterDeposit data de aquisição posterior ao depósito
+	ErrAcquisitionAfterDeposit = errors.New("acquired_at cannot be after the deposit")
+	// ErrInvalidYear ano fora do intervalo aceito
+	ErrInvalidYear = errors.New("invalid report year")
+)
+
+// Conversion conversão executada entre dois saldos do usuário
+type Conversion struct {
+	QuoteID    uuid.UUID
+	UserID     uuid.UUID
+	From       string
+	To         string
+	SellAmount decimal.Decimal
+	BuyAmount  decimal.Decimal
+	At         time.Time
+}
+
+// DetectedDeposit depósito on-chain detectado no endereço de destino (tx.new)
+type DetectedDeposit struct {
+	Chain          string
+	TxHash         string
+	ToAddress      string
+	AmountBaseUnit int64
+	At             time.Time
+}
+
+// DepositCostBasis custo de aquisição informado pelo usuário para um lote de depósito, com a data
+// em que o ativo foi adquirido fora da plataforma (padrão: a do depósito)
+type DepositCostBasis struct {
+	CostBasis  decimal.Decimal
+	AcquiredAt *time.Time
+}
+
+// DepositWallets resolve a wallet do usuário dona do endereço de destino (WalletRepository)
+type DepositWallets interface {
+	FindByAddress(ctx context.Context, address string) (*userEntity.Wallet, error)
+}
+
+// NativeAssets ativo nativo de cada chain, em que os depósitos são denominados (BlockchainRegistry)
+type NativeAssets interface {
+	NativeAsset(chain string) (string, error)
+}
+
+// TaxLotService registra os lotes de aquisição e as alienações de ativos cripto com o valor em
+// moeda fiduciária (BRL) na data e apura os ganhos realizados por FIFO, custo médio ou
+// identificação específica para o relatório anual
+type TaxLotService struct {
+	repo     repository.LotRepository
+	rates    fxDomain.RateProvider
+	wallets  DepositWallets
+	assets   NativeAssets
+	location *time.Location
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewTaxLotService cria o serviço de lotes com as cotações usadas para avaliar as operações
+func NewTaxLotService(repo repository.LotRepository, rates fxDomain.RateProvider, logger *zap.Logger) *TaxLotService {
+	return &TaxLotService{repo: repo, rates: rates, location: time.UTC, logger: logger, now: time.Now}
+}
+
+// WithLocation define o fuso que delimita os anos e meses do relatório
+func (s *TaxLotService) WithLocation(location *time.Location) *TaxLotService {
+	if location != nil {
+		s.location = location
+	}
+	return s
+}
+
+// WithDeposits habilita os lotes dos depósitos on-chain recebidos nas wallets dos usuários
+func (s *TaxLotService) WithDeposits(wallets DepositWallets, assets NativeAssets) *TaxLotService {
+	s.wallets = wallets
+	s.assets = assets
+	return s
+}
+
+// Subscribe registra a apuração dos lotes nas conversões de câmbio executadas e, com WithDeposits,
+// nos depósitos detectados
+func (s *TaxLotService) Subscribe(bus events.Bus) {
+	bus.Subscribe("fx.converted", s.onConverted)
+	if s.wallets != nil && s.assets != nil {
+		bus.Subscribe("tx.new", s.onDepositDetected)
+	}
+}
+
+func (s *TaxLotService) onDepositDetected(ctx context.Context, e events.Event) error {
+	ev, ok := e.(events.NewTransactionDetectedEvent)
+	if !ok {
+		return nil
+	}
+	_, err := s.RecordDetectedDeposit(ctx, DetectedDeposit{
+		Chain:          ev.BlockchainType,
+		TxHash:         ev.TxHash,
+		ToAddress:      ev.ToAddress,
+		AmountBaseUnit: ev.AmountBaseUnit,
+		At:             ev.OccurredAt(),
+	})
+	if err != nil {
+		s.logger.Error("tax lots: failed to record deposit", zap.String("tx_hash", ev.TxHash), zap.Error(err))
+	}
+	return err
+}
+
+func (s *TaxLotService) onConverted(ctx context.Context, e events.Event) error {
+	ev, ok := e.(events.CurrencyConvertedEvent)
+	if !ok {
+		return nil
+	}
+	err := s.RecordConversion(ctx, Conversion{
+		QuoteID:    ev.QuoteID,
+		UserID:     ev.UserID,
+		From:       ev.From,
+		To:         ev.To,
+		SellAmount: ev.SellAmount,
+		BuyAmount:  ev.BuyAmount,
+		At:         ev.OccurredAt(),
+	})
+	if err != nil {
+		s.logger.Error("tax lots: failed to record conversion", zap.String("quote_id", ev.QuoteID.String()), zap.Error(err))
+	}
+	return err
+}
+
+// RecordConversion registra a compra do ativo cripto recebido como lote e a venda do ativo cripto
+// entregue como alienação, ambos pelo valor da operação em BRL. Conversões entre moedas
+// fiduciárias são ignoradas; reentregas da mesma conversão não duplicam lotes.
+func (s *TaxLotService) RecordConversion(ctx context.Context, c Conversion) error {
+	from, err := sharedVO.ParseCurrency(c.From)
+	if err != nil {
+		return err
+	}
+	to, err := sharedVO.ParseCurrency(c.To)
+	if err != nil {
+		return err
+	}
+	if !from.IsCrypto() && !to.IsCrypto() {
+		return nil
+	}
+	if c.At.IsZero() {
+		c.At = s.now()
+	}
+	value, err := s.conversionValue(ctx, from, to, c.SellAmount, c.BuyAmount)
+	if err != nil {
+		return err
+	}
+
+	if to.IsCrypto() {
+		lot, err := entity.NewLot(c.UserID, string(to), c.BuyAmount, value, entity.LotSourceConversion, c.QuoteID.String(), c.At)
+		if err != nil {
+			return err
+		}
+		if _, err := s.repo.CreateLot(ctx, lot); err != nil {
+			return err
+		}
+	}
+	if from.IsCrypto() {
+		d, err := entity.NewDisposal(c.UserID, string(from), c.SellAmount, value, entity.LotSourceConversion, c.QuoteID.String(), c.At)
+		if err != nil {
+			return err
+		}
+		if _, err := s.repo.CreateDisposal(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conversionValue valor da conversão em BRL: o lado em BRL quando houver, senão o lado fiduciário
+// ou, entre dois ativos cripto, o vendido, avaliados pela cotação de mercado
+func (s *TaxLotService) conversionValue(ctx context.Context, from, to sharedVO.Currency, sell, buy decimal.Decimal) (decimal.Decimal, error) {
+	switch {
+	case from == sharedVO.BaseCurrency:
+		return sell, nil
+	case to == sharedVO.BaseCurrency:
+		return buy, nil
+	case !to.IsCrypto():
+		return s.fiatValue(ctx, to, buy)
+	default:
+		return s.fiatValue(ctx, from, sell)
+	}
+}
+
+func (s *TaxLotService) fiatValue(ctx context.Context, currency sharedVO.Currency, amount decimal.Decimal) (decimal.Decimal, error) {
+	rate, err := s.rates.Rate(ctx, string(currency), string(sharedVO.BaseCurrency))
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(rate.Mid).Round(sharedVO.BaseCurrency.Decimals()), nil
+}
+
+// RecordDetectedDeposit registra como lote o depósito on-chain recebido na wallet de um usuário,
+// identificado pela chain e pelo hash da transação, avaliado pela cotação atual. Depósitos em
+// endereços sem wallet de usuário e redetecções do mesmo hash retornam nil, nil.
+func (s *TaxLotService) RecordDetectedDeposit(ctx context.Context, d DetectedDeposit) (*entity.Lot, error) {
+	if s.wallets == nil || s.assets == nil || d.TxHash == "" {
+		return nil, nil
+	}
+	wallet, err := s.wallets.FindByAddress(ctx, d.ToAddress)
+	if err != nil || wallet == nil {
+		return nil, err
+	}
+	code, err := s.assets.NativeAsset(d.Chain)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := sharedVO.ParseCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+	if !asset.IsCrypto() {
+		return nil, ErrNotCryptoAsset
+	}
+	if d.At.IsZero() {
+		d.At = s.now()
+	}
+
+	quantity := decimal.New(d.AmountBaseUnit, -asset.Decimals())
+	cost, err := s.fiatValue(ctx, asset, quantity)
+	if err != nil {
+		// Sem cotação o lote fica com custo zero até o usuário informar o custo
+		s.logger.Warn("tax lots: deposit recorded without market value", zap.String("tx_hash", d.TxHash), zap.Error(err))
+		cost = decimal.Zero
+	}
+	lot, err := entity.NewLot(wallet.UserID, string(asset), quantity, cost, entity.LotSourceDeposit, d.Chain+":"+d.TxHash, d.At)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateLot(ctx, lot)
+	if err != nil || !created {
+		return nil, err
+	}
+	return lot, nil
+}
+
+// SetDepositCostBasis substitui o custo de um lote de depósito do usuário pelo custo da aquisição
+// feita fora da plataforma, que não pode ser posterior ao depósito
+func (s *TaxLotService) SetDepositCostBasis(ctx context.Context, userID, lotID uuid.UUID, req DepositCostBasis) (*entity.Lot, error) {
+	lot, err := s.repo.FindLot(ctx, lotID)
+	if err != nil {
+		return nil, err
+	}
+	if lot == nil || lot.UserID != userID {
+		return nil, ErrLotNotFound
+	}
+	if lot.Source != entity.LotSourceDeposit {
+		return nil, ErrNotDepositLot
+	}
+	if req.CostBasis.IsNegative() {
+		return nil, entity.ErrInvalidCostBasis
+	}
+	acquiredAt := lot.AcquiredAt
+	if req.AcquiredAt != nil {
+		if req.AcquiredAt.After(lot.AcquiredAt) {
+			return nil, ErrAcquisitionAfterDeposit
+		}
+		acquiredAt = *req.AcquiredAt
+	}
+	if err := s.repo.UpdateLotCost(ctx, lot.ID, req.CostBasis, acquiredAt); err != nil {
+		return nil, err
+	}
+	lot.CostBasis = req.CostBasis
+	lot.AcquiredAt = acquiredAt
+	return lot, nil
+}
+
+// Lots lista os lotes de aquisição do usuário
+func (s *TaxLotService) Lots(ctx context.Context, userID uuid.UUID) ([]*entity.Lot, error) {
+	return s.repo.ListLots(ctx, userID)
+}
+
+// Disposals lista as alienações do usuário
+func (s *TaxLotService) Disposals(ctx context.Context, userID uuid.UUID) ([]*entity.Disposal, error) {
+	return s.repo.ListDisposals(ctx, userID)
+}
+
+// Designate escolhe os lotes consumidos pela alienação na identificação específica
+func (s *TaxLotService) Designate(ctx context.Context, userID, disposalID uuid.UUID, designations []entity.LotDesignation) (*entity.Disposal, error) {
+	d, err := s.repo.FindDisposal(ctx, disposalID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil || d.UserID != userID {
+		return nil, ErrDisposalNotFound
+	}
+	lots, err := s.repo.ListLots(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*entity.Lot, len(lots))
+	for _, l := range lots {
+		byID[l.ID] = l
+	}
+	if err := d.ValidateDesignations(designations, byID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetDesignations(ctx, disposalID, designations); err != nil {
+		return nil, err
+	}
+	d.Designations = designations
+	return d, nil
+}
+
+// Report apura os ganhos e perdas realizados no ano pelo método informado e a posição ao fim do ano
+func (s *TaxLotService) Report(ctx context.Context, userID uuid.UUID, year int, method entity.CostMethod) (*entity.AnnualReport, error) {
+	if year < 2000 || year > s.now().In(s.location).Year() {
+		return nil, ErrInvalidYear
+	}
+	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, s.location)
+
+	allLots, err := s.repo.ListLots(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	allDisposals, err := s.repo.ListDisposals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var lots []*entity.Lot
+	for _, l := range allLots {
+		if l.AcquiredAt.Before(end) {
+			lots = append(lots, l)
+		}
+	}
+	var disposals []*entity.Disposal
+	for _, d := range allDisposals {
+		if d.DisposedAt.Before(end) {
+			disposals = append(disposals, d)
+		}
+	}
+
+	gains, holdings, err := entity.ComputeGains(method, lots, disposals)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewAnnualReport(userID, year, method, string(sharedVO.BaseCurrency), gains, holdings, s.location, s.now()), nil
+}
+
+// Render serializa o relatório no formato (csv ou json)
+func (s *TaxLotService) Render(report *entity.AnnualReport, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := entity.WriteTaxReport(&buf, format, report); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
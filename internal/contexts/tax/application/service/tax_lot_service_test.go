@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	fxEntity "financial-system-pro/internal/contexts/fx/domain/entity"
+	"financial-system-pro/internal/contexts/tax/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memLotRepo struct {
+	lots      []*entity.Lot
+	disposals []*entity.Disposal
+}
+
+func (r *memLotRepo) CreateLot(_ context.Context, lot *entity.Lot) (bool, error) {
+	for _, l := range r.lots {
+		if l.Source == lot.Source && l.SourceID == lot.SourceID && l.Asset == lot.Asset {
+			return false, nil
+		}
+	}
+	r.lots = append(r.lots, lot)
+	return true, nil
+}
+
+func (r *memLotRepo) CreateDisposal(_ context.Context, d *entity.Disposal) (bool, error) {
+	for _, x := range r.disposals {
+		if x.Source == d.Source && x.SourceID == d.SourceID && x.Asset == d.Asset {
+			return false, nil
+		}
+	}
+	r.disposals = append(r.disposals, d)
+	return true, nil
+}
+
+func (r *memLotRepo) FindLot(_ context.Context, id uuid.UUID) (*entity.Lot, error) {
+	for _, l := range r.lots {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memLotRepo) UpdateLotCost(_ context.Context, id uuid.UUID, costBasis decimal.Decimal, acquiredAt time.Time) error {
+	for _, l := range r.lots {
+		if l.ID == id {
+			l.CostBasis = costBasis
+			l.AcquiredAt = acquiredAt
+		}
+	}
+	return nil
+}
+
+func (r *memLotRepo) ListLots(_ context.Context, userID uuid.UUID) ([]*entity.Lot, error) {
+	var out []*entity.Lot
+	for _, l := range r.lots {
+		if l.UserID == userID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (r *memLotRepo) ListDisposals(_ context.Context, userID uuid.UUID) ([]*entity.Disposal, error) {
+	var out []*entity.Disposal
+	for _, d := range r.disposals {
+		if d.UserID == userID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *memLotRepo) FindDisposal(_ context.Context, id uuid.UUID) (*entity.Disposal, error) {
+	for _, d := range r.disposals {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memLotRepo) SetDesignations(_ context.Context, disposalID uuid.UUID, designations []entity.LotDesignation) error {
+	for _, d := range r.disposals {
+		if d.ID == disposalID {
+			d.Designations = designations
+		}
+	}
+	return nil
+}
+
+// fakeRates cotações fixas contra BRL
+type fakeRates map[string]string
+
+func (f fakeRates) Rate(_ context.Context, base, quote string) (fxEntity.Rate, error) {
+	mid, ok := f[base+"/"+quote]
+	if !ok {
+		return fxEntity.Rate{}, errors.New("rate unavailable")
+	}
+	return fxEntity.Rate{Base: base, Quote: quote, Mid: decimal.RequireFromString(mid)}, nil
+}
+
+// fakeWallets wallets de usuário por endereço
+type fakeWallets map[string]uuid.UUID
+
+func (f fakeWallets) FindByAddress(_ context.Context, address string) (*userEntity.Wallet, error) {
+	userID, ok := f[address]
+	if !ok {
+		return nil, nil
+	}
+	return &userEntity.Wallet{UserID: userID, Address: address}, nil
+}
+
+type fakeNativeAssets struct{}
+
+func (fakeNativeAssets) NativeAsset(chain string) (string, error) {
+	if chain != "ethereum" {
+		return "", errors.New("unknown chain")
+	}
+	return "ETH", nil
+}
+
+var taxNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newTestTaxService() (*TaxLotService, *memLotRepo) {
+	repo := &memLotRepo{}
+	svc := NewTaxLotService(repo, fakeRates{"ETH/BRL": "20000", "USD/BRL": "5"}, zap.NewNop())
+	svc.now = func() time.Time { return taxNow }
+	return svc, repo
+}
+
+func dec(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+func TestTaxLotService_RecordConversion(t *testing.T) {
+	svc, repo := newTestTaxService()
+	ctx := context.Background()
+	user := uuid.New()
+	buy := Conversion{QuoteID: uuid.New(), UserID: user, From: "BRL", To: "ETH", SellAmount: dec("30000"), BuyAmount: dec("2"), At: taxNow.AddDate(0, -2, 0)}
+
+	if err := svc.RecordConversion(ctx, buy); err != nil {
+		t.Fatalf("compra: %v", err)
+	}
+	if err := svc.RecordConversion(ctx, buy); err != nil {
+		t.Fatalf("reentrega: %v", err)
+	}
+	if len(repo.lots) != 1 || !repo.lots[0].CostBasis.Equal(dec("30000")) {
+		t.Fatalf("esperado um lote de custo 30000, obtido %+v", repo.lots)
+	}
+
+	// ETH -> BTC: alienação de ETH e lote de BTC pelo valor de mercado do ETH vendido
+	swap := Conversion{QuoteID: uuid.New(), UserID: user, From: "ETH", To: "BTC", SellAmount: dec("0.5"), BuyAmount: dec("0.02"), At: taxNow.AddDate(0, -1, 0)}
+	if err := svc.RecordConversion(ctx, swap); err != nil {
+		t.Fatalf("troca: %v", err)
+	}
+	if len(repo.disposals) != 1 || !repo.disposals[0].Proceeds.Equal(dec("10000")) {
+		t.Fatalf("esperada alienação de 10000, obtido %+v", repo.disposals)
+	}
+	if len(repo.lots) != 2 || repo.lots[1].Asset != "BTC" || !repo.lots[1].CostBasis.Equal(dec("10000")) {
+		t.Fatalf("esperado lote de BTC a 10000, obtido %+v", repo.lots)
+	}
+
+	// ETH -> USD: o valor vem do lado fiduciário
+	sell := Conversion{QuoteID: uuid.New(), UserID: user, From: "ETH", To: "USD", SellAmount: dec("0.5"), BuyAmount: dec("2400"), At: taxNow}
+	if err := svc.RecordConversion(ctx, sell); err != nil {
+		t.Fatalf("venda: %v", err)
+	}
+	if !repo.disposals[1].Proceeds.Equal(dec("12000")) {
+		t.Fatalf("esperado 12000, obtido %s", repo.disposals[1].Proceeds)
+	}
+
+	// conversões só entre moedas fiduciárias não geram lotes
+	if err := svc.RecordConversion(ctx, Conversion{QuoteID: uuid.New(), UserID: user, From: "BRL", To: "USD", SellAmount: dec("50"), BuyAmount: dec("10")}); err != nil {
+		t.Fatalf("fiduciária: %v", err)
+	}
+	if len(repo.lots) != 2 || len(repo.disposals) != 2 {
+		t.Fatalf("conversão fiduciária não deveria gerar lotes")
+	}
+}
+
+// depositLot registra o lote de um depósito detectado de 1 ETH na wallet do usuário
+func depositLot(t *testing.T, svc *TaxLotService, user uuid.UUID, hash string, at time.Time) *entity.Lot {
+	t.Helper()
+	svc.WithDeposits(fakeWallets{"0xuser": user}, fakeNativeAssets{})
+	lot, err := svc.RecordDetectedDeposit(context.Background(), DetectedDeposit{
+		Chain: "ethereum", TxHash: hash, ToAddress: "0xuser", AmountBaseUnit: 1_000_000_000_000_000_000, At: at,
+	})
+	if err != nil || lot == nil {
+		t.Fatalf("depósito %s: %+v, %v", hash, lot, err)
+	}
+	return lot
+}
+
+func TestTaxLotService_RecordDetectedDeposit(t *testing.T) {
+	svc, repo := newTestTaxService()
+	ctx := context.Background()
+	user := uuid.New()
+
+	lot := depositLot(t, svc, user, "0xabc", taxNow)
+	if lot.Asset != "ETH" || !lot.Quantity.Equal(dec("1")) || !lot.CostBasis.Equal(dec("20000")) ||
+		lot.Source != entity.LotSourceDeposit || lot.SourceID != "ethereum:0xabc" {
+		t.Fatalf("lote do depósito incorreto: %+v", lot)
+	}
+
+	// redetecção do mesmo hash e depósitos em endereços sem wallet de usuário não geram lotes
+	again, err := svc.RecordDetectedDeposit(ctx, DetectedDeposit{Chain: "ethereum", TxHash: "0xabc", ToAddress: "0xuser", AmountBaseUnit: 1})
+	if err != nil || again != nil {
+		t.Fatalf("redetecção deveria ser ignorada: %+v, %v", again, err)
+	}
+	other, err := svc.RecordDetectedDeposit(ctx, DetectedDeposit{Chain: "ethereum", TxHash: "0xdef", ToAddress: "0xplatform", AmountBaseUnit: 1})
+	if err != nil || other != nil {
+		t.Fatalf("endereço sem wallet deveria ser ignorado: %+v, %v", other, err)
+	}
+	if len(repo.lots) != 1 {
+		t.Fatalf("esperado um lote, obtido %d", len(repo.lots))
+	}
+}
+
+func TestTaxLotService_SetDepositCostBasis(t *testing.T) {
+	svc, _ := newTestTaxService()
+	ctx := context.Background()
+	user := uuid.New()
+	lot := depositLot(t, svc, user, "0xabc", taxNow)
+	past := taxNow.AddDate(-1, 0, 0)
+
+	updated, err := svc.SetDepositCostBasis(ctx, user, lot.ID, DepositCostBasis{CostBasis: dec("15000"), AcquiredAt: &past})
+	if err != nil || !updated.CostBasis.Equal(dec("15000")) || !updated.AcquiredAt.Equal(past) {
+		t.Fatalf("custo informado: %+v, %v", updated, err)
+	}
+
+	conversion := Conversion{QuoteID: uuid.New(), UserID: user, From: "BRL", To: "ETH", SellAmount: dec("100"), BuyAmount: dec("0.01"), At: taxNow}
+	if err := svc.RecordConversion(ctx, conversion); err != nil {
+		t.Fatalf("conversão: %v", err)
+	}
+	lots, _ := svc.Lots(ctx, user)
+	var converted *entity.Lot
+	for _, l := range lots {
+		if l.Source == entity.LotSourceConversion {
+			converted = l
+		}
+	}
+
+	after := past.AddDate(0, 6, 0)
+	cases := []struct {
+		name  string
+		user  uuid.UUID
+		lotID uuid.UUID
+		req   DepositCostBasis
+		want  error
+	}{
+		{"lote inexistente", user, uuid.New(), DepositCostBasis{CostBasis: dec("1")}, ErrLotNotFound},
+		{"lote de outro usuário", uuid.New(), lot.ID, DepositCostBasis{CostBasis: dec("1")}, ErrLotNotFound},
+		{"lote de conversão", user, converted.ID, DepositCostBasis{CostBasis: dec("1")}, ErrNotDepositLot},
+		{"custo negativo", user, lot.ID, DepositCostBasis{CostBasis: dec("-1")}, entity.ErrInvalidCostBasis},
+		{"aquisição após o depósito", user, lot.ID, DepositCostBasis{CostBasis: dec("1"), AcquiredAt: &after}, ErrAcquisitionAfterDeposit},
+	}
+	for _, tc := range cases {
+		if _, err := svc.SetDepositCostBasis(ctx, tc.user, tc.lotID, tc.req); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: esperado %v, obtido %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestTaxLotService_DesignateAndReport(t *testing.T) {
+	svc, repo := newTestTaxService()
+	ctx := context.Background()
+	user := uuid.New()
+	cheap, expensive := dec("10000"), dec("30000")
+	early, late := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	first := depositLot(t, svc, user, "0xa", early)
+	if _, err := svc.SetDepositCostBasis(ctx, user, first.ID, DepositCostBasis{CostBasis: cheap}); err != nil {
+		t.Fatalf("custo do lote: %v", err)
+	}
+	second := depositLot(t, svc, user, "0xb", late)
+	if _, err := svc.SetDepositCostBasis(ctx, user, second.ID, DepositCostBasis{CostBasis: expensive}); err != nil {
+		t.Fatalf("custo do lote: %v", err)
+	}
+	if err := svc.RecordConversion(ctx, Conversion{QuoteID: uuid.New(), UserID: user, From: "ETH", To: "BRL",
+		SellAmount: dec("1"), BuyAmount: dec("25000"), At: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatalf("venda: %v", err)
+	}
+	sale := repo.disposals[0]
+
+	report, err := svc.Report(ctx, user, 2024, entity.CostMethodFIFO)
+	if err != nil || !report.NetGain.Equal(dec("15000")) {
+		t.Fatalf("FIFO deveria apurar ganho de 15000: %+v, %v", report, err)
+	}
+
+	if _, err := svc.Designate(ctx, uuid.New(), sale.ID, nil); !errors.Is(err, ErrDisposalNotFound) {
+		t.Fatalf("alienação de outro usuário: esperado ErrDisposalNotFound, obtido %v", err)
+	}
+	if _, err := svc.Designate(ctx, user, sale.ID, []entity.LotDesignation{{LotID: second.ID, Quantity: dec("1")}}); err != nil {
+		t.Fatalf("designação: %v", err)
+	}
+	report, err = svc.Report(ctx, user, 2024, entity.CostMethodSpecific)
+	if err != nil || !report.NetGain.Equal(dec("-5000")) {
+		t.Fatalf("identificação específica deveria apurar perda de 5000: %+v, %v", report, err)
+	}
+	report, _ = svc.Report(ctx, user, 2024, entity.CostMethodAverage)
+	if !report.NetGain.Equal(dec("5000")) {
+		t.Fatalf("custo médio deveria apurar ganho de 5000, obtido %s", report.NetGain)
+	}
+
+	if report, _ = svc.Report(ctx, user, 2025, entity.CostMethodFIFO); len(report.Gains) != 0 {
+		t.Fatalf("2025 não tem alienações, obtido %d", len(report.Gains))
+	}
+	if _, err := svc.Report(ctx, user, 2026, entity.CostMethodFIFO); !errors.Is(err, ErrInvalidYear) {
+		t.Fatalf("ano futuro: esperado ErrInvalidYear, obtido %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CostMethod método de apuração do custo das alienações
+type CostMethod string
+
+const (
+	// CostMethodFIFO consome primeiro os lotes mais antigos
+	CostMethodFIFO CostMethod = "fifo"
+	// CostMethodAverage custo médio ponderado, o exigido pela Receita Federal para pessoa física
+	CostMethodAverage CostMethod = "average"
+	// CostMethodSpecific lotes escolhidos pelo usuário em cada alienação; sem escolha, FIFO
+	CostMethodSpecific CostMethod = "specific"
+)
+
+// LotSource origem da aquisição ou da alienação
+type LotSource string
+
+const (
+	LotSourceConversion LotSource = "conversion" // conversão de câmbio (fx.converted)
+	LotSourceDeposit    LotSource = "deposit"    // depósito on-chain detectado (chain:hash)
+)
+
+var (
+	ErrUnknownCostMethod  = errors.New("unknown cost method")
+	ErrInvalidQuantity    = errors.New("quantity must be positive")
+	ErrInvalidCostBasis   = errors.New("cost basis cannot be negative")
+	ErrInvalidDesignation = errors.New("invalid lot designation")
+)
+
+// ParseCostMethod valida o método; vazio resulta no custo médio
+func ParseCostMethod(method string) (CostMethod, error) {
+	switch m := CostMethod(strings.ToLower(strings.TrimSpace(method))); m {
+	case "":
+		return CostMethodAverage, nil
+	case CostMethodFIFO, CostMethodAverage, CostMethodSpecific:
+		return m, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownCostMethod, method)
+}
+
+// Lot lote de aquisição de um ativo com o custo total em moeda fiduciária (BRL) na data
+type Lot struct {
+	ID         uuid.UUID       `json:"id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	Asset      string          `json:"asset"`
+	Quantity   decimal.Decimal `json:"quantity"`
+	CostBasis  decimal.Decimal `json:"cost_basis"`
+	Source     LotSource       `json:"source"`
+	SourceID   string          `json:"source_id"`
+	AcquiredAt time.Time       `json:"acquired_at"`
+}
+
+// NewLot cria o lote de aquisição
+func NewLot(userID uuid.UUID, asset string, quantity, costBasis decimal.Decimal, source LotSource, sourceID string, acquiredAt time.Time) (*Lot, error) {
+	if !quantity.IsPositive() {
+		return nil, ErrInvalidQuantity
+	}
+	if costBasis.IsNegative() {
+		return nil, ErrInvalidCostBasis
+	}
+	return &Lot{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Asset:      strings.ToUpper(asset),
+		Quantity:   quantity,
+		CostBasis:  costBasis,
+		Source:     source,
+		SourceID:   sourceID,
+		AcquiredAt: acquiredAt,
+	}, nil
+}
+
+// UnitCost custo unitário do lote
+func (l *Lot) UnitCost() decimal.Decimal {
+	return l.CostBasis.DivRound(l.Quantity, 18)
+}
+
+// LotDesignation quantidade de um lote atribuída a uma alienação (identificação específica)
+type LotDesignation struct {
+	LotID    uuid.UUID       `json:"lot_id"`
+	Quantity decimal.Decimal `json:"quantity"`
+}
+
+// Disposal alienação de um ativo com o valor recebido em moeda fiduciária (BRL) na data
+type Disposal struct {
+	ID           uuid.UUID        `json:"id"`
+	UserID       uuid.UUID        `json:"user_id"`
+	Asset        string           `json:"asset"`
+	Quantity     decimal.Decimal  `json:"quantity"`
+	Proceeds     decimal.Decimal  `json:"proceeds"`
+	Source       LotSource        `json:"source"`
+	SourceID     string           `json:"source_id"`
+	DisposedAt   time.Time        `json:"disposed_at"`
+	Designations []LotDesignation `json:"designations,omitempty"`
+}
+
+// NewDisposal cria a alienação
+func NewDisposal(userID uuid.UUID, asset string, quantity, proceeds decimal.Decimal, source LotSource, sourceID string, disposedAt time.Time) (*Disposal, error) {
+	if !quantity.IsPositive() {
+		return nil, ErrInvalidQuantity
+	}
+	if proceeds.IsNegative() {
+		return nil, ErrInvalidCostBasis
+	}
+	return &Disposal{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Asset:      strings.ToUpper(asset),
+		Quantity:   quantity,
+		Proceeds:   proceeds,
+		Source:     source,
+		SourceID:   sourceID,
+		DisposedAt: disposedAt,
+	}, nil
+}
+
+// ValidateDesignations confere se os lotes escolhidos são do mesmo ativo, anteriores à alienação,
+// sem repetição, e se as quantidades somam exatamente a quantidade alienada
+func (d *Disposal) ValidateDesignations(designations []LotDesignation, lots map[uuid.UUID]*Lot) error {
+	total := decimal.Zero
+	seen := make(map[uuid.UUID]bool, len(designations))
+	for _, des := range designations {
+		lot, ok := lots[des.LotID]
+		switch {
+		case !ok || lot.UserID != d.UserID:
+			return fmt.Errorf("%w: lot %s not found", ErrInvalidDesignation, des.LotID)
+		case lot.Asset != d.Asset:
+			return fmt.Errorf("%w: lot %s is %s, not %s", ErrInvalidDesignation, des.LotID, lot.Asset, d.Asset)
+		case lot.AcquiredAt.After(d.DisposedAt):
+			return fmt.Errorf("%w: lot %s was acquired after the disposal", ErrInvalidDesignation, des.LotID)
+		case seen[des.LotID]:
+			return fmt.Errorf("%w: lot %s designated twice", ErrInvalidDesignation, des.LotID)
+		case !des.Quantity.IsPositive() || des.Quantity.GreaterThan(lot.Quantity):
+			return fmt.Errorf("%w: quantity of lot %s", ErrInvalidDesignation, des.LotID)
+		}
+		seen[des.LotID] = true
+		total = total.Add(des.Quantity)
+	}
+	if !total.Equal(d.Quantity) {
+		return fmt.Errorf("%w: designated %s, disposed %s", ErrInvalidDesignation, total, d.Quantity)
+	}
+	return nil
+}
+
+// LotConsumption parte de um lote consumida por uma alienação
+type LotConsumption struct {
+	LotID      uuid.UUID       `json:"lot_id"`
+	AcquiredAt time.Time       `json:"acquired_at"`
+	Quantity   decimal.Decimal `json:"quantity"`
+	CostBasis  decimal.Decimal `json:"cost_basis"`
+}
+
+// RealizedGain ganho ou perda apurado em uma alienação
+type RealizedGain struct {
+	DisposalID uuid.UUID       `json:"disposal_id"`
+	Asset      string          `json:"asset"`
+	DisposedAt time.Time       `json:"disposed_at"`
+	Quantity   decimal.Decimal `json:"quantity"`
+	Proceeds   decimal.Decimal `json:"proceeds"`
+	CostBasis  decimal.Decimal `json:"cost_basis"`
+	Gain       decimal.Decimal `json:"gain"` // Proceeds - CostBasis; negativo é perda
+	// Uncovered quantidade alienada sem lote de aquisição registrado, apurada com custo zero
+	Uncovered decimal.Decimal  `json:"uncovered"`
+	Lots      []LotConsumption `json:"lots,omitempty"` // vazio no custo médio
+}
+
+// Holding posição remanescente de um ativo após as alienações
+type Holding struct {
+	Asset     string          `json:"asset"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	CostBasis decimal.Decimal `json:"cost_basis"`
+}
+
+// openLot saldo ainda não consumido de um lote
+type openLot struct {
+	lot       *Lot
+	remaining decimal.Decimal
+}
+
+// ComputeGains apura, ativo a ativo e em ordem cronológica, o custo de cada alienação pelo método
+// e retorna os ganhos realizados e as posições remanescentes. Aquisições no mesmo instante de uma
+// alienação entram antes dela.
+func ComputeGains(method CostMethod, lots []*Lot, disposals []*Disposal) ([]RealizedGain, []Holding, error) {
+	if _, err := ParseCostMethod(string(method)); err != nil {
+		return nil, nil, err
+	}
+	lotsByAsset := make(map[string][]*Lot)
+	for _, l := range lots {
+		lotsByAsset[l.Asset] = append(lotsByAsset[l.Asset], l)
+	}
+	disposalsByAsset := make(map[string][]*Disposal)
+	for _, d := range disposals {
+		disposalsByAsset[d.Asset] = append(disposalsByAsset[d.Asset], d)
+	}
+	assets := make([]string, 0, len(lotsByAsset)+len(disposalsByAsset))
+	for asset := range lotsByAsset {
+		assets = append(assets, asset)
+	}
+	for asset := range disposalsByAsset {
+		if _, ok := lotsByAsset[asset]; !ok {
+			assets = append(assets, asset)
+		}
+	}
+	sort.Strings(assets)
+
+	var (
+		gains    []RealizedGain
+		holdings []Holding
+	)
+	for _, asset := range assets {
+		assetGains, holding := computeAsset(method, lotsByAsset[asset], disposalsByAsset[asset])
+		gains = append(gains, assetGains...)
+		if holding.Quantity.IsPositive() {
+			holding.Asset = asset
+			holdings = append(holdings, holding)
+		}
+	}
+	sort.SliceStable(gains, func(i, j int) bool { return gains[i].DisposedAt.Before(gains[j].DisposedAt) })
+	return gains, holdings, nil
+}
+
+func computeAsset(method CostMethod, lots []*Lot, disposals []*Disposal) ([]RealizedGain, Holding) {
+	sort.SliceStable(lots, func(i, j int) bool { return lots[i].AcquiredAt.Before(lots[j].AcquiredAt) })
+	sort.SliceStable(disposals, func(i, j int) bool { return disposals[i].DisposedAt.Before(disposals[j].DisposedAt) })
+
+	var (
+		open      []*openLot
+		byID      = make(map[uuid.UUID]*openLot)
+		poolQty   = decimal.Zero // custo médio
+		poolCost  = decimal.Zero
+		gains     []RealizedGain
+		nextLot   int
+		acquireTo = func(t time.Time) {
+			for ; nextLot < len(lots) && !lots[nextLot].AcquiredAt.After(t); nextLot++ {
+				ol := &openLot{lot: lots[nextLot], remaining: lots[nextLot].Quantity}
+				open = append(open, ol)
+				byID[ol.lot.ID] = ol
+				poolQty = poolQty.Add(ol.lot.Quantity)
+				poolCost = poolCost.Add(ol.lot.CostBasis)
+			}
+		}
+	)
+
+	for _, d := range disposals {
+		acquireTo(d.DisposedAt)
+		g := RealizedGain{
+			DisposalID: d.ID,
+			Asset:      d.Asset,
+			DisposedAt: d.DisposedAt,
+			Quantity:   d.Quantity,
+			Proceeds:   d.Proceeds,
+			CostBasis:  decimal.Zero,
+			Uncovered:  decimal.Zero,
+		}
+
+		if method == CostMethodAverage {
+			qty := decimal.Min(d.Quantity, poolQty)
+			if qty.IsPositive() {
+				cost := poolCost
+				if qty.LessThan(poolQty) {
+					cost = poolCost.Mul(qty).DivRound(poolQty, 18)
+				}
+				g.CostBasis = cost
+				poolQty = poolQty.Sub(qty)
+				poolCost = poolCost.Sub(cost)
+			}
+			g.Uncovered = d.Quantity.Sub(qty)
+		} else {
+			remaining := d.Quantity
+			consume := func(ol *openLot, want decimal.Decimal) decimal.Decimal {
+				qty := decimal.Min(want, ol.remaining)
+				if !qty.IsPositive() {
+					return decimal.Zero
+				}
+				cost := ol.lot.CostBasis.Mul(qty).DivRound(ol.lot.Quantity, 18)
+				ol.remaining = ol.remaining.Sub(qty)
+				g.CostBasis = g.CostBasis.Add(cost)
+				g.Lots = append(g.Lots, LotConsumption{LotID: ol.lot.ID, AcquiredAt: ol.lot.AcquiredAt, Quantity: qty, CostBasis: cost})
+				return qty
+			}
+			if method == CostMethodSpecific {
+				for _, des := range d.Designations {
+					if ol, ok := byID[des.LotID]; ok {
+						remaining = remaining.Sub(consume(ol, decimal.Min(des.Quantity, remaining)))
+					}
+				}
+			}
+			for _, ol := range open {
+				if !remaining.IsPositive() {
+					break
+				}
+				remaining = remaining.Sub(consume(ol, remaining))
+			}
+			g.Uncovered = remaining
+			// mantém os lotes do custo médio em sincronia para a posição final
+			poolQty = poolQty.Sub(d.Quantity.Sub(remaining))
+			poolCost = poolCost.Sub(g.CostBasis)
+		}
+		g.Gain = g.Proceeds.Sub(g.CostBasis)
+		gains = append(gains, g)
+	}
+	for _, l := range lots[nextLot:] {
+		poolQty = poolQty.Add(l.Quantity)
+		poolCost = poolCost.Add(l.CostBasis)
+	}
+	return gains, Holding{Quantity: poolQty, CostBasis: poolCost}
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	taxUser = uuid.New()
+	day0    = time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+func lot(t *testing.T, qty, cost string, days int) *Lot {
+	l, err := NewLot(taxUser, "eth", d(qty), d(cost), LotSourceConversion, uuid.NewString(), day0.AddDate(0, 0, days))
+	require.NoError(t, err)
+	return l
+}
+
+func disposal(t *testing.T, qty, proceeds string, days int) *Disposal {
+	dp, err := NewDisposal(taxUser, "ETH", d(qty), d(proceeds), LotSourceConversion, uuid.NewString(), day0.AddDate(0, 0, days))
+	require.NoError(t, err)
+	return dp
+}
+
+// lotes: 1 ETH a 10.000 e 1 ETH a 20.000; venda de 1,5 ETH por 45.000
+func gainsFixture(t *testing.T) ([]*Lot, []*Disposal) {
+	return []*Lot{lot(t, "1", "20000", 10), lot(t, "1", "10000", 0)}, []*Disposal{disposal(t, "1.5", "45000", 20)}
+}
+
+func TestComputeGains_FIFO(t *testing.T) {
+	lots, disposals := gainsFixture(t)
+	gains, holdings, err := ComputeGains(CostMethodFIFO, lots, disposals)
+	require.NoError(t, err)
+	require.Len(t, gains, 1)
+	assert.Equal(t, "20000", gains[0].CostBasis.String(), "1 ETH a 10.000 + 0,5 ETH a 20.000")
+	assert.Equal(t, "25000", gains[0].Gain.String())
+	require.Len(t, gains[0].Lots, 2)
+	assert.Equal(t, lots[1].ID, gains[0].Lots[0].LotID, "lote mais antigo primeiro")
+	require.Len(t, holdings, 1)
+	assert.Equal(t, "0.5", holdings[0].Quantity.String())
+	assert.Equal(t, "10000", holdings[0].CostBasis.String())
+}
+
+func TestComputeGains_Average(t *testing.T) {
+	lots, disposals := gainsFixture(t)
+	gains, holdings, err := ComputeGains(CostMethodAverage, lots, disposals)
+	require.NoError(t, err)
+	assert.Equal(t, "22500", gains[0].CostBasis.String(), "1,5 ETH ao custo médio de 15.000")
+	assert.Equal(t, "22500", gains[0].Gain.String())
+	assert.Empty(t, gains[0].Lots)
+	assert.Equal(t, "7500", holdings[0].CostBasis.String())
+}
+
+func TestComputeGains_SpecificFallsBackToFIFO(t *testing.T) {
+	lots, disposals := gainsFixture(t)
+	disposals[0].Designations = []LotDesignation{{LotID: lots[0].ID, Quantity: d("1")}}
+	gains, _, err := ComputeGains(CostMethodSpecific, lots, disposals)
+	require.NoError(t, err)
+	assert.Equal(t, "25000", gains[0].CostBasis.String(), "1 ETH do lote de 20.000 e 0,5 do mais antigo")
+	assert.Equal(t, lots[0].ID, gains[0].Lots[0].LotID)
+
+	// sem designação a identificação específica equivale ao FIFO
+	lots, disposals = gainsFixture(t)
+	gains, _, err = ComputeGains(CostMethodSpecific, lots, disposals)
+	require.NoError(t, err)
+	assert.Equal(t, "20000", gains[0].CostBasis.String())
+}
+
+func TestComputeGains_UncoveredAndOrdering(t *testing.T) {
+	// venda anterior ao segundo lote só consome o primeiro; o excedente fica sem custo
+	lots := []*Lot{lot(t, "1", "10000", 0), lot(t, "1", "20000", 30)}
+	disposals := []*Disposal{disposal(t, "1.5", "30000", 20)}
+	for _, method := range []CostMethod{CostMethodFIFO, CostMethodAverage} {
+		gains, holdings, err := ComputeGains(method, lots, disposals)
+		require.NoError(t, err)
+		assert.Equal(t, "0.5", gains[0].Uncovered.String(), method)
+		assert.Equal(t, "10000", gains[0].CostBasis.String(), method)
+		assert.Equal(t, "1", holdings[0].Quantity.String(), method)
+	}
+
+	_, _, err := ComputeGains("lifo", lots, disposals)
+	assert.True(t, errors.Is(err, ErrUnknownCostMethod))
+}
+
+func TestDisposal_ValidateDesignations(t *testing.T) {
+	early, late := lot(t, "1", "10000", 0), lot(t, "1", "10000", 30)
+	btc, err := NewLot(taxUser, "BTC", d("1"), d("1"), LotSourceDeposit, "x", day0)
+	require.NoError(t, err)
+	lots := map[uuid.UUID]*Lot{early.ID: early, late.ID: late, btc.ID: btc}
+	dp := disposal(t, "0.5", "1", 20)
+
+	assert.NoError(t, dp.ValidateDesignations([]LotDesignation{{LotID: early.ID, Quantity: d("0.5")}}, lots))
+	cases := map[string][]LotDesignation{
+		"lote posterior":   {{LotID: late.ID, Quantity: d("0.5")}},
+		"outro ativo":      {{LotID: btc.ID, Quantity: d("0.5")}},
+		"lote inexistente": {{LotID: uuid.New(), Quantity: d("0.5")}},
+		"soma divergente":  {{LotID: early.ID, Quantity: d("0.4")}},
+		"lote repetido":    {{LotID: early.ID, Quantity: d("0.25")}, {LotID: early.ID, Quantity: d("0.25")}},
+	}
+	for name, des := range cases {
+		assert.True(t, errors.Is(dp.ValidateDesignations(des, lots), ErrInvalidDesignation), name)
+	}
+}
+
+func TestAnnualReport_FiltersYearAndWritesCSV(t *testing.T) {
+	lots := []*Lot{lot(t, "2", "20000", 0)}
+	disposals := []*Disposal{disposal(t, "0.5", "6000", 10), disposal(t, "0.5", "4000", 70)} // 2024-11 e 2025-01
+	gains, holdings, err := ComputeGains(CostMethodAverage, lots, disposals)
+	require.NoError(t, err)
+
+	report := NewAnnualReport(taxUser, 2025, CostMethodAverage, "BRL", gains, holdings, time.UTC, day0)
+	require.Len(t, report.Gains, 1)
+	assert.Equal(t, "-1000", report.NetGain.String(), "perda de 1.000 em 2025")
+	require.Len(t, report.ByMonth, 1)
+	assert.Equal(t, "2025-01", report.ByMonth[0].Month)
+	require.Len(t, report.ByAsset, 1)
+	assert.Equal(t, "ETH", report.ByAsset[0].Asset)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTaxReport(&buf, ReportFormatCSV, report))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "disposed_at", rows[0][0])
+	assert.Equal(t, "-1000.00", rows[1][6])
+	assert.Equal(t, "TOTAL", rows[2][2])
+
+	assert.Error(t, WriteTaxReport(&buf, "pdf", report))
+}
@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Formatos de exportação do relatório anual
+const (
+	ReportFormatCSV  = "csv"
+	ReportFormatJSON = "json"
+)
+
+// AssetSummary totais do ano de um ativo
+type AssetSummary struct {
+	Asset     string          `json:"asset"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Proceeds  decimal.Decimal `json:"proceeds"`
+	CostBasis decimal.Decimal `json:"cost_basis"`
+	Gain      decimal.Decimal `json:"gain"`
+}
+
+// MonthSummary totais de um mês: a apuração do ganho de capital é mensal e a isenção depende do
+// total alienado no mês
+type MonthSummary struct {
+	Month    string          `json:"month"` // AAAA-MM
+	Proceeds decimal.Decimal `json:"proceeds"`
+	Gain     decimal.Decimal `json:"gain"`
+}
+
+// AnnualReport ganhos e perdas realizados pelo usuário no ano, em BRL
+type AnnualReport struct {
+	UserID        uuid.UUID       `json:"user_id"`
+	Year          int             `json:"year"`
+	Method        CostMethod      `json:"method"`
+	Currency      string          `json:"currency"`
+	Gains         []RealizedGain  `json:"gains"`
+	ByAsset       []AssetSummary  `json:"by_asset"`
+	ByMonth       []MonthSummary  `json:"by_month"`
+	TotalProceeds decimal.Decimal `json:"total_proceeds"`
+	TotalCost     decimal.Decimal `json:"total_cost"`
+	NetGain       decimal.Decimal `json:"net_gain"`
+	Holdings      []Holding       `json:"holdings"` // posição ao fim do ano
+	GeneratedAt   time.Time       `json:"generated_at"`
+}
+
+// NewAnnualReport agrega os ganhos realizados no ano (no fuso informado) por ativo e por mês
+func NewAnnualReport(userID uuid.UUID, year int, method CostMethod, currency string, gains []RealizedGain, holdings []Holding, location *time.Location, now time.Time) *AnnualReport {
+	r := &AnnualReport{
+		UserID:        userID,
+		Year:          year,
+		Method:        method,
+		Currency:      currency,
+		Gains:         []RealizedGain{},
+		ByAsset:       []AssetSummary{},
+		ByMonth:       []MonthSummary{},
+		TotalProceeds: decimal.Zero,
+		TotalCost:     decimal.Zero,
+		NetGain:       decimal.Zero,
+		Holdings:      holdings,
+		GeneratedAt:   now,
+	}
+	if r.Holdings == nil {
+		r.Holdings = []Holding{}
+	}
+	byAsset := make(map[string]*AssetSummary)
+	byMonth := make(map[string]*MonthSummary)
+	for _, g := range gains {
+		local := g.DisposedAt.In(location)
+		if local.Year() != year {
+			continue
+		}
+		r.Gains = append(r.Gains, g)
+		r.TotalProceeds = r.TotalProceeds.Add(g.Proceeds)
+		r.TotalCost = r.TotalCost.Add(g.CostBasis)
+
+		a, ok := byAsset[g.Asset]
+		if !ok {
+			a = &AssetSummary{Asset: g.Asset}
+			byAsset[g.Asset] = a
+		}
+		a.Quantity = a.Quantity.Add(g.Quantity)
+		a.Proceeds = a.Proceeds.Add(g.Proceeds)
+		a.CostBasis = a.CostBasis.Add(g.CostBasis)
+		a.Gain = a.Gain.Add(g.Gain)
+
+		month := local.Format("2006-01")
+		m, ok := byMonth[month]
+		if !ok {
+			m = &MonthSummary{Month: month}
+			byMonth[month] = m
+		}
+		m.Proceeds = m.Proceeds.Add(g.Proceeds)
+		m.Gain = m.Gain.Add(g.Gain)
+	}
+	r.NetGain = r.TotalProceeds.Sub(r.TotalCost)
+
+	for _, a := range byAsset {
+		r.ByAsset = append(r.ByAsset, *a)
+	}
+	sort.Slice(r.ByAsset, func(i, j int) bool { return r.ByAsset[i].Asset < r.ByAsset[j].Asset })
+	for _, m := range byMonth {
+		r.ByMonth = append(r.ByMonth, *m)
+	}
+	sort.Slice(r.ByMonth, func(i, j int) bool { return r.ByMonth[i].Month < r.ByMonth[j].Month })
+	return r
+}
+
+// WriteTaxReport escreve o relatório no formato informado
+func WriteTaxReport(w io.Writer, format string, r *AnnualReport) error {
+	switch format {
+	case ReportFormatCSV:
+		return writeTaxReportCSV(w, r)
+	case ReportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	return fmt.Errorf("unsupported tax report format %q", format)
+}
+
+// writeTaxReportCSV uma linha por alienação e a linha de totais do ano
+func writeTaxReportCSV(w io.Writer, r *AnnualReport) error {
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"disposed_at", "disposal_id", "asset", "quantity", "proceeds", "cost_basis", "gain", "uncovered_quantity", "method"})
+	for _, g := range r.Gains {
+		_ = out.Write([]string{
+			g.DisposedAt.UTC().Format(time.RFC3339),
+			g.DisposalID.String(),
+			g.Asset,
+			g.Quantity.String(),
+			g.Proceeds.StringFixed(2),
+			g.CostBasis.StringFixed(2),
+			g.Gain.StringFixed(2),
+			g.Uncovered.String(),
+			string(r.Method),
+		})
+	}
+	_ = out.Write([]string{fmt.Sprintf("%d", r.Year), "", "TOTAL", "", r.TotalProceeds.StringFixed(2), r.TotalCost.StringFixed(2), r.NetGain.StringFixed(2), "", string(r.Method)})
+	out.Flush()
+	return out.Error()
+}
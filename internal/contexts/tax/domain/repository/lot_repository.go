@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/tax/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// LotRepository persiste os lotes de aquisição e as alienações de ativos dos usuários
+type LotRepository interface {
+	// CreateLot grava o lote; lotes da mesma origem (source, source_id, asset) são ignorados e
+	// retornam false
+	CreateLot(ctx context.Context, lot *entity.Lot) (bool, error)
+	// CreateDisposal grava a alienação; alienações da mesma origem são ignoradas e retornam false
+	CreateDisposal(ctx context.Context, d *entity.Disposal) (bool, error)
+	// FindLot retorna nil, nil quando o lote não existe
+	FindLot(ctx context.Context, id uuid.UUID) (*entity.Lot, error)
+	// UpdateLotCost substitui o custo e a data de aquisição do lote
+	UpdateLotCost(ctx context.Context, id uuid.UUID, costBasis decimal.Decimal, acquiredAt time.Time) error
+	// ListLots lista os lotes do usuário em ordem de aquisição
+	ListLots(ctx context.Context, userID uuid.UUID) ([]*entity.Lot, error)
+	// ListDisposals lista as alienações do usuário em ordem cronológica, com os lotes designados
+	ListDisposals(ctx context.Context, userID uuid.UUID) ([]*entity.Disposal, error)
+	// FindDisposal retorna nil, nil quando a alienação não existe
+	FindDisposal(ctx context.Context, id uuid.UUID) (*entity.Disposal, error)
+	// SetDesignations substitui os lotes designados para a alienação
+	SetDesignations(ctx context.Context, disposalID uuid.UUID, designations []entity.LotDesignation) error
+}
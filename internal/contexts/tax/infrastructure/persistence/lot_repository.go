@@ -0,0 +1,213 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/tax/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresLotRepository implementa LotRepository usando PostgreSQL
+type PostgresLotRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresLotRepository cria um novo repositório de lotes e alienações
+func NewPostgresLotRepository(conn database.Connection) *PostgresLotRepository {
+	return &PostgresLotRepository{
+		conn:   conn,
+		schema: "tax_context",
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const lotColumns = `id, user_id, asset, quantity, cost_basis, source, source_id, acquired_at`
+
+const disposalColumns = `id, user_id, asset, quantity, proceeds, source, source_id, disposed_at, designations`
+
+// CreateLot grava o lote; a mesma origem não é gravada duas vezes
+func (r *PostgresLotRepository) CreateLot(ctx context.Context, lot *entity.Lot) (bool, error) {
+	res, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.lots (`+lotColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, source, source_id, asset) DO NOTHING
+	`,
+		lot.ID,
+		lot.UserID,
+		lot.Asset,
+		lot.Quantity,
+		lot.CostBasis,
+		string(lot.Source),
+		lot.SourceID,
+		lot.AcquiredAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// CreateDisposal grava a alienação; a mesma origem não é gravada duas vezes
+func (r *PostgresLotRepository) CreateDisposal(ctx context.Context, d *entity.Disposal) (bool, error) {
+	designations, err := json.Marshal(nonNilDesignations(d.Designations))
+	if err != nil {
+		return false, err
+	}
+	res, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.disposals (`+disposalColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb)
+		ON CONFLICT (user_id, source, source_id, asset) DO NOTHING
+	`,
+		d.ID,
+		d.UserID,
+		d.Asset,
+		d.Quantity,
+		d.Proceeds,
+		string(d.Source),
+		d.SourceID,
+		d.DisposedAt,
+		string(designations),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// FindLot busca o lote por ID
+func (r *PostgresLotRepository) FindLot(ctx context.Context, id uuid.UUID) (*entity.Lot, error) {
+	lot, err := scanLot(r.conn.QueryRow(ctx, `SELECT `+lotColumns+` FROM `+r.schema+`.lots WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return lot, nil
+}
+
+// UpdateLotCost substitui o custo e a data de aquisição do lote
+func (r *PostgresLotRepository) UpdateLotCost(ctx context.Context, id uuid.UUID, costBasis decimal.Decimal, acquiredAt time.Time) error {
+	_, err := r.conn.Exec(ctx, `UPDATE `+r.schema+`.lots SET cost_basis = $2, acquired_at = $3 WHERE id = $1`, id, costBasis, acquiredAt)
+	return err
+}
+
+// ListLots lista os lotes do usuário em ordem de aquisição
+func (r *PostgresLotRepository) ListLots(ctx context.Context, userID uuid.UUID) ([]*entity.Lot, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+lotColumns+`
+		FROM `+r.schema+`.lots
+		WHERE user_id = $1
+		ORDER BY acquired_at, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Lot
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, lot)
+	}
+	return out, rows.Err()
+}
+
+// ListDisposals lista as alienações do usuário em ordem cronológica
+func (r *PostgresLotRepository) ListDisposals(ctx context.Context, userID uuid.UUID) ([]*entity.Disposal, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+disposalColumns+`
+		FROM `+r.schema+`.disposals
+		WHERE user_id = $1
+		ORDER BY disposed_at, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Disposal
+	for rows.Next() {
+		d, err := scanDisposal(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// FindDisposal busca a alienação por ID
+func (r *PostgresLotRepository) FindDisposal(ctx context.Context, id uuid.UUID) (*entity.Disposal, error) {
+	d, err := scanDisposal(r.conn.QueryRow(ctx, `SELECT `+disposalColumns+` FROM `+r.schema+`.disposals WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetDesignations substitui os lotes designados para a alienação
+func (r *PostgresLotRepository) SetDesignations(ctx context.Context, disposalID uuid.UUID, designations []entity.LotDesignation) error {
+	raw, err := json.Marshal(nonNilDesignations(designations))
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.Exec(ctx, `UPDATE `+r.schema+`.disposals SET designations = $2::jsonb WHERE id = $1`, disposalID, string(raw))
+	return err
+}
+
+func nonNilDesignations(designations []entity.LotDesignation) []entity.LotDesignation {
+	if designations == nil {
+		return []entity.LotDesignation{}
+	}
+	return designations
+}
+
+func scanLot(row rowScanner) (*entity.Lot, error) {
+	lot := &entity.Lot{}
+	var source string
+	if err := row.Scan(&lot.ID, &lot.UserID, &lot.Asset, &lot.Quantity, &lot.CostBasis, &source,
+		&lot.SourceID, &lot.AcquiredAt); err != nil {
+		return nil, err
+	}
+	lot.Source = entity.LotSource(source)
+	return lot, nil
+}
+
+func scanDisposal(row rowScanner) (*entity.Disposal, error) {
+	d := &entity.Disposal{}
+	var (
+		source       string
+		designations []byte
+	)
+	if err := row.Scan(&d.ID, &d.UserID, &d.Asset, &d.Quantity, &d.Proceeds, &source, &d.SourceID,
+		&d.DisposedAt, &designations); err != nil {
+		return nil, err
+	}
+	d.Source = entity.LotSource(source)
+	if err := json.Unmarshal(designations, &d.Designations); err != nil {
+		return nil, err
+	}
+	if len(d.Designations) == 0 {
+		d.Designations = nil
+	}
+	return d, nil
+}
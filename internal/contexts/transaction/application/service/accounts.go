@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// accountAddress endereço interno que representa uma conta nomeada do tenant
+func accountAddress(accountID uuid.UUID) string {
+	return "account:" + accountID.String()
+}
+
+// DebitForAccount debita da wallet do usuário o aporte numa conta nomeada. O aporte consome os
+// limites de saída, passa pela triagem de risco e fica registrado como account_funding.
+func (s *TransactionService) DebitForAccount(ctx context.Context, userID, accountID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error) {
+	if !amount.IsPositive() {
+		return uuid.Nil, ErrInvalidAmount
+	}
+	if err := s.checkOutflowLimits(ctx, userID, amount); err != nil {
+		s.logger.Warn("account funding rejected by kyc limits", zap.String("user_id", userID.String()), zap.Error(err))
+		return uuid.Nil, err
+	}
+	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil || wallet == nil {
+		return uuid.Nil, ErrWalletNotFound
+	}
+	overdraft, err := s.overdraftLimit(ctx, wallet)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !wallet.HasAvailableFunds(amount.InexactFloat64(), overdraft) {
+		return uuid.Nil, ErrInsufficientBalance
+	}
+
+	tx := entity.NewTransaction(userID, entity.TransactionTypeAccountFunding, amount)
+	tx.FromAddress = wallet.Address
+	tx.ToAddress = accountAddress(accountID)
+	if err := s.screen(ctx, tx); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		s.logger.Error("failed to create account funding transaction", zap.Error(err))
+		return uuid.Nil, err
+	}
+	if err := adjustWallet(ctx, s.walletRepo, userID, -amount.InexactFloat64(), overdraft); err != nil {
+		tx.Fail("failed to debit wallet")
+		_ = s.txRepo.Update(ctx, tx)
+		return uuid.Nil, err
+	}
+	tx.Complete("account-fund-" + tx.ID.String())
+	_ = s.txRepo.Update(ctx, tx)
+	return tx.ID, nil
+}
+
+// CreditFromAccount credita na wallet do usuário o resgate de uma conta nomeada (account_withdrawal)
+func (s *TransactionService) CreditFromAccount(ctx context.Context, userID, accountID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error) {
+	if !amount.IsPositive() {
+		return uuid.Nil, ErrInvalidAmount
+	}
+	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil || wallet == nil {
+		return uuid.Nil, ErrWalletNotFound
+	}
+
+	tx := entity.NewTransaction(userID, entity.TransactionTypeAccountWithdrawal, amount)
+	tx.FromAddress = accountAddress(accountID)
+	tx.ToAddress = wallet.Address
+	if err := s.screen(ctx, tx); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		s.logger.Error("failed to create account withdrawal transaction", zap.Error(err))
+		return uuid.Nil, err
+	}
+	balanceBefore := wallet.Balance
+	if err := adjustWallet(ctx, s.walletRepo, userID, amount.InexactFloat64(), 0); err != nil {
+		tx.Fail("failed to credit wallet")
+		_ = s.txRepo.Update(ctx, tx)
+		return uuid.Nil, err
+	}
+	tx.Complete("account-withdraw-" + tx.ID.String())
+	_ = s.txRepo.Update(ctx, tx)
+	// Como no depósito, a entrada quita primeiro o uso da linha de crédito
+	s.repayCredit(ctx, userID, tx, amount, balanceBefore)
+	return tx.ID, nil
+}
+
+// RefundAccountFunding devolve à wallet um aporte cuja conta nomeada não pôde ser creditada e
+// marca a transação como falha
+func (s *TransactionService) RefundAccountFunding(ctx context.Context, transactionID uuid.UUID, reason string) error {
+	tx, err := s.txRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+	if tx == nil || tx.Type != entity.TransactionTypeAccountFunding {
+		return ErrTransactionNotFound
+	}
+	if tx.Status != entity.TransactionStatusCompleted {
+		return nil
+	}
+	wallet, err := s.walletRepo.FindByUserID(ctx, tx.UserID)
+	if err != nil || wallet == nil {
+		return ErrWalletNotFound
+	}
+	if err := adjustWallet(ctx, s.walletRepo, tx.UserID, tx.Amount.InexactFloat64(), 0); err != nil {
+		return err
+	}
+	tx.Fail(reason)
+	return s.txRepo.Update(ctx, tx)
+}
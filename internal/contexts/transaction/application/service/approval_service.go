@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// DefaultApprovalExpiryInterval intervalo padrão da varredura de solicitações vencidas
+const DefaultApprovalExpiryInterval = time.Minute
+
+// ApprovalService retém saques acima dos limites das políticas até a aprovação dos operadores
+// (maker-checker). O valor é debitado ao reter e devolvido se o pedido for recusado ou expirar.
+type ApprovalService struct {
+	approvals  repository.ApprovalRepository
+	txRepo     repository.TransactionRepository
+	walletRepo userRepo.WalletRepository
+	policies   []entity.ApprovalPolicy
+	fees       *FeeService
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewApprovalService cria o serviço de aprovações com as políticas configuradas
+func NewApprovalService(
+	approvals repository.ApprovalRepository,
+	txRepo repository.TransactionRepository,
+	walletRepo userRepo.WalletRepository,
+	policies []entity.ApprovalPolicy,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *ApprovalService {
+	return &ApprovalService{
+		approvals:  approvals,
+		txRepo:     txRepo,
+		walletRepo: walletRepo,
+		policies:   policies,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Policies retorna as políticas em uso
+func (s *ApprovalService) Policies() []entity.ApprovalPolicy {
+	return s.policies
+}
+
+// PolicyFor retorna a política aplicável ao saque, ou nil se ele pode seguir direto
+func (s *ApprovalService) PolicyFor(currency, chain string, amount decimal.Decimal) *entity.ApprovalPolicy {
+	return entity.SelectApprovalPolicy(s.policies, currency, chain, amount)
+}
+
+// Hold persiste o saque aguardando aprovação, debita o valor da carteira (respeitando o limite de
+// cheque especial) e abre a solicitação
+func (s *ApprovalService) Hold(ctx context.Context, tx *entity.Transaction, overdraftLimit float64, policy *entity.ApprovalPolicy, currency, chain string) (*entity.ApprovalRequest, error) {
+	req := entity.NewApprovalRequest(tx, policy, currency, chain, s.now())
+	if err := entity.RestoreTransactionAggregate(tx).AwaitApproval(req.ID, req.RequiredApprovals, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		s.logger.Error("failed to create held withdraw transaction", zap.Error(err))
+		return nil, err
+	}
+	if err := adjustWallet(ctx, s.walletRepo, tx.UserID, -tx.Amount.Add(tx.Fee).InexactFloat64(), overdraftLimit); err != nil {
+		s.logger.Error("failed to hold withdraw funds", zap.String("tx_id", tx.ID.String()), zap.Error(err))
+		tx.Fail("failed to hold funds")
+		_ = s.txRepo.Update(ctx, tx)
+		return nil, err
+	}
+	if err := s.approvals.Create(ctx, req); err != nil {
+		s.logger.Error("failed to create approval request", zap.String("tx_id", tx.ID.String()), zap.Error(err))
+		s.release(ctx, req, tx, "failed to create approval request", "approval_unavailable")
+		return nil, err
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewWithdrawalApprovalRequestedEvent(
+		req.ID, tx.ID, tx.UserID, tx.Amount, currency, chain, policy.ID, req.RequiredApprovals, req.ExpiresAt,
+	))
+	s.logger.Info("withdraw held for approval",
+		zap.String("approval_id", req.ID.String()),
+		zap.String("tx_id", tx.ID.String()),
+		zap.String("policy_id", policy.ID),
+		zap.Int("required_approvals", req.RequiredApprovals),
+		zap.Time("expires_at", req.ExpiresAt),
+	)
+	return req, nil
+}
+
+// Get retorna a solicitação com a trilha de auditoria
+func (s *ApprovalService) Get(ctx context.Context, id uuid.UUID) (*entity.ApprovalRequest, error) {
+	req, err := s.approvals.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, ErrApprovalNotFound
+	}
+	return req, nil
+}
+
+// List lista solicitações por status (vazio = todas)
+func (s *ApprovalService) List(ctx context.Context, status entity.ApprovalStatus, limit int) ([]*entity.ApprovalRequest, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.approvals.List(ctx, status, limit)
+}
+
+// Approve registra a aprovação do operador; ao atingir o quórum o saque é executado
+func (s *ApprovalService) Approve(ctx context.Context, id, approverID uuid.UUID, roles []string, comment string) (*entity.ApprovalRequest, error) {
+	req, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	action, err := req.Approve(approverID, roles, comment, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status == entity.ApprovalPending {
+		if err := s.approvals.AppendAction(ctx, req.ID, action); err != nil {
+			return nil, err
+		}
+		// Aprovações simultâneas podem completar o quórum sem que nenhuma delas o perceba
+		if req, err = s.Get(ctx, id); err != nil {
+			return nil, err
+		}
+		if req.Status != entity.ApprovalPending || req.Approvals() < req.RequiredApprovals {
+			s.logger.Info("withdraw approval recorded",
+				zap.String("approval_id", req.ID.String()),
+				zap.String("approver_id", approverID.String()),
+				zap.Int("approvals", req.Approvals()),
+				zap.Int("required", req.RequiredApprovals),
+			)
+			return req, nil
+		}
+		now := s.now()
+		req.Status, req.ResolvedAt = entity.ApprovalApproved, &now
+		if err := s.resolve(ctx, req); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.resolve(ctx, req); err != nil {
+			return nil, err
+		}
+		if err := s.approvals.AppendAction(ctx, req.ID, action); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.transaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.RestoreTransactionAggregate(tx).Approve(req.ID); err != nil {
+		return nil, err
+	}
+	tx.Complete("withdraw-" + tx.ID.String())
+	if err := s.txRepo.Update(ctx, tx); err != nil {
+		s.logger.Error("failed to complete approved withdraw", zap.String("tx_id", tx.ID.String()), zap.Error(err))
+		return nil, err
+	}
+	collectFee(ctx, s.fees, s.logger, tx)
+
+	s.eventBus.PublishAsync(ctx, events.NewWithdrawCompletedEvent(tx.UserID, tx.Amount, tx.TransactionHash))
+	s.eventBus.PublishAsync(ctx, events.NewWithdrawalApprovalResolvedEvent(req.ID, tx.ID, tx.UserID, tx.Amount, string(req.Status), &approverID))
+	s.logger.Info("withdraw approved and executed",
+		zap.String("approval_id", req.ID.String()),
+		zap.String("tx_id", tx.ID.String()),
+		zap.String("approver_id", approverID.String()),
+	)
+	return req, nil
+}
+
+// Reject recusa o saque retido e devolve o valor à carteira
+func (s *ApprovalService) Reject(ctx context.Context, id, approverID uuid.UUID, roles []string, comment string) (*entity.ApprovalRequest, error) {
+	req, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	action, err := req.Reject(approverID, roles, comment, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolve(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.approvals.AppendAction(ctx, req.ID, action); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.transaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.release(ctx, req, tx, "withdraw rejected by approver", "approval_rejected")
+	s.eventBus.PublishAsync(ctx, events.NewWithdrawalApprovalResolvedEvent(req.ID, tx.ID, tx.UserID, tx.Amount, string(req.Status), &approverID))
+	s.logger.Info("withdraw approval rejected",
+		zap.String("approval_id", req.ID.String()),
+		zap.String("tx_id", tx.ID.String()),
+		zap.String("approver_id", approverID.String()),
+	)
+	return req, nil
+}
+
+// ExpireDue encerra as solicitações vencidas e devolve os valores retidos
+func (s *ApprovalService) ExpireDue(ctx context.Context) (int, error) {
+	due, err := s.approvals.ListExpired(ctx, s.now(), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, req := range due {
+		action, ok := req.Expire(s.now())
+		if !ok {
+			continue
+		}
+		if err := s.resolve(ctx, req); err != nil {
+			if !errors.Is(err, entity.ErrApprovalResolved) {
+				s.logger.Error("failed to expire approval request", zap.String("approval_id", req.ID.String()), zap.Error(err))
+			}
+			continue
+		}
+		if err := s.approvals.AppendAction(ctx, req.ID, action); err != nil {
+			s.logger.Error("failed to record approval expiry", zap.String("approval_id", req.ID.String()), zap.Error(err))
+		}
+
+		tx, err := s.transaction(ctx, req)
+		if err != nil {
+			s.logger.Error("held transaction not found for expired approval", zap.String("approval_id", req.ID.String()), zap.Error(err))
+			continue
+		}
+		s.release(ctx, req, tx, "approval window expired", "approval_expired")
+		s.eventBus.PublishAsync(ctx, events.NewWithdrawalApprovalResolvedEvent(req.ID, tx.ID, tx.UserID, tx.Amount, string(req.Status), nil))
+		expired++
+	}
+
+	if expired > 0 {
+		s.logger.Info("expired withdraw approvals released", zap.Int("count", expired))
+	}
+	return expired, nil
+}
+
+// Run expira solicitações vencidas a cada intervalo até o contexto ser cancelado
+func (s *ApprovalService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultApprovalExpiryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpireDue(ctx); err != nil {
+				s.logger.Error("approval expiry sweep failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// resolve grava o estado final; se outra decisão chegou antes retorna ErrApprovalResolved
+func (s *ApprovalService) resolve(ctx context.Context, req *entity.ApprovalRequest) error {
+	ok, err := s.approvals.Resolve(ctx, req)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return entity.ErrApprovalResolved
+	}
+	return nil
+}
+
+func (s *ApprovalService) transaction(ctx context.Context, req *entity.ApprovalRequest) (*entity.Transaction, error) {
+	tx, err := s.txRepo.FindByID(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, ErrTransactionNotFound
+	}
+	return tx, nil
+}
+
+// release marca o saque retido como falho e devolve o valor debitado na retenção
+func (s *ApprovalService) release(ctx context.Context, req *entity.ApprovalRequest, tx *entity.Transaction, reason, code string) {
+	if err := entity.RestoreTransactionAggregate(tx).Fail(reason, code); err != nil {
+		s.logger.Error("failed to fail held transaction", zap.String("tx_id", tx.ID.String()), zap.Error(err))
+		return
+	}
+	if err := s.txRepo.Update(ctx, tx); err != nil {
+		s.logger.Error("failed to update held transaction", zap.String("tx_id", tx.ID.String()), zap.Error(err))
+	}
+
+	if err := adjustWallet(ctx, s.walletRepo, tx.UserID, tx.Amount.Add(tx.Fee).InexactFloat64(), 0); err != nil {
+		s.logger.Error("failed to release held withdraw funds",
+			zap.String("approval_id", req.ID.String()),
+			zap.String("tx_id", tx.ID.String()),
+			zap.String("amount", tx.Amount.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	s.eventBus.PublishAsync(ctx, events.NewTransactionFailedEvent(tx.UserID, string(tx.Type), tx.Amount, reason, code))
+}
+
+// WithApprovals habilita a retenção de saques acima das políticas de aprovação
+func (s *TransactionService) WithApprovals(approvals *ApprovalService) *TransactionService {
+	s.approvals = approvals
+	if approvals != nil {
+		approvals.fees = s.fees
+	}
+	return s
+}
+
+// Approvals retorna o serviço de aprovações (nil se desabilitado)
+func (s *TransactionService) Approvals() *ApprovalService {
+	return s.approvals
+}
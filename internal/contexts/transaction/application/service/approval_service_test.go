@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memApprovalRepo struct {
+	reqs map[uuid.UUID]*entity.ApprovalRequest
+}
+
+func newMemApprovalRepo() *memApprovalRepo {
+	return &memApprovalRepo{reqs: make(map[uuid.UUID]*entity.ApprovalRequest)}
+}
+
+func (r *memApprovalRepo) Create(ctx context.Context, req *entity.ApprovalRequest) error {
+	cp := *req
+	cp.Trail = append([]entity.ApprovalAction{}, req.Trail...)
+	r.reqs[req.ID] = &cp
+	return nil
+}
+func (r *memApprovalRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.ApprovalRequest, error) {
+	req, ok := r.reqs[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *req
+	cp.Trail = append([]entity.ApprovalAction{}, req.Trail...)
+	return &cp, nil
+}
+func (r *memApprovalRepo) List(ctx context.Context, status entity.ApprovalStatus, limit int) ([]*entity.ApprovalRequest, error) {
+	var out []*entity.ApprovalRequest
+	for _, req := range r.reqs {
+		if status == "" || req.Status == status {
+			cp := *req
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+func (r *memApprovalRepo) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.ApprovalRequest, error) {
+	var out []*entity.ApprovalRequest
+	for _, req := range r.reqs {
+		if req.Status == entity.ApprovalPending && !req.ExpiresAt.After(now) {
+			cp := *req
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+func (r *memApprovalRepo) AppendAction(ctx context.Context, requestID uuid.UUID, action entity.ApprovalAction) error {
+	r.reqs[requestID].Trail = append(r.reqs[requestID].Trail, action)
+	return nil
+}
+func (r *memApprovalRepo) Resolve(ctx context.Context, req *entity.ApprovalRequest) (bool, error) {
+	stored := r.reqs[req.ID]
+	if stored.Status != entity.ApprovalPending {
+		return false, nil
+	}
+	stored.Status, stored.ResolvedAt = req.Status, req.ResolvedAt
+	return true, nil
+}
+
+func setupApprovals(t *testing.T, balance float64, required int) (*TransactionService, *memTxnRepo, *memWalletRepo, *memApprovalRepo, uuid.UUID) {
+	t.Helper()
+	svc, txr, wr, uid := setupService(t, balance)
+	repo := newMemApprovalRepo()
+	policies := []entity.ApprovalPolicy{
+		{ID: "large", Currency: "BRL", MinAmount: decimal.NewFromInt(1000), RequiredApprovals: required, TTL: entity.Duration(time.Hour)},
+	}
+	svc.WithApprovals(NewApprovalService(repo, txr, wr, policies, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()))
+	return svc, txr, wr, repo, uid
+}
+
+func TestApprovalService_HoldAndApprove(t *testing.T) {
+	svc, txr, wr, repo, uid := setupApprovals(t, 5000, 2)
+	ctx := context.Background()
+
+	small, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(100), "", "")
+	if err != nil || small.Status != entity.TransactionStatusCompleted {
+		t.Fatalf("saque abaixo da política deveria seguir direto: %v", err)
+	}
+
+	tx, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(2000), "", "")
+	if err != nil || tx.Status != entity.TransactionStatusAwaitingApproval {
+		t.Fatalf("saque grande deveria aguardar aprovação: %v", err)
+	}
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 2900 {
+		t.Fatalf("valor deveria ficar retido, saldo %v", w.Balance)
+	}
+
+	pending, _ := repo.List(ctx, entity.ApprovalPending, 10)
+	if len(pending) != 1 {
+		t.Fatalf("esperada 1 solicitação pendente, obtidas %d", len(pending))
+	}
+	approvals := svc.Approvals()
+	if _, err := approvals.Approve(ctx, pending[0].ID, uid, nil, ""); !errors.Is(err, entity.ErrSelfApproval) {
+		t.Fatalf("esperado ErrSelfApproval, obtido %v", err)
+	}
+	req, err := approvals.Approve(ctx, pending[0].ID, uuid.New(), nil, "conferido")
+	if err != nil || req.Status != entity.ApprovalPending {
+		t.Fatalf("primeira aprovação deveria manter pendente: %v", err)
+	}
+	if req, err = approvals.Approve(ctx, pending[0].ID, uuid.New(), nil, ""); err != nil || req.Status != entity.ApprovalApproved {
+		t.Fatalf("quórum deveria aprovar: %v", err)
+	}
+
+	stored, _ := txr.FindByID(ctx, tx.ID)
+	if stored.Status != entity.TransactionStatusCompleted {
+		t.Fatalf("saque aprovado deveria ser executado, status %s", stored.Status)
+	}
+	if full, _ := approvals.Get(ctx, req.ID); len(full.Trail) != 3 {
+		t.Fatalf("trilha deveria registrar solicitação e duas aprovações: %+v", full.Trail)
+	}
+}
+
+func TestApprovalService_RejectAndExpireReleaseFunds(t *testing.T) {
+	svc, txr, wr, repo, uid := setupApprovals(t, 5000, 1)
+	ctx := context.Background()
+	approvals := svc.Approvals()
+
+	rejected, _ := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(2000), "", "")
+	expiring, _ := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(1500), "", "")
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 1500 {
+		t.Fatalf("valores deveriam estar retidos, saldo %v", w.Balance)
+	}
+
+	var rejectID uuid.UUID
+	for id, req := range repo.reqs {
+		if req.TransactionID == rejected.ID {
+			rejectID = id
+		}
+	}
+	if _, err := approvals.Reject(ctx, rejectID, uuid.New(), nil, "destino suspeito"); err != nil {
+		t.Fatalf("recusa falhou: %v", err)
+	}
+
+	approvals.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	n, err := approvals.ExpireDue(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("esperada 1 expiração, obtidas %d (%v)", n, err)
+	}
+
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 5000 {
+		t.Fatalf("valores deveriam ser devolvidos, saldo %v", w.Balance)
+	}
+	for _, id := range []uuid.UUID{rejected.ID, expiring.ID} {
+		if tx, _ := txr.FindByID(ctx, id); tx.Status != entity.TransactionStatusFailed {
+			t.Fatalf("saque %s deveria falhar, status %s", id, tx.Status)
+		}
+	}
+	if _, err := approvals.Approve(ctx, rejectID, uuid.New(), nil, ""); !errors.Is(err, entity.ErrApprovalResolved) {
+		t.Fatalf("esperado ErrApprovalResolved, obtido %v", err)
+	}
+}
@@ -0,0 +1,621 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultCreditSweepInterval intervalo da varredura que dispara a apropriação do dia anterior
+	DefaultCreditSweepInterval = time.Hour
+
+	creditDateLayout   = "2006-01-02"
+	creditPeriodLayout = "2006-01"
+	creditHistoryLimit = 50
+)
+
+var (
+	// ErrCreditLineNotFound usuário sem linha de crédito
+	ErrCreditLineNotFound = errors.New("credit line not found")
+	// ErrCreditLineExists usuário já tem linha de crédito aberta
+	ErrCreditLineExists = errors.New("user already has an open credit line")
+	// ErrInvalidCreditDate data fora do formato AAAA-MM-DD
+	ErrInvalidCreditDate = errors.New("invalid credit date")
+)
+
+// CreditAccrualTask apropriação de juros de um dia entregue à fila
+type CreditAccrualTask struct {
+	Date string `json:"date"` // AAAA-MM-DD
+}
+
+// TaskID identificador estável da apropriação do dia, usado para deduplicar tarefas na fila
+func (t CreditAccrualTask) TaskID() string {
+	return "credit:" + t.Date
+}
+
+// CreditAccrualDispatcher entrega a apropriação diária para processamento assíncrono (ex.: asynq)
+type CreditAccrualDispatcher interface {
+	Dispatch(ctx context.Context, task CreditAccrualTask) error
+}
+
+// CreditSummary posição da linha de crédito com o saldo atual da carteira
+type CreditSummary struct {
+	Line        *entity.CreditLine        `json:"line"`
+	Balance     decimal.Decimal           `json:"balance"`
+	Used        decimal.Decimal           `json:"used"`
+	Available   decimal.Decimal           `json:"available"`
+	Outstanding decimal.Decimal           `json:"outstanding"`
+	PastDue     bool                      `json:"past_due"`
+	Blocked     string                    `json:"blocked,omitempty"` // motivo do bloqueio das saídas
+	Charges     []*entity.CreditCharge    `json:"charges"`
+	Repayments  []*entity.CreditRepayment `json:"repayments"`
+}
+
+// CreditService mantém as linhas de crédito das carteiras: o saldo pode ficar negativo até o limite
+// aprovado, os juros sobre o utilizado são apropriados diariamente pelo saldo de fechamento do dia
+// (reconstruído a partir do razão com WithLedger) e faturados com a tarifa de uso no fim do mês, e
+// os depósitos pagam tarifas, juros e principal, nessa ordem. Encargos em atraso ou uso acima do
+// limite bloqueiam as saídas.
+type CreditService struct {
+	repo       repository.CreditRepository
+	txRepo     repository.TransactionRepository
+	walletRepo userRepo.WalletRepository
+	statements repository.StatementRepository
+	dispatcher CreditAccrualDispatcher
+	location   *time.Location
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewCreditService cria o serviço de linhas de crédito
+func NewCreditService(
+	repo repository.CreditRepository,
+	txRepo repository.TransactionRepository,
+	walletRepo userRepo.WalletRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *CreditService {
+	return &CreditService{
+		repo:       repo,
+		txRepo:     txRepo,
+		walletRepo: walletRepo,
+		location:   time.UTC,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// WithDispatcher entrega a apropriação diária à fila
+func (s *CreditService) WithDispatcher(dispatcher CreditAccrualDispatcher) *CreditService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithLedger habilita a reconstrução dos saldos de fechamento dos dias em atraso pelas
+// movimentações da carteira. Sem ela só o último dia tem juros, sobre o saldo atual, e os dias
+// anteriores ficam sem apropriação.
+func (s *CreditService) WithLedger(statements repository.StatementRepository) *CreditService {
+	s.statements = statements
+	return s
+}
+
+// WithLocation define o fuso usado nos limites do dia e do ciclo mensal
+func (s *CreditService) WithLocation(location *time.Location) *CreditService {
+	if location != nil {
+		s.location = location
+	}
+	return s
+}
+
+// ParseDate interpreta AAAA-MM-DD no fuso configurado
+func (s *CreditService) ParseDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(creditDateLayout, date, s.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidCreditDate)
+	}
+	return day, nil
+}
+
+// Yesterday início do dia anterior no fuso configurado, o dia apropriado pela execução diária
+func (s *CreditService) Yesterday() time.Time {
+	return s.startOfDay(s.now()).AddDate(0, 0, -1)
+}
+
+func (s *CreditService) startOfDay(t time.Time) time.Time {
+	t = t.In(s.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+}
+
+// Approve abre a linha de crédito do usuário ou altera os termos da linha aberta
+func (s *CreditService) Approve(ctx context.Context, userID uuid.UUID, terms entity.CreditTerms) (*entity.CreditLine, error) {
+	line, err := s.repo.FindLineByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if line != nil {
+		if err := line.UpdateTerms(terms, s.now()); err != nil {
+			return nil, err
+		}
+		if err := s.repo.UpdateLine(ctx, line); err != nil {
+			return nil, err
+		}
+		return line, nil
+	}
+
+	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+	if line, err = entity.NewCreditLine(userID, terms, s.now()); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateLine(ctx, line)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrCreditLineExists
+	}
+	s.logger.Info("credit line approved",
+		zap.String("line_id", line.ID.String()),
+		zap.String("user_id", userID.String()),
+		zap.String("limit", line.Limit.String()),
+	)
+	return line, nil
+}
+
+// Freeze congela (ou libera) o uso do limite sem afetar o saldo positivo da carteira
+func (s *CreditService) Freeze(ctx context.Context, userID uuid.UUID, frozen bool) (*entity.CreditLine, error) {
+	line, err := s.Line(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := line.SetFrozen(frozen, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateLine(ctx, line); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// Close encerra a linha; exige a carteira sem saldo negativo e os encargos pagos
+func (s *CreditService) Close(ctx context.Context, userID uuid.UUID) (*entity.CreditLine, error) {
+	line, err := s.Line(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.walletBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := line.Close(balance, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateLine(ctx, line); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// Line retorna a linha de crédito aberta do usuário
+func (s *CreditService) Line(ctx context.Context, userID uuid.UUID) (*entity.CreditLine, error) {
+	line, err := s.repo.FindLineByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if line == nil {
+		return nil, ErrCreditLineNotFound
+	}
+	return line, nil
+}
+
+// Summary retorna a posição da linha com os encargos e pagamentos mais recentes
+func (s *CreditService) Summary(ctx context.Context, userID uuid.UUID) (*CreditSummary, error) {
+	line, err := s.Line(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.walletBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	charges, err := s.repo.ListCharges(ctx, line.ID, creditHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	repayments, err := s.repo.ListRepayments(ctx, line.ID, creditHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	summary := &CreditSummary{
+		Line:        line,
+		Balance:     balance,
+		Used:        line.Used(balance),
+		Available:   line.Available(balance),
+		Outstanding: line.Outstanding(),
+		PastDue:     line.PastDue(now),
+		Charges:     charges,
+		Repayments:  repayments,
+	}
+	if _, err := line.OverdraftLimit(balance, now); err != nil {
+		summary.Available = decimal.Zero
+		summary.Blocked = err.Error()
+	}
+	if summary.Charges == nil {
+		summary.Charges = []*entity.CreditCharge{}
+	}
+	if summary.Repayments == nil {
+		summary.Repayments = []*entity.CreditRepayment{}
+	}
+	return summary, nil
+}
+
+// OverdraftLimit saldo negativo que a carteira pode atingir em uma saída; zero sem linha de crédito.
+// Retorna ErrCreditLinePastDue ou ErrCreditLimitExceeded quando as saídas estão bloqueadas.
+func (s *CreditService) OverdraftLimit(ctx context.Context, wallet *userEntity.Wallet) (float64, error) {
+	line, err := s.repo.FindLineByUser(ctx, wallet.UserID)
+	if err != nil || line == nil {
+		return 0, err
+	}
+	limit, err := line.OverdraftLimit(decimal.NewFromFloat(wallet.Balance), s.now())
+	if err != nil {
+		return 0, err
+	}
+	return limit.InexactFloat64(), nil
+}
+
+// RepayFromCredit aloca o valor creditado na carteira do usuário (depósito, transferência recebida,
+// resgate de conta ou pagamento de custódia) à sua linha de crédito. balanceBefore é o saldo da
+// carteira antes do crédito.
+func (s *CreditService) RepayFromCredit(ctx context.Context, userID uuid.UUID, source *entity.Transaction, amount decimal.Decimal, balanceBefore float64) (*entity.CreditRepayment, error) {
+	line, err := s.repo.FindLineByUser(ctx, userID)
+	if err != nil || line == nil {
+		return nil, err
+	}
+	return s.settle(ctx, line, amount, decimal.NewFromFloat(balanceBefore), source)
+}
+
+// settle aloca o valor à linha: a parte de tarifas e juros é debitada da carteira em transações
+// próprias e o restante já quitou o principal ao creditar a carteira
+func (s *CreditService) settle(ctx context.Context, line *entity.CreditLine, amount, balanceBefore decimal.Decimal, source *entity.Transaction) (*entity.CreditRepayment, error) {
+	dueDate := line.DueDate
+	allocation := line.Allocate(amount, balanceBefore)
+	if allocation.Charges().IsZero() && allocation.Principal.IsZero() {
+		return nil, nil
+	}
+	repayment := &entity.CreditRepayment{
+		ID:               uuid.New(),
+		LineID:           line.ID,
+		UserID:           line.UserID,
+		Amount:           allocation.Charges().Add(allocation.Principal),
+		CreditAllocation: allocation,
+		CreatedAt:        s.now(),
+	}
+	if source != nil {
+		repayment.SourceTxID = &source.ID
+	}
+
+	if allocation.Charges().IsPositive() {
+		line.UpdatedAt = s.now()
+		if err := s.repo.UpdateLine(ctx, line); err != nil {
+			return nil, err
+		}
+		if err := s.collect(ctx, line, repayment, source); err != nil {
+			line.Restore(allocation, dueDate)
+			if rbErr := s.repo.UpdateLine(ctx, line); rbErr != nil {
+				s.logger.Error("failed to restore credit line after collection failure",
+					zap.String("line_id", line.ID.String()), zap.Error(rbErr))
+			}
+			return nil, err
+		}
+	}
+	if err := s.repo.SaveRepayment(ctx, repayment); err != nil {
+		return nil, err
+	}
+
+	if s.eventBus != nil {
+		s.eventBus.PublishAsync(ctx, events.NewCreditRepaymentAllocatedEvent(
+			line.ID, line.UserID, repayment.Amount, allocation.Fees, allocation.Interest, allocation.Principal,
+		))
+	}
+	s.logger.Info("credit repayment allocated",
+		zap.String("line_id", line.ID.String()),
+		zap.String("fees", allocation.Fees.String()),
+		zap.String("interest", allocation.Interest.String()),
+		zap.String("principal", allocation.Principal.String()),
+	)
+	return repayment, nil
+}
+
+// collect debita da carteira as tarifas e os juros pagos
+func (s *CreditService) collect(ctx context.Context, line *entity.CreditLine, repayment *entity.CreditRepayment, source *entity.Transaction) error {
+	wallet, err := s.walletRepo.FindByUserID(ctx, line.UserID)
+	if err != nil {
+		return err
+	}
+	if wallet == nil {
+		return ErrWalletNotFound
+	}
+
+	var txs []*entity.Transaction
+	newTx := func(typ entity.TransactionType, amount decimal.Decimal) (*entity.Transaction, error) {
+		tx := entity.NewTransaction(line.UserID, typ, amount)
+		if source != nil {
+			tx.ParentID = &source.ID
+		}
+		tx.FromAddress = wallet.Address
+		tx.ToAddress = creditAddress(line)
+		if err := s.txRepo.Create(ctx, tx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+		return tx, nil
+	}
+	if repayment.Fees.IsPositive() {
+		tx, err := newTx(entity.TransactionTypeCreditFee, repayment.Fees)
+		if err != nil {
+			return err
+		}
+		repayment.FeeTxID = &tx.ID
+	}
+	if repayment.Interest.IsPositive() {
+		tx, err := newTx(entity.TransactionTypeCreditInterest, repayment.Interest)
+		if err != nil {
+			return err
+		}
+		repayment.InterestTxID = &tx.ID
+	}
+
+	// Os encargos saem do valor já alocado, então o débito pode usar o limite da linha
+	if err := adjustWallet(ctx, s.walletRepo, line.UserID, -repayment.Charges().InexactFloat64(), line.Limit.InexactFloat64()); err != nil {
+		for _, tx := range txs {
+			tx.Fail("failed to debit wallet")
+			_ = s.txRepo.Update(ctx, tx)
+		}
+		return err
+	}
+	for _, tx := range txs {
+		tx.Complete("credit-" + tx.ID.String())
+		_ = s.txRepo.Update(ctx, tx)
+	}
+	return nil
+}
+
+// creditAddress endereço interno que representa a linha de crédito nas transações de encargos
+func creditAddress(line *entity.CreditLine) string {
+	return "credit:" + line.ID.String()
+}
+
+// AccrueDay apropria os juros de todas as linhas abertas até o dia, recuperando dias perdidos,
+// fatura os ciclos encerrados, aplica a multa de atraso e cobra os encargos do saldo positivo
+func (s *CreditService) AccrueDay(ctx context.Context, day time.Time) (*entity.CreditAccrualRun, error) {
+	day = s.startOfDay(day)
+	run := &entity.CreditAccrualRun{RunDate: day, Accrued: decimal.Zero, StartedAt: s.now()}
+
+	lines, err := s.repo.ListOpenLines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		run.Lines++
+		if err := s.accrueLine(ctx, line, day, run); err != nil {
+			run.Failures++
+			s.logger.Error("credit accrual failed",
+				zap.String("line_id", line.ID.String()),
+				zap.String("date", day.Format(creditDateLayout)),
+				zap.Error(err),
+			)
+		}
+	}
+	run.FinishedAt = s.now()
+
+	if err := s.repo.SaveRun(ctx, run); err != nil {
+		return nil, err
+	}
+	s.logger.Info("credit accrual finished",
+		zap.String("date", day.Format(creditDateLayout)),
+		zap.Int("lines", run.Lines),
+		zap.String("accrued", run.Accrued.String()),
+		zap.Int("charges", run.Charges),
+		zap.Int("failures", run.Failures),
+	)
+	return run, nil
+}
+
+func (s *CreditService) accrueLine(ctx context.Context, line *entity.CreditLine, through time.Time, run *entity.CreditAccrualRun) error {
+	wallet, err := s.walletRepo.FindByUserID(ctx, line.UserID)
+	if err != nil {
+		return err
+	}
+	if wallet == nil {
+		return ErrWalletNotFound
+	}
+	balance := decimal.NewFromFloat(wallet.Balance)
+	now := s.now()
+	start := s.startOfDay(line.CreatedAt)
+	if line.LastAccrualDate != nil {
+		start = s.startOfDay(*line.LastAccrualDate).AddDate(0, 0, 1)
+	}
+	days := dayRange(start, through)
+	balances, skip, err := s.dayBalances(ctx, line, wallet, days)
+	if err != nil {
+		return err
+	}
+
+	var charges []*entity.CreditCharge
+	accrued := decimal.Zero
+	for i, day := range days {
+		if i >= skip {
+			accrued = accrued.Add(line.Accrue(balances[i], day))
+		} else {
+			d := day
+			line.LastAccrualDate = &d
+		}
+		if next := day.AddDate(0, 0, 1); next.Day() == 1 {
+			charges = append(charges, line.Bill(day.Format(creditPeriodLayout), next, now)...)
+		}
+	}
+	wasPastDue := line.LateFeeCharged
+	if c := line.ChargeLateFee(through.Format(creditPeriodLayout), now); c != nil {
+		charges = append(charges, c)
+	}
+	line.UpdatedAt = now
+	if err := s.repo.UpdateLine(ctx, line); err != nil {
+		return err
+	}
+	if len(charges) > 0 {
+		if err := s.repo.SaveCharges(ctx, charges); err != nil {
+			return err
+		}
+	}
+	run.Accrued = run.Accrued.Add(accrued)
+	run.Charges += len(charges)
+
+	if line.PastDue(now) && !wasPastDue && s.eventBus != nil {
+		s.eventBus.PublishAsync(ctx, events.NewCreditLinePastDueEvent(line.ID, line.UserID, line.Outstanding(), *line.DueDate))
+	}
+
+	// encargos em aberto são cobrados do saldo positivo da carteira
+	if balance.IsPositive() && line.Outstanding().IsPositive() {
+		repayment, err := s.settle(ctx, line, balance, balance, nil)
+		if err != nil {
+			return err
+		}
+		if repayment != nil {
+			run.Repayments++
+		}
+	}
+	return nil
+}
+
+// dayBalances saldo de fechamento de cada dia pelo razão. Sem razão só o último dia tem saldo (o
+// atual) e skip indica quantos dias em atraso ficam sem juros.
+func (s *CreditService) dayBalances(ctx context.Context, line *entity.CreditLine, wallet *userEntity.Wallet, days []time.Time) ([]decimal.Decimal, int, error) {
+	if len(days) == 0 {
+		return nil, 0, nil
+	}
+	if s.statements != nil {
+		balances, err := closingBalances(ctx, s.statements, line.UserID, wallet, days, s.now())
+		return balances, 0, err
+	}
+	skip := len(days) - 1
+	if skip > 0 {
+		s.logger.Warn("credit catch-up without ledger: past days left without interest",
+			zap.String("line_id", line.ID.String()),
+			zap.String("from", days[0].Format(creditDateLayout)),
+			zap.String("to", days[skip-1].Format(creditDateLayout)),
+		)
+	}
+	balances := make([]decimal.Decimal, len(days))
+	balances[skip] = decimal.NewFromFloat(wallet.Balance)
+	return balances, skip, nil
+}
+
+func (s *CreditService) walletBalance(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error) {
+	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if wallet == nil {
+		return decimal.Zero, ErrWalletNotFound
+	}
+	return decimal.NewFromFloat(wallet.Balance), nil
+}
+
+// Execute apropria o dia da tarefa
+func (s *CreditService) Execute(ctx context.Context, task CreditAccrualTask) error {
+	day, err := s.ParseDate(task.Date)
+	if err != nil {
+		return err
+	}
+	_, err = s.AccrueDay(ctx, day)
+	return err
+}
+
+// DispatchDaily enfileira (ou executa, sem dispatcher) a apropriação do dia anterior se ela ainda
+// não foi feita; retorna se algo foi disparado
+func (s *CreditService) DispatchDaily(ctx context.Context) (bool, error) {
+	day := s.Yesterday()
+	existing, err := s.repo.FindRun(ctx, day)
+	if err != nil || existing != nil {
+		return false, err
+	}
+	task := CreditAccrualTask{Date: day.Format(creditDateLayout)}
+	if s.dispatcher == nil {
+		return true, s.Execute(ctx, task)
+	}
+	return true, s.dispatcher.Dispatch(ctx, task)
+}
+
+// Run dispara a apropriação diária a cada intervalo até o contexto ser cancelado
+func (s *CreditService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultCreditSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchDaily(ctx); err != nil {
+				s.logger.Error("daily credit accrual failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// overdraftLimit limite de cheque especial disponível para as saídas da carteira; zero sem linhas
+// de crédito habilitadas
+func (s *TransactionService) overdraftLimit(ctx context.Context, wallet *userEntity.Wallet) (float64, error) {
+	if s.credit == nil {
+		return 0, nil
+	}
+	return s.credit.OverdraftLimit(ctx, wallet)
+}
+
+// repayCredit aloca à linha de crédito de userID toda entrada concluída na carteira; falhas não
+// desfazem a entrada e os encargos seguem em aberto para a cobrança diária
+func (s *TransactionService) repayCredit(ctx context.Context, userID uuid.UUID, source *entity.Transaction, amount decimal.Decimal, balanceBefore float64) {
+	if s.credit == nil {
+		return
+	}
+	if _, err := s.credit.RepayFromCredit(ctx, userID, source, amount, balanceBefore); err != nil {
+		s.logger.Error("failed to allocate wallet credit to credit line",
+			zap.String("tx_id", source.ID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// WithCredit habilita as linhas de crédito (cheque especial) das carteiras
+func (s *TransactionService) WithCredit(credit *CreditService) *TransactionService {
+	s.credit = credit
+	return s
+}
+
+// Credit retorna o serviço de linhas de crédito (nil se desabilitado)
+func (s *TransactionService) Credit() *CreditService {
+	return s.credit
+}
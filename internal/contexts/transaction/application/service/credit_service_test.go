@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memCreditRepo struct {
+	mu         sync.Mutex
+	lines      map[uuid.UUID]*entity.CreditLine
+	charges    []*entity.CreditCharge
+	repayments []*entity.CreditRepayment
+	runs       map[time.Time]*entity.CreditAccrualRun
+}
+
+func newMemCreditRepo() *memCreditRepo {
+	return &memCreditRepo{
+		lines: make(map[uuid.UUID]*entity.CreditLine),
+		runs:  make(map[time.Time]*entity.CreditAccrualRun),
+	}
+}
+
+func copyCreditLine(l *entity.CreditLine) *entity.CreditLine {
+	cp := *l
+	return &cp
+}
+
+func (m *memCreditRepo) CreateLine(ctx context.Context, line *entity.CreditLine) (bool, error) {
+	for _, l := range m.lines {
+		if l.UserID == line.UserID && l.Status != entity.CreditLineClosed {
+			return false, nil
+		}
+	}
+	m.lines[line.ID] = copyCreditLine(line)
+	return true, nil
+}
+
+func (m *memCreditRepo) UpdateLine(ctx context.Context, line *entity.CreditLine) error {
+	stored := m.lines[line.ID]
+	if stored == nil || stored.Version != line.Version {
+		return entity.ErrCreditLineConflict
+	}
+	line.Version++
+	m.lines[line.ID] = copyCreditLine(line)
+	return nil
+}
+
+func (m *memCreditRepo) FindLineByUser(ctx context.Context, userID uuid.UUID) (*entity.CreditLine, error) {
+	for _, l := range m.lines {
+		if l.UserID == userID && l.Status != entity.CreditLineClosed {
+			return copyCreditLine(l), nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memCreditRepo) ListOpenLines(ctx context.Context) ([]*entity.CreditLine, error) {
+	var out []*entity.CreditLine
+	for _, l := range m.lines {
+		if l.Status != entity.CreditLineClosed {
+			out = append(out, copyCreditLine(l))
+		}
+	}
+	return out, nil
+}
+
+func (m *memCreditRepo) SaveCharges(ctx context.Context, charges []*entity.CreditCharge) error {
+	m.charges = append(m.charges, charges...)
+	return nil
+}
+
+func (m *memCreditRepo) ListCharges(ctx context.Context, lineID uuid.UUID, limit int) ([]*entity.CreditCharge, error) {
+	var out []*entity.CreditCharge
+	for _, c := range m.charges {
+		if c.LineID == lineID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (m *memCreditRepo) SaveRepayment(ctx context.Context, repayment *entity.CreditRepayment) error {
+	m.repayments = append(m.repayments, repayment)
+	return nil
+}
+
+func (m *memCreditRepo) ListRepayments(ctx context.Context, lineID uuid.UUID, limit int) ([]*entity.CreditRepayment, error) {
+	var out []*entity.CreditRepayment
+	for _, p := range m.repayments {
+		if p.LineID == lineID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (m *memCreditRepo) SaveRun(ctx context.Context, run *entity.CreditAccrualRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[run.RunDate] = run
+	return nil
+}
+
+func (m *memCreditRepo) FindRun(ctx context.Context, runDate time.Time) (*entity.CreditAccrualRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.runs[runDate], nil
+}
+
+// setupCredit aprova limite de 1000 a 36,5% a.a. em ACT/365 (0,1% ao dia), tarifa de uso 10, multa 20
+// e 5 dias de carência
+func setupCredit(t *testing.T, balance float64) (*TransactionService, *CreditService, *memCreditRepo, *memTxnRepo, *memWalletRepo, *time.Time, uuid.UUID) {
+	t.Helper()
+	svc, txr, wr, uid := setupService(t, balance)
+	repo := newMemCreditRepo()
+	now := time.Date(2025, 1, 29, 10, 0, 0, 0, time.UTC)
+	credit := NewCreditService(repo, txr, wr, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()).
+		WithLedger(newMemStatementRepo(txr))
+	credit.now = func() time.Time { return now }
+	svc.WithCredit(credit)
+
+	_, err := credit.Approve(context.Background(), uid, entity.CreditTerms{
+		Limit:      decimal.NewFromInt(1000),
+		AnnualRate: decimal.RequireFromString("0.365"),
+		DayCount:   entity.DayCountACT365,
+		UsageFee:   decimal.NewFromInt(10),
+		LateFee:    decimal.NewFromInt(20),
+		GraceDays:  5,
+	})
+	if err != nil {
+		t.Fatalf("aprovar linha: %v", err)
+	}
+	return svc, credit, repo, txr, wr, &now, uid
+}
+
+func TestCreditService_WithdrawWithinOverdraft(t *testing.T) {
+	svc, credit, _, _, wr, _, uid := setupCredit(t, 100)
+	ctx := context.Background()
+
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque dentro do limite falhou: %v", err)
+	}
+	if got := balanceOf(t, wr, uid); got != -500 {
+		t.Fatalf("esperado saldo -500, obtido %v", got)
+	}
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(501)); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance além do limite, obtido %v", err)
+	}
+
+	summary, err := credit.Summary(ctx, uid)
+	if err != nil {
+		t.Fatalf("posição: %v", err)
+	}
+	if !summary.Used.Equal(decimal.NewFromInt(500)) || !summary.Available.Equal(decimal.NewFromInt(500)) || summary.Blocked != "" {
+		t.Fatalf("posição inesperada: usado %s, disponível %s, bloqueio %q", summary.Used, summary.Available, summary.Blocked)
+	}
+
+	// congelada: não libera novo uso
+	if _, err := credit.Freeze(ctx, uid, true); err != nil {
+		t.Fatalf("congelar: %v", err)
+	}
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(1)); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance com a linha congelada, obtido %v", err)
+	}
+	if _, err := credit.Close(ctx, uid); !errors.Is(err, entity.ErrCreditLineInUse) {
+		t.Fatalf("esperado ErrCreditLineInUse com saldo negativo, obtido %v", err)
+	}
+}
+
+func TestCreditService_MonthEndBillingAndDepositAllocation(t *testing.T) {
+	svc, credit, repo, txr, wr, now, uid := setupCredit(t, 100)
+	ctx := context.Background()
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+
+	// 29, 30 e 31/01 sobre 500 utilizados: 0,50 por dia; o ciclo fecha em 31/01 com a tarifa de uso
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	run, err := credit.AccrueDay(ctx, credit.Yesterday())
+	if err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+	if !run.Accrued.Equal(decimal.RequireFromString("1.5")) || run.Charges != 2 || run.Failures != 0 {
+		t.Fatalf("execução inesperada: %+v", run)
+	}
+	line, _ := credit.Line(ctx, uid)
+	if !line.InterestDue.Equal(decimal.RequireFromString("1.5")) || !line.FeesDue.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("encargos inesperados: juros %s, tarifas %s", line.InterestDue, line.FeesDue)
+	}
+	if line.DueDate == nil || !line.DueDate.Equal(time.Date(2025, 2, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("vencimento inesperado: %v", line.DueDate)
+	}
+
+	// depósito paga tarifa, juros e principal, nessa ordem
+	if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(600), ""); err != nil {
+		t.Fatalf("depósito: %v", err)
+	}
+	if got := balanceOf(t, wr, uid); got != 88.5 {
+		t.Fatalf("esperado saldo 88.5, obtido %v", got)
+	}
+	deposit := txnsOfType(txr, entity.TransactionTypeDeposit)[0]
+	fees := txnsOfType(txr, entity.TransactionTypeCreditFee)
+	interest := txnsOfType(txr, entity.TransactionTypeCreditInterest)
+	if len(fees) != 1 || !fees[0].Amount.Equal(decimal.NewFromInt(10)) || fees[0].ParentID == nil || *fees[0].ParentID != deposit.ID {
+		t.Fatalf("transação de tarifa inesperada: %+v", fees)
+	}
+	if len(interest) != 1 || !interest[0].Amount.Equal(decimal.RequireFromString("1.5")) || interest[0].Status != entity.TransactionStatusCompleted {
+		t.Fatalf("transação de juros inesperada: %+v", interest)
+	}
+	if len(repo.repayments) != 1 || !repo.repayments[0].Principal.Equal(decimal.NewFromInt(500)) {
+		t.Fatalf("pagamento inesperado: %+v", *repo.repayments[0])
+	}
+	line, _ = credit.Line(ctx, uid)
+	if !line.Outstanding().IsZero() || line.DueDate != nil {
+		t.Fatalf("encargos deveriam zerar, obtido %s venc. %v", line.Outstanding(), line.DueDate)
+	}
+	if _, err := credit.Close(ctx, uid); err != nil {
+		t.Fatalf("encerrar linha quitada: %v", err)
+	}
+}
+
+func TestCreditService_IncomingTransferRepaysLine(t *testing.T) {
+	svc, credit, repo, txr, wr, now, uid := setupCredit(t, 100)
+	ctx := context.Background()
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	if _, err := credit.AccrueDay(ctx, credit.Yesterday()); err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+
+	payer := uuid.New()
+	_ = svc.userRepo.Create(ctx, &userEntity.User{ID: payer, Email: "payer@t.com", Password: "hash"})
+	_ = wr.Create(ctx, &userEntity.Wallet{UserID: payer, Address: "PAYER", Balance: 1000})
+	transfer, err := svc.ProcessTransfer(ctx, payer, uid, decimal.NewFromInt(600))
+	if err != nil {
+		t.Fatalf("transferência: %v", err)
+	}
+	if got := balanceOf(t, wr, uid); got != 88.5 {
+		t.Fatalf("esperado saldo 88.5 após quitar encargos, obtido %v", got)
+	}
+	fees := txnsOfType(txr, entity.TransactionTypeCreditFee)
+	if len(fees) != 1 || fees[0].UserID != uid || fees[0].ParentID == nil || *fees[0].ParentID != transfer.ID {
+		t.Fatalf("tarifa deveria ser paga pela transferência recebida: %+v", fees)
+	}
+	if len(repo.repayments) != 1 || repo.repayments[0].UserID != uid || !repo.repayments[0].Principal.Equal(decimal.NewFromInt(500)) {
+		t.Fatalf("pagamento inesperado: %+v", *repo.repayments[0])
+	}
+	if got := balanceOf(t, wr, payer); got != 400 {
+		t.Fatalf("pagador não deveria ser afetado pela linha do destinatário, saldo %v", got)
+	}
+}
+
+func TestCreditService_CatchUpUsesDailyBalances(t *testing.T) {
+	svc, credit, _, txr, _, now, uid := setupCredit(t, 100)
+	ctx := context.Background()
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+	// saque em 30/01: o dia 29 fecha com saldo positivo e não tem juros
+	txnsOfType(txr, entity.TransactionTypeWithdraw)[0].CreatedAt = time.Date(2025, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	run, err := credit.AccrueDay(ctx, credit.Yesterday())
+	if err != nil || !run.Accrued.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("esperado 1,00 (30 e 31/01 sobre 500), obtido %+v err=%v", run, err)
+	}
+}
+
+func TestCreditService_CatchUpWithoutLedgerSkipsPastDays(t *testing.T) {
+	svc, credit, _, _, _, now, uid := setupCredit(t, 100)
+	credit.WithLedger(nil)
+	ctx := context.Background()
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+
+	// sem o razão o saldo atual só é aplicado ao último dia
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	run, err := credit.AccrueDay(ctx, credit.Yesterday())
+	if err != nil || !run.Accrued.Equal(decimal.RequireFromString("0.5")) {
+		t.Fatalf("esperado 0,50 só de 31/01, obtido %+v err=%v", run, err)
+	}
+	line, _ := credit.Line(ctx, uid)
+	if line.LastAccrualDate == nil || !line.LastAccrualDate.Equal(time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("os dias em atraso deveriam ficar marcados como apropriados, obtido %v", line.LastAccrualDate)
+	}
+}
+
+func TestCreditService_PastDueBlocksOutflows(t *testing.T) {
+	svc, credit, repo, _, wr, now, uid := setupCredit(t, 100)
+	ctx := context.Background()
+	dest := uuid.New()
+	_ = svc.userRepo.Create(ctx, &userEntity.User{ID: dest, Email: "d@t.com", Password: "hash"})
+	_ = wr.Create(ctx, &userEntity.Wallet{UserID: dest, Address: "DEST"})
+
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	if _, err := credit.AccrueDay(ctx, credit.Yesterday()); err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+
+	// vencido em 06/02: saídas bloqueadas e multa lançada uma única vez
+	*now = time.Date(2025, 2, 7, 3, 0, 0, 0, time.UTC)
+	if _, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(1)); !errors.Is(err, entity.ErrCreditLinePastDue) {
+		t.Fatalf("esperado ErrCreditLinePastDue, obtido %v", err)
+	}
+	if _, err := credit.AccrueDay(ctx, credit.Yesterday()); err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+	if _, err := credit.AccrueDay(ctx, credit.Yesterday()); err != nil {
+		t.Fatalf("reexecução: %v", err)
+	}
+	late := 0
+	for _, c := range repo.charges {
+		if c.Kind == entity.CreditChargeLateFee {
+			late++
+		}
+	}
+	if late != 1 {
+		t.Fatalf("esperada 1 multa, obtidas %d", late)
+	}
+
+	// depósito paga tarifa + multa (30) e juros (1,5) e amortiza o restante
+	if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(100), ""); err != nil {
+		t.Fatalf("depósito: %v", err)
+	}
+	if got := balanceOf(t, wr, uid); got != -431.5 {
+		t.Fatalf("esperado saldo -431.5, obtido %v", got)
+	}
+	if _, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(10)); err != nil {
+		t.Fatalf("transferência após regularizar falhou: %v", err)
+	}
+}
+
+func TestCreditService_DailyRunCollectsFromPositiveBalance(t *testing.T) {
+	svc, credit, _, txr, wr, now, uid := setupCredit(t, 100)
+	ctx := context.Background()
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(600)); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	if _, err := credit.AccrueDay(ctx, credit.Yesterday()); err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+
+	// crédito que não passou pelo depósito (ex.: estorno) deixa o saldo positivo com encargos em aberto
+	_ = wr.UpdateBalance(ctx, uid, 50)
+	*now = time.Date(2025, 2, 2, 3, 0, 0, 0, time.UTC)
+	dispatched, err := credit.DispatchDaily(ctx)
+	if err != nil || !dispatched {
+		t.Fatalf("esperada execução do dia, obtido %v err=%v", dispatched, err)
+	}
+	if dispatched, _ = credit.DispatchDaily(ctx); dispatched {
+		t.Fatalf("dia já apropriado não deveria ser disparado de novo")
+	}
+	if got := balanceOf(t, wr, uid); got != 38.5 {
+		t.Fatalf("esperado saldo 38.5, obtido %v", got)
+	}
+	if len(txnsOfType(txr, entity.TransactionTypeCreditFee)) != 1 || len(txnsOfType(txr, entity.TransactionTypeCreditInterest)) != 1 {
+		t.Fatalf("esperadas transações de tarifa e juros")
+	}
+	line, _ := credit.Line(ctx, uid)
+	if !line.Outstanding().IsZero() {
+		t.Fatalf("encargos deveriam zerar, obtido %s", line.Outstanding())
+	}
+}
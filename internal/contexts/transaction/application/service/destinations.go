@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DestinationPolicy autoriza o destino de um saque (catálogo de endereços, allowlist e cool-down).
+// Destino vazio indica saque sem endereço informado.
+type DestinationPolicy interface {
+	AuthorizeDestination(ctx context.Context, userID uuid.UUID, chain, address string) error
+}
+
+// WithDestinationPolicy habilita a verificação do destino antes de cada saque
+func (s *TransactionService) WithDestinationPolicy(policy DestinationPolicy) *TransactionService {
+	s.destinations = policy
+	return s
+}
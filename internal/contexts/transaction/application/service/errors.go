@@ -10,4 +10,12 @@ var (
 	ErrWalletNotFound      = errors.New("wallet not found")
 	ErrTransactionFailed   = errors.New("transaction failed")
 	ErrCircuitBreakerOpen  = errors.New("circuit breaker open - service temporarily unavailable")
+	ErrLimitExceeded       = errors.New("transaction limit exceeded")
+	ErrSameUserTransfer    = errors.New("cannot transfer to the same user")
+	ErrApprovalNotFound    = errors.New("approval request not found")
+	ErrFeeExceedsAmount    = errors.New("fee exceeds transaction amount")
+	// ErrNetworkFeeUnavailable a regra repassa a taxa de rede mas não há estimador configurado
+	ErrNetworkFeeUnavailable = errors.New("network fee estimation unavailable")
+	// ErrNetworkFeeUnpriced não há cotação para converter o ativo da rede na moeda da operação
+	ErrNetworkFeeUnpriced = errors.New("no rate to convert network fee")
 )
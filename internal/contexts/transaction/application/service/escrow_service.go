@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultEscrowSweepInterval intervalo padrão da varredura de custódias vencidas
+	DefaultEscrowSweepInterval = time.Minute
+	// escrowPayoutRetryGrace idade mínima do encerramento para retomar os pagamentos, evitando
+	// concorrer com o pagamento feito junto com a decisão
+	escrowPayoutRetryGrace = time.Minute
+	escrowPayoutRetryBatch = 100
+)
+
+// ErrEscrowNotFound custódia inexistente ou de que o usuário não participa
+var ErrEscrowNotFound = errors.New("escrow not found")
+
+// Origens do encerramento de uma custódia, informadas no evento escrow.settled
+const (
+	EscrowTriggerParty    = "party"
+	EscrowTriggerArbiter  = "arbiter"
+	EscrowTriggerOperator = "operator"
+	EscrowTriggerTimeout  = "timeout"
+)
+
+// EscrowTimeout vencimento de prazo entregue à fila; o prazo identifica a tarefa
+type EscrowTimeout struct {
+	EscrowID uuid.UUID `json:"escrow_id"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// TaskID identificador estável do vencimento, usado para deduplicar tarefas na fila
+func (t EscrowTimeout) TaskID() string {
+	return fmt.Sprintf("escrow:%s:%d", t.EscrowID, t.Deadline.Unix())
+}
+
+// EscrowTimeoutScheduler agenda o processamento do vencimento para o prazo (ex.: asynq com ProcessAt)
+type EscrowTimeoutScheduler interface {
+	ScheduleTimeout(ctx context.Context, timeout EscrowTimeout) error
+}
+
+// EscrowService gerencia custódias entre comprador e vendedor. O depósito sai da carteira do comprador
+// e o encerramento gera transações de pagamento ao vendedor e/ou devolução ao comprador.
+type EscrowService struct {
+	escrows    repository.EscrowRepository
+	txRepo     repository.TransactionRepository
+	walletRepo userRepo.WalletRepository
+	txns       *TransactionService
+	timeouts   EscrowTimeoutScheduler
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewEscrowService cria o serviço de custódias; sem agendador os prazos são tratados só pela varredura
+func NewEscrowService(
+	escrows repository.EscrowRepository,
+	txRepo repository.TransactionRepository,
+	walletRepo userRepo.WalletRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *EscrowService {
+	return &EscrowService{
+		escrows:    escrows,
+		txRepo:     txRepo,
+		walletRepo: walletRepo,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// WithTimeoutScheduler agenda o vencimento de cada custódia na fila ao criá-la
+func (s *EscrowService) WithTimeoutScheduler(timeouts EscrowTimeoutScheduler) *EscrowService {
+	s.timeouts = timeouts
+	return s
+}
+
+// Create abre a custódia do comprador aguardando o depósito
+func (s *EscrowService) Create(ctx context.Context, spec entity.EscrowSpec) (*entity.Escrow, error) {
+	escrow, err := entity.NewEscrow(spec, s.now())
+	if err != nil {
+		return nil, err
+	}
+	seller, err := s.walletRepo.FindByUserID(ctx, escrow.SellerID)
+	if err != nil {
+		return nil, err
+	}
+	if seller == nil {
+		return nil, ErrWalletNotFound
+	}
+	if err := s.escrows.Create(ctx, escrow); err != nil {
+		return nil, err
+	}
+	if s.timeouts != nil {
+		// A varredura cobre o prazo se o agendamento falhar
+		if err := s.timeouts.ScheduleTimeout(ctx, EscrowTimeout{EscrowID: escrow.ID, Deadline: escrow.Deadline}); err != nil {
+			s.logger.Warn("failed to schedule escrow timeout", zap.String("escrow_id", escrow.ID.String()), zap.Error(err))
+		}
+	}
+	s.logger.Info("escrow created",
+		zap.String("escrow_id", escrow.ID.String()),
+		zap.String("buyer_id", escrow.BuyerID.String()),
+		zap.String("seller_id", escrow.SellerID.String()),
+		zap.String("amount", escrow.Amount.String()),
+		zap.Time("deadline", escrow.Deadline),
+	)
+	return escrow, nil
+}
+
+// Get retorna a custódia de que o usuário participa (comprador, vendedor ou árbitro)
+func (s *EscrowService) Get(ctx context.Context, userID, id uuid.UUID) (*entity.Escrow, error) {
+	escrow, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !escrow.IsParty(userID) {
+		return nil, ErrEscrowNotFound
+	}
+	return escrow, nil
+}
+
+// List lista as custódias de que o usuário participa
+func (s *EscrowService) List(ctx context.Context, userID uuid.UUID) ([]*entity.Escrow, error) {
+	return s.escrows.ListByParty(ctx, userID)
+}
+
+// ListByStatus lista custódias por estado (operadores; ex.: disputas abertas)
+func (s *EscrowService) ListByStatus(ctx context.Context, status entity.EscrowStatus, limit int) ([]*entity.Escrow, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.escrows.ListByStatus(ctx, status, limit)
+}
+
+// Fund debita o valor da carteira do comprador e o mantém em custódia
+func (s *EscrowService) Fund(ctx context.Context, buyerID, id uuid.UUID) (*entity.Escrow, error) {
+	escrow, err := s.Get(ctx, buyerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.BuyerID != buyerID {
+		return nil, entity.ErrEscrowNotParty
+	}
+	if escrow.Status != entity.EscrowAwaitingFunding {
+		return nil, entity.ErrEscrowInvalidState
+	}
+	if s.txns != nil {
+		if err := s.txns.checkOutflowLimits(ctx, buyerID, escrow.Amount); err != nil {
+			return nil, err
+		}
+	}
+	wallet, err := s.walletRepo.FindByUserID(ctx, buyerID)
+	if err != nil || wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+	var overdraft float64
+	if s.txns != nil {
+		if overdraft, err = s.txns.overdraftLimit(ctx, wallet); err != nil {
+			return nil, err
+		}
+	}
+	if !wallet.HasAvailableFunds(escrow.Amount.InexactFloat64(), overdraft) {
+		return nil, ErrInsufficientBalance
+	}
+
+	tx := entity.NewTransaction(buyerID, entity.TransactionTypeEscrowFund, escrow.Amount)
+	tx.FromAddress = wallet.Address
+	tx.ToAddress = escrowAddress(escrow)
+	if err := escrow.Fund(tx.ID, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		s.logger.Error("failed to create escrow funding transaction", zap.Error(err))
+		return nil, err
+	}
+	if err := adjustWallet(ctx, s.walletRepo, buyerID, -escrow.Amount.InexactFloat64(), overdraft); err != nil {
+		tx.Fail("failed to debit buyer wallet")
+		_ = s.txRepo.Update(ctx, tx)
+		return nil, err
+	}
+	if err := s.escrows.Update(ctx, escrow); err != nil {
+		// Cancelada ou depositada por outra requisição: desfaz o débito
+		_ = adjustWallet(ctx, s.walletRepo, buyerID, escrow.Amount.InexactFloat64(), 0)
+		tx.Fail("escrow changed while funding")
+		_ = s.txRepo.Update(ctx, tx)
+		return nil, err
+	}
+	tx.Complete("escrow-fund-" + tx.ID.String())
+	_ = s.txRepo.Update(ctx, tx)
+
+	s.eventBus.PublishAsync(ctx, events.NewEscrowFundedEvent(escrow.ID, escrow.BuyerID, escrow.SellerID, tx.ID, escrow.Amount, escrow.Deadline))
+	s.logger.Info("escrow funded",
+		zap.String("escrow_id", escrow.ID.String()),
+		zap.String("tx_id", tx.ID.String()),
+	)
+	return escrow, nil
+}
+
+// ConfirmCondition o comprador confirma uma condição; confirmada a última, o valor é liberado ao vendedor
+func (s *EscrowService) ConfirmCondition(ctx context.Context, buyerID, id uuid.UUID, idx int) (*entity.Escrow, error) {
+	return s.transition(ctx, buyerID, id, EscrowTriggerParty, func(e *entity.Escrow, now time.Time) error {
+		if err := e.ConfirmCondition(buyerID, idx, now); err != nil {
+			return err
+		}
+		if e.AllConditionsMet() {
+			return e.Release(buyerID, now)
+		}
+		return nil
+	})
+}
+
+// Release o comprador libera o valor ao vendedor
+func (s *EscrowService) Release(ctx context.Context, buyerID, id uuid.UUID) (*entity.Escrow, error) {
+	return s.transition(ctx, buyerID, id, EscrowTriggerParty, func(e *entity.Escrow, now time.Time) error {
+		return e.Release(buyerID, now)
+	})
+}
+
+// Refund o vendedor devolve o valor ao comprador
+func (s *EscrowService) Refund(ctx context.Context, sellerID, id uuid.UUID) (*entity.Escrow, error) {
+	return s.transition(ctx, sellerID, id, EscrowTriggerParty, func(e *entity.Escrow, now time.Time) error {
+		return e.Refund(sellerID, now)
+	})
+}
+
+// Cancel encerra a custódia ainda não depositada
+func (s *EscrowService) Cancel(ctx context.Context, userID, id uuid.UUID) (*entity.Escrow, error) {
+	return s.transition(ctx, userID, id, EscrowTriggerParty, func(e *entity.Escrow, now time.Time) error {
+		return e.Cancel(userID, now)
+	})
+}
+
+// Dispute comprador ou vendedor contesta a custódia depositada
+func (s *EscrowService) Dispute(ctx context.Context, userID, id uuid.UUID, reason string) (*entity.Escrow, error) {
+	return s.transition(ctx, userID, id, EscrowTriggerParty, func(e *entity.Escrow, now time.Time) error {
+		return e.Dispute(userID, reason, now)
+	})
+}
+
+// Resolve o árbitro da custódia decide a disputa
+func (s *EscrowService) Resolve(ctx context.Context, arbiterID, id uuid.UUID, sellerAmount decimal.Decimal, note string) (*entity.Escrow, error) {
+	return s.transition(ctx, arbiterID, id, EscrowTriggerArbiter, func(e *entity.Escrow, now time.Time) error {
+		return e.Resolve(arbiterID, false, sellerAmount, note, now)
+	})
+}
+
+// ResolveAsOperator um operador decide a disputa (custódias sem árbitro ou árbitro ausente)
+func (s *EscrowService) ResolveAsOperator(ctx context.Context, operatorID, id uuid.UUID, sellerAmount decimal.Decimal, note string) (*entity.Escrow, error) {
+	escrow, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := escrow.Resolve(operatorID, true, sellerAmount, note, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, escrow, EscrowTriggerOperator); err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
+
+// HandleTimeout processa o vencimento entregue pela fila. Vencimentos obsoletos (custódia encerrada,
+// em disputa ou com outro prazo) são ignorados; erros retornados podem ser repetidos pela fila.
+func (s *EscrowService) HandleTimeout(ctx context.Context, timeout EscrowTimeout) error {
+	escrow, err := s.escrows.FindByID(ctx, timeout.EscrowID)
+	if err != nil {
+		return err
+	}
+	if escrow == nil || !escrow.Deadline.Equal(timeout.Deadline) {
+		return nil
+	}
+	if err := escrow.Expire(s.now()); err != nil {
+		if errors.Is(err, entity.ErrEscrowInvalidState) {
+			return nil
+		}
+		return err
+	}
+	if err := s.commit(ctx, escrow, EscrowTriggerTimeout); err != nil {
+		if errors.Is(err, entity.ErrEscrowConflict) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// ExpireDue aplica o vencimento das custódias abertas com prazo vencido
+func (s *EscrowService) ExpireDue(ctx context.Context) (int, error) {
+	expired, err := s.escrows.ListExpired(ctx, s.now(), 100)
+	if err != nil {
+		return 0, err
+	}
+	handled := 0
+	for _, escrow := range expired {
+		if err := s.HandleTimeout(ctx, EscrowTimeout{EscrowID: escrow.ID, Deadline: escrow.Deadline}); err != nil {
+			s.logger.Error("failed to expire escrow", zap.String("escrow_id", escrow.ID.String()), zap.Error(err))
+			continue
+		}
+		handled++
+	}
+	return handled, nil
+}
+
+// RetryPayouts credita os pagamentos e devoluções das custódias encerradas que falharam junto com a
+// decisão. Retorna quantas custódias foram quitadas.
+func (s *EscrowService) RetryPayouts(ctx context.Context) (int, error) {
+	pending, err := s.escrows.ListPendingPayouts(ctx, s.now().Add(-escrowPayoutRetryGrace), escrowPayoutRetryBatch)
+	if err != nil {
+		return 0, err
+	}
+	paid := 0
+	for _, escrow := range pending {
+		if err := s.payOut(ctx, escrow); err != nil {
+			s.logger.Error("escrow payout retry failed", zap.String("escrow_id", escrow.ID.String()), zap.Error(err))
+			continue
+		}
+		paid++
+	}
+	if paid > 0 {
+		s.logger.Info("pending escrow payouts recovered", zap.Int("count", paid))
+	}
+	return paid, nil
+}
+
+// Run varre as custódias vencidas a cada intervalo até o contexto ser cancelado
+func (s *EscrowService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultEscrowSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpireDue(ctx); err != nil {
+				s.logger.Error("escrow sweep failed", zap.Error(err))
+			}
+			if _, err := s.RetryPayouts(ctx); err != nil {
+				s.logger.Error("escrow payout retry sweep failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *EscrowService) find(ctx context.Context, id uuid.UUID) (*entity.Escrow, error) {
+	escrow, err := s.escrows.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if escrow == nil {
+		return nil, ErrEscrowNotFound
+	}
+	return escrow, nil
+}
+
+func (s *EscrowService) transition(ctx context.Context, userID, id uuid.UUID, trigger string, apply func(*entity.Escrow, time.Time) error) (*entity.Escrow, error) {
+	escrow, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(escrow, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.commit(ctx, escrow, trigger); err != nil {
+		return nil, err
+	}
+	return escrow, nil
+}
+
+// commit grava o novo estado (a versão garante que só uma decisão vence) e, se a custódia foi
+// encerrada, paga o vendedor e/ou devolve ao comprador. O que não for creditado fica pendente na
+// custódia e é retomado por RetryPayouts.
+func (s *EscrowService) commit(ctx context.Context, escrow *entity.Escrow, trigger string) error {
+	if err := s.escrows.Update(ctx, escrow); err != nil {
+		return err
+	}
+
+	switch {
+	case escrow.Status == entity.EscrowDisputed:
+		s.eventBus.PublishAsync(ctx, events.NewEscrowDisputedEvent(escrow.ID, escrow.BuyerID, escrow.SellerID, *escrow.DisputedBy, escrow.ArbiterID, escrow.DisputeReason))
+		s.logger.Info("escrow disputed", zap.String("escrow_id", escrow.ID.String()))
+		return nil
+	case !escrow.Status.Closed():
+		return nil
+	}
+
+	if err := s.payOut(ctx, escrow); err != nil {
+		s.logger.Warn("escrow payout deferred to retry",
+			zap.String("escrow_id", escrow.ID.String()),
+			zap.String("status", string(escrow.Status)),
+			zap.Error(err),
+		)
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewEscrowSettledEvent(
+		escrow.ID, escrow.BuyerID, escrow.SellerID, string(escrow.Status), trigger, escrow.ReleasedAmount, escrow.RefundedAmount,
+	))
+	s.logger.Info("escrow settled",
+		zap.String("escrow_id", escrow.ID.String()),
+		zap.String("status", string(escrow.Status)),
+		zap.String("trigger", trigger),
+		zap.String("released_amount", escrow.ReleasedAmount.String()),
+		zap.String("refunded_amount", escrow.RefundedAmount.String()),
+	)
+	return nil
+}
+
+// payOut credita o pagamento ao vendedor e a devolução ao comprador ainda pendentes, registrando na
+// custódia cada transação creditada
+func (s *EscrowService) payOut(ctx context.Context, escrow *entity.Escrow) error {
+	var errs error
+	if escrow.ReleasedAmount.IsPositive() && escrow.ReleaseTxID == nil {
+		txID, err := s.payout(ctx, escrow, escrow.SellerID, escrow.ReleasedAmount)
+		if err == nil {
+			escrow.ReleaseTxID = &txID
+			err = s.escrows.SetPayoutTx(ctx, escrow.ID, &txID, nil)
+		}
+		errs = errors.Join(errs, err)
+	}
+	if escrow.RefundedAmount.IsPositive() && escrow.RefundTxID == nil {
+		txID, err := s.payout(ctx, escrow, escrow.BuyerID, escrow.RefundedAmount)
+		if err == nil {
+			escrow.RefundTxID = &txID
+			err = s.escrows.SetPayoutTx(ctx, escrow.ID, nil, &txID)
+		}
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+// payout credita amount na carteira do usuário por uma transação vinculada ao depósito da custódia
+func (s *EscrowService) payout(ctx context.Context, escrow *entity.Escrow, userID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error) {
+	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if wallet == nil {
+		return uuid.Nil, ErrWalletNotFound
+	}
+
+	tx := entity.NewTransaction(userID, entity.TransactionTypeEscrowPayout, amount)
+	tx.ParentID = escrow.FundingTxID
+	tx.FromAddress = escrowAddress(escrow)
+	tx.ToAddress = wallet.Address
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		return uuid.Nil, err
+	}
+	balanceBefore := wallet.Balance
+	if err := adjustWallet(ctx, s.walletRepo, userID, amount.InexactFloat64(), 0); err != nil {
+		tx.Fail("failed to credit wallet")
+		_ = s.txRepo.Update(ctx, tx)
+		return uuid.Nil, err
+	}
+	tx.Complete("escrow-payout-" + tx.ID.String())
+	// A carteira já foi creditada: a falha ao concluir a transação não pode reabrir o pagamento
+	if err := s.txRepo.Update(ctx, tx); err != nil {
+		s.logger.Error("failed to complete escrow payout transaction", zap.String("tx_id", tx.ID.String()), zap.Error(err))
+	}
+	if s.txns != nil {
+		s.txns.repayCredit(ctx, userID, tx, amount, balanceBefore)
+	}
+	return tx.ID, nil
+}
+
+// escrowAddress endereço interno que representa o saldo mantido em custódia
+func escrowAddress(escrow *entity.Escrow) string {
+	return "escrow:" + escrow.ID.String()
+}
+
+// WithEscrows habilita as custódias entre comprador e vendedor (sujeitas aos limites de saída do comprador)
+func (s *TransactionService) WithEscrows(escrows *EscrowService) *TransactionService {
+	s.escrows = escrows
+	escrows.txns = s
+	return s
+}
+
+// Escrows retorna o serviço de custódias (nil se desabilitado)
+func (s *TransactionService) Escrows() *EscrowService {
+	return s.escrows
+}
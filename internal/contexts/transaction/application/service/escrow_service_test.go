@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memEscrowRepo struct {
+	mu      sync.Mutex
+	escrows map[uuid.UUID]*entity.Escrow
+}
+
+func newMemEscrowRepo() *memEscrowRepo {
+	return &memEscrowRepo{escrows: make(map[uuid.UUID]*entity.Escrow)}
+}
+
+func copyEscrow(e *entity.Escrow) *entity.Escrow {
+	cp := *e
+	cp.Conditions = append([]entity.EscrowCondition(nil), e.Conditions...)
+	return &cp
+}
+
+func (m *memEscrowRepo) Create(ctx context.Context, e *entity.Escrow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.escrows[e.ID] = copyEscrow(e)
+	return nil
+}
+
+func (m *memEscrowRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Escrow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.escrows[id]; ok {
+		return copyEscrow(e), nil
+	}
+	return nil, nil
+}
+
+func (m *memEscrowRepo) ListByParty(ctx context.Context, userID uuid.UUID) ([]*entity.Escrow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Escrow
+	for _, e := range m.escrows {
+		if e.IsParty(userID) {
+			out = append(out, copyEscrow(e))
+		}
+	}
+	return out, nil
+}
+
+func (m *memEscrowRepo) ListByStatus(ctx context.Context, status entity.EscrowStatus, limit int) ([]*entity.Escrow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Escrow
+	for _, e := range m.escrows {
+		if e.Status == status && len(out) < limit {
+			out = append(out, copyEscrow(e))
+		}
+	}
+	return out, nil
+}
+
+func (m *memEscrowRepo) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.Escrow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Escrow
+	for _, e := range m.escrows {
+		open := e.Status == entity.EscrowAwaitingFunding || e.Status == entity.EscrowFunded
+		if open && !e.Deadline.After(now) && len(out) < limit {
+			out = append(out, copyEscrow(e))
+		}
+	}
+	return out, nil
+}
+
+func (m *memEscrowRepo) ListPendingPayouts(ctx context.Context, before time.Time, limit int) ([]*entity.Escrow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Escrow
+	for _, e := range m.escrows {
+		if e.PayoutPending() && e.ClosedAt != nil && !e.ClosedAt.After(before) && len(out) < limit {
+			out = append(out, copyEscrow(e))
+		}
+	}
+	return out, nil
+}
+
+func (m *memEscrowRepo) SetPayoutTx(ctx context.Context, id uuid.UUID, releaseTxID, refundTxID *uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := m.escrows[id]
+	if e.ReleaseTxID == nil {
+		e.ReleaseTxID = releaseTxID
+	}
+	if e.RefundTxID == nil {
+		e.RefundTxID = refundTxID
+	}
+	return nil
+}
+
+func (m *memEscrowRepo) Update(ctx context.Context, e *entity.Escrow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cur, ok := m.escrows[e.ID]
+	if !ok || cur.Version != e.Version {
+		return entity.ErrEscrowConflict
+	}
+	e.Version++
+	m.escrows[e.ID] = copyEscrow(e)
+	return nil
+}
+
+type recordingTimeoutScheduler struct {
+	timeouts []EscrowTimeout
+}
+
+func (r *recordingTimeoutScheduler) ScheduleTimeout(ctx context.Context, timeout EscrowTimeout) error {
+	r.timeouts = append(r.timeouts, timeout)
+	return nil
+}
+
+func setupEscrows(t *testing.T, balance float64) (*EscrowService, *memEscrowRepo, *memWalletRepo, *time.Time, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	svc, txr, wr, buyer := setupService(t, balance)
+	seller := uuid.New()
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: seller, Address: "SELLER"})
+
+	repo := newMemEscrowRepo()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	escrows := NewEscrowService(repo, txr, wr, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	escrows.now = func() time.Time { return now }
+	svc.WithEscrows(escrows)
+	return escrows, repo, wr, &now, buyer, seller
+}
+
+func balanceOf(t *testing.T, wr *memWalletRepo, userID uuid.UUID) float64 {
+	t.Helper()
+	w, _ := wr.FindByUserID(context.Background(), userID)
+	return w.Balance
+}
+
+func TestEscrowService_FundAndReleaseOnConditions(t *testing.T) {
+	escrows, _, wr, now, buyer, seller := setupEscrows(t, 1000)
+	ctx := context.Background()
+	scheduler := &recordingTimeoutScheduler{}
+	escrows.WithTimeoutScheduler(scheduler)
+
+	e, err := escrows.Create(ctx, entity.EscrowSpec{
+		BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(400),
+		Conditions: []string{"delivered"}, Deadline: now.Add(48 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("criação falhou: %v", err)
+	}
+	if len(scheduler.timeouts) != 1 || !scheduler.timeouts[0].Deadline.Equal(e.Deadline) {
+		t.Fatalf("vencimento deveria ser agendado no prazo: %+v", scheduler.timeouts)
+	}
+
+	if _, err := escrows.Fund(ctx, seller, e.ID); !errors.Is(err, entity.ErrEscrowNotParty) {
+		t.Fatalf("só o comprador deposita, obtido %v", err)
+	}
+	if e, err = escrows.Fund(ctx, buyer, e.ID); err != nil || e.Status != entity.EscrowFunded {
+		t.Fatalf("depósito falhou: %v", err)
+	}
+	if b := balanceOf(t, wr, buyer); b != 600 {
+		t.Fatalf("comprador deveria ter 600, tem %v", b)
+	}
+	if _, err := escrows.Release(ctx, buyer, e.ID); !errors.Is(err, entity.ErrEscrowConditionsPending) {
+		t.Fatalf("liberação sem condições cumpridas deveria falhar, obtido %v", err)
+	}
+
+	e, err = escrows.ConfirmCondition(ctx, buyer, e.ID, 0)
+	if err != nil || e.Status != entity.EscrowReleased {
+		t.Fatalf("última condição deveria liberar: %v, %+v", err, e)
+	}
+	if b := balanceOf(t, wr, seller); b != 400 {
+		t.Fatalf("vendedor deveria receber 400, tem %v", b)
+	}
+
+	// Vencimento posterior ao encerramento é ignorado
+	*now = now.Add(72 * time.Hour)
+	if err := escrows.HandleTimeout(ctx, scheduler.timeouts[0]); err != nil {
+		t.Fatalf("vencimento obsoleto deveria ser ignorado: %v", err)
+	}
+	if b := balanceOf(t, wr, seller); b != 400 {
+		t.Fatalf("vendedor não deveria receber duas vezes, tem %v", b)
+	}
+}
+
+func TestEscrowService_RetriesFailedPayout(t *testing.T) {
+	escrows, repo, wr, now, buyer, seller := setupEscrows(t, 1000)
+	ctx := context.Background()
+	e, err := escrows.Create(ctx, entity.EscrowSpec{BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(300), Deadline: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("criação falhou: %v", err)
+	}
+	if _, err := escrows.Fund(ctx, buyer, e.ID); err != nil {
+		t.Fatalf("depósito falhou: %v", err)
+	}
+
+	wallets := &toggleCreditWalletRepo{memWalletRepo: wr, fail: true}
+	escrows.walletRepo = wallets
+	if e, err = escrows.Release(ctx, buyer, e.ID); err != nil || e.Status != entity.EscrowReleased {
+		t.Fatalf("a decisão deveria ser gravada mesmo sem o pagamento: %v", err)
+	}
+	if b := balanceOf(t, wr, seller); b != 0 {
+		t.Fatalf("vendedor não deveria ter recebido, tem %v", b)
+	}
+	if stored, _ := repo.FindByID(ctx, e.ID); !stored.PayoutPending() {
+		t.Fatalf("pagamento deveria ficar pendente: %+v", stored)
+	}
+
+	wallets.fail = false
+	if n, err := escrows.RetryPayouts(ctx); err != nil || n != 0 {
+		t.Fatalf("dentro da carência não deveria pagar: %d, %v", n, err)
+	}
+	*now = now.Add(escrowPayoutRetryGrace)
+	if n, err := escrows.RetryPayouts(ctx); err != nil || n != 1 {
+		t.Fatalf("esperado 1 pagamento retomado, obtido %d, %v", n, err)
+	}
+	if b := balanceOf(t, wr, seller); b != 300 {
+		t.Fatalf("vendedor deveria receber 300, tem %v", b)
+	}
+	if n, _ := escrows.RetryPayouts(ctx); n != 0 {
+		t.Fatalf("pagamento já creditado não deveria repetir, obtido %d", n)
+	}
+	if b := balanceOf(t, wr, seller); b != 300 {
+		t.Fatalf("vendedor não deveria receber duas vezes, tem %v", b)
+	}
+}
+
+func TestEscrowService_DisputeSplitByArbiter(t *testing.T) {
+	escrows, _, wr, now, buyer, seller := setupEscrows(t, 1000)
+	ctx := context.Background()
+	arbiter := uuid.New()
+
+	e, _ := escrows.Create(ctx, entity.EscrowSpec{BuyerID: buyer, SellerID: seller, ArbiterID: &arbiter, Amount: decimal.NewFromInt(300), Deadline: now.Add(time.Hour)})
+	if _, err := escrows.Fund(ctx, buyer, e.ID); err != nil {
+		t.Fatalf("depósito falhou: %v", err)
+	}
+	if _, err := escrows.Dispute(ctx, seller, e.ID, "buyer refuses to confirm"); err != nil {
+		t.Fatalf("disputa falhou: %v", err)
+	}
+
+	// Prazo vencido não encerra custódia em disputa
+	*now = now.Add(2 * time.Hour)
+	if n, err := escrows.ExpireDue(ctx); err != nil || n != 0 {
+		t.Fatalf("disputa não deveria vencer, processadas %d (%v)", n, err)
+	}
+	if _, err := escrows.Get(ctx, uuid.New(), e.ID); !errors.Is(err, ErrEscrowNotFound) {
+		t.Fatalf("terceiros não veem a custódia, obtido %v", err)
+	}
+
+	e, err := escrows.Resolve(ctx, arbiter, e.ID, decimal.NewFromInt(100), "partial delivery")
+	if err != nil || e.Status != entity.EscrowResolved {
+		t.Fatalf("decisão falhou: %v", err)
+	}
+	if b := balanceOf(t, wr, seller); b != 100 {
+		t.Fatalf("vendedor deveria ter 100, tem %v", b)
+	}
+	if b := balanceOf(t, wr, buyer); b != 900 {
+		t.Fatalf("comprador deveria ter 900, tem %v", b)
+	}
+}
+
+func TestEscrowService_TimeoutActions(t *testing.T) {
+	escrows, _, wr, now, buyer, seller := setupEscrows(t, 1000)
+	ctx := context.Background()
+
+	unfunded, _ := escrows.Create(ctx, entity.EscrowSpec{BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(50), Deadline: now.Add(time.Hour)})
+	refund, _ := escrows.Create(ctx, entity.EscrowSpec{BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(100), Deadline: now.Add(time.Hour)})
+	release, _ := escrows.Create(ctx, entity.EscrowSpec{BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(200), Deadline: now.Add(time.Hour), TimeoutAction: entity.EscrowTimeoutRelease})
+	for _, e := range []*entity.Escrow{refund, release} {
+		if _, err := escrows.Fund(ctx, buyer, e.ID); err != nil {
+			t.Fatalf("depósito falhou: %v", err)
+		}
+	}
+
+	if err := escrows.HandleTimeout(ctx, EscrowTimeout{EscrowID: refund.ID, Deadline: refund.Deadline}); !errors.Is(err, entity.ErrEscrowNotExpired) {
+		t.Fatalf("vencimento antecipado deveria ser repetido pela fila, obtido %v", err)
+	}
+
+	*now = now.Add(time.Hour)
+	if n, err := escrows.ExpireDue(ctx); err != nil || n != 3 {
+		t.Fatalf("esperado 3 vencimentos, obtido %d (%v)", n, err)
+	}
+	statuses := map[uuid.UUID]entity.EscrowStatus{unfunded.ID: entity.EscrowCancelled, refund.ID: entity.EscrowRefunded, release.ID: entity.EscrowReleased}
+	for id, want := range statuses {
+		if e, _ := escrows.Get(ctx, buyer, id); e.Status != want {
+			t.Fatalf("custódia %s: esperado %s, obtido %s", id, want, e.Status)
+		}
+	}
+	if b := balanceOf(t, wr, buyer); b != 800 {
+		t.Fatalf("comprador deveria ter 800, tem %v", b)
+	}
+	if b := balanceOf(t, wr, seller); b != 200 {
+		t.Fatalf("vendedor deveria ter 200, tem %v", b)
+	}
+}
+
+func TestEscrowService_FundRequiresBalance(t *testing.T) {
+	escrows, _, _, now, buyer, seller := setupEscrows(t, 10)
+	ctx := context.Background()
+
+	e, _ := escrows.Create(ctx, entity.EscrowSpec{BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(50), Deadline: now.Add(time.Hour)})
+	if _, err := escrows.Fund(ctx, buyer, e.ID); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance, obtido %v", err)
+	}
+	if _, err := escrows.Cancel(ctx, seller, e.ID); err != nil {
+		t.Fatalf("cancelamento falhou: %v", err)
+	}
+	if _, err := escrows.Fund(ctx, buyer, e.ID); !errors.Is(err, entity.ErrEscrowInvalidState) {
+		t.Fatalf("custódia cancelada não aceita depósito, obtido %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// InternalChain rede usada nas regras de taxa para transferências entre usuários da plataforma
+const InternalChain = "internal"
+
+const (
+	// DefaultFeeRetryInterval intervalo padrão da nova tentativa das cobranças de taxa que falharam
+	DefaultFeeRetryInterval = 5 * time.Minute
+	// feeRetryGrace idade mínima da operação para a nova tentativa, evitando concorrer com a
+	// cobrança feita junto com a conclusão
+	feeRetryGrace = time.Minute
+	feeRetryBatch = 100
+)
+
+// NetworkFeeEstimator estima a taxa de rede de um envio na chain, no ativo nativo (ex.: 0.00042 ETH)
+type NetworkFeeEstimator interface {
+	EstimateNetworkFee(ctx context.Context, chain, fromAddress, toAddress string) (decimal.Decimal, string, error)
+}
+
+// FeeQuoteRequest operação a cotar. Asset vazio = BRL; FromAddress vazio = endereço da carteira do usuário
+type FeeQuoteRequest struct {
+	UserID      uuid.UUID
+	Type        entity.TransactionType
+	Chain       string
+	Asset       string
+	FromAddress string
+	ToAddress   string
+	Amount      decimal.Decimal
+}
+
+// FeeQuote detalhamento da taxa cobrada sobre a operação
+type FeeQuote struct {
+	Type             entity.TransactionType `json:"type"`
+	Chain            string                 `json:"chain,omitempty"`
+	Asset            string                 `json:"asset"`
+	RuleID           string                 `json:"rule_id,omitempty"`
+	Amount           decimal.Decimal        `json:"amount"`
+	PlatformFee      decimal.Decimal        `json:"platform_fee"`
+	NetworkFee       decimal.Decimal        `json:"network_fee"`                  // convertida para Asset
+	NetworkFeeNative decimal.Decimal        `json:"network_fee_native,omitempty"` // no ativo da rede
+	NetworkFeeAsset  string                 `json:"network_fee_asset,omitempty"`
+	TotalFee         decimal.Decimal        `json:"total_fee"`
+	// Total valor debitado do cliente (saques e transferências) ou creditado (depósitos)
+	Total    decimal.Decimal `json:"total"`
+	QuotedAt time.Time       `json:"quoted_at"`
+}
+
+// FeeService calcula as taxas pela tabela configurada e credita as cobranças na carteira de receita
+type FeeService struct {
+	schedule      entity.FeeSchedule
+	txRepo        repository.TransactionRepository
+	userRepo      userRepo.UserRepository
+	walletRepo    userRepo.WalletRepository
+	revenueUserID uuid.UUID
+	estimator     NetworkFeeEstimator
+	rates         map[string]decimal.Decimal
+	eventBus      events.Bus
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+// NewFeeService cria o serviço de taxas; revenueUserID é o dono da carteira de receita da plataforma
+func NewFeeService(
+	schedule entity.FeeSchedule,
+	txRepo repository.TransactionRepository,
+	userRepo userRepo.UserRepository,
+	walletRepo userRepo.WalletRepository,
+	revenueUserID uuid.UUID,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *FeeService {
+	return &FeeService{
+		schedule:      schedule,
+		txRepo:        txRepo,
+		userRepo:      userRepo,
+		walletRepo:    walletRepo,
+		revenueUserID: revenueUserID,
+		eventBus:      eventBus,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// WithNetworkFees habilita o repasse da taxa de rede; rates converte o ativo da rede para a moeda
+// da operação (ex.: ETH -> BRL)
+func (s *FeeService) WithNetworkFees(estimator NetworkFeeEstimator, rates map[string]decimal.Decimal) *FeeService {
+	s.estimator = estimator
+	s.rates = make(map[string]decimal.Decimal, len(rates))
+	for asset, rate := range rates {
+		s.rates[strings.ToUpper(asset)] = rate
+	}
+	return s
+}
+
+// Schedule retorna a tabela de taxas em uso
+func (s *FeeService) Schedule() entity.FeeSchedule {
+	return s.schedule
+}
+
+// Quote calcula a taxa da plataforma pela regra mais específica e soma a taxa de rede quando repassada
+func (s *FeeService) Quote(ctx context.Context, req FeeQuoteRequest) (*FeeQuote, error) {
+	if !req.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	if req.Asset == "" {
+		req.Asset = "BRL"
+	}
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	quote := &FeeQuote{
+		Type:        req.Type,
+		Chain:       req.Chain,
+		Asset:       req.Asset,
+		Amount:      req.Amount,
+		PlatformFee: decimal.Zero,
+		NetworkFee:  decimal.Zero,
+		QuotedAt:    s.now(),
+	}
+	rule := s.schedule.Select(entity.FeeInput{
+		Type:   req.Type,
+		Chain:  req.Chain,
+		Asset:  req.Asset,
+		Tier:   user.KYCLevel.String(),
+		Amount: req.Amount,
+	})
+	if rule != nil {
+		quote.RuleID = rule.ID
+		quote.PlatformFee = rule.Calculate(req.Amount)
+		// Só há taxa de rede quando o envio sai para uma chain
+		if rule.PassThroughNetworkFee && req.Chain != "" && req.Chain != InternalChain {
+			if err := s.networkFee(ctx, req, quote); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	quote.TotalFee = quote.PlatformFee.Add(quote.NetworkFee)
+	if req.Type == entity.TransactionTypeDeposit {
+		if quote.TotalFee.GreaterThanOrEqual(req.Amount) {
+			return nil, ErrFeeExceedsAmount
+		}
+		quote.Total = req.Amount.Sub(quote.TotalFee)
+	} else {
+		quote.Total = req.Amount.Add(quote.TotalFee)
+	}
+	return quote, nil
+}
+
+// networkFee estima a taxa da rede e converte para o ativo da operação
+func (s *FeeService) networkFee(ctx context.Context, req FeeQuoteRequest, quote *FeeQuote) error {
+	if s.estimator == nil {
+		return ErrNetworkFeeUnavailable
+	}
+	from := req.FromAddress
+	if from == "" {
+		wallet, err := s.walletRepo.FindByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if wallet == nil {
+			return ErrWalletNotFound
+		}
+		from = wallet.Address
+	}
+	native, asset, err := s.estimator.EstimateNetworkFee(ctx, req.Chain, from, req.ToAddress)
+	if err != nil {
+		return err
+	}
+
+	rate := decimal.NewFromInt(1)
+	if !strings.EqualFold(asset, req.Asset) {
+		var ok bool
+		if rate, ok = s.rates[strings.ToUpper(asset)]; !ok {
+			return ErrNetworkFeeUnpriced
+		}
+	}
+	quote.NetworkFeeNative = native
+	quote.NetworkFeeAsset = asset
+	quote.NetworkFee = native.Mul(rate).RoundUp(entity.FeePrecision)
+	return nil
+}
+
+// Collect registra a taxa de parent (parent.Fee) como transação própria e credita a carteira de receita.
+// O valor já deve ter sido debitado do cliente junto com a operação de origem.
+func (s *FeeService) Collect(ctx context.Context, parent *entity.Transaction) (*entity.Transaction, error) {
+	if !parent.Fee.IsPositive() {
+		return nil, nil
+	}
+	revenue, err := s.walletRepo.FindByUserID(ctx, s.revenueUserID)
+	if err != nil {
+		return nil, err
+	}
+	if revenue == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	fee := entity.NewTransaction(parent.UserID, entity.TransactionTypeFee, parent.Fee)
+	fee.ParentID = &parent.ID
+	fee.FromAddress = parent.FromAddress
+	fee.ToAddress = revenue.Address
+	if err := s.txRepo.Create(ctx, fee); err != nil {
+		return nil, err
+	}
+
+	if err := adjustWallet(ctx, s.walletRepo, s.revenueUserID, parent.Fee.InexactFloat64(), 0); err != nil {
+		fee.Fail("failed to credit revenue wallet")
+		_ = s.txRepo.Update(ctx, fee)
+		return nil, err
+	}
+	fee.Complete("fee-" + fee.ID.String())
+	if err := s.txRepo.Update(ctx, fee); err != nil {
+		return nil, err
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewFeeChargedEvent(fee.ID, parent.ID, parent.UserID, string(parent.Type), parent.Fee))
+	return fee, nil
+}
+
+// WithFees habilita a cobrança de taxas pela tabela configurada (inclusive em saques retidos para aprovação)
+func (s *TransactionService) WithFees(fees *FeeService) *TransactionService {
+	s.fees = fees
+	if s.approvals != nil {
+		s.approvals.fees = fees
+	}
+	return s
+}
+
+// Fees retorna o serviço de taxas (nil se desabilitado)
+func (s *TransactionService) Fees() *FeeService {
+	return s.fees
+}
+
+// quoteFee retorna a taxa total da operação; zero quando a cobrança de taxas está desabilitada
+func (s *TransactionService) quoteFee(ctx context.Context, req FeeQuoteRequest) (decimal.Decimal, error) {
+	if s.fees == nil {
+		return decimal.Zero, nil
+	}
+	quote, err := s.fees.Quote(ctx, req)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return quote.TotalFee, nil
+}
+
+// RetryUncollected cobra as taxas já debitadas dos clientes que não chegaram à carteira de receita
+// (falha na cobrança junto com a operação de origem). Retorna quantas foram cobradas.
+func (s *FeeService) RetryUncollected(ctx context.Context) (int, error) {
+	parents, err := s.txRepo.FindUncollectedFees(ctx, s.now().Add(-feeRetryGrace), feeRetryBatch)
+	if err != nil {
+		return 0, err
+	}
+	collected := 0
+	for _, parent := range parents {
+		if _, err := s.Collect(ctx, parent); err != nil {
+			s.logger.Error("fee collection retry failed",
+				zap.String("tx_id", parent.ID.String()),
+				zap.String("fee", parent.Fee.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		collected++
+	}
+	if collected > 0 {
+		s.logger.Info("uncollected fees recovered", zap.Int("count", collected))
+	}
+	return collected, nil
+}
+
+// Run repete RetryUncollected a cada intervalo até o contexto ser cancelado
+func (s *FeeService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultFeeRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RetryUncollected(ctx); err != nil {
+				s.logger.Error("fee retry sweep failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// collectFee registra a cobrança junto com a operação. O cliente já foi debitado, então a falha não
+// desfaz a operação: a taxa fica sem cobrança registrada e é retomada por FeeService.RetryUncollected.
+func collectFee(ctx context.Context, fees *FeeService, logger *zap.Logger, tx *entity.Transaction) {
+	if fees == nil {
+		return
+	}
+	if _, err := fees.Collect(ctx, tx); err != nil {
+		logger.Warn("transaction fee collection deferred to retry",
+			zap.String("tx_id", tx.ID.String()),
+			zap.String("fee", tx.Fee.String()),
+			zap.Error(err),
+		)
+	}
+}
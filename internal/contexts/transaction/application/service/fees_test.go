@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type stubNetworkFees struct{}
+
+func (stubNetworkFees) EstimateNetworkFee(ctx context.Context, chain, from, to string) (decimal.Decimal, string, error) {
+	return decimal.RequireFromString("0.001"), "ETH", nil
+}
+
+func setupFees(t *testing.T, balance float64) (*TransactionService, *memTxnRepo, *memWalletRepo, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	svc, txr, wr, uid := setupService(t, balance)
+	schedule, err := entity.LoadFeeSchedule(strings.NewReader(`[
+		{"id": "withdraw", "transaction_type": "withdraw", "kind": "percentage", "rate": "0.01", "pass_through_network_fee": true},
+		{"id": "deposit", "transaction_type": "deposit", "kind": "flat", "flat": "1"},
+		{"id": "internal", "chain": "internal", "kind": "flat"}
+	]`))
+	if err != nil {
+		t.Fatalf("tabela inválida: %v", err)
+	}
+	revenueID := uuid.New()
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: revenueID, Address: "REVENUE"})
+
+	fees := NewFeeService(schedule, txr, svc.userRepo, wr, revenueID, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()).
+		WithNetworkFees(stubNetworkFees{}, map[string]decimal.Decimal{"eth": decimal.NewFromInt(10000)})
+	svc.WithFees(fees)
+	return svc, txr, wr, uid, revenueID
+}
+
+func TestFeeService_WithdrawChargesPlatformAndNetworkFee(t *testing.T) {
+	svc, txr, wr, uid, revenueID := setupFees(t, 5000)
+	ctx := context.Background()
+
+	quote, err := svc.Fees().Quote(ctx, FeeQuoteRequest{UserID: uid, Type: entity.TransactionTypeWithdraw, Chain: "ethereum", ToAddress: "0xabc", Amount: decimal.NewFromInt(1000)})
+	if err != nil {
+		t.Fatalf("cotação falhou: %v", err)
+	}
+	if !quote.PlatformFee.Equal(decimal.NewFromInt(10)) || !quote.NetworkFee.Equal(decimal.NewFromInt(10)) || !quote.Total.Equal(decimal.NewFromInt(1020)) {
+		t.Fatalf("cotação inesperada: %+v", quote)
+	}
+
+	tx, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(1000), "ethereum", "0xabc")
+	if err != nil {
+		t.Fatalf("saque falhou: %v", err)
+	}
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 3980 {
+		t.Fatalf("saque deveria debitar valor + taxa, saldo %v", w.Balance)
+	}
+	if w, _ := wr.FindByUserID(ctx, revenueID); w.Balance != 20 {
+		t.Fatalf("receita deveria receber a taxa, saldo %v", w.Balance)
+	}
+
+	var feeTx *entity.Transaction
+	for _, stored := range txr.txs {
+		if stored.Type == entity.TransactionTypeFee {
+			feeTx = stored
+		}
+	}
+	if feeTx == nil || feeTx.ParentID == nil || *feeTx.ParentID != tx.ID || !feeTx.Amount.Equal(decimal.NewFromInt(20)) || feeTx.Status != entity.TransactionStatusCompleted {
+		t.Fatalf("transação de taxa inesperada: %+v", feeTx)
+	}
+
+	// Saldo que cobre o valor mas não a taxa
+	if _, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(3970), "ethereum", "0xabc"); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance, obtido %v", err)
+	}
+}
+
+func TestFeeService_DepositAndInternalTransfer(t *testing.T) {
+	svc, _, wr, uid, revenueID := setupFees(t, 100)
+	ctx := context.Background()
+
+	if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(50), ""); err != nil {
+		t.Fatalf("depósito falhou: %v", err)
+	}
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 149 {
+		t.Fatalf("depósito deveria creditar valor - taxa, saldo %v", w.Balance)
+	}
+
+	other := uuid.New()
+	_ = svc.userRepo.Create(ctx, &userEntity.User{ID: other, Email: "o@t.com", Password: "hash"})
+	_ = wr.Create(ctx, &userEntity.Wallet{UserID: other, Address: "OTHER"})
+	tx, err := svc.ProcessTransfer(ctx, uid, other, decimal.NewFromInt(49))
+	if err != nil || !tx.Fee.IsZero() {
+		t.Fatalf("transferência interna não deveria ter taxa: %v %v", err, tx)
+	}
+	if w, _ := wr.FindByUserID(ctx, revenueID); w.Balance != 1 {
+		t.Fatalf("receita deveria ter apenas a taxa do depósito, saldo %v", w.Balance)
+	}
+	if _, err := svc.Fees().Quote(ctx, FeeQuoteRequest{UserID: uid, Type: entity.TransactionTypeDeposit, Amount: decimal.NewFromInt(1)}); !errors.Is(err, ErrFeeExceedsAmount) {
+		t.Fatalf("esperado ErrFeeExceedsAmount, obtido %v", err)
+	}
+}
+
+func TestFeeService_UnpricedNetworkFee(t *testing.T) {
+	svc, _, _, uid, _ := setupFees(t, 5000)
+	svc.Fees().WithNetworkFees(stubNetworkFees{}, nil)
+
+	_, err := svc.ProcessWithdrawTo(context.Background(), uid, decimal.NewFromInt(100), "ethereum", "0xabc")
+	if !errors.Is(err, ErrNetworkFeeUnpriced) {
+		t.Fatalf("esperado ErrNetworkFeeUnpriced, obtido %v", err)
+	}
+}
+
+func TestFeeService_RetryUncollectedAfterCollectionFailure(t *testing.T) {
+	svc, txr, wr, uid, revenueID := setupFees(t, 5000)
+	ctx := context.Background()
+
+	// Carteira de receita indisponível durante o saque
+	revenue := wr.wallets[revenueID]
+	delete(wr.wallets, revenueID)
+	if _, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(1000), "ethereum", "0xabc"); err != nil {
+		t.Fatalf("saque não deveria falhar pela cobrança da taxa: %v", err)
+	}
+	if len(txnsOfType(txr, entity.TransactionTypeFee)) != 0 {
+		t.Fatal("nenhuma cobrança deveria ter sido registrada")
+	}
+	wr.wallets[revenueID] = revenue
+
+	fees := svc.Fees()
+	if n, err := fees.RetryUncollected(ctx); err != nil || n != 0 {
+		t.Fatalf("operação recém-concluída não deveria ser retomada: %d %v", n, err)
+	}
+	fees.now = func() time.Time { return time.Now().Add(2 * feeRetryGrace) }
+	if n, err := fees.RetryUncollected(ctx); err != nil || n != 1 {
+		t.Fatalf("esperada 1 taxa retomada, obtido %d %v", n, err)
+	}
+	if w, _ := wr.FindByUserID(ctx, revenueID); w.Balance != 20 {
+		t.Fatalf("receita deveria receber a taxa retomada, saldo %v", w.Balance)
+	}
+	if n, _ := fees.RetryUncollected(ctx); n != 0 {
+		t.Fatalf("taxa já cobrada não pode ser cobrada de novo, obtido %d", n)
+	}
+}
@@ -0,0 +1,579 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultInterestSweepInterval intervalo da varredura que dispara a apropriação do dia anterior
+	DefaultInterestSweepInterval = time.Hour
+
+	interestDateLayout   = "2006-01-02"
+	interestPeriodLayout = "2006-01"
+
+	// withholdingAddress destino interno do imposto retido na fonte
+	withholdingAddress = "tax:withholding"
+)
+
+var (
+	// ErrInterestTypeNotFound tipo de conta remunerada inexistente
+	ErrInterestTypeNotFound = errors.New("interest account type not found")
+	// ErrInterestAccountNotFound usuário sem conta remunerada ativa
+	ErrInterestAccountNotFound = errors.New("interest account not found")
+	// ErrInterestAccountExists usuário já tem conta remunerada ativa
+	ErrInterestAccountExists = errors.New("user already has an active interest account")
+	// ErrInvalidInterestDate data fora do formato AAAA-MM-DD
+	ErrInvalidInterestDate = errors.New("invalid interest date")
+)
+
+// InterestAccrualTask apropriação de um dia entregue à fila
+type InterestAccrualTask struct {
+	Date string `json:"date"` // AAAA-MM-DD
+}
+
+// TaskID identificador estável da apropriação do dia, usado para deduplicar tarefas na fila
+func (t InterestAccrualTask) TaskID() string {
+	return "interest:" + t.Date
+}
+
+// InterestAccrualDispatcher entrega a apropriação diária para processamento assíncrono (ex.: asynq)
+type InterestAccrualDispatcher interface {
+	Dispatch(ctx context.Context, task InterestAccrualTask) error
+}
+
+// InterestHistory rendimentos apropriados no intervalo, o pendente de capitalização e as
+// capitalizações da conta
+type InterestHistory struct {
+	Account         *entity.InterestAccount          `json:"account"`
+	From            time.Time                        `json:"from"`
+	To              time.Time                        `json:"to"`
+	Accruals        []*entity.InterestAccrual        `json:"accruals"`
+	Total           decimal.Decimal                  `json:"total"`
+	Capitalizations []*entity.InterestCapitalization `json:"capitalizations"`
+}
+
+// InterestService remunera o saldo em moeda base da carteira dos usuários com conta remunerada. A
+// apropriação diária usa o saldo de fechamento de cada dia, reconstruído a partir do razão
+// (WithLedger); no último dia do mês o pendente é capitalizado como transação de rendimento, com o
+// imposto retido em transação derivada.
+type InterestService struct {
+	repo       repository.InterestRepository
+	txRepo     repository.TransactionRepository
+	walletRepo userRepo.WalletRepository
+	statements repository.StatementRepository
+	dispatcher InterestAccrualDispatcher
+	location   *time.Location
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewInterestService cria o serviço de contas remuneradas
+func NewInterestService(
+	repo repository.InterestRepository,
+	txRepo repository.TransactionRepository,
+	walletRepo userRepo.WalletRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *InterestService {
+	return &InterestService{
+		repo:       repo,
+		txRepo:     txRepo,
+		walletRepo: walletRepo,
+		location:   time.UTC,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// WithDispatcher entrega a apropriação diária à fila
+func (s *InterestService) WithDispatcher(dispatcher InterestAccrualDispatcher) *InterestService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithLedger habilita a reconstrução dos saldos de fechamento dos dias em atraso pelas
+// movimentações da carteira. Sem ela só o último dia é apropriado, com o saldo atual, e os dias
+// anteriores ficam sem rendimento.
+func (s *InterestService) WithLedger(statements repository.StatementRepository) *InterestService {
+	s.statements = statements
+	return s
+}
+
+// WithLocation define o fuso usado nos limites do dia e do mês
+func (s *InterestService) WithLocation(location *time.Location) *InterestService {
+	if location != nil {
+		s.location = location
+	}
+	return s
+}
+
+// ParseDate interpreta AAAA-MM-DD no fuso configurado
+func (s *InterestService) ParseDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(interestDateLayout, date, s.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidInterestDate)
+	}
+	return day, nil
+}
+
+// Yesterday início do dia anterior no fuso configurado, o dia apropriado pela execução diária
+func (s *InterestService) Yesterday() time.Time {
+	return s.startOfDay(s.now()).AddDate(0, 0, -1)
+}
+
+func (s *InterestService) startOfDay(t time.Time) time.Time {
+	t = t.In(s.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+}
+
+// DefineType cria ou altera um tipo de conta remunerada; contas abertas passam a seguir a nova
+// tabela de taxas a partir da próxima apropriação
+func (s *InterestService) DefineType(ctx context.Context, t *entity.InterestAccountType) (*entity.InterestAccountType, error) {
+	existing, err := s.repo.FindType(ctx, t.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		t.CreatedAt = existing.CreatedAt
+	}
+	t.UpdatedAt = s.now()
+	if err := s.repo.SaveType(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListTypes lista os tipos de conta remunerada
+func (s *InterestService) ListTypes(ctx context.Context) ([]*entity.InterestAccountType, error) {
+	return s.repo.ListTypes(ctx)
+}
+
+// Open abre a conta remunerada do usuário no tipo informado; o saldo rende a partir do dia da abertura
+func (s *InterestService) Open(ctx context.Context, userID uuid.UUID, code string) (*entity.InterestAccount, error) {
+	t, err := s.findType(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+	account, err := entity.NewInterestAccount(userID, t, s.now())
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateAccount(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrInterestAccountExists
+	}
+	s.logger.Info("interest account opened",
+		zap.String("account_id", account.ID.String()),
+		zap.String("user_id", userID.String()),
+		zap.String("type", t.Code),
+	)
+	return account, nil
+}
+
+// Account retorna a conta remunerada ativa do usuário
+func (s *InterestService) Account(ctx context.Context, userID uuid.UUID) (*entity.InterestAccount, error) {
+	account, err := s.repo.FindAccountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrInterestAccountNotFound
+	}
+	return account, nil
+}
+
+// Close apropria os dias pendentes até ontem, capitaliza o rendimento pendente e encerra a conta
+func (s *InterestService) Close(ctx context.Context, userID uuid.UUID) (*entity.InterestAccount, error) {
+	account, err := s.Account(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.findType(ctx, account.TypeCode)
+	if err != nil {
+		return nil, err
+	}
+	yesterday := s.Yesterday()
+	if _, _, err := s.accrueAccount(ctx, account, t, yesterday); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if _, err := s.capitalize(ctx, account, t, yesterday, now.In(s.location).Format(interestPeriodLayout)); err != nil {
+		return nil, err
+	}
+	if err := account.Close(now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	s.logger.Info("interest account closed",
+		zap.String("account_id", account.ID.String()),
+		zap.String("user_id", userID.String()),
+		zap.String("accrued_unpaid", account.AccruedUnpaid.String()),
+	)
+	return account, nil
+}
+
+// History retorna os rendimentos apropriados em [from, to] e as capitalizações da conta do usuário
+func (s *InterestService) History(ctx context.Context, userID uuid.UUID, from, to time.Time) (*InterestHistory, error) {
+	account, err := s.Account(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidInterestDate)
+	}
+	accruals, err := s.repo.ListAccruals(ctx, account.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	capitalizations, err := s.repo.ListCapitalizations(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	history := &InterestHistory{
+		Account:         account,
+		From:            from,
+		To:              to,
+		Accruals:        accruals,
+		Total:           decimal.Zero,
+		Capitalizations: capitalizations,
+	}
+	for _, a := range accruals {
+		history.Total = history.Total.Add(a.Amount)
+	}
+	if history.Accruals == nil {
+		history.Accruals = []*entity.InterestAccrual{}
+	}
+	if history.Capitalizations == nil {
+		history.Capitalizations = []*entity.InterestCapitalization{}
+	}
+	return history, nil
+}
+
+// AccrueDay apropria o rendimento de todas as contas ativas até o dia informado, recuperando dias
+// perdidos, e capitaliza os meses encerrados. Falhas de uma conta não interrompem as demais.
+func (s *InterestService) AccrueDay(ctx context.Context, day time.Time) (*entity.InterestAccrualRun, error) {
+	day = s.startOfDay(day)
+	run := &entity.InterestAccrualRun{RunDate: day, Total: decimal.Zero, StartedAt: s.now()}
+
+	accounts, err := s.repo.ListActiveAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]*entity.InterestAccountType)
+	for _, account := range accounts {
+		run.Accounts++
+		t, ok := types[account.TypeCode]
+		if !ok {
+			if t, err = s.findType(ctx, account.TypeCode); err != nil {
+				run.Failures++
+				s.logger.Error("interest type unavailable", zap.String("type", account.TypeCode), zap.Error(err))
+				continue
+			}
+			types[account.TypeCode] = t
+		}
+		accruals, capitalizations, err := s.accrueAccount(ctx, account, t, day)
+		for _, a := range accruals {
+			run.Accrued++
+			run.Total = run.Total.Add(a.Amount)
+		}
+		run.Capitalizations += capitalizations
+		if err != nil {
+			run.Failures++
+			s.logger.Error("interest accrual failed",
+				zap.String("account_id", account.ID.String()),
+				zap.String("date", day.Format(interestDateLayout)),
+				zap.Error(err),
+			)
+		}
+	}
+	run.FinishedAt = s.now()
+
+	if err := s.repo.SaveRun(ctx, run); err != nil {
+		return nil, err
+	}
+	s.logger.Info("interest accrual finished",
+		zap.String("date", day.Format(interestDateLayout)),
+		zap.Int("accounts", run.Accounts),
+		zap.Int("accrued", run.Accrued),
+		zap.Int("capitalizations", run.Capitalizations),
+		zap.Int("failures", run.Failures),
+	)
+	return run, nil
+}
+
+// accrueAccount apropria cada dia ainda não apropriado da conta até through, pelo saldo de
+// fechamento do dia, e capitaliza o mês a cada último dia do mês apropriado
+func (s *InterestService) accrueAccount(ctx context.Context, account *entity.InterestAccount, t *entity.InterestAccountType, through time.Time) ([]*entity.InterestAccrual, int, error) {
+	start := s.startOfDay(account.OpenedAt)
+	if account.LastAccrualDate != nil {
+		start = s.startOfDay(*account.LastAccrualDate).AddDate(0, 0, 1)
+	}
+	if start.After(through) {
+		return nil, 0, nil
+	}
+	days := dayRange(start, through)
+	balances, skip, err := s.dayBalances(ctx, account, days)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var accrued []*entity.InterestAccrual
+	capitalizations := 0
+	// o crédito líquido das capitalizações passa a render a partir do dia seguinte
+	credited := decimal.Zero
+	for i, day := range days {
+		var accrual *entity.InterestAccrual
+		if i >= skip {
+			accrual = t.Accrue(account, balances[i].Add(credited), day, s.now())
+		}
+		if accrual != nil {
+			saved, err := s.repo.SaveAccrual(ctx, accrual)
+			if err != nil {
+				return accrued, capitalizations, err
+			}
+			if saved {
+				account.AccruedUnpaid = account.AccruedUnpaid.Add(accrual.Amount)
+				accrued = append(accrued, accrual)
+			}
+		} else if err := s.repo.MarkAccrued(ctx, account.ID, day); err != nil {
+			return accrued, capitalizations, err
+		}
+		d := day
+		account.LastAccrualDate = &d
+
+		if day.AddDate(0, 0, 1).Day() != 1 {
+			continue
+		}
+		c, err := s.capitalize(ctx, account, t, day, day.Format(interestPeriodLayout))
+		if err != nil {
+			return accrued, capitalizations, err
+		}
+		if c != nil {
+			capitalizations++
+			credited = credited.Add(c.Net)
+		}
+	}
+	return accrued, capitalizations, nil
+}
+
+// dayBalances saldo de fechamento de cada dia pelo razão. Sem razão só o último dia tem saldo (o
+// atual) e skip indica quantos dias em atraso ficam sem rendimento.
+func (s *InterestService) dayBalances(ctx context.Context, account *entity.InterestAccount, days []time.Time) ([]decimal.Decimal, int, error) {
+	wallet, err := s.walletRepo.FindByUserID(ctx, account.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if wallet == nil {
+		return nil, 0, ErrWalletNotFound
+	}
+	if s.statements != nil {
+		balances, err := closingBalances(ctx, s.statements, account.UserID, wallet, days, s.now())
+		return balances, 0, err
+	}
+	skip := len(days) - 1
+	if skip > 0 {
+		s.logger.Warn("interest catch-up without ledger: past days left without accrual",
+			zap.String("account_id", account.ID.String()),
+			zap.String("from", days[0].Format(interestDateLayout)),
+			zap.String("to", days[skip-1].Format(interestDateLayout)),
+		)
+	}
+	balances := make([]decimal.Decimal, len(days))
+	balances[skip] = decimal.NewFromFloat(wallet.Balance)
+	return balances, skip, nil
+}
+
+// capitalize credita na carteira o rendimento pendente apropriado até through: transação de
+// rendimento pelo bruto e, havendo alíquota, transação derivada com o imposto retido. Se o crédito
+// falhar a capitalização é desfeita e os rendimentos voltam a ficar pendentes.
+func (s *InterestService) capitalize(ctx context.Context, account *entity.InterestAccount, t *entity.InterestAccountType, through time.Time, period string) (*entity.InterestCapitalization, error) {
+	accruals, err := s.repo.UnpaidAccruals(ctx, account.ID, through)
+	if err != nil {
+		return nil, err
+	}
+	c := entity.NewInterestCapitalization(account, len(accruals), t.WithholdingRate, period, s.now())
+	if c == nil {
+		return nil, nil
+	}
+	wallet, err := s.walletRepo.FindByUserID(ctx, account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	ids := make([]uuid.UUID, len(accruals))
+	for i, a := range accruals {
+		ids[i] = a.ID
+	}
+	saved, err := s.repo.SaveCapitalization(ctx, c, ids)
+	if err != nil || !saved {
+		return nil, err
+	}
+	account.AccruedUnpaid = account.AccruedUnpaid.Sub(c.Gross)
+
+	interest := entity.NewTransaction(account.UserID, entity.TransactionTypeInterest, c.Gross)
+	interest.FromAddress = interestAddress(account)
+	interest.ToAddress = wallet.Address
+	if err := s.txRepo.Create(ctx, interest); err != nil {
+		return nil, s.undoCapitalization(ctx, account, c, err)
+	}
+	var tax *entity.Transaction
+	if c.Withholding.IsPositive() {
+		tax = entity.NewTransaction(account.UserID, entity.TransactionTypeWithholdingTax, c.Withholding)
+		tax.ParentID = &interest.ID
+		tax.FromAddress = wallet.Address
+		tax.ToAddress = withholdingAddress
+		if err := s.txRepo.Create(ctx, tax); err != nil {
+			interest.Fail("failed to record withholding")
+			_ = s.txRepo.Update(ctx, interest)
+			return nil, s.undoCapitalization(ctx, account, c, err)
+		}
+	}
+
+	if err := adjustWallet(ctx, s.walletRepo, account.UserID, c.Net.InexactFloat64(), 0); err != nil {
+		interest.Fail("failed to credit wallet")
+		_ = s.txRepo.Update(ctx, interest)
+		if tax != nil {
+			tax.Fail("failed to credit wallet")
+			_ = s.txRepo.Update(ctx, tax)
+		}
+		return nil, s.undoCapitalization(ctx, account, c, err)
+	}
+	interest.Complete("interest-" + interest.ID.String())
+	if err := s.txRepo.Update(ctx, interest); err != nil {
+		return nil, err
+	}
+	c.InterestTxID = &interest.ID
+	if tax != nil {
+		tax.Complete("withholding-" + tax.ID.String())
+		if err := s.txRepo.Update(ctx, tax); err != nil {
+			return nil, err
+		}
+		c.TaxTxID = &tax.ID
+	}
+	if err := s.repo.SetCapitalizationTransactions(ctx, c.ID, interest.ID, c.TaxTxID); err != nil {
+		return nil, err
+	}
+
+	if s.eventBus != nil {
+		s.eventBus.PublishAsync(ctx, events.NewInterestCapitalizedEvent(
+			account.ID, account.UserID, interest.ID, c.Period, c.Gross, c.Withholding, c.Net,
+		))
+	}
+	return c, nil
+}
+
+// undoCapitalization desfaz a capitalização cujo crédito não foi feito, para que os rendimentos
+// sejam capitalizados na próxima execução, e retorna a causa
+func (s *InterestService) undoCapitalization(ctx context.Context, account *entity.InterestAccount, c *entity.InterestCapitalization, cause error) error {
+	if err := s.repo.DeleteCapitalization(ctx, c); err != nil {
+		s.logger.Error("interest capitalization recorded without wallet credit",
+			zap.String("account_id", account.ID.String()),
+			zap.String("capitalization_id", c.ID.String()),
+			zap.String("period", c.Period),
+			zap.Error(err),
+		)
+		return cause
+	}
+	account.AccruedUnpaid = account.AccruedUnpaid.Add(c.Gross)
+	return cause
+}
+
+func (s *InterestService) findType(ctx context.Context, code string) (*entity.InterestAccountType, error) {
+	t, err := s.repo.FindType(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrInterestTypeNotFound
+	}
+	return t, nil
+}
+
+// interestAddress endereço interno que representa a origem do rendimento pago pela plataforma
+func interestAddress(account *entity.InterestAccount) string {
+	return "interest:" + account.ID.String()
+}
+
+// Execute apropria o dia da tarefa
+func (s *InterestService) Execute(ctx context.Context, task InterestAccrualTask) error {
+	day, err := s.ParseDate(task.Date)
+	if err != nil {
+		return err
+	}
+	_, err = s.AccrueDay(ctx, day)
+	return err
+}
+
+// DispatchDaily enfileira (ou executa, sem dispatcher) a apropriação do dia anterior se ela ainda
+// não foi feita; retorna se algo foi disparado
+func (s *InterestService) DispatchDaily(ctx context.Context) (bool, error) {
+	day := s.Yesterday()
+	existing, err := s.repo.FindRun(ctx, day)
+	if err != nil || existing != nil {
+		return false, err
+	}
+	task := InterestAccrualTask{Date: day.Format(interestDateLayout)}
+	if s.dispatcher == nil {
+		return true, s.Execute(ctx, task)
+	}
+	return true, s.dispatcher.Dispatch(ctx, task)
+}
+
+// Run dispara a apropriação diária a cada intervalo até o contexto ser cancelado
+func (s *InterestService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterestSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchDaily(ctx); err != nil {
+				s.logger.Error("daily interest accrual failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// WithInterest habilita as contas remuneradas
+func (s *TransactionService) WithInterest(interest *InterestService) *TransactionService {
+	s.interest = interest
+	return s
+}
+
+// Interest retorna o serviço de contas remuneradas (nil se desabilitado)
+func (s *TransactionService) Interest() *InterestService {
+	return s.interest
+}
@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memInterestRepo struct {
+	mu              sync.Mutex
+	types           map[string]*entity.InterestAccountType
+	accounts        map[uuid.UUID]*entity.InterestAccount
+	accruals        []*entity.InterestAccrual
+	capitalizations []*entity.InterestCapitalization
+	runs            map[time.Time]*entity.InterestAccrualRun
+}
+
+func newMemInterestRepo() *memInterestRepo {
+	return &memInterestRepo{
+		types:    make(map[string]*entity.InterestAccountType),
+		accounts: make(map[uuid.UUID]*entity.InterestAccount),
+		runs:     make(map[time.Time]*entity.InterestAccrualRun),
+	}
+}
+
+func copyInterestAccount(a *entity.InterestAccount) *entity.InterestAccount {
+	cp := *a
+	return &cp
+}
+
+func (m *memInterestRepo) SaveType(ctx context.Context, t *entity.InterestAccountType) error {
+	m.types[t.Code] = t
+	return nil
+}
+
+func (m *memInterestRepo) FindType(ctx context.Context, code string) (*entity.InterestAccountType, error) {
+	return m.types[code], nil
+}
+
+func (m *memInterestRepo) ListTypes(ctx context.Context) ([]*entity.InterestAccountType, error) {
+	var out []*entity.InterestAccountType
+	for _, t := range m.types {
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (m *memInterestRepo) CreateAccount(ctx context.Context, account *entity.InterestAccount) (bool, error) {
+	for _, a := range m.accounts {
+		if a.UserID == account.UserID && a.Status == entity.InterestAccountActive {
+			return false, nil
+		}
+	}
+	m.accounts[account.ID] = copyInterestAccount(account)
+	return true, nil
+}
+
+func (m *memInterestRepo) UpdateAccount(ctx context.Context, account *entity.InterestAccount) error {
+	stored := m.accounts[account.ID]
+	stored.Status = account.Status
+	stored.ClosedAt = account.ClosedAt
+	return nil
+}
+
+func (m *memInterestRepo) FindAccountByUser(ctx context.Context, userID uuid.UUID) (*entity.InterestAccount, error) {
+	for _, a := range m.accounts {
+		if a.UserID == userID && a.Status == entity.InterestAccountActive {
+			return copyInterestAccount(a), nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memInterestRepo) ListActiveAccounts(ctx context.Context) ([]*entity.InterestAccount, error) {
+	var out []*entity.InterestAccount
+	for _, a := range m.accounts {
+		if a.Status == entity.InterestAccountActive {
+			out = append(out, copyInterestAccount(a))
+		}
+	}
+	return out, nil
+}
+
+func (m *memInterestRepo) SaveAccrual(ctx context.Context, accrual *entity.InterestAccrual) (bool, error) {
+	for _, a := range m.accruals {
+		if a.AccountID == accrual.AccountID && a.AccrualDate.Equal(accrual.AccrualDate) {
+			return false, nil
+		}
+	}
+	m.accruals = append(m.accruals, accrual)
+	account := m.accounts[accrual.AccountID]
+	account.AccruedUnpaid = account.AccruedUnpaid.Add(accrual.Amount)
+	return true, m.MarkAccrued(ctx, accrual.AccountID, accrual.AccrualDate)
+}
+
+func (m *memInterestRepo) MarkAccrued(ctx context.Context, accountID uuid.UUID, day time.Time) error {
+	account := m.accounts[accountID]
+	if account.LastAccrualDate == nil || day.After(*account.LastAccrualDate) {
+		account.LastAccrualDate = &day
+	}
+	return nil
+}
+
+func (m *memInterestRepo) ListAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*entity.InterestAccrual, error) {
+	var out []*entity.InterestAccrual
+	for _, a := range m.accruals {
+		if a.AccountID == accountID && !a.AccrualDate.Before(from) && !a.AccrualDate.After(to) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *memInterestRepo) UnpaidAccruals(ctx context.Context, accountID uuid.UUID, through time.Time) ([]*entity.InterestAccrual, error) {
+	var out []*entity.InterestAccrual
+	for _, a := range m.accruals {
+		if a.AccountID == accountID && a.CapitalizationID == nil && !a.AccrualDate.After(through) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *memInterestRepo) SaveCapitalization(ctx context.Context, c *entity.InterestCapitalization, accrualIDs []uuid.UUID) (bool, error) {
+	for _, existing := range m.capitalizations {
+		if existing.AccountID == c.AccountID && existing.Period == c.Period {
+			return false, nil
+		}
+	}
+	m.capitalizations = append(m.capitalizations, c)
+	for _, id := range accrualIDs {
+		for _, a := range m.accruals {
+			if a.ID == id {
+				capID := c.ID
+				a.CapitalizationID = &capID
+			}
+		}
+	}
+	account := m.accounts[c.AccountID]
+	account.AccruedUnpaid = account.AccruedUnpaid.Sub(c.Gross)
+	return true, nil
+}
+
+func (m *memInterestRepo) DeleteCapitalization(ctx context.Context, c *entity.InterestCapitalization) error {
+	for i, existing := range m.capitalizations {
+		if existing.ID != c.ID {
+			continue
+		}
+		m.capitalizations = append(m.capitalizations[:i], m.capitalizations[i+1:]...)
+		for _, a := range m.accruals {
+			if a.CapitalizationID != nil && *a.CapitalizationID == c.ID {
+				a.CapitalizationID = nil
+			}
+		}
+		account := m.accounts[c.AccountID]
+		account.AccruedUnpaid = account.AccruedUnpaid.Add(c.Gross)
+		return nil
+	}
+	return nil
+}
+
+func (m *memInterestRepo) SetCapitalizationTransactions(ctx context.Context, id uuid.UUID, interestTxID uuid.UUID, taxTxID *uuid.UUID) error {
+	for _, c := range m.capitalizations {
+		if c.ID == id {
+			c.InterestTxID = &interestTxID
+			c.TaxTxID = taxTxID
+		}
+	}
+	return nil
+}
+
+func (m *memInterestRepo) ListCapitalizations(ctx context.Context, accountID uuid.UUID) ([]*entity.InterestCapitalization, error) {
+	var out []*entity.InterestCapitalization
+	for _, c := range m.capitalizations {
+		if c.AccountID == accountID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (m *memInterestRepo) SaveRun(ctx context.Context, run *entity.InterestAccrualRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[run.RunDate] = run
+	return nil
+}
+
+func (m *memInterestRepo) FindRun(ctx context.Context, runDate time.Time) (*entity.InterestAccrualRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.runs[runDate], nil
+}
+
+type recordingInterestDispatcher struct {
+	tasks []InterestAccrualTask
+}
+
+func (r *recordingInterestDispatcher) Dispatch(ctx context.Context, task InterestAccrualTask) error {
+	r.tasks = append(r.tasks, task)
+	return nil
+}
+
+func interestDate(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func setupInterest(t *testing.T, balance float64) (*InterestService, *memTxnRepo, *memWalletRepo, *time.Time, uuid.UUID) {
+	t.Helper()
+	_, txr, wr, uid := setupService(t, balance)
+	repo := newMemInterestRepo()
+	now := time.Date(2025, 1, 29, 10, 0, 0, 0, time.UTC)
+	svc := NewInterestService(repo, txr, wr, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()).
+		WithLedger(newMemStatementRepo(txr))
+	svc.now = func() time.Time { return now }
+
+	// 36,5% a.a. em ACT/365 = 0,1% ao dia
+	typ, err := entity.NewInterestAccountType("savings", "Poupança", entity.DayCountACT365, []entity.RateStep{
+		{EffectiveFrom: interestDate(2025, 1, 1), AnnualRate: decimal.RequireFromString("0.365")},
+	}, decimal.Zero, decimal.RequireFromString("0.15"), now)
+	if err != nil {
+		t.Fatalf("tipo: %v", err)
+	}
+	if _, err := svc.DefineType(context.Background(), typ); err != nil {
+		t.Fatalf("define tipo: %v", err)
+	}
+	return svc, txr, wr, &now, uid
+}
+
+func txnsOfType(txr *memTxnRepo, typ entity.TransactionType) []*entity.Transaction {
+	var out []*entity.Transaction
+	for _, tx := range txr.txs {
+		if tx.Type == typ {
+			out = append(out, tx)
+		}
+	}
+	return out
+}
+
+func TestInterestService_AccrueAndCapitalizeMonthEnd(t *testing.T) {
+	svc, txr, wr, now, uid := setupInterest(t, 1000)
+	ctx := context.Background()
+
+	account, err := svc.Open(ctx, uid, "savings")
+	if err != nil {
+		t.Fatalf("abrir conta: %v", err)
+	}
+	if _, err := svc.Open(ctx, uid, "savings"); !errors.Is(err, ErrInterestAccountExists) {
+		t.Fatalf("esperado ErrInterestAccountExists, obtido %v", err)
+	}
+
+	// dias 29 e 30 rendem 1,00 cada; sem capitalização antes do fim do mês
+	*now = time.Date(2025, 1, 31, 3, 0, 0, 0, time.UTC)
+	run, err := svc.AccrueDay(ctx, svc.Yesterday())
+	if err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+	if run.Accrued != 2 || run.Capitalizations != 0 || !run.Total.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("esperado 2 rendimentos somando 2 sem capitalização, obtido %+v", run)
+	}
+	stored, _ := svc.Account(ctx, uid)
+	if !stored.AccruedUnpaid.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("esperado pendente 2, obtido %s", stored.AccruedUnpaid)
+	}
+
+	// reexecutar o mesmo dia não duplica
+	if run, _ = svc.AccrueDay(ctx, svc.Yesterday()); run.Accrued != 0 {
+		t.Fatalf("reexecução não deveria apropriar, obtido %d", run.Accrued)
+	}
+
+	// dia 31 fecha o mês: bruto 3,00, IR 15% = 0,45, líquido 2,55
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	if run, err = svc.AccrueDay(ctx, svc.Yesterday()); err != nil || run.Capitalizations != 1 {
+		t.Fatalf("esperada capitalização do mês, obtido %+v err=%v", run, err)
+	}
+	if got := balanceOf(t, wr, uid); got != 1002.55 {
+		t.Fatalf("esperado saldo 1002.55, obtido %v", got)
+	}
+	interest := txnsOfType(txr, entity.TransactionTypeInterest)
+	tax := txnsOfType(txr, entity.TransactionTypeWithholdingTax)
+	if len(interest) != 1 || len(tax) != 1 {
+		t.Fatalf("esperadas 1 transação de rendimento e 1 de IR, obtidas %d e %d", len(interest), len(tax))
+	}
+	if !interest[0].Amount.Equal(decimal.NewFromInt(3)) || interest[0].Status != entity.TransactionStatusCompleted {
+		t.Fatalf("rendimento inesperado: %s %s", interest[0].Amount, interest[0].Status)
+	}
+	if !tax[0].Amount.Equal(decimal.RequireFromString("0.45")) || tax[0].ParentID == nil || *tax[0].ParentID != interest[0].ID {
+		t.Fatalf("IR inesperado: %s parent=%v", tax[0].Amount, tax[0].ParentID)
+	}
+
+	history, err := svc.History(ctx, uid, interestDate(2025, 1, 1), interestDate(2025, 1, 31))
+	if err != nil {
+		t.Fatalf("histórico: %v", err)
+	}
+	if len(history.Accruals) != 3 || !history.Total.Equal(decimal.NewFromInt(3)) || len(history.Capitalizations) != 1 {
+		t.Fatalf("histórico inesperado: %d rendimentos, total %s, %d capitalizações", len(history.Accruals), history.Total, len(history.Capitalizations))
+	}
+	c := history.Capitalizations[0]
+	if c.Period != "2025-01" || c.InterestTxID == nil || c.TaxTxID == nil || !c.Net.Equal(decimal.RequireFromString("2.55")) {
+		t.Fatalf("capitalização inesperada: %+v", c)
+	}
+	if !history.Account.AccruedUnpaid.IsZero() || account.ID != history.Account.ID {
+		t.Fatalf("pendente deveria zerar após capitalizar, obtido %s", history.Account.AccruedUnpaid)
+	}
+}
+
+func TestInterestService_CatchUpAcrossMonthEnd(t *testing.T) {
+	svc, _, wr, now, uid := setupInterest(t, 1000)
+	ctx := context.Background()
+	if _, err := svc.Open(ctx, uid, "savings"); err != nil {
+		t.Fatalf("abrir conta: %v", err)
+	}
+
+	// execuções perdidas: de 29/01 a 02/02 em uma única apropriação
+	*now = time.Date(2025, 2, 3, 3, 0, 0, 0, time.UTC)
+	run, err := svc.AccrueDay(ctx, svc.Yesterday())
+	if err != nil {
+		t.Fatalf("apropriação: %v", err)
+	}
+	if run.Accrued != 5 || run.Capitalizations != 1 {
+		t.Fatalf("esperados 5 rendimentos e 1 capitalização, obtido %+v", run)
+	}
+	if got := balanceOf(t, wr, uid); got != 1002.55 {
+		t.Fatalf("esperado saldo 1002.55, obtido %v", got)
+	}
+	account, _ := svc.Account(ctx, uid)
+	// fevereiro rende sobre o saldo já capitalizado: 2 x 1,00255
+	if !account.AccruedUnpaid.Equal(decimal.RequireFromString("2.0051")) {
+		t.Fatalf("esperado pendente 2.0051, obtido %s", account.AccruedUnpaid)
+	}
+}
+
+func TestInterestService_CatchUpUsesDailyBalances(t *testing.T) {
+	svc, txr, wr, now, uid := setupInterest(t, 1000)
+	ctx := context.Background()
+	if _, err := svc.Open(ctx, uid, "savings"); err != nil {
+		t.Fatalf("abrir conta: %v", err)
+	}
+
+	// depósito de 500 em 31/01: os dias 29 e 30 rendem sobre 1000, o dia 31 sobre 1500
+	deposit := entity.NewTransaction(uid, entity.TransactionTypeDeposit, decimal.NewFromInt(500))
+	deposit.ToAddress = "ADDR"
+	deposit.Complete("dep-1")
+	deposit.CreatedAt = time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	_ = txr.Create(ctx, deposit)
+	wr.wallets[uid].Balance = 1500
+
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	run, err := svc.AccrueDay(ctx, svc.Yesterday())
+	if err != nil || run.Accrued != 3 || run.Capitalizations != 1 {
+		t.Fatalf("esperados 3 rendimentos e 1 capitalização, obtido %+v err=%v", run, err)
+	}
+	if !run.Total.Equal(decimal.RequireFromString("3.5")) {
+		t.Fatalf("esperado total 3.5 (1 + 1 + 1,5), obtido %s", run.Total)
+	}
+}
+
+func TestInterestService_CatchUpWithoutLedgerSkipsPastDays(t *testing.T) {
+	svc, _, _, now, uid := setupInterest(t, 1000)
+	svc.WithLedger(nil)
+	ctx := context.Background()
+	if _, err := svc.Open(ctx, uid, "savings"); err != nil {
+		t.Fatalf("abrir conta: %v", err)
+	}
+
+	// sem o razão o saldo atual não é aplicado a 29 e 30/01: só o dia 31 rende
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	run, err := svc.AccrueDay(ctx, svc.Yesterday())
+	if err != nil || run.Accrued != 1 || run.Capitalizations != 1 {
+		t.Fatalf("esperado 1 rendimento e 1 capitalização, obtido %+v err=%v", run, err)
+	}
+	account, _ := svc.Account(ctx, uid)
+	if account.LastAccrualDate == nil || !account.LastAccrualDate.Equal(interestDate(2025, 1, 31)) {
+		t.Fatalf("os dias em atraso deveriam ficar marcados como apropriados, obtido %v", account.LastAccrualDate)
+	}
+}
+
+// toggleCreditWalletRepo recusa a atualização de saldo enquanto fail estiver ligado
+type toggleCreditWalletRepo struct {
+	*memWalletRepo
+	fail bool
+}
+
+func (r *toggleCreditWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID, balance float64) error {
+	if r.fail {
+		return errors.New("wallet unavailable")
+	}
+	return r.memWalletRepo.UpdateBalance(ctx, userID, balance)
+}
+
+func (r *toggleCreditWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	if r.fail {
+		return errors.New("wallet unavailable")
+	}
+	return r.memWalletRepo.AdjustBalance(ctx, userID, delta, overdraftLimit)
+}
+
+func TestInterestService_CapitalizationUndoneWhenCreditFails(t *testing.T) {
+	svc, txr, wr, now, uid := setupInterest(t, 1000)
+	ctx := context.Background()
+	if _, err := svc.Open(ctx, uid, "savings"); err != nil {
+		t.Fatalf("abrir conta: %v", err)
+	}
+	failing := &toggleCreditWalletRepo{memWalletRepo: wr, fail: true}
+	svc.walletRepo = failing
+
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	run, err := svc.AccrueDay(ctx, svc.Yesterday())
+	if err != nil || run.Capitalizations != 0 || run.Failures != 1 {
+		t.Fatalf("esperada falha sem capitalização, obtido %+v err=%v", run, err)
+	}
+	account, _ := svc.Account(ctx, uid)
+	if !account.AccruedUnpaid.Equal(decimal.NewFromInt(3)) {
+		t.Fatalf("rendimento deveria continuar pendente, obtido %s", account.AccruedUnpaid)
+	}
+	history, _ := svc.History(ctx, uid, interestDate(2025, 1, 1), interestDate(2025, 1, 31))
+	if len(history.Capitalizations) != 0 {
+		t.Fatalf("capitalização sem crédito deveria ser desfeita, obtido %d", len(history.Capitalizations))
+	}
+
+	// com a carteira de volta o pendente é capitalizado no fechamento da conta
+	failing.fail = false
+	if _, err := svc.Close(ctx, uid); err != nil {
+		t.Fatalf("encerrar: %v", err)
+	}
+	if got := balanceOf(t, wr, uid); got != 1002.55 {
+		t.Fatalf("esperado saldo 1002.55, obtido %v", got)
+	}
+	completed := 0
+	for _, tx := range txnsOfType(txr, entity.TransactionTypeInterest) {
+		if tx.Status == entity.TransactionStatusCompleted {
+			completed++
+		}
+	}
+	if completed != 1 {
+		t.Fatalf("esperada 1 transação de rendimento concluída, obtidas %d", completed)
+	}
+}
+
+func TestInterestService_CloseCapitalizesPending(t *testing.T) {
+	svc, txr, wr, now, uid := setupInterest(t, 1000)
+	ctx := context.Background()
+	if _, err := svc.Open(ctx, uid, "savings"); err != nil {
+		t.Fatalf("abrir conta: %v", err)
+	}
+
+	*now = time.Date(2025, 1, 30, 15, 0, 0, 0, time.UTC)
+	account, err := svc.Close(ctx, uid)
+	if err != nil {
+		t.Fatalf("encerrar: %v", err)
+	}
+	if account.Status != entity.InterestAccountClosed || !account.AccruedUnpaid.IsZero() {
+		t.Fatalf("conta deveria estar encerrada sem pendente, obtido %s %s", account.Status, account.AccruedUnpaid)
+	}
+	if got := balanceOf(t, wr, uid); got != 1000.85 {
+		t.Fatalf("esperado saldo 1000.85 (1,00 - 15%%), obtido %v", got)
+	}
+	if len(txnsOfType(txr, entity.TransactionTypeInterest)) != 1 {
+		t.Fatal("esperada transação de rendimento no encerramento")
+	}
+	if _, err := svc.Account(ctx, uid); !errors.Is(err, ErrInterestAccountNotFound) {
+		t.Fatalf("esperado ErrInterestAccountNotFound, obtido %v", err)
+	}
+	if run, _ := svc.AccrueDay(ctx, interestDate(2025, 1, 31)); run.Accounts != 0 {
+		t.Fatalf("conta encerrada não deveria render, obtido %d contas", run.Accounts)
+	}
+}
+
+func TestInterestService_DispatchDaily(t *testing.T) {
+	svc, _, _, now, _ := setupInterest(t, 1000)
+	ctx := context.Background()
+	dispatcher := &recordingInterestDispatcher{}
+	svc.WithDispatcher(dispatcher)
+
+	*now = time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC)
+	if dispatched, err := svc.DispatchDaily(ctx); err != nil || !dispatched {
+		t.Fatalf("esperado disparo, obtido %v err=%v", dispatched, err)
+	}
+	if len(dispatcher.tasks) != 1 || dispatcher.tasks[0].TaskID() != "interest:2025-01-31" {
+		t.Fatalf("tarefa inesperada: %+v", dispatcher.tasks)
+	}
+
+	svc.WithDispatcher(nil)
+	if dispatched, err := svc.DispatchDaily(ctx); err != nil || !dispatched {
+		t.Fatalf("sem dispatcher deveria executar direto, obtido %v err=%v", dispatched, err)
+	}
+	if dispatched, _ := svc.DispatchDaily(ctx); dispatched {
+		t.Fatal("dia já apropriado não deveria ser disparado de novo")
+	}
+}
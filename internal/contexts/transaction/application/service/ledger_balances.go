@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// dayRange dias de start a through, inclusive (start e through já no início do dia)
+func dayRange(start, through time.Time) []time.Time {
+	var days []time.Time
+	for day := start; !day.After(through); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// closingBalances reconstrói o saldo de fechamento da carteira em cada dia a partir do saldo atual,
+// desfazendo as movimentações do razão registradas depois do fim de cada dia
+func closingBalances(ctx context.Context, statements repository.StatementRepository, userID uuid.UUID, wallet *userEntity.Wallet, days []time.Time, now time.Time) ([]decimal.Decimal, error) {
+	if len(days) == 0 {
+		return nil, nil
+	}
+	txs, err := statements.ListMovements(ctx, userID, wallet.Address, days[0].AddDate(0, 0, 1), now)
+	if err != nil {
+		return nil, err
+	}
+	var lines []entity.StatementLine
+	for _, tx := range txs {
+		lines = append(lines, entity.StatementLines(tx, userID, wallet.Address)...)
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].Date.Before(lines[j].Date) })
+
+	balances := make([]decimal.Decimal, len(days))
+	balance := decimal.NewFromFloat(wallet.Balance)
+	next := len(lines) - 1
+	for i := len(days) - 1; i >= 0; i-- {
+		end := days[i].AddDate(0, 0, 1)
+		for ; next >= 0 && !lines[next].Date.Before(end); next-- {
+			balance = balance.Sub(lines[next].Amount)
+		}
+		balances[i] = balance
+	}
+	return balances, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TierLimit limites acumulados de saída (saques, transferências, custódia e aportes em contas) de um nível KYC.
+// Limite zero bloqueia a operação.
+type TierLimit struct {
+	Daily   decimal.Decimal
+	Monthly decimal.Decimal
+}
+
+// LimitPolicy associa cada nível KYC aos seus limites. Níveis ausentes ficam bloqueados.
+type LimitPolicy map[userEntity.KYCLevel]TierLimit
+
+// DefaultLimitPolicy limites padrão em BRL por nível KYC
+func DefaultLimitPolicy() LimitPolicy {
+	return LimitPolicy{
+		userEntity.KYCLevelNone:     {Daily: decimal.NewFromInt(500), Monthly: decimal.NewFromInt(2000)},
+		userEntity.KYCLevelBasic:    {Daily: decimal.NewFromInt(5000), Monthly: decimal.NewFromInt(20000)},
+		userEntity.KYCLevelVerified: {Daily: decimal.NewFromInt(50000), Monthly: decimal.NewFromInt(200000)},
+		userEntity.KYCLevelEnhanced: {Daily: decimal.NewFromInt(1000000), Monthly: decimal.NewFromInt(5000000)},
+	}
+}
+
+// LimitExceededError detalha qual limite foi ultrapassado
+type LimitExceededError struct {
+	Level     userEntity.KYCLevel
+	Period    string // daily, monthly
+	Limit     decimal.Decimal
+	Used      decimal.Decimal
+	Requested decimal.Decimal
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%v: %s limit %s for kyc level %s (used %s, requested %s)",
+		ErrLimitExceeded, e.Period, e.Limit, e.Level, e.Used, e.Requested)
+}
+
+func (e *LimitExceededError) Unwrap() error { return ErrLimitExceeded }
+
+// WithLimits habilita a verificação de limites por nível KYC antes de saques e transferências
+func (s *TransactionService) WithLimits(policy LimitPolicy) *TransactionService {
+	s.limits = policy
+	return s
+}
+
+// outflowTransactionTypes transações que consomem os limites de saída
+var outflowTransactionTypes = []entity.TransactionType{
+	entity.TransactionTypeWithdraw,
+	entity.TransactionTypeTransfer,
+	entity.TransactionTypeEscrowFund,
+	entity.TransactionTypeAccountFunding,
+}
+
+// checkOutflowLimits soma as saídas não falhas do dia e do mês (UTC) no repositório e recusa a
+// operação se o novo valor ultrapassar os limites do nível do usuário.
+func (s *TransactionService) checkOutflowLimits(ctx context.Context, userID uuid.UUID, amount decimal.Decimal) error {
+	if s.limits == nil {
+		return nil
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	limit := s.limits[user.KYCLevel]
+
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	usedDay, err := s.txRepo.SumAmountSince(ctx, userID, outflowTransactionTypes, dayStart)
+	if err != nil {
+		return err
+	}
+	if usedDay.Add(amount).GreaterThan(limit.Daily) {
+		return &LimitExceededError{Level: user.KYCLevel, Period: "daily", Limit: limit.Daily, Used: usedDay, Requested: amount}
+	}
+	usedMonth, err := s.txRepo.SumAmountSince(ctx, userID, outflowTransactionTypes, monthStart)
+	if err != nil {
+		return err
+	}
+	if usedMonth.Add(amount).GreaterThan(limit.Monthly) {
+		return &LimitExceededError{Level: user.KYCLevel, Period: "monthly", Limit: limit.Monthly, Used: usedMonth, Requested: amount}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func testLimitPolicy() LimitPolicy {
+	return LimitPolicy{
+		userEntity.KYCLevelNone:  {Daily: decimal.NewFromInt(100), Monthly: decimal.NewFromInt(150)},
+		userEntity.KYCLevelBasic: {Daily: decimal.NewFromInt(1000), Monthly: decimal.NewFromInt(5000)},
+	}
+}
+
+func TestProcessWithdraw_DailyLimitByKYCLevel(t *testing.T) {
+	svc, _, _, uid := setupService(t, 1000)
+	svc.WithLimits(testLimitPolicy())
+	ctx := context.Background()
+
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(60)); err != nil {
+		t.Fatalf("primeiro saque deveria passar: %v", err)
+	}
+	err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(50))
+	var limitErr *LimitExceededError
+	if !errors.As(err, &limitErr) || !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("esperado LimitExceededError, obtido %v", err)
+	}
+	if limitErr.Period != "daily" || !limitErr.Used.Equal(decimal.NewFromInt(60)) {
+		t.Fatalf("detalhes inesperados: %+v", limitErr)
+	}
+
+	// Elevar o nível KYC libera o limite maior
+	user, _ := svc.userRepo.FindByID(ctx, uid)
+	user.RaiseKYCLevel(userEntity.KYCLevelBasic)
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(50)); err != nil {
+		t.Fatalf("saque deveria passar após upgrade: %v", err)
+	}
+}
+
+func TestProcessWithdraw_MonthlyLimitIgnoresFailedAndOldTransactions(t *testing.T) {
+	svc, txr, _, uid := setupService(t, 1000)
+	svc.WithLimits(testLimitPolicy())
+	ctx := context.Background()
+
+	old := entity.NewTransaction(uid, entity.TransactionTypeWithdraw, decimal.NewFromInt(140))
+	old.CreatedAt = time.Now().UTC().AddDate(0, -2, 0)
+	failed := entity.NewTransaction(uid, entity.TransactionTypeWithdraw, decimal.NewFromInt(140))
+	failed.Fail("boom")
+	_ = txr.Create(ctx, old)
+	_ = txr.Create(ctx, failed)
+
+	if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(50)); err != nil {
+		t.Fatalf("transações antigas/falhas não deveriam contar: %v", err)
+	}
+
+	// Transferência conta no mesmo limite de saída
+	earlier := entity.NewTransaction(uid, entity.TransactionTypeTransfer, decimal.NewFromInt(90))
+	earlier.CreatedAt = time.Now().UTC().AddDate(0, 0, -1)
+	if earlier.CreatedAt.Month() != time.Now().UTC().Month() {
+		t.Skip("início do mês: transação de ontem cai no mês anterior")
+	}
+	_ = txr.Create(ctx, earlier)
+	err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(20))
+	var limitErr *LimitExceededError
+	if !errors.As(err, &limitErr) || limitErr.Period != "monthly" {
+		t.Fatalf("esperado limite mensal, obtido %v", err)
+	}
+}
+
+func TestProcessTransfer(t *testing.T) {
+	svc, _, wr, uid := setupService(t, 100)
+	ctx := context.Background()
+	dest := uuid.New()
+	_ = svc.userRepo.Create(ctx, &userEntity.User{ID: dest, Email: "d@t.com", Password: "hash"})
+	_ = wr.Create(ctx, &userEntity.Wallet{UserID: dest, Address: "DEST", Balance: 0})
+
+	tx, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(30))
+	if err != nil {
+		t.Fatalf("transferência falhou: %v", err)
+	}
+	if tx.Status != entity.TransactionStatusCompleted || tx.ToAddress != "DEST" {
+		t.Fatalf("transação inesperada: %+v", tx)
+	}
+	from, _ := wr.FindByUserID(ctx, uid)
+	to, _ := wr.FindByUserID(ctx, dest)
+	if from.Balance != 70 || to.Balance != 30 {
+		t.Fatalf("saldos inesperados: origem %v destino %v", from.Balance, to.Balance)
+	}
+
+	if _, err := svc.ProcessTransfer(ctx, uid, uid, decimal.NewFromInt(1)); !errors.Is(err, ErrSameUserTransfer) {
+		t.Fatalf("esperado ErrSameUserTransfer, obtido %v", err)
+	}
+	if _, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(500)); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance, obtido %v", err)
+	}
+
+	svc.WithLimits(testLimitPolicy())
+	if _, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(80)); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("esperado ErrLimitExceeded, obtido %v", err)
+	}
+}
+
+// staleWalletRepo devolve sempre o saldo lido antes, como dois workers que leem a wallet ao mesmo tempo
+type staleWalletRepo struct {
+	*memWalletRepo
+	snapshot map[uuid.UUID]userEntity.Wallet
+}
+
+func (r *staleWalletRepo) FindByUserID(ctx context.Context, userID uuid.UUID) (*userEntity.Wallet, error) {
+	w, ok := r.snapshot[userID]
+	if !ok {
+		return r.memWalletRepo.FindByUserID(ctx, userID)
+	}
+	return &w, nil
+}
+
+func TestProcessTransfer_StaleReadDoesNotOverdraw(t *testing.T) {
+	svc, txr, wr, uid := setupService(t, 100)
+	ctx := context.Background()
+	dest := uuid.New()
+	_ = svc.userRepo.Create(ctx, &userEntity.User{ID: dest, Email: "d@t.com", Password: "hash"})
+	_ = wr.Create(ctx, &userEntity.Wallet{UserID: dest, Address: "DEST", Balance: 0})
+	svc.walletRepo = &staleWalletRepo{memWalletRepo: wr, snapshot: map[uuid.UUID]userEntity.Wallet{
+		uid:  *wr.wallets[uid],
+		dest: *wr.wallets[dest],
+	}}
+
+	if _, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(60)); err != nil {
+		t.Fatalf("primeira transferência falhou: %v", err)
+	}
+	if _, err := svc.ProcessTransfer(ctx, uid, dest, decimal.NewFromInt(60)); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance com saldo já debitado, obtido %v", err)
+	}
+	if from, to := wr.wallets[uid].Balance, wr.wallets[dest].Balance; from != 40 || to != 60 {
+		t.Fatalf("saldos inesperados: origem %v destino %v", from, to)
+	}
+	failed := 0
+	for _, tx := range txr.txs {
+		if tx.Status == entity.TransactionStatusFailed {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Fatalf("esperada 1 transferência falha, obtidas %d", failed)
+	}
+}
+
+func TestProcessWithdraw_EscrowFundingCountsTowardLimits(t *testing.T) {
+	svc, txr, _, uid := setupService(t, 1000)
+	svc.WithLimits(testLimitPolicy())
+	ctx := context.Background()
+
+	_ = txr.Create(ctx, entity.NewTransaction(uid, entity.TransactionTypeEscrowFund, decimal.NewFromInt(80)))
+	_ = txr.Create(ctx, entity.NewTransaction(uid, entity.TransactionTypeDeposit, decimal.NewFromInt(500)))
+
+	err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(30))
+	var limitErr *LimitExceededError
+	if !errors.As(err, &limitErr) || !limitErr.Used.Equal(decimal.NewFromInt(80)) {
+		t.Fatalf("esperado limite diário com 80 já usados em custódia, obtido %v", err)
+	}
+}
@@ -0,0 +1,472 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultPayoutMaxItems linhas aceitas por arquivo
+	DefaultPayoutMaxItems = 1000
+	// DefaultPayoutSweepInterval intervalo da varredura que reenfileira itens pendentes
+	DefaultPayoutSweepInterval = time.Minute
+)
+
+// ErrPayoutBatchNotFound lote inexistente ou de outro usuário
+var ErrPayoutBatchNotFound = errors.New("payout batch not found")
+
+// PayoutTask execução de um item do lote entregue à fila
+type PayoutTask struct {
+	BatchID uuid.UUID `json:"batch_id"`
+	ItemID  uuid.UUID `json:"item_id"`
+}
+
+// TaskID identificador estável do item, usado para deduplicar tarefas na fila
+func (t PayoutTask) TaskID() string {
+	return "payout:" + t.ItemID.String()
+}
+
+// PayoutDispatcher entrega os itens confirmados para processamento assíncrono (ex.: asynq)
+type PayoutDispatcher interface {
+	Dispatch(ctx context.Context, task PayoutTask) error
+}
+
+// PayoutPreview lote validado com os totais e a suficiência do saldo atual
+type PayoutPreview struct {
+	Batch      *entity.PayoutBatch  `json:"batch"`
+	Items      []*entity.PayoutItem `json:"items"`
+	Balance    decimal.Decimal      `json:"balance"`
+	Sufficient bool                 `json:"sufficient_balance"`
+}
+
+// PayoutService processa pagamentos em lote: o arquivo é validado por inteiro e precificado antes
+// da confirmação, e cada item é executado pela fila com o fluxo normal de transferência ou saque
+type PayoutService struct {
+	payouts    repository.PayoutRepository
+	txns       *TransactionService
+	dispatcher PayoutDispatcher
+	maxItems   int
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewPayoutService cria o serviço de lotes; sem dispatcher os itens são executados na confirmação
+func NewPayoutService(payouts repository.PayoutRepository, eventBus events.Bus, logger *zap.Logger) *PayoutService {
+	return &PayoutService{
+		payouts:  payouts,
+		maxItems: DefaultPayoutMaxItems,
+		eventBus: eventBus,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// WithDispatcher entrega os itens confirmados à fila
+func (s *PayoutService) WithDispatcher(dispatcher PayoutDispatcher) *PayoutService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithMaxItems define o número máximo de linhas por arquivo
+func (s *PayoutService) WithMaxItems(maxItems int) *PayoutService {
+	if maxItems > 0 {
+		s.maxItems = maxItems
+	}
+	return s
+}
+
+// Upload lê o arquivo, valida todas as linhas (destinatário, política de destino e taxa) e grava o lote
+// em draft. Lotes com linhas inválidas são gravados como rejected para consulta dos erros.
+func (s *PayoutService) Upload(ctx context.Context, userID uuid.UUID, format string, file io.Reader) (*PayoutPreview, error) {
+	if s.txns == nil {
+		return nil, errors.New("payout service is not attached to a transaction service")
+	}
+	rows, err := entity.ParsePayoutFile(strings.ToLower(format), file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows", entity.ErrInvalidPayoutFile)
+	}
+	if len(rows) > s.maxItems {
+		return nil, fmt.Errorf("%w: at most %d rows per file", entity.ErrInvalidPayoutFile, s.maxItems)
+	}
+
+	wallet, err := s.txns.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	batchID := uuid.New()
+	items := make([]*entity.PayoutItem, 0, len(rows))
+	for _, row := range rows {
+		item := entity.NewPayoutItem(batchID, row)
+		if item.Status == entity.PayoutItemValid {
+			if err := s.resolve(ctx, userID, wallet.Address, item); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, item)
+	}
+
+	batch := entity.NewPayoutBatch(batchID, userID, strings.ToLower(format), items, s.now())
+	if err := s.payouts.CreateBatch(ctx, batch, items); err != nil {
+		return nil, err
+	}
+	s.logger.Info("payout batch uploaded",
+		zap.String("batch_id", batch.ID.String()),
+		zap.String("user_id", userID.String()),
+		zap.String("status", string(batch.Status)),
+		zap.Int("items", batch.ItemCount),
+		zap.Int("invalid_rows", batch.InvalidRows),
+	)
+
+	balance := decimal.NewFromFloat(wallet.Balance)
+	return &PayoutPreview{
+		Batch:      batch,
+		Items:      items,
+		Balance:    balance,
+		Sufficient: balance.GreaterThanOrEqual(batch.Total()),
+	}, nil
+}
+
+// resolve confirma o destinatário e cota a taxa do item; problemas da linha invalidam o item e só
+// erros de infraestrutura são retornados
+func (s *PayoutService) resolve(ctx context.Context, userID uuid.UUID, fromAddress string, item *entity.PayoutItem) error {
+	req := FeeQuoteRequest{
+		UserID:      userID,
+		Type:        entity.TransactionTypeTransfer,
+		Chain:       InternalChain,
+		Asset:       string(sharedVO.BaseCurrency),
+		FromAddress: fromAddress,
+		Amount:      item.Amount,
+	}
+
+	switch item.Kind {
+	case entity.ScheduleKindTransfer:
+		if item.RecipientUserID == nil {
+			user, err := s.txns.userRepo.FindByEmail(ctx, item.Email)
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				item.Invalidate("recipient not found")
+				return nil
+			}
+			item.RecipientUserID = &user.ID
+		}
+		if *item.RecipientUserID == userID {
+			item.Invalidate(ErrSameUserTransfer.Error())
+			return nil
+		}
+		recipient, err := s.txns.walletRepo.FindByUserID(ctx, *item.RecipientUserID)
+		if err != nil {
+			return err
+		}
+		if recipient == nil {
+			item.Invalidate("recipient wallet not found")
+			return nil
+		}
+		req.ToAddress = recipient.Address
+	case entity.ScheduleKindWithdraw:
+		if s.txns.destinations != nil {
+			if err := s.txns.destinations.AuthorizeDestination(ctx, userID, item.Chain, item.Address); err != nil {
+				item.Invalidate(err.Error())
+				return nil
+			}
+		}
+		req.Type = entity.TransactionTypeWithdraw
+		req.Chain = item.Chain
+		req.ToAddress = item.Address
+	}
+
+	fee, err := s.txns.quoteFee(ctx, req)
+	if err != nil {
+		item.Invalidate(err.Error())
+		return nil
+	}
+	item.Fee = fee
+	return nil
+}
+
+// Get retorna o lote do usuário com seus itens
+func (s *PayoutService) Get(ctx context.Context, userID, id uuid.UUID) (*entity.PayoutBatch, []*entity.PayoutItem, error) {
+	batch, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := s.payouts.ListItems(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return batch, items, nil
+}
+
+// List lista os lotes do usuário
+func (s *PayoutService) List(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PayoutBatch, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.payouts.ListBatches(ctx, userID, limit)
+}
+
+// Confirm inicia a execução do lote em draft. O saldo precisa cobrir valores e taxas no momento da
+// confirmação; cada item ainda passa pelos limites e verificações normais ao ser executado.
+func (s *PayoutService) Confirm(ctx context.Context, userID, id uuid.UUID) (*entity.PayoutBatch, error) {
+	batch, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	switch batch.Status {
+	case entity.PayoutBatchDraft:
+	case entity.PayoutBatchRejected:
+		return nil, entity.ErrPayoutBatchInvalid
+	default:
+		return nil, entity.ErrPayoutBatchState
+	}
+
+	wallet, err := s.txns.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+	overdraft, err := s.txns.overdraftLimit(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	if !wallet.HasAvailableFunds(batch.Total().InexactFloat64(), overdraft) {
+		return nil, ErrInsufficientBalance
+	}
+
+	now := s.now()
+	if err := s.payouts.ConfirmBatch(ctx, id, now); err != nil {
+		return nil, err
+	}
+	batch.Status = entity.PayoutBatchProcessing
+	batch.ConfirmedAt = &now
+	s.logger.Info("payout batch confirmed",
+		zap.String("batch_id", batch.ID.String()),
+		zap.Int("items", batch.ItemCount),
+		zap.String("total", batch.Total().String()),
+	)
+
+	items, err := s.payouts.ListItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Status != entity.PayoutItemQueued {
+			continue
+		}
+		// Itens que falharem ao enfileirar são reenviados pela varredura
+		if err := s.dispatch(ctx, PayoutTask{BatchID: id, ItemID: item.ID}); err != nil {
+			s.logger.Error("failed to dispatch payout item", zap.String("item_id", item.ID.String()), zap.Error(err))
+		}
+	}
+	if s.dispatcher == nil {
+		if fresh, err := s.payouts.FindBatch(ctx, id); err == nil && fresh != nil {
+			batch = fresh
+		}
+	}
+	return batch, nil
+}
+
+// Cancel descarta o lote ainda não confirmado
+func (s *PayoutService) Cancel(ctx context.Context, userID, id uuid.UUID) (*entity.PayoutBatch, error) {
+	batch, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if err := s.payouts.CancelBatch(ctx, id, now); err != nil {
+		return nil, err
+	}
+	batch.Status = entity.PayoutBatchCancelled
+	batch.CompletedAt = &now
+	return batch, nil
+}
+
+// Report gera o relatório CSV com o resultado de cada item do lote
+func (s *PayoutService) Report(ctx context.Context, userID, id uuid.UUID) ([]byte, error) {
+	_, items, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := entity.WritePayoutReport(&buf, items); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Execute processa o item entregue pela fila. Itens já assumidos por outro worker são ignorados;
+// falhas da operação ficam registradas no item e só erros de infraestrutura são retornados.
+func (s *PayoutService) Execute(ctx context.Context, task PayoutTask) error {
+	if s.txns == nil {
+		return errors.New("payout service is not attached to a transaction service")
+	}
+	item, err := s.payouts.FindItem(ctx, task.ItemID)
+	if err != nil {
+		return err
+	}
+	if item == nil || item.Status != entity.PayoutItemQueued {
+		return nil
+	}
+	batch, err := s.payouts.FindBatch(ctx, item.BatchID)
+	if err != nil {
+		return err
+	}
+	if batch == nil || batch.Status != entity.PayoutBatchProcessing {
+		return nil
+	}
+	claimed, err := s.payouts.ClaimItem(ctx, item.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	tx, execErr := s.perform(ctx, batch.UserID, item)
+	var txID *uuid.UUID
+	if tx != nil {
+		txID = &tx.ID
+	}
+	item.Finish(txID, execErr, s.now())
+	if err := s.payouts.FinishItem(ctx, item); err != nil {
+		// O item fica em processing: a operação já foi feita e precisa de conciliação manual
+		s.logger.Error("failed to record payout item result",
+			zap.String("item_id", item.ID.String()),
+			zap.Stringp("tx_id", optionalID(txID)),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if execErr != nil {
+		s.logger.Warn("payout item failed",
+			zap.String("batch_id", item.BatchID.String()),
+			zap.Int("line", item.Line),
+			zap.Error(execErr),
+		)
+	}
+
+	finished, err := s.payouts.FinalizeBatch(ctx, item.BatchID, s.now())
+	if err != nil {
+		s.logger.Error("failed to finalize payout batch", zap.String("batch_id", item.BatchID.String()), zap.Error(err))
+		return nil
+	}
+	if finished != nil {
+		s.eventBus.PublishAsync(ctx, events.NewPayoutBatchCompletedEvent(
+			finished.ID, finished.UserID, string(finished.Status), finished.TotalAmount, finished.Succeeded, finished.Failed,
+		))
+		s.logger.Info("payout batch completed",
+			zap.String("batch_id", finished.ID.String()),
+			zap.String("status", string(finished.Status)),
+			zap.Int("succeeded", finished.Succeeded),
+			zap.Int("failed", finished.Failed),
+		)
+	}
+	return nil
+}
+
+// perform executa o item pelo fluxo normal (limites, triagem, taxas e aprovações)
+func (s *PayoutService) perform(ctx context.Context, userID uuid.UUID, item *entity.PayoutItem) (*entity.Transaction, error) {
+	switch item.Kind {
+	case entity.ScheduleKindTransfer:
+		return s.txns.ProcessTransfer(ctx, userID, *item.RecipientUserID, item.Amount)
+	case entity.ScheduleKindWithdraw:
+		return s.txns.ProcessWithdrawTo(ctx, userID, item.Amount, item.Chain, item.Address)
+	}
+	return nil, fmt.Errorf("unknown payout type %q", item.Kind)
+}
+
+// DispatchQueued reenvia à fila (ou executa, sem dispatcher) os itens confirmados ainda pendentes
+func (s *PayoutService) DispatchQueued(ctx context.Context) (int, error) {
+	items, err := s.payouts.ListQueuedItems(ctx, 500)
+	if err != nil {
+		return 0, err
+	}
+	dispatched := 0
+	for _, item := range items {
+		if err := s.dispatch(ctx, PayoutTask{BatchID: item.BatchID, ItemID: item.ID}); err != nil {
+			s.logger.Error("failed to dispatch payout item", zap.String("item_id", item.ID.String()), zap.Error(err))
+			continue
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+// Run varre os itens pendentes a cada intervalo até o contexto ser cancelado
+func (s *PayoutService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPayoutSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchQueued(ctx); err != nil {
+				s.logger.Error("payout sweep failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *PayoutService) dispatch(ctx context.Context, task PayoutTask) error {
+	if s.dispatcher == nil {
+		return s.Execute(ctx, task)
+	}
+	return s.dispatcher.Dispatch(ctx, task)
+}
+
+func (s *PayoutService) find(ctx context.Context, userID, id uuid.UUID) (*entity.PayoutBatch, error) {
+	batch, err := s.payouts.FindBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil || batch.UserID != userID {
+		return nil, ErrPayoutBatchNotFound
+	}
+	return batch, nil
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// WithPayouts habilita pagamentos em lote executados por este serviço
+func (s *TransactionService) WithPayouts(payouts *PayoutService) *TransactionService {
+	s.payouts = payouts
+	payouts.txns = s
+	return s
+}
+
+// Payouts retorna o serviço de pagamentos em lote (nil se desabilitado)
+func (s *TransactionService) Payouts() *PayoutService {
+	return s.payouts
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memPayoutRepo struct {
+	mu      sync.Mutex
+	batches map[uuid.UUID]*entity.PayoutBatch
+	items   map[uuid.UUID]*entity.PayoutItem
+}
+
+func newMemPayoutRepo() *memPayoutRepo {
+	return &memPayoutRepo{
+		batches: make(map[uuid.UUID]*entity.PayoutBatch),
+		items:   make(map[uuid.UUID]*entity.PayoutItem),
+	}
+}
+
+func copyPayoutBatch(b *entity.PayoutBatch) *entity.PayoutBatch { cp := *b; return &cp }
+func copyPayoutItem(i *entity.PayoutItem) *entity.PayoutItem    { cp := *i; return &cp }
+
+func (m *memPayoutRepo) CreateBatch(ctx context.Context, b *entity.PayoutBatch, items []*entity.PayoutItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches[b.ID] = copyPayoutBatch(b)
+	for _, item := range items {
+		m.items[item.ID] = copyPayoutItem(item)
+	}
+	return nil
+}
+
+func (m *memPayoutRepo) FindBatch(ctx context.Context, id uuid.UUID) (*entity.PayoutBatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if b, ok := m.batches[id]; ok {
+		return copyPayoutBatch(b), nil
+	}
+	return nil, nil
+}
+
+func (m *memPayoutRepo) ListBatches(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PayoutBatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PayoutBatch
+	for _, b := range m.batches {
+		if b.UserID == userID && len(out) < limit {
+			out = append(out, copyPayoutBatch(b))
+		}
+	}
+	return out, nil
+}
+
+func (m *memPayoutRepo) ListItems(ctx context.Context, batchID uuid.UUID) ([]*entity.PayoutItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PayoutItem
+	for _, item := range m.items {
+		if item.BatchID == batchID {
+			out = append(out, copyPayoutItem(item))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Line < out[j].Line })
+	return out, nil
+}
+
+func (m *memPayoutRepo) FindItem(ctx context.Context, id uuid.UUID) (*entity.PayoutItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if item, ok := m.items[id]; ok {
+		return copyPayoutItem(item), nil
+	}
+	return nil, nil
+}
+
+func (m *memPayoutRepo) ConfirmBatch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	return m.transition(id, entity.PayoutBatchProcessing, entity.PayoutItemQueued, now)
+}
+
+func (m *memPayoutRepo) CancelBatch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	return m.transition(id, entity.PayoutBatchCancelled, entity.PayoutItemCancelled, now)
+}
+
+func (m *memPayoutRepo) transition(id uuid.UUID, to entity.PayoutBatchStatus, itemStatus entity.PayoutItemStatus, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.batches[id]
+	if !ok || b.Status != entity.PayoutBatchDraft {
+		return entity.ErrPayoutBatchState
+	}
+	b.Status = to
+	if to == entity.PayoutBatchProcessing {
+		b.ConfirmedAt = &now
+	} else {
+		b.CompletedAt = &now
+	}
+	for _, item := range m.items {
+		if item.BatchID == id && item.Status == entity.PayoutItemValid {
+			item.Status = itemStatus
+		}
+	}
+	return nil
+}
+
+func (m *memPayoutRepo) ListQueuedItems(ctx context.Context, limit int) ([]*entity.PayoutItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PayoutItem
+	for _, item := range m.items {
+		if item.Status == entity.PayoutItemQueued && m.batches[item.BatchID].Status == entity.PayoutBatchProcessing && len(out) < limit {
+			out = append(out, copyPayoutItem(item))
+		}
+	}
+	return out, nil
+}
+
+func (m *memPayoutRepo) ClaimItem(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.items[id]
+	if !ok || item.Status != entity.PayoutItemQueued {
+		return false, nil
+	}
+	item.Status = entity.PayoutItemProcessing
+	return true, nil
+}
+
+func (m *memPayoutRepo) FinishItem(ctx context.Context, item *entity.PayoutItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur, ok := m.items[item.ID]; ok && cur.Status == entity.PayoutItemProcessing {
+		m.items[item.ID] = copyPayoutItem(item)
+	}
+	return nil
+}
+
+func (m *memPayoutRepo) FinalizeBatch(ctx context.Context, id uuid.UUID, now time.Time) (*entity.PayoutBatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.batches[id]
+	if !ok || b.Status != entity.PayoutBatchProcessing {
+		return nil, nil
+	}
+	succeeded, failed := 0, 0
+	for _, item := range m.items {
+		if item.BatchID != id {
+			continue
+		}
+		switch item.Status {
+		case entity.PayoutItemQueued, entity.PayoutItemProcessing:
+			return nil, nil
+		case entity.PayoutItemSucceeded:
+			succeeded++
+		case entity.PayoutItemFailed:
+			failed++
+		}
+	}
+	b.Succeeded, b.Failed = succeeded, failed
+	b.Status = entity.PayoutBatchCompleted
+	if failed > 0 {
+		b.Status = entity.PayoutBatchCompletedWithErrors
+	}
+	b.CompletedAt = &now
+	return copyPayoutBatch(b), nil
+}
+
+type recordingPayoutDispatcher struct {
+	tasks []PayoutTask
+}
+
+func (r *recordingPayoutDispatcher) Dispatch(ctx context.Context, task PayoutTask) error {
+	r.tasks = append(r.tasks, task)
+	return nil
+}
+
+func setupPayouts(t *testing.T, balance float64) (*PayoutService, *memPayoutRepo, *memWalletRepo, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	svc, _, wr, payer := setupService(t, balance)
+	recipient := uuid.New()
+	_ = svc.userRepo.Create(context.Background(), &userEntity.User{ID: recipient, Email: "r@t.com", Password: "hash"})
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: recipient, Address: "RECIPIENT"})
+
+	repo := newMemPayoutRepo()
+	payouts := NewPayoutService(repo, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	svc.WithPayouts(payouts)
+	return payouts, repo, wr, payer, recipient
+}
+
+func TestPayoutService_UploadPreviewAndExecuteInline(t *testing.T) {
+	payouts, repo, wr, payer, recipient := setupPayouts(t, 100)
+	ctx := context.Background()
+
+	file := "email,user_id,chain,address,amount,reference\n" +
+		"r@t.com,,,,10,invoice 1\n" +
+		"," + recipient.String() + ",,,15,\n" +
+		",,ethereum,0xabc,20,\n"
+	preview, err := payouts.Upload(ctx, payer, entity.PayoutFormatCSV, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("upload falhou: %v", err)
+	}
+	if preview.Batch.Status != entity.PayoutBatchDraft || preview.Batch.ItemCount != 3 {
+		t.Fatalf("lote inesperado: %+v", preview.Batch)
+	}
+	if !preview.Batch.TotalAmount.Equal(decimal.NewFromInt(45)) || !preview.Sufficient {
+		t.Fatalf("prévia inesperada: total %s suficiente %v", preview.Batch.TotalAmount, preview.Sufficient)
+	}
+	if *preview.Items[0].RecipientUserID != recipient {
+		t.Fatalf("e-mail deveria resolver o destinatário")
+	}
+	if balanceOf(t, wr, payer) != 100 {
+		t.Fatalf("a prévia não deve movimentar saldo")
+	}
+
+	batch, err := payouts.Confirm(ctx, payer, preview.Batch.ID)
+	if err != nil {
+		t.Fatalf("confirmação falhou: %v", err)
+	}
+	if batch.Status != entity.PayoutBatchCompleted || batch.Succeeded != 3 {
+		t.Fatalf("sem dispatcher o lote deveria concluir na confirmação: %+v", batch)
+	}
+	if balanceOf(t, wr, payer) != 55 || balanceOf(t, wr, recipient) != 25 {
+		t.Fatalf("saldos inesperados: pagador %v destinatário %v", balanceOf(t, wr, payer), balanceOf(t, wr, recipient))
+	}
+
+	items, _ := repo.ListItems(ctx, batch.ID)
+	for _, item := range items {
+		if item.Status != entity.PayoutItemSucceeded || item.TransactionID == nil {
+			t.Fatalf("item %d sem sucesso: %+v", item.Line, item)
+		}
+	}
+
+	report, err := payouts.Report(ctx, payer, batch.ID)
+	if err != nil {
+		t.Fatalf("relatório falhou: %v", err)
+	}
+	if lines := strings.Count(string(report), "\n"); lines != 4 {
+		t.Fatalf("relatório deveria ter cabeçalho e 3 linhas, obtido %d", lines)
+	}
+	if _, err := payouts.Report(ctx, uuid.New(), batch.ID); !errors.Is(err, ErrPayoutBatchNotFound) {
+		t.Fatalf("outro usuário não deveria ver o lote: %v", err)
+	}
+}
+
+func TestPayoutService_InvalidRowsRejectBatch(t *testing.T) {
+	payouts, _, _, payer, _ := setupPayouts(t, 100)
+	ctx := context.Background()
+
+	file := `[
+		{"email": "r@t.com", "amount": "5"},
+		{"email": "unknown@t.com", "amount": "5"},
+		{"email": "t@t.com", "amount": "5"},
+		{"amount": "5"}
+	]`
+	preview, err := payouts.Upload(ctx, payer, entity.PayoutFormatJSON, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("upload falhou: %v", err)
+	}
+	if preview.Batch.Status != entity.PayoutBatchRejected || preview.Batch.InvalidRows != 3 {
+		t.Fatalf("lote deveria ser rejeitado com 3 linhas inválidas: %+v", preview.Batch)
+	}
+	if preview.Items[1].Error != "recipient not found" || preview.Items[2].Error != ErrSameUserTransfer.Error() {
+		t.Fatalf("erros por linha inesperados: %q, %q", preview.Items[1].Error, preview.Items[2].Error)
+	}
+	if _, err := payouts.Confirm(ctx, payer, preview.Batch.ID); !errors.Is(err, entity.ErrPayoutBatchInvalid) {
+		t.Fatalf("lote rejeitado não pode ser confirmado: %v", err)
+	}
+}
+
+func TestPayoutService_ConfirmRequiresBalance(t *testing.T) {
+	payouts, _, _, payer, _ := setupPayouts(t, 10)
+	ctx := context.Background()
+
+	preview, err := payouts.Upload(ctx, payer, entity.PayoutFormatCSV, strings.NewReader("email,amount\nr@t.com,8\nr@t.com,8\n"))
+	if err != nil {
+		t.Fatalf("upload falhou: %v", err)
+	}
+	if preview.Sufficient {
+		t.Fatalf("prévia deveria indicar saldo insuficiente")
+	}
+	if _, err := payouts.Confirm(ctx, payer, preview.Batch.ID); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado saldo insuficiente, obtido %v", err)
+	}
+	if _, err := payouts.Cancel(ctx, payer, preview.Batch.ID); err != nil {
+		t.Fatalf("cancelamento falhou: %v", err)
+	}
+	if _, err := payouts.Confirm(ctx, payer, preview.Batch.ID); !errors.Is(err, entity.ErrPayoutBatchState) {
+		t.Fatalf("lote cancelado não pode ser confirmado: %v", err)
+	}
+}
+
+func TestPayoutService_QueuedExecutionIsIdempotent(t *testing.T) {
+	payouts, repo, wr, payer, recipient := setupPayouts(t, 100)
+	ctx := context.Background()
+	dispatcher := &recordingPayoutDispatcher{}
+	payouts.WithDispatcher(dispatcher)
+
+	preview, _ := payouts.Upload(ctx, payer, entity.PayoutFormatCSV, strings.NewReader("email,amount\nr@t.com,10\nr@t.com,85\n"))
+	batch, err := payouts.Confirm(ctx, payer, preview.Batch.ID)
+	if err != nil {
+		t.Fatalf("confirmação falhou: %v", err)
+	}
+	if batch.Status != entity.PayoutBatchProcessing || len(dispatcher.tasks) != 2 {
+		t.Fatalf("itens deveriam ir para a fila: status %s tarefas %d", batch.Status, len(dispatcher.tasks))
+	}
+
+	// A varredura reenvia os itens ainda pendentes
+	if n, _ := payouts.DispatchQueued(ctx); n != 2 {
+		t.Fatalf("varredura deveria reenviar os 2 itens pendentes, reenviou %d", n)
+	}
+	// O saldo muda entre a confirmação e a execução
+	_ = wr.UpdateBalance(ctx, payer, 50)
+
+	// Entregas duplicadas executam o item uma única vez
+	for _, task := range dispatcher.tasks {
+		if err := payouts.Execute(ctx, task); err != nil {
+			t.Fatalf("execução falhou: %v", err)
+		}
+	}
+	if balanceOf(t, wr, recipient) != 10 || balanceOf(t, wr, payer) != 40 {
+		t.Fatalf("saldos inesperados: pagador %v destinatário %v", balanceOf(t, wr, payer), balanceOf(t, wr, recipient))
+	}
+
+	final, _ := repo.FindBatch(ctx, batch.ID)
+	if final.Status != entity.PayoutBatchCompletedWithErrors || final.Succeeded != 1 || final.Failed != 1 {
+		t.Fatalf("lote deveria concluir com 1 falha: %+v", final)
+	}
+	items, _ := repo.ListItems(ctx, batch.ID)
+	if items[1].Status != entity.PayoutItemFailed || items[1].Error != ErrInsufficientBalance.Error() {
+		t.Fatalf("segundo item deveria falhar por saldo: %+v", items[1])
+	}
+}
+
+func TestPayoutService_UploadPain001(t *testing.T) {
+	payouts, _, _, payer, recipient := setupPayouts(t, 100)
+	ctx := context.Background()
+
+	file := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"><CstmrCdtTrfInitn>
+<GrpHdr><MsgId>MSG-1</MsgId><NbOfTxs>2</NbOfTxs><CtrlSum>30</CtrlSum></GrpHdr>
+<PmtInf><PmtInfId>P1</PmtInfId><PmtMtd>TRF</PmtMtd>
+<CdtTrfTxInf><PmtId><EndToEndId>E2E-1</EndToEndId></PmtId><Amt><InstdAmt Ccy="BRL">10</InstdAmt></Amt>
+<CdtrAcct><Id><Othr><Id>r@t.com</Id><SchmeNm><Prtry>EMAIL</Prtry></SchmeNm></Othr></Id></CdtrAcct></CdtTrfTxInf>
+<CdtTrfTxInf><PmtId><EndToEndId>E2E-2</EndToEndId></PmtId><Amt><InstdAmt Ccy="BRL">20</InstdAmt></Amt>
+<CdtrAcct><Id><Othr><Id>` + recipient.String() + `</Id><SchmeNm><Prtry>USERID</Prtry></SchmeNm></Othr></Id></CdtrAcct></CdtTrfTxInf>
+</PmtInf></CstmrCdtTrfInitn></Document>`
+	preview, err := payouts.Upload(ctx, payer, entity.PayoutFormatPain001, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("upload falhou: %v", err)
+	}
+	if preview.Batch.Status != entity.PayoutBatchDraft || preview.Batch.Format != entity.PayoutFormatPain001 {
+		t.Fatalf("lote inesperado: %+v", preview.Batch)
+	}
+	if !preview.Batch.TotalAmount.Equal(decimal.NewFromInt(30)) || preview.Items[0].Reference != "E2E-1" {
+		t.Fatalf("prévia inesperada: %+v", preview.Items)
+	}
+
+	rejected, err := payouts.Upload(ctx, payer, entity.PayoutFormatPain001, strings.NewReader(strings.Replace(file, `Ccy="BRL">20`, `Ccy="EUR">20`, 1)))
+	if err != nil {
+		t.Fatalf("erro de pagamento não deveria rejeitar o arquivo: %v", err)
+	}
+	if rejected.Batch.Status != entity.PayoutBatchRejected || rejected.Items[1].Status != entity.PayoutItemInvalid {
+		t.Fatalf("pagamento em EUR deveria invalidar o lote: %+v", rejected.Items[1])
+	}
+}
@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultPixChargeTTL validade das cobranças dinâmicas
+	DefaultPixChargeTTL = 30 * time.Minute
+	// DefaultPixReconcileInterval intervalo da conciliação com o PSP
+	DefaultPixReconcileInterval = 5 * time.Minute
+	// DefaultPixMaxSendAttempts tentativas de envio de um pagamento antes de marcá-lo como falho
+	DefaultPixMaxSendAttempts = 5
+
+	pixStaticTxIDSize  = 25
+	pixDynamicTxIDSize = 35
+)
+
+var (
+	// ErrPixChargeNotFound cobrança inexistente ou de outro usuário
+	ErrPixChargeNotFound = errors.New("pix charge not found")
+	// ErrPixNotificationRejected webhook com assinatura inválida ou corpo ilegível
+	ErrPixNotificationRejected = errors.New("pix notification rejected")
+)
+
+// PixProvider porta para o PSP que mantém a conta Pix da instituição
+type PixProvider interface {
+	// CreateCharge registra a cobrança dinâmica e retorna a URL do payload usada no BR Code
+	CreateCharge(ctx context.Context, txid string, amount decimal.Decimal, description string, ttl time.Duration) (entity.PixChargeLocation, error)
+	// ResolveLocation consulta a cobrança dinâmica apontada pela URL de um BR Code (chave, valor e txid)
+	ResolveLocation(ctx context.Context, url string) (*entity.BRCode, error)
+	// SendPayment envia a ordem de pagamento e retorna o EndToEndID; deve ser idempotente pelo ID da ordem
+	SendPayment(ctx context.Context, order entity.PixPaymentOrder) (string, error)
+	// ParseNotification valida a assinatura e decodifica o webhook de créditos recebidos
+	ParseNotification(body []byte, signature string) ([]entity.PixCredit, error)
+	// ListCredits lista os créditos recebidos no intervalo, para recuperar webhooks perdidos
+	ListCredits(ctx context.Context, from, to time.Time) ([]entity.PixCredit, error)
+}
+
+// PixConfig dados do recebedor usados nos BR Codes de depósito
+type PixConfig struct {
+	Key          string
+	MerchantName string
+	MerchantCity string
+	ChargeTTL    time.Duration
+}
+
+// PixChargeRequest pedido de cobrança para depósito; cobranças dinâmicas exigem valor
+type PixChargeRequest struct {
+	Dynamic     bool            `json:"dynamic"`
+	Amount      decimal.Decimal `json:"amount"`
+	Description string          `json:"description"`
+}
+
+// PixService gera BR Codes de depósito, paga BR Codes com saldo da carteira e concilia os créditos
+// recebidos do PSP com as cobranças pelo txid
+type PixService struct {
+	pix         repository.PixRepository
+	provider    PixProvider
+	txns        *TransactionService
+	config      PixConfig
+	maxAttempts int
+	eventBus    events.Bus
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewPixService cria o serviço Pix com a chave e os dados do recebedor da instituição
+func NewPixService(pix repository.PixRepository, provider PixProvider, config PixConfig, eventBus events.Bus, logger *zap.Logger) *PixService {
+	if config.ChargeTTL <= 0 {
+		config.ChargeTTL = DefaultPixChargeTTL
+	}
+	return &PixService{
+		pix:         pix,
+		provider:    provider,
+		config:      config,
+		maxAttempts: DefaultPixMaxSendAttempts,
+		eventBus:    eventBus,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Provider retorna o PSP configurado (usado pelo simulador de créditos em ambientes de teste)
+func (s *PixService) Provider() PixProvider {
+	return s.provider
+}
+
+// CreateCharge gera o BR Code de depósito do usuário. Estáticos podem ter valor livre e ser pagos
+// várias vezes; dinâmicos são registrados no PSP, têm valor fixo e expiram.
+func (s *PixService) CreateCharge(ctx context.Context, userID uuid.UUID, req PixChargeRequest) (*entity.PixCharge, error) {
+	if req.Amount.IsNegative() || (req.Dynamic && !req.Amount.IsPositive()) {
+		return nil, ErrInvalidAmount
+	}
+	wallet, err := s.txns.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	now := s.now()
+	charge := &entity.PixCharge{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Dynamic:     req.Dynamic,
+		Amount:      req.Amount,
+		Description: strings.TrimSpace(req.Description),
+		Status:      entity.PixChargeActive,
+		CreatedAt:   now,
+	}
+	code := entity.BRCode{
+		Amount:       req.Amount,
+		MerchantName: s.config.MerchantName,
+		MerchantCity: s.config.MerchantCity,
+	}
+	if req.Dynamic {
+		charge.TxID = entity.NewPixTxID(pixDynamicTxIDSize)
+		loc, err := s.provider.CreateCharge(ctx, charge.TxID, req.Amount, charge.Description, s.config.ChargeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("pix provider: %w", err)
+		}
+		expiresAt := loc.ExpiresAt
+		charge.Location = loc.URL
+		charge.ExpiresAt = &expiresAt
+		code.Dynamic = true
+		code.URL = loc.URL
+	} else {
+		charge.TxID = entity.NewPixTxID(pixStaticTxIDSize)
+		code.Key = s.config.Key
+		code.Description = charge.Description
+		code.TxID = charge.TxID
+	}
+
+	charge.Payload, err = code.Encode()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.pix.CreateCharge(ctx, charge); err != nil {
+		return nil, err
+	}
+	return charge, nil
+}
+
+// GetCharge retorna a cobrança do usuário
+func (s *PixService) GetCharge(ctx context.Context, userID, id uuid.UUID) (*entity.PixCharge, error) {
+	charge, err := s.pix.FindCharge(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if charge == nil || charge.UserID != userID {
+		return nil, ErrPixChargeNotFound
+	}
+	return charge, nil
+}
+
+// ListCharges lista as cobranças do usuário
+func (s *PixService) ListCharges(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixCharge, error) {
+	return s.pix.ListCharges(ctx, userID, limit)
+}
+
+// Decode interpreta um "copia e cola"; códigos dinâmicos são completados com a cobrança do PSP
+func (s *PixService) Decode(ctx context.Context, payload string) (*entity.BRCode, error) {
+	code, err := entity.ParseBRCode(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !code.Dynamic {
+		return code, nil
+	}
+	resolved, err := s.provider.ResolveLocation(ctx, code.URL)
+	if err != nil {
+		return nil, fmt.Errorf("pix provider: %w", err)
+	}
+	code.Key = resolved.Key
+	code.TxID = resolved.TxID
+	if resolved.HasAmount() {
+		code.Amount = resolved.Amount
+	}
+	if code.Key == "" {
+		return nil, fmt.Errorf("%w: charge has no pix key", entity.ErrInvalidBRCode)
+	}
+	return code, nil
+}
+
+// Pay paga um BR Code com saldo da carteira. O débito segue o fluxo normal de saque (limites,
+// triagem, taxas e aprovação) e a ordem vai ao PSP quando o saque é concluído.
+func (s *PixService) Pay(ctx context.Context, userID uuid.UUID, payload string, amount decimal.Decimal) (*entity.PixPayment, error) {
+	code, err := s.Decode(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	if code.HasAmount() {
+		if !amount.IsZero() && !amount.Equal(code.Amount) {
+			return nil, entity.ErrPixAmountMismatch
+		}
+		amount = code.Amount
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	tx, err := s.txns.ProcessWithdrawTo(ctx, userID, amount, entity.PixChain, entity.PixAddressPrefix+code.Key)
+	if err != nil {
+		return nil, err
+	}
+	txid := code.TxID
+	if txid == entity.BRNoTxID {
+		txid = ""
+	}
+	payment := &entity.PixPayment{
+		ID:            uuid.New(),
+		UserID:        userID,
+		TransactionID: tx.ID,
+		Key:           code.Key,
+		TxID:          txid,
+		Amount:        amount,
+		MerchantName:  code.MerchantName,
+		Status:        entity.PixPaymentPending,
+		CreatedAt:     s.now(),
+	}
+	if err := s.pix.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+	if tx.Status == entity.TransactionStatusCompleted {
+		if err := s.send(ctx, payment); err != nil {
+			return nil, err
+		}
+	}
+	return payment, nil
+}
+
+// ListPayments lista os pagamentos Pix do usuário
+func (s *PixService) ListPayments(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixPayment, error) {
+	return s.pix.ListPayments(ctx, userID, limit)
+}
+
+// send envia a ordem ao PSP; falhas mantêm o pagamento pendente até esgotar as tentativas
+func (s *PixService) send(ctx context.Context, payment *entity.PixPayment) error {
+	payment.Attempts++
+	endToEndID, err := s.provider.SendPayment(ctx, entity.PixPaymentOrder{
+		ID:     payment.ID,
+		Key:    payment.Key,
+		TxID:   payment.TxID,
+		Amount: payment.Amount,
+	})
+	if err != nil {
+		payment.Error = err.Error()
+		if payment.Attempts >= s.maxAttempts {
+			payment.Status = entity.PixPaymentFailed
+			// O saque já foi debitado: o estorno depende de conciliação manual
+			s.logger.Error("pix payment failed after retries",
+				zap.String("payment_id", payment.ID.String()),
+				zap.String("transaction_id", payment.TransactionID.String()),
+				zap.Error(err),
+			)
+		} else {
+			s.logger.Warn("pix payment send failed", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		}
+		return s.pix.UpdatePayment(ctx, payment)
+	}
+	now := s.now()
+	payment.Status = entity.PixPaymentSent
+	payment.EndToEndID = endToEndID
+	payment.Error = ""
+	payment.SentAt = &now
+	return s.pix.UpdatePayment(ctx, payment)
+}
+
+// HandleNotification processa o webhook do PSP e retorna quantos créditos foram recebidos
+func (s *PixService) HandleNotification(ctx context.Context, body []byte, signature string) (int, error) {
+	credits, err := s.provider.ParseNotification(body, signature)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrPixNotificationRejected, err)
+	}
+	for i := range credits {
+		if _, err := s.ReceiveCredit(ctx, credits[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(credits), nil
+}
+
+// ReceiveCredit concilia um crédito recebido com a cobrança do txid e deposita na carteira do
+// usuário. Créditos repetidos (mesmo EndToEndID) são ignorados; sem cobrança válida o crédito fica
+// unmatched para devolução.
+func (s *PixService) ReceiveCredit(ctx context.Context, credit entity.PixCredit) (*entity.PixCredit, error) {
+	if credit.EndToEndID == "" || !credit.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: credit requires end to end id and a positive amount", entity.ErrInvalidPixCharge)
+	}
+	now := s.now()
+	credit.ID = uuid.New()
+	credit.Status = entity.PixCreditReceived
+	credit.ReceivedAt = now
+	if credit.PaidAt.IsZero() {
+		credit.PaidAt = now
+	}
+
+	var charge *entity.PixCharge
+	if credit.TxID != "" && credit.TxID != entity.BRNoTxID {
+		found, err := s.pix.FindChargeByTxID(ctx, credit.TxID)
+		if err != nil {
+			return nil, err
+		}
+		charge = found
+	}
+	switch {
+	case charge == nil:
+		credit.Status = entity.PixCreditUnmatched
+		credit.Reason = "no charge for txid"
+	default:
+		if err := charge.Accepts(credit.Amount, credit.PaidAt); err != nil {
+			credit.Status = entity.PixCreditUnmatched
+			credit.Reason = err.Error()
+		}
+		credit.ChargeID = &charge.ID
+		credit.UserID = &charge.UserID
+	}
+
+	if err := s.pix.CreateCredit(ctx, &credit); err != nil {
+		if errors.Is(err, entity.ErrPixCreditDuplicate) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if credit.Status == entity.PixCreditUnmatched {
+		s.logger.Warn("pix credit unmatched",
+			zap.String("end_to_end_id", credit.EndToEndID),
+			zap.String("txid", credit.TxID),
+			zap.String("reason", credit.Reason),
+		)
+		return &credit, nil
+	}
+
+	if charge.Dynamic {
+		paid, err := s.pix.MarkChargePaid(ctx, charge.ID, credit.PaidAt)
+		if err != nil {
+			return nil, err
+		}
+		if !paid {
+			credit.Status = entity.PixCreditUnmatched
+			credit.Reason = "charge already paid"
+			return &credit, s.pix.UpdateCredit(ctx, &credit)
+		}
+	}
+
+	tx, err := s.txns.deposit(ctx, charge.UserID, credit.Amount, "", entity.PixAddressPrefix+credit.EndToEndID)
+	if err != nil {
+		credit.Status = entity.PixCreditFailed
+		credit.Reason = err.Error()
+		s.logger.Error("pix credit deposit failed", zap.String("end_to_end_id", credit.EndToEndID), zap.Error(err))
+		return &credit, s.pix.UpdateCredit(ctx, &credit)
+	}
+	credit.Status = entity.PixCreditCredited
+	credit.TransactionID = &tx.ID
+	if err := s.pix.UpdateCredit(ctx, &credit); err != nil {
+		return nil, err
+	}
+
+	if s.eventBus != nil {
+		s.eventBus.PublishAsync(ctx, events.NewPixCreditReceivedEvent(
+			credit.ID, charge.UserID, tx.ID, credit.EndToEndID, credit.TxID, credit.Amount,
+		))
+	}
+	return &credit, nil
+}
+
+// ListCredits lista os créditos no estado informado (ex.: unmatched para devolução)
+func (s *PixService) ListCredits(ctx context.Context, status entity.PixCreditStatus, limit int) ([]*entity.PixCredit, error) {
+	return s.pix.ListCredits(ctx, status, limit)
+}
+
+// Reconcile recupera créditos sem webhook na janela informada, expira cobranças vencidas e
+// reenvia os pagamentos pendentes cujo saque foi concluído
+func (s *PixService) Reconcile(ctx context.Context, window time.Duration) error {
+	now := s.now()
+	credits, err := s.provider.ListCredits(ctx, now.Add(-window), now)
+	if err != nil {
+		return fmt.Errorf("pix provider: %w", err)
+	}
+	for _, credit := range credits {
+		if _, err := s.ReceiveCredit(ctx, credit); err != nil {
+			s.logger.Error("pix credit reconciliation failed", zap.String("end_to_end_id", credit.EndToEndID), zap.Error(err))
+		}
+	}
+
+	if _, err := s.pix.ExpireCharges(ctx, now); err != nil {
+		return err
+	}
+
+	payments, err := s.pix.ListPendingPayments(ctx, 500)
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		tx, err := s.txns.txRepo.FindByID(ctx, payment.TransactionID)
+		if err != nil || tx == nil {
+			continue
+		}
+		switch tx.Status {
+		case entity.TransactionStatusCompleted:
+			err = s.send(ctx, payment)
+		case entity.TransactionStatusFailed:
+			payment.Status = entity.PixPaymentCancelled
+			payment.Error = "withdrawal was not approved"
+			err = s.pix.UpdatePayment(ctx, payment)
+		}
+		if err != nil {
+			s.logger.Error("pix payment retry failed", zap.String("payment_id", payment.ID.String()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Run concilia com o PSP a cada intervalo até o contexto ser cancelado
+func (s *PixService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPixReconcileInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// A janela cobre dois ciclos para tolerar atrasos do PSP
+			if err := s.Reconcile(ctx, 2*interval); err != nil {
+				s.logger.Error("pix reconciliation failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// WithPix habilita depósitos e pagamentos Pix por este serviço
+func (s *TransactionService) WithPix(pix *PixService) *TransactionService {
+	s.pix = pix
+	pix.txns = s
+	return s
+}
+
+// Pix retorna o serviço Pix (nil se desabilitado)
+func (s *TransactionService) Pix() *PixService {
+	return s.pix
+}
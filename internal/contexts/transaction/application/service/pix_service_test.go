@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memPixRepo struct {
+	mu       sync.Mutex
+	charges  map[uuid.UUID]*entity.PixCharge
+	credits  map[string]*entity.PixCredit
+	payments map[uuid.UUID]*entity.PixPayment
+}
+
+func newMemPixRepo() *memPixRepo {
+	return &memPixRepo{
+		charges:  make(map[uuid.UUID]*entity.PixCharge),
+		credits:  make(map[string]*entity.PixCredit),
+		payments: make(map[uuid.UUID]*entity.PixPayment),
+	}
+}
+
+func (m *memPixRepo) CreateCharge(ctx context.Context, c *entity.PixCharge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *c
+	m.charges[c.ID] = &cp
+	return nil
+}
+
+func (m *memPixRepo) FindCharge(ctx context.Context, id uuid.UUID) (*entity.PixCharge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.charges[id]; ok {
+		cp := *c
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m *memPixRepo) FindChargeByTxID(ctx context.Context, txid string) (*entity.PixCharge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.charges {
+		if c.TxID == txid {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memPixRepo) ListCharges(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixCharge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PixCharge
+	for _, c := range m.charges {
+		if c.UserID == userID {
+			cp := *c
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *memPixRepo) MarkChargePaid(ctx context.Context, id uuid.UUID, paidAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.charges[id]
+	if !ok || c.Status != entity.PixChargeActive {
+		return false, nil
+	}
+	c.Status = entity.PixChargePaid
+	c.PaidAt = &paidAt
+	return true, nil
+}
+
+func (m *memPixRepo) ExpireCharges(ctx context.Context, now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, c := range m.charges {
+		if c.Status == entity.PixChargeActive && c.ExpiresAt != nil && !c.ExpiresAt.After(now) {
+			c.Status = entity.PixChargeExpired
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *memPixRepo) CreateCredit(ctx context.Context, c *entity.PixCredit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.credits[c.EndToEndID]; ok {
+		return entity.ErrPixCreditDuplicate
+	}
+	cp := *c
+	m.credits[c.EndToEndID] = &cp
+	return nil
+}
+
+func (m *memPixRepo) UpdateCredit(ctx context.Context, c *entity.PixCredit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *c
+	m.credits[c.EndToEndID] = &cp
+	return nil
+}
+
+func (m *memPixRepo) ListCredits(ctx context.Context, status entity.PixCreditStatus, limit int) ([]*entity.PixCredit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PixCredit
+	for _, c := range m.credits {
+		if c.Status == status {
+			cp := *c
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *memPixRepo) CreatePayment(ctx context.Context, p *entity.PixPayment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *p
+	m.payments[p.ID] = &cp
+	return nil
+}
+
+func (m *memPixRepo) UpdatePayment(ctx context.Context, p *entity.PixPayment) error {
+	return m.CreatePayment(ctx, p)
+}
+
+func (m *memPixRepo) ListPayments(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixPayment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PixPayment
+	for _, p := range m.payments {
+		if p.UserID == userID {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *memPixRepo) ListPendingPayments(ctx context.Context, limit int) ([]*entity.PixPayment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.PixPayment
+	for _, p := range m.payments {
+		if p.Status == entity.PixPaymentPending {
+			cp := *p
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// fakePixProvider PSP em memória com falhas de envio programáveis
+type fakePixProvider struct {
+	mu        sync.Mutex
+	locations map[string]*entity.BRCode
+	sent      map[uuid.UUID]string
+	credits   []entity.PixCredit
+	sendErr   error
+}
+
+func newFakePixProvider() *fakePixProvider {
+	return &fakePixProvider{locations: make(map[string]*entity.BRCode), sent: make(map[uuid.UUID]string)}
+}
+
+func (f *fakePixProvider) CreateCharge(ctx context.Context, txid string, amount decimal.Decimal, description string, ttl time.Duration) (entity.PixChargeLocation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	url := "psp.test/v2/" + txid
+	f.locations[url] = &entity.BRCode{Dynamic: true, Key: "deposits@bank.test", URL: url, Amount: amount, TxID: txid}
+	return entity.PixChargeLocation{URL: url, ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+func (f *fakePixProvider) ResolveLocation(ctx context.Context, url string) (*entity.BRCode, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if code, ok := f.locations[url]; ok {
+		cp := *code
+		return &cp, nil
+	}
+	return nil, errors.New("location not found")
+}
+
+func (f *fakePixProvider) SendPayment(ctx context.Context, order entity.PixPaymentOrder) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sendErr != nil {
+		return "", f.sendErr
+	}
+	if id, ok := f.sent[order.ID]; ok {
+		return id, nil
+	}
+	id := "E" + entity.NewPixTxID(31)
+	f.sent[order.ID] = id
+	return id, nil
+}
+
+func (f *fakePixProvider) ParseNotification(body []byte, signature string) ([]entity.PixCredit, error) {
+	return nil, errors.New("not used")
+}
+
+func (f *fakePixProvider) ListCredits(ctx context.Context, from, to time.Time) ([]entity.PixCredit, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]entity.PixCredit(nil), f.credits...), nil
+}
+
+func setupPix(t *testing.T, balance float64) (*PixService, *memPixRepo, *fakePixProvider, *memWalletRepo, uuid.UUID) {
+	t.Helper()
+	svc, _, wr, uid := setupService(t, balance)
+	repo := newMemPixRepo()
+	provider := newFakePixProvider()
+	pix := NewPixService(repo, provider, PixConfig{
+		Key:          "deposits@bank.test",
+		MerchantName: "Financial System",
+		MerchantCity: "SAO PAULO",
+	}, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	svc.WithPix(pix)
+	return pix, repo, provider, wr, uid
+}
+
+func TestPixService_StaticChargeCreditsWalletOnce(t *testing.T) {
+	pix, repo, _, wr, uid := setupPix(t, 0)
+	ctx := context.Background()
+
+	charge, err := pix.CreateCharge(ctx, uid, PixChargeRequest{Description: "deposito"})
+	if err != nil {
+		t.Fatalf("cobrança: %v", err)
+	}
+	code, err := entity.ParseBRCode(charge.Payload)
+	if err != nil {
+		t.Fatalf("payload inválido: %v", err)
+	}
+	if code.Dynamic || code.Key != "deposits@bank.test" || code.TxID != charge.TxID || code.HasAmount() {
+		t.Fatalf("BR Code estático incorreto: %+v", code)
+	}
+
+	credit := entity.PixCredit{EndToEndID: "E1", TxID: charge.TxID, Amount: decimal.NewFromInt(30)}
+	got, err := pix.ReceiveCredit(ctx, credit)
+	if err != nil || got.Status != entity.PixCreditCredited || got.TransactionID == nil {
+		t.Fatalf("crédito deveria ser depositado: %+v, %v", got, err)
+	}
+	if b := balanceOf(t, wr, uid); b != 30 {
+		t.Fatalf("saldo esperado 30, obtido %v", b)
+	}
+
+	// Webhook repetido não credita de novo
+	if got, err := pix.ReceiveCredit(ctx, credit); err != nil || got != nil {
+		t.Fatalf("crédito repetido deveria ser ignorado: %+v, %v", got, err)
+	}
+	// Estáticos aceitam novos pagamentos
+	if _, err := pix.ReceiveCredit(ctx, entity.PixCredit{EndToEndID: "E2", TxID: charge.TxID, Amount: decimal.NewFromInt(5)}); err != nil {
+		t.Fatalf("segundo pagamento: %v", err)
+	}
+	if b := balanceOf(t, wr, uid); b != 35 {
+		t.Fatalf("saldo esperado 35, obtido %v", b)
+	}
+
+	unknown, _ := pix.ReceiveCredit(ctx, entity.PixCredit{EndToEndID: "E3", TxID: "nope", Amount: decimal.NewFromInt(1)})
+	if unknown.Status != entity.PixCreditUnmatched {
+		t.Fatalf("crédito sem cobrança deveria ficar unmatched: %+v", unknown)
+	}
+	if list, _ := repo.ListCredits(ctx, entity.PixCreditUnmatched, 10); len(list) != 1 {
+		t.Fatalf("esperado 1 crédito para devolução, obtido %d", len(list))
+	}
+}
+
+func TestPixService_DynamicChargeIsSingleUse(t *testing.T) {
+	pix, _, _, wr, uid := setupPix(t, 0)
+	ctx := context.Background()
+
+	if _, err := pix.CreateCharge(ctx, uid, PixChargeRequest{Dynamic: true}); !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("cobrança dinâmica sem valor deveria falhar, obtido %v", err)
+	}
+	charge, err := pix.CreateCharge(ctx, uid, PixChargeRequest{Dynamic: true, Amount: decimal.NewFromInt(50)})
+	if err != nil {
+		t.Fatalf("cobrança: %v", err)
+	}
+	code, _ := entity.ParseBRCode(charge.Payload)
+	if !code.Dynamic || code.URL != charge.Location || code.TxID != entity.BRNoTxID {
+		t.Fatalf("BR Code dinâmico incorreto: %+v", code)
+	}
+
+	wrong, _ := pix.ReceiveCredit(ctx, entity.PixCredit{EndToEndID: "E1", TxID: charge.TxID, Amount: decimal.NewFromInt(49)})
+	if wrong.Status != entity.PixCreditUnmatched {
+		t.Fatalf("valor divergente deveria ficar unmatched: %+v", wrong)
+	}
+	if _, err := pix.ReceiveCredit(ctx, entity.PixCredit{EndToEndID: "E2", TxID: charge.TxID, Amount: decimal.NewFromInt(50)}); err != nil {
+		t.Fatalf("pagamento: %v", err)
+	}
+	again, _ := pix.ReceiveCredit(ctx, entity.PixCredit{EndToEndID: "E3", TxID: charge.TxID, Amount: decimal.NewFromInt(50)})
+	if again.Status != entity.PixCreditUnmatched {
+		t.Fatalf("cobrança paga não aceita novo crédito: %+v", again)
+	}
+	if b := balanceOf(t, wr, uid); b != 50 {
+		t.Fatalf("saldo esperado 50, obtido %v", b)
+	}
+	if stored, _ := pix.GetCharge(ctx, uid, charge.ID); stored.Status != entity.PixChargePaid {
+		t.Fatalf("cobrança deveria estar paga, obtido %s", stored.Status)
+	}
+}
+
+func TestPixService_PayBRCodeAndRetryFailedSend(t *testing.T) {
+	pix, repo, provider, wr, uid := setupPix(t, 100)
+	ctx := context.Background()
+
+	payload, _ := entity.BRCode{
+		Key:          "loja@example.com",
+		Amount:       decimal.NewFromInt(40),
+		MerchantName: "Loja",
+		MerchantCity: "RECIFE",
+		TxID:         "PEDIDO123",
+	}.Encode()
+
+	if _, err := pix.Pay(ctx, uid, payload, decimal.NewFromInt(41)); !errors.Is(err, entity.ErrPixAmountMismatch) {
+		t.Fatalf("esperado ErrPixAmountMismatch, obtido %v", err)
+	}
+
+	provider.sendErr = errors.New("psp unavailable")
+	payment, err := pix.Pay(ctx, uid, payload, decimal.Zero)
+	if err != nil {
+		t.Fatalf("pagamento: %v", err)
+	}
+	if payment.Status != entity.PixPaymentPending || payment.Attempts != 1 || payment.TxID != "PEDIDO123" {
+		t.Fatalf("falha no PSP deveria manter o pagamento pendente: %+v", payment)
+	}
+	if b := balanceOf(t, wr, uid); b != 60 {
+		t.Fatalf("saque deveria debitar 40, saldo %v", b)
+	}
+
+	provider.sendErr = nil
+	if err := pix.Reconcile(ctx, time.Hour); err != nil {
+		t.Fatalf("conciliação: %v", err)
+	}
+	stored := repo.payments[payment.ID]
+	if stored.Status != entity.PixPaymentSent || stored.EndToEndID == "" || stored.Attempts != 2 {
+		t.Fatalf("conciliação deveria reenviar o pagamento: %+v", stored)
+	}
+}
+
+func TestPixService_ReconcileRecoversMissedWebhook(t *testing.T) {
+	pix, _, provider, wr, uid := setupPix(t, 0)
+	ctx := context.Background()
+
+	charge, _ := pix.CreateCharge(ctx, uid, PixChargeRequest{Amount: decimal.NewFromInt(12)})
+	provider.credits = []entity.PixCredit{{EndToEndID: "E1", TxID: charge.TxID, Amount: decimal.NewFromInt(12)}}
+
+	for i := 0; i < 2; i++ {
+		if err := pix.Reconcile(ctx, time.Hour); err != nil {
+			t.Fatalf("conciliação: %v", err)
+		}
+	}
+	if b := balanceOf(t, wr, uid); b != 12 {
+		t.Fatalf("crédito perdido deveria ser depositado uma vez, saldo %v", b)
+	}
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultReconciliationSweepInterval intervalo da varredura que dispara a conciliação do dia anterior
+	DefaultReconciliationSweepInterval = time.Hour
+
+	reconciliationDateLayout = "2006-01-02"
+)
+
+var (
+	// ErrReconciliationNotFound relatório de conciliação inexistente
+	ErrReconciliationNotFound = errors.New("reconciliation run not found")
+	// ErrInvalidReconciliationDate data fora do formato AAAA-MM-DD
+	ErrInvalidReconciliationDate = errors.New("invalid reconciliation date")
+)
+
+// OnChainBalanceSource consulta o saldo dos endereços na rede (BlockchainGatewayPort.GetBalance) já
+// convertido da unidade base para o ativo nativo
+type OnChainBalanceSource interface {
+	NativeAsset(chain string) (string, error)
+	GetBalance(ctx context.Context, chain, address string) (decimal.Decimal, error)
+}
+
+// ReconciliationTask conciliação de um dia entregue à fila
+type ReconciliationTask struct {
+	Date string `json:"date"` // AAAA-MM-DD
+}
+
+// TaskID identificador estável da conciliação do dia, usado para deduplicar tarefas na fila
+func (t ReconciliationTask) TaskID() string {
+	return "reconciliation:" + t.Date
+}
+
+// ReconciliationDispatcher entrega a conciliação diária para processamento assíncrono (ex.: asynq)
+type ReconciliationDispatcher interface {
+	Dispatch(ctx context.Context, task ReconciliationTask) error
+}
+
+// ReconciliationService confere, por rede e ativo, o passivo com os usuários contra o saldo dos
+// endereços da plataforma e casa as transações internas do dia com as registradas on-chain. O
+// relatório é gravado e as quebras são alertadas no barramento.
+type ReconciliationService struct {
+	repo       repository.ReconciliationRepository
+	balances   OnChainBalanceSource
+	addresses  map[string][]string
+	tolerances map[string]decimal.Decimal
+	dispatcher ReconciliationDispatcher
+	location   *time.Location
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewReconciliationService cria a conciliação dos endereços da plataforma por rede
+// (ex.: {"ethereum": ["0xhot", "0xcold"]})
+func NewReconciliationService(repo repository.ReconciliationRepository, balances OnChainBalanceSource, addresses map[string][]string, eventBus events.Bus, logger *zap.Logger) *ReconciliationService {
+	return &ReconciliationService{
+		repo:       repo,
+		balances:   balances,
+		addresses:  addresses,
+		tolerances: make(map[string]decimal.Decimal),
+		location:   time.UTC,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// WithTolerance aceita diferenças de saldo até o valor informado no ativo (ex.: poeira de taxas)
+func (s *ReconciliationService) WithTolerance(asset string, tolerance decimal.Decimal) *ReconciliationService {
+	s.tolerances[asset] = tolerance
+	return s
+}
+
+// WithDispatcher entrega a conciliação diária à fila
+func (s *ReconciliationService) WithDispatcher(dispatcher ReconciliationDispatcher) *ReconciliationService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithLocation define o fuso usado nos limites do dia
+func (s *ReconciliationService) WithLocation(location *time.Location) *ReconciliationService {
+	if location != nil {
+		s.location = location
+	}
+	return s
+}
+
+// ParseDate interpreta AAAA-MM-DD no fuso configurado
+func (s *ReconciliationService) ParseDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(reconciliationDateLayout, date, s.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidReconciliationDate)
+	}
+	return day, nil
+}
+
+// Yesterday início do dia anterior no fuso configurado, o dia conciliado pela execução diária
+func (s *ReconciliationService) Yesterday() time.Time {
+	current := s.now().In(s.location)
+	return time.Date(current.Year(), current.Month(), current.Day()-1, 0, 0, 0, 0, s.location)
+}
+
+// Reconcile concilia o dia informado: saldos atuais de cada rede e transações criadas no dia.
+// Uma nova execução do mesmo dia substitui o relatório anterior.
+func (s *ReconciliationService) Reconcile(ctx context.Context, day time.Time) (*entity.ReconciliationRun, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.location)
+	to := from.AddDate(0, 0, 1)
+	run := entity.NewReconciliationRun(from, s.now())
+
+	chains := make([]string, 0, len(s.addresses))
+	for chain := range s.addresses {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	for _, chain := range chains {
+		asset, err := s.balances.NativeAsset(chain)
+		if err != nil {
+			run.AddBreak(entity.ReconciliationBreak{Category: entity.BreakBalanceUnavailable, Chain: chain, Detail: err.Error()})
+			continue
+		}
+		result, err := s.reconcileBalance(ctx, run, chain, asset)
+		if err != nil {
+			return nil, err
+		}
+		run.Assets = append(run.Assets, result)
+
+		internal, err := s.repo.ListChainTransactions(ctx, chain, from, to)
+		if err != nil {
+			return nil, err
+		}
+		onchain, err := s.repo.ListOnChainTransfers(ctx, chain, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range entity.MatchTransfers(chain, asset, internal, onchain) {
+			run.AddBreak(b)
+		}
+	}
+	run.Finish(s.now())
+
+	if err := s.repo.Save(ctx, run); err != nil {
+		return nil, err
+	}
+	if run.BreakCount > 0 {
+		s.logger.Warn("reconciliation found breaks",
+			zap.String("run_id", run.ID.String()),
+			zap.String("date", from.Format(reconciliationDateLayout)),
+			zap.Int("breaks", run.BreakCount),
+		)
+		if s.eventBus != nil {
+			s.eventBus.PublishAsync(ctx, events.NewReconciliationBreaksDetectedEvent(
+				run.ID, from.Format(reconciliationDateLayout), run.BreakCount, run.ByCategory,
+			))
+		}
+	}
+	return run, nil
+}
+
+// reconcileBalance soma os saldos dos endereços da rede e compara com o passivo do ativo
+func (s *ReconciliationService) reconcileBalance(ctx context.Context, run *entity.ReconciliationRun, chain, asset string) (entity.AssetReconciliation, error) {
+	liabilities, err := s.repo.SumLiabilities(ctx, asset)
+	if err != nil {
+		return entity.AssetReconciliation{}, err
+	}
+	result := entity.AssetReconciliation{
+		Chain:       chain,
+		Asset:       asset,
+		Liabilities: liabilities,
+		OnChain:     decimal.Zero,
+		Addresses:   len(s.addresses[chain]),
+		Available:   true,
+	}
+	for _, address := range s.addresses[chain] {
+		balance, err := s.balances.GetBalance(ctx, chain, address)
+		if err != nil {
+			result.Available = false
+			run.AddBreak(entity.ReconciliationBreak{
+				Category: entity.BreakBalanceUnavailable,
+				Chain:    chain,
+				Asset:    asset,
+				Detail:   fmt.Sprintf("balance of %s: %v", address, err),
+			})
+			continue
+		}
+		result.OnChain = result.OnChain.Add(balance)
+	}
+	if !result.Available {
+		result.Difference = result.OnChain.Sub(result.Liabilities)
+		return result, nil
+	}
+	result, b := entity.CompareBalances(result, s.tolerances[asset])
+	if b != nil {
+		run.AddBreak(*b)
+	}
+	return result, nil
+}
+
+// Get retorna um relatório de conciliação com as quebras
+func (s *ReconciliationService) Get(ctx context.Context, id uuid.UUID) (*entity.ReconciliationRun, error) {
+	run, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrReconciliationNotFound
+	}
+	return run, nil
+}
+
+// List lista os relatórios mais recentes, sem as quebras
+func (s *ReconciliationService) List(ctx context.Context, limit int) ([]*entity.ReconciliationRun, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// Execute concilia o dia da tarefa
+func (s *ReconciliationService) Execute(ctx context.Context, task ReconciliationTask) error {
+	day, err := s.ParseDate(task.Date)
+	if err != nil {
+		return err
+	}
+	_, err = s.Reconcile(ctx, day)
+	return err
+}
+
+// DispatchDaily enfileira (ou executa, sem dispatcher) a conciliação do dia anterior se ainda não
+// houver relatório; retorna se algo foi disparado
+func (s *ReconciliationService) DispatchDaily(ctx context.Context) (bool, error) {
+	day := s.Yesterday()
+	existing, err := s.repo.FindByDate(ctx, day)
+	if err != nil || existing != nil {
+		return false, err
+	}
+	task := ReconciliationTask{Date: day.Format(reconciliationDateLayout)}
+	if s.dispatcher == nil {
+		return true, s.Execute(ctx, task)
+	}
+	return true, s.dispatcher.Dispatch(ctx, task)
+}
+
+// Run dispara a conciliação diária a cada intervalo até o contexto ser cancelado
+func (s *ReconciliationService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReconciliationSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchDaily(ctx); err != nil {
+				s.logger.Error("daily reconciliation failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// WithReconciliation habilita a conciliação diária com a rede
+func (s *TransactionService) WithReconciliation(reconciliation *ReconciliationService) *TransactionService {
+	s.reconciliation = reconciliation
+	return s
+}
+
+// Reconciliation retorna o serviço de conciliação (nil se desabilitado)
+func (s *TransactionService) Reconciliation() *ReconciliationService {
+	return s.reconciliation
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memReconciliationRepo struct {
+	mu          sync.Mutex
+	liabilities map[string]decimal.Decimal
+	internal    map[string][]*entity.Transaction
+	onchain     map[string][]entity.OnChainTransfer
+	runs        map[uuid.UUID]*entity.ReconciliationRun
+}
+
+func newMemReconciliationRepo() *memReconciliationRepo {
+	return &memReconciliationRepo{
+		liabilities: make(map[string]decimal.Decimal),
+		internal:    make(map[string][]*entity.Transaction),
+		onchain:     make(map[string][]entity.OnChainTransfer),
+		runs:        make(map[uuid.UUID]*entity.ReconciliationRun),
+	}
+}
+
+func (m *memReconciliationRepo) SumLiabilities(ctx context.Context, asset string) (decimal.Decimal, error) {
+	return m.liabilities[asset], nil
+}
+
+func (m *memReconciliationRepo) ListChainTransactions(ctx context.Context, chain string, from, to time.Time) ([]*entity.Transaction, error) {
+	var out []*entity.Transaction
+	for _, tx := range m.internal[chain] {
+		if !tx.CreatedAt.Before(from) && tx.CreatedAt.Before(to) {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+
+func (m *memReconciliationRepo) ListOnChainTransfers(ctx context.Context, chain string, from, to time.Time) ([]entity.OnChainTransfer, error) {
+	var out []entity.OnChainTransfer
+	for _, t := range m.onchain[chain] {
+		if !t.CreatedAt.Before(from) && t.CreatedAt.Before(to) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *memReconciliationRepo) Save(ctx context.Context, run *entity.ReconciliationRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, existing := range m.runs {
+		if existing.RunDate.Equal(run.RunDate) {
+			delete(m.runs, id)
+		}
+	}
+	cp := *run
+	m.runs[run.ID] = &cp
+	return nil
+}
+
+func (m *memReconciliationRepo) Find(ctx context.Context, id uuid.UUID) (*entity.ReconciliationRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if run, ok := m.runs[id]; ok {
+		cp := *run
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m *memReconciliationRepo) FindByDate(ctx context.Context, runDate time.Time) (*entity.ReconciliationRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, run := range m.runs {
+		if run.RunDate.Equal(runDate) {
+			cp := *run
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memReconciliationRepo) List(ctx context.Context, limit int) ([]*entity.ReconciliationRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.ReconciliationRun
+	for _, run := range m.runs {
+		cp := *run
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+// fakeBalances saldos on-chain por endereço; endereços ausentes falham na consulta
+type fakeBalances map[string]decimal.Decimal
+
+func (f fakeBalances) NativeAsset(chain string) (string, error) {
+	switch chain {
+	case "ethereum":
+		return "ETH", nil
+	case "bitcoin":
+		return "BTC", nil
+	}
+	return "", errors.New("unknown chain")
+}
+
+func (f fakeBalances) GetBalance(ctx context.Context, chain, address string) (decimal.Decimal, error) {
+	if balance, ok := f[address]; ok {
+		return balance, nil
+	}
+	return decimal.Zero, errors.New("rpc unavailable")
+}
+
+func TestReconciliationService_ReconcileDetectsBreaksAndAlerts(t *testing.T) {
+	repo := newMemReconciliationRepo()
+	repo.liabilities["ETH"] = decimal.RequireFromString("10")
+	repo.liabilities["BTC"] = decimal.RequireFromString("2")
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo.internal["ethereum"] = []*entity.Transaction{
+		{ID: uuid.New(), Type: entity.TransactionTypeWithdraw, Status: entity.TransactionStatusCompleted, TransactionHash: "0xaa", CreatedAt: day.Add(time.Hour)},
+		{ID: uuid.New(), Type: entity.TransactionTypeWithdraw, Status: entity.TransactionStatusCompleted, TransactionHash: "0xold", CreatedAt: day.Add(-time.Hour)},
+	}
+	repo.onchain["ethereum"] = []entity.OnChainTransfer{
+		{Hash: "0xaa", Status: "confirmed", CreatedAt: day.Add(time.Hour)},
+	}
+
+	bus := events.NewInMemoryBus(zap.NewNop())
+	alerts := make(chan events.Event, 1)
+	bus.Subscribe("reconciliation.breaks_detected", func(ctx context.Context, evt events.Event) error {
+		alerts <- evt
+		return nil
+	})
+
+	balances := fakeBalances{"0xhot": decimal.RequireFromString("4"), "0xcold": decimal.RequireFromString("6.0004")}
+	svc := NewReconciliationService(repo, balances, map[string][]string{
+		"ethereum": {"0xhot", "0xcold"},
+		"bitcoin":  {"bc1-offline"},
+	}, bus, zap.NewNop()).WithTolerance("ETH", decimal.RequireFromString("0.001"))
+
+	run, err := svc.Reconcile(context.Background(), day.Add(15*time.Hour))
+	if err != nil {
+		t.Fatalf("erro na conciliação: %v", err)
+	}
+	if !run.RunDate.Equal(day) {
+		t.Fatalf("dia conciliado deveria ser %s, veio %s", day, run.RunDate)
+	}
+	if len(run.Assets) != 2 || run.Assets[0].Chain != "bitcoin" || run.Assets[0].Available {
+		t.Fatalf("bitcoin deveria vir primeiro e indisponível: %+v", run.Assets)
+	}
+	if eth := run.Assets[1]; !eth.OnChain.Equal(decimal.RequireFromString("10.0004")) || !eth.Available {
+		t.Fatalf("saldo on-chain de ETH deveria somar os endereços: %+v", eth)
+	}
+	if run.Status != entity.ReconciliationBreaks || run.BreakCount != 1 || run.ByCategory["balance_unavailable"] != 1 {
+		t.Fatalf("só o saldo indisponível deveria quebrar: %+v", run.ByCategory)
+	}
+
+	select {
+	case evt := <-alerts:
+		alert, ok := evt.(events.ReconciliationBreaksDetectedEvent)
+		if !ok || alert.BreakCount != 1 || alert.RunDate != "2025-03-01" {
+			t.Fatalf("alerta inesperado: %+v", evt)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("alerta de quebra não publicado")
+	}
+
+	// a reexecução do mesmo dia substitui o relatório
+	balances["bc1-offline"] = decimal.RequireFromString("1.5")
+	run, err = svc.Reconcile(context.Background(), day)
+	if err != nil {
+		t.Fatalf("erro na reexecução: %v", err)
+	}
+	if run.BreakCount != 1 || run.Breaks[0].Category != entity.BreakBalanceShortfall || run.Breaks[0].Asset != "BTC" {
+		t.Fatalf("deveria apontar falta de BTC: %+v", run.Breaks)
+	}
+	if list, _ := svc.List(context.Background(), 10); len(list) != 1 {
+		t.Fatalf("deveria haver um relatório por dia, veio %d", len(list))
+	}
+	if _, err := svc.Get(context.Background(), uuid.New()); !errors.Is(err, ErrReconciliationNotFound) {
+		t.Fatalf("esperado ErrReconciliationNotFound, veio %v", err)
+	}
+}
+
+func TestReconciliationService_DispatchDailyRunsOncePerDay(t *testing.T) {
+	repo := newMemReconciliationRepo()
+	svc := NewReconciliationService(repo, fakeBalances{"0xhot": decimal.Zero}, map[string][]string{"ethereum": {"0xhot"}},
+		events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	svc.now = func() time.Time { return time.Date(2025, 3, 2, 3, 0, 0, 0, time.UTC) }
+
+	dispatched, err := svc.DispatchDaily(context.Background())
+	if err != nil || !dispatched {
+		t.Fatalf("conciliação do dia anterior deveria rodar: %v %v", dispatched, err)
+	}
+	run, _ := repo.FindByDate(context.Background(), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	if run == nil || run.Status != entity.ReconciliationBalanced {
+		t.Fatalf("relatório de 2025-03-01 deveria estar conciliado: %+v", run)
+	}
+	if dispatched, _ = svc.DispatchDaily(context.Background()); dispatched {
+		t.Fatalf("conciliação do dia não deveria repetir")
+	}
+	if err := svc.Execute(context.Background(), ReconciliationTask{Date: "01/03/2025"}); !errors.Is(err, ErrInvalidReconciliationDate) {
+		t.Fatalf("esperado ErrInvalidReconciliationDate, veio %v", err)
+	}
+}
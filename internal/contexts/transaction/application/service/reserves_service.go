@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	"financial-system-pro/internal/shared/merkle"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrReservesSnapshotNotFound snapshot de prova de reservas inexistente
+	ErrReservesSnapshotNotFound = errors.New("proof of reserves snapshot not found")
+	// ErrAccountNotInSnapshot conta sem saldo no ativo no snapshot
+	ErrAccountNotInSnapshot = errors.New("account has no balance in this snapshot")
+)
+
+// ReservesProof prova de inclusão entregue ao titular; com user_id e nonce ele recalcula o id da
+// folha e confere o caminho até a raiz publicada (ver cmd/por-verify)
+type ReservesProof struct {
+	SnapshotID uuid.UUID    `json:"snapshot_id"`
+	Asset      string       `json:"asset"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Nonce      string       `json:"nonce"`
+	Proof      merkle.Proof `json:"proof"`
+}
+
+// ReservesService gera a prova de reservas: snapshot dos saldos de todos os usuários em uma árvore
+// de Merkle de somas por ativo, com a raiz e o passivo publicados e comparados aos saldos dos
+// endereços da plataforma na rede
+type ReservesService struct {
+	repo      repository.ReservesRepository
+	balances  OnChainBalanceSource
+	addresses map[string][]string
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// NewReservesService cria a prova de reservas com os endereços da plataforma por rede
+func NewReservesService(repo repository.ReservesRepository, balances OnChainBalanceSource, addresses map[string][]string, logger *zap.Logger) *ReservesService {
+	return &ReservesService{repo: repo, balances: balances, addresses: addresses, logger: logger, now: time.Now}
+}
+
+// Generate tira o snapshot dos saldos, monta a árvore de cada ativo e grava raízes e folhas
+func (s *ReservesService) Generate(ctx context.Context) (*entity.ReservesSnapshot, error) {
+	balances, err := s.repo.ListAccountBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &entity.ReservesSnapshot{ID: uuid.New(), CreatedAt: s.now()}
+
+	byAsset := make(map[string][]entity.ReservesLeaf)
+	for _, b := range balances {
+		if !b.Balance.IsPositive() {
+			continue
+		}
+		nonce, err := reservesNonce()
+		if err != nil {
+			return nil, err
+		}
+		byAsset[b.Asset] = append(byAsset[b.Asset], entity.ReservesLeaf{
+			SnapshotID: snapshot.ID,
+			Asset:      b.Asset,
+			UserID:     b.UserID,
+			Nonce:      nonce,
+			LeafID:     merkle.AccountLeafID(b.UserID.String(), nonce),
+			Balance:    b.Balance,
+		})
+	}
+	reserves, unavailable := s.onChainReserves(ctx)
+
+	assets := make([]string, 0, len(byAsset))
+	for asset := range byAsset {
+		assets = append(assets, asset)
+	}
+	for asset := range reserves {
+		if _, ok := byAsset[asset]; !ok && !unavailable[asset] {
+			assets = append(assets, asset)
+		}
+	}
+	sort.Strings(assets)
+
+	var all []entity.ReservesLeaf
+	for _, asset := range assets {
+		leaves := byAsset[asset]
+		// a ordem pelo id da folha (aleatório por snapshot) não revela nada sobre as contas
+		sort.Slice(leaves, func(i, j int) bool { return leaves[i].LeafID < leaves[j].LeafID })
+		for i := range leaves {
+			leaves[i].Position = i
+		}
+		tree, err := merkle.Build(reservesLeaves(leaves))
+		if err != nil {
+			return nil, err
+		}
+		summary := entity.AssetReserves{
+			Asset:       asset,
+			Root:        tree.Root().Hash,
+			Liabilities: tree.Root().Sum,
+			Accounts:    len(leaves),
+			Reserves:    decimal.Zero,
+		}
+		if r, ok := reserves[asset]; ok && !unavailable[asset] {
+			summary.Reserves = r
+			summary.ReservesAvailable = true
+		}
+		if summary.ReservesAvailable && !summary.Solvent() {
+			s.logger.Warn("reserves below liabilities",
+				zap.String("asset", asset),
+				zap.String("liabilities", summary.Liabilities.String()),
+				zap.String("reserves", summary.Reserves.String()),
+			)
+		}
+		snapshot.Assets = append(snapshot.Assets, summary)
+		all = append(all, leaves...)
+	}
+
+	if err := s.repo.Save(ctx, snapshot, all); err != nil {
+		return nil, err
+	}
+	s.logger.Info("proof of reserves generated", zap.String("snapshot_id", snapshot.ID.String()), zap.Int("assets", len(snapshot.Assets)))
+	return snapshot, nil
+}
+
+// onChainReserves soma os saldos dos endereços da plataforma por ativo nativo; ativos com algum
+// endereço que não pôde ser consultado ficam indisponíveis
+func (s *ReservesService) onChainReserves(ctx context.Context) (map[string]decimal.Decimal, map[string]bool) {
+	sums := make(map[string]decimal.Decimal)
+	unavailable := make(map[string]bool)
+	for chain, addresses := range s.addresses {
+		asset, err := s.balances.NativeAsset(chain)
+		if err != nil {
+			s.logger.Warn("reserves: unknown chain", zap.String("chain", chain), zap.Error(err))
+			continue
+		}
+		for _, address := range addresses {
+			balance, err := s.balances.GetBalance(ctx, chain, address)
+			if err != nil {
+				s.logger.Warn("reserves: balance unavailable", zap.String("chain", chain), zap.String("address", address), zap.Error(err))
+				unavailable[asset] = true
+				break
+			}
+			sums[asset] = sums[asset].Add(balance)
+		}
+	}
+	return sums, unavailable
+}
+
+// Latest retorna o snapshot mais recente (raízes e passivo publicados)
+func (s *ReservesService) Latest(ctx context.Context) (*entity.ReservesSnapshot, error) {
+	snapshot, err := s.repo.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrReservesSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+// Get retorna um snapshot
+func (s *ReservesService) Get(ctx context.Context, id uuid.UUID) (*entity.ReservesSnapshot, error) {
+	snapshot, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrReservesSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+// List lista os snapshots mais recentes
+func (s *ReservesService) List(ctx context.Context, limit int) ([]*entity.ReservesSnapshot, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// Proof monta a prova de inclusão da conta no ativo; sem snapshotID usa o mais recente. A árvore é
+// reconstruída das folhas gravadas e conferida com a raiz publicada.
+func (s *ReservesService) Proof(ctx context.Context, userID uuid.UUID, asset string, snapshotID *uuid.UUID) (*ReservesProof, error) {
+	var (
+		snapshot *entity.ReservesSnapshot
+		err      error
+	)
+	if snapshotID != nil {
+		snapshot, err = s.Get(ctx, *snapshotID)
+	} else {
+		snapshot, err = s.Latest(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	summary, ok := snapshot.Asset(asset)
+	if !ok {
+		return nil, ErrAccountNotInSnapshot
+	}
+	leaves, err := s.repo.ListLeaves(ctx, snapshot.ID, asset)
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, leaf := range leaves {
+		if leaf.UserID == userID {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, ErrAccountNotInSnapshot
+	}
+
+	tree, err := merkle.Build(reservesLeaves(leaves))
+	if err != nil {
+		return nil, err
+	}
+	if tree.Root().Hash != summary.Root {
+		return nil, fmt.Errorf("proof of reserves: leaves of %s do not match published root", asset)
+	}
+	proof, err := tree.Proof(index)
+	if err != nil {
+		return nil, err
+	}
+	return &ReservesProof{
+		SnapshotID: snapshot.ID,
+		Asset:      asset,
+		UserID:     userID,
+		Nonce:      leaves[index].Nonce,
+		Proof:      proof,
+	}, nil
+}
+
+func reservesLeaves(leaves []entity.ReservesLeaf) []merkle.Leaf {
+	out := make([]merkle.Leaf, 0, len(leaves))
+	for _, leaf := range leaves {
+		out = append(out, merkle.Leaf{ID: leaf.LeafID, Sum: leaf.Balance})
+	}
+	return out
+}
+
+func reservesNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// WithReserves habilita a prova de reservas
+func (s *TransactionService) WithReserves(reserves *ReservesService) *TransactionService {
+	s.reserves = reserves
+	return s
+}
+
+// Reserves retorna o serviço de prova de reservas (nil se desabilitado)
+func (s *TransactionService) Reserves() *ReservesService {
+	return s.reserves
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/merkle"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memReservesRepo struct {
+	balances  []entity.AccountBalance
+	snapshots []*entity.ReservesSnapshot
+	leaves    []entity.ReservesLeaf
+}
+
+func (m *memReservesRepo) ListAccountBalances(ctx context.Context) ([]entity.AccountBalance, error) {
+	return m.balances, nil
+}
+
+func (m *memReservesRepo) Save(ctx context.Context, snapshot *entity.ReservesSnapshot, leaves []entity.ReservesLeaf) error {
+	cp := *snapshot
+	m.snapshots = append(m.snapshots, &cp)
+	m.leaves = append(m.leaves, leaves...)
+	return nil
+}
+
+func (m *memReservesRepo) Find(ctx context.Context, id uuid.UUID) (*entity.ReservesSnapshot, error) {
+	for _, s := range m.snapshots {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memReservesRepo) Latest(ctx context.Context) (*entity.ReservesSnapshot, error) {
+	if len(m.snapshots) == 0 {
+		return nil, nil
+	}
+	return m.snapshots[len(m.snapshots)-1], nil
+}
+
+func (m *memReservesRepo) List(ctx context.Context, limit int) ([]*entity.ReservesSnapshot, error) {
+	return m.snapshots, nil
+}
+
+func (m *memReservesRepo) ListLeaves(ctx context.Context, snapshotID uuid.UUID, asset string) ([]entity.ReservesLeaf, error) {
+	var out []entity.ReservesLeaf
+	for _, leaf := range m.leaves {
+		if leaf.SnapshotID == snapshotID && leaf.Asset == asset {
+			out = append(out, leaf)
+		}
+	}
+	return out, nil
+}
+
+func TestReservesService_GenerateAndProve(t *testing.T) {
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	repo := &memReservesRepo{balances: []entity.AccountBalance{
+		{UserID: alice, Asset: "ETH", Balance: decimal.RequireFromString("1.5")},
+		{UserID: bob, Asset: "ETH", Balance: decimal.RequireFromString("2.25")},
+		{UserID: carol, Asset: "ETH", Balance: decimal.RequireFromString("0.25")},
+		{UserID: alice, Asset: "BRL", Balance: decimal.RequireFromString("100")},
+		{UserID: bob, Asset: "BTC", Balance: decimal.RequireFromString("1")},
+	}}
+	balances := fakeBalances{"0xhot": decimal.RequireFromString("3"), "0xcold": decimal.RequireFromString("1.5")}
+	svc := NewReservesService(repo, balances, map[string][]string{
+		"ethereum": {"0xhot", "0xcold"},
+		"bitcoin":  {"bc1-offline"},
+	}, zap.NewNop())
+
+	snapshot, err := svc.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("erro ao gerar: %v", err)
+	}
+	if len(snapshot.Assets) != 3 {
+		t.Fatalf("esperado BRL, BTC e ETH, veio %+v", snapshot.Assets)
+	}
+	eth, _ := snapshot.Asset("ETH")
+	if !eth.Liabilities.Equal(decimal.RequireFromString("4")) || eth.Accounts != 3 {
+		t.Fatalf("passivo de ETH deveria ser 4 em 3 contas: %+v", eth)
+	}
+	if !eth.ReservesAvailable || !eth.Reserves.Equal(decimal.RequireFromString("4.5")) || !eth.Solvent() {
+		t.Fatalf("reservas de ETH deveriam cobrir o passivo: %+v", eth)
+	}
+	if btc, _ := snapshot.Asset("BTC"); btc.ReservesAvailable || btc.Solvent() {
+		t.Fatalf("BTC sem saldo consultável não pode ser solvente: %+v", btc)
+	}
+	if brl, _ := snapshot.Asset("BRL"); brl.ReservesAvailable {
+		t.Fatalf("BRL não tem reservas on-chain: %+v", brl)
+	}
+
+	proof, err := svc.Proof(context.Background(), bob, "ETH", nil)
+	if err != nil {
+		t.Fatalf("erro na prova: %v", err)
+	}
+	if proof.Proof.Root.Hash != eth.Root || !proof.Proof.Leaf.Sum.Equal(decimal.RequireFromString("2.25")) {
+		t.Fatalf("prova não corresponde à raiz publicada: %+v", proof.Proof)
+	}
+	if proof.Proof.Leaf.ID != merkle.AccountLeafID(bob.String(), proof.Nonce) {
+		t.Fatalf("id da folha deveria derivar de user_id e nonce")
+	}
+
+	// a prova sobrevive à serialização entregue ao verificador
+	raw, _ := json.Marshal(proof)
+	var decoded ReservesProof
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("erro ao decodificar prova: %v", err)
+	}
+	if err := merkle.Verify(decoded.Proof); err != nil {
+		t.Fatalf("prova deveria verificar: %v", err)
+	}
+
+	if _, err := svc.Proof(context.Background(), carol, "BTC", nil); !errors.Is(err, ErrAccountNotInSnapshot) {
+		t.Fatalf("esperado ErrAccountNotInSnapshot, veio %v", err)
+	}
+	missing := uuid.New()
+	if _, err := svc.Proof(context.Background(), bob, "ETH", &missing); !errors.Is(err, ErrReservesSnapshotNotFound) {
+		t.Fatalf("esperado ErrReservesSnapshotNotFound, veio %v", err)
+	}
+}
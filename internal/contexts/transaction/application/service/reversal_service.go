@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// ReversalService estorna transações concluídas, total ou parcialmente. Cada estorno é uma nova
+// transação (tipo reversal, ParentID = original) com sua própria movimentação de saldo.
+type ReversalService struct {
+	reversals  repository.ReversalRepository
+	txRepo     repository.TransactionRepository
+	walletRepo userRepo.WalletRepository
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewReversalService cria o serviço de estornos
+func NewReversalService(
+	reversals repository.ReversalRepository,
+	txRepo repository.TransactionRepository,
+	walletRepo userRepo.WalletRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *ReversalService {
+	return &ReversalService{
+		reversals:  reversals,
+		txRepo:     txRepo,
+		walletRepo: walletRepo,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// ReversalRequest pedido de estorno feito por um operador
+type ReversalRequest struct {
+	OriginalID uuid.UUID
+	Amount     decimal.Decimal // zero = todo o valor ainda não estornado
+	Reason     entity.ReversalReason
+	Note       string
+	OperatorID uuid.UUID
+}
+
+// ReversalSummary estornos de uma transação e o valor que ainda pode ser estornado
+type ReversalSummary struct {
+	Original      *entity.Transaction `json:"original"`
+	Reversals     []*entity.Reversal  `json:"reversals"`
+	TotalReversed decimal.Decimal     `json:"total_reversed"`
+	Remaining     decimal.Decimal     `json:"remaining"`
+}
+
+// Reverse estorna a transação: depósitos são debitados do cliente; transferências e taxas são
+// debitadas do destinatário e devolvidas ao pagador
+func (s *ReversalService) Reverse(ctx context.Context, req ReversalRequest) (*entity.Reversal, *entity.Transaction, error) {
+	original, err := s.txRepo.FindByID(ctx, req.OriginalID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if original == nil {
+		return nil, nil, ErrTransactionNotFound
+	}
+	reversed, err := s.reversals.TotalReversed(ctx, original.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	limit := entity.ReversibleAmount(original)
+
+	amount := req.Amount
+	if amount.IsZero() {
+		amount = limit.Sub(reversed)
+		if !amount.IsPositive() && limit.IsPositive() {
+			return nil, nil, entity.ErrReversalExceedsOriginal
+		}
+	} else if amount.IsNegative() {
+		return nil, nil, ErrInvalidAmount
+	}
+
+	tx := entity.NewTransaction(original.UserID, entity.TransactionTypeReversal, amount)
+	tx.ParentID = &original.ID
+	rev := entity.NewReversal(original, tx, req.Reason, req.Note, req.OperatorID)
+	if err := entity.RestoreTransactionAggregate(original).Reverse(rev.ID, amount, reversed, req.Reason); err != nil {
+		return nil, nil, err
+	}
+
+	debit, credit, err := s.counterparties(ctx, original)
+	if err != nil {
+		return nil, nil, err
+	}
+	if debit.Balance < amount.InexactFloat64() {
+		return nil, nil, ErrInsufficientBalance
+	}
+	tx.FromAddress = debit.Address
+	if credit != nil {
+		tx.ToAddress = credit.Address
+	}
+
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		s.logger.Error("failed to create reversal transaction", zap.Error(err))
+		return nil, nil, err
+	}
+	// O limite é conferido de novo de forma atômica: estornos simultâneos não excedem o original
+	if err := s.reversals.Create(ctx, rev, limit); err != nil {
+		s.fail(ctx, tx, "failed to record reversal")
+		return nil, nil, err
+	}
+
+	if err := adjustWallet(ctx, s.walletRepo, debit.UserID, -amount.InexactFloat64(), 0); err != nil {
+		s.fail(ctx, tx, "failed to debit wallet")
+		return nil, nil, err
+	}
+	if credit != nil {
+		if err := adjustWallet(ctx, s.walletRepo, credit.UserID, amount.InexactFloat64(), 0); err != nil {
+			// Desfaz o débito
+			_ = adjustWallet(ctx, s.walletRepo, debit.UserID, amount.InexactFloat64(), 0)
+			s.fail(ctx, tx, "failed to credit wallet")
+			return nil, nil, err
+		}
+	}
+
+	tx.Complete("reversal-" + tx.ID.String())
+	_ = s.txRepo.Update(ctx, tx)
+
+	total := reversed.Add(amount)
+	s.eventBus.PublishAsync(ctx, events.NewTransactionReversedEvent(
+		rev.ID, original.ID, tx.ID, original.UserID, req.OperatorID, string(original.Type), amount, total, string(req.Reason), total.Equal(limit),
+	))
+	s.logger.Info("transaction reversed",
+		zap.String("original_id", original.ID.String()),
+		zap.String("reversal_tx_id", tx.ID.String()),
+		zap.String("amount", amount.String()),
+		zap.String("reason", string(req.Reason)),
+		zap.String("operator_id", req.OperatorID.String()),
+	)
+	return rev, tx, nil
+}
+
+// List retorna os estornos da transação e o valor restante estornável
+func (s *ReversalService) List(ctx context.Context, originalID uuid.UUID) (*ReversalSummary, error) {
+	original, err := s.txRepo.FindByID(ctx, originalID)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, ErrTransactionNotFound
+	}
+	list, err := s.reversals.ListByOriginal(ctx, originalID)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.reversals.TotalReversed(ctx, originalID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := entity.ReversibleAmount(original).Sub(total)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+	if list == nil {
+		list = []*entity.Reversal{}
+	}
+	return &ReversalSummary{Original: original, Reversals: list, TotalReversed: total, Remaining: remaining}, nil
+}
+
+// counterparties resolve a carteira debitada e a creditada (nil em depósitos, cujo valor volta à origem externa)
+func (s *ReversalService) counterparties(ctx context.Context, original *entity.Transaction) (*userEntity.Wallet, *userEntity.Wallet, error) {
+	payer, err := s.walletRepo.FindByUserID(ctx, original.UserID)
+	if err != nil || payer == nil {
+		return nil, nil, ErrWalletNotFound
+	}
+	if original.Type == entity.TransactionTypeDeposit {
+		return payer, nil, nil
+	}
+	recipient, err := s.walletRepo.FindByAddress(ctx, original.ToAddress)
+	if err != nil || recipient == nil {
+		return nil, nil, ErrWalletNotFound
+	}
+	return recipient, payer, nil
+}
+
+func (s *ReversalService) fail(ctx context.Context, tx *entity.Transaction, reason string) {
+	s.logger.Error("reversal failed", zap.String("tx_id", tx.ID.String()), zap.String("reason", reason))
+	tx.Fail(reason)
+	_ = s.txRepo.Update(ctx, tx)
+}
+
+// WithReversals habilita o estorno de transações concluídas pelos operadores
+func (s *TransactionService) WithReversals(reversals *ReversalService) *TransactionService {
+	s.reversals = reversals
+	return s
+}
+
+// Reversals retorna o serviço de estornos (nil se desabilitado)
+func (s *TransactionService) Reversals() *ReversalService {
+	return s.reversals
+}
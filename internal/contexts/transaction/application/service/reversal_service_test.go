@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memReversalRepo struct {
+	mu        sync.Mutex
+	txs       *memTxnRepo
+	reversals []*entity.Reversal
+}
+
+func (m *memReversalRepo) Create(ctx context.Context, r *entity.Reversal, limit decimal.Decimal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.total(r.OriginalID).Add(r.Amount).GreaterThan(limit) {
+		return entity.ErrReversalExceedsOriginal
+	}
+	cp := *r
+	m.reversals = append(m.reversals, &cp)
+	return nil
+}
+
+func (m *memReversalRepo) ListByOriginal(ctx context.Context, originalID uuid.UUID) ([]*entity.Reversal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Reversal
+	for _, r := range m.reversals {
+		if r.OriginalID == originalID {
+			cp := *r
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *memReversalRepo) TotalReversed(ctx context.Context, originalID uuid.UUID) (decimal.Decimal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total(originalID), nil
+}
+
+func (m *memReversalRepo) total(originalID uuid.UUID) decimal.Decimal {
+	total := decimal.Zero
+	for _, r := range m.reversals {
+		if tx := m.txs.txs[r.TransactionID]; r.OriginalID == originalID && (tx == nil || tx.Status != entity.TransactionStatusFailed) {
+			total = total.Add(r.Amount)
+		}
+	}
+	return total
+}
+
+func setupReversals(t *testing.T, balance float64) (*TransactionService, *ReversalService, *memReversalRepo, *memWalletRepo, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	svc, txr, wr, uid := setupService(t, balance)
+	recipient := uuid.New()
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: recipient, Address: "RCPT"})
+
+	repo := &memReversalRepo{txs: txr}
+	reversals := NewReversalService(repo, txr, wr, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	svc.WithReversals(reversals)
+	return svc, reversals, repo, wr, uid, recipient
+}
+
+func TestReversalService_PartialThenFullTransferReversal(t *testing.T) {
+	svc, reversals, _, wr, uid, recipient := setupReversals(t, 1000)
+	ctx := context.Background()
+	operator := uuid.New()
+
+	orig, err := svc.ProcessTransfer(ctx, uid, recipient, decimal.NewFromInt(400))
+	if err != nil {
+		t.Fatalf("transferência falhou: %v", err)
+	}
+
+	rev, tx, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: orig.ID, Amount: decimal.NewFromInt(150), Reason: entity.ReversalCustomerRequest, OperatorID: operator})
+	if err != nil {
+		t.Fatalf("estorno parcial falhou: %v", err)
+	}
+	if tx.Type != entity.TransactionTypeReversal || tx.Status != entity.TransactionStatusCompleted || tx.ParentID == nil || *tx.ParentID != orig.ID {
+		t.Fatalf("transação de estorno inválida: %+v", tx)
+	}
+	if rev.TransactionID != tx.ID || !rev.Amount.Equal(decimal.NewFromInt(150)) || rev.OperatorID != operator {
+		t.Fatalf("registro de estorno inválido: %+v", rev)
+	}
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 750 {
+		t.Fatalf("pagador deveria ter 750, tem %v", w.Balance)
+	}
+	if w, _ := wr.FindByUserID(ctx, recipient); w.Balance != 250 {
+		t.Fatalf("destinatário deveria ter 250, tem %v", w.Balance)
+	}
+
+	// Sem valor estorna o restante
+	if _, tx, err = reversals.Reverse(ctx, ReversalRequest{OriginalID: orig.ID, Reason: entity.ReversalOperationalError, OperatorID: operator}); err != nil {
+		t.Fatalf("estorno do restante falhou: %v", err)
+	}
+	if !tx.Amount.Equal(decimal.NewFromInt(250)) {
+		t.Fatalf("restante esperado 250, obtido %s", tx.Amount)
+	}
+	if w, _ := wr.FindByUserID(ctx, recipient); w.Balance != 0 {
+		t.Fatalf("destinatário deveria ficar zerado, tem %v", w.Balance)
+	}
+
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: orig.ID, Amount: decimal.NewFromInt(1), Reason: entity.ReversalOther, OperatorID: operator}); !errors.Is(err, entity.ErrReversalExceedsOriginal) {
+		t.Fatalf("esperado ErrReversalExceedsOriginal, obtido %v", err)
+	}
+	summary, err := reversals.List(ctx, orig.ID)
+	if err != nil || len(summary.Reversals) != 2 || !summary.TotalReversed.Equal(decimal.NewFromInt(400)) || !summary.Remaining.IsZero() {
+		t.Fatalf("resumo inesperado: %+v, %v", summary, err)
+	}
+}
+
+func TestReversalService_DepositReversalDebitsCustomer(t *testing.T) {
+	svc, reversals, _, wr, uid, _ := setupReversals(t, 0)
+	ctx := context.Background()
+	if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(100), ""); err != nil {
+		t.Fatalf("depósito falhou: %v", err)
+	}
+	var deposit *entity.Transaction
+	for _, tx := range svc.txRepo.(*memTxnRepo).txs {
+		deposit = tx
+	}
+
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: deposit.ID, Amount: decimal.NewFromInt(101), Reason: entity.ReversalDuplicate}); !errors.Is(err, entity.ErrReversalExceedsOriginal) {
+		t.Fatalf("estorno acima do original deveria falhar, obtido %v", err)
+	}
+
+	_ = wr.UpdateBalance(ctx, uid, 30)
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: deposit.ID, Amount: decimal.NewFromInt(60), Reason: entity.ReversalDuplicate}); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("esperado ErrInsufficientBalance, obtido %v", err)
+	}
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: deposit.ID, Amount: decimal.NewFromInt(30), Reason: entity.ReversalDuplicate}); err != nil {
+		t.Fatalf("estorno falhou: %v", err)
+	}
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 0 {
+		t.Fatalf("saldo deveria ser 0, é %v", w.Balance)
+	}
+}
+
+func TestReversalService_Rejections(t *testing.T) {
+	svc, reversals, repo, _, uid, recipient := setupReversals(t, 1000)
+	ctx := context.Background()
+
+	withdraw := entity.NewTransaction(uid, entity.TransactionTypeWithdraw, decimal.NewFromInt(10))
+	withdraw.Complete("0xhash")
+	_ = svc.txRepo.Create(ctx, withdraw)
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: withdraw.ID, Reason: entity.ReversalFraud}); !errors.Is(err, entity.ErrNotReversible) {
+		t.Fatalf("saque não deveria ser estornável, obtido %v", err)
+	}
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: uuid.New(), Reason: entity.ReversalFraud}); !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("esperado ErrTransactionNotFound, obtido %v", err)
+	}
+
+	orig, err := svc.ProcessTransfer(ctx, uid, recipient, decimal.NewFromInt(100))
+	if err != nil {
+		t.Fatalf("transferência falhou: %v", err)
+	}
+	if _, _, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: orig.ID, Reason: "whatever"}); !errors.Is(err, entity.ErrInvalidReversalReason) {
+		t.Fatalf("esperado ErrInvalidReversalReason, obtido %v", err)
+	}
+
+	// Estorno cuja transação falhou não consome o limite
+	_, tx, err := reversals.Reverse(ctx, ReversalRequest{OriginalID: orig.ID, Reason: entity.ReversalFraud})
+	if err != nil {
+		t.Fatalf("estorno falhou: %v", err)
+	}
+	tx.Fail("chargeback cancelado")
+	if total, _ := repo.TotalReversed(ctx, orig.ID); !total.IsZero() {
+		t.Fatalf("estorno falho não deveria contar, total %s", total)
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"go.uber.org/zap"
+)
+
+// WithMonitoring habilita a triagem de fraude/AML antes da execução das transações
+func (s *TransactionService) WithMonitoring(monitor *complianceSvc.MonitoringService) *TransactionService {
+	s.monitor = monitor
+	return s
+}
+
+// Monitoring retorna o serviço de monitoramento de risco (nil se desabilitado)
+func (s *TransactionService) Monitoring() *complianceSvc.MonitoringService {
+	return s.monitor
+}
+
+// screen aplica as regras síncronas e grava o score na transação. Transações bloqueadas
+// são persistidas como falhas para manter a trilha e o erro *BlockedError é retornado.
+func (s *TransactionService) screen(ctx context.Context, tx *entity.Transaction) error {
+	if s.monitor == nil {
+		return nil
+	}
+
+	assessment, err := s.monitor.Screen(ctx, complianceEntity.Subject{
+		TransactionID: tx.ID,
+		UserID:        tx.UserID,
+		Type:          string(tx.Type),
+		Amount:        tx.Amount,
+		Destination:   tx.ToAddress,
+		OccurredAt:    tx.CreatedAt,
+	})
+	if assessment != nil {
+		tx.RiskScore = assessment.Score
+	}
+	if err == nil {
+		return nil
+	}
+
+	tx.Fail("blocked by risk rules")
+	if cerr := s.txRepo.Create(ctx, tx); cerr != nil {
+		s.logger.Error("failed to record blocked transaction", zap.String("tx_id", tx.ID.String()), zap.Error(cerr))
+	}
+	return err
+}
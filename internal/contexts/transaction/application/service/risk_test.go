@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+	complianceDomain "financial-system-pro/internal/contexts/compliance/domain/service"
+	"financial-system-pro/internal/contexts/compliance/infrastructure/signals"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type nopAssessmentRepo struct{}
+
+func (nopAssessmentRepo) Save(ctx context.Context, a *complianceEntity.Assessment) error { return nil }
+func (nopAssessmentRepo) FindByTransactionID(ctx context.Context, id uuid.UUID) ([]*complianceEntity.Assessment, error) {
+	return nil, nil
+}
+
+type countingCaseRepo struct{ created int }
+
+func (r *countingCaseRepo) Create(ctx context.Context, c *complianceEntity.Case) error {
+	r.created++
+	return nil
+}
+func (r *countingCaseRepo) FindByID(ctx context.Context, id uuid.UUID) (*complianceEntity.Case, error) {
+	return nil, nil
+}
+func (r *countingCaseRepo) List(ctx context.Context, status complianceEntity.CaseStatus, limit int) ([]*complianceEntity.Case, error) {
+	return nil, nil
+}
+func (r *countingCaseRepo) Update(ctx context.Context, c *complianceEntity.Case) error { return nil }
+func (r *countingCaseRepo) AddNote(ctx context.Context, caseID uuid.UUID, n *complianceEntity.CaseNote) error {
+	return nil
+}
+
+func TestProcessWithdraw_BlockedByRiskRules(t *testing.T) {
+	svc, txr, wr, uid := setupService(t, 1000)
+	ctx := context.Background()
+
+	velocity := complianceEntity.DefaultRuleDefinitions()[0]
+	velocity.MaxCount = 2
+	cases := &countingCaseRepo{}
+	svc.WithMonitoring(complianceSvc.NewMonitoringService(
+		complianceDomain.NewRuleEngine([]complianceEntity.RuleDefinition{velocity}),
+		signals.NewRepositorySignalProvider(txr, nil),
+		nopAssessmentRepo{},
+		cases,
+		events.NewInMemoryBus(zap.NewNop()),
+		zap.NewNop(),
+	))
+
+	for i := 0; i < 2; i++ {
+		if err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(10)); err != nil {
+			t.Fatalf("saque %d deveria passar: %v", i, err)
+		}
+	}
+
+	err := svc.ProcessWithdraw(ctx, uid, decimal.NewFromInt(10))
+	if !errors.Is(err, complianceSvc.ErrTransactionBlocked) {
+		t.Fatalf("esperado bloqueio por risco, obtido %v", err)
+	}
+	if cases.created != 1 {
+		t.Fatalf("esperado 1 caso aberto, obtido %d", cases.created)
+	}
+
+	w, _ := wr.FindByUserID(ctx, uid)
+	if w.Balance != 980 {
+		t.Fatalf("saldo não deveria ser debitado no bloqueio: %v", w.Balance)
+	}
+	history, _ := txr.FindByUserID(ctx, uid)
+	var blocked *entity.Transaction
+	for _, tx := range history {
+		if tx.Status == entity.TransactionStatusFailed {
+			blocked = tx
+		}
+	}
+	if blocked == nil || blocked.RiskScore != velocity.Score {
+		t.Fatalf("transação bloqueada deveria ser registrada com o score: %+v", blocked)
+	}
+}
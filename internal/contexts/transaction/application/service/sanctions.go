@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// WithSanctions habilita a triagem de sanções dos destinos de saque; o serviço também é exposto
+// nas rotas de operador
+func (s *TransactionService) WithSanctions(screener *complianceSvc.SanctionsScreener) *TransactionService {
+	s.sanctions = screener
+	return s
+}
+
+// Sanctions retorna o serviço de triagem de sanções (nil se desabilitado)
+func (s *TransactionService) Sanctions() *complianceSvc.SanctionsScreener {
+	return s.sanctions
+}
+
+// screenDestination triagem de sanções do destino de um saque antes de qualquer débito. Destino
+// bloqueado retorna *SanctionsHitError e lista indisponível ErrSanctionsListUnavailable (fail-closed).
+func (s *TransactionService) screenDestination(ctx context.Context, userID uuid.UUID, chain, address string) error {
+	if s.sanctions == nil || address == "" {
+		return nil
+	}
+	if _, err := s.sanctions.Screen(ctx, complianceSvc.ScreeningRequest{
+		UserID:    &userID,
+		Direction: complianceEntity.DirectionOutbound,
+		Chain:     chain,
+		Address:   address,
+	}); err != nil {
+		s.logger.Warn("withdraw rejected by sanctions screening", zap.String("user_id", userID.String()), zap.Error(err))
+		return err
+	}
+	return nil
+}
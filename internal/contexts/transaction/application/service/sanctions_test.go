@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type stubSanctionsSource struct {
+	list *complianceEntity.SanctionsList
+}
+
+func (s stubSanctionsSource) Load(ctx context.Context) (*complianceEntity.SanctionsList, error) {
+	return s.list, nil
+}
+
+type nopScreeningRepo struct{}
+
+func (nopScreeningRepo) Save(ctx context.Context, _ *complianceEntity.ScreeningResult) error {
+	return nil
+}
+func (nopScreeningRepo) List(ctx context.Context, _ complianceEntity.ScreeningDecision, _ int) ([]*complianceEntity.ScreeningResult, error) {
+	return nil, nil
+}
+
+func TestProcessWithdrawTo_ScreensDestination(t *testing.T) {
+	svc, _, wr, uid := setupService(t, 100)
+	screener := complianceSvc.NewSanctionsScreener(stubSanctionsSource{list: complianceEntity.NewSanctionsList("v1", time.Now(), nil, []complianceEntity.SanctionsEntry{
+		{Source: "ofac_sdn", Name: "Sanctioned", Chain: "ETH", Address: "0xsanctioned"},
+	})}, nopScreeningRepo{}, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	svc.WithSanctions(screener)
+
+	// Sem lista carregada o saque é recusado (fail-closed)
+	if _, err := svc.ProcessWithdrawTo(context.Background(), uid, decimal.NewFromInt(10), "ethereum", "0xclean"); !errors.Is(err, complianceSvc.ErrSanctionsListUnavailable) {
+		t.Fatalf("esperado ErrSanctionsListUnavailable, obtido %v", err)
+	}
+	if err := screener.Reload(context.Background()); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	if _, err := svc.ProcessWithdrawTo(context.Background(), uid, decimal.NewFromInt(10), "ethereum", "0xsanctioned"); !errors.Is(err, complianceSvc.ErrSanctionedAddress) {
+		t.Fatalf("esperado bloqueio por sanções, obtido %v", err)
+	}
+	if w, _ := wr.FindByUserID(context.Background(), uid); w.Balance != 100 {
+		t.Fatalf("destino bloqueado não pode debitar a carteira, saldo %v", w.Balance)
+	}
+
+	if _, err := svc.ProcessWithdrawTo(context.Background(), uid, decimal.NewFromInt(10), "ethereum", "0xclean"); err != nil {
+		t.Fatalf("destino sem coincidência deveria passar: %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSchedulePollInterval intervalo padrão da varredura de agendamentos vencidos
+	DefaultSchedulePollInterval = time.Minute
+	// DefaultScheduleMaxRetries novas tentativas por ocorrência quando falta saldo
+	DefaultScheduleMaxRetries = 3
+	// DefaultScheduleRetryBackoff espera antes da primeira nova tentativa (dobra a cada falha)
+	DefaultScheduleRetryBackoff = time.Hour
+)
+
+// ErrScheduleNotFound agendamento inexistente ou de outro usuário
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ScheduledRun execução vencida entregue à fila; identifica a tentativa da ocorrência
+type ScheduledRun struct {
+	ScheduleID uuid.UUID `json:"schedule_id"`
+	DueAt      time.Time `json:"due_at"`
+	Attempt    int       `json:"attempt"`
+}
+
+// TaskID identificador estável da execução, usado para deduplicar tarefas na fila
+func (r ScheduledRun) TaskID() string {
+	return fmt.Sprintf("schedule:%s:%d:%d", r.ScheduleID, r.DueAt.Unix(), r.Attempt)
+}
+
+// ScheduleDispatcher entrega execuções vencidas para processamento assíncrono (ex.: asynq)
+type ScheduleDispatcher interface {
+	Dispatch(ctx context.Context, run ScheduledRun) error
+}
+
+// ScheduleNotifier avisa o cliente quando uma execução agendada falha
+type ScheduleNotifier interface {
+	// NotifyExecutionFailed retryAt nil indica que a ocorrência foi pulada
+	NotifyExecutionFailed(ctx context.Context, s *entity.ScheduledTransfer, exec *entity.ScheduleExecution, retryAt *time.Time) error
+}
+
+// ScheduleService gerencia transferências e saques agendados: a varredura entrega as execuções
+// vencidas à fila e o worker executa pelo TransactionService, com novas tentativas quando falta saldo
+type ScheduleService struct {
+	schedules  repository.ScheduleRepository
+	txns       *TransactionService
+	dispatcher ScheduleDispatcher
+	notifier   ScheduleNotifier
+	maxRetries int
+	backoff    time.Duration
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewScheduleService cria o serviço de agendamentos; sem dispatcher as execuções rodam na própria varredura
+func NewScheduleService(
+	schedules repository.ScheduleRepository,
+	notifier ScheduleNotifier,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *ScheduleService {
+	return &ScheduleService{
+		schedules:  schedules,
+		notifier:   notifier,
+		maxRetries: DefaultScheduleMaxRetries,
+		backoff:    DefaultScheduleRetryBackoff,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// WithDispatcher entrega as execuções vencidas à fila em vez de executá-las na varredura
+func (s *ScheduleService) WithDispatcher(dispatcher ScheduleDispatcher) *ScheduleService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithRetryPolicy define as novas tentativas por ocorrência e o backoff inicial
+func (s *ScheduleService) WithRetryPolicy(maxRetries int, backoff time.Duration) *ScheduleService {
+	if maxRetries >= 0 {
+		s.maxRetries = maxRetries
+	}
+	if backoff > 0 {
+		s.backoff = backoff
+	}
+	return s
+}
+
+// Create valida e grava um novo agendamento do usuário
+func (s *ScheduleService) Create(ctx context.Context, spec entity.ScheduleSpec) (*entity.ScheduledTransfer, error) {
+	if spec.MaxRetries == 0 {
+		spec.MaxRetries = s.maxRetries
+	}
+	sched, err := entity.NewScheduledTransfer(spec, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if sched.Kind == entity.ScheduleKindTransfer && s.txns != nil {
+		wallet, err := s.txns.walletRepo.FindByUserID(ctx, *sched.ToUserID)
+		if err != nil {
+			return nil, err
+		}
+		if wallet == nil {
+			return nil, ErrWalletNotFound
+		}
+	}
+	if err := s.schedules.Create(ctx, sched); err != nil {
+		return nil, err
+	}
+	s.logger.Info("transfer scheduled",
+		zap.String("schedule_id", sched.ID.String()),
+		zap.String("user_id", sched.UserID.String()),
+		zap.String("type", string(sched.Kind)),
+		zap.String("recurrence", sched.Recurrence),
+		zap.Timep("next_run_at", sched.NextRunAt),
+	)
+	return sched, nil
+}
+
+// Get retorna o agendamento do usuário
+func (s *ScheduleService) Get(ctx context.Context, userID, id uuid.UUID) (*entity.ScheduledTransfer, error) {
+	sched, err := s.schedules.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sched == nil || sched.UserID != userID {
+		return nil, ErrScheduleNotFound
+	}
+	return sched, nil
+}
+
+// List lista os agendamentos do usuário
+func (s *ScheduleService) List(ctx context.Context, userID uuid.UUID) ([]*entity.ScheduledTransfer, error) {
+	return s.schedules.ListByUser(ctx, userID)
+}
+
+// History lista as tentativas de execução do agendamento
+func (s *ScheduleService) History(ctx context.Context, userID, id uuid.UUID, limit int) ([]*entity.ScheduleExecution, error) {
+	if _, err := s.Get(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.schedules.ListExecutions(ctx, id, limit)
+}
+
+// Pause suspende o agendamento
+func (s *ScheduleService) Pause(ctx context.Context, userID, id uuid.UUID) (*entity.ScheduledTransfer, error) {
+	return s.transition(ctx, userID, id, (*entity.ScheduledTransfer).Pause)
+}
+
+// Resume reativa o agendamento pausado
+func (s *ScheduleService) Resume(ctx context.Context, userID, id uuid.UUID) (*entity.ScheduledTransfer, error) {
+	return s.transition(ctx, userID, id, (*entity.ScheduledTransfer).Resume)
+}
+
+// Cancel encerra o agendamento
+func (s *ScheduleService) Cancel(ctx context.Context, userID, id uuid.UUID) (*entity.ScheduledTransfer, error) {
+	return s.transition(ctx, userID, id, (*entity.ScheduledTransfer).Cancel)
+}
+
+func (s *ScheduleService) transition(ctx context.Context, userID, id uuid.UUID, apply func(*entity.ScheduledTransfer, time.Time) error) (*entity.ScheduledTransfer, error) {
+	sched, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(sched, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.schedules.Update(ctx, sched); err != nil {
+		return nil, err
+	}
+	s.logger.Info("schedule status changed",
+		zap.String("schedule_id", sched.ID.String()),
+		zap.String("status", string(sched.Status)),
+	)
+	return sched, nil
+}
+
+// DispatchDue entrega à fila (ou executa, sem dispatcher) os agendamentos vencidos
+func (s *ScheduleService) DispatchDue(ctx context.Context) (int, error) {
+	due, err := s.schedules.ListDue(ctx, s.now(), 100)
+	if err != nil {
+		return 0, err
+	}
+	dispatched := 0
+	for _, sched := range due {
+		run := ScheduledRun{ScheduleID: sched.ID, DueAt: sched.DueAt(), Attempt: sched.Attempts + 1}
+		if s.dispatcher == nil {
+			err = s.Execute(ctx, run)
+		} else {
+			err = s.dispatcher.Dispatch(ctx, run)
+		}
+		if err != nil {
+			s.logger.Error("failed to dispatch scheduled transfer", zap.String("schedule_id", sched.ID.String()), zap.Error(err))
+			continue
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+// Run varre os agendamentos vencidos a cada intervalo até o contexto ser cancelado
+func (s *ScheduleService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSchedulePollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchDue(ctx); err != nil {
+				s.logger.Error("schedule sweep failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Execute processa a tentativa entregue pela fila. Execuções obsoletas (agendamento pausado,
+// cancelado ou já avançado) e duplicadas são ignoradas; erros retornados são de infraestrutura
+// e podem ser repetidos pela fila.
+func (s *ScheduleService) Execute(ctx context.Context, run ScheduledRun) error {
+	if s.txns == nil {
+		return errors.New("schedule service is not attached to a transaction service")
+	}
+	sched, err := s.schedules.FindByID(ctx, run.ScheduleID)
+	if err != nil {
+		return err
+	}
+	if sched == nil || !sched.IsDue(s.now()) || !sched.DueAt().Equal(run.DueAt) || sched.Attempts+1 != run.Attempt {
+		s.logger.Debug("stale scheduled run skipped", zap.String("schedule_id", run.ScheduleID.String()))
+		return nil
+	}
+
+	exec := entity.NewScheduleExecution(sched, s.now())
+	if err := s.schedules.StartExecution(ctx, exec); err != nil {
+		if errors.Is(err, entity.ErrExecutionDuplicate) {
+			return nil
+		}
+		return err
+	}
+
+	tx, execErr := s.perform(ctx, sched)
+
+	var (
+		willRetry bool
+		retryAt   *time.Time
+	)
+	for attempt := 0; ; attempt++ {
+		now := s.now()
+		if execErr == nil {
+			sched.RecordSuccess(now)
+		} else {
+			willRetry = sched.RecordFailure(now, retryableScheduleError(execErr), s.backoff)
+			retryAt = sched.RetryAt
+		}
+		err = s.schedules.Update(ctx, sched)
+		if !errors.Is(err, entity.ErrScheduleConflict) || attempt == 2 {
+			break
+		}
+		// Pausado ou cancelado durante a execução: reaplica o resultado sobre o estado atual
+		fresh, findErr := s.schedules.FindByID(ctx, sched.ID)
+		if findErr != nil || fresh == nil {
+			break
+		}
+		sched = fresh
+	}
+	if err != nil {
+		s.logger.Error("failed to record scheduled run", zap.String("schedule_id", sched.ID.String()), zap.Error(err))
+	}
+
+	now := s.now()
+	switch {
+	case execErr == nil:
+		exec.Finish(entity.ExecutionSucceeded, &tx.ID, "", now)
+	case willRetry:
+		exec.Finish(entity.ExecutionRetrying, nil, execErr.Error(), now)
+	default:
+		exec.Finish(entity.ExecutionFailed, nil, execErr.Error(), now)
+	}
+	if err := s.schedules.FinishExecution(ctx, exec); err != nil {
+		s.logger.Error("failed to record schedule execution", zap.String("execution_id", exec.ID.String()), zap.Error(err))
+	}
+
+	if execErr == nil {
+		s.eventBus.PublishAsync(ctx, events.NewScheduledTransferExecutedEvent(sched.ID, sched.UserID, tx.ID, string(sched.Kind), sched.Amount, exec.Occurrence))
+		s.logger.Info("scheduled transfer executed",
+			zap.String("schedule_id", sched.ID.String()),
+			zap.String("tx_id", tx.ID.String()),
+			zap.Timep("next_run_at", sched.NextRunAt),
+		)
+		return nil
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewScheduledTransferFailedEvent(sched.ID, sched.UserID, string(sched.Kind), sched.Amount, exec.Occurrence, exec.Attempt, execErr.Error(), retryAt))
+	if s.notifier != nil {
+		if err := s.notifier.NotifyExecutionFailed(ctx, sched, exec, retryAt); err != nil {
+			s.logger.Warn("failed to notify schedule failure", zap.String("schedule_id", sched.ID.String()), zap.Error(err))
+		}
+	}
+	s.logger.Warn("scheduled transfer failed",
+		zap.String("schedule_id", sched.ID.String()),
+		zap.Int("attempt", exec.Attempt),
+		zap.Bool("will_retry", willRetry),
+		zap.Error(execErr),
+	)
+	return nil
+}
+
+// perform executa a operação pelo fluxo normal (limites, triagem, taxas e aprovações)
+func (s *ScheduleService) perform(ctx context.Context, sched *entity.ScheduledTransfer) (*entity.Transaction, error) {
+	switch sched.Kind {
+	case entity.ScheduleKindTransfer:
+		return s.txns.ProcessTransfer(ctx, sched.UserID, *sched.ToUserID, sched.Amount)
+	case entity.ScheduleKindWithdraw:
+		return s.txns.ProcessWithdrawTo(ctx, sched.UserID, sched.Amount, sched.Chain, sched.ToAddress)
+	}
+	return nil, fmt.Errorf("%w: unknown type %s", entity.ErrInvalidSchedule, sched.Kind)
+}
+
+// retryableScheduleError falhas que podem se resolver sozinhas até a próxima tentativa
+func retryableScheduleError(err error) bool {
+	var limitErr *LimitExceededError
+	return errors.Is(err, ErrInsufficientBalance) || errors.Is(err, ErrCircuitBreakerOpen) || errors.As(err, &limitErr) ||
+		errors.Is(err, entity.ErrCreditLinePastDue) || errors.Is(err, entity.ErrCreditLimitExceeded)
+}
+
+// WithSchedules habilita transferências e saques agendados executados por este serviço
+func (s *TransactionService) WithSchedules(schedules *ScheduleService) *TransactionService {
+	s.schedules = schedules
+	schedules.txns = s
+	return s
+}
+
+// Schedules retorna o serviço de agendamentos (nil se desabilitado)
+func (s *TransactionService) Schedules() *ScheduleService {
+	return s.schedules
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memScheduleRepo struct {
+	mu         sync.Mutex
+	schedules  map[uuid.UUID]*entity.ScheduledTransfer
+	executions map[uuid.UUID]*entity.ScheduleExecution
+}
+
+func newMemScheduleRepo() *memScheduleRepo {
+	return &memScheduleRepo{
+		schedules:  make(map[uuid.UUID]*entity.ScheduledTransfer),
+		executions: make(map[uuid.UUID]*entity.ScheduleExecution),
+	}
+}
+
+func (m *memScheduleRepo) Create(ctx context.Context, s *entity.ScheduledTransfer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *s
+	m.schedules[s.ID] = &cp
+	return nil
+}
+
+func (m *memScheduleRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.ScheduledTransfer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.schedules[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (m *memScheduleRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.ScheduledTransfer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.ScheduledTransfer
+	for _, s := range m.schedules {
+		if s.UserID == userID {
+			cp := *s
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *memScheduleRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ScheduledTransfer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.ScheduledTransfer
+	for _, s := range m.schedules {
+		if s.IsDue(now) {
+			cp := *s
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *memScheduleRepo) Update(ctx context.Context, s *entity.ScheduledTransfer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.schedules[s.ID]
+	if !ok || stored.Version != s.Version {
+		return entity.ErrScheduleConflict
+	}
+	s.Version++
+	cp := *s
+	m.schedules[s.ID] = &cp
+	return nil
+}
+
+func (m *memScheduleRepo) StartExecution(ctx context.Context, exec *entity.ScheduleExecution) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.executions {
+		if e.ScheduleID == exec.ScheduleID && e.Occurrence.Equal(exec.Occurrence) && e.Attempt == exec.Attempt {
+			return entity.ErrExecutionDuplicate
+		}
+	}
+	cp := *exec
+	m.executions[exec.ID] = &cp
+	return nil
+}
+
+func (m *memScheduleRepo) FinishExecution(ctx context.Context, exec *entity.ScheduleExecution) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *exec
+	m.executions[exec.ID] = &cp
+	return nil
+}
+
+func (m *memScheduleRepo) ListExecutions(ctx context.Context, scheduleID uuid.UUID, limit int) ([]*entity.ScheduleExecution, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.ScheduleExecution
+	for _, e := range m.executions {
+		if e.ScheduleID == scheduleID {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartedAt.After(out[j].StartedAt) })
+	return out, nil
+}
+
+type recordingScheduleNotifier struct {
+	failures []*time.Time
+}
+
+func (n *recordingScheduleNotifier) NotifyExecutionFailed(ctx context.Context, s *entity.ScheduledTransfer, exec *entity.ScheduleExecution, retryAt *time.Time) error {
+	n.failures = append(n.failures, retryAt)
+	return nil
+}
+
+type recordingDispatcher struct {
+	runs []ScheduledRun
+}
+
+func (d *recordingDispatcher) Dispatch(ctx context.Context, run ScheduledRun) error {
+	d.runs = append(d.runs, run)
+	return nil
+}
+
+func setupSchedules(t *testing.T, balance float64) (*ScheduleService, *memScheduleRepo, *memWalletRepo, *recordingScheduleNotifier, *time.Time, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	svc, _, wr, uid := setupService(t, balance)
+	recipient := uuid.New()
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: recipient, Address: "RENT"})
+
+	repo := newMemScheduleRepo()
+	notifier := &recordingScheduleNotifier{}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	schedules := NewScheduleService(repo, notifier, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()).
+		WithRetryPolicy(2, time.Hour)
+	schedules.now = func() time.Time { return now }
+	svc.WithSchedules(schedules)
+	return schedules, repo, wr, notifier, &now, uid, recipient
+}
+
+func TestScheduleService_OneOffTransferExecutesOnce(t *testing.T) {
+	schedules, repo, wr, _, now, uid, recipient := setupSchedules(t, 1000)
+	ctx := context.Background()
+
+	sched, err := schedules.Create(ctx, entity.ScheduleSpec{UserID: uid, Kind: entity.ScheduleKindTransfer, Amount: decimal.NewFromInt(300), ToUserID: &recipient, StartAt: now.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("agendamento falhou: %v", err)
+	}
+	if n, _ := schedules.DispatchDue(ctx); n != 0 {
+		t.Fatalf("nada deveria vencer antes da data, executados %d", n)
+	}
+
+	*now = now.Add(24 * time.Hour)
+	run := ScheduledRun{ScheduleID: sched.ID, DueAt: *sched.NextRunAt, Attempt: 1}
+	if n, err := schedules.DispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("esperada 1 execução, obtido %d (%v)", n, err)
+	}
+	// Tarefa duplicada da fila não executa de novo
+	if err := schedules.Execute(ctx, run); err != nil {
+		t.Fatalf("execução duplicada deveria ser ignorada: %v", err)
+	}
+
+	if w, _ := wr.FindByUserID(ctx, uid); w.Balance != 700 {
+		t.Fatalf("origem deveria ser debitada uma vez, saldo %v", w.Balance)
+	}
+	if w, _ := wr.FindByUserID(ctx, recipient); w.Balance != 300 {
+		t.Fatalf("destino deveria receber uma vez, saldo %v", w.Balance)
+	}
+	stored, _ := repo.FindByID(ctx, sched.ID)
+	if stored.Status != entity.ScheduleCompleted || stored.RunCount != 1 {
+		t.Fatalf("agendamento único deveria estar concluído: %+v", stored)
+	}
+	history, _ := schedules.History(ctx, uid, sched.ID, 0)
+	if len(history) != 1 || history[0].Status != entity.ExecutionSucceeded || history[0].TransactionID == nil {
+		t.Fatalf("histórico inesperado: %+v", history)
+	}
+}
+
+func TestScheduleService_InsufficientFundsRetriesThenSkips(t *testing.T) {
+	schedules, repo, wr, notifier, now, uid, recipient := setupSchedules(t, 100)
+	ctx := context.Background()
+
+	sched, err := schedules.Create(ctx, entity.ScheduleSpec{UserID: uid, Kind: entity.ScheduleKindTransfer, Amount: decimal.NewFromInt(500), ToUserID: &recipient, Recurrence: "FREQ=MONTHLY", StartAt: *now})
+	if err != nil {
+		t.Fatalf("agendamento falhou: %v", err)
+	}
+
+	// Primeira tentativa e duas novas tentativas falham por saldo
+	for i, wait := range []time.Duration{0, time.Hour, 2 * time.Hour} {
+		*now = now.Add(wait)
+		if n, _ := schedules.DispatchDue(ctx); n != 1 {
+			t.Fatalf("tentativa %d deveria executar", i+1)
+		}
+	}
+	if len(notifier.failures) != 3 || notifier.failures[0] == nil || notifier.failures[2] != nil {
+		t.Fatalf("avisos inesperados: %v", notifier.failures)
+	}
+	stored, _ := repo.FindByID(ctx, sched.ID)
+	if stored.Status != entity.ScheduleActive || stored.RetryAt != nil || !stored.NextRunAt.Equal(time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("ocorrência deveria ser pulada para fevereiro: %+v", stored)
+	}
+
+	// Com saldo, a ocorrência seguinte é executada
+	_ = wr.UpdateBalance(ctx, uid, 1000)
+	*now = time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	if n, _ := schedules.DispatchDue(ctx); n != 1 {
+		t.Fatal("ocorrência de fevereiro deveria executar")
+	}
+	history, _ := schedules.History(ctx, uid, sched.ID, 0)
+	statuses := map[entity.ScheduleExecutionStatus]int{}
+	for _, e := range history {
+		statuses[e.Status]++
+	}
+	if statuses[entity.ExecutionRetrying] != 2 || statuses[entity.ExecutionFailed] != 1 || statuses[entity.ExecutionSucceeded] != 1 {
+		t.Fatalf("histórico inesperado: %v", statuses)
+	}
+}
+
+func TestScheduleService_DispatcherAndStatusChanges(t *testing.T) {
+	schedules, _, _, _, now, uid, recipient := setupSchedules(t, 1000)
+	dispatcher := &recordingDispatcher{}
+	schedules.WithDispatcher(dispatcher)
+	ctx := context.Background()
+
+	sched, err := schedules.Create(ctx, entity.ScheduleSpec{UserID: uid, Kind: entity.ScheduleKindTransfer, Amount: decimal.NewFromInt(10), ToUserID: &recipient, Recurrence: "0 9 * * *"})
+	if err != nil {
+		t.Fatalf("agendamento falhou: %v", err)
+	}
+	if _, err := schedules.Pause(ctx, uuid.New(), sched.ID); !errors.Is(err, ErrScheduleNotFound) {
+		t.Fatalf("agendamento de outro usuário deveria ser ErrScheduleNotFound, obtido %v", err)
+	}
+	if _, err := schedules.Pause(ctx, uid, sched.ID); err != nil {
+		t.Fatalf("pausa falhou: %v", err)
+	}
+
+	*now = now.Add(48 * time.Hour)
+	if n, _ := schedules.DispatchDue(ctx); n != 0 {
+		t.Fatal("agendamento pausado não deveria ser enfileirado")
+	}
+	resumed, err := schedules.Resume(ctx, uid, sched.ID)
+	if err != nil {
+		t.Fatalf("retomada falhou: %v", err)
+	}
+	*now = *resumed.NextRunAt
+	if n, _ := schedules.DispatchDue(ctx); n != 1 || len(dispatcher.runs) != 1 {
+		t.Fatalf("execução vencida deveria ir para a fila: %+v", dispatcher.runs)
+	}
+	if want := fmt.Sprintf("schedule:%s:%d:1", sched.ID, resumed.NextRunAt.Unix()); dispatcher.runs[0].TaskID() != want {
+		t.Fatalf("task id inesperado: %s", dispatcher.runs[0].TaskID())
+	}
+
+	if _, err := schedules.Cancel(ctx, uid, sched.ID); err != nil {
+		t.Fatalf("cancelamento falhou: %v", err)
+	}
+	// Execução já enfileirada de agendamento cancelado é descartada
+	if err := schedules.Execute(ctx, dispatcher.runs[0]); err != nil {
+		t.Fatalf("execução obsoleta deveria ser ignorada: %v", err)
+	}
+	if history, _ := schedules.History(ctx, uid, sched.ID, 0); len(history) != 0 {
+		t.Fatalf("nenhuma execução deveria ser registrada: %+v", history)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/cqrs"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSearchLimit itens por página quando a consulta não informa limit
+	DefaultSearchLimit = 50
+	// MaxSearchLimit limite máximo de itens por página
+	MaxSearchLimit = 200
+)
+
+// ErrInvalidSearchQuery filtro, ordenação ou cursor inválido
+var ErrInvalidSearchQuery = errors.New("invalid transaction search query")
+
+// TransactionSearchService busca as transações do usuário sobre o modelo de leitura, com filtros,
+// ordenação e paginação por cursor. O cursor guarda o valor da ordenação e o id do último item, de
+// modo que as páginas seguintes não se deslocam quando novas transações entram.
+type TransactionSearchService struct {
+	repo   cqrs.TransactionQueryRepository
+	logger *zap.Logger
+}
+
+// NewTransactionSearchService cria o serviço de busca de transações
+func NewTransactionSearchService(repo cqrs.TransactionQueryRepository, logger *zap.Logger) *TransactionSearchService {
+	return &TransactionSearchService{repo: repo, logger: logger}
+}
+
+// Search retorna uma página das transações do usuário que atendem à consulta e o total de
+// resultados. ID e Offset são ignorados; a paginação é feita pelo cursor.
+func (s *TransactionSearchService) Search(ctx context.Context, userID uuid.UUID, query cqrs.TransactionQuery) (*cqrs.TransactionPage, error) {
+	query.UserID = &userID
+	query.ID = nil
+	query.Offset = 0
+	if err := normalizeSearchQuery(&query); err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	page := query
+	page.Limit = limit + 1 // um item a mais indica que há próxima página
+	items, err := s.repo.FindAll(ctx, &page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.Count(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cqrs.TransactionPage{Transactions: items, Total: total}
+	if len(items) > limit {
+		result.Transactions = items[:limit]
+		result.HasMore = true
+		result.NextCursor = cqrs.CursorAfter(items[limit-1], query.SortBy, query.SortOrder).Encode()
+	}
+	if result.Transactions == nil {
+		result.Transactions = []*cqrs.TransactionReadModel{}
+	}
+	return result, nil
+}
+
+// normalizeSearchQuery aplica os padrões e valida filtros, ordenação e cursor
+func normalizeSearchQuery(query *cqrs.TransactionQuery) error {
+	switch {
+	case query.Limit <= 0:
+		query.Limit = DefaultSearchLimit
+	case query.Limit > MaxSearchLimit:
+		query.Limit = MaxSearchLimit
+	}
+	if query.SortBy == "" {
+		query.SortBy = cqrs.TransactionSortCreatedAt
+	}
+	if query.SortOrder == "" {
+		query.SortOrder = cqrs.SortDesc
+	}
+	if query.SortBy != cqrs.TransactionSortCreatedAt && query.SortBy != cqrs.TransactionSortAmount {
+		return fmt.Errorf("%w: sort must be created_at or amount", ErrInvalidSearchQuery)
+	}
+	if query.SortOrder != cqrs.SortAsc && query.SortOrder != cqrs.SortDesc {
+		return fmt.Errorf("%w: order must be asc or desc", ErrInvalidSearchQuery)
+	}
+	if query.Type != nil && !knownTransactionType(entity.TransactionType(*query.Type)) {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidSearchQuery, *query.Type)
+	}
+	if query.Status != nil && !knownTransactionStatus(entity.TransactionStatus(*query.Status)) {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidSearchQuery, *query.Status)
+	}
+	if query.MinAmount != nil && query.MaxAmount != nil && query.MinAmount.GreaterThan(*query.MaxAmount) {
+		return fmt.Errorf("%w: min_amount is greater than max_amount", ErrInvalidSearchQuery)
+	}
+	if query.FromDate != nil && query.ToDate != nil && !query.FromDate.Before(*query.ToDate) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidSearchQuery)
+	}
+	if query.Cursor != "" {
+		if _, err := cqrs.DecodeTransactionCursor(query.Cursor, query.SortBy, query.SortOrder); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSearchQuery, err)
+		}
+	}
+	return nil
+}
+
+func knownTransactionType(t entity.TransactionType) bool {
+	switch t {
+	case entity.TransactionTypeDeposit, entity.TransactionTypeWithdraw, entity.TransactionTypeTransfer,
+		entity.TransactionTypeFee, entity.TransactionTypeReversal, entity.TransactionTypeEscrowFund,
+		entity.TransactionTypeEscrowPayout, entity.TransactionTypeInterest, entity.TransactionTypeWithholdingTax,
+		entity.TransactionTypeCreditInterest, entity.TransactionTypeCreditFee,
+		entity.TransactionTypeAccountFunding, entity.TransactionTypeAccountWithdrawal, entity.TransactionTypeConversion:
+		return true
+	}
+	return false
+}
+
+func knownTransactionStatus(s entity.TransactionStatus) bool {
+	switch s {
+	case entity.TransactionStatusPending, entity.TransactionStatusCompleted, entity.TransactionStatusFailed,
+		entity.TransactionStatusAwaitingApproval:
+		return true
+	}
+	return false
+}
+
+// WithSearch habilita a busca paginada de transações
+func (s *TransactionService) WithSearch(search *TransactionSearchService) *TransactionService {
+	s.search = search
+	return s
+}
+
+// Search retorna o serviço de busca de transações (nil se não configurado)
+func (s *TransactionService) Search() *TransactionSearchService {
+	return s.search
+}
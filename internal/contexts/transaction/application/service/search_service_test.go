@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/shared/cqrs"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// memTxnQueryRepo modelo de leitura sobre o repositório em memória, com a mesma semântica de filtros
+// e keyset da implementação Postgres para os campos usados nos testes
+type memTxnQueryRepo struct {
+	txns *memTxnRepo
+}
+
+func (m *memTxnQueryRepo) FindByID(ctx context.Context, id string) (*cqrs.TransactionReadModel, error) {
+	return nil, nil
+}
+
+func (m *memTxnQueryRepo) FindByUser(ctx context.Context, userID string, limit, offset int) ([]*cqrs.TransactionReadModel, error) {
+	return nil, nil
+}
+
+func (m *memTxnQueryRepo) FindRecent(ctx context.Context, limit int) ([]*cqrs.TransactionReadModel, error) {
+	return nil, nil
+}
+
+func (m *memTxnQueryRepo) match(q *cqrs.TransactionQuery) []*cqrs.TransactionReadModel {
+	var out []*cqrs.TransactionReadModel
+	for _, tx := range m.txns.txs {
+		switch {
+		case q.UserID != nil && tx.UserID != *q.UserID,
+			q.Type != nil && string(tx.Type) != *q.Type,
+			q.Chain != nil && tx.Chain != *q.Chain,
+			q.Counterparty != nil && tx.FromAddress != *q.Counterparty && tx.ToAddress != *q.Counterparty,
+			q.MinAmount != nil && tx.Amount.LessThan(*q.MinAmount):
+			continue
+		}
+		out = append(out, &cqrs.TransactionReadModel{ID: tx.ID, UserID: tx.UserID, Type: string(tx.Type), Amount: tx.Amount, Blockchain: tx.Chain, CreatedAt: tx.CreatedAt})
+	}
+	return out
+}
+
+func (m *memTxnQueryRepo) FindAll(ctx context.Context, q *cqrs.TransactionQuery) ([]*cqrs.TransactionReadModel, error) {
+	// compare ordena por (valor da ordenação, id)
+	compare := func(a *cqrs.TransactionReadModel, amount decimal.Decimal, at time.Time, id uuid.UUID) int {
+		c := a.CreatedAt.Compare(at)
+		if q.SortBy == cqrs.TransactionSortAmount {
+			c = a.Amount.Cmp(amount)
+		}
+		if c == 0 {
+			return bytes.Compare(a.ID[:], id[:])
+		}
+		return c
+	}
+	items := m.match(q)
+	sort.Slice(items, func(i, j int) bool {
+		c := compare(items[i], items[j].Amount, items[j].CreatedAt, items[j].ID)
+		if q.SortOrder == cqrs.SortAsc {
+			return c < 0
+		}
+		return c > 0
+	})
+	if q.Cursor != "" {
+		cursor, err := cqrs.DecodeTransactionCursor(q.Cursor, q.SortBy, q.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		var rest []*cqrs.TransactionReadModel
+		for _, item := range items {
+			c := compare(item, cursor.Amount, cursor.CreatedAt, cursor.ID)
+			if (q.SortOrder == cqrs.SortAsc && c > 0) || (q.SortOrder != cqrs.SortAsc && c < 0) {
+				rest = append(rest, item)
+			}
+		}
+		items = rest
+	}
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+	}
+	return items, nil
+}
+
+func (m *memTxnQueryRepo) Count(ctx context.Context, q *cqrs.TransactionQuery) (int, error) {
+	return len(m.match(q)), nil
+}
+
+func setupSearch(t *testing.T, balance float64) (*TransactionSearchService, *TransactionService, uuid.UUID) {
+	t.Helper()
+	svc, txr, _, uid := setupService(t, balance)
+	search := NewTransactionSearchService(&memTxnQueryRepo{txns: txr}, zap.NewNop())
+	svc.WithSearch(search)
+	return search, svc, uid
+}
+
+func TestTransactionSearchService_CursorPagination(t *testing.T) {
+	search, svc, uid := setupSearch(t, 1000)
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(int64(i*10)), ""); err != nil {
+			t.Fatalf("depósito: %v", err)
+		}
+	}
+	// Timestamps iguais nos dois primeiros: o id desempata sem repetir nem pular itens
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	backdate(search.repo.(*memTxnQueryRepo).txns, base, base, base.Add(time.Hour), base.Add(2*time.Hour), base.Add(3*time.Hour))
+
+	seen := make(map[uuid.UUID]bool)
+	query := cqrs.TransactionQuery{Limit: 2}
+	pages := 0
+	for {
+		page, err := search.Search(ctx, uid, query)
+		if err != nil {
+			t.Fatalf("busca: %v", err)
+		}
+		pages++
+		if page.Total != 5 {
+			t.Fatalf("total esperado 5, obtido %d", page.Total)
+		}
+		for _, tx := range page.Transactions {
+			if seen[tx.ID] {
+				t.Fatalf("transação repetida entre páginas: %s", tx.ID)
+			}
+			seen[tx.ID] = true
+		}
+		if !page.HasMore {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if pages != 3 || len(seen) != 5 {
+		t.Fatalf("esperado 3 páginas com 5 transações, obtido %d páginas e %d transações", pages, len(seen))
+	}
+
+	page, err := search.Search(ctx, uid, cqrs.TransactionQuery{SortBy: cqrs.TransactionSortAmount, SortOrder: cqrs.SortAsc, Limit: 1})
+	if err != nil || !page.Transactions[0].Amount.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("menor valor esperado primeiro: %+v, %v", page, err)
+	}
+	if _, err := search.Search(ctx, uid, cqrs.TransactionQuery{Cursor: page.NextCursor}); !errors.Is(err, ErrInvalidSearchQuery) {
+		t.Fatalf("cursor de outra ordenação deveria ser rejeitado, obtido %v", err)
+	}
+}
+
+func TestTransactionSearchService_FiltersAndScope(t *testing.T) {
+	search, svc, uid := setupSearch(t, 100)
+	ctx := context.Background()
+
+	if _, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(20), "ethereum", "0xabc"); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+	if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(5), ""); err != nil {
+		t.Fatalf("depósito: %v", err)
+	}
+
+	chain, party := "ethereum", "0xabc"
+	page, err := search.Search(ctx, uid, cqrs.TransactionQuery{Chain: &chain, Counterparty: &party})
+	if err != nil || page.Total != 1 || page.Transactions[0].Blockchain != "ethereum" {
+		t.Fatalf("filtro por rede e contraparte: %+v, %v", page, err)
+	}
+
+	other := uuid.New()
+	if page, _ := search.Search(ctx, other, cqrs.TransactionQuery{UserID: &uid}); page.Total != 0 {
+		t.Fatalf("a busca é restrita ao usuário autenticado, obtido %d", page.Total)
+	}
+
+	badType, min, max := "bogus", decimal.NewFromInt(10), decimal.NewFromInt(1)
+	for name, q := range map[string]cqrs.TransactionQuery{
+		"tipo":      {Type: &badType},
+		"ordenação": {SortBy: "fee"},
+		"faixa":     {MinAmount: &min, MaxAmount: &max},
+		"cursor":    {Cursor: "x"},
+	} {
+		if _, err := search.Search(ctx, uid, q); !errors.Is(err, ErrInvalidSearchQuery) {
+			t.Fatalf("%s inválido deveria falhar, obtido %v", name, err)
+		}
+	}
+	if page, _ := search.Search(ctx, uid, cqrs.TransactionQuery{Limit: 10_000}); len(page.Transactions) != 2 {
+		t.Fatalf("limite acima do máximo deve ser limitado, não rejeitado")
+	}
+}
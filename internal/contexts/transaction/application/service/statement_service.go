@@ -0,0 +1,254 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/contexts/transaction/domain/repository"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultStatementMaxPeriod período máximo de um extrato sob demanda
+	DefaultStatementMaxPeriod = 366 * 24 * time.Hour
+	// DefaultStatementSweepInterval intervalo da varredura que gera os extratos mensais pendentes
+	DefaultStatementSweepInterval = time.Hour
+
+	statementPeriodLayout = "2006-01"
+)
+
+// ErrStatementNotFound extrato inexistente ou de outro usuário
+var ErrStatementNotFound = errors.New("statement not found")
+
+// StatementTask geração do extrato mensal de um usuário entregue à fila
+type StatementTask struct {
+	UserID uuid.UUID `json:"user_id"`
+	Period string    `json:"period"` // AAAA-MM
+}
+
+// TaskID identificador estável do extrato do mês, usado para deduplicar tarefas na fila
+func (t StatementTask) TaskID() string {
+	return "statement:" + t.UserID.String() + ":" + t.Period
+}
+
+// StatementDispatcher entrega a geração dos extratos mensais para processamento assíncrono (ex.: asynq)
+type StatementDispatcher interface {
+	Dispatch(ctx context.Context, task StatementTask) error
+}
+
+// StatementService gera extratos com saldo inicial, movimentações, saldo corrente e saldo final.
+// O saldo inicial é obtido a partir do saldo atual da carteira descontando as movimentações desde o
+// início do período, de modo que o extrato sempre fecha com o saldo registrado.
+type StatementService struct {
+	statements repository.StatementRepository
+	txns       *TransactionService
+	dispatcher StatementDispatcher
+	location   *time.Location
+	maxPeriod  time.Duration
+	eventBus   events.Bus
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+// NewStatementService cria o serviço de extratos; sem dispatcher os extratos mensais são gerados na varredura
+func NewStatementService(statements repository.StatementRepository, eventBus events.Bus, logger *zap.Logger) *StatementService {
+	return &StatementService{
+		statements: statements,
+		location:   time.UTC,
+		maxPeriod:  DefaultStatementMaxPeriod,
+		eventBus:   eventBus,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// WithDispatcher entrega a geração dos extratos mensais à fila
+func (s *StatementService) WithDispatcher(dispatcher StatementDispatcher) *StatementService {
+	s.dispatcher = dispatcher
+	return s
+}
+
+// WithLocation define o fuso usado nos limites dos meses
+func (s *StatementService) WithLocation(location *time.Location) *StatementService {
+	if location != nil {
+		s.location = location
+	}
+	return s
+}
+
+// Generate monta o extrato do período [from, to) da carteira do usuário
+func (s *StatementService) Generate(ctx context.Context, userID uuid.UUID, from, to time.Time) (*entity.Statement, error) {
+	if !from.Before(to) || to.Sub(from) > s.maxPeriod {
+		return nil, fmt.Errorf("%w: period must be positive and at most %d days", entity.ErrInvalidStatementPeriod, int(s.maxPeriod.Hours()/24))
+	}
+	wallet, err := s.txns.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	now := s.now()
+	until := now
+	if to.After(until) {
+		until = to
+	}
+	txs, err := s.statements.ListMovements(ctx, userID, wallet.Address, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	// O saldo atual menos tudo o que entrou e saiu desde o início do período é o saldo inicial
+	opening := decimal.NewFromFloat(wallet.Balance)
+	var lines []entity.StatementLine
+	for _, tx := range txs {
+		for _, line := range entity.StatementLines(tx, userID, wallet.Address) {
+			opening = opening.Sub(line.Amount)
+			if line.Date.Before(to) {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return entity.NewStatement(userID, wallet.Address, string(sharedVO.BaseCurrency), from, to, opening, lines, now), nil
+}
+
+// Render exporta o extrato em CSV, OFX, camt.053 ou JSON
+func (s *StatementService) Render(st *entity.Statement, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := entity.WriteStatement(&buf, format, st); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MonthPeriod retorna os limites do mês AAAA-MM no fuso configurado
+func (s *StatementService) MonthPeriod(period string) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(statementPeriodLayout, period, s.location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: month must be YYYY-MM", entity.ErrInvalidStatementPeriod)
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+// Get retorna um extrato mensal gerado
+func (s *StatementService) Get(ctx context.Context, userID, id uuid.UUID) (*entity.Statement, error) {
+	st, err := s.statements.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil || st.UserID != userID {
+		return nil, ErrStatementNotFound
+	}
+	return st, nil
+}
+
+// List lista os extratos mensais gerados para o usuário
+func (s *StatementService) List(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Statement, error) {
+	return s.statements.List(ctx, userID, limit)
+}
+
+// Execute gera e grava o extrato mensal da tarefa
+func (s *StatementService) Execute(ctx context.Context, task StatementTask) error {
+	from, to, err := s.MonthPeriod(task.Period)
+	if err != nil {
+		return err
+	}
+	st, err := s.Generate(ctx, task.UserID, from, to)
+	if err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := s.statements.Save(ctx, st); err != nil {
+		return err
+	}
+
+	if s.eventBus != nil {
+		s.eventBus.PublishAsync(ctx, events.NewStatementGeneratedEvent(
+			st.ID, st.UserID, task.Period, st.OpeningBalance, st.ClosingBalance, st.LineCount,
+		))
+	}
+	return nil
+}
+
+// DispatchMonthly enfileira (ou gera, sem dispatcher) os extratos do mês anterior ainda não gerados
+func (s *StatementService) DispatchMonthly(ctx context.Context) (int, error) {
+	current := s.now().In(s.location)
+	period := time.Date(current.Year(), current.Month()-1, 1, 0, 0, 0, 0, s.location).Format(statementPeriodLayout)
+	from, to, err := s.MonthPeriod(period)
+	if err != nil {
+		return 0, err
+	}
+
+	const page = 500
+	dispatched := 0
+	for {
+		users, err := s.statements.ListPendingAccounts(ctx, from, to, page)
+		if err != nil {
+			return dispatched, err
+		}
+		failed := 0
+		for _, userID := range users {
+			if err := s.dispatch(ctx, StatementTask{UserID: userID, Period: period}); err != nil {
+				s.logger.Error("failed to dispatch monthly statement", zap.String("user_id", userID.String()), zap.Error(err))
+				failed++
+				continue
+			}
+			dispatched++
+		}
+		// Com a fila os usuários só deixam de ser pendentes quando a tarefa roda; a próxima
+		// varredura cuida do restante
+		if s.dispatcher != nil || len(users) < page || failed > 0 {
+			return dispatched, nil
+		}
+	}
+}
+
+// Run gera os extratos mensais pendentes a cada intervalo até o contexto ser cancelado
+func (s *StatementService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultStatementSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchMonthly(ctx); err != nil {
+				s.logger.Error("monthly statement sweep failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *StatementService) dispatch(ctx context.Context, task StatementTask) error {
+	if s.dispatcher == nil {
+		return s.Execute(ctx, task)
+	}
+	return s.dispatcher.Dispatch(ctx, task)
+}
+
+// WithStatements habilita os extratos das carteiras deste serviço
+func (s *TransactionService) WithStatements(statements *StatementService) *TransactionService {
+	s.statements = statements
+	statements.txns = s
+	return s
+}
+
+// Statements retorna o serviço de extratos (nil se desabilitado)
+func (s *TransactionService) Statements() *StatementService {
+	return s.statements
+}
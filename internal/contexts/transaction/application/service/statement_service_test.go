@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memStatementRepo struct {
+	mu         sync.Mutex
+	txns       *memTxnRepo
+	accounts   []uuid.UUID
+	statements map[uuid.UUID]*entity.Statement
+}
+
+func newMemStatementRepo(txns *memTxnRepo, accounts ...uuid.UUID) *memStatementRepo {
+	return &memStatementRepo{txns: txns, accounts: accounts, statements: make(map[uuid.UUID]*entity.Statement)}
+}
+
+func (m *memStatementRepo) ListMovements(ctx context.Context, userID uuid.UUID, address string, from, to time.Time) ([]*entity.Transaction, error) {
+	var out []*entity.Transaction
+	for _, tx := range m.txns.txs {
+		if tx.UserID != userID && tx.FromAddress != address && tx.ToAddress != address {
+			continue
+		}
+		if entity.AffectsBalance(tx) && !tx.CreatedAt.Before(from) && tx.CreatedAt.Before(to) {
+			cp := *tx
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (m *memStatementRepo) ListPendingAccounts(ctx context.Context, from, to time.Time, limit int) ([]uuid.UUID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []uuid.UUID
+	for _, userID := range m.accounts {
+		generated := false
+		for _, st := range m.statements {
+			if st.UserID == userID && st.PeriodStart.Equal(from) && st.PeriodEnd.Equal(to) {
+				generated = true
+			}
+		}
+		if !generated && len(out) < limit {
+			out = append(out, userID)
+		}
+	}
+	return out, nil
+}
+
+func (m *memStatementRepo) Save(ctx context.Context, st *entity.Statement) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *st
+	m.statements[st.ID] = &cp
+	return nil
+}
+
+func (m *memStatementRepo) Find(ctx context.Context, id uuid.UUID) (*entity.Statement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if st, ok := m.statements[id]; ok {
+		cp := *st
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m *memStatementRepo) List(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Statement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*entity.Statement
+	for _, st := range m.statements {
+		if st.UserID == userID {
+			cp := *st
+			cp.Lines = nil
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func setupStatements(t *testing.T, balance float64) (*StatementService, *memStatementRepo, *memTxnRepo, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	svc, txr, wr, uid := setupService(t, balance)
+	recipient := uuid.New()
+	_ = svc.userRepo.Create(context.Background(), &userEntity.User{ID: recipient, Email: "r@t.com", Password: "hash"})
+	_ = wr.Create(context.Background(), &userEntity.Wallet{UserID: recipient, Address: "RECIPIENT", Balance: 10})
+
+	repo := newMemStatementRepo(txr, uid, recipient)
+	statements := NewStatementService(repo, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	svc.WithStatements(statements)
+	return statements, repo, txr, uid, recipient
+}
+
+// backdate move as transações do usuário para os instantes informados, na ordem de criação
+func backdate(txr *memTxnRepo, times ...time.Time) {
+	var txs []*entity.Transaction
+	for _, tx := range txr.txs {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].CreatedAt.Before(txs[j].CreatedAt) })
+	for i, tx := range txs {
+		tx.CreatedAt = times[i]
+	}
+}
+
+func TestStatementService_RunningBalancesAndPeriodCut(t *testing.T) {
+	statements, _, txr, uid, recipient := setupStatements(t, 100)
+	svc := statements.txns
+	ctx := context.Background()
+
+	if err := svc.ProcessDeposit(ctx, uid, decimal.NewFromInt(50), ""); err != nil {
+		t.Fatalf("depósito: %v", err)
+	}
+	if _, err := svc.ProcessTransfer(ctx, uid, recipient, decimal.NewFromInt(30)); err != nil {
+		t.Fatalf("transferência: %v", err)
+	}
+	if _, err := svc.ProcessWithdrawTo(ctx, uid, decimal.NewFromInt(20), "ethereum", "0xabc"); err != nil {
+		t.Fatalf("saque: %v", err)
+	}
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	backdate(txr, day(1), day(2), day(3))
+
+	// Período até o dia 3 (exclusive): o saque fica fora, mas entra no cálculo do saldo inicial
+	st, err := statements.Generate(ctx, uid, day(1).Add(-time.Hour), day(3).Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("extrato: %v", err)
+	}
+	if !st.OpeningBalance.Equal(decimal.NewFromInt(100)) || !st.ClosingBalance.Equal(decimal.NewFromInt(120)) {
+		t.Fatalf("saldos esperados 100 → 120, obtido %s → %s", st.OpeningBalance, st.ClosingBalance)
+	}
+	if len(st.Lines) != 2 || !st.Lines[0].Balance.Equal(decimal.NewFromInt(150)) || !st.Lines[1].Amount.Equal(decimal.NewFromInt(-30)) {
+		t.Fatalf("linhas incorretas: %+v", st.Lines)
+	}
+	if st.Lines[0].Channel != entity.StatementChannelOnChain || st.Lines[1].Channel != entity.StatementChannelInternal {
+		t.Fatalf("canais incorretos: %s, %s", st.Lines[0].Channel, st.Lines[1].Channel)
+	}
+
+	// O destinatário vê a transferência recebida
+	st, err = statements.Generate(ctx, recipient, day(1), day(4))
+	if err != nil {
+		t.Fatalf("extrato do destinatário: %v", err)
+	}
+	if len(st.Lines) != 1 || !st.Lines[0].Amount.Equal(decimal.NewFromInt(30)) ||
+		!st.OpeningBalance.Equal(decimal.NewFromInt(10)) || !st.ClosingBalance.Equal(decimal.NewFromInt(40)) {
+		t.Fatalf("extrato do destinatário incorreto: %+v", st)
+	}
+
+	if _, err := statements.Generate(ctx, uid, day(3), day(1)); !errors.Is(err, entity.ErrInvalidStatementPeriod) {
+		t.Fatalf("esperado ErrInvalidStatementPeriod, obtido %v", err)
+	}
+}
+
+func TestStatementService_MonthlyGenerationIsIdempotent(t *testing.T) {
+	statements, repo, _, uid, recipient := setupStatements(t, 100)
+	statements.now = func() time.Time { return time.Date(2025, 4, 2, 3, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	n, err := statements.DispatchMonthly(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("esperado 2 extratos gerados, obtido %d, %v", n, err)
+	}
+	if n, _ := statements.DispatchMonthly(ctx); n != 0 {
+		t.Fatalf("varredura seguinte não deveria gerar novamente, obtido %d", n)
+	}
+
+	list, _ := statements.List(ctx, uid, 10)
+	if len(list) != 1 || list[0].PeriodStart != time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC) {
+		t.Fatalf("extrato de março esperado: %+v", list)
+	}
+	if _, err := statements.Get(ctx, recipient, list[0].ID); !errors.Is(err, ErrStatementNotFound) {
+		t.Fatalf("extrato de outro usuário não deveria ser visível, obtido %v", err)
+	}
+	if len(repo.statements) != 2 {
+		t.Fatalf("esperado 2 extratos gravados, obtido %d", len(repo.statements))
+	}
+}
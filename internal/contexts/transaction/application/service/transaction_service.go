@@ -3,12 +3,15 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
 	"financial-system-pro/internal/contexts/transaction/domain/entity"
 	"financial-system-pro/internal/contexts/transaction/domain/repository"
 	"financial-system-pro/internal/contexts/transaction/domain/valueobject"
 	userEntity "financial-system-pro/internal/contexts/user/domain/entity"
 	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
 	"financial-system-pro/internal/shared/breaker"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
 	"financial-system-pro/internal/shared/events"
 
 	"github.com/google/uuid"
@@ -24,6 +27,23 @@ type TransactionService struct {
 	eventBus       events.Bus
 	breakerManager *breaker.BreakerManager
 	logger         *zap.Logger
+	limits         LimitPolicy
+	monitor        *complianceSvc.MonitoringService
+	sanctions      *complianceSvc.SanctionsScreener
+	destinations   DestinationPolicy
+	approvals      *ApprovalService
+	fees           *FeeService
+	schedules      *ScheduleService
+	reversals      *ReversalService
+	escrows        *EscrowService
+	payouts        *PayoutService
+	pix            *PixService
+	statements     *StatementService
+	search         *TransactionSearchService
+	reconciliation *ReconciliationService
+	reserves       *ReservesService
+	interest       *InterestService
+	credit         *CreditService
 }
 
 // NewTransactionService cria uma nova instância do serviço
@@ -56,19 +76,35 @@ func (s *TransactionService) writeOutbox(ctx context.Context, typ string, payloa
 
 // ProcessDeposit processa um depósito
 func (s *TransactionService) ProcessDeposit(ctx context.Context, userID uuid.UUID, amount decimal.Decimal, callbackURL string) error {
+	_, err := s.deposit(ctx, userID, amount, callbackURL, "")
+	return err
+}
+
+// deposit credita a carteira do usuário; fromAddress identifica a origem externa (ex.: pix:<endToEndId>)
+func (s *TransactionService) deposit(ctx context.Context, userID uuid.UUID, amount decimal.Decimal, callbackURL, fromAddress string) (*entity.Transaction, error) {
 	// Money VO
-	money, err := valueobject.NewMoney(amount, valueobject.Currency("BRL"))
+	money, err := valueobject.NewMoney(amount, valueobject.Currency(sharedVO.BaseCurrency))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Criar transação
 	tx := entity.NewTransaction(userID, entity.TransactionTypeDeposit, amount)
 	tx.CallbackURL = callbackURL
+	tx.FromAddress = fromAddress
+	if entity.IsPixAddress(fromAddress) {
+		tx.Chain = entity.PixChain
+	}
+	if tx.Fee, err = s.quoteFee(ctx, FeeQuoteRequest{UserID: userID, Type: tx.Type, Asset: string(money.Currency()), Amount: money.Amount()}); err != nil {
+		return nil, err
+	}
+	if err := s.screen(ctx, tx); err != nil {
+		return nil, err
+	}
 
 	if err := s.txRepo.Create(ctx, tx); err != nil {
 		s.logger.Error("failed to create deposit transaction", zap.Error(err))
 		s.writeOutbox(ctx, "deposit.failed", map[string]interface{}{"error": "create_tx", "user_id": userID.String(), "amount": amount.String()})
-		return err
+		return nil, err
 	}
 
 	// Buscar wallet do usuário usando circuit breaker
@@ -86,19 +122,19 @@ func (s *TransactionService) ProcessDeposit(ctx context.Context, userID uuid.UUI
 		tx.Fail("failed to get user wallet")
 		_ = s.txRepo.Update(ctx, tx)
 		s.writeOutbox(ctx, "deposit.failed", map[string]interface{}{"error": "wallet_lookup", "user_id": userID.String(), "amount": amount.String()})
-		return err
+		return nil, err
 	}
 
 	wallet := walletInterface.(*userEntity.Wallet)
 
-	// Atualizar saldo
-	newBalance := wallet.Balance + money.Amount().InexactFloat64()
-	if err := s.walletRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+	// Atualizar saldo (a taxa é descontada do valor creditado)
+	balanceBefore := wallet.Balance
+	if err := adjustWallet(ctx, s.walletRepo, userID, money.Amount().Sub(tx.Fee).InexactFloat64(), 0); err != nil {
 		s.logger.Error("failed to update balance", zap.Error(err))
 		tx.Fail("failed to update balance")
 		_ = s.txRepo.Update(ctx, tx)
 		s.writeOutbox(ctx, "deposit.failed", map[string]interface{}{"error": "update_balance", "user_id": userID.String(), "amount": amount.String()})
-		return err
+		return nil, err
 	}
 
 	// Marcar como concluída
@@ -106,8 +142,11 @@ func (s *TransactionService) ProcessDeposit(ctx context.Context, userID uuid.UUI
 	if err := s.txRepo.Update(ctx, tx); err != nil {
 		s.logger.Error("failed to update transaction", zap.Error(err))
 		s.writeOutbox(ctx, "deposit.failed", map[string]interface{}{"error": "update_tx", "user_id": userID.String(), "amount": amount.String()})
-		return err
+		return nil, err
 	}
+	collectFee(ctx, s.fees, s.logger, tx)
+	// Depósito paga primeiro os encargos e o saldo negativo da linha de crédito
+	s.repayCredit(ctx, userID, tx, money.Amount().Sub(tx.Fee), balanceBefore)
 
 	// Publicar evento
 	s.eventBus.PublishAsync(ctx, events.NewDepositCompletedEvent(
@@ -123,14 +162,35 @@ func (s *TransactionService) ProcessDeposit(ctx context.Context, userID uuid.UUI
 		zap.String("amount", amount.String()),
 	)
 
-	return nil
+	return tx, nil
 }
 
-// ProcessWithdraw processa um saque
+// ProcessWithdraw processa um saque sem destino informado
 func (s *TransactionService) ProcessWithdraw(ctx context.Context, userID uuid.UUID, amount decimal.Decimal) error {
-	money, err := valueobject.NewMoney(amount, valueobject.Currency("BRL"))
+	_, err := s.ProcessWithdrawTo(ctx, userID, amount, "", "")
+	return err
+}
+
+// ProcessWithdrawTo processa um saque para o endereço informado na chain. O destino passa pela
+// política de endereços e pela triagem de sanções antes do débito. Saques que atingem
+// uma política de aprovação retornam a transação em awaiting_approval, com o valor já retido.
+func (s *TransactionService) ProcessWithdrawTo(ctx context.Context, userID uuid.UUID, amount decimal.Decimal, chain, toAddress string) (*entity.Transaction, error) {
+	money, err := valueobject.NewMoney(amount, valueobject.Currency(sharedVO.BaseCurrency))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := s.checkOutflowLimits(ctx, userID, money.Amount()); err != nil {
+		s.logger.Warn("withdraw rejected by kyc limits", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, err
+	}
+	if s.destinations != nil {
+		if err := s.destinations.AuthorizeDestination(ctx, userID, chain, toAddress); err != nil {
+			s.logger.Warn("withdraw rejected by destination policy", zap.String("user_id", userID.String()), zap.Error(err))
+			return nil, err
+		}
+	}
+	if err := s.screenDestination(ctx, userID, chain, toAddress); err != nil {
+		return nil, err
 	}
 	// Validar saldo usando circuit breaker
 	breaker := s.breakerManager.GetBreaker(breaker.BreakerTransactionToUser)
@@ -142,38 +202,70 @@ func (s *TransactionService) ProcessWithdraw(ctx context.Context, userID uuid.UU
 	if err != nil {
 		s.logger.Error("failed to get user wallet", zap.Error(err))
 		s.writeOutbox(ctx, "withdraw.failed", map[string]interface{}{"error": "wallet_lookup", "user_id": userID.String(), "amount": amount.String()})
-		return ErrInsufficientBalance
+		return nil, ErrInsufficientBalance
 	}
 
 	wallet := walletInterface.(*userEntity.Wallet)
 
-	if wallet.Balance < money.Amount().InexactFloat64() {
-		return ErrInsufficientBalance
+	fee, err := s.quoteFee(ctx, FeeQuoteRequest{
+		UserID:      userID,
+		Type:        entity.TransactionTypeWithdraw,
+		Chain:       chain,
+		Asset:       string(money.Currency()),
+		FromAddress: wallet.Address,
+		ToAddress:   toAddress,
+		Amount:      money.Amount(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	overdraft, err := s.overdraftLimit(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	if !wallet.HasAvailableFunds(money.Amount().Add(fee).InexactFloat64(), overdraft) {
+		return nil, ErrInsufficientBalance
 	}
 
 	// Criar transação
 	tx := entity.NewTransaction(userID, entity.TransactionTypeWithdraw, amount)
 	tx.FromAddress = wallet.Address
+	tx.ToAddress = toAddress
+	tx.Chain = chain
+	tx.Fee = fee
+	if err := s.screen(ctx, tx); err != nil {
+		return nil, err
+	}
+	if s.approvals != nil {
+		currency := string(money.Currency())
+		if policy := s.approvals.PolicyFor(currency, chain, money.Amount()); policy != nil {
+			if _, err := s.approvals.Hold(ctx, tx, overdraft, policy, currency, chain); err != nil {
+				return nil, err
+			}
+			return tx, nil
+		}
+	}
 
 	if err := s.txRepo.Create(ctx, tx); err != nil {
 		s.logger.Error("failed to create withdraw transaction", zap.Error(err))
 		s.writeOutbox(ctx, "withdraw.failed", map[string]interface{}{"error": "create_tx", "user_id": userID.String(), "amount": amount.String()})
-		return err
+		return nil, err
 	}
 
-	// Atualizar saldo
-	newBalance := wallet.Balance - money.Amount().InexactFloat64()
-	if err := s.walletRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+	// Atualizar saldo (valor + taxa)
+	// O débito é condicional no banco: saques concorrentes não passam do limite
+	if err := adjustWallet(ctx, s.walletRepo, userID, -money.Amount().Add(tx.Fee).InexactFloat64(), overdraft); err != nil {
 		s.logger.Error("failed to update balance", zap.Error(err))
 		tx.Fail("failed to update balance")
 		_ = s.txRepo.Update(ctx, tx)
 		s.writeOutbox(ctx, "withdraw.failed", map[string]interface{}{"error": "update_balance", "user_id": userID.String(), "amount": amount.String()})
-		return err
+		return nil, err
 	}
 
 	// Marcar como concluída
 	tx.Complete("withdraw-" + tx.ID.String())
 	_ = s.txRepo.Update(ctx, tx)
+	collectFee(ctx, s.fees, s.logger, tx)
 	s.writeOutbox(ctx, "withdraw.completed", map[string]interface{}{"user_id": userID.String(), "amount": money.Amount().String(), "tx_hash": tx.TransactionHash})
 
 	// Publicar evento
@@ -183,7 +275,114 @@ func (s *TransactionService) ProcessWithdraw(ctx context.Context, userID uuid.UU
 		tx.TransactionHash,
 	))
 
-	return nil
+	return tx, nil
+}
+
+// ProcessTransfer transfere saldo entre wallets de dois usuários
+func (s *TransactionService) ProcessTransfer(ctx context.Context, fromUserID, toUserID uuid.UUID, amount decimal.Decimal) (*entity.Transaction, error) {
+	money, err := valueobject.NewMoney(amount, valueobject.Currency(sharedVO.BaseCurrency))
+	if err != nil {
+		return nil, err
+	}
+	if fromUserID == toUserID {
+		return nil, ErrSameUserTransfer
+	}
+	if err := s.checkOutflowLimits(ctx, fromUserID, money.Amount()); err != nil {
+		s.logger.Warn("transfer rejected by kyc limits", zap.String("user_id", fromUserID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	breaker := s.breakerManager.GetBreaker(breaker.BreakerTransactionToUser)
+	fromInterface, err := breaker.Execute(func() (interface{}, error) {
+		return s.walletRepo.FindByUserID(ctx, fromUserID)
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+	toInterface, err := breaker.Execute(func() (interface{}, error) {
+		return s.walletRepo.FindByUserID(ctx, toUserID)
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+	fromWallet, _ := fromInterface.(*userEntity.Wallet)
+	toWallet, _ := toInterface.(*userEntity.Wallet)
+	if fromWallet == nil || toWallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	fee, err := s.quoteFee(ctx, FeeQuoteRequest{
+		UserID: fromUserID,
+		Type:   entity.TransactionTypeTransfer,
+		Chain:  InternalChain,
+		Asset:  string(money.Currency()),
+		Amount: money.Amount(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	value := money.Amount().InexactFloat64()
+	debit := money.Amount().Add(fee).InexactFloat64()
+	overdraft, err := s.overdraftLimit(ctx, fromWallet)
+	if err != nil {
+		return nil, err
+	}
+	if !fromWallet.HasAvailableFunds(debit, overdraft) {
+		return nil, ErrInsufficientBalance
+	}
+
+	tx := entity.NewTransaction(fromUserID, entity.TransactionTypeTransfer, amount)
+	tx.FromAddress = fromWallet.Address
+	tx.ToAddress = toWallet.Address
+	tx.Fee = fee
+	if err := s.screen(ctx, tx); err != nil {
+		return nil, err
+	}
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		s.logger.Error("failed to create transfer transaction", zap.Error(err))
+		return nil, err
+	}
+
+	if err := adjustWallet(ctx, s.walletRepo, fromUserID, -debit, overdraft); err != nil {
+		tx.Fail("failed to debit source wallet")
+		_ = s.txRepo.Update(ctx, tx)
+		return nil, err
+	}
+	toBalanceBefore := toWallet.Balance
+	if err := adjustWallet(ctx, s.walletRepo, toUserID, value, 0); err != nil {
+		// Desfaz o débito da origem
+		_ = adjustWallet(ctx, s.walletRepo, fromUserID, debit, 0)
+		tx.Fail("failed to credit destination wallet")
+		_ = s.txRepo.Update(ctx, tx)
+		return nil, err
+	}
+
+	tx.Complete("transfer-" + tx.ID.String())
+	_ = s.txRepo.Update(ctx, tx)
+	collectFee(ctx, s.fees, s.logger, tx)
+	// A transferência recebida quita o uso da linha de crédito do destinatário, como um depósito
+	s.repayCredit(ctx, toUserID, tx, money.Amount(), toBalanceBefore)
+	s.writeOutbox(ctx, "transfer.completed", map[string]interface{}{"from_user_id": fromUserID.String(), "to_user_id": toUserID.String(), "amount": money.Amount().String(), "tx_hash": tx.TransactionHash})
+
+	s.eventBus.PublishAsync(ctx, events.NewTransferCompletedEvent(fromUserID, toUserID, money.Amount(), tx.TransactionHash))
+
+	s.logger.Info("transfer processed successfully",
+		zap.String("tx_id", tx.ID.String()),
+		zap.String("from_user_id", fromUserID.String()),
+		zap.String("to_user_id", toUserID.String()),
+		zap.String("amount", amount.String()),
+	)
+	return tx, nil
+}
+
+// adjustWallet soma delta ao saldo da wallet de forma atômica; débito além do limite de cheque
+// especial vira ErrInsufficientBalance
+func adjustWallet(ctx context.Context, wallets userRepo.WalletRepository, userID uuid.UUID, delta, overdraftLimit float64) error {
+	err := wallets.AdjustBalance(ctx, userID, delta, overdraftLimit)
+	if errors.Is(err, userEntity.ErrWalletInsufficientFunds) {
+		return ErrInsufficientBalance
+	}
+	return err
 }
 
 // GetTransactionHistory retorna o histórico de transações de um usuário
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	txEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
 	txRepoIface "financial-system-pro/internal/contexts/transaction/domain/repository"
@@ -34,6 +35,14 @@ func (r *txRepoMock) UpdateStatus(ctx context.Context, id uuid.UUID, status txEn
 	return nil
 }
 
+func (r *txRepoMock) SumAmountSince(ctx context.Context, userID uuid.UUID, types []txEntity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	return decimal.Zero, nil
+}
+
+func (r *txRepoMock) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*txEntity.Transaction, error) {
+	return nil, nil
+}
+
 var _ txRepoIface.TransactionRepository = (*txRepoMock)(nil)
 
 // walletRepoMock: implementação mínima da interface WalletRepository
@@ -57,6 +66,14 @@ func (w *walletRepoMock) UpdateBalance(ctx context.Context, userID uuid.UUID, ne
 	return nil
 }
 
+func (w *walletRepoMock) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	if delta < 0 && w.balance+delta < -overdraftLimit {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	w.balance += delta
+	return nil
+}
+
 var _ userRepoIface.WalletRepository = (*walletRepoMock)(nil)
 
 // userRepoMock: implementação mínima da interface UserRepository
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	appsvc "financial-system-pro/internal/application/services"
 	txEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
@@ -63,6 +64,14 @@ func (r *txRepoMockOutbox) UpdateStatus(ctx context.Context, id uuid.UUID, statu
 	return nil
 }
 
+func (r *txRepoMockOutbox) SumAmountSince(ctx context.Context, userID uuid.UUID, types []txEntity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	return decimal.Zero, nil
+}
+
+func (r *txRepoMockOutbox) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*txEntity.Transaction, error) {
+	return nil, nil
+}
+
 // walletRepoMockOutbox allows failure injection and balance tracking.
 type walletRepoMockOutbox struct {
 	balance float64
@@ -86,6 +95,14 @@ func (w *walletRepoMockOutbox) UpdateBalance(ctx context.Context, userID uuid.UU
 	return nil
 }
 
+func (w *walletRepoMockOutbox) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	if delta < 0 && w.balance+delta < -overdraftLimit {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	w.balance += delta
+	return nil
+}
+
 // userRepoMockOutbox minimal implementation.
 type userRepoMockOutbox struct{}
 
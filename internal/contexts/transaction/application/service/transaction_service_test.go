@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"financial-system-pro/internal/contexts/transaction/domain/entity"
 	txnRepo "financial-system-pro/internal/contexts/transaction/domain/repository"
@@ -57,6 +58,41 @@ func (r *memTxnRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status enti
 	return nil
 }
 
+func (r *memTxnRepo) SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, tx := range r.txs {
+		if tx.UserID != userID || tx.Status == entity.TransactionStatusFailed || tx.CreatedAt.Before(since) {
+			continue
+		}
+		for _, t := range types {
+			if tx.Type == t {
+				total = total.Add(tx.Amount)
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *memTxnRepo) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error) {
+	var out []*entity.Transaction
+	for _, tx := range r.txs {
+		if tx.Type == entity.TransactionTypeFee || !tx.Fee.IsPositive() || tx.Status != entity.TransactionStatusCompleted ||
+			tx.CompletedAt == nil || !tx.CompletedAt.Before(before) {
+			continue
+		}
+		collected := false
+		for _, fee := range r.txs {
+			if fee.Type == entity.TransactionTypeFee && fee.ParentID != nil && *fee.ParentID == tx.ID && fee.Status != entity.TransactionStatusFailed {
+				collected = true
+			}
+		}
+		if !collected && len(out) < limit {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+
 var _ txnRepo.TransactionRepository = (*memTxnRepo)(nil)
 
 type memUserRepo struct {
@@ -119,6 +155,15 @@ func (r *memWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID, bal
 	return nil
 }
 
+func (r *memWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	w := r.wallets[userID]
+	if w == nil || (delta < 0 && w.Balance+delta < -overdraftLimit) {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	w.Balance += delta
+	return nil
+}
+
 var _ userRepo.WalletRepository = (*memWalletRepo)(nil)
 
 // Helper de setup
@@ -221,6 +266,10 @@ func (failingWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID, ba
 	return ErrWalletNotFound
 }
 
+func (failingWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	return ErrWalletNotFound
+}
+
 var _ userRepo.WalletRepository = (*failingWalletRepo)(nil)
 
 func TestProcessDeposit_FalhaWallet(t *testing.T) {
@@ -0,0 +1,291 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidApprovalPolicy  = errors.New("invalid approval policy")
+	ErrApprovalResolved       = errors.New("approval request already resolved")
+	ErrApprovalExpired        = errors.New("approval request expired")
+	ErrSelfApproval           = errors.New("requester cannot approve own withdrawal")
+	ErrDuplicateApproval      = errors.New("approver already approved this request")
+	ErrApproverRoleNotAllowed = errors.New("approver role not allowed by policy")
+)
+
+// Duration duração serializada no formato de time.ParseDuration ("24h", "30m")
+type Duration time.Duration
+
+// UnmarshalJSON aceita durações no formato de time.ParseDuration
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON serializa no formato de time.Duration
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// ApprovalPolicy define quando um saque precisa de aprovação (maker-checker) e por quem
+type ApprovalPolicy struct {
+	ID                string          `json:"id"`
+	Currency          string          `json:"currency,omitempty"` // vazio = qualquer moeda
+	Chain             string          `json:"chain,omitempty"`    // vazio = qualquer rede
+	MinAmount         decimal.Decimal `json:"min_amount"`
+	RequiredApprovals int             `json:"required_approvals"`
+	ApproverRoles     []string        `json:"approver_roles,omitempty"` // vazio = qualquer operador
+	TTL               Duration        `json:"ttl"`
+}
+
+// Validate verifica os parâmetros obrigatórios da política
+func (p ApprovalPolicy) Validate() error {
+	if p.ID == "" {
+		return fmt.Errorf("%w: id is required", ErrInvalidApprovalPolicy)
+	}
+	if !p.MinAmount.IsPositive() {
+		return fmt.Errorf("%w: %s: min_amount must be positive", ErrInvalidApprovalPolicy, p.ID)
+	}
+	if p.RequiredApprovals <= 0 {
+		return fmt.Errorf("%w: %s: required_approvals must be positive", ErrInvalidApprovalPolicy, p.ID)
+	}
+	if p.TTL <= 0 {
+		return fmt.Errorf("%w: %s: ttl is required", ErrInvalidApprovalPolicy, p.ID)
+	}
+	return nil
+}
+
+// Matches indica se o saque atinge o limite da política na moeda e rede informadas
+func (p ApprovalPolicy) Matches(currency, chain string, amount decimal.Decimal) bool {
+	if p.Currency != "" && !strings.EqualFold(p.Currency, currency) {
+		return false
+	}
+	if p.Chain != "" && !strings.EqualFold(p.Chain, chain) {
+		return false
+	}
+	return amount.GreaterThanOrEqual(p.MinAmount)
+}
+
+// AllowsRole indica se algum dos papéis do operador pode aprovar
+func (p ApprovalPolicy) AllowsRole(roles []string) bool {
+	return rolesAllowed(p.ApproverRoles, roles)
+}
+
+// SelectApprovalPolicy retorna a política aplicável de maior limite (a mais restritiva), ou nil
+func SelectApprovalPolicy(policies []ApprovalPolicy, currency, chain string, amount decimal.Decimal) *ApprovalPolicy {
+	var selected *ApprovalPolicy
+	for i := range policies {
+		p := &policies[i]
+		if !p.Matches(currency, chain, amount) {
+			continue
+		}
+		if selected == nil || p.MinAmount.GreaterThan(selected.MinAmount) {
+			selected = p
+		}
+	}
+	return selected
+}
+
+// LoadApprovalPolicies lê uma lista JSON de políticas e valida cada uma
+func LoadApprovalPolicies(r io.Reader) ([]ApprovalPolicy, error) {
+	var policies []ApprovalPolicy
+	if err := json.NewDecoder(r).Decode(&policies); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidApprovalPolicy, err)
+	}
+	seen := make(map[string]struct{}, len(policies))
+	for _, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+		if _, dup := seen[p.ID]; dup {
+			return nil, fmt.Errorf("%w: duplicated id %s", ErrInvalidApprovalPolicy, p.ID)
+		}
+		seen[p.ID] = struct{}{}
+	}
+	return policies, nil
+}
+
+// ApprovalStatus estado de uma solicitação de aprovação
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalExpired  ApprovalStatus = "expired"
+)
+
+// ApprovalActionType tipo de registro na trilha de auditoria
+type ApprovalActionType string
+
+const (
+	ApprovalActionRequested ApprovalActionType = "requested"
+	ApprovalActionApproved  ApprovalActionType = "approved"
+	ApprovalActionRejected  ApprovalActionType = "rejected"
+	ApprovalActionExpired   ApprovalActionType = "expired"
+)
+
+// ApprovalAction registro imutável da trilha de auditoria; ActorID nil indica o sistema
+type ApprovalAction struct {
+	ID        uuid.UUID          `json:"id"`
+	Action    ApprovalActionType `json:"action"`
+	ActorID   *uuid.UUID         `json:"actor_id,omitempty"`
+	Roles     []string           `json:"roles,omitempty"`
+	Comment   string             `json:"comment,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// ApprovalRequest saque retido aguardando aprovação dos operadores
+type ApprovalRequest struct {
+	ID                uuid.UUID
+	TransactionID     uuid.UUID
+	RequesterID       uuid.UUID
+	PolicyID          string
+	Amount            decimal.Decimal
+	Currency          string
+	Chain             string
+	ToAddress         string
+	RequiredApprovals int
+	ApproverRoles     []string
+	Status            ApprovalStatus
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+	ResolvedAt        *time.Time
+	Trail             []ApprovalAction
+}
+
+// NewApprovalRequest abre a solicitação para a transação conforme a política
+func NewApprovalRequest(tx *Transaction, policy *ApprovalPolicy, currency, chain string, now time.Time) *ApprovalRequest {
+	requester := tx.UserID
+	return &ApprovalRequest{
+		ID:                uuid.New(),
+		TransactionID:     tx.ID,
+		RequesterID:       tx.UserID,
+		PolicyID:          policy.ID,
+		Amount:            tx.Amount,
+		Currency:          currency,
+		Chain:             chain,
+		ToAddress:         tx.ToAddress,
+		RequiredApprovals: policy.RequiredApprovals,
+		ApproverRoles:     policy.ApproverRoles,
+		Status:            ApprovalPending,
+		CreatedAt:         now,
+		ExpiresAt:         now.Add(time.Duration(policy.TTL)),
+		Trail: []ApprovalAction{
+			{ID: uuid.New(), Action: ApprovalActionRequested, ActorID: &requester, CreatedAt: now},
+		},
+	}
+}
+
+// Approvals quantidade de aprovações registradas
+func (r *ApprovalRequest) Approvals() int {
+	n := 0
+	for _, a := range r.Trail {
+		if a.Action == ApprovalActionApproved {
+			n++
+		}
+	}
+	return n
+}
+
+// Approve registra a aprovação do operador; atingido o quórum a solicitação fica aprovada
+func (r *ApprovalRequest) Approve(approverID uuid.UUID, roles []string, comment string, now time.Time) (ApprovalAction, error) {
+	if err := r.checkDecision(approverID, roles, now); err != nil {
+		return ApprovalAction{}, err
+	}
+	for _, a := range r.Trail {
+		if a.Action == ApprovalActionApproved && a.ActorID != nil && *a.ActorID == approverID {
+			return ApprovalAction{}, ErrDuplicateApproval
+		}
+	}
+
+	action := r.record(ApprovalActionApproved, &approverID, roles, comment, now)
+	if r.Approvals() >= r.RequiredApprovals {
+		r.resolve(ApprovalApproved, now)
+	}
+	return action, nil
+}
+
+// Reject recusa a solicitação; uma única recusa encerra o pedido
+func (r *ApprovalRequest) Reject(approverID uuid.UUID, roles []string, comment string, now time.Time) (ApprovalAction, error) {
+	if err := r.checkDecision(approverID, roles, now); err != nil {
+		return ApprovalAction{}, err
+	}
+	action := r.record(ApprovalActionRejected, &approverID, roles, comment, now)
+	r.resolve(ApprovalRejected, now)
+	return action, nil
+}
+
+// Expire encerra a solicitação pendente após o prazo
+func (r *ApprovalRequest) Expire(now time.Time) (ApprovalAction, bool) {
+	if r.Status != ApprovalPending || now.Before(r.ExpiresAt) {
+		return ApprovalAction{}, false
+	}
+	action := r.record(ApprovalActionExpired, nil, nil, "approval window elapsed", now)
+	r.resolve(ApprovalExpired, now)
+	return action, true
+}
+
+func (r *ApprovalRequest) checkDecision(approverID uuid.UUID, roles []string, now time.Time) error {
+	if r.Status != ApprovalPending {
+		return ErrApprovalResolved
+	}
+	if !now.Before(r.ExpiresAt) {
+		return ErrApprovalExpired
+	}
+	if approverID == r.RequesterID {
+		return ErrSelfApproval
+	}
+	if !rolesAllowed(r.ApproverRoles, roles) {
+		return ErrApproverRoleNotAllowed
+	}
+	return nil
+}
+
+func (r *ApprovalRequest) record(kind ApprovalActionType, actorID *uuid.UUID, roles []string, comment string, now time.Time) ApprovalAction {
+	action := ApprovalAction{
+		ID:        uuid.New(),
+		Action:    kind,
+		ActorID:   actorID,
+		Roles:     roles,
+		Comment:   strings.TrimSpace(comment),
+		CreatedAt: now,
+	}
+	r.Trail = append(r.Trail, action)
+	return action
+}
+
+func (r *ApprovalRequest) resolve(status ApprovalStatus, now time.Time) {
+	r.Status = status
+	r.ResolvedAt = &now
+}
+
+func rolesAllowed(allowed, roles []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, want := range allowed {
+		for _, have := range roles {
+			if strings.EqualFold(want, have) {
+				return true
+			}
+		}
+	}
+	return false
+}
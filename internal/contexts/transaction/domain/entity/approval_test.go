@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestLoadApprovalPolicies_SelectsMostRestrictive(t *testing.T) {
+	policies, err := LoadApprovalPolicies(strings.NewReader(`[
+		{"id": "brl-large", "currency": "BRL", "min_amount": "10000", "required_approvals": 1, "ttl": "24h"},
+		{"id": "brl-huge", "currency": "BRL", "min_amount": "100000", "required_approvals": 2, "approver_roles": ["treasury"], "ttl": "12h"},
+		{"id": "eth-any", "chain": "ethereum", "min_amount": "5000", "required_approvals": 1, "ttl": "1h"}
+	]`))
+	if err != nil {
+		t.Fatalf("carregar políticas falhou: %v", err)
+	}
+
+	if p := SelectApprovalPolicy(policies, "BRL", "", decimal.NewFromInt(9999)); p != nil {
+		t.Fatalf("valor abaixo do limite não deveria exigir aprovação: %s", p.ID)
+	}
+	if p := SelectApprovalPolicy(policies, "BRL", "", decimal.NewFromInt(150000)); p == nil || p.ID != "brl-huge" {
+		t.Fatalf("esperada política brl-huge, obtido %+v", p)
+	}
+	if p := SelectApprovalPolicy(policies, "USD", "ethereum", decimal.NewFromInt(6000)); p == nil || p.ID != "eth-any" {
+		t.Fatalf("esperada política eth-any, obtido %+v", p)
+	}
+
+	if _, err := LoadApprovalPolicies(strings.NewReader(`[{"id": "x", "min_amount": "10", "required_approvals": 0, "ttl": "1h"}]`)); !errors.Is(err, ErrInvalidApprovalPolicy) {
+		t.Fatalf("esperado ErrInvalidApprovalPolicy, obtido %v", err)
+	}
+}
+
+func TestApprovalRequest_QuorumAndSeparationOfDuties(t *testing.T) {
+	now := time.Now()
+	tx := NewTransaction(uuid.New(), TransactionTypeWithdraw, decimal.NewFromInt(200000))
+	policy := &ApprovalPolicy{ID: "p", MinAmount: decimal.NewFromInt(1), RequiredApprovals: 2, ApproverRoles: []string{"treasury"}, TTL: Duration(time.Hour)}
+	req := NewApprovalRequest(tx, policy, "BRL", "", now)
+
+	first, second := uuid.New(), uuid.New()
+	if _, err := req.Approve(tx.UserID, []string{"treasury"}, "", now); !errors.Is(err, ErrSelfApproval) {
+		t.Fatalf("esperado ErrSelfApproval, obtido %v", err)
+	}
+	if _, err := req.Approve(first, []string{"support"}, "", now); !errors.Is(err, ErrApproverRoleNotAllowed) {
+		t.Fatalf("esperado ErrApproverRoleNotAllowed, obtido %v", err)
+	}
+	if _, err := req.Approve(first, []string{"Treasury"}, "ok", now); err != nil || req.Status != ApprovalPending {
+		t.Fatalf("primeira aprovação deveria manter pendente: %v %s", err, req.Status)
+	}
+	if _, err := req.Approve(first, []string{"treasury"}, "", now); !errors.Is(err, ErrDuplicateApproval) {
+		t.Fatalf("esperado ErrDuplicateApproval, obtido %v", err)
+	}
+	if _, err := req.Approve(second, []string{"treasury"}, "", now); err != nil || req.Status != ApprovalApproved {
+		t.Fatalf("quórum deveria aprovar: %v %s", err, req.Status)
+	}
+	if len(req.Trail) != 3 || req.Trail[0].Action != ApprovalActionRequested {
+		t.Fatalf("trilha inesperada: %+v", req.Trail)
+	}
+	if _, err := req.Reject(uuid.New(), []string{"treasury"}, "", now); !errors.Is(err, ErrApprovalResolved) {
+		t.Fatalf("solicitação resolvida não aceita decisões: %v", err)
+	}
+}
+
+func TestApprovalRequest_Expire(t *testing.T) {
+	now := time.Now()
+	tx := NewTransaction(uuid.New(), TransactionTypeWithdraw, decimal.NewFromInt(10))
+	req := NewApprovalRequest(tx, &ApprovalPolicy{ID: "p", RequiredApprovals: 1, TTL: Duration(time.Hour)}, "BRL", "", now)
+
+	if _, ok := req.Expire(now.Add(30 * time.Minute)); ok {
+		t.Fatalf("não deveria expirar antes do prazo")
+	}
+	if _, err := req.Approve(uuid.New(), nil, "", now.Add(2*time.Hour)); !errors.Is(err, ErrApprovalExpired) {
+		t.Fatalf("esperado ErrApprovalExpired, obtido %v", err)
+	}
+	action, ok := req.Expire(now.Add(2 * time.Hour))
+	if !ok || req.Status != ApprovalExpired || action.ActorID != nil {
+		t.Fatalf("expiração deveria ser registrada pelo sistema: %+v", action)
+	}
+}
+
+func TestTransactionAggregate_AwaitApproval(t *testing.T) {
+	tx := NewTransaction(uuid.New(), TransactionTypeWithdraw, decimal.NewFromInt(10))
+	agg := RestoreTransactionAggregate(tx)
+	if err := agg.Approve(uuid.New()); err == nil {
+		t.Fatalf("transação pendente não pode ser aprovada")
+	}
+	if err := agg.AwaitApproval(uuid.New(), 1, time.Now().Add(time.Hour)); err != nil || !agg.IsAwaitingApproval() {
+		t.Fatalf("deveria aguardar aprovação: %v", err)
+	}
+	if err := agg.Approve(uuid.New()); err != nil || !agg.IsPending() {
+		t.Fatalf("aprovação deveria liberar a transação: %v", err)
+	}
+	if len(agg.DomainEvents()) != 2 {
+		t.Fatalf("esperados 2 eventos, obtidos %d", len(agg.DomainEvents()))
+	}
+}
@@ -0,0 +1,246 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidBRCode = errors.New("invalid pix br code")
+	ErrBRCodeCRC     = errors.New("pix br code checksum mismatch")
+)
+
+// Identificadores EMV do BR Code (Manual de Padrões para Iniciação do Pix)
+const (
+	brPayloadFormat     = "00"
+	brPointOfInitiation = "01"
+	brMerchantAccount   = "26"
+	brMCC               = "52"
+	brCurrency          = "53"
+	brAmount            = "54"
+	brCountry           = "58"
+	brMerchantName      = "59"
+	brMerchantCity      = "60"
+	brPostalCode        = "61"
+	brAdditionalData    = "62"
+	brCRC               = "63"
+
+	brGUI         = "00"
+	brKey         = "01"
+	brInfo        = "02"
+	brURL         = "25"
+	brReference   = "05"
+	brPixGUI      = "br.gov.bcb.pix"
+	brCurrencyBRL = "986"
+	// BRNoTxID txid de códigos estáticos sem identificador e de todos os códigos dinâmicos
+	BRNoTxID = "***"
+)
+
+var (
+	staticTxIDPattern  = regexp.MustCompile(`^[A-Za-z0-9]{1,25}$`)
+	dynamicTxIDPattern = regexp.MustCompile(`^[A-Za-z0-9]{26,35}$`)
+)
+
+// ValidStaticTxID txid aceito em códigos estáticos: até 25 caracteres alfanuméricos
+func ValidStaticTxID(txid string) bool {
+	return staticTxIDPattern.MatchString(txid)
+}
+
+// ValidDynamicTxID txid de cobranças com vencimento na API do PSP: 26 a 35 caracteres alfanuméricos
+func ValidDynamicTxID(txid string) bool {
+	return dynamicTxIDPattern.MatchString(txid)
+}
+
+// BRCode conteúdo do "Pix copia e cola". Códigos estáticos trazem a chave (e opcionalmente valor e
+// txid); códigos dinâmicos trazem a URL do payload da cobrança no PSP.
+type BRCode struct {
+	Dynamic      bool            `json:"dynamic"`
+	Key          string          `json:"key,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	URL          string          `json:"url,omitempty"`
+	Amount       decimal.Decimal `json:"amount"`
+	MerchantName string          `json:"merchant_name"`
+	MerchantCity string          `json:"merchant_city"`
+	PostalCode   string          `json:"postal_code,omitempty"`
+	TxID         string          `json:"txid"`
+}
+
+// HasAmount indica se o código fixa o valor do pagamento
+func (c BRCode) HasAmount() bool {
+	return c.Amount.IsPositive()
+}
+
+// Encode gera o payload EMV com o CRC16 ao final
+func (c BRCode) Encode() (string, error) {
+	if err := c.validate(); err != nil {
+		return "", err
+	}
+
+	account := emvField(brGUI, brPixGUI)
+	if c.Dynamic {
+		account += emvField(brURL, strings.TrimPrefix(c.URL, "https://"))
+	} else {
+		account += emvField(brKey, c.Key)
+		if c.Description != "" {
+			account += emvField(brInfo, c.Description)
+		}
+	}
+	txid := c.TxID
+	if txid == "" || c.Dynamic {
+		txid = BRNoTxID
+	}
+
+	var b strings.Builder
+	b.WriteString(emvField(brPayloadFormat, "01"))
+	if c.Dynamic {
+		// Códigos dinâmicos não podem ser pagos mais de uma vez
+		b.WriteString(emvField(brPointOfInitiation, "12"))
+	}
+	b.WriteString(emvField(brMerchantAccount, account))
+	b.WriteString(emvField(brMCC, "0000"))
+	b.WriteString(emvField(brCurrency, brCurrencyBRL))
+	if c.HasAmount() {
+		b.WriteString(emvField(brAmount, c.Amount.StringFixed(2)))
+	}
+	b.WriteString(emvField(brCountry, "BR"))
+	b.WriteString(emvField(brMerchantName, c.MerchantName))
+	b.WriteString(emvField(brMerchantCity, c.MerchantCity))
+	if c.PostalCode != "" {
+		b.WriteString(emvField(brPostalCode, c.PostalCode))
+	}
+	b.WriteString(emvField(brAdditionalData, emvField(brReference, txid)))
+	b.WriteString(brCRC + "04")
+	payload := b.String()
+	return payload + CRC16(payload), nil
+}
+
+func (c BRCode) validate() error {
+	switch {
+	case c.Dynamic && c.URL == "":
+		return fmt.Errorf("%w: dynamic code requires the charge url", ErrInvalidBRCode)
+	case !c.Dynamic && c.Key == "":
+		return fmt.Errorf("%w: static code requires a pix key", ErrInvalidBRCode)
+	case !c.Dynamic && c.TxID != "" && c.TxID != BRNoTxID && !ValidStaticTxID(c.TxID):
+		return fmt.Errorf("%w: txid must have up to 25 alphanumeric characters", ErrInvalidBRCode)
+	case c.Amount.IsNegative():
+		return fmt.Errorf("%w: amount cannot be negative", ErrInvalidBRCode)
+	case c.HasAmount() && len(c.Amount.StringFixed(2)) > 13:
+		return fmt.Errorf("%w: amount too large", ErrInvalidBRCode)
+	case c.MerchantName == "" || utf8.RuneCountInString(c.MerchantName) > 25:
+		return fmt.Errorf("%w: merchant name must have 1 to 25 characters", ErrInvalidBRCode)
+	case c.MerchantCity == "" || utf8.RuneCountInString(c.MerchantCity) > 15:
+		return fmt.Errorf("%w: merchant city must have 1 to 15 characters", ErrInvalidBRCode)
+	}
+	if len(brPixGUI)+len(c.Key)+len(c.Description)+len(c.URL)+12 > 99 {
+		return fmt.Errorf("%w: key, description and url exceed the merchant account size", ErrInvalidBRCode)
+	}
+	return nil
+}
+
+// ParseBRCode valida o CRC e decodifica um payload "copia e cola"
+func ParseBRCode(payload string) (*BRCode, error) {
+	payload = strings.TrimSpace(payload)
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != brCRC+"04" {
+		return nil, fmt.Errorf("%w: missing crc", ErrInvalidBRCode)
+	}
+	if !strings.EqualFold(CRC16(payload[:len(payload)-4]), payload[len(payload)-4:]) {
+		return nil, ErrBRCodeCRC
+	}
+
+	fields, err := parseEMV(payload[:len(payload)-8])
+	if err != nil {
+		return nil, err
+	}
+	if fields[brPayloadFormat] != "01" {
+		return nil, fmt.Errorf("%w: unsupported payload format", ErrInvalidBRCode)
+	}
+	if fields[brCurrency] != brCurrencyBRL {
+		return nil, fmt.Errorf("%w: currency must be BRL (986)", ErrInvalidBRCode)
+	}
+
+	account, err := parseEMV(fields[brMerchantAccount])
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(account[brGUI], brPixGUI) {
+		return nil, fmt.Errorf("%w: not a pix code", ErrInvalidBRCode)
+	}
+
+	code := &BRCode{
+		Dynamic:      fields[brPointOfInitiation] == "12" || account[brURL] != "",
+		Key:          account[brKey],
+		Description:  account[brInfo],
+		URL:          account[brURL],
+		Amount:       decimal.Zero,
+		MerchantName: fields[brMerchantName],
+		MerchantCity: fields[brMerchantCity],
+		PostalCode:   fields[brPostalCode],
+		TxID:         BRNoTxID,
+	}
+	if code.Key == "" && code.URL == "" {
+		return nil, fmt.Errorf("%w: missing pix key or url", ErrInvalidBRCode)
+	}
+	if raw, ok := fields[brAmount]; ok {
+		amount, err := decimal.NewFromString(raw)
+		if err != nil || !amount.IsPositive() {
+			return nil, fmt.Errorf("%w: invalid amount", ErrInvalidBRCode)
+		}
+		code.Amount = amount
+	}
+	if raw, ok := fields[brAdditionalData]; ok {
+		additional, err := parseEMV(raw)
+		if err != nil {
+			return nil, err
+		}
+		if txid := additional[brReference]; txid != "" {
+			code.TxID = txid
+		}
+	}
+	return code, nil
+}
+
+// CRC16 CRC-16/CCITT-FALSE (polinômio 0x1021, valor inicial 0xFFFF) em 4 dígitos hexadecimais maiúsculos
+func CRC16(data string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// emvField codifica ID + tamanho (2 dígitos, em caracteres) + valor
+func emvField(id, value string) string {
+	return id + fmt.Sprintf("%02d", utf8.RuneCountInString(value)) + value
+}
+
+// parseEMV decodifica uma sequência de campos ID + tamanho + valor
+func parseEMV(data string) (map[string]string, error) {
+	fields := make(map[string]string)
+	runes := []rune(data)
+	for i := 0; i < len(runes); {
+		if i+4 > len(runes) {
+			return nil, fmt.Errorf("%w: truncated field", ErrInvalidBRCode)
+		}
+		id := string(runes[i : i+2])
+		size, err := strconv.Atoi(string(runes[i+2 : i+4]))
+		if err != nil || i+4+size > len(runes) {
+			return nil, fmt.Errorf("%w: invalid length for field %s", ErrInvalidBRCode, id)
+		}
+		fields[id] = string(runes[i+4 : i+4+size])
+		i += 4 + size
+	}
+	return fields, nil
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, "29B1", CRC16("123456789"))
+}
+
+func TestParseBRCode_CentralBankExample(t *testing.T) {
+	payload := "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR" +
+		"5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+	code, err := ParseBRCode(payload)
+	require.NoError(t, err)
+	assert.False(t, code.Dynamic)
+	assert.Equal(t, "123e4567-e12b-12d1-a456-426655440000", code.Key)
+	assert.Equal(t, "Fulano de Tal", code.MerchantName)
+	assert.Equal(t, "BRASILIA", code.MerchantCity)
+	assert.Equal(t, BRNoTxID, code.TxID)
+	assert.False(t, code.HasAmount())
+
+	encoded, err := code.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, payload, encoded)
+
+	_, err = ParseBRCode(payload[:len(payload)-4] + "0000")
+	assert.ErrorIs(t, err, ErrBRCodeCRC)
+}
+
+func TestBRCode_RoundTrip(t *testing.T) {
+	static := BRCode{
+		Key:          "pagamentos@example.com",
+		Description:  "Depósito",
+		Amount:       decimal.RequireFromString("1234.5"),
+		MerchantName: "São João Ltda",
+		MerchantCity: "SÃO PAULO",
+		TxID:         "ABC123",
+	}
+	payload, err := static.Encode()
+	require.NoError(t, err)
+	assert.Contains(t, payload, "54071234.50")
+
+	parsed, err := ParseBRCode(payload)
+	require.NoError(t, err)
+	assert.Equal(t, static.Key, parsed.Key)
+	assert.Equal(t, static.Description, parsed.Description)
+	assert.Equal(t, static.MerchantName, parsed.MerchantName, "tamanhos contam caracteres, não bytes")
+	assert.True(t, parsed.Amount.Equal(static.Amount))
+	assert.Equal(t, "ABC123", parsed.TxID)
+
+	dynamic := BRCode{Dynamic: true, URL: "https://psp.example.com/v2/cobv/9d36b84f", MerchantName: "Loja", MerchantCity: "RECIFE", TxID: "ignored"}
+	payload, err = dynamic.Encode()
+	require.NoError(t, err)
+	assert.Contains(t, payload, "010212")
+	parsed, err = ParseBRCode(payload)
+	require.NoError(t, err)
+	assert.True(t, parsed.Dynamic)
+	assert.Equal(t, "psp.example.com/v2/cobv/9d36b84f", parsed.URL)
+	assert.Equal(t, BRNoTxID, parsed.TxID, "códigos dinâmicos usam o txid da cobrança no PSP")
+}
+
+func TestBRCode_Validation(t *testing.T) {
+	base := BRCode{Key: "k", MerchantName: "N", MerchantCity: "C"}
+
+	invalid := map[string]func(c *BRCode){
+		"no key":          func(c *BRCode) { c.Key = "" },
+		"dynamic no url":  func(c *BRCode) { c.Dynamic = true },
+		"long txid":       func(c *BRCode) { c.TxID = "ABCDEFGHIJKLMNOPQRSTUVWXYZ" },
+		"txid symbols":    func(c *BRCode) { c.TxID = "a-b" },
+		"negative amount": func(c *BRCode) { c.Amount = decimal.NewFromInt(-1) },
+		"long city":       func(c *BRCode) { c.MerchantCity = "RIO DE JANEIRO RJ" },
+	}
+	for name, mutate := range invalid {
+		c := base
+		mutate(&c)
+		_, err := c.Encode()
+		assert.ErrorIs(t, err, ErrInvalidBRCode, name)
+	}
+
+	_, err := ParseBRCode("not a code")
+	assert.ErrorIs(t, err, ErrInvalidBRCode)
+}
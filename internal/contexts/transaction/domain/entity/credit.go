@@ -0,0 +1,337 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CreditLineStatus estado da linha de crédito (cheque especial) da carteira
+type CreditLineStatus string
+
+const (
+	CreditLineActive CreditLineStatus = "active"
+	// CreditLineFrozen não libera novo uso do limite; o saldo positivo da carteira segue disponível
+	CreditLineFrozen CreditLineStatus = "frozen"
+	CreditLineClosed CreditLineStatus = "closed"
+)
+
+// CreditChargeKind natureza do encargo lançado na linha de crédito
+type CreditChargeKind string
+
+const (
+	// CreditChargeInterest juros do ciclo sobre o limite utilizado
+	CreditChargeInterest CreditChargeKind = "interest"
+	// CreditChargeUsageFee tarifa mensal dos ciclos em que o limite foi usado
+	CreditChargeUsageFee CreditChargeKind = "usage_fee"
+	// CreditChargeLateFee multa cobrada uma vez quando os encargos entram em atraso
+	CreditChargeLateFee CreditChargeKind = "late_fee"
+)
+
+var (
+	ErrInvalidCreditTerms  = errors.New("invalid credit line terms")
+	ErrCreditLimitExceeded = errors.New("credit limit exceeded")
+	ErrCreditLinePastDue   = errors.New("credit line has past-due charges")
+	ErrCreditLineClosed    = errors.New("credit line is closed")
+	ErrCreditLineInUse     = errors.New("credit line has an outstanding balance")
+	ErrCreditLineConflict  = errors.New("credit line was modified concurrently")
+)
+
+// CreditTerms condições aprovadas da linha de crédito
+type CreditTerms struct {
+	Limit      decimal.Decimal `json:"limit"`       // saldo negativo máximo da carteira
+	AnnualRate decimal.Decimal `json:"annual_rate"` // juros anuais sobre o utilizado (0.12 = 12% a.a.)
+	DayCount   DayCount        `json:"day_count"`
+	UsageFee   decimal.Decimal `json:"usage_fee"`  // tarifa por ciclo com uso
+	LateFee    decimal.Decimal `json:"late_fee"`   // multa por atraso
+	GraceDays  int             `json:"grace_days"` // dias após o fechamento do ciclo para pagar os encargos
+}
+
+// Validate confere os termos e normaliza a convenção de dias
+func (t *CreditTerms) Validate() error {
+	if !t.Limit.IsPositive() {
+		return fmt.Errorf("%w: limit must be positive", ErrInvalidCreditTerms)
+	}
+	if t.AnnualRate.IsNegative() || t.AnnualRate.GreaterThan(maxAnnualInterestRate) {
+		return fmt.Errorf("%w: annual_rate must be between 0 and 1", ErrInvalidCreditTerms)
+	}
+	dayCount, err := ParseDayCount(string(t.DayCount))
+	if err != nil {
+		return err
+	}
+	t.DayCount = dayCount
+	if t.UsageFee.IsNegative() || t.LateFee.IsNegative() {
+		return fmt.Errorf("%w: fees cannot be negative", ErrInvalidCreditTerms)
+	}
+	if t.GraceDays < 0 || t.GraceDays > 60 {
+		return fmt.Errorf("%w: grace_days must be between 0 and 60", ErrInvalidCreditTerms)
+	}
+	return nil
+}
+
+// CreditLine limite de cheque especial aprovado para a carteira em moeda base. O principal é o saldo
+// negativo da carteira; juros e tarifas ficam em aberto na linha até serem pagos pelos depósitos.
+type CreditLine struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	CreditTerms
+	Status          CreditLineStatus `json:"status"`
+	AccruedInterest decimal.Decimal  `json:"accrued_interest"` // juros apropriados ainda não faturados
+	InterestDue     decimal.Decimal  `json:"interest_due"`     // juros faturados em aberto
+	FeesDue         decimal.Decimal  `json:"fees_due"`         // tarifas e multas em aberto
+	DueDate         *time.Time       `json:"due_date,omitempty"`
+	UsedInCycle     bool             `json:"used_in_cycle"`
+	LateFeeCharged  bool             `json:"late_fee_charged"`
+	LastAccrualDate *time.Time       `json:"last_accrual_date,omitempty"`
+	Version         int              `json:"-"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	ClosedAt        *time.Time       `json:"closed_at,omitempty"`
+}
+
+// NewCreditLine aprova a linha de crédito do usuário nos termos informados
+func NewCreditLine(userID uuid.UUID, terms CreditTerms, now time.Time) (*CreditLine, error) {
+	if err := terms.Validate(); err != nil {
+		return nil, err
+	}
+	return &CreditLine{
+		ID:              uuid.New(),
+		UserID:          userID,
+		CreditTerms:     terms,
+		Status:          CreditLineActive,
+		AccruedInterest: decimal.Zero,
+		InterestDue:     decimal.Zero,
+		FeesDue:         decimal.Zero,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// UpdateTerms altera os termos; um limite menor que o utilizado bloqueia as saídas até a quitação
+func (l *CreditLine) UpdateTerms(terms CreditTerms, now time.Time) error {
+	if l.Status == CreditLineClosed {
+		return ErrCreditLineClosed
+	}
+	if err := terms.Validate(); err != nil {
+		return err
+	}
+	l.CreditTerms = terms
+	l.UpdatedAt = now
+	return nil
+}
+
+// SetFrozen congela ou libera o uso do limite
+func (l *CreditLine) SetFrozen(frozen bool, now time.Time) error {
+	if l.Status == CreditLineClosed {
+		return ErrCreditLineClosed
+	}
+	l.Status = CreditLineActive
+	if frozen {
+		l.Status = CreditLineFrozen
+	}
+	l.UpdatedAt = now
+	return nil
+}
+
+// Close encerra a linha; exige carteira sem saldo negativo e nenhum encargo pendente
+func (l *CreditLine) Close(balance decimal.Decimal, now time.Time) error {
+	if l.Status == CreditLineClosed {
+		return ErrCreditLineClosed
+	}
+	if l.Used(balance).IsPositive() || l.Outstanding().IsPositive() || l.AccruedInterest.IsPositive() {
+		return ErrCreditLineInUse
+	}
+	l.Status = CreditLineClosed
+	l.ClosedAt = &now
+	l.UpdatedAt = now
+	return nil
+}
+
+// Used parcela do limite utilizada: o saldo negativo da carteira
+func (l *CreditLine) Used(balance decimal.Decimal) decimal.Decimal {
+	if balance.IsNegative() {
+		return balance.Neg()
+	}
+	return decimal.Zero
+}
+
+// Available limite ainda disponível para uso
+func (l *CreditLine) Available(balance decimal.Decimal) decimal.Decimal {
+	available := l.Limit.Sub(l.Used(balance))
+	if available.IsNegative() || l.Status != CreditLineActive {
+		return decimal.Zero
+	}
+	return available
+}
+
+// Outstanding juros e tarifas faturados em aberto
+func (l *CreditLine) Outstanding() decimal.Decimal {
+	return l.InterestDue.Add(l.FeesDue)
+}
+
+// PastDue indica encargos em aberto após o vencimento
+func (l *CreditLine) PastDue(now time.Time) bool {
+	return l.DueDate != nil && now.After(*l.DueDate) && l.Outstanding().IsPositive()
+}
+
+// OverdraftLimit saldo negativo permitido nas saídas. Encargos em atraso ou uso acima do limite
+// bloqueiam qualquer saída até a regularização.
+func (l *CreditLine) OverdraftLimit(balance decimal.Decimal, now time.Time) (decimal.Decimal, error) {
+	if l.Status == CreditLineClosed {
+		return decimal.Zero, nil
+	}
+	if l.PastDue(now) {
+		return decimal.Zero, ErrCreditLinePastDue
+	}
+	if l.Used(balance).GreaterThan(l.Limit) {
+		return decimal.Zero, ErrCreditLimitExceeded
+	}
+	if l.Status == CreditLineFrozen {
+		return decimal.Zero, nil
+	}
+	return l.Limit, nil
+}
+
+// Accrue apropria os juros do dia sobre o utilizado; retorna o valor apropriado
+func (l *CreditLine) Accrue(balance decimal.Decimal, day time.Time) decimal.Decimal {
+	used := l.Used(balance)
+	d := day
+	l.LastAccrualDate = &d
+	if !used.IsPositive() {
+		return decimal.Zero
+	}
+	l.UsedInCycle = true
+	amount := used.Mul(l.DayCount.DailyRate(l.AnnualRate, day)).Round(interestAmountPlaces)
+	l.AccruedInterest = l.AccruedInterest.Add(amount)
+	return amount
+}
+
+// Bill fecha o ciclo: fatura os juros apropriados (em centavos; a fração segue para o próximo ciclo)
+// e a tarifa de uso, com vencimento em GraceDays dias a partir de due
+func (l *CreditLine) Bill(period string, due time.Time, now time.Time) []*CreditCharge {
+	var charges []*CreditCharge
+	if interest := l.AccruedInterest.RoundFloor(interestCapitalizationPlaces); interest.IsPositive() {
+		l.AccruedInterest = l.AccruedInterest.Sub(interest)
+		l.InterestDue = l.InterestDue.Add(interest)
+		charges = append(charges, l.charge(CreditChargeInterest, interest, period, now))
+	}
+	if l.UsedInCycle && l.UsageFee.IsPositive() {
+		l.FeesDue = l.FeesDue.Add(l.UsageFee)
+		charges = append(charges, l.charge(CreditChargeUsageFee, l.UsageFee, period, now))
+	}
+	l.UsedInCycle = false
+	if len(charges) > 0 && l.DueDate == nil {
+		dueDate := due.AddDate(0, 0, l.GraceDays)
+		l.DueDate = &dueDate
+	}
+	return charges
+}
+
+// ChargeLateFee lança a multa uma única vez por atraso
+func (l *CreditLine) ChargeLateFee(period string, now time.Time) *CreditCharge {
+	if !l.PastDue(now) || l.LateFeeCharged || !l.LateFee.IsPositive() {
+		return nil
+	}
+	l.LateFeeCharged = true
+	l.FeesDue = l.FeesDue.Add(l.LateFee)
+	return l.charge(CreditChargeLateFee, l.LateFee, period, now)
+}
+
+func (l *CreditLine) charge(kind CreditChargeKind, amount decimal.Decimal, period string, now time.Time) *CreditCharge {
+	return &CreditCharge{
+		ID:        uuid.New(),
+		LineID:    l.ID,
+		UserID:    l.UserID,
+		Kind:      kind,
+		Amount:    amount,
+		Period:    period,
+		CreatedAt: now,
+	}
+}
+
+// Allocate reparte um valor recebido entre tarifas, juros e principal, nessa ordem. balanceBefore é
+// o saldo da carteira antes do crédito, que define o principal utilizado.
+func (l *CreditLine) Allocate(amount, balanceBefore decimal.Decimal) CreditAllocation {
+	rest := amount
+	take := func(due decimal.Decimal) decimal.Decimal {
+		paid := decimal.Min(rest, due)
+		if paid.IsNegative() {
+			paid = decimal.Zero
+		}
+		rest = rest.Sub(paid)
+		return paid
+	}
+	allocation := CreditAllocation{}
+	allocation.Fees = take(l.FeesDue)
+	allocation.Interest = take(l.InterestDue)
+	allocation.Principal = take(l.Used(balanceBefore))
+
+	l.FeesDue = l.FeesDue.Sub(allocation.Fees)
+	l.InterestDue = l.InterestDue.Sub(allocation.Interest)
+	if !l.Outstanding().IsPositive() {
+		l.DueDate = nil
+		l.LateFeeCharged = false
+	}
+	return allocation
+}
+
+// Restore devolve à linha os encargos de uma alocação que não pôde ser debitada da carteira
+func (l *CreditLine) Restore(allocation CreditAllocation, dueDate *time.Time) {
+	l.FeesDue = l.FeesDue.Add(allocation.Fees)
+	l.InterestDue = l.InterestDue.Add(allocation.Interest)
+	if l.DueDate == nil {
+		l.DueDate = dueDate
+	}
+}
+
+// CreditAllocation repartição de um pagamento da linha de crédito
+type CreditAllocation struct {
+	Fees      decimal.Decimal `json:"fees"`
+	Interest  decimal.Decimal `json:"interest"`
+	Principal decimal.Decimal `json:"principal"`
+}
+
+// Charges juros e tarifas pagos, debitados da carteira
+func (a CreditAllocation) Charges() decimal.Decimal {
+	return a.Fees.Add(a.Interest)
+}
+
+// CreditCharge encargo lançado na linha de crédito
+type CreditCharge struct {
+	ID        uuid.UUID        `json:"id"`
+	LineID    uuid.UUID        `json:"line_id"`
+	UserID    uuid.UUID        `json:"user_id"`
+	Kind      CreditChargeKind `json:"kind"`
+	Amount    decimal.Decimal  `json:"amount"`
+	Period    string           `json:"period"` // AAAA-MM do ciclo
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// CreditRepayment pagamento da linha de crédito a partir de um depósito ou do saldo positivo
+type CreditRepayment struct {
+	ID     uuid.UUID `json:"id"`
+	LineID uuid.UUID `json:"line_id"`
+	UserID uuid.UUID `json:"user_id"`
+	// SourceTxID depósito que originou o pagamento; nil na cobrança sobre o saldo positivo
+	SourceTxID *uuid.UUID      `json:"source_transaction_id,omitempty"`
+	Amount     decimal.Decimal `json:"amount"`
+	CreditAllocation
+	FeeTxID      *uuid.UUID `json:"fee_transaction_id,omitempty"`
+	InterestTxID *uuid.UUID `json:"interest_transaction_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreditAccrualRun execução diária da apropriação de juros das linhas de crédito
+type CreditAccrualRun struct {
+	RunDate    time.Time       `json:"run_date"`
+	Lines      int             `json:"lines"`
+	Accrued    decimal.Decimal `json:"accrued"`
+	Charges    int             `json:"charges"`
+	Repayments int             `json:"repayments"`
+	Failures   int             `json:"failures"`
+	StartedAt  time.Time       `json:"started_at"`
+	FinishedAt time.Time       `json:"finished_at"`
+}
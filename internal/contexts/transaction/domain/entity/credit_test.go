@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func creditTerms() CreditTerms {
+	return CreditTerms{
+		Limit:      decimal.NewFromInt(1000),
+		AnnualRate: decimal.RequireFromString("0.365"),
+		DayCount:   "act/365",
+		UsageFee:   decimal.NewFromInt(10),
+		LateFee:    decimal.NewFromInt(20),
+		GraceDays:  5,
+	}
+}
+
+func TestNewCreditLine_Validation(t *testing.T) {
+	now := time.Now()
+	line, err := NewCreditLine(uuid.New(), creditTerms(), now)
+	require.NoError(t, err)
+	assert.Equal(t, CreditLineActive, line.Status)
+	assert.Equal(t, DayCountACT365, line.DayCount)
+
+	bad := creditTerms()
+	bad.Limit = decimal.Zero
+	_, err = NewCreditLine(uuid.New(), bad, now)
+	assert.ErrorIs(t, err, ErrInvalidCreditTerms)
+
+	bad = creditTerms()
+	bad.AnnualRate = decimal.NewFromInt(12)
+	_, err = NewCreditLine(uuid.New(), bad, now)
+	assert.ErrorIs(t, err, ErrInvalidCreditTerms, "12 = 1200% a.a., provável percentual digitado")
+
+	bad = creditTerms()
+	bad.LateFee = decimal.NewFromInt(-1)
+	_, err = NewCreditLine(uuid.New(), bad, now)
+	assert.ErrorIs(t, err, ErrInvalidCreditTerms)
+
+	bad = creditTerms()
+	bad.DayCount = "30/360"
+	_, err = NewCreditLine(uuid.New(), bad, now)
+	assert.ErrorIs(t, err, ErrUnknownDayCount)
+}
+
+func TestCreditLine_OverdraftLimit(t *testing.T) {
+	now := interestDay(2025, 2, 10)
+	line, err := NewCreditLine(uuid.New(), creditTerms(), now)
+	require.NoError(t, err)
+
+	limit, err := line.OverdraftLimit(decimal.NewFromInt(-400), now)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", limit.String())
+	assert.Equal(t, "600", line.Available(decimal.NewFromInt(-400)).String())
+
+	_, err = line.OverdraftLimit(decimal.NewFromInt(-1001), now)
+	assert.ErrorIs(t, err, ErrCreditLimitExceeded, "limite reduzido abaixo do utilizado")
+
+	require.NoError(t, line.SetFrozen(true, now))
+	limit, err = line.OverdraftLimit(decimal.NewFromInt(-400), now)
+	require.NoError(t, err)
+	assert.True(t, limit.IsZero(), "congelada não libera novo uso")
+	require.NoError(t, line.SetFrozen(false, now))
+
+	due := interestDay(2025, 2, 5)
+	line.DueDate = &due
+	line.FeesDue = decimal.NewFromInt(10)
+	_, err = line.OverdraftLimit(decimal.NewFromInt(100), now)
+	assert.ErrorIs(t, err, ErrCreditLinePastDue, "atraso bloqueia mesmo com saldo positivo")
+
+	assert.ErrorIs(t, line.Close(decimal.NewFromInt(100), now), ErrCreditLineInUse)
+}
+
+func TestCreditLine_AccrueBillAndLateFee(t *testing.T) {
+	line, err := NewCreditLine(uuid.New(), creditTerms(), interestDay(2025, 1, 1))
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.5", line.Accrue(decimal.NewFromInt(-500), interestDay(2025, 1, 30)).String())
+	assert.Equal(t, "0.0123", line.Accrue(decimal.RequireFromString("-12.3"), interestDay(2025, 1, 31)).String())
+	assert.True(t, line.Accrue(decimal.NewFromInt(50), interestDay(2025, 1, 31)).IsZero(), "saldo positivo não gera juros")
+
+	charges := line.Bill("2025-01", interestDay(2025, 2, 1), time.Now())
+	require.Len(t, charges, 2)
+	assert.Equal(t, CreditChargeInterest, charges[0].Kind)
+	assert.Equal(t, "0.51", charges[0].Amount.String())
+	assert.Equal(t, CreditChargeUsageFee, charges[1].Kind)
+	assert.Equal(t, "0.0023", line.AccruedInterest.String(), "fração abaixo do centavo segue para o próximo ciclo")
+	assert.Equal(t, interestDay(2025, 2, 6), *line.DueDate)
+	assert.Empty(t, line.Bill("2025-02", interestDay(2025, 3, 1), time.Now()), "ciclo sem uso não gera encargos")
+
+	assert.Nil(t, line.ChargeLateFee("2025-02", interestDay(2025, 2, 6)), "no vencimento ainda não está em atraso")
+	late := line.ChargeLateFee("2025-02", interestDay(2025, 2, 7))
+	require.NotNil(t, late)
+	assert.Equal(t, CreditChargeLateFee, late.Kind)
+	assert.Nil(t, line.ChargeLateFee("2025-02", interestDay(2025, 2, 8)), "multa uma única vez por atraso")
+	assert.Equal(t, "30", line.FeesDue.String())
+}
+
+func TestCreditLine_AllocateAndRestore(t *testing.T) {
+	line, err := NewCreditLine(uuid.New(), creditTerms(), time.Now())
+	require.NoError(t, err)
+	due := interestDay(2025, 2, 6)
+	line.DueDate = &due
+	line.FeesDue = decimal.NewFromInt(30)
+	line.InterestDue = decimal.RequireFromString("1.5")
+
+	partial := line.Allocate(decimal.NewFromInt(20), decimal.NewFromInt(-500))
+	assert.Equal(t, "20", partial.Fees.String())
+	assert.True(t, partial.Interest.IsZero())
+	assert.True(t, partial.Principal.IsZero())
+	assert.NotNil(t, line.DueDate, "encargos ainda em aberto")
+
+	line.Restore(partial, &due)
+	assert.Equal(t, "30", line.FeesDue.String())
+
+	full := line.Allocate(decimal.NewFromInt(600), decimal.NewFromInt(-500))
+	assert.Equal(t, "30", full.Fees.String())
+	assert.Equal(t, "1.5", full.Interest.String())
+	assert.Equal(t, "500", full.Principal.String(), "principal limitado ao utilizado")
+	assert.Equal(t, "31.5", full.Charges().String())
+	assert.True(t, line.Outstanding().IsZero())
+	assert.Nil(t, line.DueDate)
+
+	line.Restore(full, &due)
+	assert.Equal(t, "31.5", line.Outstanding().String())
+	assert.Equal(t, due, *line.DueDate)
+}
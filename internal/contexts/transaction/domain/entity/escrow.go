@@ -0,0 +1,314 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidEscrow           = errors.New("invalid escrow")
+	ErrEscrowNotParty          = errors.New("user is not allowed to act on this escrow")
+	ErrEscrowInvalidState      = errors.New("operation not allowed in the current escrow state")
+	ErrEscrowConditionsPending = errors.New("escrow conditions are not all met")
+	ErrEscrowDeadlinePassed    = errors.New("escrow deadline has passed")
+	ErrEscrowNotExpired        = errors.New("escrow deadline has not passed")
+	ErrEscrowConflict          = errors.New("escrow was modified concurrently")
+)
+
+// EscrowStatus estado da custódia
+type EscrowStatus string
+
+const (
+	EscrowAwaitingFunding EscrowStatus = "awaiting_funding"
+	EscrowFunded          EscrowStatus = "funded"
+	EscrowDisputed        EscrowStatus = "disputed"
+	EscrowReleased        EscrowStatus = "released"  // valor integral ao vendedor
+	EscrowRefunded        EscrowStatus = "refunded"  // valor integral de volta ao comprador
+	EscrowResolved        EscrowStatus = "resolved"  // disputa dividida pelo árbitro
+	EscrowCancelled       EscrowStatus = "cancelled" // encerrada sem depósito
+)
+
+// Closed indica se a custódia foi encerrada
+func (s EscrowStatus) Closed() bool {
+	return s == EscrowReleased || s == EscrowRefunded || s == EscrowResolved || s == EscrowCancelled
+}
+
+// EscrowTimeoutAction ação aplicada quando o prazo vence com a custódia depositada e sem disputa
+type EscrowTimeoutAction string
+
+const (
+	EscrowTimeoutRelease EscrowTimeoutAction = "release"
+	EscrowTimeoutRefund  EscrowTimeoutAction = "refund"
+)
+
+// EscrowCondition condição que o comprador confirma antes da liberação (ex.: "produto entregue")
+type EscrowCondition struct {
+	Description string     `json:"description"`
+	Met         bool       `json:"met"`
+	MetAt       *time.Time `json:"met_at,omitempty"`
+}
+
+// EscrowSpec parâmetros de criação de uma custódia
+type EscrowSpec struct {
+	BuyerID       uuid.UUID
+	SellerID      uuid.UUID
+	ArbiterID     *uuid.UUID // sem árbitro as disputas são resolvidas pelos operadores
+	Amount        decimal.Decimal
+	Description   string
+	Conditions    []string
+	Deadline      time.Time
+	TimeoutAction EscrowTimeoutAction // padrão: refund
+}
+
+// Escrow custódia entre comprador e vendedor: o valor sai da carteira do comprador no depósito
+// e só é liberado ao vendedor (ou devolvido) pelas partes, pelo árbitro ou pelo prazo
+type Escrow struct {
+	ID             uuid.UUID           `json:"id"`
+	BuyerID        uuid.UUID           `json:"buyer_id"`
+	SellerID       uuid.UUID           `json:"seller_id"`
+	ArbiterID      *uuid.UUID          `json:"arbiter_id,omitempty"`
+	Amount         decimal.Decimal     `json:"amount"`
+	Description    string              `json:"description,omitempty"`
+	Conditions     []EscrowCondition   `json:"conditions"`
+	Deadline       time.Time           `json:"deadline"`
+	TimeoutAction  EscrowTimeoutAction `json:"timeout_action"`
+	Status         EscrowStatus        `json:"status"`
+	FundingTxID    *uuid.UUID          `json:"funding_tx_id,omitempty"`
+	ReleasedAmount decimal.Decimal     `json:"released_amount"`
+	RefundedAmount decimal.Decimal     `json:"refunded_amount"`
+	ReleaseTxID    *uuid.UUID          `json:"release_tx_id,omitempty"` // pagamento ao vendedor já creditado
+	RefundTxID     *uuid.UUID          `json:"refund_tx_id,omitempty"`  // devolução ao comprador já creditada
+	DisputedBy     *uuid.UUID          `json:"disputed_by,omitempty"`
+	DisputeReason  string              `json:"dispute_reason,omitempty"`
+	ResolvedBy     *uuid.UUID          `json:"resolved_by,omitempty"`
+	Resolution     string              `json:"resolution,omitempty"`
+	Version        int                 `json:"-"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	FundedAt       *time.Time          `json:"funded_at,omitempty"`
+	ClosedAt       *time.Time          `json:"closed_at,omitempty"`
+}
+
+// NewEscrow valida e abre a custódia aguardando o depósito do comprador
+func NewEscrow(spec EscrowSpec, now time.Time) (*Escrow, error) {
+	if !spec.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidEscrow)
+	}
+	if spec.BuyerID == uuid.Nil || spec.SellerID == uuid.Nil || spec.BuyerID == spec.SellerID {
+		return nil, fmt.Errorf("%w: buyer and seller must be different users", ErrInvalidEscrow)
+	}
+	if spec.ArbiterID != nil && (*spec.ArbiterID == spec.BuyerID || *spec.ArbiterID == spec.SellerID) {
+		return nil, fmt.Errorf("%w: arbiter cannot be a party", ErrInvalidEscrow)
+	}
+	if !spec.Deadline.After(now) {
+		return nil, fmt.Errorf("%w: deadline must be in the future", ErrInvalidEscrow)
+	}
+	switch spec.TimeoutAction {
+	case "":
+		spec.TimeoutAction = EscrowTimeoutRefund
+	case EscrowTimeoutRelease, EscrowTimeoutRefund:
+	default:
+		return nil, fmt.Errorf("%w: timeout_action must be release or refund", ErrInvalidEscrow)
+	}
+
+	conditions := make([]EscrowCondition, 0, len(spec.Conditions))
+	for _, c := range spec.Conditions {
+		if c == "" {
+			return nil, fmt.Errorf("%w: empty condition", ErrInvalidEscrow)
+		}
+		conditions = append(conditions, EscrowCondition{Description: c})
+	}
+
+	return &Escrow{
+		ID:             uuid.New(),
+		BuyerID:        spec.BuyerID,
+		SellerID:       spec.SellerID,
+		ArbiterID:      spec.ArbiterID,
+		Amount:         spec.Amount,
+		Description:    spec.Description,
+		Conditions:     conditions,
+		Deadline:       spec.Deadline,
+		TimeoutAction:  spec.TimeoutAction,
+		Status:         EscrowAwaitingFunding,
+		ReleasedAmount: decimal.Zero,
+		RefundedAmount: decimal.Zero,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// IsParty indica se o usuário é comprador, vendedor ou árbitro da custódia
+func (e *Escrow) IsParty(userID uuid.UUID) bool {
+	return userID == e.BuyerID || userID == e.SellerID || (e.ArbiterID != nil && *e.ArbiterID == userID)
+}
+
+// PayoutPending indica se a custódia encerrada ainda tem pagamento ao vendedor ou devolução ao
+// comprador por creditar
+func (e *Escrow) PayoutPending() bool {
+	if !e.Status.Closed() {
+		return false
+	}
+	return (e.ReleasedAmount.IsPositive() && e.ReleaseTxID == nil) || (e.RefundedAmount.IsPositive() && e.RefundTxID == nil)
+}
+
+// AllConditionsMet indica se todas as condições foram confirmadas (sempre verdadeiro sem condições)
+func (e *Escrow) AllConditionsMet() bool {
+	for _, c := range e.Conditions {
+		if !c.Met {
+			return false
+		}
+	}
+	return true
+}
+
+// Fund registra o depósito do comprador feito pela transação txID
+func (e *Escrow) Fund(txID uuid.UUID, now time.Time) error {
+	if e.Status != EscrowAwaitingFunding {
+		return ErrEscrowInvalidState
+	}
+	if !now.Before(e.Deadline) {
+		return ErrEscrowDeadlinePassed
+	}
+	e.Status = EscrowFunded
+	e.FundingTxID = &txID
+	e.FundedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+// ConfirmCondition o comprador confirma a condição de índice idx
+func (e *Escrow) ConfirmCondition(actor uuid.UUID, idx int, now time.Time) error {
+	if actor != e.BuyerID {
+		return ErrEscrowNotParty
+	}
+	if e.Status != EscrowFunded {
+		return ErrEscrowInvalidState
+	}
+	if idx < 0 || idx >= len(e.Conditions) {
+		return fmt.Errorf("%w: unknown condition %d", ErrInvalidEscrow, idx)
+	}
+	if !e.Conditions[idx].Met {
+		e.Conditions[idx].Met = true
+		e.Conditions[idx].MetAt = &now
+		e.UpdatedAt = now
+	}
+	return nil
+}
+
+// Release o comprador libera o valor ao vendedor depois de cumpridas as condições
+func (e *Escrow) Release(actor uuid.UUID, now time.Time) error {
+	if actor != e.BuyerID {
+		return ErrEscrowNotParty
+	}
+	if e.Status != EscrowFunded {
+		return ErrEscrowInvalidState
+	}
+	if !e.AllConditionsMet() {
+		return ErrEscrowConditionsPending
+	}
+	e.settle(EscrowReleased, e.Amount, now)
+	return nil
+}
+
+// Refund o vendedor abre mão do valor, devolvido ao comprador
+func (e *Escrow) Refund(actor uuid.UUID, now time.Time) error {
+	if actor != e.SellerID {
+		return ErrEscrowNotParty
+	}
+	if e.Status != EscrowFunded {
+		return ErrEscrowInvalidState
+	}
+	e.settle(EscrowRefunded, decimal.Zero, now)
+	return nil
+}
+
+// Cancel encerra a custódia ainda não depositada (comprador ou vendedor)
+func (e *Escrow) Cancel(actor uuid.UUID, now time.Time) error {
+	if actor != e.BuyerID && actor != e.SellerID {
+		return ErrEscrowNotParty
+	}
+	if e.Status != EscrowAwaitingFunding {
+		return ErrEscrowInvalidState
+	}
+	e.settle(EscrowCancelled, decimal.Zero, now)
+	return nil
+}
+
+// Dispute comprador ou vendedor contesta a custódia depositada; o prazo deixa de valer até a decisão
+func (e *Escrow) Dispute(actor uuid.UUID, reason string, now time.Time) error {
+	if actor != e.BuyerID && actor != e.SellerID {
+		return ErrEscrowNotParty
+	}
+	if e.Status != EscrowFunded {
+		return ErrEscrowInvalidState
+	}
+	if reason == "" {
+		return fmt.Errorf("%w: dispute reason is required", ErrInvalidEscrow)
+	}
+	e.Status = EscrowDisputed
+	e.DisputedBy = &actor
+	e.DisputeReason = reason
+	e.UpdatedAt = now
+	return nil
+}
+
+// Resolve o árbitro (ou um operador, quando operator é verdadeiro) decide a disputa: sellerAmount vai
+// ao vendedor e o restante volta ao comprador
+func (e *Escrow) Resolve(resolver uuid.UUID, operator bool, sellerAmount decimal.Decimal, note string, now time.Time) error {
+	if !operator && (e.ArbiterID == nil || *e.ArbiterID != resolver) {
+		return ErrEscrowNotParty
+	}
+	if e.Status != EscrowDisputed {
+		return ErrEscrowInvalidState
+	}
+	if sellerAmount.IsNegative() || sellerAmount.GreaterThan(e.Amount) {
+		return fmt.Errorf("%w: seller_amount must be between 0 and the escrow amount", ErrInvalidEscrow)
+	}
+	status := EscrowResolved
+	switch {
+	case sellerAmount.Equal(e.Amount):
+		status = EscrowReleased
+	case sellerAmount.IsZero():
+		status = EscrowRefunded
+	}
+	e.ResolvedBy = &resolver
+	e.Resolution = note
+	e.settle(status, sellerAmount, now)
+	return nil
+}
+
+// Expire aplica o vencimento do prazo: sem depósito a custódia é cancelada; depositada e sem disputa
+// segue TimeoutAction. Custódias em disputa aguardam o árbitro.
+func (e *Escrow) Expire(now time.Time) error {
+	if now.Before(e.Deadline) {
+		return ErrEscrowNotExpired
+	}
+	switch e.Status {
+	case EscrowAwaitingFunding:
+		e.settle(EscrowCancelled, decimal.Zero, now)
+	case EscrowFunded:
+		if e.TimeoutAction == EscrowTimeoutRelease {
+			e.settle(EscrowReleased, e.Amount, now)
+		} else {
+			e.settle(EscrowRefunded, decimal.Zero, now)
+		}
+	default:
+		return ErrEscrowInvalidState
+	}
+	return nil
+}
+
+// settle encerra a custódia com sellerAmount ao vendedor e o restante depositado ao comprador
+func (e *Escrow) settle(status EscrowStatus, sellerAmount decimal.Decimal, now time.Time) {
+	e.Status = status
+	if e.FundingTxID != nil {
+		e.ReleasedAmount = sellerAmount
+		e.RefundedAmount = e.Amount.Sub(sellerAmount)
+	}
+	e.ClosedAt = &now
+	e.UpdatedAt = now
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEscrow(t *testing.T, now time.Time, conditions ...string) *Escrow {
+	t.Helper()
+	arbiter := uuid.New()
+	e, err := NewEscrow(EscrowSpec{
+		BuyerID:    uuid.New(),
+		SellerID:   uuid.New(),
+		ArbiterID:  &arbiter,
+		Amount:     decimal.NewFromInt(500),
+		Conditions: conditions,
+		Deadline:   now.Add(72 * time.Hour),
+	}, now)
+	require.NoError(t, err)
+	return e
+}
+
+func TestNewEscrow_Validation(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	buyer, seller := uuid.New(), uuid.New()
+	valid := EscrowSpec{BuyerID: buyer, SellerID: seller, Amount: decimal.NewFromInt(10), Deadline: now.Add(time.Hour)}
+
+	e, err := NewEscrow(valid, now)
+	require.NoError(t, err)
+	assert.Equal(t, EscrowAwaitingFunding, e.Status)
+	assert.Equal(t, EscrowTimeoutRefund, e.TimeoutAction)
+
+	cases := map[string]func(s *EscrowSpec){
+		"zero amount":      func(s *EscrowSpec) { s.Amount = decimal.Zero },
+		"same parties":     func(s *EscrowSpec) { s.SellerID = buyer },
+		"arbiter is party": func(s *EscrowSpec) { s.ArbiterID = &seller },
+		"past deadline":    func(s *EscrowSpec) { s.Deadline = now },
+		"bad action":       func(s *EscrowSpec) { s.TimeoutAction = "keep" },
+		"empty condition":  func(s *EscrowSpec) { s.Conditions = []string{""} },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := valid
+			mutate(&spec)
+			_, err := NewEscrow(spec, now)
+			assert.ErrorIs(t, err, ErrInvalidEscrow)
+		})
+	}
+}
+
+func TestEscrow_ConditionalRelease(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	e := newTestEscrow(t, now, "item shipped", "item received")
+
+	assert.ErrorIs(t, e.Release(e.BuyerID, now), ErrEscrowInvalidState)
+	require.NoError(t, e.Fund(uuid.New(), now))
+	assert.ErrorIs(t, e.Fund(uuid.New(), now), ErrEscrowInvalidState)
+
+	assert.ErrorIs(t, e.ConfirmCondition(e.SellerID, 0, now), ErrEscrowNotParty)
+	require.NoError(t, e.ConfirmCondition(e.BuyerID, 0, now))
+	assert.ErrorIs(t, e.Release(e.BuyerID, now), ErrEscrowConditionsPending)
+	assert.ErrorIs(t, e.ConfirmCondition(e.BuyerID, 5, now), ErrInvalidEscrow)
+
+	require.NoError(t, e.ConfirmCondition(e.BuyerID, 1, now))
+	assert.ErrorIs(t, e.Release(e.SellerID, now), ErrEscrowNotParty)
+	require.NoError(t, e.Release(e.BuyerID, now))
+	assert.Equal(t, EscrowReleased, e.Status)
+	assert.True(t, e.ReleasedAmount.Equal(decimal.NewFromInt(500)))
+	assert.True(t, e.RefundedAmount.IsZero())
+	assert.NotNil(t, e.ClosedAt)
+}
+
+func TestEscrow_DisputeResolution(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	e := newTestEscrow(t, now)
+	require.NoError(t, e.Fund(uuid.New(), now))
+
+	assert.ErrorIs(t, e.Dispute(uuid.New(), "not delivered", now), ErrEscrowNotParty)
+	assert.ErrorIs(t, e.Dispute(e.BuyerID, "", now), ErrInvalidEscrow)
+	require.NoError(t, e.Dispute(e.BuyerID, "not delivered", now))
+	assert.ErrorIs(t, e.Release(e.BuyerID, now), ErrEscrowInvalidState)
+
+	// Disputa não vence pelo prazo
+	assert.ErrorIs(t, e.Expire(e.Deadline.Add(time.Hour)), ErrEscrowInvalidState)
+
+	assert.ErrorIs(t, e.Resolve(e.SellerID, false, decimal.NewFromInt(100), "", now), ErrEscrowNotParty)
+	assert.ErrorIs(t, e.Resolve(*e.ArbiterID, false, decimal.NewFromInt(501), "", now), ErrInvalidEscrow)
+	require.NoError(t, e.Resolve(*e.ArbiterID, false, decimal.NewFromInt(200), "partial delivery", now))
+	assert.Equal(t, EscrowResolved, e.Status)
+	assert.True(t, e.ReleasedAmount.Equal(decimal.NewFromInt(200)))
+	assert.True(t, e.RefundedAmount.Equal(decimal.NewFromInt(300)))
+}
+
+func TestEscrow_OperatorResolvesWithoutArbiter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	e := newTestEscrow(t, now)
+	e.ArbiterID = nil
+	require.NoError(t, e.Fund(uuid.New(), now))
+	require.NoError(t, e.Dispute(e.SellerID, "buyer unresponsive", now))
+
+	assert.ErrorIs(t, e.Resolve(uuid.New(), false, decimal.Zero, "", now), ErrEscrowNotParty)
+	require.NoError(t, e.Resolve(uuid.New(), true, decimal.Zero, "refund", now))
+	assert.Equal(t, EscrowRefunded, e.Status)
+	assert.True(t, e.RefundedAmount.Equal(decimal.NewFromInt(500)))
+}
+
+func TestEscrow_Expire(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	unfunded := newTestEscrow(t, now)
+	assert.ErrorIs(t, unfunded.Expire(now), ErrEscrowNotExpired)
+	require.NoError(t, unfunded.Expire(unfunded.Deadline))
+	assert.Equal(t, EscrowCancelled, unfunded.Status)
+	assert.True(t, unfunded.RefundedAmount.IsZero())
+
+	refund := newTestEscrow(t, now)
+	require.NoError(t, refund.Fund(uuid.New(), now))
+	require.NoError(t, refund.Expire(refund.Deadline))
+	assert.Equal(t, EscrowRefunded, refund.Status)
+	assert.True(t, refund.RefundedAmount.Equal(decimal.NewFromInt(500)))
+
+	release := newTestEscrow(t, now)
+	release.TimeoutAction = EscrowTimeoutRelease
+	require.NoError(t, release.Fund(uuid.New(), now))
+	require.NoError(t, release.Expire(release.Deadline))
+	assert.Equal(t, EscrowReleased, release.Status)
+
+	late := newTestEscrow(t, now)
+	assert.ErrorIs(t, late.Fund(uuid.New(), late.Deadline), ErrEscrowDeadlinePassed)
+}
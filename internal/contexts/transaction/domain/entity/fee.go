@@ -0,0 +1,213 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidFeeRule = errors.New("invalid fee rule")
+
+// FeePrecision casas decimais das taxas (mesma escala dos valores persistidos)
+const FeePrecision = 2
+
+// FeeKind forma de cálculo da taxa da plataforma
+type FeeKind string
+
+const (
+	FeeKindFlat       FeeKind = "flat"       // valor fixo
+	FeeKindPercentage FeeKind = "percentage" // percentual do valor (+ fixo opcional)
+	FeeKindTiered     FeeKind = "tiered"     // percentual progressivo por faixas (+ fixo opcional)
+)
+
+// FeeTier faixa progressiva: a parte do valor até UpTo paga Rate. UpTo zero = sem teto (última faixa)
+type FeeTier struct {
+	UpTo decimal.Decimal `json:"up_to"`
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// FeeInput dados da operação usados para escolher e calcular a regra
+type FeeInput struct {
+	Type   TransactionType
+	Chain  string // "internal" para transferências entre usuários
+	Asset  string
+	Tier   string // nível KYC do cliente
+	Amount decimal.Decimal
+}
+
+// FeeRule regra da tabela de taxas. Critérios vazios valem para qualquer valor;
+// a faixa de valor é [MinAmount, MaxAmount) e MaxAmount zero significa sem teto.
+type FeeRule struct {
+	ID              string          `json:"id"`
+	TransactionType TransactionType `json:"transaction_type,omitempty"`
+	Chain           string          `json:"chain,omitempty"`
+	Asset           string          `json:"asset,omitempty"`
+	Tier            string          `json:"tier,omitempty"`
+	MinAmount       decimal.Decimal `json:"min_amount"`
+	MaxAmount       decimal.Decimal `json:"max_amount"`
+	Kind            FeeKind         `json:"kind"`
+	Flat            decimal.Decimal `json:"flat"`
+	Rate            decimal.Decimal `json:"rate"`
+	Tiers           []FeeTier       `json:"tiers,omitempty"`
+	MinFee          decimal.Decimal `json:"min_fee"`
+	MaxFee          decimal.Decimal `json:"max_fee"` // zero = sem teto
+	// PassThroughNetworkFee repassa ao cliente a taxa de rede estimada pelo gateway da chain
+	PassThroughNetworkFee bool `json:"pass_through_network_fee,omitempty"`
+}
+
+// Validate verifica a consistência da regra
+func (r FeeRule) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("%w: id is required", ErrInvalidFeeRule)
+	}
+	if r.Flat.IsNegative() || r.Rate.IsNegative() || r.MinFee.IsNegative() || r.MaxFee.IsNegative() || r.MinAmount.IsNegative() || r.MaxAmount.IsNegative() {
+		return fmt.Errorf("%w: %s: values must not be negative", ErrInvalidFeeRule, r.ID)
+	}
+	if r.MaxAmount.IsPositive() && r.MaxAmount.LessThanOrEqual(r.MinAmount) {
+		return fmt.Errorf("%w: %s: max_amount must be greater than min_amount", ErrInvalidFeeRule, r.ID)
+	}
+	if r.MaxFee.IsPositive() && r.MaxFee.LessThan(r.MinFee) {
+		return fmt.Errorf("%w: %s: max_fee must not be lower than min_fee", ErrInvalidFeeRule, r.ID)
+	}
+	switch r.Kind {
+	case FeeKindFlat, FeeKindPercentage:
+	case FeeKindTiered:
+		if len(r.Tiers) == 0 {
+			return fmt.Errorf("%w: %s: tiered rule needs tiers", ErrInvalidFeeRule, r.ID)
+		}
+		prev := decimal.Zero
+		for i, t := range r.Tiers {
+			if t.Rate.IsNegative() {
+				return fmt.Errorf("%w: %s: tier rate must not be negative", ErrInvalidFeeRule, r.ID)
+			}
+			last := i == len(r.Tiers)-1
+			if t.UpTo.IsZero() && !last {
+				return fmt.Errorf("%w: %s: only the last tier may be unbounded", ErrInvalidFeeRule, r.ID)
+			}
+			if !t.UpTo.IsZero() && t.UpTo.LessThanOrEqual(prev) {
+				return fmt.Errorf("%w: %s: tiers must be ascending", ErrInvalidFeeRule, r.ID)
+			}
+			prev = t.UpTo
+		}
+	default:
+		return fmt.Errorf("%w: %s: unknown kind %q", ErrInvalidFeeRule, r.ID, r.Kind)
+	}
+	return nil
+}
+
+// Matches indica se a regra se aplica à operação
+func (r FeeRule) Matches(in FeeInput) bool {
+	if r.TransactionType != "" && r.TransactionType != in.Type {
+		return false
+	}
+	if r.Chain != "" && !strings.EqualFold(r.Chain, in.Chain) {
+		return false
+	}
+	if r.Asset != "" && !strings.EqualFold(r.Asset, in.Asset) {
+		return false
+	}
+	if r.Tier != "" && !strings.EqualFold(r.Tier, in.Tier) {
+		return false
+	}
+	if in.Amount.LessThan(r.MinAmount) {
+		return false
+	}
+	return !r.MaxAmount.IsPositive() || in.Amount.LessThan(r.MaxAmount)
+}
+
+// specificity quantos critérios a regra fixa; a mais específica vence
+func (r FeeRule) specificity() int {
+	n := 0
+	for _, set := range []bool{r.TransactionType != "", r.Chain != "", r.Asset != "", r.Tier != "", r.MinAmount.IsPositive() || r.MaxAmount.IsPositive()} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// Calculate calcula a taxa da plataforma (sem a taxa de rede) aplicando piso e teto
+func (r FeeRule) Calculate(amount decimal.Decimal) decimal.Decimal {
+	fee := r.Flat
+	switch r.Kind {
+	case FeeKindPercentage:
+		fee = fee.Add(amount.Mul(r.Rate))
+	case FeeKindTiered:
+		lower := decimal.Zero
+		for _, t := range r.Tiers {
+			if amount.LessThanOrEqual(lower) {
+				break
+			}
+			upper := amount
+			if !t.UpTo.IsZero() && t.UpTo.LessThan(amount) {
+				upper = t.UpTo
+			}
+			fee = fee.Add(upper.Sub(lower).Mul(t.Rate))
+			lower = upper
+		}
+	}
+	if fee.LessThan(r.MinFee) {
+		fee = r.MinFee
+	}
+	if r.MaxFee.IsPositive() && fee.GreaterThan(r.MaxFee) {
+		fee = r.MaxFee
+	}
+	return fee.Round(FeePrecision)
+}
+
+// FeeSchedule tabela de taxas da plataforma
+type FeeSchedule []FeeRule
+
+// DefaultFeeSchedule tabela padrão: transferências internas sem taxa, demais operações 1%
+func DefaultFeeSchedule() FeeSchedule {
+	return FeeSchedule{
+		{ID: "internal", Chain: "internal", Kind: FeeKindFlat},
+		{ID: "default", Kind: FeeKindPercentage, Rate: decimal.NewFromFloat(0.01)},
+	}
+}
+
+// Select retorna a regra mais específica aplicável (empate: a primeira da tabela), ou nil
+func (s FeeSchedule) Select(in FeeInput) *FeeRule {
+	var selected *FeeRule
+	for i := range s {
+		r := &s[i]
+		if !r.Matches(in) {
+			continue
+		}
+		if selected == nil || r.specificity() > selected.specificity() {
+			selected = r
+		}
+	}
+	return selected
+}
+
+// Calculate retorna a taxa da plataforma para a operação; zero quando nenhuma regra se aplica
+func (s FeeSchedule) Calculate(in FeeInput) decimal.Decimal {
+	if r := s.Select(in); r != nil {
+		return r.Calculate(in.Amount)
+	}
+	return decimal.Zero
+}
+
+// LoadFeeSchedule lê uma lista JSON de regras e valida cada uma
+func LoadFeeSchedule(r io.Reader) (FeeSchedule, error) {
+	var schedule FeeSchedule
+	if err := json.NewDecoder(r).Decode(&schedule); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeeRule, err)
+	}
+	seen := make(map[string]struct{}, len(schedule))
+	for _, rule := range schedule {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if _, dup := seen[rule.ID]; dup {
+			return nil, fmt.Errorf("%w: duplicated id %s", ErrInvalidFeeRule, rule.ID)
+		}
+		seen[rule.ID] = struct{}{}
+	}
+	return schedule, nil
+}
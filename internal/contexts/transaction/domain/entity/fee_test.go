@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFeeRule_Calculate(t *testing.T) {
+	d := decimal.RequireFromString
+	cases := []struct {
+		name   string
+		rule   FeeRule
+		amount string
+		want   string
+	}{
+		{"flat", FeeRule{Kind: FeeKindFlat, Flat: d("2.5")}, "1000", "2.5"},
+		{"percentage com fixo", FeeRule{Kind: FeeKindPercentage, Rate: d("0.01"), Flat: d("0.5")}, "200", "2.5"},
+		{"piso", FeeRule{Kind: FeeKindPercentage, Rate: d("0.01"), MinFee: d("5")}, "100", "5"},
+		{"teto", FeeRule{Kind: FeeKindPercentage, Rate: d("0.01"), MaxFee: d("50")}, "100000", "50"},
+		{"faixas progressivas", FeeRule{Kind: FeeKindTiered, Tiers: []FeeTier{
+			{UpTo: d("1000"), Rate: d("0.02")},
+			{UpTo: d("10000"), Rate: d("0.01")},
+			{Rate: d("0.005")},
+		}}, "15000", "135"}, // 20 + 90 + 25
+		{"arredondamento", FeeRule{Kind: FeeKindPercentage, Rate: d("0.015")}, "10.01", "0.15"},
+	}
+	for _, tc := range cases {
+		if got := tc.rule.Calculate(d(tc.amount)); !got.Equal(d(tc.want)) {
+			t.Errorf("%s: esperado %s, obtido %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestFeeSchedule_SelectsMostSpecific(t *testing.T) {
+	schedule, err := LoadFeeSchedule(strings.NewReader(`[
+		{"id": "default", "kind": "percentage", "rate": "0.01"},
+		{"id": "withdraw-eth", "transaction_type": "withdraw", "chain": "ethereum", "kind": "flat", "flat": "10", "pass_through_network_fee": true},
+		{"id": "withdraw-eth-enhanced", "transaction_type": "withdraw", "chain": "ethereum", "tier": "enhanced", "kind": "flat", "flat": "3"},
+		{"id": "transfer-big", "transaction_type": "transfer", "min_amount": "10000", "kind": "flat", "flat": "1"}
+	]`))
+	if err != nil {
+		t.Fatalf("carregar tabela falhou: %v", err)
+	}
+
+	in := FeeInput{Type: TransactionTypeWithdraw, Chain: "Ethereum", Tier: "basic", Amount: decimal.NewFromInt(500)}
+	if r := schedule.Select(in); r == nil || r.ID != "withdraw-eth" {
+		t.Fatalf("esperada withdraw-eth, obtida %+v", r)
+	}
+	in.Tier = "enhanced"
+	if r := schedule.Select(in); r == nil || r.ID != "withdraw-eth-enhanced" {
+		t.Fatalf("esperada withdraw-eth-enhanced, obtida %+v", r)
+	}
+	if r := schedule.Select(FeeInput{Type: TransactionTypeTransfer, Amount: decimal.NewFromInt(9999)}); r == nil || r.ID != "default" {
+		t.Fatalf("abaixo da faixa deveria cair na regra padrão: %+v", r)
+	}
+	if fee := schedule.Calculate(FeeInput{Type: TransactionTypeTransfer, Amount: decimal.NewFromInt(20000)}); !fee.Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("faixa de valor deveria aplicar taxa fixa, obtido %s", fee)
+	}
+}
+
+func TestLoadFeeSchedule_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		`[{"id": "x", "kind": "bogus"}]`,
+		`[{"id": "x", "kind": "tiered"}]`,
+		`[{"id": "x", "kind": "tiered", "tiers": [{"rate": "0.01"}, {"up_to": "10", "rate": "0.01"}]}]`,
+		`[{"id": "x", "kind": "flat", "min_fee": "10", "max_fee": "5"}]`,
+		`[{"id": "x", "kind": "flat"}, {"id": "x", "kind": "flat"}]`,
+	} {
+		if _, err := LoadFeeSchedule(strings.NewReader(raw)); !errors.Is(err, ErrInvalidFeeRule) {
+			t.Errorf("esperado ErrInvalidFeeRule para %s, obtido %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DayCount convenção de contagem de dias que converte a taxa anual em taxa diária
+type DayCount string
+
+const (
+	// DayCountACT365 juros simples de taxa/365 por dia corrido
+	DayCountACT365 DayCount = "ACT/365"
+	// DayCountACT360 juros simples de taxa/360 por dia corrido
+	DayCountACT360 DayCount = "ACT/360"
+	// DayCountBUS252 taxa equivalente (1+taxa)^(1/252)-1 por dia útil; sábados e domingos não rendem
+	DayCountBUS252 DayCount = "BUS/252"
+)
+
+const (
+	// interestAmountPlaces casas decimais do rendimento diário
+	interestAmountPlaces = 10
+	// interestCapitalizationPlaces a capitalização credita centavos
+	interestCapitalizationPlaces = 2
+)
+
+// InterestAccountStatus estado da conta remunerada
+type InterestAccountStatus string
+
+const (
+	InterestAccountActive InterestAccountStatus = "active"
+	InterestAccountClosed InterestAccountStatus = "closed"
+)
+
+var (
+	ErrUnknownDayCount        = errors.New("unknown day count convention")
+	ErrInvalidInterestType    = errors.New("invalid interest account type")
+	ErrInvalidRateSchedule    = errors.New("invalid rate schedule")
+	ErrInvalidWithholdingRate = errors.New("withholding rate must be between 0 and 1")
+	ErrInterestAccountClosed  = errors.New("interest account is closed")
+	ErrInterestTypeInactive   = errors.New("interest account type is not open for new accounts")
+)
+
+var (
+	interestTypeCodePattern = regexp.MustCompile(`^[a-z0-9_-]{2,32}$`)
+	// maxAnnualInterestRate teto da taxa anual (100% a.a.), contra erros de digitação em percentual
+	maxAnnualInterestRate = decimal.NewFromInt(1)
+)
+
+// ParseDayCount interpreta a convenção (ex.: "ACT/365", "bus/252")
+func ParseDayCount(s string) (DayCount, error) {
+	d := DayCount(strings.ToUpper(strings.TrimSpace(s)))
+	switch d {
+	case DayCountACT365, DayCountACT360, DayCountBUS252:
+		return d, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownDayCount, s)
+}
+
+// DailyRate taxa aplicada ao saldo no dia pela convenção
+func (d DayCount) DailyRate(annual decimal.Decimal, day time.Time) decimal.Decimal {
+	switch d {
+	case DayCountACT360:
+		return annual.DivRound(decimal.NewFromInt(360), 18)
+	case DayCountBUS252:
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			return decimal.Zero
+		}
+		rate, _ := annual.Float64()
+		return decimal.NewFromFloat(math.Pow(1+rate, 1.0/252) - 1).Round(18)
+	default:
+		return annual.DivRound(decimal.NewFromInt(365), 18)
+	}
+}
+
+// RateStep taxa anual (0.1 = 10% a.a.) vigente a partir da data
+type RateStep struct {
+	EffectiveFrom time.Time       `json:"effective_from"`
+	AnnualRate    decimal.Decimal `json:"annual_rate"`
+}
+
+// InterestAccountType tipo de conta remunerada: convenção de dias, tabela de taxas por vigência,
+// saldo mínimo para render e alíquota de imposto retido na capitalização
+type InterestAccountType struct {
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	DayCount        DayCount        `json:"day_count"`
+	Schedule        []RateStep      `json:"schedule"`
+	MinBalance      decimal.Decimal `json:"min_balance"`
+	WithholdingRate decimal.Decimal `json:"withholding_rate"`
+	Active          bool            `json:"active"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// NewInterestAccountType valida o tipo e ordena a tabela de taxas por vigência
+func NewInterestAccountType(code, name string, dayCount DayCount, schedule []RateStep, minBalance, withholdingRate decimal.Decimal, now time.Time) (*InterestAccountType, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !interestTypeCodePattern.MatchString(code) {
+		return nil, fmt.Errorf("%w: code must have 2-32 lowercase letters, digits, '-' or '_'", ErrInvalidInterestType)
+	}
+	if strings.TrimSpace(name) == "" {
+		name = code
+	}
+	dayCount, err := ParseDayCount(string(dayCount))
+	if err != nil {
+		return nil, err
+	}
+	if minBalance.IsNegative() {
+		return nil, fmt.Errorf("%w: min_balance cannot be negative", ErrInvalidInterestType)
+	}
+	if withholdingRate.IsNegative() || withholdingRate.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, ErrInvalidWithholdingRate
+	}
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf("%w: at least one rate is required", ErrInvalidRateSchedule)
+	}
+
+	steps := make([]RateStep, len(schedule))
+	seen := make(map[string]bool, len(schedule))
+	for i, step := range schedule {
+		if step.AnnualRate.IsNegative() || step.AnnualRate.GreaterThan(maxAnnualInterestRate) {
+			return nil, fmt.Errorf("%w: annual_rate must be between 0 and 1", ErrInvalidRateSchedule)
+		}
+		day := step.EffectiveFrom.UTC()
+		day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		key := day.Format("2006-01-02")
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate effective date %s", ErrInvalidRateSchedule, key)
+		}
+		seen[key] = true
+		steps[i] = RateStep{EffectiveFrom: day, AnnualRate: step.AnnualRate}
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].EffectiveFrom.Before(steps[j].EffectiveFrom) })
+
+	return &InterestAccountType{
+		Code:            code,
+		Name:            strings.TrimSpace(name),
+		DayCount:        dayCount,
+		Schedule:        steps,
+		MinBalance:      minBalance,
+		WithholdingRate: withholdingRate,
+		Active:          true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// RateOn taxa anual vigente no dia (comparada pela data civil); false antes da primeira vigência
+func (t *InterestAccountType) RateOn(day time.Time) (decimal.Decimal, bool) {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	rate, ok := decimal.Zero, false
+	for _, step := range t.Schedule {
+		if step.EffectiveFrom.After(date) {
+			break
+		}
+		rate, ok = step.AnnualRate, true
+	}
+	return rate, ok
+}
+
+// Accrue calcula o rendimento do dia sobre o saldo; nil quando não há rendimento (saldo abaixo do
+// mínimo, dia sem rendimento pela convenção ou antes da primeira vigência)
+func (t *InterestAccountType) Accrue(account *InterestAccount, balance decimal.Decimal, day time.Time, now time.Time) *InterestAccrual {
+	if !balance.IsPositive() || balance.LessThan(t.MinBalance) {
+		return nil
+	}
+	annual, ok := t.RateOn(day)
+	if !ok || annual.IsZero() {
+		return nil
+	}
+	daily := t.DayCount.DailyRate(annual, day)
+	amount := balance.Mul(daily).Round(interestAmountPlaces)
+	if !amount.IsPositive() {
+		return nil
+	}
+	return &InterestAccrual{
+		ID:          uuid.New(),
+		AccountID:   account.ID,
+		UserID:      account.UserID,
+		AccrualDate: day,
+		Balance:     balance,
+		AnnualRate:  annual,
+		DayCount:    t.DayCount,
+		DailyRate:   daily,
+		Amount:      amount,
+		CreatedAt:   now,
+	}
+}
+
+// InterestAccount conta remunerada do usuário: o saldo em moeda base da carteira rende pelo tipo
+// contratado. AccruedUnpaid é o rendimento apropriado ainda não capitalizado.
+type InterestAccount struct {
+	ID              uuid.UUID             `json:"id"`
+	UserID          uuid.UUID             `json:"user_id"`
+	TypeCode        string                `json:"type"`
+	Status          InterestAccountStatus `json:"status"`
+	AccruedUnpaid   decimal.Decimal       `json:"accrued_unpaid"`
+	LastAccrualDate *time.Time            `json:"last_accrual_date,omitempty"`
+	OpenedAt        time.Time             `json:"opened_at"`
+	ClosedAt        *time.Time            `json:"closed_at,omitempty"`
+}
+
+// NewInterestAccount abre a conta remunerada do tipo
+func NewInterestAccount(userID uuid.UUID, t *InterestAccountType, now time.Time) (*InterestAccount, error) {
+	if !t.Active {
+		return nil, ErrInterestTypeInactive
+	}
+	return &InterestAccount{
+		ID:            uuid.New(),
+		UserID:        userID,
+		TypeCode:      t.Code,
+		Status:        InterestAccountActive,
+		AccruedUnpaid: decimal.Zero,
+		OpenedAt:      now,
+	}, nil
+}
+
+// Close encerra a conta
+func (a *InterestAccount) Close(now time.Time) error {
+	if a.Status == InterestAccountClosed {
+		return ErrInterestAccountClosed
+	}
+	a.Status = InterestAccountClosed
+	a.ClosedAt = &now
+	return nil
+}
+
+// InterestAccrual rendimento apropriado em um dia sobre o saldo da conta
+type InterestAccrual struct {
+	ID               uuid.UUID       `json:"id"`
+	AccountID        uuid.UUID       `json:"account_id"`
+	UserID           uuid.UUID       `json:"user_id"`
+	AccrualDate      time.Time       `json:"accrual_date"`
+	Balance          decimal.Decimal `json:"balance"`
+	AnnualRate       decimal.Decimal `json:"annual_rate"`
+	DayCount         DayCount        `json:"day_count"`
+	DailyRate        decimal.Decimal `json:"daily_rate"`
+	Amount           decimal.Decimal `json:"amount"`
+	CapitalizationID *uuid.UUID      `json:"capitalization_id,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+// InterestCapitalization rendimento do mês creditado na carteira: bruto, imposto retido e líquido,
+// com as transações de rendimento e de retenção
+type InterestCapitalization struct {
+	ID              uuid.UUID       `json:"id"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	UserID          uuid.UUID       `json:"user_id"`
+	Period          string          `json:"period"` // AAAA-MM do último dia apropriado
+	Gross           decimal.Decimal `json:"gross"`
+	WithholdingRate decimal.Decimal `json:"withholding_rate"`
+	Withholding     decimal.Decimal `json:"withholding"`
+	Net             decimal.Decimal `json:"net"`
+	AccrualCount    int             `json:"accrual_count"`
+	InterestTxID    *uuid.UUID      `json:"interest_transaction_id,omitempty"`
+	TaxTxID         *uuid.UUID      `json:"withholding_transaction_id,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// NewInterestCapitalization arredonda para centavos o rendimento pendente da conta; a fração abaixo
+// do centavo segue pendente para o próximo mês. nil quando o pendente não chega a um centavo.
+func NewInterestCapitalization(account *InterestAccount, accrualCount int, withholdingRate decimal.Decimal, period string, now time.Time) *InterestCapitalization {
+	gross := account.AccruedUnpaid.RoundFloor(interestCapitalizationPlaces)
+	if !gross.IsPositive() {
+		return nil
+	}
+	withholding := gross.Mul(withholdingRate).Round(interestCapitalizationPlaces)
+	return &InterestCapitalization{
+		ID:              uuid.New(),
+		AccountID:       account.ID,
+		UserID:          account.UserID,
+		Period:          period,
+		Gross:           gross,
+		WithholdingRate: withholdingRate,
+		Withholding:     withholding,
+		Net:             gross.Sub(withholding),
+		AccrualCount:    accrualCount,
+		CreatedAt:       now,
+	}
+}
+
+// InterestAccrualRun execução diária da apropriação de rendimentos
+type InterestAccrualRun struct {
+	RunDate         time.Time       `json:"run_date"`
+	Accounts        int             `json:"accounts"`
+	Accrued         int             `json:"accrued"`
+	Total           decimal.Decimal `json:"total"`
+	Capitalizations int             `json:"capitalizations"`
+	Failures        int             `json:"failures"`
+	StartedAt       time.Time       `json:"started_at"`
+	FinishedAt      time.Time       `json:"finished_at"`
+}
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func interestDay(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func savingsType(t *testing.T, dayCount DayCount, minBalance string) *InterestAccountType {
+	t.Helper()
+	typ, err := NewInterestAccountType("savings", "Poupança", dayCount, []RateStep{
+		{EffectiveFrom: interestDay(2025, 3, 1), AnnualRate: decimal.RequireFromString("0.1460")},
+		{EffectiveFrom: interestDay(2025, 1, 1), AnnualRate: decimal.RequireFromString("0.1095")},
+	}, decimal.RequireFromString(minBalance), decimal.RequireFromString("0.225"), time.Now())
+	require.NoError(t, err)
+	return typ
+}
+
+func TestDayCount_DailyRate(t *testing.T) {
+	monday := interestDay(2025, 3, 3)
+	assert.Equal(t, "0.0003", DayCountACT365.DailyRate(decimal.RequireFromString("0.1095"), monday).String())
+	assert.Equal(t, "0.001", DayCountACT360.DailyRate(decimal.RequireFromString("0.36"), monday).String())
+
+	assert.True(t, DayCountBUS252.DailyRate(decimal.RequireFromString("0.1"), interestDay(2025, 3, 1)).IsZero(), "sábado não rende")
+	daily := DayCountBUS252.DailyRate(decimal.RequireFromString("0.1"), monday)
+	require.True(t, daily.IsPositive())
+	compounded := decimal.NewFromInt(1).Add(daily).Pow(decimal.NewFromInt(252)).Sub(decimal.NewFromInt(1))
+	assert.True(t, compounded.Sub(decimal.RequireFromString("0.1")).Abs().LessThan(decimal.RequireFromString("0.0000001")),
+		"252 dias úteis compõem a taxa anual, obtido %s", compounded)
+
+	d, err := ParseDayCount(" bus/252 ")
+	require.NoError(t, err)
+	assert.Equal(t, DayCountBUS252, d)
+	_, err = ParseDayCount("30/360")
+	assert.ErrorIs(t, err, ErrUnknownDayCount)
+}
+
+func TestNewInterestAccountType_Validation(t *testing.T) {
+	rate := []RateStep{{EffectiveFrom: interestDay(2025, 1, 1), AnnualRate: decimal.RequireFromString("0.1")}}
+	now := time.Now()
+
+	_, err := NewInterestAccountType("Poupança VIP", "", DayCountACT365, rate, decimal.Zero, decimal.Zero, now)
+	assert.ErrorIs(t, err, ErrInvalidInterestType)
+	_, err = NewInterestAccountType("savings", "", "ACT/999", rate, decimal.Zero, decimal.Zero, now)
+	assert.ErrorIs(t, err, ErrUnknownDayCount)
+	_, err = NewInterestAccountType("savings", "", DayCountACT365, nil, decimal.Zero, decimal.Zero, now)
+	assert.ErrorIs(t, err, ErrInvalidRateSchedule)
+	_, err = NewInterestAccountType("savings", "", DayCountACT365, []RateStep{{EffectiveFrom: interestDay(2025, 1, 1), AnnualRate: decimal.NewFromInt(10)}}, decimal.Zero, decimal.Zero, now)
+	assert.ErrorIs(t, err, ErrInvalidRateSchedule, "10 = 1000% a.a., provável percentual digitado")
+	_, err = NewInterestAccountType("savings", "", DayCountACT365, append(rate, rate[0]), decimal.Zero, decimal.Zero, now)
+	assert.ErrorIs(t, err, ErrInvalidRateSchedule)
+	_, err = NewInterestAccountType("savings", "", DayCountACT365, rate, decimal.Zero, decimal.RequireFromString("1.5"), now)
+	assert.ErrorIs(t, err, ErrInvalidWithholdingRate)
+
+	typ, err := NewInterestAccountType(" Savings ", "", "act/360", rate, decimal.Zero, decimal.Zero, now)
+	require.NoError(t, err)
+	assert.Equal(t, "savings", typ.Code)
+	assert.Equal(t, "savings", typ.Name)
+	assert.Equal(t, DayCountACT360, typ.DayCount)
+	assert.True(t, typ.Active)
+}
+
+func TestInterestAccountType_RateOn(t *testing.T) {
+	typ := savingsType(t, DayCountACT365, "0")
+	assert.Equal(t, interestDay(2025, 1, 1), typ.Schedule[0].EffectiveFrom, "tabela ordenada por vigência")
+
+	_, ok := typ.RateOn(interestDay(2024, 12, 31))
+	assert.False(t, ok)
+	rate, ok := typ.RateOn(interestDay(2025, 2, 28))
+	require.True(t, ok)
+	assert.Equal(t, "0.1095", rate.String())
+	rate, _ = typ.RateOn(time.Date(2025, 3, 1, 23, 0, 0, 0, time.FixedZone("BRT", -3*3600)))
+	assert.Equal(t, "0.146", rate.String(), "vigência comparada pela data civil do dia")
+}
+
+func TestInterestAccountType_Accrue(t *testing.T) {
+	typ := savingsType(t, DayCountACT365, "100")
+	account, err := NewInterestAccount(uuid.New(), typ, time.Now())
+	require.NoError(t, err)
+
+	accrual := typ.Accrue(account, decimal.NewFromInt(1000), interestDay(2025, 2, 10), time.Now())
+	require.NotNil(t, accrual)
+	assert.Equal(t, "0.3", accrual.Amount.String())
+	assert.Equal(t, account.ID, accrual.AccountID)
+	assert.Equal(t, DayCountACT365, accrual.DayCount)
+
+	assert.Nil(t, typ.Accrue(account, decimal.NewFromInt(99), interestDay(2025, 2, 10), time.Now()), "abaixo do saldo mínimo")
+	assert.Nil(t, typ.Accrue(account, decimal.NewFromInt(1000), interestDay(2024, 12, 1), time.Now()), "antes da primeira vigência")
+
+	bus := savingsType(t, DayCountBUS252, "0")
+	assert.Nil(t, bus.Accrue(account, decimal.NewFromInt(1000), interestDay(2025, 2, 9), time.Now()), "domingo não rende")
+}
+
+func TestNewInterestCapitalization(t *testing.T) {
+	typ := savingsType(t, DayCountACT365, "0")
+	account, err := NewInterestAccount(uuid.New(), typ, time.Now())
+	require.NoError(t, err)
+
+	account.AccruedUnpaid = decimal.RequireFromString("12.3456")
+	c := NewInterestCapitalization(account, 31, typ.WithholdingRate, "2025-01", time.Now())
+	require.NotNil(t, c)
+	assert.Equal(t, "12.34", c.Gross.String(), "fração abaixo do centavo fica pendente")
+	assert.Equal(t, "2.78", c.Withholding.String())
+	assert.Equal(t, "9.56", c.Net.String())
+	assert.Equal(t, 31, c.AccrualCount)
+
+	account.AccruedUnpaid = decimal.RequireFromString("0.009")
+	assert.Nil(t, NewInterestCapitalization(account, 3, typ.WithholdingRate, "2025-02", time.Now()))
+
+	typ.Active = false
+	_, err = NewInterestAccount(uuid.New(), typ, time.Now())
+	assert.ErrorIs(t, err, ErrInterestTypeInactive)
+	require.NoError(t, account.Close(time.Now()))
+	assert.ErrorIs(t, account.Close(time.Now()), ErrInterestAccountClosed)
+}
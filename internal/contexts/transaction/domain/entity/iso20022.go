@@ -0,0 +1,389 @@
+package entity
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Namespaces ISO 20022 gerados e aceitos
+const (
+	camt053Namespace       = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+	pain001NamespacePrefix = "urn:iso:std:iso:20022:tech:xsd:pain.001."
+)
+
+// iso20022DateTime ISODateTime em UTC
+const iso20022DateTime = "2006-01-02T15:04:05Z"
+
+// camt053Document extrato bank-to-customer (BkToCstmrStmt)
+type camt053Document struct {
+	XMLName   xml.Name `xml:"Document"`
+	Namespace string   `xml:"xmlns,attr"`
+	Statement struct {
+		Header struct {
+			MsgID     string `xml:"MsgId"`
+			CreatedAt string `xml:"CreDtTm"`
+		} `xml:"GrpHdr"`
+		Stmt camt053Statement `xml:"Stmt"`
+	} `xml:"BkToCstmrStmt"`
+}
+
+type camt053Statement struct {
+	ID        string `xml:"Id"`
+	CreatedAt string `xml:"CreDtTm"`
+	Period    struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	} `xml:"FrToDt"`
+	Account struct {
+		ID       iso20022Account `xml:"Id"`
+		Currency string          `xml:"Ccy"`
+	} `xml:"Acct"`
+	Balances []camt053Balance `xml:"Bal"`
+	Summary  struct {
+		Total struct {
+			Count int            `xml:"NbOfNtries"`
+			Sum   string         `xml:"Sum"`
+			Net   iso20022Amount `xml:"TtlNetNtry"`
+		} `xml:"TtlNtries"`
+		Credits camt053Totals `xml:"TtlCdtNtries"`
+		Debits  camt053Totals `xml:"TtlDbtNtries"`
+	} `xml:"TxsSummry"`
+	Entries []camt053Entry `xml:"Ntry"`
+}
+
+type iso20022Account struct {
+	Other struct {
+		ID string `xml:"Id"`
+	} `xml:"Othr"`
+}
+
+type iso20022Amount struct {
+	Amount    string `xml:"Amt"`
+	Indicator string `xml:"CdtDbtInd"`
+}
+
+type iso20022Money struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camt053DateTime struct {
+	DateTime string `xml:"DtTm"`
+}
+
+type camt053Totals struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camt053Balance struct {
+	Type      string          `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    iso20022Money   `xml:"Amt"`
+	Indicator string          `xml:"CdtDbtInd"`
+	Date      camt053DateTime `xml:"Dt"`
+}
+
+type camt053Entry struct {
+	Ref         string          `xml:"NtryRef"`
+	Amount      iso20022Money   `xml:"Amt"`
+	Indicator   string          `xml:"CdtDbtInd"`
+	Status      string          `xml:"Sts>Cd"`
+	BookingDate camt053DateTime `xml:"BookgDt"`
+	ValueDate   camt053DateTime `xml:"ValDt"`
+	ServicerRef string          `xml:"AcctSvcrRef"`
+	BankCode    struct {
+		Code   string `xml:"Cd"`
+		Issuer string `xml:"Issr"`
+	} `xml:"BkTxCd>Prtry"`
+	Details struct {
+		Refs struct {
+			ServicerRef string `xml:"AcctSvcrRef"`
+			EndToEndID  string `xml:"EndToEndId,omitempty"`
+		} `xml:"Refs"`
+		Parties *camt053Parties `xml:"RltdPties,omitempty"`
+		Info    string          `xml:"RmtInf>Ustrd"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+type camt053Parties struct {
+	Debtor   *iso20022Account `xml:"DbtrAcct>Id,omitempty"`
+	Creditor *iso20022Account `xml:"CdtrAcct>Id,omitempty"`
+}
+
+// writeCamt053 extrato no formato camt.053.001.08; saldos negativos vão com indicador DBIT e
+// saques aguardando aprovação com status PDNG
+func writeCamt053(w io.Writer, st *Statement) error {
+	var doc camt053Document
+	doc.Namespace = camt053Namespace
+	doc.Statement.Header.MsgID = iso20022ID(st.ID)
+	doc.Statement.Header.CreatedAt = st.GeneratedAt.UTC().Format(iso20022DateTime)
+
+	stmt := &doc.Statement.Stmt
+	stmt.ID = iso20022ID(st.ID)
+	stmt.CreatedAt = doc.Statement.Header.CreatedAt
+	stmt.Period.From = st.PeriodStart.UTC().Format(iso20022DateTime)
+	stmt.Period.To = st.PeriodEnd.UTC().Format(iso20022DateTime)
+	stmt.Account.ID.Other.ID = st.Account
+	stmt.Account.Currency = st.Currency
+	stmt.Balances = []camt053Balance{
+		camt053Bal("OPBD", st.OpeningBalance, st.Currency, stmt.Period.From),
+		camt053Bal("CLBD", st.ClosingBalance, st.Currency, stmt.Period.To),
+	}
+
+	net := st.TotalCredits.Sub(st.TotalDebits)
+	stmt.Summary.Total.Count = len(st.Lines)
+	stmt.Summary.Total.Sum = camt053Decimal(st.TotalCredits.Add(st.TotalDebits))
+	stmt.Summary.Total.Net = iso20022Amount{Amount: camt053Decimal(net.Abs()), Indicator: camt053Indicator(net)}
+	stmt.Summary.Credits.Sum = camt053Decimal(st.TotalCredits)
+	stmt.Summary.Debits.Sum = camt053Decimal(st.TotalDebits)
+
+	for _, line := range st.Lines {
+		if line.Amount.IsNegative() {
+			stmt.Summary.Debits.Count++
+		} else {
+			stmt.Summary.Credits.Count++
+		}
+		date := line.Date.UTC().Format(iso20022DateTime)
+		entry := camt053Entry{
+			Ref:         line.FITID,
+			Amount:      iso20022Money{Value: camt053Decimal(line.Amount.Abs()), Currency: st.Currency},
+			Indicator:   camt053Indicator(line.Amount),
+			Status:      "BOOK",
+			BookingDate: camt053DateTime{DateTime: date},
+			ValueDate:   camt053DateTime{DateTime: date},
+			ServicerRef: line.TransactionID.String(),
+		}
+		if line.Pending {
+			entry.Status = "PDNG"
+		}
+		entry.BankCode.Code = strings.ToUpper(string(line.Channel) + "-" + string(line.Type))
+		entry.BankCode.Issuer = ofxBankID
+		entry.Details.Refs.ServicerRef = line.TransactionID.String()
+		entry.Details.Refs.EndToEndID = ofxText(line.Reference, 35)
+		entry.Details.Info = ofxText(line.Description, 140)
+		if line.Counterparty != "" {
+			account := &iso20022Account{}
+			account.Other.ID = line.Counterparty
+			if line.Amount.IsNegative() {
+				entry.Details.Parties = &camt053Parties{Creditor: account}
+			} else {
+				entry.Details.Parties = &camt053Parties{Debtor: account}
+			}
+		}
+		stmt.Entries = append(stmt.Entries, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func camt053Bal(code string, amount decimal.Decimal, currency, date string) camt053Balance {
+	return camt053Balance{
+		Type:      code,
+		Amount:    iso20022Money{Value: camt053Decimal(amount.Abs()), Currency: currency},
+		Indicator: camt053Indicator(amount),
+		Date:      camt053DateTime{DateTime: date},
+	}
+}
+
+func camt053Indicator(amount decimal.Decimal) string {
+	if amount.IsNegative() {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+func camt053Decimal(amount decimal.Decimal) string {
+	return amount.StringFixed(2)
+}
+
+// iso20022ID identificador Max35Text derivado do UUID
+func iso20022ID(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+// pain001Document iniciação de transferências (CstmrCdtTrfInitn), versões 001.03 a 001.11
+type pain001Document struct {
+	XMLName    xml.Name
+	Initiation *struct {
+		Header struct {
+			MsgID   string `xml:"MsgId"`
+			Count   string `xml:"NbOfTxs"`
+			CtrlSum string `xml:"CtrlSum"`
+		} `xml:"GrpHdr"`
+		Payments []pain001PaymentInfo `xml:"PmtInf"`
+	} `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001PaymentInfo struct {
+	ID        string            `xml:"PmtInfId"`
+	Method    string            `xml:"PmtMtd"`
+	Count     string            `xml:"NbOfTxs"`
+	CtrlSum   string            `xml:"CtrlSum"`
+	Transfers []pain001Transfer `xml:"CdtTrfTxInf"`
+}
+
+type pain001Transfer struct {
+	EndToEndID string          `xml:"PmtId>EndToEndId"`
+	Amount     *iso20022Money  `xml:"Amt>InstdAmt"`
+	Creditor   string          `xml:"Cdtr>Nm"`
+	Account    *pain001Account `xml:"CdtrAcct>Id"`
+}
+
+type pain001Account struct {
+	IBAN  string `xml:"IBAN"`
+	Other *struct {
+		ID          string `xml:"Id"`
+		Code        string `xml:"SchmeNm>Cd"`
+		Proprietary string `xml:"SchmeNm>Prtry"`
+	} `xml:"Othr"`
+}
+
+var iso20022CurrencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// parsePain001 converte cada CdtTrfTxInf em uma linha do lote. Erros de estrutura do documento
+// (raiz, cabeçalho, NbOfTxs/CtrlSum divergentes) rejeitam o arquivo; erros de um pagamento
+// (EndToEndId, valor, moeda, conta do credor) ficam na linha correspondente. A conta do credor é
+// informada em CdtrAcct/Id/Othr: esquema EMAIL ou USERID para transferências internas, ou o nome
+// da rede (ethereum, bitcoin, ...) para saques, com o endereço em Id.
+func parsePain001(r io.Reader) ([]PayoutRow, error) {
+	var doc pain001Document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayoutFile, err)
+	}
+	if doc.XMLName.Local != "Document" || !strings.HasPrefix(doc.XMLName.Space, pain001NamespacePrefix) {
+		return nil, fmt.Errorf("%w: root must be a pain.001 Document (%s*)", ErrInvalidPayoutFile, pain001NamespacePrefix)
+	}
+	initn := doc.Initiation
+	if initn == nil {
+		return nil, fmt.Errorf("%w: CstmrCdtTrfInitn is required", ErrInvalidPayoutFile)
+	}
+	if msgID := strings.TrimSpace(initn.Header.MsgID); msgID == "" || len(msgID) > 35 {
+		return nil, fmt.Errorf("%w: GrpHdr/MsgId is required (max 35 characters)", ErrInvalidPayoutFile)
+	}
+	if len(initn.Payments) == 0 {
+		return nil, fmt.Errorf("%w: at least one PmtInf is required", ErrInvalidPayoutFile)
+	}
+
+	var rows []PayoutRow
+	seen := make(map[string]bool)
+	total := decimal.Zero
+	for _, info := range initn.Payments {
+		infoTotal := decimal.Zero
+		for _, trf := range info.Transfers {
+			row := PayoutRow{Line: len(rows) + 1, Reference: strings.TrimSpace(trf.EndToEndID)}
+			if trf.Amount != nil {
+				row.Amount = strings.TrimSpace(trf.Amount.Value)
+				if amount, err := decimal.NewFromString(row.Amount); err == nil {
+					infoTotal = infoTotal.Add(amount)
+				}
+			}
+			row.Error = pain001Recipient(&row, info.Method, trf.Amount, trf.Account)
+			if row.Error == "" {
+				switch {
+				case row.Reference == "" || len(row.Reference) > 35:
+					row.Error = "PmtId/EndToEndId is required (max 35 characters)"
+				case seen[row.Reference]:
+					row.Error = "duplicate EndToEndId " + row.Reference
+				case len([]rune(trf.Creditor)) > 140:
+					row.Error = "Cdtr/Nm exceeds 140 characters"
+				}
+			}
+			seen[row.Reference] = true
+			rows = append(rows, row)
+		}
+		if err := pain001CheckTotals("PmtInf "+info.ID, info.Count, info.CtrlSum, len(info.Transfers), infoTotal); err != nil {
+			return nil, err
+		}
+		total = total.Add(infoTotal)
+	}
+	if err := pain001CheckTotals("GrpHdr", initn.Header.Count, initn.Header.CtrlSum, len(rows), total); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no CdtTrfTxInf found", ErrInvalidPayoutFile)
+	}
+	return rows, nil
+}
+
+// pain001Recipient valida método, valor e conta do credor e preenche o destinatário da linha
+func pain001Recipient(row *PayoutRow, method string, amount *iso20022Money, account *pain001Account) string {
+	if m := strings.TrimSpace(method); m != "TRF" {
+		return fmt.Sprintf("PmtMtd must be TRF, got %q", m)
+	}
+	if amount == nil {
+		return "Amt/InstdAmt is required"
+	}
+	value, err := decimal.NewFromString(row.Amount)
+	if err != nil || !value.IsPositive() {
+		return "InstdAmt must be a positive decimal"
+	}
+	if value.Exponent() < -5 || len(strings.TrimLeft(value.Coefficient().String(), "-")) > 18 {
+		return "InstdAmt allows at most 18 digits with 5 decimals"
+	}
+	currency := strings.TrimSpace(amount.Currency)
+	if !iso20022CurrencyPattern.MatchString(currency) {
+		return "InstdAmt/@Ccy must be an ISO 4217 code"
+	}
+	if currency != string(sharedVO.BaseCurrency) {
+		return fmt.Sprintf("currency %s not supported; payments must be in %s", currency, sharedVO.BaseCurrency)
+	}
+	if account == nil {
+		return "CdtrAcct/Id is required"
+	}
+	if account.IBAN != "" {
+		return "IBAN creditor accounts are not supported; use Othr with SchmeNm EMAIL, USERID or the chain name"
+	}
+	if account.Other == nil || strings.TrimSpace(account.Other.ID) == "" {
+		return "CdtrAcct/Id/Othr/Id is required"
+	}
+	id := strings.TrimSpace(account.Other.ID)
+	if len(id) > 128 {
+		return "CdtrAcct/Id/Othr/Id is too long"
+	}
+	scheme := strings.TrimSpace(account.Other.Proprietary)
+	if scheme == "" {
+		scheme = strings.TrimSpace(account.Other.Code)
+	}
+	switch strings.ToUpper(scheme) {
+	case "EMAIL":
+		row.Email = id
+	case "USERID":
+		row.UserID = id
+	case "":
+		return "CdtrAcct/Id/Othr/SchmeNm is required (EMAIL, USERID or the chain name)"
+	default:
+		row.Chain = strings.ToLower(scheme)
+		row.Address = id
+	}
+	return ""
+}
+
+func pain001CheckTotals(scope, count, ctrlSum string, n int, sum decimal.Decimal) error {
+	if count = strings.TrimSpace(count); count != "" && count != fmt.Sprint(n) {
+		return fmt.Errorf("%w: %s NbOfTxs is %s but the file has %d transactions", ErrInvalidPayoutFile, scope, count, n)
+	}
+	if ctrlSum = strings.TrimSpace(ctrlSum); ctrlSum != "" {
+		expected, err := decimal.NewFromString(ctrlSum)
+		if err != nil || !expected.Equal(sum) {
+			return fmt.Errorf("%w: %s CtrlSum %s does not match the sum of amounts %s", ErrInvalidPayoutFile, scope, ctrlSum, sum)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStatement_Camt053(t *testing.T) {
+	st := testStatement()
+	st.Lines[1].Pending = true
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatement(&buf, StatementFormatCamt053, st))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, xml.Header))
+	assert.Contains(t, out, `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">`)
+
+	var doc camt053Document
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	stmt := doc.Statement.Stmt
+	require.Len(t, stmt.Balances, 2)
+	assert.Equal(t, "OPBD", stmt.Balances[0].Type)
+	assert.Equal(t, "100.00", stmt.Balances[0].Amount.Value)
+	assert.Equal(t, "BRL", stmt.Balances[0].Amount.Currency)
+	assert.Equal(t, "130.00", stmt.Balances[1].Amount.Value)
+	assert.Equal(t, "2025-03-01T00:00:00Z", stmt.Period.From)
+	assert.LessOrEqual(t, len(doc.Statement.Header.MsgID), 35)
+
+	require.Len(t, stmt.Entries, 2)
+	assert.Equal(t, "CRDT", stmt.Entries[0].Indicator)
+	assert.Equal(t, "BOOK", stmt.Entries[0].Status)
+	assert.Equal(t, "DBIT", stmt.Entries[1].Indicator)
+	assert.Equal(t, "20.00", stmt.Entries[1].Amount.Value, "valor sem sinal, direção no indicador")
+	assert.Equal(t, "PDNG", stmt.Entries[1].Status)
+	require.NotNil(t, stmt.Entries[1].Details.Parties)
+	assert.Equal(t, "0x<&>", stmt.Entries[1].Details.Parties.Creditor.Other.ID)
+
+	assert.Equal(t, 2, stmt.Summary.Total.Count)
+	assert.Equal(t, 1, stmt.Summary.Debits.Count)
+	assert.Equal(t, "30.00", stmt.Summary.Total.Net.Amount)
+	assert.Equal(t, "CRDT", stmt.Summary.Total.Net.Indicator)
+}
+
+func TestWriteStatement_Camt053NegativeBalance(t *testing.T) {
+	base := testStatement()
+	st := NewStatement(uuid.New(), "W", "BRL", base.PeriodStart, base.PeriodEnd, decimal.NewFromInt(-5), nil, base.GeneratedAt)
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatement(&buf, StatementFormatCamt053, st))
+	var doc camt053Document
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "DBIT", doc.Statement.Stmt.Balances[0].Indicator)
+	assert.Equal(t, "5.00", doc.Statement.Stmt.Balances[0].Amount.Value)
+}
+
+const pain001Header = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+<CstmrCdtTrfInitn>
+<GrpHdr><MsgId>MSG-1</MsgId><CreDtTm>2025-03-01T10:00:00</CreDtTm><NbOfTxs>%d</NbOfTxs><InitgPty><Nm>Tesouraria</Nm></InitgPty></GrpHdr>
+<PmtInf><PmtInfId>P1</PmtInfId><PmtMtd>TRF</PmtMtd>`
+
+const pain001Footer = `</PmtInf></CstmrCdtTrfInitn></Document>`
+
+func pain001Tx(e2e, amount, ccy, account string) string {
+	return `<CdtTrfTxInf><PmtId><EndToEndId>` + e2e + `</EndToEndId></PmtId>` +
+		`<Amt><InstdAmt Ccy="` + ccy + `">` + amount + `</InstdAmt></Amt>` +
+		`<Cdtr><Nm>Fornecedor</Nm></Cdtr>` + account + `</CdtTrfTxInf>`
+}
+
+func pain001Acct(scheme, id string) string {
+	return `<CdtrAcct><Id><Othr><Id>` + id + `</Id><SchmeNm><Prtry>` + scheme + `</Prtry></SchmeNm></Othr></Id></CdtrAcct>`
+}
+
+func pain001File(txs ...string) string {
+	return fmt.Sprintf(pain001Header, len(txs)) + strings.Join(txs, "") + pain001Footer
+}
+
+func TestParsePayoutFile_Pain001(t *testing.T) {
+	user := uuid.New()
+	file := pain001File(
+		pain001Tx("E2E-1", "10.50", "BRL", pain001Acct("EMAIL", "r@t.com")),
+		pain001Tx("E2E-2", "5", "BRL", pain001Acct("USERID", user.String())),
+		pain001Tx("E2E-3", "1", "BRL", pain001Acct("Ethereum", "0xabc")),
+	)
+	rows, err := ParsePayoutFile(PayoutFormatPain001, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, PayoutRow{Line: 1, Email: "r@t.com", Amount: "10.50", Reference: "E2E-1"}, rows[0])
+	assert.Equal(t, user.String(), rows[1].UserID)
+	assert.Equal(t, "ethereum", rows[2].Chain)
+	assert.Equal(t, "0xabc", rows[2].Address)
+
+	item := NewPayoutItem(uuid.New(), rows[2])
+	assert.Equal(t, PayoutItemValid, item.Status)
+	assert.Equal(t, ScheduleKindWithdraw, item.Kind)
+}
+
+func TestParsePayoutFile_Pain001PaymentErrors(t *testing.T) {
+	file := pain001File(
+		pain001Tx("E2E-1", "10", "USD", pain001Acct("EMAIL", "r@t.com")),
+		pain001Tx("E2E-2", "-1", "BRL", pain001Acct("EMAIL", "r@t.com")),
+		pain001Tx("E2E-3", "1.123456", "BRL", pain001Acct("EMAIL", "r@t.com")),
+		pain001Tx("E2E-4", "1", "BRL", `<CdtrAcct><Id><IBAN>BR1800360305000010009795493C1</IBAN></Id></CdtrAcct>`),
+		pain001Tx("E2E-5", "1", "BRL", ""),
+		pain001Tx("", "1", "BRL", pain001Acct("EMAIL", "r@t.com")),
+		pain001Tx("E2E-7", "1", "BRL", pain001Acct("EMAIL", "r@t.com")),
+		pain001Tx("E2E-7", "1", "BRL", pain001Acct("EMAIL", "r@t.com")),
+	)
+	rows, err := ParsePayoutFile(PayoutFormatPain001, strings.NewReader(file))
+	require.NoError(t, err, "erros de um pagamento não rejeitam o arquivo")
+	require.Len(t, rows, 8)
+	assert.Contains(t, rows[0].Error, "currency USD")
+	assert.Contains(t, rows[1].Error, "positive")
+	assert.Contains(t, rows[2].Error, "5 decimals")
+	assert.Contains(t, rows[3].Error, "IBAN")
+	assert.Contains(t, rows[4].Error, "CdtrAcct")
+	assert.Contains(t, rows[5].Error, "EndToEndId")
+	assert.Empty(t, rows[6].Error)
+	assert.Contains(t, rows[7].Error, "duplicate")
+
+	items := make([]*PayoutItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, NewPayoutItem(uuid.New(), row))
+	}
+	assert.Equal(t, PayoutItemInvalid, items[0].Status)
+	assert.Equal(t, rows[0].Error, items[0].Error)
+	batch := NewPayoutBatch(uuid.New(), uuid.New(), PayoutFormatPain001, items, testStatement().GeneratedAt)
+	assert.Equal(t, PayoutBatchRejected, batch.Status)
+}
+
+func TestParsePayoutFile_Pain001FileErrors(t *testing.T) {
+	tx := pain001Tx("E2E-1", "10", "BRL", pain001Acct("EMAIL", "r@t.com"))
+	cases := map[string]string{
+		"xml malformado":      "<Document",
+		"namespace incorreto": strings.Replace(pain001File(tx), "pain.001.001.09", "pain.008.001.08", 1),
+		"NbOfTxs divergente":  strings.Replace(pain001File(tx), "<NbOfTxs>1</NbOfTxs>", "<NbOfTxs>2</NbOfTxs>", 1),
+		"CtrlSum divergente":  strings.Replace(pain001File(tx), "</NbOfTxs>", "</NbOfTxs><CtrlSum>11</CtrlSum>", 1),
+		"sem MsgId":           strings.Replace(pain001File(tx), "<MsgId>MSG-1</MsgId>", "", 1),
+	}
+	for name, file := range cases {
+		_, err := ParsePayoutFile(PayoutFormatPain001, strings.NewReader(file))
+		assert.True(t, errors.Is(err, ErrInvalidPayoutFile), name)
+	}
+}
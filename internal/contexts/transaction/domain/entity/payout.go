@@ -0,0 +1,355 @@
+package entity
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidPayoutFile  = errors.New("invalid payout file")
+	ErrPayoutBatchInvalid = errors.New("payout batch has invalid rows")
+	ErrPayoutBatchState   = errors.New("operation not allowed in the current payout batch state")
+)
+
+// Formatos aceitos no envio de lotes
+const (
+	PayoutFormatCSV  = "csv"
+	PayoutFormatJSON = "json"
+	// PayoutFormatPain001 ISO 20022 customer credit transfer initiation (pain.001)
+	PayoutFormatPain001 = "pain.001"
+)
+
+// PayoutBatchStatus estado do lote de pagamentos
+type PayoutBatchStatus string
+
+const (
+	PayoutBatchDraft               PayoutBatchStatus = "draft"    // validado, aguardando confirmação
+	PayoutBatchRejected            PayoutBatchStatus = "rejected" // linhas inválidas; não pode ser executado
+	PayoutBatchProcessing          PayoutBatchStatus = "processing"
+	PayoutBatchCompleted           PayoutBatchStatus = "completed"
+	PayoutBatchCompletedWithErrors PayoutBatchStatus = "completed_with_errors"
+	PayoutBatchCancelled           PayoutBatchStatus = "cancelled"
+)
+
+// PayoutItemStatus estado de um pagamento do lote
+type PayoutItemStatus string
+
+const (
+	PayoutItemValid      PayoutItemStatus = "valid"
+	PayoutItemInvalid    PayoutItemStatus = "invalid"
+	PayoutItemQueued     PayoutItemStatus = "queued"
+	PayoutItemProcessing PayoutItemStatus = "processing"
+	PayoutItemSucceeded  PayoutItemStatus = "succeeded"
+	PayoutItemFailed     PayoutItemStatus = "failed"
+	PayoutItemCancelled  PayoutItemStatus = "cancelled"
+)
+
+// PayoutRow linha do arquivo enviado: destinatário por user_id, email ou chain + address
+type PayoutRow struct {
+	Line      int
+	UserID    string
+	Email     string
+	Chain     string
+	Address   string
+	Amount    string
+	Reference string
+	Error     string // erro de validação do formato de origem (ex.: schema ISO 20022)
+}
+
+// PayoutBatch lote de transferências e saques enviados em um arquivo
+type PayoutBatch struct {
+	ID          uuid.UUID         `json:"id"`
+	UserID      uuid.UUID         `json:"user_id"`
+	Format      string            `json:"format"`
+	Status      PayoutBatchStatus `json:"status"`
+	ItemCount   int               `json:"item_count"`
+	InvalidRows int               `json:"invalid_rows"`
+	TotalAmount decimal.Decimal   `json:"total_amount"`
+	TotalFee    decimal.Decimal   `json:"total_fee"`
+	Succeeded   int               `json:"succeeded"`
+	Failed      int               `json:"failed"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ConfirmedAt *time.Time        `json:"confirmed_at,omitempty"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+}
+
+// PayoutItem pagamento de um lote: transferência interna (RecipientUserID) ou saque (Chain + Address)
+type PayoutItem struct {
+	ID              uuid.UUID        `json:"id"`
+	BatchID         uuid.UUID        `json:"batch_id"`
+	Line            int              `json:"line"`
+	Kind            ScheduleKind     `json:"type"`
+	RecipientUserID *uuid.UUID       `json:"recipient_user_id,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	Chain           string           `json:"chain,omitempty"`
+	Address         string           `json:"address,omitempty"`
+	Amount          decimal.Decimal  `json:"amount"`
+	Fee             decimal.Decimal  `json:"fee"`
+	Reference       string           `json:"reference,omitempty"`
+	Status          PayoutItemStatus `json:"status"`
+	TransactionID   *uuid.UUID       `json:"transaction_id,omitempty"`
+	Error           string           `json:"error,omitempty"`
+	ProcessedAt     *time.Time       `json:"processed_at,omitempty"`
+}
+
+// NewPayoutItem valida o formato da linha (destinatário único e valor positivo). A existência do
+// destinatário e a taxa são resolvidas pelo serviço.
+func NewPayoutItem(batchID uuid.UUID, row PayoutRow) *PayoutItem {
+	item := &PayoutItem{
+		ID:        uuid.New(),
+		BatchID:   batchID,
+		Line:      row.Line,
+		Email:     strings.TrimSpace(row.Email),
+		Chain:     strings.TrimSpace(row.Chain),
+		Address:   strings.TrimSpace(row.Address),
+		Reference: strings.TrimSpace(row.Reference),
+		Amount:    decimal.Zero,
+		Fee:       decimal.Zero,
+		Status:    PayoutItemValid,
+	}
+	if row.Error != "" {
+		item.Invalidate(row.Error)
+		return item
+	}
+
+	amount, err := decimal.NewFromString(strings.TrimSpace(row.Amount))
+	if err != nil || !amount.IsPositive() {
+		item.Invalidate("amount must be a positive number")
+		return item
+	}
+	item.Amount = amount
+
+	userID := strings.TrimSpace(row.UserID)
+	recipients := 0
+	for _, set := range []bool{userID != "", item.Email != "", item.Chain != "" || item.Address != ""} {
+		if set {
+			recipients++
+		}
+	}
+	if recipients != 1 {
+		item.Invalidate("exactly one recipient is required: user_id, email or chain + address")
+		return item
+	}
+
+	switch {
+	case userID != "":
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			item.Invalidate("invalid user_id")
+			return item
+		}
+		item.Kind = ScheduleKindTransfer
+		item.RecipientUserID = &id
+	case item.Email != "":
+		item.Kind = ScheduleKindTransfer
+	default:
+		if item.Chain == "" || item.Address == "" {
+			item.Invalidate("chain and address are both required for withdrawals")
+			return item
+		}
+		item.Kind = ScheduleKindWithdraw
+	}
+	return item
+}
+
+// Invalidate marca o item como inválido com o motivo
+func (i *PayoutItem) Invalidate(reason string) {
+	i.Status = PayoutItemInvalid
+	i.Error = reason
+}
+
+// Finish registra o resultado da execução do item
+func (i *PayoutItem) Finish(txID *uuid.UUID, err error, now time.Time) {
+	i.TransactionID = txID
+	i.ProcessedAt = &now
+	if err != nil {
+		i.Status = PayoutItemFailed
+		i.Error = err.Error()
+		return
+	}
+	i.Status = PayoutItemSucceeded
+	i.Error = ""
+}
+
+// NewPayoutBatch consolida os itens validados: totais de valor e taxa e rejeição se houver linha inválida
+func NewPayoutBatch(id, userID uuid.UUID, format string, items []*PayoutItem, now time.Time) *PayoutBatch {
+	b := &PayoutBatch{
+		ID:          id,
+		UserID:      userID,
+		Format:      format,
+		Status:      PayoutBatchDraft,
+		ItemCount:   len(items),
+		TotalAmount: decimal.Zero,
+		TotalFee:    decimal.Zero,
+		CreatedAt:   now,
+	}
+	for _, item := range items {
+		if item.Status == PayoutItemInvalid {
+			b.InvalidRows++
+			continue
+		}
+		b.TotalAmount = b.TotalAmount.Add(item.Amount)
+		b.TotalFee = b.TotalFee.Add(item.Fee)
+	}
+	if b.InvalidRows > 0 {
+		b.Status = PayoutBatchRejected
+	}
+	return b
+}
+
+// Total valor debitado do pagador na execução completa (valores + taxas)
+func (b *PayoutBatch) Total() decimal.Decimal {
+	return b.TotalAmount.Add(b.TotalFee)
+}
+
+// ParsePayoutFile lê as linhas de um arquivo CSV (com cabeçalho) ou JSON (lista de objetos) com as
+// colunas user_id, email, chain, address, amount e reference, ou os pagamentos de um pain.001
+func ParsePayoutFile(format string, r io.Reader) ([]PayoutRow, error) {
+	switch format {
+	case PayoutFormatCSV:
+		return parsePayoutCSV(r)
+	case PayoutFormatJSON:
+		return parsePayoutJSON(r)
+	case PayoutFormatPain001:
+		return parsePain001(r)
+	}
+	return nil, fmt.Errorf("%w: format must be csv, json or pain.001", ErrInvalidPayoutFile)
+}
+
+var payoutColumns = []string{"user_id", "email", "chain", "address", "amount", "reference"}
+
+func parsePayoutCSV(r io.Reader) ([]PayoutRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidPayoutFile)
+	}
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		col = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\uFEFF")))
+		known := false
+		for _, c := range payoutColumns {
+			known = known || c == col
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidPayoutFile, col)
+		}
+		index[col] = i
+	}
+	if _, ok := index["amount"]; !ok {
+		return nil, fmt.Errorf("%w: amount column is required", ErrInvalidPayoutFile)
+	}
+
+	field := func(rec []string, col string) string {
+		if i, ok := index[col]; ok && i < len(rec) {
+			return rec[i]
+		}
+		return ""
+	}
+	var rows []PayoutRow
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPayoutFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, PayoutRow{
+			Line:      line,
+			UserID:    field(rec, "user_id"),
+			Email:     field(rec, "email"),
+			Chain:     field(rec, "chain"),
+			Address:   field(rec, "address"),
+			Amount:    field(rec, "amount"),
+			Reference: field(rec, "reference"),
+		})
+	}
+	return rows, nil
+}
+
+func parsePayoutJSON(r io.Reader) ([]PayoutRow, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var raw []map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayoutFile, err)
+	}
+	rows := make([]PayoutRow, 0, len(raw))
+	for i, obj := range raw {
+		str := func(key string) string {
+			switch v := obj[key].(type) {
+			case string:
+				return v
+			case json.Number:
+				return v.String()
+			case nil:
+				return ""
+			default:
+				return fmt.Sprint(v)
+			}
+		}
+		rows = append(rows, PayoutRow{
+			Line:      i + 1,
+			UserID:    str("user_id"),
+			Email:     str("email"),
+			Chain:     str("chain"),
+			Address:   str("address"),
+			Amount:    str("amount"),
+			Reference: str("reference"),
+		})
+	}
+	return rows, nil
+}
+
+// WritePayoutReport escreve o relatório CSV do lote com o resultado de cada item
+func WritePayoutReport(w io.Writer, items []*PayoutItem) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"line", "type", "recipient", "amount", "fee", "reference", "status", "transaction_id", "error", "processed_at"}); err != nil {
+		return err
+	}
+	for _, item := range items {
+		recipient := item.Email
+		switch {
+		case item.Kind == ScheduleKindWithdraw:
+			recipient = item.Chain + ":" + item.Address
+		case recipient == "" && item.RecipientUserID != nil:
+			recipient = item.RecipientUserID.String()
+		}
+		txID, processedAt := "", ""
+		if item.TransactionID != nil {
+			txID = item.TransactionID.String()
+		}
+		if item.ProcessedAt != nil {
+			processedAt = item.ProcessedAt.UTC().Format(time.RFC3339)
+		}
+		err := out.Write([]string{
+			strconv.Itoa(item.Line),
+			string(item.Kind),
+			recipient,
+			item.Amount.String(),
+			item.Fee.String(),
+			item.Reference,
+			string(item.Status),
+			txID,
+			item.Error,
+			processedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePayoutFile_CSV(t *testing.T) {
+	file := "\uFEFFEmail,Amount,Reference,chain,address,user_id\n" +
+		"ana@example.com,10.50,invoice 1,,,\n" +
+		",20,,ethereum,0xabc,\n" +
+		",5,,,," + uuid.NewString() + "\n"
+
+	rows, err := ParsePayoutFile(PayoutFormatCSV, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "ana@example.com", rows[0].Email)
+	assert.Equal(t, "10.50", rows[0].Amount)
+	assert.Equal(t, "invoice 1", rows[0].Reference)
+	assert.Equal(t, "ethereum", rows[1].Chain)
+	assert.Equal(t, 4, rows[2].Line)
+
+	_, err = ParsePayoutFile(PayoutFormatCSV, strings.NewReader("email,value\nx,1\n"))
+	assert.ErrorIs(t, err, ErrInvalidPayoutFile)
+	_, err = ParsePayoutFile(PayoutFormatCSV, strings.NewReader("email\nx\n"))
+	assert.ErrorIs(t, err, ErrInvalidPayoutFile, "amount column is required")
+	_, err = ParsePayoutFile("xml", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrInvalidPayoutFile)
+}
+
+func TestParsePayoutFile_JSON(t *testing.T) {
+	rows, err := ParsePayoutFile(PayoutFormatJSON, strings.NewReader(`[
+		{"email": "ana@example.com", "amount": 12.345678901234567890},
+		{"chain": "bitcoin", "address": "bc1q", "amount": "3", "reference": "r"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "12.345678901234567890", rows[0].Amount, "números preservam a precisão")
+	assert.Equal(t, 2, rows[1].Line)
+	assert.Equal(t, "bc1q", rows[1].Address)
+
+	_, err = ParsePayoutFile(PayoutFormatJSON, strings.NewReader(`{"amount": 1}`))
+	assert.ErrorIs(t, err, ErrInvalidPayoutFile)
+}
+
+func TestNewPayoutItem_Validation(t *testing.T) {
+	batchID := uuid.New()
+	userID := uuid.New()
+
+	item := NewPayoutItem(batchID, PayoutRow{Line: 1, UserID: userID.String(), Amount: "10"})
+	assert.Equal(t, PayoutItemValid, item.Status)
+	assert.Equal(t, ScheduleKindTransfer, item.Kind)
+	assert.Equal(t, userID, *item.RecipientUserID)
+
+	item = NewPayoutItem(batchID, PayoutRow{Line: 2, Chain: "ethereum", Address: "0xabc", Amount: "1"})
+	assert.Equal(t, ScheduleKindWithdraw, item.Kind)
+
+	invalid := map[string]PayoutRow{
+		"no recipient":        {Amount: "1"},
+		"two recipients":      {Email: "a@b.c", UserID: userID.String(), Amount: "1"},
+		"address without net": {Address: "0xabc", Amount: "1"},
+		"bad user id":         {UserID: "nope", Amount: "1"},
+		"zero amount":         {Email: "a@b.c", Amount: "0"},
+		"bad amount":          {Email: "a@b.c", Amount: "ten"},
+	}
+	for name, row := range invalid {
+		item := NewPayoutItem(batchID, row)
+		assert.Equal(t, PayoutItemInvalid, item.Status, name)
+		assert.NotEmpty(t, item.Error, name)
+	}
+}
+
+func TestNewPayoutBatch_TotalsAndRejection(t *testing.T) {
+	now := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	batchID := uuid.New()
+	a := NewPayoutItem(batchID, PayoutRow{Line: 1, Email: "a@b.c", Amount: "10"})
+	a.Fee = decimal.RequireFromString("0.5")
+	b := NewPayoutItem(batchID, PayoutRow{Line: 2, Email: "d@e.f", Amount: "15"})
+
+	batch := NewPayoutBatch(batchID, uuid.New(), PayoutFormatCSV, []*PayoutItem{a, b}, now)
+	assert.Equal(t, PayoutBatchDraft, batch.Status)
+	assert.True(t, batch.TotalAmount.Equal(decimal.NewFromInt(25)))
+	assert.True(t, batch.Total().Equal(decimal.RequireFromString("25.5")))
+
+	bad := NewPayoutItem(batchID, PayoutRow{Line: 3, Amount: "1"})
+	batch = NewPayoutBatch(batchID, uuid.New(), PayoutFormatCSV, []*PayoutItem{a, b, bad}, now)
+	assert.Equal(t, PayoutBatchRejected, batch.Status)
+	assert.Equal(t, 1, batch.InvalidRows)
+	assert.True(t, batch.TotalAmount.Equal(decimal.NewFromInt(25)), "linhas inválidas não entram no total")
+}
+
+func TestWritePayoutReport(t *testing.T) {
+	now := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	batchID := uuid.New()
+	ok := NewPayoutItem(batchID, PayoutRow{Line: 2, Chain: "ethereum", Address: "0xabc", Amount: "3"})
+	txID := uuid.New()
+	ok.Finish(&txID, nil, now)
+	failed := NewPayoutItem(batchID, PayoutRow{Line: 3, Email: "a@b.c", Amount: "4", Reference: "r, 1"})
+	failed.Finish(nil, assert.AnError, now)
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePayoutReport(&buf, []*PayoutItem{ok, failed}))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"2", "withdraw", "ethereum:0xabc", "3", "0", "", "succeeded", txID.String(), "", "2025-04-01T09:00:00Z"}, records[1])
+	assert.Equal(t, "r, 1", records[2][5])
+	assert.Equal(t, "failed", records[2][6])
+	assert.Equal(t, assert.AnError.Error(), records[2][8])
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidPixCharge   = errors.New("invalid pix charge")
+	ErrPixCreditDuplicate = errors.New("pix credit already received")
+	ErrPixAmountMismatch  = errors.New("pix amount does not match the code")
+)
+
+const (
+	// PixChain rede usada nas transações de saque liquidadas por Pix
+	PixChain = "pix"
+	// PixAddressPrefix prefixo dos endereços de transações Pix: "pix:<chave>" nos saques e
+	// "pix:<endToEndId>" nos depósitos
+	PixAddressPrefix = "pix:"
+)
+
+// IsPixAddress indica se o endereço da transação identifica uma movimentação Pix
+func IsPixAddress(address string) bool {
+	return strings.HasPrefix(address, PixAddressPrefix)
+}
+
+// PixChargeStatus estado da cobrança de depósito
+type PixChargeStatus string
+
+const (
+	PixChargeActive  PixChargeStatus = "active"
+	PixChargePaid    PixChargeStatus = "paid" // somente cobranças dinâmicas (uso único)
+	PixChargeExpired PixChargeStatus = "expired"
+)
+
+// PixCharge cobrança Pix para depósito na carteira do usuário; o txid identifica o crédito recebido.
+// Cobranças estáticas podem ser pagas várias vezes; dinâmicas são de uso único e expiram.
+type PixCharge struct {
+	ID          uuid.UUID       `json:"id"`
+	UserID      uuid.UUID       `json:"user_id"`
+	TxID        string          `json:"txid"`
+	Dynamic     bool            `json:"dynamic"`
+	Amount      decimal.Decimal `json:"amount"` // zero: valor livre (somente estáticas)
+	Description string          `json:"description,omitempty"`
+	Location    string          `json:"location,omitempty"`
+	Payload     string          `json:"payload"`
+	Status      PixChargeStatus `json:"status"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ExpiresAt   *time.Time      `json:"expires_at,omitempty"`
+	PaidAt      *time.Time      `json:"paid_at,omitempty"`
+}
+
+// Accepts verifica se o crédito pode liquidar a cobrança
+func (c *PixCharge) Accepts(amount decimal.Decimal, now time.Time) error {
+	if c.Status != PixChargeActive {
+		return fmt.Errorf("%w: charge is %s", ErrInvalidPixCharge, c.Status)
+	}
+	if c.ExpiresAt != nil && !now.Before(*c.ExpiresAt) {
+		return fmt.Errorf("%w: charge expired", ErrInvalidPixCharge)
+	}
+	if c.Amount.IsPositive() && !c.Amount.Equal(amount) {
+		return ErrPixAmountMismatch
+	}
+	return nil
+}
+
+// PixCreditStatus resultado da conciliação de um crédito recebido do PSP
+type PixCreditStatus string
+
+const (
+	PixCreditReceived  PixCreditStatus = "received"  // registrado, depósito em andamento
+	PixCreditCredited  PixCreditStatus = "credited"  // depositado na carteira
+	PixCreditUnmatched PixCreditStatus = "unmatched" // sem cobrança ou recusado pela cobrança; requer devolução
+	PixCreditFailed    PixCreditStatus = "failed"    // depósito falhou; requer conciliação manual
+)
+
+// PixCredit Pix recebido na conta da instituição; EndToEndID é único por pagamento
+type PixCredit struct {
+	ID            uuid.UUID       `json:"id"`
+	EndToEndID    string          `json:"end_to_end_id"`
+	TxID          string          `json:"txid"`
+	Amount        decimal.Decimal `json:"amount"`
+	PayerName     string          `json:"payer_name,omitempty"`
+	PayerDocument string          `json:"payer_document,omitempty"`
+	ChargeID      *uuid.UUID      `json:"charge_id,omitempty"`
+	UserID        *uuid.UUID      `json:"user_id,omitempty"`
+	Status        PixCreditStatus `json:"status"`
+	TransactionID *uuid.UUID      `json:"transaction_id,omitempty"`
+	Reason        string          `json:"reason,omitempty"`
+	PaidAt        time.Time       `json:"paid_at"`
+	ReceivedAt    time.Time       `json:"received_at"`
+}
+
+// PixPaymentStatus estado de um pagamento Pix enviado pelo usuário
+type PixPaymentStatus string
+
+const (
+	PixPaymentPending   PixPaymentStatus = "pending" // aguardando aprovação do saque ou novo envio ao PSP
+	PixPaymentSent      PixPaymentStatus = "sent"
+	PixPaymentFailed    PixPaymentStatus = "failed"    // recusado pelo PSP após as tentativas
+	PixPaymentCancelled PixPaymentStatus = "cancelled" // saque recusado antes do envio
+)
+
+// PixPayment pagamento de um BR Code com saldo da carteira: o saque é debitado pelo fluxo normal e
+// a ordem é enviada ao PSP quando concluído
+type PixPayment struct {
+	ID            uuid.UUID        `json:"id"`
+	UserID        uuid.UUID        `json:"user_id"`
+	TransactionID uuid.UUID        `json:"transaction_id"`
+	Key           string           `json:"key"`
+	TxID          string           `json:"txid"`
+	Amount        decimal.Decimal  `json:"amount"`
+	MerchantName  string           `json:"merchant_name"`
+	Status        PixPaymentStatus `json:"status"`
+	EndToEndID    string           `json:"end_to_end_id,omitempty"`
+	Attempts      int              `json:"attempts"`
+	Error         string           `json:"error,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	SentAt        *time.Time       `json:"sent_at,omitempty"`
+}
+
+// PixChargeLocation cobrança dinâmica registrada no PSP
+type PixChargeLocation struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PixPaymentOrder ordem de pagamento enviada ao PSP; ID torna o envio idempotente
+type PixPaymentOrder struct {
+	ID          uuid.UUID
+	Key         string
+	TxID        string
+	Amount      decimal.Decimal
+	Description string
+}
+
+const txidAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// NewPixTxID gera um txid alfanumérico aleatório com o tamanho informado
+func NewPixTxID(size int) string {
+	out := make([]byte, size)
+	max := big.NewInt(int64(len(txidAlphabet)))
+	for i := range out {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		out[i] = txidAlphabet[n.Int64()]
+	}
+	return string(out)
+}
@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationStatus resultado de uma execução da conciliação
+type ReconciliationStatus string
+
+const (
+	ReconciliationBalanced ReconciliationStatus = "balanced" // nenhuma quebra encontrada
+	ReconciliationBreaks   ReconciliationStatus = "breaks"   // há quebras a investigar
+)
+
+// ReconciliationBreakCategory classificação da quebra encontrada
+type ReconciliationBreakCategory string
+
+const (
+	// BreakBalanceShortfall endereços on-chain somam menos que o devido aos usuários
+	BreakBalanceShortfall ReconciliationBreakCategory = "balance_shortfall"
+	// BreakBalanceSurplus endereços on-chain somam mais que o devido aos usuários
+	BreakBalanceSurplus ReconciliationBreakCategory = "balance_surplus"
+	// BreakBalanceUnavailable saldo on-chain não pôde ser consultado
+	BreakBalanceUnavailable ReconciliationBreakCategory = "balance_unavailable"
+	// BreakMissingOnChain transação interna concluída sem a transação correspondente na rede
+	BreakMissingOnChain ReconciliationBreakCategory = "missing_onchain"
+	// BreakMissingInternal transação na rede sem a transação interna correspondente
+	BreakMissingInternal ReconciliationBreakCategory = "missing_internal"
+	// BreakStatusMismatch transação concluída de um lado e falha ou pendente do outro
+	BreakStatusMismatch ReconciliationBreakCategory = "status_mismatch"
+	// BreakAddressMismatch endereço de destino divergente entre os dois lados
+	BreakAddressMismatch ReconciliationBreakCategory = "address_mismatch"
+)
+
+// ReconciliationBreak divergência entre o registro interno e a rede
+type ReconciliationBreak struct {
+	ID            uuid.UUID                   `json:"id"`
+	RunID         uuid.UUID                   `json:"run_id"`
+	Category      ReconciliationBreakCategory `json:"category"`
+	Chain         string                      `json:"chain"`
+	Asset         string                      `json:"asset"`
+	TransactionID *uuid.UUID                  `json:"transaction_id,omitempty"`
+	TxHash        string                      `json:"tx_hash,omitempty"`
+	Expected      decimal.Decimal             `json:"expected"`
+	Actual        decimal.Decimal             `json:"actual"`
+	Detail        string                      `json:"detail"`
+}
+
+// AssetReconciliation passivo interno e saldo on-chain de um ativo em uma rede
+type AssetReconciliation struct {
+	Chain       string          `json:"chain"`
+	Asset       string          `json:"asset"`
+	Liabilities decimal.Decimal `json:"liabilities"` // soma dos saldos dos usuários no ativo
+	OnChain     decimal.Decimal `json:"onchain"`     // soma dos saldos dos endereços da plataforma
+	Difference  decimal.Decimal `json:"difference"`  // OnChain - Liabilities
+	Addresses   int             `json:"addresses"`
+	Available   bool            `json:"available"`
+}
+
+// OnChainTransfer transação registrada na rede (blockchain_transactions)
+type OnChainTransfer struct {
+	Hash        string
+	FromAddress string
+	ToAddress   string
+	Amount      decimal.Decimal
+	Status      string // pending, confirmed ou failed
+	CreatedAt   time.Time
+}
+
+// ReconciliationRun relatório de uma conciliação diária: saldos por rede/ativo e quebras
+type ReconciliationRun struct {
+	ID         uuid.UUID             `json:"id"`
+	RunDate    time.Time             `json:"run_date"` // dia das transações conciliadas
+	Status     ReconciliationStatus  `json:"status"`
+	Assets     []AssetReconciliation `json:"assets"`
+	Breaks     []ReconciliationBreak `json:"breaks,omitempty"`
+	BreakCount int                   `json:"break_count"`
+	ByCategory map[string]int        `json:"by_category,omitempty"`
+	StartedAt  time.Time             `json:"started_at"`
+	FinishedAt time.Time             `json:"finished_at"`
+}
+
+// NewReconciliationRun inicia o relatório do dia
+func NewReconciliationRun(runDate, now time.Time) *ReconciliationRun {
+	return &ReconciliationRun{
+		ID:         uuid.New(),
+		RunDate:    runDate,
+		Status:     ReconciliationBalanced,
+		StartedAt:  now,
+		ByCategory: map[string]int{},
+	}
+}
+
+// AddBreak registra a quebra no relatório
+func (r *ReconciliationRun) AddBreak(b ReconciliationBreak) {
+	b.ID = uuid.New()
+	b.RunID = r.ID
+	r.Breaks = append(r.Breaks, b)
+	r.BreakCount = len(r.Breaks)
+	r.ByCategory[string(b.Category)]++
+	r.Status = ReconciliationBreaks
+}
+
+// Finish encerra o relatório
+func (r *ReconciliationRun) Finish(now time.Time) {
+	r.FinishedAt = now
+}
+
+// CompareBalances compara o saldo on-chain com o passivo do ativo; diferenças acima da tolerância
+// viram quebra de falta ou sobra
+func CompareBalances(asset AssetReconciliation, tolerance decimal.Decimal) (AssetReconciliation, *ReconciliationBreak) {
+	asset.Difference = asset.OnChain.Sub(asset.Liabilities)
+	if asset.Difference.Abs().LessThanOrEqual(tolerance) {
+		return asset, nil
+	}
+	b := &ReconciliationBreak{
+		Category: BreakBalanceSurplus,
+		Chain:    asset.Chain,
+		Asset:    asset.Asset,
+		Expected: asset.Liabilities,
+		Actual:   asset.OnChain,
+		Detail:   fmt.Sprintf("on-chain balance exceeds liabilities by %s %s", asset.Difference, asset.Asset),
+	}
+	if asset.Difference.IsNegative() {
+		b.Category = BreakBalanceShortfall
+		b.Detail = fmt.Sprintf("on-chain balance is %s %s short of liabilities", asset.Difference.Neg(), asset.Asset)
+	}
+	return asset, b
+}
+
+// MatchTransfers casa as transações internas da rede com as registradas on-chain pelo hash. Os
+// valores internos estão na moeda base e os on-chain no ativo nativo, por isso só status e
+// destino são comparados nas transações casadas.
+func MatchTransfers(chain, asset string, internal []*Transaction, onchain []OnChainTransfer) []ReconciliationBreak {
+	byHash := make(map[string]OnChainTransfer, len(onchain))
+	for _, t := range onchain {
+		byHash[strings.ToLower(t.Hash)] = t
+	}
+	matched := make(map[string]bool, len(internal))
+
+	var breaks []ReconciliationBreak
+	for _, tx := range internal {
+		id := tx.ID
+		base := ReconciliationBreak{Chain: chain, Asset: asset, TransactionID: &id, TxHash: tx.TransactionHash, Expected: tx.Amount, Actual: decimal.Zero}
+		hash := strings.ToLower(tx.TransactionHash)
+		remote, ok := byHash[hash]
+		if hash == "" || !ok {
+			if tx.Status == TransactionStatusCompleted {
+				b := base
+				b.Category = BreakMissingOnChain
+				b.Detail = fmt.Sprintf("completed %s has no on-chain transaction", tx.Type)
+				breaks = append(breaks, b)
+			}
+			continue
+		}
+		matched[hash] = true
+		base.Actual = remote.Amount
+
+		switch {
+		case tx.Status == TransactionStatusCompleted && remote.Status == "failed":
+			b := base
+			b.Category = BreakStatusMismatch
+			b.Detail = "completed internally but failed on-chain"
+			breaks = append(breaks, b)
+		case tx.Status != TransactionStatusCompleted && remote.Status == "confirmed":
+			b := base
+			b.Category = BreakStatusMismatch
+			b.Detail = fmt.Sprintf("confirmed on-chain but %s internally", tx.Status)
+			breaks = append(breaks, b)
+		case tx.Type == TransactionTypeWithdraw && tx.ToAddress != "" && !strings.EqualFold(tx.ToAddress, remote.ToAddress):
+			b := base
+			b.Category = BreakAddressMismatch
+			b.Detail = fmt.Sprintf("withdrawal to %s but on-chain destination is %s", tx.ToAddress, remote.ToAddress)
+			breaks = append(breaks, b)
+		}
+	}
+
+	for _, t := range onchain {
+		if matched[strings.ToLower(t.Hash)] || t.Status == "failed" {
+			continue
+		}
+		breaks = append(breaks, ReconciliationBreak{
+			Category: BreakMissingInternal,
+			Chain:    chain,
+			Asset:    asset,
+			TxHash:   t.Hash,
+			Expected: decimal.Zero,
+			Actual:   t.Amount,
+			Detail:   fmt.Sprintf("on-chain transfer %s -> %s has no internal transaction", t.FromAddress, t.ToAddress),
+		})
+	}
+	return breaks
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareBalances(t *testing.T) {
+	asset := AssetReconciliation{Chain: "ethereum", Asset: "ETH", Liabilities: decimal.RequireFromString("10"), OnChain: decimal.RequireFromString("9.9995")}
+
+	got, b := CompareBalances(asset, decimal.RequireFromString("0.001"))
+	assert.Nil(t, b, "diferença dentro da tolerância")
+	assert.Equal(t, "-0.0005", got.Difference.String())
+
+	_, b = CompareBalances(asset, decimal.Zero)
+	require.NotNil(t, b)
+	assert.Equal(t, BreakBalanceShortfall, b.Category)
+	assert.Contains(t, b.Detail, "0.0005 ETH short")
+
+	asset.OnChain = decimal.RequireFromString("12")
+	_, b = CompareBalances(asset, decimal.Zero)
+	require.NotNil(t, b)
+	assert.Equal(t, BreakBalanceSurplus, b.Category)
+	assert.True(t, b.Actual.Equal(decimal.NewFromInt(12)))
+}
+
+func reconTx(typ TransactionType, status TransactionStatus, hash, to string) *Transaction {
+	return &Transaction{ID: uuid.New(), Type: typ, Status: status, TransactionHash: hash, ToAddress: to, Amount: decimal.NewFromInt(100)}
+}
+
+func TestMatchTransfers(t *testing.T) {
+	internal := []*Transaction{
+		reconTx(TransactionTypeWithdraw, TransactionStatusCompleted, "0xAA", "0xdest"),     // casada
+		reconTx(TransactionTypeWithdraw, TransactionStatusCompleted, "0xbb", ""),           // sem on-chain
+		reconTx(TransactionTypeWithdraw, TransactionStatusPending, "", ""),                 // pendente sem hash: ok
+		reconTx(TransactionTypeWithdraw, TransactionStatusCompleted, "0xcc", ""),           // falhou na rede
+		reconTx(TransactionTypeDeposit, TransactionStatusPending, "0xdd", ""),              // confirmado na rede
+		reconTx(TransactionTypeWithdraw, TransactionStatusCompleted, "0xee", "0xexpected"), // destino divergente
+	}
+	onchain := []OnChainTransfer{
+		{Hash: "0xaa", ToAddress: "0xDEST", Status: "confirmed", Amount: decimal.RequireFromString("0.01")},
+		{Hash: "0xcc", Status: "failed"},
+		{Hash: "0xdd", Status: "confirmed"},
+		{Hash: "0xee", ToAddress: "0xother", Status: "confirmed"},
+		{Hash: "0xff", FromAddress: "0xa", ToAddress: "0xb", Status: "confirmed", Amount: decimal.NewFromInt(1)},
+		{Hash: "0x99", Status: "failed"}, // falha sem transação interna não é quebra
+	}
+
+	breaks := MatchTransfers("ethereum", "ETH", internal, onchain)
+	byCategory := map[ReconciliationBreakCategory][]ReconciliationBreak{}
+	for _, b := range breaks {
+		byCategory[b.Category] = append(byCategory[b.Category], b)
+	}
+	require.Len(t, breaks, 5)
+	require.Len(t, byCategory[BreakMissingOnChain], 1)
+	assert.Equal(t, internal[1].ID, *byCategory[BreakMissingOnChain][0].TransactionID)
+	require.Len(t, byCategory[BreakStatusMismatch], 2)
+	require.Len(t, byCategory[BreakAddressMismatch], 1)
+	assert.Equal(t, "0xee", byCategory[BreakAddressMismatch][0].TxHash)
+	require.Len(t, byCategory[BreakMissingInternal], 1)
+	assert.Equal(t, "0xff", byCategory[BreakMissingInternal][0].TxHash)
+	assert.Nil(t, byCategory[BreakMissingInternal][0].TransactionID)
+}
+
+func TestReconciliationRun_AddBreak(t *testing.T) {
+	now := time.Date(2025, 3, 2, 1, 0, 0, 0, time.UTC)
+	run := NewReconciliationRun(now.AddDate(0, 0, -1), now)
+	assert.Equal(t, ReconciliationBalanced, run.Status)
+
+	run.AddBreak(ReconciliationBreak{Category: BreakMissingOnChain})
+	run.AddBreak(ReconciliationBreak{Category: BreakMissingOnChain})
+	assert.Equal(t, ReconciliationBreaks, run.Status)
+	assert.Equal(t, 2, run.BreakCount)
+	assert.Equal(t, 2, run.ByCategory["missing_onchain"])
+	assert.Equal(t, run.ID, run.Breaks[0].RunID)
+	assert.NotEqual(t, run.Breaks[0].ID, run.Breaks[1].ID)
+}
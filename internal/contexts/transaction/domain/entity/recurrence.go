@@ -0,0 +1,252 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ErrInvalidRecurrence regra de recorrência não reconhecida
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// maxRecurrencePeriods limite de períodos avaliados ao procurar a próxima ocorrência
+const maxRecurrencePeriods = 1000
+
+// Recurrence calcula as ocorrências de um agendamento recorrente
+type Recurrence interface {
+	// Next retorna a primeira ocorrência estritamente depois de after e não anterior a start;
+	// zero quando não há mais ocorrências
+	Next(start, after time.Time) time.Time
+}
+
+// ParseRecurrence aceita um subconjunto de RRULE ("FREQ=MONTHLY;BYMONTHDAY=5") ou uma expressão
+// cron de cinco campos ("0 9 * * 1", "@daily", com prefixo opcional CRON_TZ=)
+func ParseRecurrence(expr string) (Recurrence, error) {
+	expr = strings.TrimSpace(expr)
+	upper := strings.ToUpper(expr)
+	if strings.HasPrefix(upper, "RRULE:") || strings.HasPrefix(upper, "FREQ=") {
+		return ParseRRule(expr)
+	}
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	return cronRecurrence{schedule: schedule}, nil
+}
+
+type cronRecurrence struct {
+	schedule cron.Schedule
+}
+
+func (r cronRecurrence) Next(start, after time.Time) time.Time {
+	if after.Before(start) {
+		after = start.Add(-time.Second)
+	}
+	return r.schedule.Next(after)
+}
+
+// Frequency frequência base de uma RRULE
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// RRule subconjunto da RFC 5545: FREQ, INTERVAL, BYDAY (semanal), BYMONTHDAY (mensal, -1 = último dia),
+// COUNT e UNTIL. O horário das ocorrências é o do início do agendamento.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule interpreta a regra no formato "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"
+func ParseRRule(expr string) (*RRule, error) {
+	expr = strings.TrimSpace(expr)
+	if len(expr) >= 6 && strings.EqualFold(expr[:6], "RRULE:") {
+		expr = expr[6:]
+	}
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(expr, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+		value = strings.ToUpper(value)
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(value)
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("%w: unsupported BYDAY %q", ErrInvalidRecurrence, day)
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -1 || n > 31 {
+					return nil, fmt.Errorf("%w: BYMONTHDAY must be 1..31 or -1", ErrInvalidRecurrence)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseRRuleTime(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL: %v", ErrInvalidRecurrence, err)
+			}
+			rule.Until = until
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", ErrInvalidRecurrence, key)
+		}
+	}
+
+	switch rule.Freq {
+	case FrequencyDaily, FrequencyYearly:
+		if len(rule.ByDay) > 0 || len(rule.ByMonthDay) > 0 {
+			return nil, fmt.Errorf("%w: BYDAY/BYMONTHDAY not supported with FREQ=%s", ErrInvalidRecurrence, rule.Freq)
+		}
+	case FrequencyWeekly:
+		if len(rule.ByMonthDay) > 0 {
+			return nil, fmt.Errorf("%w: BYMONTHDAY requires FREQ=MONTHLY", ErrInvalidRecurrence)
+		}
+	case FrequencyMonthly:
+		if len(rule.ByDay) > 0 {
+			return nil, fmt.Errorf("%w: BYDAY requires FREQ=WEEKLY", ErrInvalidRecurrence)
+		}
+	default:
+		return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY", ErrInvalidRecurrence)
+	}
+	return rule, nil
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// Next percorre os períodos a partir do início; meses sem o dia pedido usam o último dia do mês
+func (r *RRule) Next(start, after time.Time) time.Time {
+	first := r.estimatePeriod(start, after)
+	for k := first; k < first+maxRecurrencePeriods; k++ {
+		for _, candidate := range r.occurrences(start, k) {
+			if candidate.Before(start) || !candidate.After(after) {
+				continue
+			}
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return time.Time{}
+			}
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+// estimatePeriod primeiro período que pode conter uma ocorrência depois de after
+func (r *RRule) estimatePeriod(start, after time.Time) int {
+	if !after.After(start) {
+		return 0
+	}
+	var units int
+	switch r.Freq {
+	case FrequencyDaily:
+		units = int(after.Sub(start).Hours() / 24)
+	case FrequencyWeekly:
+		units = int(after.Sub(start).Hours() / (24 * 7))
+	case FrequencyMonthly:
+		units = (after.Year()-start.Year())*12 + int(after.Month()-start.Month())
+	case FrequencyYearly:
+		units = after.Year() - start.Year()
+	}
+	if k := units/r.Interval - 1; k > 0 {
+		return k
+	}
+	return 0
+}
+
+// occurrences lista em ordem as ocorrências do período k
+func (r *RRule) occurrences(start time.Time, k int) []time.Time {
+	h, m, s := start.Clock()
+	at := func(y int, mo time.Month, d int) time.Time {
+		return time.Date(y, mo, d, h, m, s, 0, start.Location())
+	}
+	step := k * r.Interval
+
+	var out []time.Time
+	switch r.Freq {
+	case FrequencyDaily:
+		day := at(start.Year(), start.Month(), start.Day()).AddDate(0, 0, step)
+		out = append(out, day)
+	case FrequencyWeekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Semanas começam na segunda-feira (WKST=MO)
+		offset := (int(start.Weekday()) + 6) % 7
+		monday := at(start.Year(), start.Month(), start.Day()).AddDate(0, 0, -offset+7*step)
+		for _, wd := range days {
+			out = append(out, monday.AddDate(0, 0, (int(wd)+6)%7))
+		}
+	case FrequencyMonthly:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+		month := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, start.Location())
+		for _, d := range days {
+			out = append(out, at(month.Year(), month.Month(), clampMonthDay(month.Year(), month.Month(), d)))
+		}
+	case FrequencyYearly:
+		y := start.Year() + step
+		out = append(out, at(y, start.Month(), clampMonthDay(y, start.Month(), start.Day())))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// clampMonthDay ajusta o dia ao tamanho do mês; -1 = último dia
+func clampMonthDay(year int, month time.Month, day int) int {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day < 0 || day > last {
+		return last
+	}
+	return day
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AccountBalance saldo de um usuário em um ativo no momento do snapshot
+type AccountBalance struct {
+	UserID  uuid.UUID
+	Asset   string
+	Balance decimal.Decimal
+}
+
+// AssetReserves raiz da árvore de somas do ativo, passivo total e reservas on-chain
+type AssetReserves struct {
+	Asset       string          `json:"asset"`
+	Root        string          `json:"root"`        // hash da raiz da árvore de Merkle de somas
+	Liabilities decimal.Decimal `json:"liabilities"` // soma da raiz
+	Accounts    int             `json:"accounts"`
+	// Reserves soma dos endereços da plataforma nas redes do ativo; ausente para ativos sem rede
+	// (ex.: BRL) ou quando algum saldo não pôde ser consultado
+	Reserves          decimal.Decimal `json:"reserves"`
+	ReservesAvailable bool            `json:"reserves_available"`
+}
+
+// Solvent reservas cobrem o passivo do ativo
+func (a AssetReserves) Solvent() bool {
+	return a.ReservesAvailable && a.Reserves.GreaterThanOrEqual(a.Liabilities)
+}
+
+// ReservesSnapshot prova de reservas: snapshot dos saldos de todos os usuários por ativo
+type ReservesSnapshot struct {
+	ID        uuid.UUID       `json:"id"`
+	Assets    []AssetReserves `json:"assets"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Asset retorna o resumo do ativo no snapshot
+func (s *ReservesSnapshot) Asset(asset string) (AssetReserves, bool) {
+	for _, a := range s.Assets {
+		if a.Asset == asset {
+			return a, true
+		}
+	}
+	return AssetReserves{}, false
+}
+
+// ReservesLeaf folha de uma conta na árvore do ativo. O nonce é sorteado por conta e snapshot e só
+// é revelado ao titular, junto com a prova de inclusão.
+type ReservesLeaf struct {
+	SnapshotID uuid.UUID
+	Asset      string
+	UserID     uuid.UUID
+	Nonce      string
+	LeafID     string
+	Balance    decimal.Decimal
+	Position   int
+}
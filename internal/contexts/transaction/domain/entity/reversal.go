@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNotReversible           = errors.New("transaction cannot be reversed")
+	ErrReversalExceedsOriginal = errors.New("reversals would exceed the original amount")
+	ErrInvalidReversalReason   = errors.New("invalid reversal reason")
+)
+
+// ReversalReason código do motivo do estorno
+type ReversalReason string
+
+const (
+	ReversalDuplicate        ReversalReason = "duplicate"
+	ReversalOperationalError ReversalReason = "operational_error"
+	ReversalFraud            ReversalReason = "fraud"
+	ReversalCustomerRequest  ReversalReason = "customer_request"
+	ReversalOther            ReversalReason = "other"
+)
+
+// Valid indica se o código de motivo é conhecido
+func (r ReversalReason) Valid() bool {
+	switch r {
+	case ReversalDuplicate, ReversalOperationalError, ReversalFraud, ReversalCustomerRequest, ReversalOther:
+		return true
+	}
+	return false
+}
+
+// Reversal estorno total ou parcial de uma transação concluída. A movimentação de saldo é a
+// transação TransactionID (tipo reversal, ParentID = OriginalID).
+type Reversal struct {
+	ID            uuid.UUID       `json:"id"`
+	OriginalID    uuid.UUID       `json:"original_id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Reason        ReversalReason  `json:"reason"`
+	Note          string          `json:"note,omitempty"`
+	OperatorID    uuid.UUID       `json:"operator_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NewReversal registra o estorno de original pela transação de estorno tx
+func NewReversal(original, tx *Transaction, reason ReversalReason, note string, operatorID uuid.UUID) *Reversal {
+	return &Reversal{
+		ID:            uuid.New(),
+		OriginalID:    original.ID,
+		TransactionID: tx.ID,
+		Amount:        tx.Amount,
+		Reason:        reason,
+		Note:          note,
+		OperatorID:    operatorID,
+		CreatedAt:     tx.CreatedAt,
+	}
+}
+
+// ReversibleAmount valor máximo que pode ser estornado da transação: o valor creditado ao cliente
+// nos depósitos (descontada a taxa) e o valor movimentado nas transferências e taxas.
+// Saques já saíram para a rede e não são estornáveis.
+func ReversibleAmount(tx *Transaction) decimal.Decimal {
+	switch tx.Type {
+	case TransactionTypeDeposit:
+		return tx.Amount.Sub(tx.Fee)
+	case TransactionTypeTransfer, TransactionTypeFee:
+		return tx.Amount
+	}
+	return decimal.Zero
+}
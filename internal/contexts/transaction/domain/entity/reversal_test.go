@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+
+	"financial-system-pro/internal/contexts/transaction/domain/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReversibleAmount(t *testing.T) {
+	deposit := NewTransaction(uuid.New(), TransactionTypeDeposit, decimal.NewFromInt(100))
+	deposit.Fee = decimal.NewFromInt(2)
+	transfer := NewTransaction(uuid.New(), TransactionTypeTransfer, decimal.NewFromInt(50))
+	transfer.Fee = decimal.NewFromInt(1)
+
+	assert.True(t, ReversibleAmount(deposit).Equal(decimal.NewFromInt(98)))
+	assert.True(t, ReversibleAmount(transfer).Equal(decimal.NewFromInt(50)))
+	assert.True(t, ReversibleAmount(NewTransaction(uuid.New(), TransactionTypeWithdraw, decimal.NewFromInt(10))).IsZero())
+	assert.True(t, ReversibleAmount(NewTransaction(uuid.New(), TransactionTypeReversal, decimal.NewFromInt(10))).IsZero())
+}
+
+func TestTransactionAggregate_Reverse(t *testing.T) {
+	completed := func() *Transaction {
+		tx := NewTransaction(uuid.New(), TransactionTypeTransfer, decimal.NewFromInt(100))
+		tx.Complete("hash")
+		return tx
+	}
+
+	t.Run("partial reversal emits event", func(t *testing.T) {
+		agg := RestoreTransactionAggregate(completed())
+		require.NoError(t, agg.Reverse(uuid.New(), decimal.NewFromInt(40), decimal.NewFromInt(20), ReversalFraud))
+
+		require.Len(t, agg.DomainEvents(), 1)
+		evt, ok := agg.DomainEvents()[0].(*events.TransactionReversed)
+		require.True(t, ok)
+		assert.Equal(t, 40.0, evt.Amount)
+		assert.Equal(t, 60.0, evt.TotalReversed)
+		assert.False(t, evt.Full)
+		assert.Equal(t, TransactionStatusCompleted, agg.GetStatus())
+	})
+
+	t.Run("full reversal", func(t *testing.T) {
+		agg := RestoreTransactionAggregate(completed())
+		require.NoError(t, agg.Reverse(uuid.New(), decimal.NewFromInt(100), decimal.Zero, ReversalDuplicate))
+		assert.True(t, agg.DomainEvents()[0].(*events.TransactionReversed).Full)
+	})
+
+	t.Run("cannot exceed original", func(t *testing.T) {
+		agg := RestoreTransactionAggregate(completed())
+		assert.ErrorIs(t, agg.Reverse(uuid.New(), decimal.NewFromInt(30), decimal.NewFromInt(80), ReversalOther), ErrReversalExceedsOriginal)
+		assert.Empty(t, agg.DomainEvents())
+	})
+
+	t.Run("requires completed transaction and valid reason", func(t *testing.T) {
+		pending := NewTransaction(uuid.New(), TransactionTypeTransfer, decimal.NewFromInt(100))
+		assert.ErrorIs(t, RestoreTransactionAggregate(pending).Reverse(uuid.New(), decimal.NewFromInt(1), decimal.Zero, ReversalOther), ErrNotReversible)
+		assert.ErrorIs(t, RestoreTransactionAggregate(completed()).Reverse(uuid.New(), decimal.NewFromInt(1), decimal.Zero, "typo"), ErrInvalidReversalReason)
+	})
+}
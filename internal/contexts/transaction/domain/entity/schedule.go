@@ -0,0 +1,325 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidSchedule    = errors.New("invalid schedule")
+	ErrScheduleNotActive  = errors.New("schedule is not active")
+	ErrScheduleNotPaused  = errors.New("schedule is not paused")
+	ErrScheduleFinished   = errors.New("schedule is cancelled or completed")
+	ErrScheduleConflict   = errors.New("schedule was modified concurrently")
+	ErrExecutionDuplicate = errors.New("schedule execution already started")
+)
+
+// ScheduleKind operação executada pelo agendamento
+type ScheduleKind string
+
+const (
+	ScheduleKindTransfer ScheduleKind = "transfer"
+	ScheduleKindWithdraw ScheduleKind = "withdraw"
+)
+
+// ScheduleStatus estado do agendamento
+type ScheduleStatus string
+
+const (
+	ScheduleActive    ScheduleStatus = "active"
+	SchedulePaused    ScheduleStatus = "paused"
+	ScheduleCancelled ScheduleStatus = "cancelled"
+	ScheduleCompleted ScheduleStatus = "completed"
+)
+
+// ScheduleSpec parâmetros de criação de um agendamento
+type ScheduleSpec struct {
+	UserID      uuid.UUID
+	Kind        ScheduleKind
+	Amount      decimal.Decimal
+	ToUserID    *uuid.UUID // transferências
+	Chain       string     // saques
+	ToAddress   string     // saques
+	Description string
+	// Recurrence RRULE ou cron; vazio = execução única em StartAt
+	Recurrence string
+	StartAt    time.Time
+	EndAt      *time.Time
+	MaxRuns    int // 0 = sem limite
+	MaxRetries int
+}
+
+// ScheduledTransfer transferência ou saque agendado, único ou recorrente.
+// NextRunAt é a ocorrência pendente; RetryAt, quando preenchido, adia a nova tentativa dela.
+type ScheduledTransfer struct {
+	ID          uuid.UUID       `json:"id"`
+	UserID      uuid.UUID       `json:"user_id"`
+	Kind        ScheduleKind    `json:"type"`
+	Amount      decimal.Decimal `json:"amount"`
+	ToUserID    *uuid.UUID      `json:"to_user_id,omitempty"`
+	Chain       string          `json:"chain,omitempty"`
+	ToAddress   string          `json:"to_address,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Recurrence  string          `json:"recurrence,omitempty"`
+	StartAt     time.Time       `json:"start_at"`
+	EndAt       *time.Time      `json:"end_at,omitempty"`
+	MaxRuns     int             `json:"max_runs,omitempty"`
+	RunCount    int             `json:"run_count"`
+	NextRunAt   *time.Time      `json:"next_run_at,omitempty"`
+	RetryAt     *time.Time      `json:"retry_at,omitempty"`
+	Attempts    int             `json:"attempts"`
+	MaxRetries  int             `json:"max_retries"`
+	Status      ScheduleStatus  `json:"status"`
+	Version     int             `json:"-"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// NewScheduledTransfer valida o agendamento e calcula a primeira ocorrência.
+// COUNT e UNTIL de uma RRULE preenchem MaxRuns e EndAt quando não informados.
+func NewScheduledTransfer(spec ScheduleSpec, now time.Time) (*ScheduledTransfer, error) {
+	if !spec.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidSchedule)
+	}
+	switch spec.Kind {
+	case ScheduleKindTransfer:
+		if spec.ToUserID == nil || *spec.ToUserID == uuid.Nil {
+			return nil, fmt.Errorf("%w: to_user_id is required", ErrInvalidSchedule)
+		}
+		if *spec.ToUserID == spec.UserID {
+			return nil, fmt.Errorf("%w: cannot schedule a transfer to yourself", ErrInvalidSchedule)
+		}
+	case ScheduleKindWithdraw:
+		if spec.Chain == "" || spec.ToAddress == "" {
+			return nil, fmt.Errorf("%w: chain and to_address are required", ErrInvalidSchedule)
+		}
+	default:
+		return nil, fmt.Errorf("%w: type must be transfer or withdraw", ErrInvalidSchedule)
+	}
+	if spec.MaxRuns < 0 || spec.MaxRetries < 0 {
+		return nil, fmt.Errorf("%w: max_runs and max_retries cannot be negative", ErrInvalidSchedule)
+	}
+
+	s := &ScheduledTransfer{
+		ID:          uuid.New(),
+		UserID:      spec.UserID,
+		Kind:        spec.Kind,
+		Amount:      spec.Amount,
+		ToUserID:    spec.ToUserID,
+		Chain:       spec.Chain,
+		ToAddress:   spec.ToAddress,
+		Description: spec.Description,
+		Recurrence:  spec.Recurrence,
+		StartAt:     spec.StartAt,
+		EndAt:       spec.EndAt,
+		MaxRuns:     spec.MaxRuns,
+		MaxRetries:  spec.MaxRetries,
+		Status:      ScheduleActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	var first time.Time
+	if spec.Recurrence == "" {
+		if !spec.StartAt.After(now) {
+			return nil, fmt.Errorf("%w: start_at must be in the future", ErrInvalidSchedule)
+		}
+		s.MaxRuns, s.EndAt = 1, nil
+		first = spec.StartAt
+	} else {
+		rec, err := ParseRecurrence(spec.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		if rule, ok := rec.(*RRule); ok {
+			if s.MaxRuns == 0 {
+				s.MaxRuns = rule.Count
+			}
+			if s.EndAt == nil && !rule.Until.IsZero() {
+				until := rule.Until
+				s.EndAt = &until
+			}
+		}
+		// Início informado conta como ocorrência; sem início a primeira é a próxima depois de agora
+		after := now
+		if s.StartAt.IsZero() {
+			s.StartAt = now
+		} else if !s.StartAt.Before(now) {
+			after = s.StartAt.Add(-time.Second)
+		}
+		first = rec.Next(s.StartAt, after)
+	}
+	if first.IsZero() || (s.EndAt != nil && first.After(*s.EndAt)) {
+		return nil, fmt.Errorf("%w: recurrence has no future occurrence", ErrInvalidSchedule)
+	}
+	s.NextRunAt = &first
+	return s, nil
+}
+
+// DueAt momento da próxima execução (nova tentativa ou ocorrência); zero se não houver
+func (s *ScheduledTransfer) DueAt() time.Time {
+	if s.RetryAt != nil {
+		return *s.RetryAt
+	}
+	if s.NextRunAt != nil {
+		return *s.NextRunAt
+	}
+	return time.Time{}
+}
+
+// IsDue indica se o agendamento ativo deve ser executado em now
+func (s *ScheduledTransfer) IsDue(now time.Time) bool {
+	due := s.DueAt()
+	return s.Status == ScheduleActive && !due.IsZero() && !due.After(now)
+}
+
+// RecordSuccess conta a ocorrência executada e avança para a próxima
+func (s *ScheduledTransfer) RecordSuccess(now time.Time) {
+	s.RunCount++
+	s.advance(now)
+}
+
+// RecordFailure agenda nova tentativa da ocorrência após backoff (dobrando a cada falha) enquanto
+// restarem tentativas; depois disso a ocorrência é pulada. Retorna true se haverá nova tentativa.
+func (s *ScheduledTransfer) RecordFailure(now time.Time, retryable bool, backoff time.Duration) bool {
+	if retryable && s.Attempts < s.MaxRetries {
+		s.Attempts++
+		retryAt := now.Add(backoff << (s.Attempts - 1))
+		s.RetryAt = &retryAt
+		s.UpdatedAt = now
+		return true
+	}
+	s.RunCount++
+	s.advance(now)
+	return false
+}
+
+// advance calcula a próxima ocorrência depois da atual e de now (ocorrências perdidas são puladas)
+func (s *ScheduledTransfer) advance(now time.Time) {
+	s.Attempts = 0
+	s.RetryAt = nil
+	s.UpdatedAt = now
+
+	var next time.Time
+	if s.Recurrence != "" && s.NextRunAt != nil && (s.MaxRuns == 0 || s.RunCount < s.MaxRuns) {
+		if rec, err := ParseRecurrence(s.Recurrence); err == nil {
+			after := *s.NextRunAt
+			if now.After(after) {
+				after = now
+			}
+			next = rec.Next(s.StartAt, after)
+		}
+	}
+	if next.IsZero() || (s.EndAt != nil && next.After(*s.EndAt)) {
+		s.NextRunAt = nil
+		if s.Status == ScheduleActive || s.Status == SchedulePaused {
+			s.Status = ScheduleCompleted
+		}
+		return
+	}
+	s.NextRunAt = &next
+}
+
+// Pause suspende as execuções
+func (s *ScheduledTransfer) Pause(now time.Time) error {
+	if s.Status != ScheduleActive {
+		if s.Status == SchedulePaused {
+			return ErrScheduleNotActive
+		}
+		return ErrScheduleFinished
+	}
+	s.Status = SchedulePaused
+	s.UpdatedAt = now
+	return nil
+}
+
+// Resume reativa o agendamento; ocorrências recorrentes perdidas durante a pausa são puladas
+// e tentativas pendentes são descartadas
+func (s *ScheduledTransfer) Resume(now time.Time) error {
+	if s.Status != SchedulePaused {
+		if s.Status == ScheduleActive {
+			return ErrScheduleNotPaused
+		}
+		return ErrScheduleFinished
+	}
+	s.Status = ScheduleActive
+	s.Attempts = 0
+	s.RetryAt = nil
+	s.UpdatedAt = now
+	if s.Recurrence != "" && s.NextRunAt != nil && s.NextRunAt.Before(now) {
+		rec, err := ParseRecurrence(s.Recurrence)
+		if err != nil {
+			return err
+		}
+		next := rec.Next(s.StartAt, now)
+		if next.IsZero() || (s.EndAt != nil && next.After(*s.EndAt)) {
+			s.NextRunAt = nil
+			s.Status = ScheduleCompleted
+			return nil
+		}
+		s.NextRunAt = &next
+	}
+	return nil
+}
+
+// Cancel encerra o agendamento definitivamente
+func (s *ScheduledTransfer) Cancel(now time.Time) error {
+	if s.Status == ScheduleCancelled || s.Status == ScheduleCompleted {
+		return ErrScheduleFinished
+	}
+	s.Status = ScheduleCancelled
+	s.NextRunAt = nil
+	s.RetryAt = nil
+	s.UpdatedAt = now
+	return nil
+}
+
+// ScheduleExecutionStatus resultado de uma tentativa de execução
+type ScheduleExecutionStatus string
+
+const (
+	ExecutionRunning   ScheduleExecutionStatus = "running"
+	ExecutionSucceeded ScheduleExecutionStatus = "succeeded"
+	ExecutionRetrying  ScheduleExecutionStatus = "retrying" // falhou, haverá nova tentativa
+	ExecutionFailed    ScheduleExecutionStatus = "failed"   // falhou, ocorrência pulada
+)
+
+// ScheduleExecution tentativa de execução de uma ocorrência; (ScheduleID, Occurrence, Attempt) é único
+type ScheduleExecution struct {
+	ID            uuid.UUID               `json:"id"`
+	ScheduleID    uuid.UUID               `json:"schedule_id"`
+	Occurrence    time.Time               `json:"occurrence"`
+	Attempt       int                     `json:"attempt"`
+	Status        ScheduleExecutionStatus `json:"status"`
+	TransactionID *uuid.UUID              `json:"transaction_id,omitempty"`
+	Error         string                  `json:"error,omitempty"`
+	StartedAt     time.Time               `json:"started_at"`
+	FinishedAt    *time.Time              `json:"finished_at,omitempty"`
+}
+
+// NewScheduleExecution abre a tentativa atual da ocorrência pendente
+func NewScheduleExecution(s *ScheduledTransfer, now time.Time) *ScheduleExecution {
+	exec := &ScheduleExecution{
+		ID:         uuid.New(),
+		ScheduleID: s.ID,
+		Attempt:    s.Attempts + 1,
+		Status:     ExecutionRunning,
+		StartedAt:  now,
+	}
+	if s.NextRunAt != nil {
+		exec.Occurrence = *s.NextRunAt
+	}
+	return exec
+}
+
+// Finish registra o resultado da tentativa
+func (e *ScheduleExecution) Finish(status ScheduleExecutionStatus, txID *uuid.UUID, errMsg string, now time.Time) {
+	e.Status = status
+	e.TransactionID = txID
+	e.Error = errMsg
+	e.FinishedAt = &now
+}
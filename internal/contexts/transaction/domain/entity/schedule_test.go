@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestRRule_Next(t *testing.T) {
+	start := mustTime(t, "2025-01-31T09:00:00Z") // sexta-feira
+	cases := []struct {
+		name  string
+		rule  string
+		after string
+		want  string
+	}{
+		{"primeira ocorrência é o início", "FREQ=DAILY", "2025-01-31T08:59:59Z", "2025-01-31T09:00:00Z"},
+		{"diária com intervalo", "FREQ=DAILY;INTERVAL=3", "2025-02-01T00:00:00Z", "2025-02-03T09:00:00Z"},
+		{"mensal ajusta fim de mês", "RRULE:FREQ=MONTHLY", "2025-01-31T09:00:00Z", "2025-02-28T09:00:00Z"},
+		{"mensal volta ao dia 31", "FREQ=MONTHLY", "2025-02-28T09:00:00Z", "2025-03-31T09:00:00Z"},
+		{"último dia do mês", "FREQ=MONTHLY;BYMONTHDAY=-1", "2025-03-31T09:00:00Z", "2025-04-30T09:00:00Z"},
+		{"semanal em dias escolhidos", "FREQ=WEEKLY;BYDAY=MO,WE", "2025-01-31T09:00:00Z", "2025-02-03T09:00:00Z"},
+		{"quinzenal", "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", "2025-01-31T09:00:00Z", "2025-02-14T09:00:00Z"},
+		{"anual em ano bissexto", "FREQ=YEARLY", "2027-06-01T00:00:00Z", "2028-01-31T09:00:00Z"},
+		{"muito depois do início", "FREQ=DAILY", "2030-05-10T10:00:00Z", "2030-05-11T09:00:00Z"},
+		{"depois de UNTIL", "FREQ=DAILY;UNTIL=20250202", "2025-02-01T09:00:00Z", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := ParseRRule(tc.rule)
+			if err != nil {
+				t.Fatalf("regra inválida: %v", err)
+			}
+			got := rule.Next(start, mustTime(t, tc.after))
+			if tc.want == "" {
+				if !got.IsZero() {
+					t.Fatalf("não deveria haver ocorrência, obtido %s", got)
+				}
+				return
+			}
+			if !got.Equal(mustTime(t, tc.want)) {
+				t.Fatalf("esperado %s, obtido %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	rec, err := ParseRecurrence("0 9 5 * *")
+	if err != nil {
+		t.Fatalf("cron inválido: %v", err)
+	}
+	start := mustTime(t, "2025-01-10T00:00:00Z")
+	if got := rec.Next(start, start.AddDate(0, 0, -30)); !got.Equal(mustTime(t, "2025-02-05T09:00:00Z")) {
+		t.Fatalf("cron não deveria ocorrer antes do início: %s", got)
+	}
+
+	for _, expr := range []string{"FREQ=HOURLY", "FREQ=MONTHLY;BYDAY=MO", "FREQ=DAILY;INTERVAL=0", "FREQ=MONTHLY;BYMONTHDAY=32", "não é cron"} {
+		if _, err := ParseRecurrence(expr); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Fatalf("%q deveria ser inválida, obtido %v", expr, err)
+		}
+	}
+}
+
+func TestNewScheduledTransfer_Validation(t *testing.T) {
+	now := mustTime(t, "2025-01-01T12:00:00Z")
+	uid, other := uuid.New(), uuid.New()
+	base := func() ScheduleSpec {
+		return ScheduleSpec{UserID: uid, Kind: ScheduleKindTransfer, Amount: decimal.NewFromInt(100), ToUserID: &other, StartAt: now.Add(time.Hour)}
+	}
+
+	cases := map[string]func(*ScheduleSpec){
+		"valor zero":         func(s *ScheduleSpec) { s.Amount = decimal.Zero },
+		"para si mesmo":      func(s *ScheduleSpec) { s.ToUserID = &uid },
+		"sem destinatário":   func(s *ScheduleSpec) { s.ToUserID = nil },
+		"saque sem endereço": func(s *ScheduleSpec) { s.Kind = ScheduleKindWithdraw },
+		"tipo desconhecido":  func(s *ScheduleSpec) { s.Kind = "deposit" },
+		"único no passado":   func(s *ScheduleSpec) { s.StartAt = now.Add(-time.Minute) },
+		"fim antes do início": func(s *ScheduleSpec) {
+			end := now
+			s.Recurrence, s.EndAt = "FREQ=DAILY", &end
+		},
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := base()
+			mutate(&spec)
+			if _, err := NewScheduledTransfer(spec, now); !errors.Is(err, ErrInvalidSchedule) {
+				t.Fatalf("esperado ErrInvalidSchedule, obtido %v", err)
+			}
+		})
+	}
+}
+
+func TestScheduledTransfer_RecurringLifecycle(t *testing.T) {
+	now := mustTime(t, "2025-01-01T12:00:00Z")
+	other := uuid.New()
+	s, err := NewScheduledTransfer(ScheduleSpec{
+		UserID: uuid.New(), Kind: ScheduleKindTransfer, Amount: decimal.NewFromInt(1500), ToUserID: &other,
+		Recurrence: "FREQ=MONTHLY;BYMONTHDAY=5;COUNT=2", StartAt: now, MaxRetries: 2,
+	}, now)
+	if err != nil {
+		t.Fatalf("agendamento inválido: %v", err)
+	}
+	first := mustTime(t, "2025-01-05T12:00:00Z")
+	if s.MaxRuns != 2 || !s.NextRunAt.Equal(first) || s.IsDue(now) || !s.IsDue(first) {
+		t.Fatalf("primeira ocorrência inesperada: %+v", s)
+	}
+
+	// Falta de saldo: duas novas tentativas com backoff dobrando, depois a ocorrência é pulada
+	if !s.RecordFailure(first, true, time.Hour) || !s.RetryAt.Equal(first.Add(time.Hour)) {
+		t.Fatalf("primeira falha deveria agendar nova tentativa em 1h: %+v", s.RetryAt)
+	}
+	if !s.RecordFailure(first.Add(time.Hour), true, time.Hour) || !s.RetryAt.Equal(first.Add(3*time.Hour)) {
+		t.Fatalf("segunda falha deveria agendar nova tentativa em 2h: %+v", s.RetryAt)
+	}
+	if s.RecordFailure(first.Add(3*time.Hour), true, time.Hour) {
+		t.Fatal("tentativas esgotadas deveriam pular a ocorrência")
+	}
+	if s.RetryAt != nil || s.Attempts != 0 || s.RunCount != 1 || !s.NextRunAt.Equal(mustTime(t, "2025-02-05T12:00:00Z")) {
+		t.Fatalf("deveria avançar para fevereiro: %+v", s)
+	}
+
+	s.RecordSuccess(mustTime(t, "2025-02-05T12:00:01Z"))
+	if s.Status != ScheduleCompleted || s.NextRunAt != nil {
+		t.Fatalf("COUNT=2 deveria concluir o agendamento: %+v", s)
+	}
+	if err := s.Cancel(now); !errors.Is(err, ErrScheduleFinished) {
+		t.Fatalf("esperado ErrScheduleFinished, obtido %v", err)
+	}
+}
+
+func TestScheduledTransfer_PauseResumeCancel(t *testing.T) {
+	now := mustTime(t, "2025-01-01T12:00:00Z")
+	s, err := NewScheduledTransfer(ScheduleSpec{
+		UserID: uuid.New(), Kind: ScheduleKindWithdraw, Amount: decimal.NewFromInt(10), Chain: "tron", ToAddress: "TADDR",
+		Recurrence: "FREQ=DAILY", StartAt: now,
+	}, now)
+	if err != nil {
+		t.Fatalf("agendamento inválido: %v", err)
+	}
+	if err := s.Resume(now); !errors.Is(err, ErrScheduleNotPaused) {
+		t.Fatalf("esperado ErrScheduleNotPaused, obtido %v", err)
+	}
+	if err := s.Pause(now); err != nil {
+		t.Fatalf("pausa falhou: %v", err)
+	}
+	if s.IsDue(now.AddDate(0, 0, 10)) {
+		t.Fatal("agendamento pausado não deveria vencer")
+	}
+
+	// Ocorrências perdidas durante a pausa são puladas
+	later := now.AddDate(0, 0, 10).Add(time.Hour)
+	if err := s.Resume(later); err != nil {
+		t.Fatalf("retomada falhou: %v", err)
+	}
+	if !s.NextRunAt.Equal(mustTime(t, "2025-01-12T12:00:00Z")) {
+		t.Fatalf("próxima ocorrência inesperada: %s", s.NextRunAt)
+	}
+
+	if err := s.Cancel(later); err != nil {
+		t.Fatalf("cancelamento falhou: %v", err)
+	}
+	if err := s.Pause(later); !errors.Is(err, ErrScheduleFinished) {
+		t.Fatalf("esperado ErrScheduleFinished, obtido %v", err)
+	}
+	if s.IsDue(later.AddDate(1, 0, 0)) {
+		t.Fatal("agendamento cancelado não deveria vencer")
+	}
+}
@@ -0,0 +1,375 @@
+package entity
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidStatementPeriod período vazio, invertido ou longo demais
+var ErrInvalidStatementPeriod = errors.New("invalid statement period")
+
+// Formatos de exportação do extrato
+const (
+	StatementFormatCSV  = "csv"
+	StatementFormatOFX  = "ofx"
+	StatementFormatJSON = "json"
+	// StatementFormatCamt053 extrato ISO 20022 bank-to-customer (camt.053.001.08)
+	StatementFormatCamt053 = "camt053"
+)
+
+// StatementChannel meio pelo qual o valor entrou ou saiu da carteira
+type StatementChannel string
+
+const (
+	StatementChannelInternal StatementChannel = "internal" // entre carteiras da plataforma
+	StatementChannelPix      StatementChannel = "pix"      // moeda fiduciária via Pix
+	StatementChannelOnChain  StatementChannel = "onchain"  // depósitos e saques em blockchain
+)
+
+// StatementLine movimentação do extrato; Amount é positivo para créditos e negativo para débitos e
+// Balance é o saldo após a movimentação
+type StatementLine struct {
+	Date          time.Time        `json:"date"`
+	TransactionID uuid.UUID        `json:"transaction_id"`
+	FITID         string           `json:"fitid"`
+	Type          TransactionType  `json:"type"`
+	Channel       StatementChannel `json:"channel"`
+	Description   string           `json:"description"`
+	Counterparty  string           `json:"counterparty,omitempty"`
+	Reference     string           `json:"reference,omitempty"`
+	Amount        decimal.Decimal  `json:"amount"`
+	Balance       decimal.Decimal  `json:"balance"`
+	Pending       bool             `json:"pending,omitempty"` // saque retido aguardando aprovação
+}
+
+// Statement extrato do período [PeriodStart, PeriodEnd) com saldos inicial e final
+type Statement struct {
+	ID             uuid.UUID       `json:"id"`
+	UserID         uuid.UUID       `json:"user_id"`
+	Account        string          `json:"account"`
+	Currency       string          `json:"currency"`
+	PeriodStart    time.Time       `json:"period_start"`
+	PeriodEnd      time.Time       `json:"period_end"`
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+	TotalCredits   decimal.Decimal `json:"total_credits"`
+	TotalDebits    decimal.Decimal `json:"total_debits"`
+	LineCount      int             `json:"line_count"`
+	Lines          []StatementLine `json:"lines,omitempty"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+// AffectsBalance indica se a transação movimentou saldo: concluídas e saques retidos para
+// aprovação (debitados na retenção; se recusados passam a failed e o valor volta)
+func AffectsBalance(tx *Transaction) bool {
+	return tx.Status == TransactionStatusCompleted || tx.Status == TransactionStatusAwaitingApproval
+}
+
+// StatementLines converte a transação nas movimentações da carteira account. Débitos com taxa
+// geram uma linha para o valor e outra para a taxa; a transação de taxa (type fee) só credita a
+// carteira de receita, pois o débito do cliente já aparece na transação de origem.
+func StatementLines(tx *Transaction, userID uuid.UUID, account string) []StatementLine {
+	if !AffectsBalance(tx) {
+		return nil
+	}
+	base := StatementLine{
+		Date:          tx.CreatedAt,
+		TransactionID: tx.ID,
+		FITID:         tx.ID.String(),
+		Type:          tx.Type,
+		Channel:       statementChannel(tx),
+		Reference:     tx.TransactionHash,
+		Pending:       tx.Status == TransactionStatusAwaitingApproval,
+	}
+
+	var lines []StatementLine
+	credit := tx.ToAddress != "" && tx.ToAddress == account
+	if tx.Type == TransactionTypeDeposit && tx.UserID == userID {
+		credit = true
+	}
+	debit := tx.Type != TransactionTypeFee && tx.FromAddress != "" && tx.FromAddress == account
+
+	if debit {
+		line := base
+		line.Amount = tx.Amount.Neg()
+		line.Counterparty = tx.ToAddress
+		line.Description = describeMovement(tx, false)
+		lines = append(lines, line)
+		if tx.Fee.IsPositive() {
+			fee := base
+			fee.FITID = tx.ID.String() + "-fee"
+			fee.Type = TransactionTypeFee
+			fee.Amount = tx.Fee.Neg()
+			fee.Description = "Tarifa - " + describeMovement(tx, false)
+			lines = append(lines, fee)
+		}
+	}
+	if credit {
+		line := base
+		line.Amount = tx.Amount
+		line.Counterparty = tx.FromAddress
+		line.Description = describeMovement(tx, true)
+		if debit {
+			// Movimentação da carteira para ela mesma
+			line.FITID = tx.ID.String() + "-in"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func statementChannel(tx *Transaction) StatementChannel {
+	switch {
+	case IsPixAddress(tx.FromAddress) || IsPixAddress(tx.ToAddress):
+		return StatementChannelPix
+	case tx.Type == TransactionTypeDeposit || tx.Type == TransactionTypeWithdraw:
+		return StatementChannelOnChain
+	}
+	return StatementChannelInternal
+}
+
+func describeMovement(tx *Transaction, credit bool) string {
+	switch tx.Type {
+	case TransactionTypeDeposit:
+		return "Depósito"
+	case TransactionTypeWithdraw:
+		if tx.Status == TransactionStatusAwaitingApproval {
+			return "Saque (aguardando aprovação)"
+		}
+		return "Saque"
+	case TransactionTypeTransfer:
+		if credit {
+			return "Transferência recebida"
+		}
+		return "Transferência enviada"
+	case TransactionTypeFee:
+		return "Receita de tarifa"
+	case TransactionTypeReversal:
+		return "Estorno"
+	case TransactionTypeEscrowFund:
+		return "Depósito em custódia"
+	case TransactionTypeEscrowPayout:
+		return "Liberação de custódia"
+	case TransactionTypeInterest:
+		return "Rendimento"
+	case TransactionTypeWithholdingTax:
+		return "IR retido na fonte"
+	case TransactionTypeCreditInterest:
+		return "Juros do cheque especial"
+	case TransactionTypeCreditFee:
+		return "Tarifa do cheque especial"
+	case TransactionTypeAccountFunding:
+		return "Aporte em conta"
+	case TransactionTypeAccountWithdrawal:
+		return "Resgate de conta"
+	case TransactionTypeConversion:
+		if credit {
+			return "Câmbio - entrada"
+		}
+		return "Câmbio - saída"
+	}
+	return string(tx.Type)
+}
+
+// NewStatement monta o extrato a partir do saldo inicial e das linhas do período em ordem cronológica
+func NewStatement(userID uuid.UUID, account, currency string, from, to time.Time, opening decimal.Decimal, lines []StatementLine, now time.Time) *Statement {
+	st := &Statement{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Account:        account,
+		Currency:       currency,
+		PeriodStart:    from,
+		PeriodEnd:      to,
+		OpeningBalance: opening,
+		TotalCredits:   decimal.Zero,
+		TotalDebits:    decimal.Zero,
+		Lines:          make([]StatementLine, 0, len(lines)),
+		GeneratedAt:    now,
+	}
+	balance := opening
+	for _, line := range lines {
+		balance = balance.Add(line.Amount)
+		line.Balance = balance
+		if line.Amount.IsNegative() {
+			st.TotalDebits = st.TotalDebits.Add(line.Amount.Neg())
+		} else {
+			st.TotalCredits = st.TotalCredits.Add(line.Amount)
+		}
+		st.Lines = append(st.Lines, line)
+	}
+	st.ClosingBalance = balance
+	st.LineCount = len(st.Lines)
+	return st
+}
+
+// WriteStatement exporta o extrato no formato informado
+func WriteStatement(w io.Writer, format string, st *Statement) error {
+	switch format {
+	case StatementFormatCSV:
+		return writeStatementCSV(w, st)
+	case StatementFormatOFX:
+		return writeStatementOFX(w, st)
+	case StatementFormatCamt053:
+		return writeCamt053(w, st)
+	case StatementFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(st)
+	}
+	return fmt.Errorf("unsupported statement format %q", format)
+}
+
+// writeStatementCSV uma linha por movimentação, com as linhas de saldo inicial e final
+func writeStatementCSV(w io.Writer, st *Statement) error {
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"date", "transaction_id", "type", "channel", "description", "counterparty", "reference", "amount", "balance"})
+	_ = out.Write([]string{st.PeriodStart.UTC().Format(time.RFC3339), "", "", "", "Saldo inicial", "", "", "", st.OpeningBalance.String()})
+	for _, line := range st.Lines {
+		_ = out.Write([]string{
+			line.Date.UTC().Format(time.RFC3339),
+			line.TransactionID.String(),
+			string(line.Type),
+			string(line.Channel),
+			line.Description,
+			line.Counterparty,
+			line.Reference,
+			line.Amount.String(),
+			line.Balance.String(),
+		})
+	}
+	_ = out.Write([]string{st.PeriodEnd.UTC().Format(time.RFC3339), "", "", "", "Saldo final", "", "", "", st.ClosingBalance.String()})
+	out.Flush()
+	return out.Error()
+}
+
+// ofxBankID identificador da instituição no BANKACCTFROM
+const ofxBankID = "FINSYSPRO"
+
+type ofxDocument struct {
+	XMLName xml.Name `xml:"OFX"`
+	SignOn  struct {
+		Response struct {
+			Status   ofxStatus `xml:"STATUS"`
+			DTServer string    `xml:"DTSERVER"`
+			Language string    `xml:"LANGUAGE"`
+		} `xml:"SONRS"`
+	} `xml:"SIGNONMSGSRSV1"`
+	Bank struct {
+		Transaction struct {
+			TrnUID    string    `xml:"TRNUID"`
+			Status    ofxStatus `xml:"STATUS"`
+			Statement struct {
+				Currency string `xml:"CURDEF"`
+				Account  struct {
+					BankID   string `xml:"BANKID"`
+					AcctID   string `xml:"ACCTID"`
+					AcctType string `xml:"ACCTTYPE"`
+				} `xml:"BANKACCTFROM"`
+				List struct {
+					Start        string           `xml:"DTSTART"`
+					End          string           `xml:"DTEND"`
+					Transactions []ofxTransaction `xml:"STMTTRN"`
+				} `xml:"BANKTRANLIST"`
+				Ledger ofxBalance `xml:"LEDGERBAL"`
+			} `xml:"STMTRS"`
+		} `xml:"STMTTRNRS"`
+	} `xml:"BANKMSGSRSV1"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FITID  string `xml:"FITID"`
+	Name   string `xml:"NAME,omitempty"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
+
+// writeStatementOFX exporta no OFX 2.2 (XML) com uma STMTTRN por movimentação
+func writeStatementOFX(w io.Writer, st *Statement) error {
+	var doc ofxDocument
+	doc.SignOn.Response.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	doc.SignOn.Response.DTServer = ofxTime(st.GeneratedAt)
+	doc.SignOn.Response.Language = "POR"
+
+	trn := &doc.Bank.Transaction
+	trn.TrnUID = st.ID.String()
+	trn.Status = ofxStatus{Code: 0, Severity: "INFO"}
+	trn.Statement.Currency = st.Currency
+	trn.Statement.Account.BankID = ofxBankID
+	trn.Statement.Account.AcctID = st.Account
+	trn.Statement.Account.AcctType = "CHECKING"
+	trn.Statement.List.Start = ofxTime(st.PeriodStart)
+	trn.Statement.List.End = ofxTime(st.PeriodEnd)
+	for _, line := range st.Lines {
+		trn.Statement.List.Transactions = append(trn.Statement.List.Transactions, ofxTransaction{
+			Type:   ofxTransactionType(line),
+			Posted: ofxTime(line.Date),
+			Amount: line.Amount.StringFixed(2),
+			FITID:  line.FITID,
+			Name:   ofxText(line.Counterparty, 32),
+			Memo:   ofxText(line.Description, 255),
+		})
+	}
+	trn.Statement.Ledger = ofxBalance{Amount: st.ClosingBalance.StringFixed(2), AsOf: ofxTime(st.PeriodEnd)}
+
+	if _, err := io.WriteString(w, xml.Header+
+		`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func ofxTransactionType(line StatementLine) string {
+	switch {
+	case line.Type == TransactionTypeFee && line.Amount.IsNegative(), line.Type == TransactionTypeCreditFee:
+		return "FEE"
+	case line.Type == TransactionTypeInterest, line.Type == TransactionTypeCreditInterest:
+		return "INT"
+	case line.Type == TransactionTypeDeposit:
+		return "DEP"
+	case line.Type == TransactionTypeTransfer:
+		return "XFER"
+	case line.Amount.IsNegative():
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+// ofxTime data no formato OFX (AAAAMMDDHHMMSS.XXX[gmt offset:tz name]) em UTC
+func ofxTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+func ofxText(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > max {
+		return string(r[:max])
+	}
+	return s
+}
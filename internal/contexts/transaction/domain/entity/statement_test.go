@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completedTx(userID uuid.UUID, txType TransactionType, amount int64, from, to string) *Transaction {
+	tx := NewTransaction(userID, txType, decimal.NewFromInt(amount))
+	tx.FromAddress = from
+	tx.ToAddress = to
+	tx.Complete("")
+	return tx
+}
+
+func TestStatementLines_Directions(t *testing.T) {
+	user := uuid.New()
+
+	deposit := completedTx(user, TransactionTypeDeposit, 10, PixAddressPrefix+"E123", "")
+	lines := StatementLines(deposit, user, "W")
+	require.Len(t, lines, 1)
+	assert.True(t, lines[0].Amount.Equal(decimal.NewFromInt(10)))
+	assert.Equal(t, StatementChannelPix, lines[0].Channel)
+
+	withdraw := completedTx(user, TransactionTypeWithdraw, 5, "W", "0xabc")
+	withdraw.Fee = decimal.RequireFromString("0.5")
+	lines = StatementLines(withdraw, user, "W")
+	require.Len(t, lines, 2, "a taxa vira uma linha própria")
+	assert.True(t, lines[0].Amount.Equal(decimal.NewFromInt(-5)))
+	assert.Equal(t, TransactionTypeFee, lines[1].Type)
+	assert.True(t, lines[1].Amount.Equal(decimal.RequireFromString("-0.5")))
+	assert.Equal(t, StatementChannelOnChain, lines[0].Channel)
+
+	incoming := completedTx(uuid.New(), TransactionTypeTransfer, 7, "OTHER", "W")
+	lines = StatementLines(incoming, user, "W")
+	require.Len(t, lines, 1)
+	assert.True(t, lines[0].Amount.Equal(decimal.NewFromInt(7)))
+	assert.Equal(t, "Transferência recebida", lines[0].Description)
+
+	fee := completedTx(user, TransactionTypeFee, 1, "W", "REVENUE")
+	assert.Empty(t, StatementLines(fee, user, "W"), "o débito da taxa já consta na transação de origem")
+	assert.Len(t, StatementLines(fee, uuid.New(), "REVENUE"), 1)
+
+	sold := completedTx(user, TransactionTypeConversion, 4, "W", "fx:q1")
+	lines = StatementLines(sold, user, "W")
+	require.Len(t, lines, 1)
+	assert.True(t, lines[0].Amount.Equal(decimal.NewFromInt(-4)))
+	assert.Equal(t, "Câmbio - saída", lines[0].Description)
+
+	failed := NewTransaction(user, TransactionTypeWithdraw, decimal.NewFromInt(3))
+	failed.FromAddress = "W"
+	failed.Fail("x")
+	assert.Empty(t, StatementLines(failed, user, "W"))
+}
+
+func testStatement() *Statement {
+	user := uuid.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	deposit := completedTx(user, TransactionTypeDeposit, 50, "", "")
+	deposit.CreatedAt = from.Add(time.Hour)
+	withdraw := completedTx(user, TransactionTypeWithdraw, 20, "W", "0x<&>")
+	withdraw.CreatedAt = from.Add(2 * time.Hour)
+
+	var lines []StatementLine
+	lines = append(lines, StatementLines(deposit, user, "W")...)
+	lines = append(lines, StatementLines(withdraw, user, "W")...)
+	return NewStatement(user, "W", "BRL", from, from.AddDate(0, 1, 0), decimal.NewFromInt(100), lines, from.AddDate(0, 1, 1))
+}
+
+func TestNewStatement_Totals(t *testing.T) {
+	st := testStatement()
+	assert.True(t, st.ClosingBalance.Equal(decimal.NewFromInt(130)))
+	assert.True(t, st.TotalCredits.Equal(decimal.NewFromInt(50)))
+	assert.True(t, st.TotalDebits.Equal(decimal.NewFromInt(20)))
+	assert.Equal(t, 2, st.LineCount)
+	assert.True(t, st.Lines[0].Balance.Equal(decimal.NewFromInt(150)))
+}
+
+func TestWriteStatement_CSVAndOFX(t *testing.T) {
+	st := testStatement()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatement(&buf, StatementFormatCSV, st))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5, "cabeçalho, saldo inicial, 2 movimentações e saldo final")
+	assert.Equal(t, "100", records[1][8])
+	assert.Equal(t, "-20", records[3][7])
+	assert.Equal(t, "130", records[4][8])
+
+	buf.Reset()
+	require.NoError(t, WriteStatement(&buf, StatementFormatOFX, st))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, out, `<?OFX OFXHEADER="200" VERSION="220"`)
+	assert.Contains(t, out, "<DTSTART>20250301000000.000[0:GMT]</DTSTART>")
+
+	var doc ofxDocument
+	require.NoError(t, xml.Unmarshal([]byte(out[strings.Index(out, "<OFX>"):]), &doc))
+	trns := doc.Bank.Transaction.Statement.List.Transactions
+	require.Len(t, trns, 2)
+	assert.Equal(t, "DEP", trns[0].Type)
+	assert.Equal(t, "-20.00", trns[1].Amount)
+	assert.Equal(t, "0x<&>", trns[1].Name, "texto é escapado no XML")
+	assert.Equal(t, "130.00", doc.Bank.Transaction.Statement.Ledger.Amount)
+
+	assert.Error(t, WriteStatement(&buf, "pdf", st))
+}
@@ -14,6 +14,28 @@ const (
 	TransactionTypeDeposit  TransactionType = "deposit"
 	TransactionTypeWithdraw TransactionType = "withdraw"
 	TransactionTypeTransfer TransactionType = "transfer"
+	// TransactionTypeFee taxa cobrada sobre outra transação (ParentID), creditada na carteira de receita
+	TransactionTypeFee TransactionType = "fee"
+	// TransactionTypeReversal estorno total ou parcial de outra transação (ParentID)
+	TransactionTypeReversal TransactionType = "reversal"
+	// TransactionTypeEscrowFund depósito do comprador em custódia
+	TransactionTypeEscrowFund TransactionType = "escrow_fund"
+	// TransactionTypeEscrowPayout pagamento da custódia ao vendedor ou devolução ao comprador (ParentID = depósito)
+	TransactionTypeEscrowPayout TransactionType = "escrow_payout"
+	// TransactionTypeInterest rendimento capitalizado na carteira (bruto)
+	TransactionTypeInterest TransactionType = "interest"
+	// TransactionTypeWithholdingTax imposto retido na fonte sobre o rendimento (ParentID = rendimento)
+	TransactionTypeWithholdingTax TransactionType = "withholding_tax"
+	// TransactionTypeCreditInterest pagamento de juros do cheque especial (ParentID = depósito, se houver)
+	TransactionTypeCreditInterest TransactionType = "credit_interest"
+	// TransactionTypeCreditFee pagamento de tarifas e multas do cheque especial (ParentID = depósito, se houver)
+	TransactionTypeCreditFee TransactionType = "credit_fee"
+	// TransactionTypeAccountFunding aporte da carteira numa conta nomeada (ToAddress = account:<id>)
+	TransactionTypeAccountFunding TransactionType = "account_funding"
+	// TransactionTypeAccountWithdrawal resgate de uma conta nomeada para a carteira (FromAddress = account:<id>)
+	TransactionTypeAccountWithdrawal TransactionType = "account_withdrawal"
+	// TransactionTypeConversion perna em moeda base de uma conversão de câmbio (contraparte fx:<cotação>)
+	TransactionTypeConversion TransactionType = "fx_conversion"
 )
 
 // TransactionStatus define os status de transação
@@ -23,6 +45,8 @@ const (
 	TransactionStatusPending   TransactionStatus = "pending"
 	TransactionStatusCompleted TransactionStatus = "completed"
 	TransactionStatusFailed    TransactionStatus = "failed"
+	// TransactionStatusAwaitingApproval saque retido (valor debitado) até a aprovação dos operadores
+	TransactionStatusAwaitingApproval TransactionStatus = "awaiting_approval"
 )
 
 // Transaction representa uma transação financeira
@@ -40,6 +64,10 @@ type Transaction struct {
 	Type            TransactionType
 	ID              uuid.UUID
 	UserID          uuid.UUID
+	RiskScore       int             // score 0-100 atribuído pelo monitoramento de fraude/AML antes da execução
+	Fee             decimal.Decimal // taxa total cobrada além de Amount (plataforma + rede)
+	ParentID        *uuid.UUID      // transação de origem, para transações derivadas (ex.: taxa)
+	Chain           string          // rede de saques e depósitos externos (ethereum, pix, ...); vazio nas internas
 }
 
 // NewTransaction cria uma nova transação
@@ -48,6 +48,14 @@ func NewTransactionAggregate(userID uuid.UUID, txType TransactionType, amount de
 	return agg, nil
 }
 
+// RestoreTransactionAggregate reconstrói o agregado a partir de uma transação persistida
+func RestoreTransactionAggregate(tx *Transaction) *TransactionAggregate {
+	return &TransactionAggregate{
+		transaction:  tx,
+		domainEvents: make([]interface{}, 0),
+	}
+}
+
 // Transaction retorna a entidade Transaction
 func (a *TransactionAggregate) Transaction() *Transaction {
 	return a.transaction
@@ -152,6 +160,68 @@ func (a *TransactionAggregate) Fail(reason, errorCode string) error {
 	return nil
 }
 
+// AwaitApproval retém a transação pendente até a aprovação da solicitação informada
+func (a *TransactionAggregate) AwaitApproval(approvalID uuid.UUID, requiredApprovals int, expiresAt time.Time) error {
+	if a.transaction.Status != TransactionStatusPending {
+		return errors.New("only pending transactions can await approval")
+	}
+
+	a.transaction.Status = TransactionStatusAwaitingApproval
+	a.transaction.UpdatedAt = time.Now()
+
+	event := events.NewTransactionAwaitingApproval(a.transaction.ID, approvalID, requiredApprovals, expiresAt)
+	a.domainEvents = append(a.domainEvents, event)
+
+	return nil
+}
+
+// Approve libera a transação retida para execução
+func (a *TransactionAggregate) Approve(approvalID uuid.UUID) error {
+	if a.transaction.Status != TransactionStatusAwaitingApproval {
+		return errors.New("transaction is not awaiting approval")
+	}
+
+	a.transaction.Status = TransactionStatusPending
+	a.transaction.UpdatedAt = time.Now()
+
+	event := events.NewTransactionApproved(a.transaction.ID, approvalID)
+	a.domainEvents = append(a.domainEvents, event)
+
+	return nil
+}
+
+// Reverse valida o estorno de amount sobre a transação concluída, considerando o total já estornado
+func (a *TransactionAggregate) Reverse(reversalID uuid.UUID, amount, alreadyReversed decimal.Decimal, reason ReversalReason) error {
+	if a.transaction.Status != TransactionStatusCompleted {
+		return ErrNotReversible
+	}
+	reversible := ReversibleAmount(a.transaction)
+	if !reversible.IsPositive() {
+		return ErrNotReversible
+	}
+	if !reason.Valid() {
+		return ErrInvalidReversalReason
+	}
+	if !amount.IsPositive() {
+		return errors.New("reversal amount must be positive")
+	}
+	total := alreadyReversed.Add(amount)
+	if total.GreaterThan(reversible) {
+		return ErrReversalExceedsOriginal
+	}
+
+	// A transação original permanece concluída; o estorno é uma nova transação vinculada
+	event := events.NewTransactionReversed(a.transaction.ID, reversalID, amount.InexactFloat64(), total.InexactFloat64(), string(reason), total.Equal(reversible))
+	a.domainEvents = append(a.domainEvents, event)
+
+	return nil
+}
+
+// IsAwaitingApproval verifica se a transação está retida para aprovação
+func (a *TransactionAggregate) IsAwaitingApproval() bool {
+	return a.transaction.Status == TransactionStatusAwaitingApproval
+}
+
 // IsPending verifica se a transação está pendente
 func (a *TransactionAggregate) IsPending() bool {
 	return a.transaction.Status == TransactionStatusPending
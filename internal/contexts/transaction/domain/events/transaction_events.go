@@ -82,3 +82,58 @@ func NewTransactionFailed(txID uuid.UUID, reason, errorCode string) *Transaction
 		ErrorCode:       errorCode,
 	}
 }
+
+// TransactionAwaitingApproval evento disparado quando o saque fica retido para aprovação
+type TransactionAwaitingApproval struct {
+	events.BaseDomainEvent
+	ApprovalID        uuid.UUID
+	RequiredApprovals int
+	ExpiresAt         time.Time
+}
+
+func NewTransactionAwaitingApproval(txID, approvalID uuid.UUID, requiredApprovals int, expiresAt time.Time) *TransactionAwaitingApproval {
+	return &TransactionAwaitingApproval{
+		BaseDomainEvent:   events.NewBaseDomainEvent("TransactionAwaitingApproval", txID),
+		ApprovalID:        approvalID,
+		RequiredApprovals: requiredApprovals,
+		ExpiresAt:         expiresAt,
+	}
+}
+
+// TransactionApproved evento disparado quando o quórum de aprovação é atingido
+type TransactionApproved struct {
+	events.BaseDomainEvent
+	ApprovalID uuid.UUID
+	ApprovedAt time.Time
+}
+
+func NewTransactionApproved(txID, approvalID uuid.UUID) *TransactionApproved {
+	return &TransactionApproved{
+		BaseDomainEvent: events.NewBaseDomainEvent("TransactionApproved", txID),
+		ApprovalID:      approvalID,
+		ApprovedAt:      time.Now(),
+	}
+}
+
+// TransactionReversed evento disparado quando a transação concluída é estornada (total ou parcialmente)
+type TransactionReversed struct {
+	events.BaseDomainEvent
+	ReversalID    uuid.UUID
+	Amount        float64
+	TotalReversed float64
+	Reason        string
+	Full          bool
+	ReversedAt    time.Time
+}
+
+func NewTransactionReversed(txID, reversalID uuid.UUID, amount, totalReversed float64, reason string, full bool) *TransactionReversed {
+	return &TransactionReversed{
+		BaseDomainEvent: events.NewBaseDomainEvent("TransactionReversed", txID),
+		ReversalID:      reversalID,
+		Amount:          amount,
+		TotalReversed:   totalReversed,
+		Reason:          reason,
+		Full:            full,
+		ReversedAt:      time.Now(),
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalRepository persiste as solicitações de aprovação de saques e sua trilha de auditoria
+type ApprovalRepository interface {
+	// Create grava a solicitação com os registros iniciais da trilha
+	Create(ctx context.Context, req *entity.ApprovalRequest) error
+	// FindByID retorna nil, nil quando a solicitação não existe; inclui a trilha
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ApprovalRequest, error)
+	// List lista solicitações por status (vazio = todas), mais recentes primeiro
+	List(ctx context.Context, status entity.ApprovalStatus, limit int) ([]*entity.ApprovalRequest, error)
+	// ListExpired lista solicitações pendentes com prazo vencido
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.ApprovalRequest, error)
+	// AppendAction acrescenta um registro à trilha (nunca altera registros anteriores)
+	AppendAction(ctx context.Context, requestID uuid.UUID, action entity.ApprovalAction) error
+	// Resolve grava o estado final apenas se a solicitação ainda estiver pendente;
+	// retorna false quando outra decisão chegou antes
+	Resolve(ctx context.Context, req *entity.ApprovalRequest) (bool, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditRepository persiste as linhas de crédito (cheque especial), os encargos lançados e os
+// pagamentos alocados
+type CreditRepository interface {
+	// CreateLine grava a linha; retorna false se o usuário já tem linha não encerrada
+	CreateLine(ctx context.Context, line *entity.CreditLine) (bool, error)
+	// UpdateLine grava o estado com controle otimista por versão; em conflito retorna
+	// ErrCreditLineConflict
+	UpdateLine(ctx context.Context, line *entity.CreditLine) error
+	// FindLineByUser retorna a linha não encerrada do usuário ou nil, nil
+	FindLineByUser(ctx context.Context, userID uuid.UUID) (*entity.CreditLine, error)
+	ListOpenLines(ctx context.Context) ([]*entity.CreditLine, error)
+
+	SaveCharges(ctx context.Context, charges []*entity.CreditCharge) error
+	// ListCharges lista os encargos da linha, mais recentes primeiro
+	ListCharges(ctx context.Context, lineID uuid.UUID, limit int) ([]*entity.CreditCharge, error)
+	SaveRepayment(ctx context.Context, repayment *entity.CreditRepayment) error
+	// ListRepayments lista os pagamentos da linha, mais recentes primeiro
+	ListRepayments(ctx context.Context, lineID uuid.UUID, limit int) ([]*entity.CreditRepayment, error)
+
+	SaveRun(ctx context.Context, run *entity.CreditAccrualRun) error
+	// FindRun retorna nil, nil quando o dia ainda não foi apropriado
+	FindRun(ctx context.Context, runDate time.Time) (*entity.CreditAccrualRun, error)
+}
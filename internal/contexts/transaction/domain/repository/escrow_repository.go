@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EscrowRepository persiste as custódias entre comprador e vendedor
+type EscrowRepository interface {
+	Create(ctx context.Context, e *entity.Escrow) error
+	// FindByID retorna nil, nil quando a custódia não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Escrow, error)
+	// ListByParty lista as custódias em que o usuário é comprador, vendedor ou árbitro, mais recentes primeiro
+	ListByParty(ctx context.Context, userID uuid.UUID) ([]*entity.Escrow, error)
+	// ListByStatus lista as custódias no estado informado, mais antigas primeiro
+	ListByStatus(ctx context.Context, status entity.EscrowStatus, limit int) ([]*entity.Escrow, error)
+	// ListExpired lista custódias aguardando depósito ou depositadas com prazo vencido até now
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.Escrow, error)
+	// ListPendingPayouts lista custódias encerradas até before com pagamento ou devolução por creditar
+	ListPendingPayouts(ctx context.Context, before time.Time, limit int) ([]*entity.Escrow, error)
+	// SetPayoutTx registra as transações de pagamento (releaseTxID) e devolução (refundTxID) creditadas;
+	// nil mantém o valor gravado. Não altera Version.
+	SetPayoutTx(ctx context.Context, id uuid.UUID, releaseTxID, refundTxID *uuid.UUID) error
+	// Update grava o estado se Version não mudou desde a leitura (incrementando-a);
+	// caso contrário retorna ErrEscrowConflict
+	Update(ctx context.Context, e *entity.Escrow) error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InterestRepository persiste os tipos de conta remunerada, as contas, os rendimentos diários e as
+// capitalizações
+type InterestRepository interface {
+	// SaveType cria ou substitui o tipo pelo código
+	SaveType(ctx context.Context, t *entity.InterestAccountType) error
+	// FindType retorna nil, nil quando o tipo não existe
+	FindType(ctx context.Context, code string) (*entity.InterestAccountType, error)
+	ListTypes(ctx context.Context) ([]*entity.InterestAccountType, error)
+
+	// CreateAccount grava a conta; retorna false se o usuário já tem conta ativa
+	CreateAccount(ctx context.Context, account *entity.InterestAccount) (bool, error)
+	UpdateAccount(ctx context.Context, account *entity.InterestAccount) error
+	// FindAccountByUser retorna a conta ativa do usuário ou nil, nil
+	FindAccountByUser(ctx context.Context, userID uuid.UUID) (*entity.InterestAccount, error)
+	ListActiveAccounts(ctx context.Context) ([]*entity.InterestAccount, error)
+
+	// SaveAccrual grava o rendimento do dia e o soma ao pendente da conta; retorna false se a conta
+	// já tem rendimento na data
+	SaveAccrual(ctx context.Context, accrual *entity.InterestAccrual) (bool, error)
+	// MarkAccrued registra a data como apropriada na conta, mesmo sem rendimento no dia
+	MarkAccrued(ctx context.Context, accountID uuid.UUID, day time.Time) error
+	// ListAccruals lista os rendimentos da conta com data em [from, to], em ordem cronológica
+	ListAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*entity.InterestAccrual, error)
+	// UnpaidAccruals lista os rendimentos ainda não capitalizados com data até through
+	UnpaidAccruals(ctx context.Context, accountID uuid.UUID, through time.Time) ([]*entity.InterestAccrual, error)
+
+	// SaveCapitalization grava a capitalização, vincula os rendimentos e desconta o bruto do
+	// pendente da conta; retorna false se a conta já tem capitalização no período
+	SaveCapitalization(ctx context.Context, c *entity.InterestCapitalization, accrualIDs []uuid.UUID) (bool, error)
+	// DeleteCapitalization desfaz a capitalização: desvincula os rendimentos, devolve o bruto ao
+	// pendente da conta e apaga o registro
+	DeleteCapitalization(ctx context.Context, c *entity.InterestCapitalization) error
+	// SetCapitalizationTransactions registra as transações de rendimento e de retenção
+	SetCapitalizationTransactions(ctx context.Context, id uuid.UUID, interestTxID uuid.UUID, taxTxID *uuid.UUID) error
+	ListCapitalizations(ctx context.Context, accountID uuid.UUID) ([]*entity.InterestCapitalization, error)
+
+	SaveRun(ctx context.Context, run *entity.InterestAccrualRun) error
+	// FindRun retorna nil, nil quando o dia ainda não foi apropriado
+	FindRun(ctx context.Context, runDate time.Time) (*entity.InterestAccrualRun, error)
+}
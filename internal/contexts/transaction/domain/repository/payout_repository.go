@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayoutRepository persiste os lotes de pagamentos e o resultado de cada item
+type PayoutRepository interface {
+	// CreateBatch grava o lote e seus itens atomicamente
+	CreateBatch(ctx context.Context, batch *entity.PayoutBatch, items []*entity.PayoutItem) error
+	// FindBatch retorna nil, nil quando o lote não existe
+	FindBatch(ctx context.Context, id uuid.UUID) (*entity.PayoutBatch, error)
+	// ListBatches lista os lotes do usuário, mais recentes primeiro
+	ListBatches(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PayoutBatch, error)
+	// ListItems lista os itens do lote na ordem do arquivo
+	ListItems(ctx context.Context, batchID uuid.UUID) ([]*entity.PayoutItem, error)
+	// FindItem retorna nil, nil quando o item não existe
+	FindItem(ctx context.Context, id uuid.UUID) (*entity.PayoutItem, error)
+	// ConfirmBatch move o lote de draft para processing e seus itens para queued;
+	// retorna ErrPayoutBatchState se o lote não estava em draft
+	ConfirmBatch(ctx context.Context, id uuid.UUID, now time.Time) error
+	// CancelBatch cancela o lote ainda em draft; retorna ErrPayoutBatchState caso contrário
+	CancelBatch(ctx context.Context, id uuid.UUID, now time.Time) error
+	// ListQueuedItems lista itens aguardando execução em lotes em processamento, mais antigos primeiro
+	ListQueuedItems(ctx context.Context, limit int) ([]*entity.PayoutItem, error)
+	// ClaimItem move o item de queued para processing; false se outro worker já o assumiu
+	ClaimItem(ctx context.Context, id uuid.UUID) (bool, error)
+	// FinishItem grava o resultado do item em processamento
+	FinishItem(ctx context.Context, item *entity.PayoutItem) error
+	// FinalizeBatch encerra o lote em processing sem itens pendentes, gravando as contagens;
+	// retorna nil, nil enquanto houver itens pendentes ou se outro worker já o encerrou
+	FinalizeBatch(ctx context.Context, id uuid.UUID, now time.Time) (*entity.PayoutBatch, error)
+}
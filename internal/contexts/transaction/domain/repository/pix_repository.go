@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PixRepository persiste cobranças, créditos recebidos e pagamentos Pix
+type PixRepository interface {
+	CreateCharge(ctx context.Context, c *entity.PixCharge) error
+	// FindCharge retorna nil, nil quando a cobrança não existe
+	FindCharge(ctx context.Context, id uuid.UUID) (*entity.PixCharge, error)
+	// FindChargeByTxID retorna nil, nil quando não há cobrança com o txid
+	FindChargeByTxID(ctx context.Context, txid string) (*entity.PixCharge, error)
+	// ListCharges lista as cobranças do usuário, mais recentes primeiro
+	ListCharges(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixCharge, error)
+	// MarkChargePaid liquida a cobrança dinâmica ativa; false se já foi paga ou expirou
+	MarkChargePaid(ctx context.Context, id uuid.UUID, paidAt time.Time) (bool, error)
+	// ExpireCharges expira as cobranças ativas vencidas até now e retorna quantas
+	ExpireCharges(ctx context.Context, now time.Time) (int, error)
+
+	// CreateCredit registra o crédito; retorna ErrPixCreditDuplicate se o EndToEndID já foi recebido
+	CreateCredit(ctx context.Context, c *entity.PixCredit) error
+	UpdateCredit(ctx context.Context, c *entity.PixCredit) error
+	// ListCredits lista os créditos no estado informado, mais recentes primeiro
+	ListCredits(ctx context.Context, status entity.PixCreditStatus, limit int) ([]*entity.PixCredit, error)
+
+	CreatePayment(ctx context.Context, p *entity.PixPayment) error
+	UpdatePayment(ctx context.Context, p *entity.PixPayment) error
+	// ListPayments lista os pagamentos do usuário, mais recentes primeiro
+	ListPayments(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixPayment, error)
+	// ListPendingPayments lista os pagamentos aguardando envio, mais antigos primeiro
+	ListPendingPayments(ctx context.Context, limit int) ([]*entity.PixPayment, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationRepository lê os dois lados da conciliação e persiste os relatórios
+type ReconciliationRepository interface {
+	// SumLiabilities soma os saldos dos usuários no ativo
+	SumLiabilities(ctx context.Context, asset string) (decimal.Decimal, error)
+	// ListChainTransactions lista as transações internas de depósito e saque da rede no intervalo
+	ListChainTransactions(ctx context.Context, chain string, from, to time.Time) ([]*entity.Transaction, error)
+	// ListOnChainTransfers lista as transações registradas on-chain na rede no intervalo
+	ListOnChainTransfers(ctx context.Context, chain string, from, to time.Time) ([]entity.OnChainTransfer, error)
+	Save(ctx context.Context, run *entity.ReconciliationRun) error
+	Find(ctx context.Context, id uuid.UUID) (*entity.ReconciliationRun, error)
+	FindByDate(ctx context.Context, runDate time.Time) (*entity.ReconciliationRun, error)
+	List(ctx context.Context, limit int) ([]*entity.ReconciliationRun, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ReservesRepository lê os saldos dos usuários e persiste os snapshots da prova de reservas
+type ReservesRepository interface {
+	// ListAccountBalances lista os saldos positivos de todos os usuários em todos os ativos
+	ListAccountBalances(ctx context.Context) ([]entity.AccountBalance, error)
+	Save(ctx context.Context, snapshot *entity.ReservesSnapshot, leaves []entity.ReservesLeaf) error
+	Find(ctx context.Context, id uuid.UUID) (*entity.ReservesSnapshot, error)
+	Latest(ctx context.Context) (*entity.ReservesSnapshot, error)
+	List(ctx context.Context, limit int) ([]*entity.ReservesSnapshot, error)
+	// ListLeaves lista as folhas do ativo no snapshot na ordem da árvore
+	ListLeaves(ctx context.Context, snapshotID uuid.UUID, asset string) ([]entity.ReservesLeaf, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReversalRepository persiste os estornos de transações
+type ReversalRepository interface {
+	// Create grava o estorno se a soma dos estornos não falhos da transação original, somada ao novo,
+	// não ultrapassar limit; caso contrário retorna ErrReversalExceedsOriginal. A verificação é
+	// atômica para que estornos simultâneos não excedam o original.
+	Create(ctx context.Context, r *entity.Reversal, limit decimal.Decimal) error
+	// ListByOriginal lista os estornos da transação, mais antigos primeiro
+	ListByOriginal(ctx context.Context, originalID uuid.UUID) ([]*entity.Reversal, error)
+	// TotalReversed soma os estornos cuja transação de estorno não falhou
+	TotalReversed(ctx context.Context, originalID uuid.UUID) (decimal.Decimal, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleRepository persiste os agendamentos de transferências e saques e seu histórico de execuções
+type ScheduleRepository interface {
+	Create(ctx context.Context, s *entity.ScheduledTransfer) error
+	// FindByID retorna nil, nil quando o agendamento não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ScheduledTransfer, error)
+	// ListByUser lista os agendamentos do usuário, mais recentes primeiro
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.ScheduledTransfer, error)
+	// ListDue lista agendamentos ativos cuja execução (nova tentativa ou ocorrência) venceu até now
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ScheduledTransfer, error)
+	// Update grava o estado se Version não mudou desde a leitura (incrementando-a);
+	// caso contrário retorna ErrScheduleConflict
+	Update(ctx context.Context, s *entity.ScheduledTransfer) error
+	// StartExecution registra a tentativa; retorna ErrExecutionDuplicate se a mesma tentativa
+	// da ocorrência já foi iniciada (garante execução única mesmo com tarefas duplicadas)
+	StartExecution(ctx context.Context, exec *entity.ScheduleExecution) error
+	// FinishExecution grava o resultado da tentativa
+	FinishExecution(ctx context.Context, exec *entity.ScheduleExecution) error
+	// ListExecutions lista as tentativas do agendamento, mais recentes primeiro
+	ListExecutions(ctx context.Context, scheduleID uuid.UUID, limit int) ([]*entity.ScheduleExecution, error)
+}
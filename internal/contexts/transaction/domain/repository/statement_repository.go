@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementRepository consulta as movimentações da carteira e guarda os extratos mensais gerados
+type StatementRepository interface {
+	// ListMovements lista as transações que movimentaram a carteira (do usuário ou com o endereço
+	// como origem ou destino) criadas em [from, to), em ordem cronológica
+	ListMovements(ctx context.Context, userID uuid.UUID, address string, from, to time.Time) ([]*entity.Transaction, error)
+	// ListPendingAccounts lista os usuários com carteira que ainda não têm extrato do período
+	ListPendingAccounts(ctx context.Context, from, to time.Time, limit int) ([]uuid.UUID, error)
+
+	// Save grava o extrato; um novo extrato do mesmo usuário e período substitui o anterior
+	Save(ctx context.Context, st *entity.Statement) error
+	// Find retorna nil, nil quando o extrato não existe
+	Find(ctx context.Context, id uuid.UUID) (*entity.Statement, error)
+	// List lista os extratos do usuário sem as linhas, mais recentes primeiro
+	List(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Statement, error)
+}
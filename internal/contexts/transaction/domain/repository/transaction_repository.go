@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // TransactionRepository define as operações de persistência para Transaction
@@ -15,4 +17,10 @@ type TransactionRepository interface {
 	FindByHash(ctx context.Context, hash string) (*entity.Transaction, error)
 	Update(ctx context.Context, tx *entity.Transaction) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TransactionStatus) error
+	// SumAmountSince soma o valor das transações não falhas do usuário com os tipos informados,
+	// criadas a partir de since
+	SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error)
+	// FindUncollectedFees lista as transações concluídas antes de before com taxa e sem cobrança
+	// (type fee com parent_id) registrada ou em andamento, mais antigas primeiro
+	FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error)
 }
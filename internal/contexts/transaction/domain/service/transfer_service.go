@@ -5,6 +5,7 @@ import (
 	"errors"
 	"financial-system-pro/internal/contexts/transaction/domain/entity"
 	userentity "financial-system-pro/internal/contexts/user/domain/entity"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -13,12 +14,18 @@ import (
 // TransferService coordena transferências entre wallets de usuários
 // Domain Service para lógica cross-aggregate
 type TransferService struct {
-	// Dependências serão injetadas
+	fees entity.FeeSchedule
 }
 
-// NewTransferService cria uma nova instância do serviço
+// NewTransferService cria uma nova instância do serviço com a tabela de taxas padrão
 func NewTransferService() *TransferService {
-	return &TransferService{}
+	return &TransferService{fees: entity.DefaultFeeSchedule()}
+}
+
+// WithFeeSchedule substitui a tabela de taxas usada em CalculateFee
+func (s *TransferService) WithFeeSchedule(schedule entity.FeeSchedule) *TransferService {
+	s.fees = schedule
+	return s
 }
 
 // TransferRequest representa uma solicitação de transferência
@@ -94,7 +101,7 @@ func (s *TransferService) ExecuteTransfer(
 		fromAggregate.User().ID,
 		entity.TransactionTypeTransfer,
 		amount,
-		"USD",
+		string(sharedVO.BaseCurrency),
 		"internal",
 		toAggregate.User().ID.String(),
 	)
@@ -112,16 +119,14 @@ func (s *TransferService) ExecuteTransfer(
 	return txAgg, nil
 }
 
-// CalculateFee calcula a taxa de transferência
+// CalculateFee calcula a taxa de transferência pela tabela de taxas; transferType é a rede
+// ("internal" para transferências entre usuários)
 func (s *TransferService) CalculateFee(amount decimal.Decimal, transferType string) decimal.Decimal {
-	// Transferências internas não têm taxa
-	if transferType == "internal" {
-		return decimal.Zero
-	}
-
-	// Taxa de 1% para outras transferências
-	feePercentage := decimal.NewFromFloat(0.01)
-	return amount.Mul(feePercentage)
+	return s.fees.Calculate(entity.FeeInput{
+		Type:   entity.TransactionTypeTransfer,
+		Chain:  transferType,
+		Amount: amount,
+	})
 }
 
 // ValidateBalance verifica se o usuário tem saldo suficiente incluindo taxa
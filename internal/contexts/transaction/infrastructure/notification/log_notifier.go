@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"go.uber.org/zap"
+)
+
+// LogScheduleNotifier implementa ScheduleNotifier apenas registrando em log.
+// Usado até existir um provedor de email/push.
+type LogScheduleNotifier struct {
+	logger *zap.Logger
+}
+
+// NewLogScheduleNotifier cria o notificador de agendamentos baseado em log
+func NewLogScheduleNotifier(logger *zap.Logger) *LogScheduleNotifier {
+	return &LogScheduleNotifier{logger: logger}
+}
+
+// NotifyExecutionFailed registra o aviso de falha da execução agendada
+func (n *LogScheduleNotifier) NotifyExecutionFailed(ctx context.Context, s *entity.ScheduledTransfer, exec *entity.ScheduleExecution, retryAt *time.Time) error {
+	n.logger.Info("scheduled transfer failure notice issued",
+		zap.String("user_id", s.UserID.String()),
+		zap.String("schedule_id", s.ID.String()),
+		zap.String("amount", s.Amount.String()),
+		zap.Time("occurrence", exec.Occurrence),
+		zap.String("reason", exec.Error),
+		zap.Timep("retry_at", retryAt),
+	)
+	return nil
+}
@@ -0,0 +1,244 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresApprovalRepository implementa ApprovalRepository usando PostgreSQL
+type PostgresApprovalRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresApprovalRepository cria um novo repositório de aprovações de saque
+func NewPostgresApprovalRepository(conn database.Connection) *PostgresApprovalRepository {
+	return &PostgresApprovalRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const approvalColumns = `id, transaction_id, requester_id, policy_id, amount, currency, chain, to_address,
+	required_approvals, approver_roles, status, created_at, expires_at, resolved_at`
+
+type execer interface {
+	Exec(ctx context.Context, query string, args ...interface{}) (database.Result, error)
+}
+
+// Create grava a solicitação e a trilha inicial na mesma transação
+func (r *PostgresApprovalRepository) Create(ctx context.Context, req *entity.ApprovalRequest) error {
+	roles, err := json.Marshal(nonNilRoles(req.ApproverRoles))
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.approval_requests (`+approvalColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::jsonb, $11, $12, $13, $14)
+	`,
+		req.ID,
+		req.TransactionID,
+		req.RequesterID,
+		req.PolicyID,
+		req.Amount,
+		req.Currency,
+		req.Chain,
+		req.ToAddress,
+		req.RequiredApprovals,
+		string(roles),
+		string(req.Status),
+		req.CreatedAt,
+		req.ExpiresAt,
+		req.ResolvedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range req.Trail {
+		if err := r.insertAction(ctx, tx, req.ID, a); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// FindByID busca a solicitação com a trilha em ordem cronológica
+func (r *PostgresApprovalRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ApprovalRequest, error) {
+	query := `SELECT ` + approvalColumns + ` FROM ` + r.schema + `.approval_requests WHERE id = $1`
+
+	req, err := scanApproval(r.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rows, err := r.conn.Query(ctx, `
+		SELECT id, action, actor_id, roles, comment, created_at
+		FROM `+r.schema+`.approval_actions
+		WHERE request_id = $1
+		ORDER BY created_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			a       entity.ApprovalAction
+			action  string
+			actorID uuid.NullUUID
+			roles   []byte
+		)
+		if err := rows.Scan(&a.ID, &action, &actorID, &roles, &a.Comment, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Action = entity.ApprovalActionType(action)
+		if actorID.Valid {
+			a.ActorID = &actorID.UUID
+		}
+		if err := json.Unmarshal(roles, &a.Roles); err != nil {
+			return nil, err
+		}
+		req.Trail = append(req.Trail, a)
+	}
+
+	return req, rows.Err()
+}
+
+// List lista solicitações por status, mais recentes primeiro (sem a trilha)
+func (r *PostgresApprovalRepository) List(ctx context.Context, status entity.ApprovalStatus, limit int) ([]*entity.ApprovalRequest, error) {
+	query := `
+		SELECT ` + approvalColumns + `
+		FROM ` + r.schema + `.approval_requests
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+	return r.query(ctx, query, string(status), limit)
+}
+
+// ListExpired lista pendentes com prazo vencido, mais antigas primeiro
+func (r *PostgresApprovalRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.ApprovalRequest, error) {
+	query := `
+		SELECT ` + approvalColumns + `
+		FROM ` + r.schema + `.approval_requests
+		WHERE status = 'pending' AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+	return r.query(ctx, query, now, limit)
+}
+
+// AppendAction grava um registro da trilha
+func (r *PostgresApprovalRepository) AppendAction(ctx context.Context, requestID uuid.UUID, action entity.ApprovalAction) error {
+	return r.insertAction(ctx, r.conn, requestID, action)
+}
+
+// Resolve grava o estado final se a solicitação ainda estiver pendente
+func (r *PostgresApprovalRepository) Resolve(ctx context.Context, req *entity.ApprovalRequest) (bool, error) {
+	result, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.approval_requests
+		SET status = $2, resolved_at = $3
+		WHERE id = $1 AND status = 'pending'
+	`, req.ID, string(req.Status), req.ResolvedAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *PostgresApprovalRepository) insertAction(ctx context.Context, db execer, requestID uuid.UUID, a entity.ApprovalAction) error {
+	roles, err := json.Marshal(nonNilRoles(a.Roles))
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(ctx, `
+		INSERT INTO `+r.schema+`.approval_actions (id, request_id, action, actor_id, roles, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7)
+	`, a.ID, requestID, string(a.Action), a.ActorID, string(roles), a.Comment, a.CreatedAt)
+	return err
+}
+
+func (r *PostgresApprovalRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.ApprovalRequest, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ApprovalRequest
+	for rows.Next() {
+		req, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, req)
+	}
+
+	return out, rows.Err()
+}
+
+func scanApproval(row rowScanner) (*entity.ApprovalRequest, error) {
+	req := &entity.ApprovalRequest{}
+	var (
+		roles      []byte
+		status     string
+		resolvedAt sql.NullTime
+	)
+	err := row.Scan(
+		&req.ID,
+		&req.TransactionID,
+		&req.RequesterID,
+		&req.PolicyID,
+		&req.Amount,
+		&req.Currency,
+		&req.Chain,
+		&req.ToAddress,
+		&req.RequiredApprovals,
+		&roles,
+		&status,
+		&req.CreatedAt,
+		&req.ExpiresAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(roles, &req.ApproverRoles); err != nil {
+		return nil, err
+	}
+	req.Status = entity.ApprovalStatus(status)
+	if resolvedAt.Valid {
+		req.ResolvedAt = &resolvedAt.Time
+	}
+	return req, nil
+}
+
+func nonNilRoles(roles []string) []string {
+	if roles == nil {
+		return []string{}
+	}
+	return roles
+}
@@ -0,0 +1,331 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresCreditRepository implementa CreditRepository usando PostgreSQL
+type PostgresCreditRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresCreditRepository cria um novo repositório de linhas de crédito
+func NewPostgresCreditRepository(conn database.Connection) *PostgresCreditRepository {
+	return &PostgresCreditRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const creditLineColumns = `id, user_id, credit_limit, annual_rate, day_count, usage_fee, late_fee, grace_days, status,
+	accrued_interest, interest_due, fees_due, due_date, used_in_cycle, late_fee_charged, last_accrual_date,
+	version, created_at, updated_at, closed_at`
+
+const creditChargeColumns = `id, line_id, user_id, kind, amount, period, created_at`
+
+const creditRepaymentColumns = `id, line_id, user_id, source_tx_id, amount, fees, interest, principal,
+	fee_tx_id, interest_tx_id, created_at`
+
+const creditRunColumns = `run_date, lines, accrued, charges, repayments, failures, started_at, finished_at`
+
+// CreateLine insere a linha; o índice único parcial impede duas linhas abertas por usuário
+func (r *PostgresCreditRepository) CreateLine(ctx context.Context, line *entity.CreditLine) (bool, error) {
+	res, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.credit_lines (`+creditLineColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		ON CONFLICT DO NOTHING
+	`,
+		line.ID,
+		line.UserID,
+		line.Limit,
+		line.AnnualRate,
+		string(line.DayCount),
+		line.UsageFee,
+		line.LateFee,
+		line.GraceDays,
+		string(line.Status),
+		line.AccruedInterest,
+		line.InterestDue,
+		line.FeesDue,
+		line.DueDate,
+		line.UsedInCycle,
+		line.LateFeeCharged,
+		line.LastAccrualDate,
+		line.Version,
+		line.CreatedAt,
+		line.UpdatedAt,
+		line.ClosedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// UpdateLine grava o estado com controle otimista por versão
+func (r *PostgresCreditRepository) UpdateLine(ctx context.Context, line *entity.CreditLine) error {
+	result, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.credit_lines
+		SET credit_limit = $3, annual_rate = $4, day_count = $5, usage_fee = $6, late_fee = $7, grace_days = $8,
+			status = $9, accrued_interest = $10, interest_due = $11, fees_due = $12, due_date = $13,
+			used_in_cycle = $14, late_fee_charged = $15, last_accrual_date = $16, updated_at = $17,
+			closed_at = $18, version = version + 1
+		WHERE id = $1 AND version = $2
+	`,
+		line.ID,
+		line.Version,
+		line.Limit,
+		line.AnnualRate,
+		string(line.DayCount),
+		line.UsageFee,
+		line.LateFee,
+		line.GraceDays,
+		string(line.Status),
+		line.AccruedInterest,
+		line.InterestDue,
+		line.FeesDue,
+		line.DueDate,
+		line.UsedInCycle,
+		line.LateFeeCharged,
+		line.LastAccrualDate,
+		line.UpdatedAt,
+		line.ClosedAt,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrCreditLineConflict
+	}
+	line.Version++
+	return nil
+}
+
+// FindLineByUser busca a linha não encerrada do usuário
+func (r *PostgresCreditRepository) FindLineByUser(ctx context.Context, userID uuid.UUID) (*entity.CreditLine, error) {
+	line, err := scanCreditLine(r.conn.QueryRow(ctx, `
+		SELECT `+creditLineColumns+`
+		FROM `+r.schema+`.credit_lines
+		WHERE user_id = $1 AND status <> 'closed'
+	`, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return line, nil
+}
+
+// ListOpenLines lista as linhas não encerradas em ordem de aprovação
+func (r *PostgresCreditRepository) ListOpenLines(ctx context.Context) ([]*entity.CreditLine, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+creditLineColumns+`
+		FROM `+r.schema+`.credit_lines
+		WHERE status <> 'closed'
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.CreditLine
+	for rows.Next() {
+		line, err := scanCreditLine(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, line)
+	}
+	return out, rows.Err()
+}
+
+// SaveCharges grava os encargos lançados na mesma transação
+func (r *PostgresCreditRepository) SaveCharges(ctx context.Context, charges []*entity.CreditCharge) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, c := range charges {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO `+r.schema+`.credit_charges (`+creditChargeColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, c.ID, c.LineID, c.UserID, string(c.Kind), c.Amount, c.Period, c.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListCharges lista os encargos da linha, mais recentes primeiro
+func (r *PostgresCreditRepository) ListCharges(ctx context.Context, lineID uuid.UUID, limit int) ([]*entity.CreditCharge, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+creditChargeColumns+`
+		FROM `+r.schema+`.credit_charges
+		WHERE line_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, lineID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.CreditCharge
+	for rows.Next() {
+		c := &entity.CreditCharge{}
+		var kind string
+		if err := rows.Scan(&c.ID, &c.LineID, &c.UserID, &kind, &c.Amount, &c.Period, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.Kind = entity.CreditChargeKind(kind)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// SaveRepayment grava o pagamento alocado
+func (r *PostgresCreditRepository) SaveRepayment(ctx context.Context, p *entity.CreditRepayment) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.credit_repayments (`+creditRepaymentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		p.ID,
+		p.LineID,
+		p.UserID,
+		p.SourceTxID,
+		p.Amount,
+		p.Fees,
+		p.Interest,
+		p.Principal,
+		p.FeeTxID,
+		p.InterestTxID,
+		p.CreatedAt,
+	)
+	return err
+}
+
+// ListRepayments lista os pagamentos da linha, mais recentes primeiro
+func (r *PostgresCreditRepository) ListRepayments(ctx context.Context, lineID uuid.UUID, limit int) ([]*entity.CreditRepayment, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+creditRepaymentColumns+`
+		FROM `+r.schema+`.credit_repayments
+		WHERE line_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, lineID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.CreditRepayment
+	for rows.Next() {
+		p := &entity.CreditRepayment{}
+		var sourceTxID, feeTxID, interestTxID uuid.NullUUID
+		if err := rows.Scan(&p.ID, &p.LineID, &p.UserID, &sourceTxID, &p.Amount, &p.Fees, &p.Interest, &p.Principal,
+			&feeTxID, &interestTxID, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		if sourceTxID.Valid {
+			p.SourceTxID = &sourceTxID.UUID
+		}
+		if feeTxID.Valid {
+			p.FeeTxID = &feeTxID.UUID
+		}
+		if interestTxID.Valid {
+			p.InterestTxID = &interestTxID.UUID
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// SaveRun grava a execução diária, substituindo uma execução anterior do mesmo dia
+func (r *PostgresCreditRepository) SaveRun(ctx context.Context, run *entity.CreditAccrualRun) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.credit_accrual_runs (`+creditRunColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (run_date) DO UPDATE SET
+			lines = EXCLUDED.lines,
+			accrued = EXCLUDED.accrued,
+			charges = EXCLUDED.charges,
+			repayments = EXCLUDED.repayments,
+			failures = EXCLUDED.failures,
+			started_at = EXCLUDED.started_at,
+			finished_at = EXCLUDED.finished_at
+	`,
+		run.RunDate,
+		run.Lines,
+		run.Accrued,
+		run.Charges,
+		run.Repayments,
+		run.Failures,
+		run.StartedAt,
+		run.FinishedAt,
+	)
+	return err
+}
+
+// FindRun busca a execução do dia
+func (r *PostgresCreditRepository) FindRun(ctx context.Context, runDate time.Time) (*entity.CreditAccrualRun, error) {
+	run := &entity.CreditAccrualRun{}
+	err := r.conn.QueryRow(ctx, `
+		SELECT `+creditRunColumns+`
+		FROM `+r.schema+`.credit_accrual_runs
+		WHERE run_date = $1
+	`, runDate).Scan(&run.RunDate, &run.Lines, &run.Accrued, &run.Charges, &run.Repayments, &run.Failures,
+		&run.StartedAt, &run.FinishedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+func scanCreditLine(row rowScanner) (*entity.CreditLine, error) {
+	line := &entity.CreditLine{}
+	var (
+		dayCount        string
+		status          string
+		dueDate         sql.NullTime
+		lastAccrualDate sql.NullTime
+		closedAt        sql.NullTime
+	)
+	if err := row.Scan(&line.ID, &line.UserID, &line.Limit, &line.AnnualRate, &dayCount, &line.UsageFee, &line.LateFee,
+		&line.GraceDays, &status, &line.AccruedInterest, &line.InterestDue, &line.FeesDue, &dueDate,
+		&line.UsedInCycle, &line.LateFeeCharged, &lastAccrualDate, &line.Version, &line.CreatedAt, &line.UpdatedAt,
+		&closedAt); err != nil {
+		return nil, err
+	}
+	line.DayCount = entity.DayCount(dayCount)
+	line.Status = entity.CreditLineStatus(status)
+	if dueDate.Valid {
+		line.DueDate = &dueDate.Time
+	}
+	if lastAccrualDate.Valid {
+		line.LastAccrualDate = &lastAccrualDate.Time
+	}
+	if closedAt.Valid {
+		line.ClosedAt = &closedAt.Time
+	}
+	return line, nil
+}
@@ -0,0 +1,271 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresEscrowRepository implementa EscrowRepository usando PostgreSQL
+type PostgresEscrowRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresEscrowRepository cria um novo repositório de custódias
+func NewPostgresEscrowRepository(conn database.Connection) *PostgresEscrowRepository {
+	return &PostgresEscrowRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const escrowColumns = `id, buyer_id, seller_id, arbiter_id, amount, description, conditions, deadline, timeout_action,
+	status, funding_tx_id, released_amount, refunded_amount, disputed_by, dispute_reason, resolved_by, resolution,
+	version, created_at, updated_at, funded_at, closed_at, release_tx_id, refund_tx_id`
+
+// Create insere uma nova custódia
+func (r *PostgresEscrowRepository) Create(ctx context.Context, e *entity.Escrow) error {
+	conditions, err := json.Marshal(e.Conditions)
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.escrows (`+escrowColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+	`,
+		e.ID,
+		e.BuyerID,
+		e.SellerID,
+		e.ArbiterID,
+		e.Amount,
+		e.Description,
+		string(conditions),
+		e.Deadline,
+		string(e.TimeoutAction),
+		string(e.Status),
+		e.FundingTxID,
+		e.ReleasedAmount,
+		e.RefundedAmount,
+		e.DisputedBy,
+		e.DisputeReason,
+		e.ResolvedBy,
+		e.Resolution,
+		e.Version,
+		e.CreatedAt,
+		e.UpdatedAt,
+		e.FundedAt,
+		e.ClosedAt,
+		e.ReleaseTxID,
+		e.RefundTxID,
+	)
+	return err
+}
+
+// FindByID busca uma custódia por ID
+func (r *PostgresEscrowRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Escrow, error) {
+	e, err := scanEscrow(r.conn.QueryRow(ctx, `SELECT `+escrowColumns+` FROM `+r.schema+`.escrows WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// ListByParty lista as custódias do comprador, vendedor ou árbitro
+func (r *PostgresEscrowRepository) ListByParty(ctx context.Context, userID uuid.UUID) ([]*entity.Escrow, error) {
+	return r.query(ctx, `
+		SELECT `+escrowColumns+`
+		FROM `+r.schema+`.escrows
+		WHERE buyer_id = $1 OR seller_id = $1 OR arbiter_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+}
+
+// ListByStatus lista as custódias no estado informado
+func (r *PostgresEscrowRepository) ListByStatus(ctx context.Context, status entity.EscrowStatus, limit int) ([]*entity.Escrow, error) {
+	return r.query(ctx, `
+		SELECT `+escrowColumns+`
+		FROM `+r.schema+`.escrows
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT $2
+	`, string(status), limit)
+}
+
+// ListExpired lista as custódias abertas com prazo vencido, mais atrasadas primeiro
+func (r *PostgresEscrowRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.Escrow, error) {
+	return r.query(ctx, `
+		SELECT `+escrowColumns+`
+		FROM `+r.schema+`.escrows
+		WHERE status IN ('awaiting_funding', 'funded') AND deadline <= $1
+		ORDER BY deadline
+		LIMIT $2
+	`, now, limit)
+}
+
+// ListPendingPayouts lista as custódias encerradas até before que ainda devem pagamento ou devolução
+func (r *PostgresEscrowRepository) ListPendingPayouts(ctx context.Context, before time.Time, limit int) ([]*entity.Escrow, error) {
+	return r.query(ctx, `
+		SELECT `+escrowColumns+`
+		FROM `+r.schema+`.escrows
+		WHERE status IN ('released', 'refunded', 'resolved') AND closed_at <= $1
+			AND ((released_amount > 0 AND release_tx_id IS NULL) OR (refunded_amount > 0 AND refund_tx_id IS NULL))
+		ORDER BY closed_at
+		LIMIT $2
+	`, before, limit)
+}
+
+// SetPayoutTx registra as transações de pagamento e devolução creditadas sem mexer na versão
+func (r *PostgresEscrowRepository) SetPayoutTx(ctx context.Context, id uuid.UUID, releaseTxID, refundTxID *uuid.UUID) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.escrows
+		SET release_tx_id = COALESCE(release_tx_id, $2), refund_tx_id = COALESCE(refund_tx_id, $3)
+		WHERE id = $1
+	`, id, releaseTxID, refundTxID)
+	return err
+}
+
+// Update grava o estado com controle otimista por versão
+func (r *PostgresEscrowRepository) Update(ctx context.Context, e *entity.Escrow) error {
+	conditions, err := json.Marshal(e.Conditions)
+	if err != nil {
+		return err
+	}
+	result, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.escrows
+		SET conditions = $3::jsonb, status = $4, funding_tx_id = $5, released_amount = $6, refunded_amount = $7,
+			disputed_by = $8, dispute_reason = $9, resolved_by = $10, resolution = $11,
+			funded_at = $12, closed_at = $13, updated_at = $14, version = version + 1
+		WHERE id = $1 AND version = $2
+	`,
+		e.ID,
+		e.Version,
+		string(conditions),
+		string(e.Status),
+		e.FundingTxID,
+		e.ReleasedAmount,
+		e.RefundedAmount,
+		e.DisputedBy,
+		e.DisputeReason,
+		e.ResolvedBy,
+		e.Resolution,
+		e.FundedAt,
+		e.ClosedAt,
+		e.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrEscrowConflict
+	}
+	e.Version++
+	return nil
+}
+
+func (r *PostgresEscrowRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.Escrow, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Escrow
+	for rows.Next() {
+		e, err := scanEscrow(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+func scanEscrow(row rowScanner) (*entity.Escrow, error) {
+	e := &entity.Escrow{}
+	var (
+		arbiterID     uuid.NullUUID
+		conditions    []byte
+		timeoutAction string
+		status        string
+		fundingTxID   uuid.NullUUID
+		disputedBy    uuid.NullUUID
+		resolvedBy    uuid.NullUUID
+		fundedAt      sql.NullTime
+		closedAt      sql.NullTime
+		releaseTxID   uuid.NullUUID
+		refundTxID    uuid.NullUUID
+	)
+	err := row.Scan(
+		&e.ID,
+		&e.BuyerID,
+		&e.SellerID,
+		&arbiterID,
+		&e.Amount,
+		&e.Description,
+		&conditions,
+		&e.Deadline,
+		&timeoutAction,
+		&status,
+		&fundingTxID,
+		&e.ReleasedAmount,
+		&e.RefundedAmount,
+		&disputedBy,
+		&e.DisputeReason,
+		&resolvedBy,
+		&e.Resolution,
+		&e.Version,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&fundedAt,
+		&closedAt,
+		&releaseTxID,
+		&refundTxID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(conditions, &e.Conditions); err != nil {
+		return nil, err
+	}
+	e.TimeoutAction = entity.EscrowTimeoutAction(timeoutAction)
+	e.Status = entity.EscrowStatus(status)
+	if arbiterID.Valid {
+		e.ArbiterID = &arbiterID.UUID
+	}
+	if fundingTxID.Valid {
+		e.FundingTxID = &fundingTxID.UUID
+	}
+	if disputedBy.Valid {
+		e.DisputedBy = &disputedBy.UUID
+	}
+	if resolvedBy.Valid {
+		e.ResolvedBy = &resolvedBy.UUID
+	}
+	if fundedAt.Valid {
+		e.FundedAt = &fundedAt.Time
+	}
+	if closedAt.Valid {
+		e.ClosedAt = &closedAt.Time
+	}
+	if releaseTxID.Valid {
+		e.ReleaseTxID = &releaseTxID.UUID
+	}
+	if refundTxID.Valid {
+		e.RefundTxID = &refundTxID.UUID
+	}
+	return e, nil
+}
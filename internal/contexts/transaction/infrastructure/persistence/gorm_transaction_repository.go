@@ -7,8 +7,10 @@ import (
 	"financial-system-pro/internal/contexts/transaction/domain/repository"
 	"financial-system-pro/internal/infrastructure/database/mappers"
 	"financial-system-pro/internal/infrastructure/database/models"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -91,3 +93,41 @@ func (r *GormTransactionRepository) UpdateStatus(ctx context.Context, id uuid.UU
 		Update("status", string(status)).
 		Error
 }
+
+// SumAmountSince soma o valor das transações não falhas do usuário com os tipos informados desde since
+func (r *GormTransactionRepository) SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	if len(types) == 0 {
+		return decimal.Zero, nil
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	var total decimal.NullDecimal
+	err := r.db.WithContext(ctx).
+		Model(&models.TransactionModel{}).
+		Select("SUM(amount)").
+		Where("user_id = ? AND status <> ? AND created_at >= ? AND type IN ?", userID, string(entity.TransactionStatusFailed), since, names).
+		Scan(&total).Error
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return total.Decimal, nil
+}
+
+// FindUncollectedFees busca as operações concluídas com taxa sem cobrança registrada ou em andamento
+func (r *GormTransactionRepository) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error) {
+	fee := string(entity.TransactionTypeFee)
+	var models []*models.TransactionModel
+	err := r.db.WithContext(ctx).
+		Where("fee > 0 AND status = ? AND type <> ? AND completed_at < ?", string(entity.TransactionStatusCompleted), fee, before).
+		Where("NOT EXISTS (SELECT 1 FROM transaction_context.transactions f WHERE f.parent_id = transactions.id AND f.type = ? AND f.status <> ?)",
+			fee, string(entity.TransactionStatusFailed)).
+		Order("completed_at").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.mapper.ToDomainList(models), nil
+}
@@ -0,0 +1,485 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresInterestRepository implementa InterestRepository usando PostgreSQL
+type PostgresInterestRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresInterestRepository cria um novo repositório de contas remuneradas
+func NewPostgresInterestRepository(conn database.Connection) *PostgresInterestRepository {
+	return &PostgresInterestRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const interestTypeColumns = `code, name, day_count, schedule, min_balance, withholding_rate, active, created_at, updated_at`
+
+const interestAccountColumns = `id, user_id, type_code, status, accrued_unpaid, last_accrual_date, opened_at, closed_at`
+
+const interestAccrualColumns = `id, account_id, user_id, accrual_date, balance, annual_rate, day_count, daily_rate, amount,
+	capitalization_id, created_at`
+
+const interestCapitalizationColumns = `id, account_id, user_id, period, gross, withholding_rate, withholding, net,
+	accrual_count, interest_tx_id, tax_tx_id, created_at`
+
+const interestRunColumns = `run_date, accounts, accrued, total, capitalizations, failures, started_at, finished_at`
+
+// SaveType cria ou substitui o tipo pelo código
+func (r *PostgresInterestRepository) SaveType(ctx context.Context, t *entity.InterestAccountType) error {
+	schedule, err := json.Marshal(t.Schedule)
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.interest_account_types (`+interestTypeColumns+`)
+		VALUES ($1, $2, $3, $4::jsonb, $5, $6, $7, $8, $9)
+		ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			day_count = EXCLUDED.day_count,
+			schedule = EXCLUDED.schedule,
+			min_balance = EXCLUDED.min_balance,
+			withholding_rate = EXCLUDED.withholding_rate,
+			active = EXCLUDED.active,
+			updated_at = EXCLUDED.updated_at
+	`,
+		t.Code,
+		t.Name,
+		string(t.DayCount),
+		string(schedule),
+		t.MinBalance,
+		t.WithholdingRate,
+		t.Active,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+	return err
+}
+
+// FindType busca o tipo pelo código
+func (r *PostgresInterestRepository) FindType(ctx context.Context, code string) (*entity.InterestAccountType, error) {
+	t, err := scanInterestType(r.conn.QueryRow(ctx, `
+		SELECT `+interestTypeColumns+`
+		FROM `+r.schema+`.interest_account_types
+		WHERE code = $1
+	`, code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListTypes lista os tipos por código
+func (r *PostgresInterestRepository) ListTypes(ctx context.Context) ([]*entity.InterestAccountType, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+interestTypeColumns+`
+		FROM `+r.schema+`.interest_account_types
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.InterestAccountType
+	for rows.Next() {
+		t, err := scanInterestType(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// CreateAccount insere a conta; o índice único parcial impede duas contas ativas por usuário
+func (r *PostgresInterestRepository) CreateAccount(ctx context.Context, account *entity.InterestAccount) (bool, error) {
+	res, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.interest_accounts (`+interestAccountColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING
+	`,
+		account.ID,
+		account.UserID,
+		account.TypeCode,
+		string(account.Status),
+		account.AccruedUnpaid,
+		account.LastAccrualDate,
+		account.OpenedAt,
+		account.ClosedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// UpdateAccount atualiza o estado da conta; o pendente é mantido pelas gravações de rendimento e
+// de capitalização
+func (r *PostgresInterestRepository) UpdateAccount(ctx context.Context, account *entity.InterestAccount) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_accounts
+		SET status = $2, closed_at = $3
+		WHERE id = $1
+	`, account.ID, string(account.Status), account.ClosedAt)
+	return err
+}
+
+// FindAccountByUser busca a conta ativa do usuário
+func (r *PostgresInterestRepository) FindAccountByUser(ctx context.Context, userID uuid.UUID) (*entity.InterestAccount, error) {
+	account, err := scanInterestAccount(r.conn.QueryRow(ctx, `
+		SELECT `+interestAccountColumns+`
+		FROM `+r.schema+`.interest_accounts
+		WHERE user_id = $1 AND status = 'active'
+	`, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// ListActiveAccounts lista as contas ativas em ordem de abertura
+func (r *PostgresInterestRepository) ListActiveAccounts(ctx context.Context) ([]*entity.InterestAccount, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+interestAccountColumns+`
+		FROM `+r.schema+`.interest_accounts
+		WHERE status = 'active'
+		ORDER BY opened_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.InterestAccount
+	for rows.Next() {
+		account, err := scanInterestAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, account)
+	}
+	return out, rows.Err()
+}
+
+// SaveAccrual grava o rendimento e o soma ao pendente da conta na mesma transação
+func (r *PostgresInterestRepository) SaveAccrual(ctx context.Context, accrual *entity.InterestAccrual) (bool, error) {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.interest_accruals (`+interestAccrualColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (account_id, accrual_date) DO NOTHING
+	`,
+		accrual.ID,
+		accrual.AccountID,
+		accrual.UserID,
+		accrual.AccrualDate,
+		accrual.Balance,
+		accrual.AnnualRate,
+		string(accrual.DayCount),
+		accrual.DailyRate,
+		accrual.Amount,
+		accrual.CapitalizationID,
+		accrual.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_accounts
+		SET accrued_unpaid = accrued_unpaid + $2,
+			last_accrual_date = GREATEST(COALESCE(last_accrual_date, $3), $3)
+		WHERE id = $1
+	`, accrual.AccountID, accrual.Amount, accrual.AccrualDate); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// MarkAccrued avança a última data apropriada da conta
+func (r *PostgresInterestRepository) MarkAccrued(ctx context.Context, accountID uuid.UUID, day time.Time) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_accounts
+		SET last_accrual_date = GREATEST(COALESCE(last_accrual_date, $2), $2)
+		WHERE id = $1
+	`, accountID, day)
+	return err
+}
+
+// ListAccruals lista os rendimentos da conta no intervalo
+func (r *PostgresInterestRepository) ListAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*entity.InterestAccrual, error) {
+	return r.listAccruals(ctx, `WHERE account_id = $1 AND accrual_date >= $2 AND accrual_date <= $3`, accountID, from, to)
+}
+
+// UnpaidAccruals lista os rendimentos não capitalizados até a data
+func (r *PostgresInterestRepository) UnpaidAccruals(ctx context.Context, accountID uuid.UUID, through time.Time) ([]*entity.InterestAccrual, error) {
+	return r.listAccruals(ctx, `WHERE account_id = $1 AND capitalization_id IS NULL AND accrual_date <= $2`, accountID, through)
+}
+
+func (r *PostgresInterestRepository) listAccruals(ctx context.Context, where string, args ...interface{}) ([]*entity.InterestAccrual, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+interestAccrualColumns+`
+		FROM `+r.schema+`.interest_accruals
+		`+where+`
+		ORDER BY accrual_date
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.InterestAccrual
+	for rows.Next() {
+		a := &entity.InterestAccrual{}
+		var (
+			dayCount         string
+			capitalizationID uuid.NullUUID
+		)
+		if err := rows.Scan(&a.ID, &a.AccountID, &a.UserID, &a.AccrualDate, &a.Balance, &a.AnnualRate, &dayCount,
+			&a.DailyRate, &a.Amount, &capitalizationID, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.DayCount = entity.DayCount(dayCount)
+		if capitalizationID.Valid {
+			a.CapitalizationID = &capitalizationID.UUID
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// SaveCapitalization grava a capitalização, vincula os rendimentos e desconta o bruto do pendente
+// da conta na mesma transação
+func (r *PostgresInterestRepository) SaveCapitalization(ctx context.Context, c *entity.InterestCapitalization, accrualIDs []uuid.UUID) (bool, error) {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.interest_capitalizations (`+interestCapitalizationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (account_id, period) DO NOTHING
+	`,
+		c.ID,
+		c.AccountID,
+		c.UserID,
+		c.Period,
+		c.Gross,
+		c.WithholdingRate,
+		c.Withholding,
+		c.Net,
+		c.AccrualCount,
+		c.InterestTxID,
+		c.TaxTxID,
+		c.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	for _, id := range accrualIDs {
+		if _, err := tx.Exec(ctx, `
+			UPDATE `+r.schema+`.interest_accruals
+			SET capitalization_id = $2
+			WHERE id = $1 AND capitalization_id IS NULL
+		`, id, c.ID); err != nil {
+			return false, err
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_accounts
+		SET accrued_unpaid = accrued_unpaid - $2
+		WHERE id = $1
+	`, c.AccountID, c.Gross); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// DeleteCapitalization desfaz a capitalização cujo crédito falhou
+func (r *PostgresInterestRepository) DeleteCapitalization(ctx context.Context, c *entity.InterestCapitalization) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_accruals
+		SET capitalization_id = NULL
+		WHERE capitalization_id = $1
+	`, c.ID); err != nil {
+		return err
+	}
+	res, err := tx.Exec(ctx, `DELETE FROM `+r.schema+`.interest_capitalizations WHERE id = $1`, c.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_accounts
+		SET accrued_unpaid = accrued_unpaid + $2
+		WHERE id = $1
+	`, c.AccountID, c.Gross); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetCapitalizationTransactions registra as transações geradas pela capitalização
+func (r *PostgresInterestRepository) SetCapitalizationTransactions(ctx context.Context, id uuid.UUID, interestTxID uuid.UUID, taxTxID *uuid.UUID) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.interest_capitalizations
+		SET interest_tx_id = $2, tax_tx_id = $3
+		WHERE id = $1
+	`, id, interestTxID, taxTxID)
+	return err
+}
+
+// ListCapitalizations lista as capitalizações da conta, mais recentes primeiro
+func (r *PostgresInterestRepository) ListCapitalizations(ctx context.Context, accountID uuid.UUID) ([]*entity.InterestCapitalization, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+interestCapitalizationColumns+`
+		FROM `+r.schema+`.interest_capitalizations
+		WHERE account_id = $1
+		ORDER BY created_at DESC
+	`, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.InterestCapitalization
+	for rows.Next() {
+		c := &entity.InterestCapitalization{}
+		var interestTxID, taxTxID uuid.NullUUID
+		if err := rows.Scan(&c.ID, &c.AccountID, &c.UserID, &c.Period, &c.Gross, &c.WithholdingRate, &c.Withholding,
+			&c.Net, &c.AccrualCount, &interestTxID, &taxTxID, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		if interestTxID.Valid {
+			c.InterestTxID = &interestTxID.UUID
+		}
+		if taxTxID.Valid {
+			c.TaxTxID = &taxTxID.UUID
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// SaveRun grava a execução diária, substituindo uma execução anterior do mesmo dia
+func (r *PostgresInterestRepository) SaveRun(ctx context.Context, run *entity.InterestAccrualRun) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.interest_accrual_runs (`+interestRunColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (run_date) DO UPDATE SET
+			accounts = EXCLUDED.accounts,
+			accrued = EXCLUDED.accrued,
+			total = EXCLUDED.total,
+			capitalizations = EXCLUDED.capitalizations,
+			failures = EXCLUDED.failures,
+			started_at = EXCLUDED.started_at,
+			finished_at = EXCLUDED.finished_at
+	`,
+		run.RunDate,
+		run.Accounts,
+		run.Accrued,
+		run.Total,
+		run.Capitalizations,
+		run.Failures,
+		run.StartedAt,
+		run.FinishedAt,
+	)
+	return err
+}
+
+// FindRun busca a execução do dia
+func (r *PostgresInterestRepository) FindRun(ctx context.Context, runDate time.Time) (*entity.InterestAccrualRun, error) {
+	run := &entity.InterestAccrualRun{}
+	err := r.conn.QueryRow(ctx, `
+		SELECT `+interestRunColumns+`
+		FROM `+r.schema+`.interest_accrual_runs
+		WHERE run_date = $1
+	`, runDate).Scan(&run.RunDate, &run.Accounts, &run.Accrued, &run.Total, &run.Capitalizations, &run.Failures,
+		&run.StartedAt, &run.FinishedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+func scanInterestType(row rowScanner) (*entity.InterestAccountType, error) {
+	t := &entity.InterestAccountType{}
+	var (
+		dayCount string
+		schedule []byte
+	)
+	if err := row.Scan(&t.Code, &t.Name, &dayCount, &schedule, &t.MinBalance, &t.WithholdingRate, &t.Active,
+		&t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	t.DayCount = entity.DayCount(dayCount)
+	if err := json.Unmarshal(schedule, &t.Schedule); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func scanInterestAccount(row rowScanner) (*entity.InterestAccount, error) {
+	account := &entity.InterestAccount{}
+	var (
+		status          string
+		lastAccrualDate sql.NullTime
+		closedAt        sql.NullTime
+	)
+	if err := row.Scan(&account.ID, &account.UserID, &account.TypeCode, &status, &account.AccruedUnpaid,
+		&lastAccrualDate, &account.OpenedAt, &closedAt); err != nil {
+		return nil, err
+	}
+	account.Status = entity.InterestAccountStatus(status)
+	if lastAccrualDate.Valid {
+		account.LastAccrualDate = &lastAccrualDate.Time
+	}
+	if closedAt.Valid {
+		account.ClosedAt = &closedAt.Time
+	}
+	return account, nil
+}
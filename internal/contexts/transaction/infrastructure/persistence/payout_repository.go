@@ -0,0 +1,360 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresPayoutRepository implementa PayoutRepository usando PostgreSQL
+type PostgresPayoutRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresPayoutRepository cria um novo repositório de lotes de pagamentos
+func NewPostgresPayoutRepository(conn database.Connection) *PostgresPayoutRepository {
+	return &PostgresPayoutRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const payoutBatchColumns = `id, user_id, format, status, item_count, invalid_rows, total_amount, total_fee,
+	succeeded, failed, created_at, confirmed_at, completed_at`
+
+const payoutItemColumns = `id, batch_id, line, kind, recipient_user_id, email, chain, address, amount, fee,
+	reference, status, transaction_id, error, processed_at`
+
+// CreateBatch grava o lote e seus itens em uma única transação
+func (r *PostgresPayoutRepository) CreateBatch(ctx context.Context, b *entity.PayoutBatch, items []*entity.PayoutItem) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.payout_batches (`+payoutBatchColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		b.ID,
+		b.UserID,
+		b.Format,
+		string(b.Status),
+		b.ItemCount,
+		b.InvalidRows,
+		b.TotalAmount,
+		b.TotalFee,
+		b.Succeeded,
+		b.Failed,
+		b.CreatedAt,
+		b.ConfirmedAt,
+		b.CompletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO `+r.schema+`.payout_items (`+payoutItemColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`,
+			item.ID,
+			item.BatchID,
+			item.Line,
+			string(item.Kind),
+			item.RecipientUserID,
+			item.Email,
+			item.Chain,
+			item.Address,
+			item.Amount,
+			item.Fee,
+			item.Reference,
+			string(item.Status),
+			item.TransactionID,
+			item.Error,
+			item.ProcessedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// FindBatch busca um lote por ID
+func (r *PostgresPayoutRepository) FindBatch(ctx context.Context, id uuid.UUID) (*entity.PayoutBatch, error) {
+	b, err := scanPayoutBatch(r.conn.QueryRow(ctx, `SELECT `+payoutBatchColumns+` FROM `+r.schema+`.payout_batches WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListBatches lista os lotes do usuário
+func (r *PostgresPayoutRepository) ListBatches(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PayoutBatch, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+payoutBatchColumns+`
+		FROM `+r.schema+`.payout_batches
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.PayoutBatch
+	for rows.Next() {
+		b, err := scanPayoutBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// ListItems lista os itens do lote na ordem do arquivo
+func (r *PostgresPayoutRepository) ListItems(ctx context.Context, batchID uuid.UUID) ([]*entity.PayoutItem, error) {
+	return r.queryItems(ctx, `
+		SELECT `+payoutItemColumns+`
+		FROM `+r.schema+`.payout_items
+		WHERE batch_id = $1
+		ORDER BY line
+	`, batchID)
+}
+
+// FindItem busca um item por ID
+func (r *PostgresPayoutRepository) FindItem(ctx context.Context, id uuid.UUID) (*entity.PayoutItem, error) {
+	item, err := scanPayoutItem(r.conn.QueryRow(ctx, `SELECT `+payoutItemColumns+` FROM `+r.schema+`.payout_items WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// ConfirmBatch inicia o processamento do lote em draft e enfileira seus itens
+func (r *PostgresPayoutRepository) ConfirmBatch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	return r.transitionBatch(ctx, id, `status = 'processing', confirmed_at = $2`, entity.PayoutItemQueued, now)
+}
+
+// CancelBatch cancela o lote em draft e seus itens
+func (r *PostgresPayoutRepository) CancelBatch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	return r.transitionBatch(ctx, id, `status = 'cancelled', completed_at = $2`, entity.PayoutItemCancelled, now)
+}
+
+func (r *PostgresPayoutRepository) transitionBatch(ctx context.Context, id uuid.UUID, set string, itemStatus entity.PayoutItemStatus, now time.Time) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(ctx, `UPDATE `+r.schema+`.payout_batches SET `+set+` WHERE id = $1 AND status = 'draft'`, id, now)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrPayoutBatchState
+	}
+	if _, err := tx.Exec(ctx, `UPDATE `+r.schema+`.payout_items SET status = $2 WHERE batch_id = $1 AND status = 'valid'`, id, string(itemStatus)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListQueuedItems lista itens enfileirados de lotes em processamento
+func (r *PostgresPayoutRepository) ListQueuedItems(ctx context.Context, limit int) ([]*entity.PayoutItem, error) {
+	return r.queryItems(ctx, `
+		SELECT `+prefixColumns("i", payoutItemColumns)+`
+		FROM `+r.schema+`.payout_items i
+		JOIN `+r.schema+`.payout_batches b ON b.id = i.batch_id
+		WHERE i.status = 'queued' AND b.status = 'processing'
+		ORDER BY b.confirmed_at, i.line
+		LIMIT $1
+	`, limit)
+}
+
+// ClaimItem assume o item enfileirado para execução
+func (r *PostgresPayoutRepository) ClaimItem(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := r.conn.Exec(ctx, `UPDATE `+r.schema+`.payout_items SET status = 'processing' WHERE id = $1 AND status = 'queued'`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// FinishItem grava o resultado do item em processamento
+func (r *PostgresPayoutRepository) FinishItem(ctx context.Context, item *entity.PayoutItem) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.payout_items
+		SET status = $2, transaction_id = $3, error = $4, processed_at = $5
+		WHERE id = $1 AND status = 'processing'
+	`,
+		item.ID,
+		string(item.Status),
+		item.TransactionID,
+		item.Error,
+		item.ProcessedAt,
+	)
+	return err
+}
+
+// FinalizeBatch encerra o lote quando não há mais itens pendentes
+func (r *PostgresPayoutRepository) FinalizeBatch(ctx context.Context, id uuid.UUID, now time.Time) (*entity.PayoutBatch, error) {
+	b, err := scanPayoutBatch(r.conn.QueryRow(ctx, `
+		WITH counts AS (
+			SELECT
+				COUNT(*) FILTER (WHERE status IN ('queued', 'processing')) AS pending,
+				COUNT(*) FILTER (WHERE status = 'succeeded') AS succeeded,
+				COUNT(*) FILTER (WHERE status = 'failed') AS failed
+			FROM `+r.schema+`.payout_items
+			WHERE batch_id = $1
+		)
+		UPDATE `+r.schema+`.payout_batches b
+		SET succeeded = counts.succeeded,
+			failed = counts.failed,
+			status = CASE WHEN counts.failed > 0 THEN 'completed_with_errors' ELSE 'completed' END,
+			completed_at = $2
+		FROM counts
+		WHERE b.id = $1 AND b.status = 'processing' AND counts.pending = 0
+		RETURNING `+prefixColumns("b", payoutBatchColumns),
+		id, now,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *PostgresPayoutRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*entity.PayoutItem, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.PayoutItem
+	for rows.Next() {
+		item, err := scanPayoutItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
+
+func scanPayoutBatch(row rowScanner) (*entity.PayoutBatch, error) {
+	b := &entity.PayoutBatch{}
+	var (
+		status      string
+		confirmedAt sql.NullTime
+		completedAt sql.NullTime
+	)
+	err := row.Scan(
+		&b.ID,
+		&b.UserID,
+		&b.Format,
+		&status,
+		&b.ItemCount,
+		&b.InvalidRows,
+		&b.TotalAmount,
+		&b.TotalFee,
+		&b.Succeeded,
+		&b.Failed,
+		&b.CreatedAt,
+		&confirmedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	b.Status = entity.PayoutBatchStatus(status)
+	if confirmedAt.Valid {
+		b.ConfirmedAt = &confirmedAt.Time
+	}
+	if completedAt.Valid {
+		b.CompletedAt = &completedAt.Time
+	}
+	return b, nil
+}
+
+func scanPayoutItem(row rowScanner) (*entity.PayoutItem, error) {
+	item := &entity.PayoutItem{}
+	var (
+		kind            string
+		status          string
+		recipientUserID uuid.NullUUID
+		transactionID   uuid.NullUUID
+		processedAt     sql.NullTime
+	)
+	err := row.Scan(
+		&item.ID,
+		&item.BatchID,
+		&item.Line,
+		&kind,
+		&recipientUserID,
+		&item.Email,
+		&item.Chain,
+		&item.Address,
+		&item.Amount,
+		&item.Fee,
+		&item.Reference,
+		&status,
+		&transactionID,
+		&item.Error,
+		&processedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	item.Kind = entity.ScheduleKind(kind)
+	item.Status = entity.PayoutItemStatus(status)
+	if recipientUserID.Valid {
+		item.RecipientUserID = &recipientUserID.UUID
+	}
+	if transactionID.Valid {
+		item.TransactionID = &transactionID.UUID
+	}
+	if processedAt.Valid {
+		item.ProcessedAt = &processedAt.Time
+	}
+	return item, nil
+}
+
+// prefixColumns qualifica a lista de colunas com o alias da tabela (necessário em JOIN e UPDATE ... FROM)
+func prefixColumns(alias, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, col := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(col)
+	}
+	return strings.Join(parts, ", ")
+}
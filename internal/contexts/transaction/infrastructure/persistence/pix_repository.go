@@ -0,0 +1,380 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresPixRepository implementa PixRepository usando PostgreSQL
+type PostgresPixRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresPixRepository cria um novo repositório Pix
+func NewPostgresPixRepository(conn database.Connection) *PostgresPixRepository {
+	return &PostgresPixRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const pixChargeColumns = `id, user_id, txid, dynamic, amount, description, location, payload, status,
+	created_at, expires_at, paid_at`
+
+const pixCreditColumns = `id, end_to_end_id, txid, amount, payer_name, payer_document, charge_id, user_id,
+	status, transaction_id, reason, paid_at, received_at`
+
+const pixPaymentColumns = `id, user_id, transaction_id, pix_key, txid, amount, merchant_name, status,
+	end_to_end_id, attempts, error, created_at, sent_at`
+
+// CreateCharge grava uma nova cobrança
+func (r *PostgresPixRepository) CreateCharge(ctx context.Context, c *entity.PixCharge) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.pix_charges (`+pixChargeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		c.ID,
+		c.UserID,
+		c.TxID,
+		c.Dynamic,
+		c.Amount,
+		c.Description,
+		c.Location,
+		c.Payload,
+		string(c.Status),
+		c.CreatedAt,
+		c.ExpiresAt,
+		c.PaidAt,
+	)
+	return err
+}
+
+// FindCharge busca uma cobrança por ID
+func (r *PostgresPixRepository) FindCharge(ctx context.Context, id uuid.UUID) (*entity.PixCharge, error) {
+	return r.findCharge(ctx, `SELECT `+pixChargeColumns+` FROM `+r.schema+`.pix_charges WHERE id = $1`, id)
+}
+
+// FindChargeByTxID busca uma cobrança pelo txid
+func (r *PostgresPixRepository) FindChargeByTxID(ctx context.Context, txid string) (*entity.PixCharge, error) {
+	return r.findCharge(ctx, `SELECT `+pixChargeColumns+` FROM `+r.schema+`.pix_charges WHERE txid = $1`, txid)
+}
+
+// ListCharges lista as cobranças do usuário
+func (r *PostgresPixRepository) ListCharges(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixCharge, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+pixChargeColumns+`
+		FROM `+r.schema+`.pix_charges
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.PixCharge
+	for rows.Next() {
+		c, err := scanPixCharge(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// MarkChargePaid liquida a cobrança dinâmica ativa
+func (r *PostgresPixRepository) MarkChargePaid(ctx context.Context, id uuid.UUID, paidAt time.Time) (bool, error) {
+	result, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.pix_charges
+		SET status = 'paid', paid_at = $2
+		WHERE id = $1 AND status = 'active'
+	`, id, paidAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ExpireCharges expira as cobranças ativas vencidas
+func (r *PostgresPixRepository) ExpireCharges(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.pix_charges
+		SET status = 'expired'
+		WHERE status = 'active' AND expires_at IS NOT NULL AND expires_at <= $1
+	`, now)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// CreateCredit grava o crédito; o EndToEndID único garante o processamento único do webhook
+func (r *PostgresPixRepository) CreateCredit(ctx context.Context, c *entity.PixCredit) error {
+	result, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.pix_credits (`+pixCreditColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (end_to_end_id) DO NOTHING
+	`,
+		c.ID,
+		c.EndToEndID,
+		c.TxID,
+		c.Amount,
+		c.PayerName,
+		c.PayerDocument,
+		c.ChargeID,
+		c.UserID,
+		string(c.Status),
+		c.TransactionID,
+		c.Reason,
+		c.PaidAt,
+		c.ReceivedAt,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrPixCreditDuplicate
+	}
+	return nil
+}
+
+// UpdateCredit grava o resultado da conciliação do crédito
+func (r *PostgresPixRepository) UpdateCredit(ctx context.Context, c *entity.PixCredit) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.pix_credits
+		SET status = $2, transaction_id = $3, reason = $4
+		WHERE id = $1
+	`, c.ID, string(c.Status), c.TransactionID, c.Reason)
+	return err
+}
+
+// ListCredits lista os créditos no estado informado
+func (r *PostgresPixRepository) ListCredits(ctx context.Context, status entity.PixCreditStatus, limit int) ([]*entity.PixCredit, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+pixCreditColumns+`
+		FROM `+r.schema+`.pix_credits
+		WHERE status = $1
+		ORDER BY received_at DESC
+		LIMIT $2
+	`, string(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.PixCredit
+	for rows.Next() {
+		c, err := scanPixCredit(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// CreatePayment grava um novo pagamento
+func (r *PostgresPixRepository) CreatePayment(ctx context.Context, p *entity.PixPayment) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.pix_payments (`+pixPaymentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`,
+		p.ID,
+		p.UserID,
+		p.TransactionID,
+		p.Key,
+		p.TxID,
+		p.Amount,
+		p.MerchantName,
+		string(p.Status),
+		p.EndToEndID,
+		p.Attempts,
+		p.Error,
+		p.CreatedAt,
+		p.SentAt,
+	)
+	return err
+}
+
+// UpdatePayment grava o resultado do envio ao PSP
+func (r *PostgresPixRepository) UpdatePayment(ctx context.Context, p *entity.PixPayment) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.pix_payments
+		SET status = $2, end_to_end_id = $3, attempts = $4, error = $5, sent_at = $6
+		WHERE id = $1
+	`, p.ID, string(p.Status), p.EndToEndID, p.Attempts, p.Error, p.SentAt)
+	return err
+}
+
+// ListPayments lista os pagamentos do usuário
+func (r *PostgresPixRepository) ListPayments(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.PixPayment, error) {
+	return r.queryPayments(ctx, `
+		SELECT `+pixPaymentColumns+`
+		FROM `+r.schema+`.pix_payments
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userID, limit)
+}
+
+// ListPendingPayments lista os pagamentos aguardando envio
+func (r *PostgresPixRepository) ListPendingPayments(ctx context.Context, limit int) ([]*entity.PixPayment, error) {
+	return r.queryPayments(ctx, `
+		SELECT `+pixPaymentColumns+`
+		FROM `+r.schema+`.pix_payments
+		WHERE status = 'pending'
+		ORDER BY created_at
+		LIMIT $1
+	`, limit)
+}
+
+func (r *PostgresPixRepository) findCharge(ctx context.Context, query string, arg interface{}) (*entity.PixCharge, error) {
+	c, err := scanPixCharge(r.conn.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *PostgresPixRepository) queryPayments(ctx context.Context, query string, args ...interface{}) ([]*entity.PixPayment, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.PixPayment
+	for rows.Next() {
+		p, err := scanPixPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func scanPixCharge(row rowScanner) (*entity.PixCharge, error) {
+	c := &entity.PixCharge{}
+	var (
+		status    string
+		expiresAt sql.NullTime
+		paidAt    sql.NullTime
+	)
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.TxID,
+		&c.Dynamic,
+		&c.Amount,
+		&c.Description,
+		&c.Location,
+		&c.Payload,
+		&status,
+		&c.CreatedAt,
+		&expiresAt,
+		&paidAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Status = entity.PixChargeStatus(status)
+	if expiresAt.Valid {
+		c.ExpiresAt = &expiresAt.Time
+	}
+	if paidAt.Valid {
+		c.PaidAt = &paidAt.Time
+	}
+	return c, nil
+}
+
+func scanPixCredit(row rowScanner) (*entity.PixCredit, error) {
+	c := &entity.PixCredit{}
+	var (
+		status        string
+		chargeID      uuid.NullUUID
+		userID        uuid.NullUUID
+		transactionID uuid.NullUUID
+	)
+	err := row.Scan(
+		&c.ID,
+		&c.EndToEndID,
+		&c.TxID,
+		&c.Amount,
+		&c.PayerName,
+		&c.PayerDocument,
+		&chargeID,
+		&userID,
+		&status,
+		&transactionID,
+		&c.Reason,
+		&c.PaidAt,
+		&c.ReceivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Status = entity.PixCreditStatus(status)
+	if chargeID.Valid {
+		c.ChargeID = &chargeID.UUID
+	}
+	if userID.Valid {
+		c.UserID = &userID.UUID
+	}
+	if transactionID.Valid {
+		c.TransactionID = &transactionID.UUID
+	}
+	return c, nil
+}
+
+func scanPixPayment(row rowScanner) (*entity.PixPayment, error) {
+	p := &entity.PixPayment{}
+	var (
+		status string
+		sentAt sql.NullTime
+	)
+	err := row.Scan(
+		&p.ID,
+		&p.UserID,
+		&p.TransactionID,
+		&p.Key,
+		&p.TxID,
+		&p.Amount,
+		&p.MerchantName,
+		&status,
+		&p.EndToEndID,
+		&p.Attempts,
+		&p.Error,
+		&p.CreatedAt,
+		&sentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Status = entity.PixPaymentStatus(status)
+	if sentAt.Valid {
+		p.SentAt = &sentAt.Time
+	}
+	return p, nil
+}
@@ -0,0 +1,252 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresReconciliationRepository implementa ReconciliationRepository usando PostgreSQL.
+// O passivo vem de user_context.wallet_balances e as transações on-chain de
+// blockchain_context.blockchain_transactions.
+type PostgresReconciliationRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresReconciliationRepository cria um novo repositório de conciliação
+func NewPostgresReconciliationRepository(conn database.Connection) *PostgresReconciliationRepository {
+	return &PostgresReconciliationRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const reconciliationRunColumns = `id, run_date, status, break_count, by_category, assets, started_at, finished_at`
+
+const reconciliationBreakColumns = `id, run_id, category, chain, asset, transaction_id, tx_hash, expected, actual, detail`
+
+// SumLiabilities soma os saldos dos usuários no ativo
+func (r *PostgresReconciliationRepository) SumLiabilities(ctx context.Context, asset string) (decimal.Decimal, error) {
+	var total decimal.Decimal
+	err := r.conn.QueryRow(ctx, `
+		SELECT COALESCE(SUM(balance), 0)
+		FROM user_context.wallet_balances
+		WHERE currency = $1
+	`, asset).Scan(&total)
+	return total, err
+}
+
+// ListChainTransactions lista os depósitos e saques da rede criados no intervalo
+func (r *PostgresReconciliationRepository) ListChainTransactions(ctx context.Context, chain string, from, to time.Time) ([]*entity.Transaction, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+transactionColumns+`
+		FROM `+r.schema+`.transactions
+		WHERE chain = $1
+			AND type IN ('deposit', 'withdraw')
+			AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at, id
+	`, chain, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Transaction
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tx)
+	}
+	return out, rows.Err()
+}
+
+// ListOnChainTransfers lista as transações registradas na rede no intervalo
+func (r *PostgresReconciliationRepository) ListOnChainTransfers(ctx context.Context, chain string, from, to time.Time) ([]entity.OnChainTransfer, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT COALESCE(transaction_hash, ''), from_address, to_address, amount, status, created_at
+		FROM blockchain_context.blockchain_transactions
+		WHERE UPPER(network) = UPPER($1)
+			AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at, id
+	`, chain, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.OnChainTransfer
+	for rows.Next() {
+		var t entity.OnChainTransfer
+		if err := rows.Scan(&t.Hash, &t.FromAddress, &t.ToAddress, &t.Amount, &t.Status, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// Save grava o relatório e suas quebras, substituindo o relatório do mesmo dia
+func (r *PostgresReconciliationRepository) Save(ctx context.Context, run *entity.ReconciliationRun) error {
+	byCategory, err := json.Marshal(run.ByCategory)
+	if err != nil {
+		return err
+	}
+	assets, err := json.Marshal(run.Assets)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// as quebras do relatório anterior saem em cascata
+	if _, err = tx.Exec(ctx, `DELETE FROM `+r.schema+`.reconciliation_runs WHERE run_date = $1`, run.RunDate); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.reconciliation_runs (`+reconciliationRunColumns+`)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6::jsonb, $7, $8)
+	`,
+		run.ID,
+		run.RunDate,
+		string(run.Status),
+		run.BreakCount,
+		string(byCategory),
+		string(assets),
+		run.StartedAt,
+		run.FinishedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range run.Breaks {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO `+r.schema+`.reconciliation_breaks (`+reconciliationBreakColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`,
+			b.ID,
+			b.RunID,
+			string(b.Category),
+			b.Chain,
+			b.Asset,
+			b.TransactionID,
+			b.TxHash,
+			b.Expected,
+			b.Actual,
+			b.Detail,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Find busca o relatório por ID, com as quebras
+func (r *PostgresReconciliationRepository) Find(ctx context.Context, id uuid.UUID) (*entity.ReconciliationRun, error) {
+	run, err := r.findRun(ctx, `WHERE id = $1`, id)
+	if err != nil || run == nil {
+		return nil, err
+	}
+
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+reconciliationBreakColumns+`
+		FROM `+r.schema+`.reconciliation_breaks
+		WHERE run_id = $1
+		ORDER BY category, chain, tx_hash
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b entity.ReconciliationBreak
+		var category string
+		if err := rows.Scan(&b.ID, &b.RunID, &category, &b.Chain, &b.Asset, &b.TransactionID,
+			&b.TxHash, &b.Expected, &b.Actual, &b.Detail); err != nil {
+			return nil, err
+		}
+		b.Category = entity.ReconciliationBreakCategory(category)
+		run.Breaks = append(run.Breaks, b)
+	}
+	return run, rows.Err()
+}
+
+// FindByDate busca o relatório do dia, sem as quebras
+func (r *PostgresReconciliationRepository) FindByDate(ctx context.Context, runDate time.Time) (*entity.ReconciliationRun, error) {
+	return r.findRun(ctx, `WHERE run_date = $1`, runDate)
+}
+
+// List lista os relatórios mais recentes, sem as quebras
+func (r *PostgresReconciliationRepository) List(ctx context.Context, limit int) ([]*entity.ReconciliationRun, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+reconciliationRunColumns+`
+		FROM `+r.schema+`.reconciliation_runs
+		ORDER BY run_date DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ReconciliationRun
+	for rows.Next() {
+		run, err := scanReconciliationRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, run)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresReconciliationRepository) findRun(ctx context.Context, where string, arg interface{}) (*entity.ReconciliationRun, error) {
+	run, err := scanReconciliationRun(r.conn.QueryRow(ctx, `
+		SELECT `+reconciliationRunColumns+`
+		FROM `+r.schema+`.reconciliation_runs
+		`+where, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+func scanReconciliationRun(row rowScanner) (*entity.ReconciliationRun, error) {
+	run := &entity.ReconciliationRun{}
+	var (
+		status     string
+		byCategory []byte
+		assets     []byte
+	)
+	if err := row.Scan(&run.ID, &run.RunDate, &status, &run.BreakCount, &byCategory, &assets,
+		&run.StartedAt, &run.FinishedAt); err != nil {
+		return nil, err
+	}
+	run.Status = entity.ReconciliationStatus(status)
+	if err := json.Unmarshal(byCategory, &run.ByCategory); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(assets, &run.Assets); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
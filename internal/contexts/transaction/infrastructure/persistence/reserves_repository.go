@@ -0,0 +1,177 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+
+	"github.com/google/uuid"
+)
+
+// PostgresReservesRepository implementa ReservesRepository usando PostgreSQL. Os saldos vêm de
+// user_context.wallet_info (moeda base) e user_context.wallet_balances (demais ativos).
+type PostgresReservesRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresReservesRepository cria um novo repositório da prova de reservas
+func NewPostgresReservesRepository(conn database.Connection) *PostgresReservesRepository {
+	return &PostgresReservesRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+// ListAccountBalances lista os saldos positivos de todos os usuários em todos os ativos
+func (r *PostgresReservesRepository) ListAccountBalances(ctx context.Context) ([]entity.AccountBalance, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT user_id, $1::text, balance FROM user_context.wallet_info WHERE balance > 0
+		UNION ALL
+		SELECT user_id, currency, balance FROM user_context.wallet_balances WHERE balance > 0
+	`, string(sharedVO.BaseCurrency))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.AccountBalance
+	for rows.Next() {
+		var b entity.AccountBalance
+		if err := rows.Scan(&b.UserID, &b.Asset, &b.Balance); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// Save grava o snapshot com as raízes e todas as folhas em uma única transação
+func (r *PostgresReservesRepository) Save(ctx context.Context, snapshot *entity.ReservesSnapshot, leaves []entity.ReservesLeaf) error {
+	assets, err := json.Marshal(snapshot.Assets)
+	if err != nil {
+		return err
+	}
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.reserves_snapshots (id, assets, created_at)
+		VALUES ($1, $2::jsonb, $3)
+	`, snapshot.ID, string(assets), snapshot.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, leaf := range leaves {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO `+r.schema+`.reserves_leaves (snapshot_id, asset, user_id, nonce, leaf_id, balance, position)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`,
+			leaf.SnapshotID,
+			leaf.Asset,
+			leaf.UserID,
+			leaf.Nonce,
+			leaf.LeafID,
+			leaf.Balance,
+			leaf.Position,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Find busca o snapshot por ID
+func (r *PostgresReservesRepository) Find(ctx context.Context, id uuid.UUID) (*entity.ReservesSnapshot, error) {
+	return r.findSnapshot(r.conn.QueryRow(ctx, `
+		SELECT id, assets, created_at FROM `+r.schema+`.reserves_snapshots WHERE id = $1
+	`, id))
+}
+
+// Latest busca o snapshot mais recente
+func (r *PostgresReservesRepository) Latest(ctx context.Context) (*entity.ReservesSnapshot, error) {
+	return r.findSnapshot(r.conn.QueryRow(ctx, `
+		SELECT id, assets, created_at FROM `+r.schema+`.reserves_snapshots ORDER BY created_at DESC LIMIT 1
+	`))
+}
+
+// List lista os snapshots mais recentes
+func (r *PostgresReservesRepository) List(ctx context.Context, limit int) ([]*entity.ReservesSnapshot, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT id, assets, created_at
+		FROM `+r.schema+`.reserves_snapshots
+		ORDER BY created_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ReservesSnapshot
+	for rows.Next() {
+		snapshot, err := scanReservesSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, snapshot)
+	}
+	return out, rows.Err()
+}
+
+// ListLeaves lista as folhas do ativo no snapshot na ordem da árvore
+func (r *PostgresReservesRepository) ListLeaves(ctx context.Context, snapshotID uuid.UUID, asset string) ([]entity.ReservesLeaf, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT snapshot_id, asset, user_id, nonce, leaf_id, balance, position
+		FROM `+r.schema+`.reserves_leaves
+		WHERE snapshot_id = $1 AND asset = $2
+		ORDER BY position
+	`, snapshotID, asset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []entity.ReservesLeaf
+	for rows.Next() {
+		var leaf entity.ReservesLeaf
+		if err := rows.Scan(&leaf.SnapshotID, &leaf.Asset, &leaf.UserID, &leaf.Nonce, &leaf.LeafID,
+			&leaf.Balance, &leaf.Position); err != nil {
+			return nil, err
+		}
+		out = append(out, leaf)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresReservesRepository) findSnapshot(row rowScanner) (*entity.ReservesSnapshot, error) {
+	snapshot, err := scanReservesSnapshot(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func scanReservesSnapshot(row rowScanner) (*entity.ReservesSnapshot, error) {
+	snapshot := &entity.ReservesSnapshot{}
+	var assets []byte
+	if err := row.Scan(&snapshot.ID, &assets, &snapshot.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(assets, &snapshot.Assets); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresReversalRepository implementa ReversalRepository usando PostgreSQL
+type PostgresReversalRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresReversalRepository cria um novo repositório de estornos
+func NewPostgresReversalRepository(conn database.Connection) *PostgresReversalRepository {
+	return &PostgresReversalRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const reversalColumns = `id, original_id, transaction_id, amount, reason, note, operator_id, created_at`
+
+// Create bloqueia a transação original e grava o estorno somente se couber no limite
+func (r *PostgresReversalRepository) Create(ctx context.Context, rev *entity.Reversal, limit decimal.Decimal) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Serializa estornos concorrentes da mesma transação
+	var originalID uuid.UUID
+	if err := tx.QueryRow(ctx, `SELECT id FROM `+r.schema+`.transactions WHERE id = $1 FOR UPDATE`, rev.OriginalID).Scan(&originalID); err != nil {
+		return err
+	}
+
+	var total decimal.Decimal
+	if err := tx.QueryRow(ctx, r.totalQuery(), rev.OriginalID).Scan(&total); err != nil {
+		return err
+	}
+	if total.Add(rev.Amount).GreaterThan(limit) {
+		return entity.ErrReversalExceedsOriginal
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.transaction_reversals (`+reversalColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		rev.ID,
+		rev.OriginalID,
+		rev.TransactionID,
+		rev.Amount,
+		string(rev.Reason),
+		rev.Note,
+		rev.OperatorID,
+		rev.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListByOriginal lista os estornos da transação em ordem cronológica
+func (r *PostgresReversalRepository) ListByOriginal(ctx context.Context, originalID uuid.UUID) ([]*entity.Reversal, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+reversalColumns+`
+		FROM `+r.schema+`.transaction_reversals
+		WHERE original_id = $1
+		ORDER BY created_at
+	`, originalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Reversal
+	for rows.Next() {
+		var (
+			rev    entity.Reversal
+			reason string
+		)
+		if err := rows.Scan(&rev.ID, &rev.OriginalID, &rev.TransactionID, &rev.Amount, &reason, &rev.Note, &rev.OperatorID, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		rev.Reason = entity.ReversalReason(reason)
+		out = append(out, &rev)
+	}
+	return out, rows.Err()
+}
+
+// TotalReversed soma os estornos cuja transação de estorno não falhou
+func (r *PostgresReversalRepository) TotalReversed(ctx context.Context, originalID uuid.UUID) (decimal.Decimal, error) {
+	var total decimal.Decimal
+	if err := r.conn.QueryRow(ctx, r.totalQuery(), originalID).Scan(&total); err != nil {
+		return decimal.Zero, err
+	}
+	return total, nil
+}
+
+func (r *PostgresReversalRepository) totalQuery() string {
+	return `
+		SELECT COALESCE(SUM(rv.amount), 0)
+		FROM ` + r.schema + `.transaction_reversals rv
+		JOIN ` + r.schema + `.transactions t ON t.id = rv.transaction_id
+		WHERE rv.original_id = $1 AND t.status <> 'failed'
+	`
+}
@@ -0,0 +1,266 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresScheduleRepository implementa ScheduleRepository usando PostgreSQL
+type PostgresScheduleRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresScheduleRepository cria um novo repositório de agendamentos
+func NewPostgresScheduleRepository(conn database.Connection) *PostgresScheduleRepository {
+	return &PostgresScheduleRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const scheduleColumns = `id, user_id, kind, amount, to_user_id, chain, to_address, description, recurrence,
+	start_at, end_at, max_runs, run_count, next_run_at, retry_at, attempts, max_retries, status, version,
+	created_at, updated_at`
+
+const executionColumns = `id, schedule_id, occurrence, attempt, status, transaction_id, error, started_at, finished_at`
+
+// Create insere um novo agendamento
+func (r *PostgresScheduleRepository) Create(ctx context.Context, s *entity.ScheduledTransfer) error {
+	_, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.scheduled_transfers (`+scheduleColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+	`,
+		s.ID,
+		s.UserID,
+		string(s.Kind),
+		s.Amount,
+		s.ToUserID,
+		s.Chain,
+		s.ToAddress,
+		s.Description,
+		s.Recurrence,
+		s.StartAt,
+		s.EndAt,
+		s.MaxRuns,
+		s.RunCount,
+		s.NextRunAt,
+		s.RetryAt,
+		s.Attempts,
+		s.MaxRetries,
+		string(s.Status),
+		s.Version,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	return err
+}
+
+// FindByID busca um agendamento por ID
+func (r *PostgresScheduleRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ScheduledTransfer, error) {
+	s, err := scanSchedule(r.conn.QueryRow(ctx, `SELECT `+scheduleColumns+` FROM `+r.schema+`.scheduled_transfers WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// ListByUser lista os agendamentos do usuário
+func (r *PostgresScheduleRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.ScheduledTransfer, error) {
+	return r.query(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM `+r.schema+`.scheduled_transfers
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`, userID)
+}
+
+// ListDue lista os agendamentos ativos vencidos, mais atrasados primeiro
+func (r *PostgresScheduleRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ScheduledTransfer, error) {
+	return r.query(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM `+r.schema+`.scheduled_transfers
+		WHERE status = 'active' AND COALESCE(retry_at, next_run_at) <= $1
+		ORDER BY COALESCE(retry_at, next_run_at)
+		LIMIT $2
+	`, now, limit)
+}
+
+// Update grava o estado com controle otimista por versão
+func (r *PostgresScheduleRepository) Update(ctx context.Context, s *entity.ScheduledTransfer) error {
+	result, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.scheduled_transfers
+		SET run_count = $3, next_run_at = $4, retry_at = $5, attempts = $6, status = $7,
+			version = version + 1, updated_at = $8
+		WHERE id = $1 AND version = $2
+	`, s.ID, s.Version, s.RunCount, s.NextRunAt, s.RetryAt, s.Attempts, string(s.Status), s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrScheduleConflict
+	}
+	s.Version++
+	return nil
+}
+
+// StartExecution registra a tentativa; a chave única (schedule_id, occurrence, attempt) barra duplicatas
+func (r *PostgresScheduleRepository) StartExecution(ctx context.Context, exec *entity.ScheduleExecution) error {
+	result, err := r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.schedule_executions (`+executionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (schedule_id, occurrence, attempt) DO NOTHING
+	`,
+		exec.ID,
+		exec.ScheduleID,
+		exec.Occurrence,
+		exec.Attempt,
+		string(exec.Status),
+		exec.TransactionID,
+		exec.Error,
+		exec.StartedAt,
+		exec.FinishedAt,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrExecutionDuplicate
+	}
+	return nil
+}
+
+// FinishExecution grava o resultado da tentativa
+func (r *PostgresScheduleRepository) FinishExecution(ctx context.Context, exec *entity.ScheduleExecution) error {
+	_, err := r.conn.Exec(ctx, `
+		UPDATE `+r.schema+`.schedule_executions
+		SET status = $2, transaction_id = $3, error = $4, finished_at = $5
+		WHERE id = $1
+	`, exec.ID, string(exec.Status), exec.TransactionID, exec.Error, exec.FinishedAt)
+	return err
+}
+
+// ListExecutions lista o histórico de tentativas do agendamento
+func (r *PostgresScheduleRepository) ListExecutions(ctx context.Context, scheduleID uuid.UUID, limit int) ([]*entity.ScheduleExecution, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+executionColumns+`
+		FROM `+r.schema+`.schedule_executions
+		WHERE schedule_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`, scheduleID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ScheduleExecution
+	for rows.Next() {
+		var (
+			e          entity.ScheduleExecution
+			status     string
+			txID       uuid.NullUUID
+			finishedAt sql.NullTime
+		)
+		if err := rows.Scan(&e.ID, &e.ScheduleID, &e.Occurrence, &e.Attempt, &status, &txID, &e.Error, &e.StartedAt, &finishedAt); err != nil {
+			return nil, err
+		}
+		e.Status = entity.ScheduleExecutionStatus(status)
+		if txID.Valid {
+			e.TransactionID = &txID.UUID
+		}
+		if finishedAt.Valid {
+			e.FinishedAt = &finishedAt.Time
+		}
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresScheduleRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.ScheduledTransfer, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ScheduledTransfer
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func scanSchedule(row rowScanner) (*entity.ScheduledTransfer, error) {
+	s := &entity.ScheduledTransfer{}
+	var (
+		kind      string
+		status    string
+		toUserID  uuid.NullUUID
+		endAt     sql.NullTime
+		nextRunAt sql.NullTime
+		retryAt   sql.NullTime
+	)
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&kind,
+		&s.Amount,
+		&toUserID,
+		&s.Chain,
+		&s.ToAddress,
+		&s.Description,
+		&s.Recurrence,
+		&s.StartAt,
+		&endAt,
+		&s.MaxRuns,
+		&s.RunCount,
+		&nextRunAt,
+		&retryAt,
+		&s.Attempts,
+		&s.MaxRetries,
+		&status,
+		&s.Version,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.Kind = entity.ScheduleKind(kind)
+	s.Status = entity.ScheduleStatus(status)
+	if toUserID.Valid {
+		s.ToUserID = &toUserID.UUID
+	}
+	if endAt.Valid {
+		s.EndAt = &endAt.Time
+	}
+	if nextRunAt.Valid {
+		s.NextRunAt = &nextRunAt.Time
+	}
+	if retryAt.Valid {
+		s.RetryAt = &retryAt.Time
+	}
+	return s, nil
+}
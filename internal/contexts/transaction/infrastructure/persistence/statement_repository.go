@@ -0,0 +1,184 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresStatementRepository implementa StatementRepository usando PostgreSQL.
+// As carteiras são lidas de user_context.wallet_info para a geração mensal.
+type PostgresStatementRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresStatementRepository cria um novo repositório de extratos
+func NewPostgresStatementRepository(conn database.Connection) *PostgresStatementRepository {
+	return &PostgresStatementRepository{
+		conn:   conn,
+		schema: "transaction_context",
+	}
+}
+
+const statementColumns = `id, user_id, account, currency, period_start, period_end, opening_balance, closing_balance,
+	total_credits, total_debits, line_count, generated_at`
+
+// ListMovements lista as transações da carteira no intervalo
+func (r *PostgresStatementRepository) ListMovements(ctx context.Context, userID uuid.UUID, address string, from, to time.Time) ([]*entity.Transaction, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+transactionColumns+`
+		FROM `+r.schema+`.transactions
+		WHERE (user_id = $1 OR from_address = $2 OR to_address = $2)
+			AND status IN ('completed', 'awaiting_approval')
+			AND created_at >= $3 AND created_at < $4
+		ORDER BY created_at, id
+	`, userID, address, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Transaction
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tx)
+	}
+	return out, rows.Err()
+}
+
+// ListPendingAccounts lista as carteiras sem extrato gerado para o período
+func (r *PostgresStatementRepository) ListPendingAccounts(ctx context.Context, from, to time.Time, limit int) ([]uuid.UUID, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT w.user_id
+		FROM user_context.wallet_info w
+		WHERE NOT EXISTS (
+			SELECT 1 FROM `+r.schema+`.statements s
+			WHERE s.user_id = w.user_id AND s.period_start = $1 AND s.period_end = $2
+		)
+		ORDER BY w.user_id
+		LIMIT $3
+	`, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+// Save grava o extrato com as linhas em JSON, substituindo o do mesmo período
+func (r *PostgresStatementRepository) Save(ctx context.Context, st *entity.Statement) error {
+	lines, err := json.Marshal(st.Lines)
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.Exec(ctx, `
+		INSERT INTO `+r.schema+`.statements (`+statementColumns+`, lines)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13::jsonb)
+		ON CONFLICT (user_id, period_start, period_end) DO UPDATE
+		SET id = EXCLUDED.id, account = EXCLUDED.account, opening_balance = EXCLUDED.opening_balance,
+			closing_balance = EXCLUDED.closing_balance, total_credits = EXCLUDED.total_credits,
+			total_debits = EXCLUDED.total_debits, line_count = EXCLUDED.line_count,
+			generated_at = EXCLUDED.generated_at, lines = EXCLUDED.lines
+	`,
+		st.ID,
+		st.UserID,
+		st.Account,
+		st.Currency,
+		st.PeriodStart,
+		st.PeriodEnd,
+		st.OpeningBalance,
+		st.ClosingBalance,
+		st.TotalCredits,
+		st.TotalDebits,
+		st.LineCount,
+		st.GeneratedAt,
+		string(lines),
+	)
+	return err
+}
+
+// Find busca o extrato por ID, com as linhas
+func (r *PostgresStatementRepository) Find(ctx context.Context, id uuid.UUID) (*entity.Statement, error) {
+	var lines []byte
+	st, err := scanStatement(r.conn.QueryRow(ctx, `
+		SELECT `+statementColumns+`, lines
+		FROM `+r.schema+`.statements
+		WHERE id = $1
+	`, id), &lines)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(lines, &st.Lines); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// List lista os extratos do usuário
+func (r *PostgresStatementRepository) List(ctx context.Context, userID uuid.UUID, limit int) ([]*entity.Statement, error) {
+	rows, err := r.conn.Query(ctx, `
+		SELECT `+statementColumns+`
+		FROM `+r.schema+`.statements
+		WHERE user_id = $1
+		ORDER BY period_start DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Statement
+	for rows.Next() {
+		st, err := scanStatement(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+func scanStatement(row rowScanner, extra ...interface{}) (*entity.Statement, error) {
+	st := &entity.Statement{}
+	dest := []interface{}{
+		&st.ID,
+		&st.UserID,
+		&st.Account,
+		&st.Currency,
+		&st.PeriodStart,
+		&st.PeriodEnd,
+		&st.OpeningBalance,
+		&st.ClosingBalance,
+		&st.TotalCredits,
+		&st.TotalDebits,
+		&st.LineCount,
+		&st.GeneratedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
@@ -0,0 +1,206 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+	"financial-system-pro/internal/shared/cqrs"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresTransactionQueryRepository implementa cqrs.TransactionQueryRepository sobre a tabela de
+// transações, com filtros combináveis e paginação por cursor (keyset)
+type PostgresTransactionQueryRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresTransactionQueryRepository cria o repositório de consultas de transações
+func NewPostgresTransactionQueryRepository(conn database.Connection) *PostgresTransactionQueryRepository {
+	return &PostgresTransactionQueryRepository{conn: conn, schema: "transaction_context"}
+}
+
+// FindByID busca a transação pelo ID
+func (r *PostgresTransactionQueryRepository) FindByID(ctx context.Context, id string) (*cqrs.TransactionReadModel, error) {
+	txID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	list, err := r.FindAll(ctx, &cqrs.TransactionQuery{ID: &txID, Limit: 1})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+// FindByUser lista as transações do usuário, mais recentes primeiro
+func (r *PostgresTransactionQueryRepository) FindByUser(ctx context.Context, userID string, limit, offset int) ([]*cqrs.TransactionReadModel, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.FindAll(ctx, &cqrs.TransactionQuery{UserID: &id, Limit: limit, Offset: offset})
+}
+
+// FindRecent lista as transações mais recentes da plataforma
+func (r *PostgresTransactionQueryRepository) FindRecent(ctx context.Context, limit int) ([]*cqrs.TransactionReadModel, error) {
+	return r.FindAll(ctx, &cqrs.TransactionQuery{Limit: limit})
+}
+
+// FindAll busca as transações que atendem aos filtros, na ordem e a partir do cursor da consulta
+func (r *PostgresTransactionQueryRepository) FindAll(ctx context.Context, query *cqrs.TransactionQuery) ([]*cqrs.TransactionReadModel, error) {
+	where, args, err := transactionQueryFilter(query, true)
+	if err != nil {
+		return nil, err
+	}
+	column, direction := transactionQuerySort(query)
+	sqlQuery := `SELECT ` + transactionColumns + ` FROM ` + r.schema + `.transactions` + where +
+		` ORDER BY ` + column + ` ` + direction + `, id ` + direction
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		sqlQuery += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if query.Offset > 0 {
+		args = append(args, query.Offset)
+		sqlQuery += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.conn.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*cqrs.TransactionReadModel
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, transactionReadModel(tx))
+	}
+	return out, rows.Err()
+}
+
+// Count conta as transações que atendem aos filtros, ignorando cursor, limite e offset
+func (r *PostgresTransactionQueryRepository) Count(ctx context.Context, query *cqrs.TransactionQuery) (int, error) {
+	where, args, err := transactionQueryFilter(query, false)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	err = r.conn.QueryRow(ctx, `SELECT COUNT(*) FROM `+r.schema+`.transactions`+where, args...).Scan(&n)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return n, err
+}
+
+// transactionQuerySort coluna e direção da ordenação; padrão created_at desc
+func transactionQuerySort(query *cqrs.TransactionQuery) (string, string) {
+	column := "created_at"
+	if query.SortBy == cqrs.TransactionSortAmount {
+		column = "amount"
+	}
+	if query.SortOrder == cqrs.SortAsc {
+		return column, "ASC"
+	}
+	return column, "DESC"
+}
+
+// transactionQueryFilter monta o WHERE parametrizado da consulta; com withCursor inclui a condição
+// keyset (valor da ordenação, id) após a última linha da página anterior
+func transactionQueryFilter(query *cqrs.TransactionQuery, withCursor bool) (string, []interface{}, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, values ...interface{}) {
+		for _, v := range values {
+			args = append(args, v)
+			cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(args)), 1)
+		}
+		conds = append(conds, cond)
+	}
+
+	if query.ID != nil {
+		add("id = ?", *query.ID)
+	}
+	if query.UserID != nil {
+		add("user_id = ?", *query.UserID)
+	}
+	if query.Type != nil {
+		add("type = ?", *query.Type)
+	}
+	if query.Status != nil {
+		add("status = ?", *query.Status)
+	}
+	if query.Chain != nil {
+		add("chain = ?", *query.Chain)
+	}
+	if query.TxHash != nil {
+		add("transaction_hash = ?", *query.TxHash)
+	}
+	if query.Counterparty != nil {
+		add("(from_address = ? OR to_address = ?)", *query.Counterparty, *query.Counterparty)
+	}
+	if query.MinAmount != nil {
+		add("amount >= ?", *query.MinAmount)
+	}
+	if query.MaxAmount != nil {
+		add("amount <= ?", *query.MaxAmount)
+	}
+	if query.FromDate != nil {
+		add("created_at >= ?", *query.FromDate)
+	}
+	if query.ToDate != nil {
+		add("created_at < ?", *query.ToDate)
+	}
+
+	if withCursor && query.Cursor != "" {
+		cursor, err := cqrs.DecodeTransactionCursor(query.Cursor, query.SortBy, query.SortOrder)
+		if err != nil {
+			return "", nil, err
+		}
+		column, direction := transactionQuerySort(query)
+		op := "<"
+		if direction == "ASC" {
+			op = ">"
+		}
+		var value interface{} = cursor.CreatedAt
+		if column == "amount" {
+			value = cursor.Amount
+		}
+		add("("+column+", id) "+op+" (?, ?)", value, cursor.ID)
+	}
+
+	if len(conds) == 0 {
+		return "", args, nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args, nil
+}
+
+// transactionReadModel projeta a transação no modelo de leitura
+func transactionReadModel(tx *entity.Transaction) *cqrs.TransactionReadModel {
+	return &cqrs.TransactionReadModel{
+		ID:          tx.ID,
+		UserID:      tx.UserID,
+		Type:        string(tx.Type),
+		Amount:      tx.Amount,
+		Status:      string(tx.Status),
+		Blockchain:  tx.Chain,
+		TxHash:      tx.TransactionHash,
+		FromAddress: tx.FromAddress,
+		ToAddress:   tx.ToAddress,
+		Fee:         tx.Fee,
+		CreatedAt:   tx.CreatedAt,
+		UpdatedAt:   tx.UpdatedAt,
+		CompletedAt: tx.CompletedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/shared/cqrs"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestTransactionQueryFilter(t *testing.T) {
+	user := uuid.New()
+	chain := "ethereum"
+	party := "0xabc"
+	min := decimal.NewFromInt(10)
+	query := &cqrs.TransactionQuery{UserID: &user, Chain: &chain, Counterparty: &party, MinAmount: &min}
+
+	where, args, err := transactionQueryFilter(query, true)
+	if err != nil {
+		t.Fatalf("filtro: %v", err)
+	}
+	expected := " WHERE user_id = $1 AND chain = $2 AND (from_address = $3 OR to_address = $4) AND amount >= $5"
+	if where != expected || len(args) != 5 {
+		t.Fatalf("WHERE inesperado: %q %v", where, args)
+	}
+
+	query.SortBy, query.SortOrder = cqrs.TransactionSortAmount, cqrs.SortAsc
+	query.Cursor = cqrs.CursorAfter(&cqrs.TransactionReadModel{ID: uuid.New(), Amount: min, CreatedAt: time.Now()}, cqrs.TransactionSortAmount, cqrs.SortAsc).Encode()
+	where, args, _ = transactionQueryFilter(query, true)
+	if where != expected+" AND (amount, id) > ($6, $7)" || len(args) != 7 {
+		t.Fatalf("condição keyset ausente: %q", where)
+	}
+	if where, _, _ = transactionQueryFilter(query, false); where != expected {
+		t.Fatalf("contagem não deve usar o cursor: %q", where)
+	}
+	if column, direction := transactionQuerySort(&cqrs.TransactionQuery{}); column != "created_at" || direction != "DESC" {
+		t.Fatalf("ordenação padrão inesperada: %s %s", column, direction)
+	}
+
+	query.SortOrder = cqrs.SortDesc
+	if _, _, err := transactionQueryFilter(query, true); !errors.Is(err, cqrs.ErrInvalidCursor) {
+		t.Fatalf("cursor de outra ordenação deveria falhar, obtido %v", err)
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"financial-system-pro/internal/contexts/transaction/domain/entity"
 	"financial-system-pro/internal/shared/database"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // PostgresTransactionRepository implementa TransactionRepository usando PostgreSQL
@@ -25,13 +28,14 @@ func NewPostgresTransactionRepository(conn database.Connection) *PostgresTransac
 	}
 }
 
+const transactionColumns = `id, user_id, type, amount, status, transaction_hash, from_address, to_address,
+	callback_url, error_message, created_at, updated_at, completed_at, risk_score, fee, parent_id, chain`
+
 // Create insere uma nova transação no banco
 func (r *PostgresTransactionRepository) Create(ctx context.Context, tx *entity.Transaction) error {
 	query := `
-		INSERT INTO ` + r.schema + `.transactions 
-		(id, user_id, type, amount, status, transaction_hash, from_address, to_address, 
-		 callback_url, error_message, created_at, updated_at, completed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO ` + r.schema + `.transactions (` + transactionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
 	_, err := r.conn.Exec(ctx, query,
@@ -48,6 +52,10 @@ func (r *PostgresTransactionRepository) Create(ctx context.Context, tx *entity.T
 		tx.CreatedAt,
 		tx.UpdatedAt,
 		tx.CompletedAt,
+		tx.RiskScore,
+		tx.Fee,
+		tx.ParentID,
+		tx.Chain,
 	)
 
 	return err
@@ -55,32 +63,9 @@ func (r *PostgresTransactionRepository) Create(ctx context.Context, tx *entity.T
 
 // FindByID busca uma transação por ID
 func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
-	query := `
-		SELECT id, user_id, type, amount, status, transaction_hash, from_address, to_address,
-		       callback_url, error_message, created_at, updated_at, completed_at
-		FROM ` + r.schema + `.transactions
-		WHERE id = $1
-	`
-
-	tx := &entity.Transaction{}
-	var completedAt sql.NullTime
-
-	err := r.conn.QueryRow(ctx, query, id).Scan(
-		&tx.ID,
-		&tx.UserID,
-		&tx.Type,
-		&tx.Amount,
-		&tx.Status,
-		&tx.TransactionHash,
-		&tx.FromAddress,
-		&tx.ToAddress,
-		&tx.CallbackURL,
-		&tx.ErrorMessage,
-		&tx.CreatedAt,
-		&tx.UpdatedAt,
-		&completedAt,
-	)
+	query := `SELECT ` + transactionColumns + ` FROM ` + r.schema + `.transactions WHERE id = $1`
 
+	tx, err := scanTransaction(r.conn.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -88,18 +73,13 @@ func (r *PostgresTransactionRepository) FindByID(ctx context.Context, id uuid.UU
 		return nil, err
 	}
 
-	if completedAt.Valid {
-		tx.CompletedAt = &completedAt.Time
-	}
-
 	return tx, nil
 }
 
 // FindByUserID busca todas as transações de um usuário
 func (r *PostgresTransactionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Transaction, error) {
 	query := `
-		SELECT id, user_id, type, amount, status, transaction_hash, from_address, to_address,
-		       callback_url, error_message, created_at, updated_at, completed_at
+		SELECT ` + transactionColumns + `
 		FROM ` + r.schema + `.transactions
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -114,33 +94,10 @@ func (r *PostgresTransactionRepository) FindByUserID(ctx context.Context, userID
 	var transactions []*entity.Transaction
 
 	for rows.Next() {
-		tx := &entity.Transaction{}
-		var completedAt sql.NullTime
-
-		err := rows.Scan(
-			&tx.ID,
-			&tx.UserID,
-			&tx.Type,
-			&tx.Amount,
-			&tx.Status,
-			&tx.TransactionHash,
-			&tx.FromAddress,
-			&tx.ToAddress,
-			&tx.CallbackURL,
-			&tx.ErrorMessage,
-			&tx.CreatedAt,
-			&tx.UpdatedAt,
-			&completedAt,
-		)
-
+		tx, err := scanTransaction(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		if completedAt.Valid {
-			tx.CompletedAt = &completedAt.Time
-		}
-
 		transactions = append(transactions, tx)
 	}
 
@@ -153,32 +110,9 @@ func (r *PostgresTransactionRepository) FindByUserID(ctx context.Context, userID
 
 // FindByHash busca uma transação por hash
 func (r *PostgresTransactionRepository) FindByHash(ctx context.Context, hash string) (*entity.Transaction, error) {
-	query := `
-		SELECT id, user_id, type, amount, status, transaction_hash, from_address, to_address,
-		       callback_url, error_message, created_at, updated_at, completed_at
-		FROM ` + r.schema + `.transactions
-		WHERE transaction_hash = $1
-	`
-
-	tx := &entity.Transaction{}
-	var completedAt sql.NullTime
-
-	err := r.conn.QueryRow(ctx, query, hash).Scan(
-		&tx.ID,
-		&tx.UserID,
-		&tx.Type,
-		&tx.Amount,
-		&tx.Status,
-		&tx.TransactionHash,
-		&tx.FromAddress,
-		&tx.ToAddress,
-		&tx.CallbackURL,
-		&tx.ErrorMessage,
-		&tx.CreatedAt,
-		&tx.UpdatedAt,
-		&completedAt,
-	)
+	query := `SELECT ` + transactionColumns + ` FROM ` + r.schema + `.transactions WHERE transaction_hash = $1`
 
+	tx, err := scanTransaction(r.conn.QueryRow(ctx, query, hash))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -186,10 +120,6 @@ func (r *PostgresTransactionRepository) FindByHash(ctx context.Context, hash str
 		return nil, err
 	}
 
-	if completedAt.Valid {
-		tx.CompletedAt = &completedAt.Time
-	}
-
 	return tx, nil
 }
 
@@ -198,7 +128,7 @@ func (r *PostgresTransactionRepository) Update(ctx context.Context, tx *entity.T
 	query := `
 		UPDATE ` + r.schema + `.transactions
 		SET status = $2, transaction_hash = $3, error_message = $4, 
-		    updated_at = $5, completed_at = $6
+		    updated_at = $5, completed_at = $6, risk_score = $7
 		WHERE id = $1
 	`
 
@@ -211,6 +141,7 @@ func (r *PostgresTransactionRepository) Update(ctx context.Context, tx *entity.T
 		tx.ErrorMessage,
 		tx.UpdatedAt,
 		tx.CompletedAt,
+		tx.RiskScore,
 	)
 
 	return err
@@ -227,3 +158,104 @@ func (r *PostgresTransactionRepository) UpdateStatus(ctx context.Context, id uui
 	_, err := r.conn.Exec(ctx, query, id, status, time.Now())
 	return err
 }
+
+// SumAmountSince soma no banco o valor das transações do período, sem carregar o histórico
+func (r *PostgresTransactionRepository) SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	if len(types) == 0 {
+		return decimal.Zero, nil
+	}
+	args := []interface{}{userID, entity.TransactionStatusFailed, since}
+	placeholders := make([]string, len(types))
+	for i, t := range types {
+		args = append(args, t)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM ` + r.schema + `.transactions
+		WHERE user_id = $1 AND status <> $2 AND created_at >= $3 AND type IN (` + strings.Join(placeholders, ", ") + `)
+	`
+
+	var total decimal.Decimal
+	if err := r.conn.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return decimal.Zero, err
+	}
+	return total, nil
+}
+
+// FindUncollectedFees busca as operações concluídas cuja taxa não chegou à carteira de receita.
+// Cobranças falhas não contam, então a operação volta a ser listada até a taxa ser registrada.
+func (r *PostgresTransactionRepository) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM ` + r.schema + `.transactions t
+		WHERE t.fee > 0 AND t.status = $1 AND t.type <> $2 AND t.completed_at < $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM ` + r.schema + `.transactions f
+			WHERE f.parent_id = t.id AND f.type = $2 AND f.status <> $4
+		  )
+		ORDER BY t.completed_at
+		LIMIT $5
+	`
+
+	rows, err := r.conn.Query(ctx, query,
+		entity.TransactionStatusCompleted, entity.TransactionTypeFee, before, entity.TransactionStatusFailed, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*entity.Transaction
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTransaction(row rowScanner) (*entity.Transaction, error) {
+	tx := &entity.Transaction{}
+	var (
+		completedAt sql.NullTime
+		parentID    uuid.NullUUID
+	)
+
+	err := row.Scan(
+		&tx.ID,
+		&tx.UserID,
+		&tx.Type,
+		&tx.Amount,
+		&tx.Status,
+		&tx.TransactionHash,
+		&tx.FromAddress,
+		&tx.ToAddress,
+		&tx.CallbackURL,
+		&tx.ErrorMessage,
+		&tx.CreatedAt,
+		&tx.UpdatedAt,
+		&completedAt,
+		&tx.RiskScore,
+		&tx.Fee,
+		&parentID,
+		&tx.Chain,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		tx.CompletedAt = &completedAt.Time
+	}
+	if parentID.Valid {
+		tx.ParentID = &parentID.UUID
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,228 @@
+package pix
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidSignature assinatura do webhook ausente ou diferente da esperada
+	ErrInvalidSignature = errors.New("invalid pix webhook signature")
+	// ErrLocationNotFound URL de cobrança dinâmica desconhecida pelo PSP
+	ErrLocationNotFound = errors.New("pix charge location not found")
+)
+
+// simulatorISPB ISPB fictício usado nos EndToEndIDs gerados pelo simulador
+const simulatorISPB = "99999999"
+
+type simCharge struct {
+	txid      string
+	amount    decimal.Decimal
+	expiresAt time.Time
+}
+
+// Simulator PSP em memória para desenvolvimento e testes offline: registra cobranças dinâmicas,
+// aceita ordens de pagamento (pagamentos para a própria chave voltam como crédito) e gera créditos
+// e webhooks assinados sob demanda
+type Simulator struct {
+	mu       sync.Mutex
+	key      string
+	host     string
+	secret   string
+	charges  map[string]simCharge
+	credits  []entity.PixCredit
+	payments map[uuid.UUID]string
+	now      func() time.Time
+}
+
+// NewSimulator cria o simulador da conta com a chave informada; baseURL é o host das cobranças
+// dinâmicas e secret a chave exigida na assinatura dos webhooks (sem ela todo webhook é rejeitado)
+func NewSimulator(key, baseURL, secret string) *Simulator {
+	if baseURL == "" {
+		baseURL = "pix.example.com/qr"
+	}
+	return &Simulator{
+		key:      key,
+		host:     strings.TrimSuffix(strings.TrimPrefix(baseURL, "https://"), "/"),
+		secret:   secret,
+		charges:  make(map[string]simCharge),
+		payments: make(map[uuid.UUID]string),
+		now:      time.Now,
+	}
+}
+
+// CreateCharge registra a cobrança dinâmica e retorna a URL do payload
+func (s *Simulator) CreateCharge(_ context.Context, txid string, amount decimal.Decimal, _ string, ttl time.Duration) (entity.PixChargeLocation, error) {
+	if !entity.ValidDynamicTxID(txid) {
+		return entity.PixChargeLocation{}, fmt.Errorf("%w: invalid txid", entity.ErrInvalidPixCharge)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	url := s.host + "/v2/" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	expiresAt := s.now().Add(ttl)
+	s.charges[url] = simCharge{txid: txid, amount: amount, expiresAt: expiresAt}
+	return entity.PixChargeLocation{URL: url, ExpiresAt: expiresAt}, nil
+}
+
+// ResolveLocation retorna chave, valor e txid da cobrança registrada na URL
+func (s *Simulator) ResolveLocation(_ context.Context, url string) (*entity.BRCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	url = strings.TrimPrefix(url, "https://")
+	charge, ok := s.charges[url]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+	return &entity.BRCode{Dynamic: true, Key: s.key, URL: url, Amount: charge.amount, TxID: charge.txid}, nil
+}
+
+// SendPayment aceita a ordem uma única vez por ID; pagamentos para a própria chave geram o crédito
+// correspondente, como o PSP faria ao liquidar
+func (s *Simulator) SendPayment(_ context.Context, order entity.PixPaymentOrder) (string, error) {
+	if order.Key == "" || !order.Amount.IsPositive() {
+		return "", fmt.Errorf("%w: payment requires key and positive amount", entity.ErrInvalidPixCharge)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endToEndID, ok := s.payments[order.ID]; ok {
+		return endToEndID, nil
+	}
+	endToEndID := s.endToEndID()
+	s.payments[order.ID] = endToEndID
+	if order.Key == s.key {
+		s.credits = append(s.credits, entity.PixCredit{
+			EndToEndID: endToEndID,
+			TxID:       order.TxID,
+			Amount:     order.Amount,
+			PaidAt:     s.now(),
+		})
+	}
+	return endToEndID, nil
+}
+
+// SimulateCredit registra um Pix recebido para o txid, como se pago por um banco externo
+func (s *Simulator) SimulateCredit(txid string, amount decimal.Decimal, payerName string) entity.PixCredit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	credit := entity.PixCredit{
+		EndToEndID: s.endToEndID(),
+		TxID:       txid,
+		Amount:     amount,
+		PayerName:  payerName,
+		PaidAt:     s.now(),
+	}
+	s.credits = append(s.credits, credit)
+	return credit
+}
+
+// ListCredits lista os créditos pagos no intervalo [from, to]
+func (s *Simulator) ListCredits(_ context.Context, from, to time.Time) ([]entity.PixCredit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []entity.PixCredit
+	for _, credit := range s.credits {
+		if !credit.PaidAt.Before(from) && !credit.PaidAt.After(to) {
+			out = append(out, credit)
+		}
+	}
+	return out, nil
+}
+
+// webhookBody formato do webhook de Pix recebidos da API Pix do Banco Central
+type webhookBody struct {
+	Pix []webhookCredit `json:"pix"`
+}
+
+type webhookCredit struct {
+	EndToEndID string          `json:"endToEndId"`
+	TxID       string          `json:"txid,omitempty"`
+	Valor      decimal.Decimal `json:"valor"`
+	Horario    time.Time       `json:"horario"`
+	Pagador    *webhookPayer   `json:"pagador,omitempty"`
+}
+
+type webhookPayer struct {
+	Nome string `json:"nome,omitempty"`
+	CPF  string `json:"cpf,omitempty"`
+	CNPJ string `json:"cnpj,omitempty"`
+}
+
+// Notification monta o corpo do webhook dos créditos e a respectiva assinatura
+func (s *Simulator) Notification(credits ...entity.PixCredit) ([]byte, string, error) {
+	body := webhookBody{Pix: make([]webhookCredit, 0, len(credits))}
+	for _, c := range credits {
+		item := webhookCredit{EndToEndID: c.EndToEndID, TxID: c.TxID, Valor: c.Amount, Horario: c.PaidAt}
+		if c.PayerName != "" || c.PayerDocument != "" {
+			item.Pagador = &webhookPayer{Nome: c.PayerName, CPF: c.PayerDocument}
+		}
+		body.Pix = append(body.Pix, item)
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, Sign(s.secret, raw), nil
+}
+
+// ParseNotification valida a assinatura HMAC-SHA256 e decodifica os créditos. Sem segredo
+// configurado ou sem assinatura o webhook é rejeitado.
+func (s *Simulator) ParseNotification(body []byte, signature string) ([]entity.PixCredit, error) {
+	if s.secret == "" || signature == "" || !hmac.Equal([]byte(Sign(s.secret, body)), []byte(strings.ToLower(signature))) {
+		return nil, ErrInvalidSignature
+	}
+	return ParseWebhook(body)
+}
+
+// ParseWebhook decodifica o corpo do webhook de Pix recebidos
+func ParseWebhook(body []byte) ([]entity.PixCredit, error) {
+	var payload webhookBody
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode pix webhook: %w", err)
+	}
+	credits := make([]entity.PixCredit, 0, len(payload.Pix))
+	for _, item := range payload.Pix {
+		credit := entity.PixCredit{
+			EndToEndID: item.EndToEndID,
+			TxID:       item.TxID,
+			Amount:     item.Valor,
+			PaidAt:     item.Horario,
+		}
+		if item.Pagador != nil {
+			credit.PayerName = item.Pagador.Nome
+			credit.PayerDocument = item.Pagador.CPF
+			if credit.PayerDocument == "" {
+				credit.PayerDocument = item.Pagador.CNPJ
+			}
+		}
+		credits = append(credits, credit)
+	}
+	return credits, nil
+}
+
+// Sign assinatura HMAC-SHA256 do corpo em hexadecimal; vazia sem segredo
+func Sign(secret string, body []byte) string {
+	if secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// endToEndID E + ISPB + data/hora (AAAAMMDDHHMM) + 11 caracteres aleatórios
+func (s *Simulator) endToEndID() string {
+	return "E" + simulatorISPB + s.now().UTC().Format("200601021504") + entity.NewPixTxID(11)
+}
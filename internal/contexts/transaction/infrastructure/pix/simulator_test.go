@@ -0,0 +1,90 @@
+package pix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/transaction/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestSimulator_DynamicChargeAndLoopbackPayment(t *testing.T) {
+	sim := NewSimulator("key@example.com", "https://psp.test/qr", "")
+	ctx := context.Background()
+	txid := entity.NewPixTxID(35)
+
+	loc, err := sim.CreateCharge(ctx, txid, decimal.NewFromInt(42), "", time.Minute)
+	if err != nil {
+		t.Fatalf("cobrança: %v", err)
+	}
+	code, err := sim.ResolveLocation(ctx, "https://"+loc.URL)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if code.Key != "key@example.com" || code.TxID != txid || !code.Amount.Equal(decimal.NewFromInt(42)) {
+		t.Fatalf("cobrança resolvida incorreta: %+v", code)
+	}
+	if _, err := sim.ResolveLocation(ctx, "psp.test/qr/v2/unknown"); !errors.Is(err, ErrLocationNotFound) {
+		t.Fatalf("esperado ErrLocationNotFound, obtido %v", err)
+	}
+
+	order := entity.PixPaymentOrder{ID: uuid.New(), Key: code.Key, TxID: txid, Amount: code.Amount}
+	first, err := sim.SendPayment(ctx, order)
+	if err != nil {
+		t.Fatalf("pagamento: %v", err)
+	}
+	if len(first) != 32 || first[0] != 'E' {
+		t.Fatalf("EndToEndID inválido: %q", first)
+	}
+	again, _ := sim.SendPayment(ctx, order)
+	if again != first {
+		t.Fatalf("envio repetido deveria ser idempotente: %s != %s", again, first)
+	}
+
+	credits, _ := sim.ListCredits(ctx, time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	if len(credits) != 1 || credits[0].TxID != txid || credits[0].EndToEndID != first {
+		t.Fatalf("pagamento para a própria chave deveria gerar um crédito: %+v", credits)
+	}
+}
+
+func TestSimulator_SignedNotification(t *testing.T) {
+	sim := NewSimulator("key", "", "s3cret")
+	credit := sim.SimulateCredit("abc123", decimal.RequireFromString("10.50"), "Fulano")
+
+	body, signature, err := sim.Notification(credit)
+	if err != nil {
+		t.Fatalf("notificação: %v", err)
+	}
+	credits, err := sim.ParseNotification(body, signature)
+	if err != nil {
+		t.Fatalf("webhook assinado rejeitado: %v", err)
+	}
+	if len(credits) != 1 || credits[0].EndToEndID != credit.EndToEndID || credits[0].PayerName != "Fulano" ||
+		!credits[0].Amount.Equal(credit.Amount) {
+		t.Fatalf("crédito decodificado incorreto: %+v", credits)
+	}
+
+	if _, err := sim.ParseNotification(body, "deadbeef"); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("esperado ErrInvalidSignature, obtido %v", err)
+	}
+	if _, err := sim.ParseNotification(body, ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("webhook sem assinatura deveria ser rejeitado, obtido %v", err)
+	}
+}
+
+func TestSimulator_NotificationWithoutSecretRejected(t *testing.T) {
+	sim := NewSimulator("key", "", "")
+	credit := sim.SimulateCredit("abc123", decimal.RequireFromString("10.50"), "Fulano")
+
+	body, signature, err := sim.Notification(credit)
+	if err != nil {
+		t.Fatalf("notificação: %v", err)
+	}
+	if _, err := sim.ParseNotification(body, signature); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("sem segredo o webhook deveria ser rejeitado, obtido %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package scheduling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	// TypeScheduledTransfer tarefa de execução de uma ocorrência agendada
+	TypeScheduledTransfer = "transaction:scheduled_transfer"
+	// Queue fila das execuções agendadas (a mesma das demais transações)
+	Queue = "transactions"
+	// maxTaskRetries novas tentativas da fila para falhas de infraestrutura
+	maxTaskRetries = 5
+)
+
+// task payload de uma tarefa do contexto de transações. O TaskID derivado da tarefa impede que
+// varreduras concorrentes enfileirem a mesma tarefa duas vezes.
+type task interface {
+	TaskID() string
+}
+
+// dispatcher enfileira as tarefas de um tipo no asynq
+type dispatcher[T task] struct {
+	client   *asynq.Client
+	taskType string
+}
+
+// newDispatcher cria o dispatcher do tipo de tarefa sobre o client informado
+func newDispatcher[T task](client *asynq.Client, taskType string) *dispatcher[T] {
+	return &dispatcher[T]{client: client, taskType: taskType}
+}
+
+// Dispatch enfileira a tarefa; tarefas já enfileiradas são ignoradas
+func (d *dispatcher[T]) Dispatch(ctx context.Context, t T) error {
+	return enqueue(ctx, d.client, d.taskType, t)
+}
+
+// enqueue enfileira a tarefa com o TaskID dela, ignorando tarefas já enfileiradas
+func enqueue[T task](ctx context.Context, client *asynq.Client, taskType string, t T, opts ...asynq.Option) error {
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	opts = append([]asynq.Option{
+		asynq.Queue(Queue),
+		asynq.TaskID(t.TaskID()),
+		asynq.MaxRetry(maxTaskRetries),
+	}, opts...)
+	_, err = client.EnqueueContext(ctx, asynq.NewTask(taskType, payload), opts...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) || errors.Is(err, asynq.ErrDuplicateTask) {
+		return nil
+	}
+	return err
+}
+
+// handler cria o handler do worker que decodifica o payload e executa a tarefa; payloads
+// inválidos não voltam para a fila
+func handler[T any](name string, execute func(context.Context, T) error) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, at *asynq.Task) error {
+		var t T
+		if err := json.Unmarshal(at.Payload(), &t); err != nil {
+			return fmt.Errorf("decode %s: %v: %w", name, err, asynq.SkipRetry)
+		}
+		return execute(ctx, t)
+	})
+}
+
+// NewAsynqDispatcher cria o dispatcher das execuções vencidas sobre o client informado
+func NewAsynqDispatcher(client *asynq.Client) *dispatcher[txnSvc.ScheduledRun] {
+	return newDispatcher[txnSvc.ScheduledRun](client, TypeScheduledTransfer)
+}
+
+// NewHandler cria o handler do worker que executa as ocorrências entregues pela fila
+func NewHandler(schedules *txnSvc.ScheduleService) asynq.Handler {
+	return handler("scheduled run", schedules.Execute)
+}
@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeDailyCreditAccrual tarefa de apropriação diária de juros das linhas de crédito
+const TypeDailyCreditAccrual = "transaction:daily_credit_accrual"
+
+// NewAsynqCreditDispatcher cria o dispatcher que enfileira a apropriação diária no asynq; o TaskID do
+// dia impede que varreduras seguidas enfileirem a mesma apropriação duas vezes
+func NewAsynqCreditDispatcher(client *asynq.Client) *dispatcher[txnSvc.CreditAccrualTask] {
+	return newDispatcher[txnSvc.CreditAccrualTask](client, TypeDailyCreditAccrual)
+}
+
+// NewCreditHandler cria o handler do worker que executa as apropriações entregues pela fila
+func NewCreditHandler(credit *txnSvc.CreditService) asynq.Handler {
+	return handler("credit accrual task", credit.Execute)
+}
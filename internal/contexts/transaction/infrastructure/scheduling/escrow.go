@@ -0,0 +1,32 @@
+package scheduling
+
+import (
+	"context"
+
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeEscrowTimeout tarefa de vencimento do prazo de uma custódia
+const TypeEscrowTimeout = "transaction:escrow_timeout"
+
+// AsynqEscrowScheduler agenda o vencimento das custódias no asynq para o momento do prazo
+type AsynqEscrowScheduler struct {
+	client *asynq.Client
+}
+
+// NewAsynqEscrowScheduler cria o agendador sobre o client informado
+func NewAsynqEscrowScheduler(client *asynq.Client) *AsynqEscrowScheduler {
+	return &AsynqEscrowScheduler{client: client}
+}
+
+// ScheduleTimeout enfileira o vencimento para o prazo; vencimentos já agendados são ignorados
+func (s *AsynqEscrowScheduler) ScheduleTimeout(ctx context.Context, timeout txnSvc.EscrowTimeout) error {
+	return enqueue(ctx, s.client, TypeEscrowTimeout, timeout, asynq.ProcessAt(timeout.Deadline))
+}
+
+// NewEscrowTimeoutHandler cria o handler do worker que aplica os vencimentos entregues pela fila
+func NewEscrowTimeoutHandler(escrows *txnSvc.EscrowService) asynq.Handler {
+	return handler("escrow timeout", escrows.HandleTimeout)
+}
@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeDailyInterestAccrual tarefa de apropriação diária de rendimentos
+const TypeDailyInterestAccrual = "transaction:daily_interest_accrual"
+
+// NewAsynqInterestDispatcher cria o dispatcher que enfileira a apropriação diária no asynq; o TaskID
+// do dia impede que varreduras seguidas enfileirem a mesma apropriação duas vezes
+func NewAsynqInterestDispatcher(client *asynq.Client) *dispatcher[txnSvc.InterestAccrualTask] {
+	return newDispatcher[txnSvc.InterestAccrualTask](client, TypeDailyInterestAccrual)
+}
+
+// NewInterestHandler cria o handler do worker que executa as apropriações entregues pela fila
+func NewInterestHandler(interest *txnSvc.InterestService) asynq.Handler {
+	return handler("interest accrual task", interest.Execute)
+}
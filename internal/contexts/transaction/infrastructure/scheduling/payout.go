@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypePayoutItem tarefa de execução de um item de lote de pagamentos
+const TypePayoutItem = "transaction:payout_item"
+
+// NewAsynqPayoutDispatcher cria o dispatcher que enfileira os itens confirmados no asynq; o TaskID do
+// item impede que a confirmação e a varredura enfileirem o mesmo item duas vezes
+func NewAsynqPayoutDispatcher(client *asynq.Client) *dispatcher[txnSvc.PayoutTask] {
+	return newDispatcher[txnSvc.PayoutTask](client, TypePayoutItem)
+}
+
+// NewPayoutHandler cria o handler do worker que executa os itens entregues pela fila
+func NewPayoutHandler(payouts *txnSvc.PayoutService) asynq.Handler {
+	return handler("payout task", payouts.Execute)
+}
@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeDailyReconciliation tarefa de conciliação diária com a rede
+const TypeDailyReconciliation = "transaction:daily_reconciliation"
+
+// NewAsynqReconciliationDispatcher cria o dispatcher que enfileira a conciliação diária no asynq; o
+// TaskID do dia impede que varreduras seguidas enfileirem a mesma conciliação duas vezes
+func NewAsynqReconciliationDispatcher(client *asynq.Client) *dispatcher[txnSvc.ReconciliationTask] {
+	return newDispatcher[txnSvc.ReconciliationTask](client, TypeDailyReconciliation)
+}
+
+// NewReconciliationHandler cria o handler do worker que executa as conciliações entregues pela fila
+func NewReconciliationHandler(reconciliation *txnSvc.ReconciliationService) asynq.Handler {
+	return handler("reconciliation task", reconciliation.Execute)
+}
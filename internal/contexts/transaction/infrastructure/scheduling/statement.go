@@ -0,0 +1,21 @@
+package scheduling
+
+import (
+	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+
+	"github.com/hibiken/asynq"
+)
+
+// TypeMonthlyStatement tarefa de geração do extrato mensal de um usuário
+const TypeMonthlyStatement = "transaction:monthly_statement"
+
+// NewAsynqStatementDispatcher cria o dispatcher que enfileira os extratos mensais no asynq; o TaskID
+// do usuário e mês impede que varreduras seguidas enfileirem o mesmo extrato duas vezes
+func NewAsynqStatementDispatcher(client *asynq.Client) *dispatcher[txnSvc.StatementTask] {
+	return newDispatcher[txnSvc.StatementTask](client, TypeMonthlyStatement)
+}
+
+// NewStatementHandler cria o handler do worker que gera os extratos entregues pela fila
+func NewStatementHandler(statements *txnSvc.StatementService) asynq.Handler {
+	return handler("statement task", statements.Execute)
+}
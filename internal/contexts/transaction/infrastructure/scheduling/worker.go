@@ -0,0 +1,69 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+// Worker client e servidor asynq compartilhados pelas tarefas do contexto de transações.
+// Os handlers são registrados antes de Start; todos consomem a mesma fila.
+type Worker struct {
+	client  *asynq.Client
+	server  *asynq.Server
+	mux     *asynq.ServeMux
+	running bool
+}
+
+// NewWorker conecta ao Redis informado (formato redis://)
+func NewWorker(redisURL string, concurrency int) (*Worker, error) {
+	redisOpt, err := asynq.ParseRedisURI(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+	return &Worker{
+		client: asynq.NewClient(redisOpt),
+		server: asynq.NewServer(redisOpt, asynq.Config{
+			Concurrency: concurrency,
+			Queues:      map[string]int{Queue: 1},
+		}),
+		mux: asynq.NewServeMux(),
+	}, nil
+}
+
+// Client client usado pelos dispatchers para enfileirar tarefas
+func (w *Worker) Client() *asynq.Client {
+	return w.client
+}
+
+// Handle registra o handler de um tipo de tarefa
+func (w *Worker) Handle(taskType string, handler asynq.Handler) {
+	w.mux.Handle(taskType, handler)
+}
+
+// Start inicia o processamento das tarefas
+func (w *Worker) Start() error {
+	if err := w.server.Start(w.mux); err != nil {
+		return err
+	}
+	w.running = true
+	return nil
+}
+
+// Running indica se o servidor está processando tarefas
+func (w *Worker) Running() bool {
+	return w != nil && w.running
+}
+
+// Shutdown encerra o servidor e o client
+func (w *Worker) Shutdown(context.Context) error {
+	if w.running {
+		w.server.Shutdown()
+		w.running = false
+	}
+	return w.client.Close()
+}
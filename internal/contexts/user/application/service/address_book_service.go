@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultAddressCoolDown período entre a confirmação e o primeiro saque para o endereço
+const DefaultAddressCoolDown = 24 * time.Hour
+
+// AddressValidator valida endereços com o gateway da rede (BlockchainGatewayPort.ValidateAddress)
+type AddressValidator interface {
+	// ValidateAddress retorna erro se não houver gateway para a rede
+	ValidateAddress(chain, address string) (bool, error)
+}
+
+// AddressConfirmationNotifier envia ao titular o código de confirmação de um novo endereço
+type AddressConfirmationNotifier interface {
+	SendAddressConfirmation(ctx context.Context, email, label, chain, address, code string, expiresAt time.Time) error
+}
+
+// AddressBookService gerencia o catálogo de endereços de saque, o modo allowlist e o cool-down
+type AddressBookService struct {
+	repo      repository.WithdrawalAddressRepository
+	userRepo  repository.UserRepository
+	validator AddressValidator
+	notifier  AddressConfirmationNotifier
+	eventBus  events.Bus
+	logger    *zap.Logger
+	coolDown  time.Duration
+	codeTTL   time.Duration
+	now       func() time.Time
+}
+
+// NewAddressBookService cria o serviço de catálogo de endereços
+func NewAddressBookService(
+	repo repository.WithdrawalAddressRepository,
+	userRepo repository.UserRepository,
+	validator AddressValidator,
+	notifier AddressConfirmationNotifier,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *AddressBookService {
+	return &AddressBookService{
+		repo:      repo,
+		userRepo:  userRepo,
+		validator: validator,
+		notifier:  notifier,
+		eventBus:  eventBus,
+		logger:    logger,
+		coolDown:  DefaultAddressCoolDown,
+		codeTTL:   15 * time.Minute,
+		now:       time.Now,
+	}
+}
+
+// WithCoolDown define o período de espera após a confirmação (e após desligar a allowlist)
+func (s *AddressBookService) WithCoolDown(d time.Duration) *AddressBookService {
+	if d >= 0 {
+		s.coolDown = d
+	}
+	return s
+}
+
+// CoolDown retorna o período de espera configurado
+func (s *AddressBookService) CoolDown() time.Duration {
+	return s.coolDown
+}
+
+// Add valida o endereço com o gateway da rede e o registra pendente de confirmação,
+// enviando o código por email
+func (s *AddressBookService) Add(ctx context.Context, userID uuid.UUID, label, chain, address string) (*entity.WithdrawalAddress, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	address = strings.TrimSpace(address)
+	if err := s.validate(chain, address); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range existing {
+		if a.Matches(chain, address) {
+			return nil, ErrWithdrawalAddressExists
+		}
+	}
+
+	code, err := newConfirmationCode()
+	if err != nil {
+		return nil, err
+	}
+	codeHash, err := utils.HashAString(code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	entry, err := entity.NewWithdrawalAddress(userID, label, chain, address, codeHash, now.Add(s.codeTTL), now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if s.notifier == nil {
+		s.logger.Warn("address confirmation notifier not configured", zap.String("user_id", userID.String()))
+	} else if err := s.notifier.SendAddressConfirmation(ctx, user.Email.String(), entry.Label, chain, address, code, entry.ConfirmationExpires); err != nil {
+		s.logger.Error("failed to send address confirmation", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("withdrawal address added, awaiting confirmation",
+		zap.String("user_id", userID.String()),
+		zap.String("address_id", entry.ID.String()),
+		zap.String("chain", chain),
+	)
+	return entry, nil
+}
+
+// Confirm ativa o endereço com o código recebido; ele só recebe saques após o cool-down
+func (s *AddressBookService) Confirm(ctx context.Context, userID, addressID uuid.UUID, code string) (*entity.WithdrawalAddress, error) {
+	entry, err := s.Get(ctx, userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+	codeHash, err := utils.HashAString(strings.TrimSpace(code))
+	if err != nil {
+		return nil, err
+	}
+
+	confirmErr := entry.Confirm(codeHash, s.coolDown, s.now())
+	if confirmErr != nil && !errors.Is(confirmErr, entity.ErrAddressConfirmationFailed) {
+		return nil, confirmErr
+	}
+	// Tentativas erradas também são persistidas
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	if confirmErr != nil {
+		return nil, confirmErr
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewWithdrawalAddressConfirmedEvent(userID, entry.ID, entry.Chain, entry.Address, *entry.AvailableAt))
+	s.logger.Info("withdrawal address confirmed",
+		zap.String("user_id", userID.String()),
+		zap.String("address_id", entry.ID.String()),
+		zap.Time("available_at", *entry.AvailableAt),
+	)
+	return entry, nil
+}
+
+// Get retorna um endereço do catálogo do usuário
+func (s *AddressBookService) Get(ctx context.Context, userID, addressID uuid.UUID) (*entity.WithdrawalAddress, error) {
+	entry, err := s.repo.FindByID(ctx, addressID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.UserID != userID {
+		return nil, ErrWithdrawalAddressNotFound
+	}
+	return entry, nil
+}
+
+// List lista o catálogo do usuário
+func (s *AddressBookService) List(ctx context.Context, userID uuid.UUID) ([]*entity.WithdrawalAddress, error) {
+	return s.repo.ListByUserID(ctx, userID)
+}
+
+// Remove exclui um endereço do catálogo
+func (s *AddressBookService) Remove(ctx context.Context, userID, addressID uuid.UUID) error {
+	if _, err := s.Get(ctx, userID, addressID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, addressID)
+}
+
+// Settings retorna as preferências de saque (padrão: allowlist desligada)
+func (s *AddressBookService) Settings(ctx context.Context, userID uuid.UUID) (*entity.WithdrawalSettings, error) {
+	settings, err := s.repo.FindSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &entity.WithdrawalSettings{UserID: userID}
+	}
+	return settings, nil
+}
+
+// SetAllowlistOnly liga a allowlist imediatamente; ao desligar ela continua valendo até o fim do cool-down
+func (s *AddressBookService) SetAllowlistOnly(ctx context.Context, userID uuid.UUID, enabled bool) (*entity.WithdrawalSettings, error) {
+	settings, err := s.Settings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings.SetAllowlistOnly(enabled, s.coolDown, s.now())
+	if err := s.repo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	s.logger.Info("withdrawal allowlist updated", zap.String("user_id", userID.String()), zap.Bool("allowlist_only", enabled))
+	return settings, nil
+}
+
+// AuthorizeDestination decide se um saque pode ir para o destino informado. Endereços do catálogo
+// precisam estar confirmados e fora do cool-down; com allowlist ativa, apenas eles são aceitos.
+func (s *AddressBookService) AuthorizeDestination(ctx context.Context, userID uuid.UUID, chain, address string) error {
+	now := s.now()
+	chain = strings.ToLower(strings.TrimSpace(chain))
+	address = strings.TrimSpace(address)
+
+	settings, err := s.repo.FindSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	enforced := settings.AllowlistEnforced(now)
+
+	if address == "" {
+		if enforced {
+			return ErrDestinationNotAllowlisted
+		}
+		return nil
+	}
+	if err := s.validate(chain, address); err != nil {
+		return err
+	}
+
+	entries, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, a := range entries {
+		if a.Matches(chain, address) {
+			return a.CheckAvailable(now)
+		}
+	}
+	if enforced {
+		return ErrDestinationNotAllowlisted
+	}
+	return nil
+}
+
+func (s *AddressBookService) validate(chain, address string) error {
+	if chain == "" || address == "" {
+		return ErrInvalidWithdrawalAddress
+	}
+	if s.validator == nil {
+		return nil
+	}
+	ok, err := s.validator.ValidateAddress(chain, address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedChain, err)
+	}
+	if !ok {
+		return fmt.Errorf("%w for chain %s", ErrInvalidWithdrawalAddress, chain)
+	}
+	return nil
+}
+
+// newConfirmationCode gera um código numérico de 6 dígitos
+func newConfirmationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type memWithdrawalAddressRepo struct {
+	addresses map[uuid.UUID]*entity.WithdrawalAddress
+	settings  map[uuid.UUID]*entity.WithdrawalSettings
+}
+
+func newMemWithdrawalAddressRepo() *memWithdrawalAddressRepo {
+	return &memWithdrawalAddressRepo{
+		addresses: make(map[uuid.UUID]*entity.WithdrawalAddress),
+		settings:  make(map[uuid.UUID]*entity.WithdrawalSettings),
+	}
+}
+
+func (r *memWithdrawalAddressRepo) Create(ctx context.Context, a *entity.WithdrawalAddress) error {
+	r.addresses[a.ID] = a
+	return nil
+}
+func (r *memWithdrawalAddressRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.WithdrawalAddress, error) {
+	return r.addresses[id], nil
+}
+func (r *memWithdrawalAddressRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WithdrawalAddress, error) {
+	var out []*entity.WithdrawalAddress
+	for _, a := range r.addresses {
+		if a.UserID == userID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (r *memWithdrawalAddressRepo) Update(ctx context.Context, a *entity.WithdrawalAddress) error {
+	r.addresses[a.ID] = a
+	return nil
+}
+func (r *memWithdrawalAddressRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.addresses, id)
+	return nil
+}
+func (r *memWithdrawalAddressRepo) FindSettings(ctx context.Context, userID uuid.UUID) (*entity.WithdrawalSettings, error) {
+	return r.settings[userID], nil
+}
+func (r *memWithdrawalAddressRepo) SaveSettings(ctx context.Context, s *entity.WithdrawalSettings) error {
+	r.settings[s.UserID] = s
+	return nil
+}
+
+type stubAddressValidator struct{}
+
+func (stubAddressValidator) ValidateAddress(chain, address string) (bool, error) {
+	if chain != "ethereum" {
+		return false, errors.New("no gateway")
+	}
+	return len(address) == 42, nil
+}
+
+type captureAddressNotifier struct{ code string }
+
+func (n *captureAddressNotifier) SendAddressConfirmation(ctx context.Context, email, label, chain, address, code string, expiresAt time.Time) error {
+	n.code = code
+	return nil
+}
+
+const testEVMAddress = "0x52908400098527886E0F7030069857D2E4169EE7"
+
+func newAddressBookFixture(t *testing.T) (*AddressBookService, *captureAddressNotifier, *entity.User, *time.Time) {
+	t.Helper()
+	ur := newMemUserRepo2()
+	user := entity.NewUser(valueobject.Email("holder@test.com"), valueobject.HashedPassword("x"))
+	_ = ur.Create(context.Background(), user)
+
+	notifier := &captureAddressNotifier{}
+	svc := NewAddressBookService(newMemWithdrawalAddressRepo(), ur, stubAddressValidator{}, notifier, events.NewInMemoryBus(zap.NewNop()), zap.NewNop()).
+		WithCoolDown(time.Hour)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	return svc, notifier, user, &now
+}
+
+func TestAddressBook_AddConfirmAndCoolDown(t *testing.T) {
+	ctx := context.Background()
+	svc, notifier, user, now := newAddressBookFixture(t)
+
+	if _, err := svc.Add(ctx, user.ID, "Hot", "ethereum", "0x123"); !errors.Is(err, ErrInvalidWithdrawalAddress) {
+		t.Fatalf("esperado ErrInvalidWithdrawalAddress, obtido %v", err)
+	}
+	if _, err := svc.Add(ctx, user.ID, "Hot", "dogecoin", testEVMAddress); !errors.Is(err, ErrUnsupportedChain) {
+		t.Fatalf("esperado ErrUnsupportedChain, obtido %v", err)
+	}
+
+	entry, err := svc.Add(ctx, user.ID, "Cold", "ethereum", testEVMAddress)
+	if err != nil || notifier.code == "" {
+		t.Fatalf("adicionar endereço falhou: %v", err)
+	}
+	if _, err := svc.Add(ctx, user.ID, "Dup", "ethereum", testEVMAddress); !errors.Is(err, ErrWithdrawalAddressExists) {
+		t.Fatalf("esperado ErrWithdrawalAddressExists, obtido %v", err)
+	}
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", testEVMAddress); !errors.Is(err, entity.ErrAddressNotConfirmed) {
+		t.Fatalf("endereço pendente não deveria receber saques: %v", err)
+	}
+
+	if _, err := svc.Confirm(ctx, user.ID, entry.ID, "000000x"); !errors.Is(err, entity.ErrAddressConfirmationFailed) {
+		t.Fatalf("código errado deveria falhar: %v", err)
+	}
+	if _, err := svc.Confirm(ctx, user.ID, entry.ID, notifier.code); err != nil {
+		t.Fatalf("confirmação falhou: %v", err)
+	}
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", testEVMAddress); !errors.Is(err, entity.ErrAddressCoolingDown) {
+		t.Fatalf("esperado ErrAddressCoolingDown, obtido %v", err)
+	}
+	*now = now.Add(2 * time.Hour)
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", testEVMAddress); err != nil {
+		t.Fatalf("endereço deveria estar liberado: %v", err)
+	}
+}
+
+func TestAddressBook_AllowlistOnly(t *testing.T) {
+	ctx := context.Background()
+	svc, _, user, now := newAddressBookFixture(t)
+	other := "0x0000000000000000000000000000000000000001"
+
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", other); err != nil {
+		t.Fatalf("sem allowlist qualquer endereço válido deveria ser aceito: %v", err)
+	}
+	if _, err := svc.SetAllowlistOnly(ctx, user.ID, true); err != nil {
+		t.Fatalf("ativar allowlist falhou: %v", err)
+	}
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", other); !errors.Is(err, ErrDestinationNotAllowlisted) {
+		t.Fatalf("esperado ErrDestinationNotAllowlisted, obtido %v", err)
+	}
+	if err := svc.AuthorizeDestination(ctx, user.ID, "", ""); !errors.Is(err, ErrDestinationNotAllowlisted) {
+		t.Fatalf("saque sem destino deveria ser recusado com allowlist")
+	}
+
+	if _, err := svc.SetAllowlistOnly(ctx, user.ID, false); err != nil {
+		t.Fatalf("desativar allowlist falhou: %v", err)
+	}
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", other); !errors.Is(err, ErrDestinationNotAllowlisted) {
+		t.Fatalf("allowlist deveria continuar valendo durante o cool-down")
+	}
+	*now = now.Add(2 * time.Hour)
+	if err := svc.AuthorizeDestination(ctx, user.ID, "ethereum", other); err != nil {
+		t.Fatalf("após o cool-down a allowlist não deveria valer: %v", err)
+	}
+}
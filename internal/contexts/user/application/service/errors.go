@@ -10,4 +10,25 @@ var (
 	ErrPasswordHashFailed  = errors.New("password hash failed")
 	ErrWalletNotFound      = errors.New("wallet not found")
 	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrAccountLocked       = errors.New("account temporarily locked")
+	ErrLoginThrottled      = errors.New("too many failed login attempts")
+	ErrInvalidUnlockToken  = errors.New("invalid or expired unlock token")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionRevoked      = errors.New("session revoked or expired")
+	ErrKYCNotFound         = errors.New("kyc submission not found")
+	ErrUserAlreadyErased   = errors.New("user data already erased")
+	ErrErasureHasBalance   = errors.New("cannot erase user with non-zero balance")
+	ErrNotOrgMember        = errors.New("user is not a member of the organization")
+	ErrOrgPermissionDenied = errors.New("role does not allow this operation")
+	ErrOrganizationScope   = errors.New("operation requires an organization scope")
+	ErrOwnerRoleReserved   = errors.New("owner role cannot be granted or removed")
+	ErrAccountNotFound     = errors.New("account not found")
+	// ErrAccountLedgerUnavailable aportes e resgates exigem o registro das transações da wallet
+	ErrAccountLedgerUnavailable = errors.New("account funding is unavailable")
+
+	ErrWithdrawalAddressNotFound = errors.New("withdrawal address not found")
+	ErrWithdrawalAddressExists   = errors.New("withdrawal address already in address book")
+	ErrInvalidWithdrawalAddress  = errors.New("invalid withdrawal address")
+	ErrUnsupportedChain          = errors.New("unsupported chain")
+	ErrDestinationNotAllowlisted = errors.New("destination is not in the withdrawal allowlist")
 )
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// KYCSubmissionInput dados enviados pelo usuário para verificação
+type KYCSubmissionInput struct {
+	RequestedLevel entity.KYCLevel
+	DocumentType   entity.KYCDocumentType
+	DocumentNumber string
+	DocumentRef    string
+}
+
+// KYCService gerencia submissões de documentos e a revisão por operadores
+type KYCService struct {
+	userRepo repository.UserRepository
+	kycRepo  repository.KYCRepository
+	eventBus events.Bus
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewKYCService cria o serviço de KYC
+func NewKYCService(userRepo repository.UserRepository, kycRepo repository.KYCRepository, eventBus events.Bus, logger *zap.Logger) *KYCService {
+	return &KYCService{
+		userRepo: userRepo,
+		kycRepo:  kycRepo,
+		eventBus: eventBus,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Submit registra um documento para revisão
+func (s *KYCService) Submit(ctx context.Context, userID uuid.UUID, in KYCSubmissionInput) (*entity.KYCSubmission, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	submission, err := entity.NewKYCSubmission(userID, in.RequestedLevel, in.DocumentType, in.DocumentNumber, in.DocumentRef)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.kycRepo.Create(ctx, submission); err != nil {
+		s.logger.Error("failed to create kyc submission", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("kyc submission received",
+		zap.String("user_id", userID.String()),
+		zap.String("submission_id", submission.ID.String()),
+		zap.String("document_type", string(submission.DocumentType)),
+		zap.String("requested_level", submission.RequestedLevel.String()),
+	)
+	return submission, nil
+}
+
+// Status retorna o nível atual e o histórico de submissões do usuário
+func (s *KYCService) Status(ctx context.Context, userID uuid.UUID) (entity.KYCLevel, []*entity.KYCSubmission, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return entity.KYCLevelNone, nil, err
+	}
+	if user == nil {
+		return entity.KYCLevelNone, nil, ErrUserNotFound
+	}
+	submissions, err := s.kycRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return entity.KYCLevelNone, nil, err
+	}
+	return user.KYCLevel, submissions, nil
+}
+
+// ListPending retorna a fila de revisão dos operadores
+func (s *KYCService) ListPending(ctx context.Context, limit int) ([]*entity.KYCSubmission, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.kycRepo.FindByStatus(ctx, entity.KYCStatusPending, limit)
+}
+
+// Approve aprova a submissão e eleva o nível KYC do usuário
+func (s *KYCService) Approve(ctx context.Context, submissionID, reviewerID uuid.UUID) (*entity.KYCSubmission, error) {
+	submission, user, err := s.loadForReview(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := submission.Approve(reviewerID, s.now()); err != nil {
+		return nil, err
+	}
+
+	previous := user.KYCLevel
+	if user.RaiseKYCLevel(submission.RequestedLevel) {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			s.logger.Error("failed to update kyc level", zap.String("user_id", user.ID.String()), zap.Error(err))
+			return nil, err
+		}
+	}
+	if err := s.kycRepo.Update(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("kyc submission approved",
+		zap.String("submission_id", submission.ID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+		zap.String("previous_level", previous.String()),
+		zap.String("current_level", user.KYCLevel.String()),
+	)
+	s.eventBus.PublishAsync(ctx, events.NewKYCReviewedEvent(user.ID, submission.ID, reviewerID, string(submission.Status), previous.String(), user.KYCLevel.String(), ""))
+	return submission, nil
+}
+
+// Reject rejeita a submissão; o nível do usuário não é alterado
+func (s *KYCService) Reject(ctx context.Context, submissionID, reviewerID uuid.UUID, reason string) (*entity.KYCSubmission, error) {
+	submission, user, err := s.loadForReview(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := submission.Reject(reviewerID, reason, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.kycRepo.Update(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("kyc submission rejected",
+		zap.String("submission_id", submission.ID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+		zap.String("reason", submission.RejectionReason),
+	)
+	s.eventBus.PublishAsync(ctx, events.NewKYCReviewedEvent(user.ID, submission.ID, reviewerID, string(submission.Status), user.KYCLevel.String(), user.KYCLevel.String(), submission.RejectionReason))
+	return submission, nil
+}
+
+func (s *KYCService) loadForReview(ctx context.Context, submissionID uuid.UUID) (*entity.KYCSubmission, *entity.User, error) {
+	submission, err := s.kycRepo.FindByID(ctx, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if submission == nil {
+		return nil, nil, ErrKYCNotFound
+	}
+	user, err := s.userRepo.FindByID(ctx, submission.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, ErrUserNotFound
+	}
+	return submission, user, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type memKYCRepo struct {
+	subs map[uuid.UUID]*entity.KYCSubmission
+}
+
+func newMemKYCRepo() *memKYCRepo { return &memKYCRepo{subs: make(map[uuid.UUID]*entity.KYCSubmission)} }
+
+func (r *memKYCRepo) Create(ctx context.Context, s *entity.KYCSubmission) error {
+	r.subs[s.ID] = s
+	return nil
+}
+func (r *memKYCRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.KYCSubmission, error) {
+	return r.subs[id], nil
+}
+func (r *memKYCRepo) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.KYCSubmission, error) {
+	var out []*entity.KYCSubmission
+	for _, s := range r.subs {
+		if s.UserID == userID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (r *memKYCRepo) FindByStatus(ctx context.Context, status entity.KYCStatus, limit int) ([]*entity.KYCSubmission, error) {
+	var out []*entity.KYCSubmission
+	for _, s := range r.subs {
+		if s.Status == status {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (r *memKYCRepo) Update(ctx context.Context, s *entity.KYCSubmission) error {
+	r.subs[s.ID] = s
+	return nil
+}
+
+var _ userRepo.KYCRepository = (*memKYCRepo)(nil)
+
+func TestKYCService_ApproveRaisesLevel(t *testing.T) {
+	ctx := context.Background()
+	ur := newAuthTestUserRepo()
+	emailVO, _ := valueobject.NewEmail("kyc@test.com")
+	hashedVO, _ := valueobject.HashFromRaw("senha123")
+	user := entity.NewUser(emailVO, hashedVO)
+	_ = ur.Create(ctx, user)
+
+	svc := NewKYCService(ur, newMemKYCRepo(), events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+
+	sub, err := svc.Submit(ctx, user.ID, KYCSubmissionInput{RequestedLevel: entity.KYCLevelVerified, DocumentType: entity.KYCDocumentIDCard, DocumentNumber: "123"})
+	if err != nil {
+		t.Fatalf("submit falhou: %v", err)
+	}
+	pending, _ := svc.ListPending(ctx, 0)
+	if len(pending) != 1 {
+		t.Fatalf("esperado 1 pendente, obtido %d", len(pending))
+	}
+
+	if _, err := svc.Approve(ctx, sub.ID, uuid.New()); err != nil {
+		t.Fatalf("approve falhou: %v", err)
+	}
+	level, subs, err := svc.Status(ctx, user.ID)
+	if err != nil || level != entity.KYCLevelVerified || len(subs) != 1 {
+		t.Fatalf("status inesperado: %v %v %v", level, len(subs), err)
+	}
+
+	if _, err := svc.Reject(ctx, sub.ID, uuid.New(), "duplicado"); !errors.Is(err, entity.ErrKYCAlreadyReviewed) {
+		t.Fatalf("esperado ErrKYCAlreadyReviewed, obtido %v", err)
+	}
+	if _, err := svc.Approve(ctx, uuid.New(), uuid.New()); !errors.Is(err, ErrKYCNotFound) {
+		t.Fatalf("esperado ErrKYCNotFound, obtido %v", err)
+	}
+}
+
+func TestKYCService_RejectKeepsLevel(t *testing.T) {
+	ctx := context.Background()
+	ur := newAuthTestUserRepo()
+	emailVO, _ := valueobject.NewEmail("kyc2@test.com")
+	hashedVO, _ := valueobject.HashFromRaw("senha123")
+	user := entity.NewUser(emailVO, hashedVO)
+	_ = ur.Create(ctx, user)
+
+	svc := NewKYCService(ur, newMemKYCRepo(), events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	sub, _ := svc.Submit(ctx, user.ID, KYCSubmissionInput{RequestedLevel: entity.KYCLevelBasic, DocumentType: entity.KYCDocumentSelfie})
+
+	out, err := svc.Reject(ctx, sub.ID, uuid.New(), "imagem ilegível")
+	if err != nil || out.Status != entity.KYCStatusRejected {
+		t.Fatalf("reject falhou: %v", err)
+	}
+	if user.KYCLevel != entity.KYCLevelNone {
+		t.Fatalf("nível não deveria mudar após rejeição")
+	}
+	if _, err := svc.Submit(ctx, uuid.New(), KYCSubmissionInput{RequestedLevel: entity.KYCLevelBasic, DocumentType: entity.KYCDocumentSelfie}); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("esperado ErrUserNotFound, obtido %v", err)
+	}
+}
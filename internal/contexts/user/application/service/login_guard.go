@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/metrics"
+	"financial-system-pro/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LoginContext carrega metadados da requisição de login
+type LoginContext struct {
+	IPAddress string
+	UserAgent string
+	DeviceID  string // identificador opcional enviado pelo cliente (X-Device-ID)
+}
+
+// UnlockNotifier envia ao titular da conta o token de desbloqueio
+type UnlockNotifier interface {
+	SendUnlockInstructions(ctx context.Context, email, token string, expiresAt time.Time) error
+}
+
+// LoginBlockedError indica que o login foi recusado antes de validar a senha
+type LoginBlockedError struct {
+	Err        error
+	Scope      entity.LoginAttemptScope
+	RetryAfter time.Duration
+}
+
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%v (%s, retry after %s)", e.Err, e.Scope, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginBlockedError) Unwrap() error { return e.Err }
+
+// LoginGuard aplica atraso progressivo e bloqueio temporário por conta e por IP
+type LoginGuard struct {
+	repo           repository.LoginAttemptRepository
+	notifier       UnlockNotifier
+	eventBus       events.Bus
+	logger         *zap.Logger
+	now            func() time.Time
+	accountPolicy  entity.LockoutPolicy
+	ipPolicy       entity.LockoutPolicy
+	unlockTokenTTL time.Duration
+}
+
+// NewLoginGuard cria um guard com as políticas padrão
+func NewLoginGuard(
+	repo repository.LoginAttemptRepository,
+	notifier UnlockNotifier,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *LoginGuard {
+	return &LoginGuard{
+		repo:           repo,
+		notifier:       notifier,
+		eventBus:       eventBus,
+		logger:         logger,
+		now:            time.Now,
+		accountPolicy:  entity.DefaultAccountLockoutPolicy(),
+		ipPolicy:       entity.DefaultIPLockoutPolicy(),
+		unlockTokenTTL: 30 * time.Minute,
+	}
+}
+
+// WithPolicies substitui as políticas por conta e por IP
+func (g *LoginGuard) WithPolicies(account, ip entity.LockoutPolicy) *LoginGuard {
+	g.accountPolicy = account
+	g.ipPolicy = ip
+	return g
+}
+
+// Check recusa a tentativa se a conta ou o IP estiverem bloqueados ou em atraso progressivo
+func (g *LoginGuard) Check(ctx context.Context, email, ip string) error {
+	now := g.now()
+	if err := g.checkKey(ctx, entity.LoginScopeAccount, accountKey(email), g.accountPolicy, now); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return g.checkKey(ctx, entity.LoginScopeIP, ip, g.ipPolicy, now)
+}
+
+// RecordFailure contabiliza a falha para conta e IP, publicando user.locked ao bloquear
+func (g *LoginGuard) RecordFailure(ctx context.Context, userID uuid.UUID, email, ip string) error {
+	now := g.now()
+	if err := g.registerFailure(ctx, entity.LoginScopeAccount, accountKey(email), g.accountPolicy, userID, ip, now); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return g.registerFailure(ctx, entity.LoginScopeIP, ip, g.ipPolicy, uuid.Nil, ip, now)
+}
+
+// RecordSuccess zera o contador da conta. O contador do IP é mantido para que um login
+// válido não sirva para "limpar" tentativas contra outras contas a partir do mesmo IP.
+func (g *LoginGuard) RecordSuccess(ctx context.Context, email string) error {
+	return g.repo.Delete(ctx, entity.LoginScopeAccount, accountKey(email))
+}
+
+// RequestUnlock gera um token de desbloqueio e o envia por email se a conta estiver bloqueada
+func (g *LoginGuard) RequestUnlock(ctx context.Context, email string) error {
+	now := g.now()
+	attempts, err := g.repo.Find(ctx, entity.LoginScopeAccount, accountKey(email))
+	if err != nil {
+		return err
+	}
+	if attempts == nil || !attempts.IsLocked(now) {
+		// Sem bloqueio ativo: nada a fazer (resposta idêntica evita enumeração de contas)
+		return nil
+	}
+
+	token, err := newUnlockToken()
+	if err != nil {
+		return err
+	}
+	tokenHash, err := utils.HashAString(token)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := now.Add(g.unlockTokenTTL)
+	attempts.SetUnlockToken(tokenHash, expiresAt)
+	if err := g.repo.Save(ctx, attempts); err != nil {
+		return err
+	}
+
+	if g.notifier == nil {
+		g.logger.Warn("unlock notifier not configured", zap.String("email", email))
+		return nil
+	}
+	return g.notifier.SendUnlockInstructions(ctx, email, token, expiresAt)
+}
+
+// ConfirmUnlock valida o token recebido por email e remove o bloqueio da conta
+func (g *LoginGuard) ConfirmUnlock(ctx context.Context, email, token string) error {
+	attempts, err := g.repo.Find(ctx, entity.LoginScopeAccount, accountKey(email))
+	if err != nil {
+		return err
+	}
+	if attempts == nil {
+		return ErrInvalidUnlockToken
+	}
+	tokenHash, err := utils.HashAString(token)
+	if err != nil {
+		return err
+	}
+	if !attempts.HasValidUnlockToken(tokenHash, g.now()) {
+		return ErrInvalidUnlockToken
+	}
+
+	g.logger.Info("account unlocked by email token", zap.String("email", email))
+	return g.repo.Delete(ctx, entity.LoginScopeAccount, accountKey(email))
+}
+
+func (g *LoginGuard) checkKey(ctx context.Context, scope entity.LoginAttemptScope, key string, policy entity.LockoutPolicy, now time.Time) error {
+	attempts, err := g.repo.Find(ctx, scope, key)
+	if err != nil {
+		// Falha no storage não deve derrubar o login; registra e segue
+		g.logger.Error("failed to load login attempts", zap.String("scope", string(scope)), zap.Error(err))
+		return nil
+	}
+	if attempts == nil {
+		return nil
+	}
+
+	wait := attempts.RetryAfter(policy, now)
+	if wait <= 0 {
+		return nil
+	}
+
+	if attempts.IsLocked(now) {
+		metrics.RecordLoginThrottled(string(scope), "locked")
+		return &LoginBlockedError{Err: ErrAccountLocked, Scope: scope, RetryAfter: wait}
+	}
+	metrics.RecordLoginThrottled(string(scope), "delayed")
+	return &LoginBlockedError{Err: ErrLoginThrottled, Scope: scope, RetryAfter: wait}
+}
+
+func (g *LoginGuard) registerFailure(ctx context.Context, scope entity.LoginAttemptScope, key string, policy entity.LockoutPolicy, userID uuid.UUID, ip string, now time.Time) error {
+	attempts, err := g.repo.Find(ctx, scope, key)
+	if err != nil {
+		return err
+	}
+	if attempts == nil {
+		attempts = entity.NewLoginAttempts(scope, key)
+	}
+
+	locked := attempts.RegisterFailure(policy, now)
+	if err := g.repo.Save(ctx, attempts); err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	g.logger.Warn("login locked after repeated failures",
+		zap.String("scope", string(scope)),
+		zap.String("key", key),
+		zap.Int("failures", attempts.Failures),
+		zap.Time("locked_until", *attempts.LockedUntil),
+	)
+	g.eventBus.PublishAsync(ctx, events.NewUserLockedEvent(userID, string(scope), key, ip, attempts.Failures, *attempts.LockedUntil))
+	return nil
+}
+
+// accountKey normaliza o email usado como chave do contador por conta
+func accountKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func newUnlockToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
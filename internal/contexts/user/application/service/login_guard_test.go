@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"go.uber.org/zap"
+)
+
+type memLoginAttemptRepo struct {
+	mu   sync.Mutex
+	data map[string]*entity.LoginAttempts
+}
+
+func newMemLoginAttemptRepo() *memLoginAttemptRepo {
+	return &memLoginAttemptRepo{data: make(map[string]*entity.LoginAttempts)}
+}
+
+func (r *memLoginAttemptRepo) Find(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.data[string(scope)+":"+key]
+	if !ok {
+		return nil, nil
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *memLoginAttemptRepo) Save(ctx context.Context, a *entity.LoginAttempts) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *a
+	r.data[string(a.Scope)+":"+a.Key] = &cp
+	return nil
+}
+
+func (r *memLoginAttemptRepo) Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.data, string(scope)+":"+key)
+	return nil
+}
+
+var _ userRepo.LoginAttemptRepository = (*memLoginAttemptRepo)(nil)
+
+type captureNotifier struct{ token string }
+
+func (n *captureNotifier) SendUnlockInstructions(ctx context.Context, email, token string, expiresAt time.Time) error {
+	n.token = token
+	return nil
+}
+
+func setupGuardedService(t *testing.T) (*UserService, *captureNotifier, *events.InMemoryBus) {
+	t.Helper()
+	t.Setenv("SECRET_KEY", "test-secret")
+	lg := zap.NewNop()
+	bus := events.NewInMemoryBus(lg)
+	ur := newAuthTestUserRepo()
+	emailVO, _ := valueobject.NewEmail("locked@test.com")
+	hashedVO, _ := valueobject.HashFromRaw("senha123")
+	_ = ur.Create(context.Background(), entity.NewUser(emailVO, hashedVO))
+
+	notifier := &captureNotifier{}
+	policy := entity.LockoutPolicy{MaxFailures: 3, LockoutDuration: time.Minute, FailureWindow: time.Hour}
+	guard := NewLoginGuard(newMemLoginAttemptRepo(), notifier, bus, lg).WithPolicies(policy, entity.DefaultIPLockoutPolicy())
+	svc := NewUserService(ur, authTestWalletRepo{}, bus, lg).WithLoginGuard(guard)
+	return svc, notifier, bus
+}
+
+func TestAuthenticateFrom_LocksAccountAfterFailures(t *testing.T) {
+	svc, _, bus := setupGuardedService(t)
+	ctx := context.Background()
+	lc := LoginContext{IPAddress: "10.0.0.1"}
+
+	locked := make(chan events.UserLockedEvent, 1)
+	bus.Subscribe("user.locked", func(ctx context.Context, e events.Event) error {
+		locked <- e.(events.UserLockedEvent)
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.AuthenticateFrom(ctx, "locked@test.com", "errada", lc); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("tentativa %d: esperado ErrInvalidCredentials, obtido %v", i, err)
+		}
+	}
+
+	// Mesmo com a senha correta, a conta está bloqueada
+	_, err := svc.AuthenticateFrom(ctx, "locked@test.com", "senha123", lc)
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("esperado ErrAccountLocked, obtido %v", err)
+	}
+	if blocked.RetryAfter <= 0 || blocked.Scope != entity.LoginScopeAccount {
+		t.Fatalf("bloqueio inesperado: %+v", blocked)
+	}
+
+	select {
+	case evt := <-locked:
+		if evt.Scope != string(entity.LoginScopeAccount) || evt.Failures != 3 || evt.IPAddress != "10.0.0.1" {
+			t.Fatalf("evento user.locked inesperado: %+v", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("evento user.locked não publicado")
+	}
+}
+
+func TestAuthenticateFrom_SuccessResetsFailures(t *testing.T) {
+	svc, _, _ := setupGuardedService(t)
+	ctx := context.Background()
+
+	_, _ = svc.AuthenticateFrom(ctx, "locked@test.com", "errada", LoginContext{})
+	_, _ = svc.AuthenticateFrom(ctx, "locked@test.com", "errada", LoginContext{})
+	if _, err := svc.AuthenticateFrom(ctx, "locked@test.com", "senha123", LoginContext{}); err != nil {
+		t.Fatalf("login deveria passar: %v", err)
+	}
+	// Contador zerado: mais duas falhas ainda não bloqueiam
+	_, _ = svc.AuthenticateFrom(ctx, "LOCKED@test.com", "errada", LoginContext{})
+	_, _ = svc.AuthenticateFrom(ctx, "locked@test.com", "errada", LoginContext{})
+	if _, err := svc.AuthenticateFrom(ctx, "locked@test.com", "senha123", LoginContext{}); err != nil {
+		t.Fatalf("conta não deveria estar bloqueada: %v", err)
+	}
+}
+
+func TestUnlockByEmail(t *testing.T) {
+	svc, notifier, _ := setupGuardedService(t)
+	ctx := context.Background()
+
+	// Sem bloqueio, nenhum token é emitido
+	if err := svc.RequestUnlock(ctx, "locked@test.com"); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if notifier.token != "" {
+		t.Fatalf("token não deveria ser emitido sem bloqueio")
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _ = svc.AuthenticateFrom(ctx, "locked@test.com", "errada", LoginContext{})
+	}
+	if err := svc.RequestUnlock(ctx, "locked@test.com"); err != nil {
+		t.Fatalf("erro ao solicitar desbloqueio: %v", err)
+	}
+	if notifier.token == "" {
+		t.Fatalf("token de desbloqueio não enviado")
+	}
+
+	if err := svc.ConfirmUnlock(ctx, "locked@test.com", "token-invalido"); !errors.Is(err, ErrInvalidUnlockToken) {
+		t.Fatalf("esperado ErrInvalidUnlockToken, obtido %v", err)
+	}
+	if err := svc.ConfirmUnlock(ctx, "locked@test.com", notifier.token); err != nil {
+		t.Fatalf("desbloqueio falhou: %v", err)
+	}
+	if _, err := svc.AuthenticateFrom(ctx, "locked@test.com", "senha123", LoginContext{}); err != nil {
+		t.Fatalf("login após desbloqueio falhou: %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/shared/events"
+	"financial-system-pro/internal/shared/tenant"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// AccessScope identifica quem age, em nome de qual tenant e com qual papel.
+// Sem organização o tenant é o próprio usuário, que age como dono das suas contas.
+type AccessScope struct {
+	TenantID       uuid.UUID
+	ActorID        uuid.UUID
+	OrganizationID uuid.UUID // uuid.Nil no escopo pessoal
+	Role           entity.OrgRole
+}
+
+// IsOrganization indica se o escopo é de uma organização
+func (s AccessScope) IsOrganization() bool {
+	return s.OrganizationID != uuid.Nil
+}
+
+// Context aplica o tenant do escopo ao context usado nos repositórios
+func (s AccessScope) Context(ctx context.Context) context.Context {
+	return tenant.WithID(ctx, s.TenantID)
+}
+
+// AccountLedger movimenta a wallet pessoal do ator nos aportes e resgates das contas nomeadas.
+// Cada movimento fica registrado como transação da wallet; aportes consomem os limites de saída
+// e passam pela triagem de risco.
+type AccountLedger interface {
+	// DebitForAccount debita o aporte da wallet e retorna o ID da transação
+	DebitForAccount(ctx context.Context, userID, accountID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error)
+	// CreditFromAccount credita o resgate na wallet e retorna o ID da transação
+	CreditFromAccount(ctx context.Context, userID, accountID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error)
+	// RefundAccountFunding devolve à wallet um aporte que não chegou à conta
+	RefundAccountFunding(ctx context.Context, transactionID uuid.UUID, reason string) error
+}
+
+// OrganizationService gerencia organizações, membros e contas nomeadas por tenant
+type OrganizationService struct {
+	orgRepo     repository.OrganizationRepository
+	accountRepo repository.AccountRepository
+	userRepo    repository.UserRepository
+	ledger      AccountLedger
+	eventBus    events.Bus
+	logger      *zap.Logger
+}
+
+// NewOrganizationService cria o serviço de organizações
+func NewOrganizationService(
+	orgRepo repository.OrganizationRepository,
+	accountRepo repository.AccountRepository,
+	userRepo repository.UserRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:     orgRepo,
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		eventBus:    eventBus,
+		logger:      logger,
+	}
+}
+
+// WithLedger habilita aportes e resgates entre a wallet pessoal e as contas nomeadas
+func (s *OrganizationService) WithLedger(ledger AccountLedger) *OrganizationService {
+	s.ledger = ledger
+	return s
+}
+
+// ResolveScope define o tenant da requisição. orgID nulo resolve para o escopo pessoal.
+func (s *OrganizationService) ResolveScope(ctx context.Context, userID, orgID uuid.UUID) (AccessScope, error) {
+	if orgID == uuid.Nil {
+		return AccessScope{TenantID: userID, ActorID: userID, Role: entity.OrgRoleOwner}, nil
+	}
+	membership, err := s.orgRepo.FindMembership(ctx, orgID, userID)
+	if err != nil {
+		return AccessScope{}, err
+	}
+	if membership == nil {
+		return AccessScope{}, ErrNotOrgMember
+	}
+	return AccessScope{TenantID: orgID, ActorID: userID, OrganizationID: orgID, Role: membership.Role}, nil
+}
+
+// CreateOrganization cria a organização com o usuário como dono
+func (s *OrganizationService) CreateOrganization(ctx context.Context, ownerID uuid.UUID, name string) (*entity.Organization, error) {
+	org, err := entity.NewOrganization(name, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := entity.NewMembership(org.ID, ownerID, entity.OrgRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.Create(ctx, org, owner); err != nil {
+		s.logger.Error("failed to create organization", zap.String("owner_id", ownerID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("organization created", zap.String("organization_id", org.ID.String()), zap.String("owner_id", ownerID.String()))
+	return org, nil
+}
+
+// ListOrganizations lista as organizações das quais o usuário é membro
+func (s *OrganizationService) ListOrganizations(ctx context.Context, userID uuid.UUID) ([]*entity.Organization, error) {
+	return s.orgRepo.ListByMember(ctx, userID)
+}
+
+// ListMembers lista os membros da organização do escopo
+func (s *OrganizationService) ListMembers(ctx context.Context, scope AccessScope) ([]*entity.Membership, error) {
+	if err := requireOrgPermission(scope, entity.PermView); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(scope.Context(ctx))
+}
+
+// SetMember adiciona um usuário (pelo email) à organização ou altera seu papel
+func (s *OrganizationService) SetMember(ctx context.Context, scope AccessScope, email string, role entity.OrgRole) (*entity.Membership, error) {
+	if err := requireOrgPermission(scope, entity.PermManageMembers); err != nil {
+		return nil, err
+	}
+	if role == entity.OrgRoleOwner {
+		return nil, ErrOwnerRoleReserved
+	}
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	current, err := s.orgRepo.FindMembership(ctx, scope.OrganizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Role == entity.OrgRoleOwner {
+		return nil, ErrOwnerRoleReserved
+	}
+
+	membership, err := entity.NewMembership(scope.OrganizationID, user.ID, role)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		membership.CreatedAt = current.CreatedAt
+	}
+	if err := s.orgRepo.SaveMembership(scope.Context(ctx), membership); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("organization member set",
+		zap.String("organization_id", scope.OrganizationID.String()),
+		zap.String("user_id", user.ID.String()),
+		zap.String("role", string(role)),
+		zap.String("actor_id", scope.ActorID.String()),
+	)
+	return membership, nil
+}
+
+// RemoveMember remove um membro da organização; o dono não pode ser removido
+func (s *OrganizationService) RemoveMember(ctx context.Context, scope AccessScope, userID uuid.UUID) error {
+	if err := requireOrgPermission(scope, entity.PermManageMembers); err != nil {
+		return err
+	}
+	current, err := s.orgRepo.FindMembership(ctx, scope.OrganizationID, userID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return ErrNotOrgMember
+	}
+	if current.Role == entity.OrgRoleOwner {
+		return ErrOwnerRoleReserved
+	}
+	return s.orgRepo.DeleteMembership(scope.Context(ctx), userID)
+}
+
+// CreateAccount cria uma conta nomeada no tenant do escopo
+func (s *OrganizationService) CreateAccount(ctx context.Context, scope AccessScope, name, currency string) (*entity.Account, error) {
+	if !scope.Role.Can(entity.PermManageAccounts) {
+		return nil, ErrOrgPermissionDenied
+	}
+	account, err := entity.NewAccount(scope.TenantID, name, currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.Create(scope.Context(ctx), account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// ListAccounts lista as contas do tenant do escopo
+func (s *OrganizationService) ListAccounts(ctx context.Context, scope AccessScope) ([]*entity.Account, error) {
+	if !scope.Role.Can(entity.PermView) {
+		return nil, ErrOrgPermissionDenied
+	}
+	return s.accountRepo.List(scope.Context(ctx))
+}
+
+// FundAccount move saldo da wallet pessoal do ator para uma conta do tenant do escopo
+func (s *OrganizationService) FundAccount(ctx context.Context, scope AccessScope, accountID uuid.UUID, amount decimal.Decimal) (*entity.Account, error) {
+	if !scope.Role.Can(entity.PermTransfer) {
+		return nil, ErrOrgPermissionDenied
+	}
+	if !amount.IsPositive() {
+		return nil, entity.ErrInvalidAmount
+	}
+	if s.ledger == nil {
+		return nil, ErrAccountLedgerUnavailable
+	}
+	tenantCtx := scope.Context(ctx)
+	account, err := s.findAccount(tenantCtx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Currency != entity.DefaultAccountCurrency {
+		return nil, entity.ErrUnsupportedCurrency
+	}
+
+	txID, err := s.ledger.DebitForAccount(ctx, scope.ActorID, account.ID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.Credit(tenantCtx, account.ID, amount); err != nil {
+		// Compensa o débito na wallet
+		if rbErr := s.ledger.RefundAccountFunding(ctx, txID, "account credit failed"); rbErr != nil {
+			s.logger.Error("failed to refund wallet after account funding failure",
+				zap.String("user_id", scope.ActorID.String()), zap.String("tx_id", txID.String()), zap.Error(rbErr))
+		}
+		return nil, err
+	}
+
+	account.Balance = account.Balance.Add(amount)
+	s.eventBus.PublishAsync(ctx, events.NewAccountWalletMovementEvent(scope.TenantID, account.ID, scope.ActorID, txID, "funded", amount))
+	return account, nil
+}
+
+// WithdrawAccount resgata saldo de uma conta do tenant do escopo para a wallet pessoal do ator
+func (s *OrganizationService) WithdrawAccount(ctx context.Context, scope AccessScope, accountID uuid.UUID, amount decimal.Decimal) (*entity.Account, error) {
+	if !scope.Role.Can(entity.PermTransfer) {
+		return nil, ErrOrgPermissionDenied
+	}
+	if !amount.IsPositive() {
+		return nil, entity.ErrInvalidAmount
+	}
+	if s.ledger == nil {
+		return nil, ErrAccountLedgerUnavailable
+	}
+	tenantCtx := scope.Context(ctx)
+	account, err := s.findAccount(tenantCtx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Balance.LessThan(amount) {
+		return nil, entity.ErrInsufficientFunds
+	}
+
+	if err := s.accountRepo.Debit(tenantCtx, account.ID, amount); err != nil {
+		return nil, err
+	}
+	txID, err := s.ledger.CreditFromAccount(ctx, scope.ActorID, account.ID, amount)
+	if err != nil {
+		// Devolve o valor à conta
+		if rbErr := s.accountRepo.Credit(tenantCtx, account.ID, amount); rbErr != nil {
+			s.logger.Error("failed to restore account after wallet credit failure",
+				zap.String("account_id", account.ID.String()), zap.Error(rbErr))
+		}
+		return nil, err
+	}
+
+	account.Balance = account.Balance.Sub(amount)
+	s.eventBus.PublishAsync(ctx, events.NewAccountWalletMovementEvent(scope.TenantID, account.ID, scope.ActorID, txID, "withdrawn", amount))
+	return account, nil
+}
+
+// Transfer move saldo entre duas contas do tenant do escopo
+func (s *OrganizationService) Transfer(ctx context.Context, scope AccessScope, fromID, toID uuid.UUID, amount decimal.Decimal, reference string) (*entity.AccountTransfer, error) {
+	if !scope.Role.Can(entity.PermTransfer) {
+		return nil, ErrOrgPermissionDenied
+	}
+	tenantCtx := scope.Context(ctx)
+	from, err := s.findAccount(tenantCtx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findAccount(tenantCtx, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := entity.TransferBetween(from, to, amount, reference, scope.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.ApplyTransfer(tenantCtx, from, to, transfer); err != nil {
+		s.logger.Error("failed to apply account transfer", zap.String("tenant_id", scope.TenantID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	s.eventBus.PublishAsync(ctx, events.NewAccountTransferCompletedEvent(
+		transfer.ID, transfer.TenantID, transfer.FromAccountID, transfer.ToAccountID, scope.ActorID, transfer.Amount, transfer.Currency,
+	))
+	return transfer, nil
+}
+
+// ListTransfers lista as transferências de uma conta do tenant do escopo
+func (s *OrganizationService) ListTransfers(ctx context.Context, scope AccessScope, accountID uuid.UUID, limit int) ([]*entity.AccountTransfer, error) {
+	if !scope.Role.Can(entity.PermView) {
+		return nil, ErrOrgPermissionDenied
+	}
+	tenantCtx := scope.Context(ctx)
+	if _, err := s.findAccount(tenantCtx, accountID); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.ListTransfers(tenantCtx, accountID, limit)
+}
+
+func (s *OrganizationService) findAccount(tenantCtx context.Context, id uuid.UUID) (*entity.Account, error) {
+	account, err := s.accountRepo.FindByID(tenantCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// requireOrgPermission exige escopo de organização e papel com a permissão
+func requireOrgPermission(scope AccessScope, p entity.OrgPermission) error {
+	if !scope.IsOrganization() {
+		return ErrOrganizationScope
+	}
+	if !scope.Role.Can(p) {
+		return ErrOrgPermissionDenied
+	}
+	return nil
+}
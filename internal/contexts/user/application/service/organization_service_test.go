@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type memOrgRepo struct {
+	members map[uuid.UUID]map[uuid.UUID]*entity.Membership
+}
+
+func (r *memOrgRepo) Create(ctx context.Context, o *entity.Organization, owner *entity.Membership) error {
+	r.members[o.ID] = map[uuid.UUID]*entity.Membership{owner.UserID: owner}
+	return nil
+}
+func (r *memOrgRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	return nil, nil
+}
+func (r *memOrgRepo) ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.Organization, error) {
+	return nil, nil
+}
+func (r *memOrgRepo) FindMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error) {
+	return r.members[orgID][userID], nil
+}
+func (r *memOrgRepo) ListMembers(ctx context.Context) ([]*entity.Membership, error) { return nil, nil }
+func (r *memOrgRepo) SaveMembership(ctx context.Context, m *entity.Membership) error {
+	r.members[m.OrganizationID][m.UserID] = m
+	return nil
+}
+func (r *memOrgRepo) DeleteMembership(ctx context.Context, userID uuid.UUID) error { return nil }
+
+type noopAccountRepo struct{}
+
+func (noopAccountRepo) Create(ctx context.Context, a *entity.Account) error { return nil }
+func (noopAccountRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	return nil, nil
+}
+func (noopAccountRepo) List(ctx context.Context) ([]*entity.Account, error) { return nil, nil }
+func (noopAccountRepo) Credit(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
+	return nil
+}
+func (noopAccountRepo) Debit(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
+	return nil
+}
+func (noopAccountRepo) ApplyTransfer(ctx context.Context, from, to *entity.Account, t *entity.AccountTransfer) error {
+	return nil
+}
+func (noopAccountRepo) ListTransfers(ctx context.Context, id uuid.UUID, limit int) ([]*entity.AccountTransfer, error) {
+	return nil, nil
+}
+
+func TestOrganizationService_MembershipRules(t *testing.T) {
+	ctx := context.Background()
+	ur := newMemUserRepo2()
+	owner := entity.NewUser(valueobject.Email("owner@test.com"), valueobject.HashedPassword("x"))
+	viewer := entity.NewUser(valueobject.Email("viewer@test.com"), valueobject.HashedPassword("x"))
+	_ = ur.Create(ctx, owner)
+	_ = ur.Create(ctx, viewer)
+
+	svc := NewOrganizationService(&memOrgRepo{members: map[uuid.UUID]map[uuid.UUID]*entity.Membership{}}, noopAccountRepo{}, ur, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	org, err := svc.CreateOrganization(ctx, owner.ID, "Acme")
+	if err != nil {
+		t.Fatalf("criar organização falhou: %v", err)
+	}
+
+	personal, _ := svc.ResolveScope(ctx, owner.ID, uuid.Nil)
+	if personal.TenantID != owner.ID || personal.IsOrganization() {
+		t.Fatalf("escopo pessoal inesperado: %+v", personal)
+	}
+	if _, err := svc.SetMember(ctx, personal, "viewer@test.com", entity.OrgRoleViewer); !errors.Is(err, ErrOrganizationScope) {
+		t.Fatalf("esperado ErrOrganizationScope, obtido %v", err)
+	}
+	if _, err := svc.ResolveScope(ctx, viewer.ID, org.ID); !errors.Is(err, ErrNotOrgMember) {
+		t.Fatalf("esperado ErrNotOrgMember, obtido %v", err)
+	}
+
+	ownerScope, _ := svc.ResolveScope(ctx, owner.ID, org.ID)
+	if _, err := svc.SetMember(ctx, ownerScope, "viewer@test.com", entity.OrgRoleOwner); !errors.Is(err, ErrOwnerRoleReserved) {
+		t.Fatalf("esperado ErrOwnerRoleReserved, obtido %v", err)
+	}
+	if _, err := svc.SetMember(ctx, ownerScope, "viewer@test.com", entity.OrgRoleViewer); err != nil {
+		t.Fatalf("adicionar membro falhou: %v", err)
+	}
+	if err := svc.RemoveMember(ctx, ownerScope, owner.ID); !errors.Is(err, ErrOwnerRoleReserved) {
+		t.Fatalf("dono não pode ser removido, obtido %v", err)
+	}
+
+	viewerScope, err := svc.ResolveScope(ctx, viewer.ID, org.ID)
+	if err != nil || viewerScope.TenantID != org.ID {
+		t.Fatalf("escopo do membro inesperado: %+v %v", viewerScope, err)
+	}
+	if _, err := svc.Transfer(ctx, viewerScope, uuid.New(), uuid.New(), decimal.NewFromInt(1), ""); !errors.Is(err, ErrOrgPermissionDenied) {
+		t.Fatalf("viewer não pode transferir, obtido %v", err)
+	}
+}
+
+type fakeAccountLedger struct {
+	debited  decimal.Decimal
+	refunded []uuid.UUID
+}
+
+func (l *fakeAccountLedger) DebitForAccount(ctx context.Context, userID, accountID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error) {
+	l.debited = l.debited.Add(amount)
+	return uuid.New(), nil
+}
+func (l *fakeAccountLedger) CreditFromAccount(ctx context.Context, userID, accountID uuid.UUID, amount decimal.Decimal) (uuid.UUID, error) {
+	return uuid.New(), nil
+}
+func (l *fakeAccountLedger) RefundAccountFunding(ctx context.Context, txID uuid.UUID, reason string) error {
+	l.refunded = append(l.refunded, txID)
+	return nil
+}
+
+type failingCreditAccountRepo struct {
+	noopAccountRepo
+	account *entity.Account
+}
+
+func (r failingCreditAccountRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	return r.account, nil
+}
+func (failingCreditAccountRepo) Credit(ctx context.Context, id uuid.UUID, amount decimal.Decimal) error {
+	return errors.New("db down")
+}
+
+func TestOrganizationService_FundAccountRefundsWalletOnFailure(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	account, _ := entity.NewAccount(userID, "operations", "")
+	scope := AccessScope{TenantID: userID, ActorID: userID, Role: entity.OrgRoleOwner}
+
+	svc := NewOrganizationService(&memOrgRepo{}, failingCreditAccountRepo{account: account}, newMemUserRepo2(), events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	if _, err := svc.FundAccount(ctx, scope, account.ID, decimal.NewFromInt(10)); !errors.Is(err, ErrAccountLedgerUnavailable) {
+		t.Fatalf("sem ledger o aporte deveria ser recusado, obtido %v", err)
+	}
+
+	ledger := &fakeAccountLedger{}
+	svc.WithLedger(ledger)
+	if _, err := svc.FundAccount(ctx, scope, account.ID, decimal.NewFromInt(10)); err == nil {
+		t.Fatal("esperado erro ao creditar a conta")
+	}
+	if !ledger.debited.Equal(decimal.NewFromInt(10)) || len(ledger.refunded) != 1 {
+		t.Fatalf("débito deveria ser devolvido à wallet: debitado %s, devoluções %d", ledger.debited, len(ledger.refunded))
+	}
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Ações registradas em audit_logs pelas operações de privacidade
+const (
+	AuditActionDataExport = "LGPD_DATA_EXPORT"
+	AuditActionErasure    = "LGPD_ERASURE"
+)
+
+// retainedOnErasure registros mantidos após a eliminação por obrigação legal
+// (Lei 9.613/98 e normas do BACEN exigem guarda de registros financeiros e de KYC)
+var retainedOnErasure = []string{"transactions", "wallets", "onchain_wallets", "kyc_submissions", "audit_logs"}
+
+// PersonalDataExport pacote com todos os dados pessoais do titular
+type PersonalDataExport struct {
+	GeneratedAt    time.Time                    `json:"generated_at"`
+	Profile        ExportProfile                `json:"profile"`
+	Wallet         *ExportWallet                `json:"wallet,omitempty"`
+	OnChainWallets []entity.OnChainWalletRecord `json:"onchain_wallets"`
+	Transactions   []entity.TransactionRecord   `json:"transactions"`
+	AuditLogs      []entity.AuditLogRecord      `json:"audit_logs"`
+	Sessions       []ExportSession              `json:"sessions,omitempty"`
+	KYCSubmissions []ExportKYCSubmission        `json:"kyc_submissions,omitempty"`
+}
+
+// ExportProfile dados cadastrais
+type ExportProfile struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	KYCLevel  string    `json:"kyc_level"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportWallet wallet custodial (sem chave privada)
+type ExportWallet struct {
+	Address   string    `json:"address"`
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportSession sessão de login registrada
+type ExportSession struct {
+	ID         uuid.UUID  `json:"id"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ExportKYCSubmission documento enviado para verificação
+type ExportKYCSubmission struct {
+	ID             uuid.UUID  `json:"id"`
+	RequestedLevel string     `json:"requested_level"`
+	DocumentType   string     `json:"document_type"`
+	DocumentNumber string     `json:"document_number"`
+	Status         string     `json:"status"`
+	SubmittedAt    time.Time  `json:"submitted_at"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// ErasureReport resultado da eliminação
+type ErasureReport struct {
+	UserID                 uuid.UUID `json:"user_id"`
+	ErasedAt               time.Time `json:"erased_at"`
+	AuditLogsPseudonymized int       `json:"audit_logs_pseudonymized"`
+	SessionsRevoked        int       `json:"sessions_revoked"`
+	SessionsPseudonymized  int       `json:"sessions_pseudonymized"`
+	LoginAttemptsDeleted   int       `json:"login_attempts_deleted"`
+	Retained               []string  `json:"retained"`
+}
+
+// PrivacyService atende pedidos do titular (LGPD): exportação e eliminação de dados pessoais
+type PrivacyService struct {
+	userRepo     repository.UserRepository
+	walletRepo   repository.WalletRepository
+	personalRepo repository.PersonalDataRepository
+	sessionRepo  repository.SessionRepository
+	kycRepo      repository.KYCRepository
+	eventBus     events.Bus
+	logger       *zap.Logger
+	now          func() time.Time
+}
+
+// NewPrivacyService cria o serviço de privacidade
+func NewPrivacyService(
+	userRepo repository.UserRepository,
+	walletRepo repository.WalletRepository,
+	personalRepo repository.PersonalDataRepository,
+	eventBus events.Bus,
+	logger *zap.Logger,
+) *PrivacyService {
+	return &PrivacyService{
+		userRepo:     userRepo,
+		walletRepo:   walletRepo,
+		personalRepo: personalRepo,
+		eventBus:     eventBus,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// WithSessionRepository inclui sessões na exportação; na eliminação elas são revogadas e perdem IP,
+// user agent e impressão do dispositivo
+func (s *PrivacyService) WithSessionRepository(repo repository.SessionRepository) *PrivacyService {
+	s.sessionRepo = repo
+	return s
+}
+
+// WithKYCRepository inclui submissões KYC na exportação
+func (s *PrivacyService) WithKYCRepository(repo repository.KYCRepository) *PrivacyService {
+	s.kycRepo = repo
+	return s
+}
+
+// Export reúne os dados pessoais do usuário. requestedBy é o próprio titular ou o operador.
+func (s *PrivacyService) Export(ctx context.Context, userID, requestedBy uuid.UUID, ip string) (*PersonalDataExport, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &PersonalDataExport{
+		GeneratedAt: s.now().UTC(),
+		Profile: ExportProfile{
+			ID:        user.ID,
+			Email:     user.Email.String(),
+			KYCLevel:  user.KYCLevel.String(),
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+	}
+
+	if wallet, err := s.walletRepo.FindByUserID(ctx, userID); err == nil && wallet != nil {
+		export.Wallet = &ExportWallet{Address: wallet.Address, Balance: wallet.Balance, CreatedAt: wallet.CreatedAt}
+	}
+	if export.OnChainWallets, err = s.personalRepo.FindOnChainWallets(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Transactions, err = s.personalRepo.FindTransactions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.AuditLogs, err = s.personalRepo.FindAuditLogs(ctx, userID); err != nil {
+		return nil, err
+	}
+	if s.sessionRepo != nil {
+		sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID, s.now())
+		if err != nil {
+			return nil, err
+		}
+		for _, ss := range sessions {
+			export.Sessions = append(export.Sessions, ExportSession{
+				ID: ss.ID, IPAddress: ss.IPAddress, UserAgent: ss.UserAgent,
+				CreatedAt: ss.CreatedAt, LastSeenAt: ss.LastSeenAt, RevokedAt: ss.RevokedAt,
+			})
+		}
+	}
+	if s.kycRepo != nil {
+		submissions, err := s.kycRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range submissions {
+			export.KYCSubmissions = append(export.KYCSubmissions, ExportKYCSubmission{
+				ID: k.ID, RequestedLevel: k.RequestedLevel.String(), DocumentType: string(k.DocumentType),
+				DocumentNumber: k.DocumentNumber, Status: string(k.Status), SubmittedAt: k.SubmittedAt, ReviewedAt: k.ReviewedAt,
+			})
+		}
+	}
+
+	s.audit(ctx, userID, AuditActionDataExport, ip, map[string]interface{}{
+		"requested_by": requestedBy,
+		"transactions": len(export.Transactions),
+		"audit_logs":   len(export.AuditLogs),
+	})
+	s.logger.Info("personal data exported", zap.String("user_id", userID.String()), zap.String("requested_by", requestedBy.String()))
+	return export, nil
+}
+
+// Erase pseudonimiza os dados pessoais do usuário em users, sessions e audit_logs e apaga os
+// contadores de login chaveados pelo email.
+// Transações, wallets e KYC são retidos por obrigação legal, vinculados apenas ao ID.
+func (s *PrivacyService) Erase(ctx context.Context, userID, requestedBy uuid.UUID, reason, ip string) (*ErasureReport, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsErased() {
+		return nil, ErrUserAlreadyErased
+	}
+	if wallet, err := s.walletRepo.FindByUserID(ctx, userID); err == nil && wallet != nil && wallet.Balance != 0 {
+		return nil, ErrErasureHasBalance
+	}
+
+	unusable, err := unusablePassword()
+	if err != nil {
+		return nil, err
+	}
+	email := user.Email.String()
+	user.Pseudonymize(unusable)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("failed to pseudonymize user", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	report := &ErasureReport{UserID: userID, ErasedAt: now, Retained: retainedOnErasure}
+
+	if s.sessionRepo != nil {
+		if report.SessionsRevoked, err = s.sessionRepo.RevokeAllExcept(ctx, userID, uuid.Nil, now); err != nil {
+			return nil, err
+		}
+		if report.SessionsPseudonymized, err = s.sessionRepo.PseudonymizeSessions(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	if report.AuditLogsPseudonymized, err = s.personalRepo.PseudonymizeAuditLogs(ctx, userID); err != nil {
+		return nil, err
+	}
+	if report.LoginAttemptsDeleted, err = s.personalRepo.DeleteLoginAttempts(ctx, accountKey(email)); err != nil {
+		return nil, err
+	}
+
+	// Registrado após a pseudonimização para que o próprio registro da operação seja preservado
+	s.audit(ctx, userID, AuditActionErasure, ip, map[string]interface{}{
+		"requested_by":             requestedBy,
+		"reason":                   reason,
+		"audit_logs_pseudonymized": report.AuditLogsPseudonymized,
+		"sessions_revoked":         report.SessionsRevoked,
+		"sessions_pseudonymized":   report.SessionsPseudonymized,
+		"login_attempts_deleted":   report.LoginAttemptsDeleted,
+		"retained":                 report.Retained,
+	})
+	s.eventBus.PublishAsync(ctx, events.NewUserErasedEvent(userID, requestedBy, now))
+
+	s.logger.Info("personal data erased",
+		zap.String("user_id", userID.String()),
+		zap.String("requested_by", requestedBy.String()),
+		zap.Int("audit_logs_pseudonymized", report.AuditLogsPseudonymized),
+	)
+	return report, nil
+}
+
+func (s *PrivacyService) findUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// audit registra a operação; falhas são logadas mas não desfazem a operação já aplicada
+func (s *PrivacyService) audit(ctx context.Context, userID uuid.UUID, action, ip string, payload map[string]interface{}) {
+	data, _ := json.Marshal(payload)
+	record := entity.AuditLogRecord{
+		UserID:     userID,
+		Action:     action,
+		IP:         ip,
+		OldPayload: json.RawMessage(`{}`),
+		NewPayload: data,
+		CreatedAt:  s.now().UTC(),
+	}
+	if err := s.personalRepo.AppendAuditLog(ctx, record); err != nil {
+		s.logger.Error("failed to write privacy audit log", zap.String("action", action), zap.String("user_id", userID.String()), zap.Error(err))
+	}
+}
+
+// unusablePassword gera um hash de senha aleatória que ninguém conhece
+func unusablePassword() (valueobject.HashedPassword, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return valueobject.HashFromRaw(hex.EncodeToString(buf))
+}
+
+// WriteZIP grava a exportação como arquivo ZIP, com um JSON por categoria de dado
+func (e *PersonalDataExport) WriteZIP(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", e.Profile},
+		{"wallet.json", e.Wallet},
+		{"onchain_wallets.json", e.OnChainWallets},
+		{"transactions.json", e.Transactions},
+		{"audit_logs.json", e.AuditLogs},
+		{"sessions.json", e.Sessions},
+		{"kyc_submissions.json", e.KYCSubmissions},
+	}
+
+	manifest := map[string]interface{}{"generated_at": e.GeneratedAt, "user_id": e.Profile.ID}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		if err := writeZIPJSON(zw, f.name, f.data); err != nil {
+			return err
+		}
+		names = append(names, f.name)
+	}
+	manifest["files"] = names
+	if err := writeZIPJSON(zw, "manifest.json", manifest); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func writeZIPJSON(zw *zip.Writer, name string, data interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
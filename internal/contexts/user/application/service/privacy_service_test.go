@@ -0,0 +1,225 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"financial-system-pro/internal/shared/events"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type memPersonalDataRepo struct {
+	txs           []entity.TransactionRecord
+	logs          []entity.AuditLogRecord
+	loginAttempts map[string]int
+}
+
+func (r *memPersonalDataRepo) FindOnChainWallets(ctx context.Context, userID uuid.UUID) ([]entity.OnChainWalletRecord, error) {
+	return nil, nil
+}
+func (r *memPersonalDataRepo) FindTransactions(ctx context.Context, userID uuid.UUID) ([]entity.TransactionRecord, error) {
+	return r.txs, nil
+}
+func (r *memPersonalDataRepo) FindAuditLogs(ctx context.Context, userID uuid.UUID) ([]entity.AuditLogRecord, error) {
+	return r.logs, nil
+}
+func (r *memPersonalDataRepo) PseudonymizeAuditLogs(ctx context.Context, userID uuid.UUID) (int, error) {
+	n := 0
+	for i := range r.logs {
+		if r.logs[i].UserID == userID {
+			r.logs[i].IP = ""
+			n++
+		}
+	}
+	return n, nil
+}
+func (r *memPersonalDataRepo) DeleteLoginAttempts(ctx context.Context, accountKey string) (int, error) {
+	if _, ok := r.loginAttempts[accountKey]; !ok {
+		return 0, nil
+	}
+	delete(r.loginAttempts, accountKey)
+	return 1, nil
+}
+func (r *memPersonalDataRepo) AppendAuditLog(ctx context.Context, l entity.AuditLogRecord) error {
+	r.logs = append(r.logs, l)
+	return nil
+}
+
+var _ userRepo.PersonalDataRepository = (*memPersonalDataRepo)(nil)
+
+type memSessionRepo struct {
+	sessions []*entity.Session
+}
+
+func (r *memSessionRepo) Create(ctx context.Context, session *entity.Session) error {
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+func (r *memSessionRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
+	for _, ss := range r.sessions {
+		if ss.ID == id {
+			return ss, nil
+		}
+	}
+	return nil, nil
+}
+func (r *memSessionRepo) ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*entity.Session, error) {
+	var out []*entity.Session
+	for _, ss := range r.sessions {
+		if ss.UserID == userID && ss.IsActive(now) {
+			out = append(out, ss)
+		}
+	}
+	return out, nil
+}
+func (r *memSessionRepo) Update(ctx context.Context, session *entity.Session) error { return nil }
+func (r *memSessionRepo) RevokeAllExcept(ctx context.Context, userID, keepID uuid.UUID, at time.Time) (int, error) {
+	n := 0
+	for _, ss := range r.sessions {
+		if ss.UserID == userID && ss.ID != keepID && ss.RevokedAt == nil {
+			ss.Revoke(at)
+			n++
+		}
+	}
+	return n, nil
+}
+func (r *memSessionRepo) PseudonymizeSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	n := 0
+	for _, ss := range r.sessions {
+		if ss.UserID == userID {
+			ss.IPAddress, ss.UserAgent, ss.DeviceFingerprint = "", "", ""
+			n++
+		}
+	}
+	return n, nil
+}
+
+var _ userRepo.SessionRepository = (*memSessionRepo)(nil)
+
+func newPrivacyFixture(t *testing.T) (*PrivacyService, *memUserRepo2, *memWalletRepo2, *memPersonalDataRepo, *entity.User) {
+	t.Helper()
+	ctx := context.Background()
+	ur := newMemUserRepo2()
+	wr := newMemWalletRepo2()
+	pr := &memPersonalDataRepo{}
+
+	email, _ := valueobject.NewEmail("titular@test.com")
+	pass, _ := valueobject.HashFromRaw("senha123")
+	user := entity.NewUser(email, pass)
+	_ = ur.Create(ctx, user)
+	_ = wr.Create(ctx, entity.NewWallet(user.ID, "0xabc", "encrypted"))
+	pr.logs = []entity.AuditLogRecord{{UserID: user.ID, Action: "LOGIN", IP: "10.0.0.1", CreatedAt: time.Now()}}
+	pr.txs = []entity.TransactionRecord{{ID: uuid.New(), Type: "deposit", Status: "completed"}}
+
+	svc := NewPrivacyService(ur, wr, pr, events.NewInMemoryBus(zap.NewNop()), zap.NewNop())
+	return svc, ur, wr, pr, user
+}
+
+func TestPrivacyService_ExportIncludesDataAndAudits(t *testing.T) {
+	svc, _, _, pr, user := newPrivacyFixture(t)
+
+	export, err := svc.Export(context.Background(), user.ID, user.ID, "10.0.0.2")
+	if err != nil {
+		t.Fatalf("export falhou: %v", err)
+	}
+	if export.Profile.Email != "titular@test.com" || export.Wallet == nil || len(export.Transactions) != 1 {
+		t.Fatalf("exportação incompleta: %+v", export)
+	}
+	if last := pr.logs[len(pr.logs)-1]; last.Action != AuditActionDataExport {
+		t.Fatalf("esperado registro de auditoria da exportação, obtido %s", last.Action)
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteZIP(&buf); err != nil {
+		t.Fatalf("zip falhou: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip inválido: %v", err)
+	}
+	if len(zr.File) != 8 {
+		t.Fatalf("esperados 8 arquivos no zip, obtidos %d", len(zr.File))
+	}
+}
+
+func TestPrivacyService_ErasePseudonymizes(t *testing.T) {
+	ctx := context.Background()
+	svc, ur, _, pr, user := newPrivacyFixture(t)
+	operator := uuid.New()
+	pr.loginAttempts = map[string]int{accountKey(user.Email.String()): 3}
+
+	report, err := svc.Erase(ctx, user.ID, operator, "pedido do titular", "10.0.0.3")
+	if err != nil {
+		t.Fatalf("erase falhou: %v", err)
+	}
+	if report.AuditLogsPseudonymized != 1 {
+		t.Fatalf("esperado 1 log pseudonimizado, obtido %d", report.AuditLogsPseudonymized)
+	}
+	if report.LoginAttemptsDeleted != 1 || len(pr.loginAttempts) != 0 {
+		t.Fatalf("contadores de login com o email não foram apagados: %v", pr.loginAttempts)
+	}
+
+	stored, _ := ur.FindByID(ctx, user.ID)
+	if !stored.IsErased() || stored.IsActive() || strings.Contains(stored.Email.String(), "titular") {
+		t.Fatalf("usuário não foi pseudonimizado: %s", stored.Email.String())
+	}
+	if stored.Password.Matches("senha123") {
+		t.Fatal("senha antiga ainda válida")
+	}
+	if pr.logs[0].IP != "" {
+		t.Fatal("IP do log de auditoria não removido")
+	}
+	if last := pr.logs[len(pr.logs)-1]; last.Action != AuditActionErasure {
+		t.Fatalf("esperado registro de auditoria da eliminação, obtido %s", last.Action)
+	}
+
+	if _, err := svc.Erase(ctx, user.ID, operator, "de novo", ""); !errors.Is(err, ErrUserAlreadyErased) {
+		t.Fatalf("esperado ErrUserAlreadyErased, obtido %v", err)
+	}
+}
+
+func TestPrivacyService_EraseRefusesNonZeroBalance(t *testing.T) {
+	ctx := context.Background()
+	svc, _, wr, _, user := newPrivacyFixture(t)
+	_ = wr.UpdateBalance(ctx, user.ID, 10)
+
+	if _, err := svc.Erase(ctx, user.ID, uuid.New(), "pedido", ""); !errors.Is(err, ErrErasureHasBalance) {
+		t.Fatalf("esperado ErrErasureHasBalance, obtido %v", err)
+	}
+}
+
+func TestPrivacyService_EraseClearsSessionDeviceData(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _, _, user := newPrivacyFixture(t)
+	now := time.Now()
+	revoked := &entity.Session{ID: uuid.New(), UserID: user.ID, IPAddress: "10.0.0.9", UserAgent: "curl", ExpiresAt: now.Add(time.Hour)}
+	revoked.Revoke(now.Add(-time.Minute))
+	sessions := &memSessionRepo{sessions: []*entity.Session{
+		{ID: uuid.New(), UserID: user.ID, IPAddress: "10.0.0.1", UserAgent: "Mozilla", DeviceFingerprint: "fp", ExpiresAt: now.Add(time.Hour)},
+		revoked,
+	}}
+	svc.WithSessionRepository(sessions)
+
+	report, err := svc.Erase(ctx, user.ID, uuid.New(), "pedido do titular", "")
+	if err != nil {
+		t.Fatalf("erase falhou: %v", err)
+	}
+	if report.SessionsRevoked != 1 || report.SessionsPseudonymized != 2 {
+		t.Fatalf("esperada 1 sessão revogada e 2 pseudonimizadas, obtido %+v", report)
+	}
+	for _, ss := range sessions.sessions {
+		if ss.IPAddress != "" || ss.UserAgent != "" || ss.DeviceFingerprint != "" {
+			t.Fatalf("dados do dispositivo mantidos na sessão %s", ss.ID)
+		}
+	}
+}
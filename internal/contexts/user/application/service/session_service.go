@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/contexts/user/domain/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SessionService registra logins por dispositivo e valida o `jti` dos tokens
+type SessionService struct {
+	repo          repository.SessionRepository
+	logger        *zap.Logger
+	now           func() time.Time
+	ttl           time.Duration
+	touchInterval time.Duration
+}
+
+// NewSessionService cria o serviço de sessões com TTL informado (padrão 24h)
+func NewSessionService(repo repository.SessionRepository, ttl time.Duration, logger *zap.Logger) *SessionService {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &SessionService{
+		repo:          repo,
+		logger:        logger,
+		now:           time.Now,
+		ttl:           ttl,
+		touchInterval: time.Minute,
+	}
+}
+
+// Start registra uma nova sessão para o login bem sucedido
+func (s *SessionService) Start(ctx context.Context, userID uuid.UUID, lc LoginContext) (*entity.Session, error) {
+	session := entity.NewSession(userID, lc.DeviceID, lc.IPAddress, lc.UserAgent, s.ttl)
+	if err := s.repo.Create(ctx, session); err != nil {
+		s.logger.Error("failed to create session", zap.String("user_id", userID.String()), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("session started",
+		zap.String("user_id", userID.String()),
+		zap.String("session_id", session.ID.String()),
+		zap.String("ip", lc.IPAddress),
+	)
+	return session, nil
+}
+
+// Validate confirma que a sessão pertence ao usuário e segue ativa, atualizando o último acesso
+func (s *SessionService) Validate(ctx context.Context, userID, sessionID uuid.UUID, ipAddress string) error {
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	now := s.now()
+	if !session.IsActive(now) {
+		return ErrSessionRevoked
+	}
+	if session.Touch(ipAddress, now, s.touchInterval) {
+		if err := s.repo.Update(ctx, session); err != nil {
+			// Falha ao registrar last seen não invalida a sessão
+			s.logger.Warn("failed to update session last seen", zap.String("session_id", sessionID.String()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// List retorna as sessões ativas do usuário
+func (s *SessionService) List(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	return s.repo.ListActiveByUserID(ctx, userID, s.now())
+}
+
+// Revoke encerra uma sessão específica do usuário
+func (s *SessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := s.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	session.Revoke(s.now())
+	if err := s.repo.Update(ctx, session); err != nil {
+		return err
+	}
+	s.logger.Info("session revoked", zap.String("user_id", userID.String()), zap.String("session_id", sessionID.String()))
+	return nil
+}
+
+// RevokeOthers encerra todas as sessões do usuário exceto a atual
+func (s *SessionService) RevokeOthers(ctx context.Context, userID, currentSessionID uuid.UUID) (int, error) {
+	n, err := s.repo.RevokeAllExcept(ctx, userID, currentSessionID, s.now())
+	if err != nil {
+		return 0, err
+	}
+	s.logger.Info("other sessions revoked", zap.String("user_id", userID.String()), zap.Int("count", n))
+	return n, nil
+}
@@ -18,6 +18,12 @@ type UserService struct {
 	walletRepo repository.WalletRepository
 	eventBus   events.Bus
 	logger     *zap.Logger
+	loginGuard *LoginGuard
+	sessions   *SessionService
+	kyc        *KYCService
+	privacy    *PrivacyService
+	orgs       *OrganizationService
+	addresses  *AddressBookService
 }
 
 // NewUserService cria uma nova instância do serviço
@@ -35,6 +41,67 @@ func NewUserService(
 	}
 }
 
+// WithLoginGuard habilita proteção contra força bruta no login
+func (s *UserService) WithLoginGuard(guard *LoginGuard) *UserService {
+	s.loginGuard = guard
+	return s
+}
+
+// WithSessions habilita o registro de sessões por dispositivo
+func (s *UserService) WithSessions(sessions *SessionService) *UserService {
+	s.sessions = sessions
+	return s
+}
+
+// Sessions retorna o serviço de sessões (nil quando desabilitado)
+func (s *UserService) Sessions() *SessionService {
+	return s.sessions
+}
+
+// WithKYC habilita submissões e revisão de KYC
+func (s *UserService) WithKYC(kyc *KYCService) *UserService {
+	s.kyc = kyc
+	return s
+}
+
+// KYC retorna o serviço de KYC (nil quando desabilitado)
+func (s *UserService) KYC() *KYCService {
+	return s.kyc
+}
+
+// WithPrivacy habilita exportação e eliminação de dados pessoais (LGPD)
+func (s *UserService) WithPrivacy(privacy *PrivacyService) *UserService {
+	s.privacy = privacy
+	return s
+}
+
+// Privacy retorna o serviço de privacidade (nil quando desabilitado)
+func (s *UserService) Privacy() *PrivacyService {
+	return s.privacy
+}
+
+// WithOrganizations habilita organizações e contas nomeadas por tenant
+func (s *UserService) WithOrganizations(orgs *OrganizationService) *UserService {
+	s.orgs = orgs
+	return s
+}
+
+// Organizations retorna o serviço de organizações (nil quando desabilitado)
+func (s *UserService) Organizations() *OrganizationService {
+	return s.orgs
+}
+
+// WithAddressBook habilita o catálogo de endereços de saque
+func (s *UserService) WithAddressBook(addresses *AddressBookService) *UserService {
+	s.addresses = addresses
+	return s
+}
+
+// AddressBook retorna o catálogo de endereços de saque (nil quando desabilitado)
+func (s *UserService) AddressBook() *AddressBookService {
+	return s.addresses
+}
+
 // CreateUser cria um novo usuário com wallet
 
 func (s *UserService) CreateUser(ctx context.Context, emailRaw, passwordRaw string) (*entity.User, error) {
@@ -72,25 +139,82 @@ func (s *UserService) CreateUser(ctx context.Context, emailRaw, passwordRaw stri
 
 // Authenticate valida credenciais e retorna o usuário
 func (s *UserService) Authenticate(ctx context.Context, emailRaw, passwordRaw string) (*entity.User, error) {
+	return s.AuthenticateFrom(ctx, emailRaw, passwordRaw, LoginContext{})
+}
+
+// AuthenticateFrom valida credenciais considerando IP e user agent da requisição.
+// Com LoginGuard configurado, recusa tentativas de contas/IPs bloqueados antes de checar a senha.
+func (s *UserService) AuthenticateFrom(ctx context.Context, emailRaw, passwordRaw string, lc LoginContext) (*entity.User, error) {
 	email, err := valueobject.NewEmail(emailRaw)
 	if err != nil {
 		return nil, ErrInvalidCredentials
 	}
+
+	if s.loginGuard != nil {
+		if err := s.loginGuard.Check(ctx, email.String(), lc.IPAddress); err != nil {
+			s.logger.Warn("login blocked", zap.String("email", email.String()), zap.String("ip", lc.IPAddress), zap.Error(err))
+			return nil, err
+		}
+	}
+
 	user, err := s.userRepo.FindByEmail(ctx, email.String())
 	if err != nil || user == nil { // adiciona verificação nil para evitar panic
 		s.logger.Warn("user not found", zap.String("email", email.String()))
+		s.recordLoginFailure(ctx, uuid.Nil, email.String(), lc)
 		return nil, ErrInvalidCredentials
 	}
+
 	// Verificar senha usando VO
 	if !user.Password.Matches(passwordRaw) {
 		s.logger.Warn("invalid password", zap.String("email", email.String()))
+		s.recordLoginFailure(ctx, user.ID, email.String(), lc)
 		return nil, ErrInvalidCredentials
 	}
-	s.eventBus.PublishAsync(ctx, events.UserAuthenticatedEvent{OldBaseEvent: events.NewOldBaseEvent("user.authenticated", user.ID.String()), UserID: user.ID, Email: user.Email.String(), IPAddress: "", UserAgent: ""})
 
+	if s.loginGuard != nil {
+		if err := s.loginGuard.RecordSuccess(ctx, email.String()); err != nil {
+			s.logger.Error("failed to reset login attempts", zap.String("user_id", user.ID.String()), zap.Error(err))
+		}
+	}
+
+	s.eventBus.PublishAsync(ctx, events.UserAuthenticatedEvent{OldBaseEvent: events.NewOldBaseEvent("user.authenticated", user.ID.String()), UserID: user.ID, Email: user.Email.String(), IPAddress: lc.IPAddress, UserAgent: lc.UserAgent})
 	return user, nil
 }
 
+// RequestUnlock envia por email o token de desbloqueio se a conta estiver bloqueada
+func (s *UserService) RequestUnlock(ctx context.Context, emailRaw string) error {
+	if s.loginGuard == nil {
+		return nil
+	}
+	email, err := valueobject.NewEmail(emailRaw)
+	if err != nil {
+		return ErrInvalidEmail
+	}
+	return s.loginGuard.RequestUnlock(ctx, email.String())
+}
+
+// ConfirmUnlock remove o bloqueio da conta a partir do token recebido por email
+func (s *UserService) ConfirmUnlock(ctx context.Context, emailRaw, token string) error {
+	if s.loginGuard == nil {
+		return ErrInvalidUnlockToken
+	}
+	email, err := valueobject.NewEmail(emailRaw)
+	if err != nil {
+		// Nenhuma conta tem esse email, então não há token válido para ele
+		return ErrInvalidUnlockToken
+	}
+	return s.loginGuard.ConfirmUnlock(ctx, email.String(), token)
+}
+
+func (s *UserService) recordLoginFailure(ctx context.Context, userID uuid.UUID, email string, lc LoginContext) {
+	if s.loginGuard == nil {
+		return
+	}
+	if err := s.loginGuard.RecordFailure(ctx, userID, email, lc.IPAddress); err != nil {
+		s.logger.Error("failed to record login failure", zap.String("email", email), zap.Error(err))
+	}
+}
+
 // GetUserWallet retorna a wallet do usuário
 func (s *UserService) GetUserWallet(ctx context.Context, userID uuid.UUID) (*entity.Wallet, error) {
 	wallet, err := s.walletRepo.FindByUserID(ctx, userID)
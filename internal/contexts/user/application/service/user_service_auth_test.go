@@ -57,6 +57,10 @@ func (authTestWalletRepo) UpdateBalance(ctx context.Context, userID uuid.UUID, b
 	return nil
 }
 
+func (authTestWalletRepo) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	return nil
+}
+
 var _ userRepo.WalletRepository = (*authTestWalletRepo)(nil)
 
 func TestAuthenticate_UserNotFoundReturnsInvalidCredentials(t *testing.T) {
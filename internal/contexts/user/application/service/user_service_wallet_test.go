@@ -68,6 +68,15 @@ func (r *memWalletRepo2) UpdateBalance(ctx context.Context, userID uuid.UUID, ba
 	return nil
 }
 
+func (r *memWalletRepo2) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	w := r.wallets[userID]
+	if w == nil || (delta < 0 && w.Balance+delta < -overdraftLimit) {
+		return entity.ErrWalletInsufficientFunds
+	}
+	w.Balance += delta
+	return nil
+}
+
 var _ userRepo.WalletRepository = (*memWalletRepo2)(nil)
 
 func TestGetUserWallet_Sucesso(t *testing.T) {
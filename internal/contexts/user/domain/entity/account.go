@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultAccountCurrency moeda das contas. As contas são aportadas e resgatadas pela wallet,
+// que só mantém saldo na moeda base, por isso outras moedas são recusadas.
+const DefaultAccountCurrency = "BRL"
+
+var (
+	ErrInvalidAccountName  = errors.New("account name is required")
+	ErrInvalidAmount       = errors.New("amount must be positive")
+	ErrInsufficientFunds   = errors.New("insufficient account balance")
+	ErrSameAccount         = errors.New("source and destination accounts must differ")
+	ErrCurrencyMismatch    = errors.New("accounts have different currencies")
+	ErrCrossTenantTransfer = errors.New("accounts belong to different tenants")
+	ErrUnsupportedCurrency = errors.New("accounts only support the BRL currency")
+)
+
+// Account conta nomeada (operações, folha, tesouraria) pertencente a um tenant.
+// O tenant é o ID do usuário (conta pessoal) ou da organização.
+type Account struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	Name      string
+	Currency  string
+	Balance   decimal.Decimal
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewAccount cria uma conta com saldo zero
+func NewAccount(tenantID uuid.UUID, name, currency string) (*Account, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidAccountName
+	}
+	if currency == "" {
+		currency = DefaultAccountCurrency
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != DefaultAccountCurrency {
+		return nil, ErrUnsupportedCurrency
+	}
+	now := time.Now()
+	return &Account{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		Currency:  currency,
+		Balance:   decimal.Zero,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Credit adiciona saldo
+func (a *Account) Credit(amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	a.Balance = a.Balance.Add(amount)
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// Debit remove saldo
+func (a *Account) Debit(amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if a.Balance.LessThan(amount) {
+		return ErrInsufficientFunds
+	}
+	a.Balance = a.Balance.Sub(amount)
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// AccountTransfer movimentação entre duas contas do mesmo tenant
+type AccountTransfer struct {
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	Amount        decimal.Decimal
+	Currency      string
+	Reference     string
+	CreatedBy     uuid.UUID
+	CreatedAt     time.Time
+}
+
+// TransferBetween valida e aplica a transferência nas duas contas, retornando o registro da movimentação
+func TransferBetween(from, to *Account, amount decimal.Decimal, reference string, createdBy uuid.UUID) (*AccountTransfer, error) {
+	if from.ID == to.ID {
+		return nil, ErrSameAccount
+	}
+	if from.TenantID != to.TenantID {
+		return nil, ErrCrossTenantTransfer
+	}
+	if from.Currency != to.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if err := from.Debit(amount); err != nil {
+		return nil, err
+	}
+	if err := to.Credit(amount); err != nil {
+		return nil, err
+	}
+	return &AccountTransfer{
+		ID:            uuid.New(),
+		TenantID:      from.TenantID,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Reference:     reference,
+		CreatedBy:     createdBy,
+		CreatedAt:     time.Now(),
+	}, nil
+}
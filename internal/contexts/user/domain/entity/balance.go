@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Balance saldo da carteira em uma moeda (fiat ou cripto).
+// O saldo na moeda base continua em Wallet.Balance e aparece aqui como mais uma moeda.
+type Balance struct {
+	UserID    uuid.UUID       `json:"user_id"`
+	Currency  string          `json:"currency"`
+	Amount    decimal.Decimal `json:"amount"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
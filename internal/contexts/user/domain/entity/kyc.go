@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KYCLevel representa o nível de verificação (due diligence) do usuário
+type KYCLevel int
+
+const (
+	KYCLevelNone     KYCLevel = iota // cadastro sem documentos
+	KYCLevelBasic                    // documento de identidade aprovado
+	KYCLevelVerified                 // identidade + comprovante de endereço + selfie
+	KYCLevelEnhanced                 // due diligence reforçada (revisão manual de origem de recursos)
+)
+
+var kycLevelNames = map[KYCLevel]string{
+	KYCLevelNone:     "none",
+	KYCLevelBasic:    "basic",
+	KYCLevelVerified: "verified",
+	KYCLevelEnhanced: "enhanced",
+}
+
+// String retorna o nome do nível
+func (l KYCLevel) String() string {
+	if name, ok := kycLevelNames[l]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// IsValid verifica se o nível é conhecido
+func (l KYCLevel) IsValid() bool {
+	_, ok := kycLevelNames[l]
+	return ok
+}
+
+// ParseKYCLevel converte o nome do nível
+func ParseKYCLevel(s string) (KYCLevel, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for level, name := range kycLevelNames {
+		if name == s {
+			return level, nil
+		}
+	}
+	return KYCLevelNone, ErrInvalidKYCLevel
+}
+
+// KYCDocumentType define os tipos de documento aceitos
+type KYCDocumentType string
+
+const (
+	KYCDocumentIDCard         KYCDocumentType = "id_card"
+	KYCDocumentPassport       KYCDocumentType = "passport"
+	KYCDocumentDriverLicense  KYCDocumentType = "driver_license"
+	KYCDocumentProofOfAddress KYCDocumentType = "proof_of_address"
+	KYCDocumentSelfie         KYCDocumentType = "selfie"
+	KYCDocumentSourceOfFunds  KYCDocumentType = "source_of_funds"
+)
+
+// IsValid verifica se o tipo de documento é aceito
+func (t KYCDocumentType) IsValid() bool {
+	switch t {
+	case KYCDocumentIDCard, KYCDocumentPassport, KYCDocumentDriverLicense,
+		KYCDocumentProofOfAddress, KYCDocumentSelfie, KYCDocumentSourceOfFunds:
+		return true
+	}
+	return false
+}
+
+// KYCStatus define os estados de revisão de uma submissão
+type KYCStatus string
+
+const (
+	KYCStatusPending  KYCStatus = "pending"
+	KYCStatusApproved KYCStatus = "approved"
+	KYCStatusRejected KYCStatus = "rejected"
+)
+
+var (
+	ErrInvalidKYCLevel    = errors.New("invalid kyc level")
+	ErrInvalidKYCDocument = errors.New("invalid kyc document type")
+	ErrKYCAlreadyReviewed = errors.New("kyc submission already reviewed")
+	ErrKYCReasonRequired  = errors.New("rejection reason is required")
+)
+
+// KYCSubmission registra um documento enviado para elevar o nível de verificação
+type KYCSubmission struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	RequestedLevel  KYCLevel
+	DocumentType    KYCDocumentType
+	DocumentNumber  string
+	DocumentRef     string // referência ao arquivo no storage (URL, hash)
+	Status          KYCStatus
+	ReviewerID      *uuid.UUID
+	RejectionReason string
+	SubmittedAt     time.Time
+	ReviewedAt      *time.Time
+}
+
+// NewKYCSubmission cria uma submissão pendente de revisão
+func NewKYCSubmission(userID uuid.UUID, level KYCLevel, docType KYCDocumentType, docNumber, docRef string) (*KYCSubmission, error) {
+	if !level.IsValid() || level == KYCLevelNone {
+		return nil, ErrInvalidKYCLevel
+	}
+	if !docType.IsValid() {
+		return nil, ErrInvalidKYCDocument
+	}
+	return &KYCSubmission{
+		ID:             uuid.New(),
+		UserID:         userID,
+		RequestedLevel: level,
+		DocumentType:   docType,
+		DocumentNumber: strings.TrimSpace(docNumber),
+		DocumentRef:    strings.TrimSpace(docRef),
+		Status:         KYCStatusPending,
+		SubmittedAt:    time.Now(),
+	}, nil
+}
+
+// Approve aprova a submissão
+func (s *KYCSubmission) Approve(reviewerID uuid.UUID, now time.Time) error {
+	if s.Status != KYCStatusPending {
+		return ErrKYCAlreadyReviewed
+	}
+	s.Status = KYCStatusApproved
+	s.ReviewerID = &reviewerID
+	s.ReviewedAt = &now
+	return nil
+}
+
+// Reject rejeita a submissão com o motivo informado ao usuário
+func (s *KYCSubmission) Reject(reviewerID uuid.UUID, reason string, now time.Time) error {
+	if s.Status != KYCStatusPending {
+		return ErrKYCAlreadyReviewed
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrKYCReasonRequired
+	}
+	s.Status = KYCStatusRejected
+	s.ReviewerID = &reviewerID
+	s.RejectionReason = reason
+	s.ReviewedAt = &now
+	return nil
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestKYCSubmission_ReviewStates(t *testing.T) {
+	s, err := NewKYCSubmission(uuid.New(), KYCLevelBasic, KYCDocumentPassport, " AB123 ", "s3://docs/1")
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if s.Status != KYCStatusPending || s.DocumentNumber != "AB123" {
+		t.Fatalf("submissão inválida: %+v", s)
+	}
+
+	reviewer := uuid.New()
+	if err := s.Reject(reviewer, "  ", time.Now()); err != ErrKYCReasonRequired {
+		t.Fatalf("esperado ErrKYCReasonRequired, obtido %v", err)
+	}
+	if err := s.Approve(reviewer, time.Now()); err != nil {
+		t.Fatalf("aprovação falhou: %v", err)
+	}
+	if err := s.Reject(reviewer, "late", time.Now()); err != ErrKYCAlreadyReviewed {
+		t.Fatalf("submissão revisada não deveria mudar, obtido %v", err)
+	}
+}
+
+func TestNewKYCSubmission_Validation(t *testing.T) {
+	if _, err := NewKYCSubmission(uuid.New(), KYCLevelNone, KYCDocumentIDCard, "", ""); err != ErrInvalidKYCLevel {
+		t.Fatalf("esperado ErrInvalidKYCLevel, obtido %v", err)
+	}
+	if _, err := NewKYCSubmission(uuid.New(), KYCLevelBasic, KYCDocumentType("tattoo"), "", ""); err != ErrInvalidKYCDocument {
+		t.Fatalf("esperado ErrInvalidKYCDocument, obtido %v", err)
+	}
+}
+
+func TestUser_RaiseKYCLevelNeverDowngrades(t *testing.T) {
+	u := &User{}
+	if !u.RaiseKYCLevel(KYCLevelVerified) || u.KYCLevel != KYCLevelVerified {
+		t.Fatalf("nível deveria subir")
+	}
+	if u.RaiseKYCLevel(KYCLevelBasic) || u.KYCLevel != KYCLevelVerified {
+		t.Fatalf("nível não deveria ser rebaixado")
+	}
+	if lvl, err := ParseKYCLevel("Enhanced"); err != nil || lvl != KYCLevelEnhanced {
+		t.Fatalf("parse falhou: %v %v", lvl, err)
+	}
+}
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+// LoginAttemptScope identifica a dimensão do contador de falhas de login
+type LoginAttemptScope string
+
+const (
+	LoginScopeAccount LoginAttemptScope = "account"
+	LoginScopeIP      LoginAttemptScope = "ip"
+)
+
+// LockoutPolicy define atrasos progressivos e bloqueio temporário após falhas consecutivas
+type LockoutPolicy struct {
+	MaxFailures     int           // falhas até o bloqueio temporário
+	BaseDelay       time.Duration // atraso após a primeira falha (dobra a cada falha)
+	MaxDelay        time.Duration // teto do atraso progressivo
+	LockoutDuration time.Duration // duração do bloqueio
+	FailureWindow   time.Duration // falhas mais antigas que a janela são descartadas
+}
+
+// DefaultAccountLockoutPolicy retorna a política padrão por conta
+func DefaultAccountLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxFailures:     5,
+		BaseDelay:       1 * time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutDuration: 15 * time.Minute,
+		FailureWindow:   15 * time.Minute,
+	}
+}
+
+// DefaultIPLockoutPolicy retorna a política padrão por IP (mais tolerante por causa de NAT)
+func DefaultIPLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxFailures:     20,
+		BaseDelay:       0,
+		MaxDelay:        0,
+		LockoutDuration: 30 * time.Minute,
+		FailureWindow:   15 * time.Minute,
+	}
+}
+
+// LoginAttempts acumula falhas de login para uma chave (email ou IP)
+type LoginAttempts struct {
+	LastFailureAt        time.Time
+	UpdatedAt            time.Time
+	LockedUntil          *time.Time
+	UnlockTokenExpiresAt *time.Time
+	Scope                LoginAttemptScope
+	Key                  string
+	UnlockTokenHash      string
+	Failures             int
+}
+
+// NewLoginAttempts cria um contador vazio para a chave
+func NewLoginAttempts(scope LoginAttemptScope, key string) *LoginAttempts {
+	return &LoginAttempts{
+		Scope:     scope,
+		Key:       key,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// IsLocked verifica se a chave está bloqueada no instante informado
+func (a *LoginAttempts) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// RetryAfter retorna quanto tempo falta até a próxima tentativa ser aceita
+// Considera tanto o bloqueio quanto o atraso progressivo entre falhas
+func (a *LoginAttempts) RetryAfter(policy LockoutPolicy, now time.Time) time.Duration {
+	if a.IsLocked(now) {
+		return a.LockedUntil.Sub(now)
+	}
+	if a.Failures == 0 || a.expired(policy, now) {
+		return 0
+	}
+	next := a.LastFailureAt.Add(a.progressiveDelay(policy))
+	if now.Before(next) {
+		return next.Sub(now)
+	}
+	return 0
+}
+
+// RegisterFailure contabiliza uma falha e retorna true se ela disparou o bloqueio
+func (a *LoginAttempts) RegisterFailure(policy LockoutPolicy, now time.Time) bool {
+	if a.expired(policy, now) || (a.LockedUntil != nil && !a.IsLocked(now)) {
+		a.Failures = 0
+		a.LockedUntil = nil
+	}
+
+	a.Failures++
+	a.LastFailureAt = now
+	a.UpdatedAt = now
+
+	if policy.MaxFailures > 0 && a.Failures >= policy.MaxFailures && !a.IsLocked(now) {
+		until := now.Add(policy.LockoutDuration)
+		a.LockedUntil = &until
+		return true
+	}
+	return false
+}
+
+// Reset limpa falhas, bloqueio e token de desbloqueio
+func (a *LoginAttempts) Reset() {
+	a.Failures = 0
+	a.LockedUntil = nil
+	a.UnlockTokenHash = ""
+	a.UnlockTokenExpiresAt = nil
+	a.UpdatedAt = time.Now()
+}
+
+// SetUnlockToken registra o hash do token de desbloqueio enviado por email
+func (a *LoginAttempts) SetUnlockToken(tokenHash string, expiresAt time.Time) {
+	a.UnlockTokenHash = tokenHash
+	a.UnlockTokenExpiresAt = &expiresAt
+	a.UpdatedAt = time.Now()
+}
+
+// HasValidUnlockToken verifica se o hash confere e o token ainda não expirou
+func (a *LoginAttempts) HasValidUnlockToken(tokenHash string, now time.Time) bool {
+	if a.UnlockTokenHash == "" || a.UnlockTokenExpiresAt == nil {
+		return false
+	}
+	match := subtle.ConstantTimeCompare([]byte(a.UnlockTokenHash), []byte(tokenHash)) == 1
+	return match && now.Before(*a.UnlockTokenExpiresAt)
+}
+
+// expired indica que a última falha saiu da janela de contagem
+func (a *LoginAttempts) expired(policy LockoutPolicy, now time.Time) bool {
+	if a.IsLocked(now) || policy.FailureWindow <= 0 || a.LastFailureAt.IsZero() {
+		return false
+	}
+	return now.Sub(a.LastFailureAt) > policy.FailureWindow
+}
+
+// progressiveDelay calcula BaseDelay * 2^(falhas-1), limitado por MaxDelay
+func (a *LoginAttempts) progressiveDelay(policy LockoutPolicy) time.Duration {
+	if policy.BaseDelay <= 0 || a.Failures == 0 {
+		return 0
+	}
+	delay := policy.BaseDelay
+	for i := 1; i < a.Failures; i++ {
+		delay *= 2
+		if policy.MaxDelay > 0 && delay >= policy.MaxDelay {
+			return policy.MaxDelay
+		}
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttempts_ProgressiveDelay(t *testing.T) {
+	policy := LockoutPolicy{MaxFailures: 5, BaseDelay: time.Second, MaxDelay: 4 * time.Second, LockoutDuration: time.Minute, FailureWindow: time.Hour}
+	now := time.Now()
+	a := NewLoginAttempts(LoginScopeAccount, "user@test.com")
+
+	assert.Equal(t, time.Duration(0), a.RetryAfter(policy, now))
+
+	a.RegisterFailure(policy, now)
+	assert.Equal(t, time.Second, a.RetryAfter(policy, now))
+
+	a.RegisterFailure(policy, now)
+	assert.Equal(t, 2*time.Second, a.RetryAfter(policy, now))
+
+	a.RegisterFailure(policy, now)
+	a.RegisterFailure(policy, now)
+	assert.Equal(t, 4*time.Second, a.RetryAfter(policy, now), "delay must be capped by MaxDelay")
+	assert.Equal(t, time.Duration(0), a.RetryAfter(policy, now.Add(5*time.Second)))
+}
+
+func TestLoginAttempts_LocksAfterMaxFailures(t *testing.T) {
+	policy := DefaultAccountLockoutPolicy()
+	now := time.Now()
+	a := NewLoginAttempts(LoginScopeAccount, "user@test.com")
+
+	for i := 0; i < policy.MaxFailures-1; i++ {
+		assert.False(t, a.RegisterFailure(policy, now))
+	}
+	assert.True(t, a.RegisterFailure(policy, now))
+	assert.True(t, a.IsLocked(now))
+	assert.Equal(t, policy.LockoutDuration, a.RetryAfter(policy, now))
+
+	// Falhas durante o bloqueio não estendem nem disparam novo bloqueio
+	assert.False(t, a.RegisterFailure(policy, now.Add(time.Minute)))
+
+	// Após expirar, o contador recomeça
+	after := now.Add(policy.LockoutDuration + time.Second)
+	assert.False(t, a.IsLocked(after))
+	assert.False(t, a.RegisterFailure(policy, after))
+	assert.Equal(t, 1, a.Failures)
+}
+
+func TestLoginAttempts_FailureWindowExpires(t *testing.T) {
+	policy := DefaultAccountLockoutPolicy()
+	now := time.Now()
+	a := NewLoginAttempts(LoginScopeIP, "10.0.0.1")
+
+	a.RegisterFailure(policy, now)
+	a.RegisterFailure(policy, now)
+	a.RegisterFailure(policy, now.Add(policy.FailureWindow+time.Second))
+
+	assert.Equal(t, 1, a.Failures)
+}
+
+func TestLoginAttempts_UnlockToken(t *testing.T) {
+	now := time.Now()
+	a := NewLoginAttempts(LoginScopeAccount, "user@test.com")
+	assert.False(t, a.HasValidUnlockToken("hash", now))
+
+	a.SetUnlockToken("hash", now.Add(time.Minute))
+	assert.True(t, a.HasValidUnlockToken("hash", now))
+	assert.False(t, a.HasValidUnlockToken("other", now))
+	assert.False(t, a.HasValidUnlockToken("hash", now.Add(2*time.Minute)))
+
+	a.Reset()
+	require.Empty(t, a.UnlockTokenHash)
+	assert.Nil(t, a.LockedUntil)
+	assert.Equal(t, 0, a.Failures)
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgRole papel de um membro dentro da organização
+type OrgRole string
+
+const (
+	OrgRoleOwner   OrgRole = "owner"
+	OrgRoleAdmin   OrgRole = "admin"
+	OrgRoleFinance OrgRole = "finance"
+	OrgRoleViewer  OrgRole = "viewer"
+)
+
+// OrgPermission ação controlada por papel
+type OrgPermission string
+
+const (
+	PermManageMembers  OrgPermission = "manage_members"
+	PermManageAccounts OrgPermission = "manage_accounts"
+	PermTransfer       OrgPermission = "transfer"
+	PermView           OrgPermission = "view"
+)
+
+var rolePermissions = map[OrgRole][]OrgPermission{
+	OrgRoleOwner:   {PermManageMembers, PermManageAccounts, PermTransfer, PermView},
+	OrgRoleAdmin:   {PermManageMembers, PermManageAccounts, PermTransfer, PermView},
+	OrgRoleFinance: {PermTransfer, PermView},
+	OrgRoleViewer:  {PermView},
+}
+
+var (
+	ErrInvalidOrgName = errors.New("organization name is required")
+	ErrInvalidOrgRole = errors.New("invalid organization role")
+)
+
+// IsValid indica se o papel é conhecido
+func (r OrgRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can indica se o papel concede a permissão
+func (r OrgRole) Can(p OrgPermission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Organization conta empresarial com vários membros e contas nomeadas.
+// O ID da organização é o tenant de suas contas.
+type Organization struct {
+	ID        uuid.UUID
+	Name      string
+	OwnerID   uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewOrganization cria uma organização; o dono deve ser registrado como membro OrgRoleOwner
+func NewOrganization(name string, ownerID uuid.UUID) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidOrgName
+	}
+	now := time.Now()
+	return &Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Membership vínculo de um usuário com uma organização
+type Membership struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           OrgRole
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewMembership cria um vínculo com o papel informado
+func NewMembership(orgID, userID uuid.UUID, role OrgRole) (*Membership, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidOrgRole
+	}
+	now := time.Now()
+	return &Membership{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrgRole_Permissions(t *testing.T) {
+	if !OrgRoleOwner.Can(PermManageMembers) || !OrgRoleFinance.Can(PermTransfer) {
+		t.Fatal("permissões esperadas não concedidas")
+	}
+	if OrgRoleViewer.Can(PermTransfer) || OrgRoleFinance.Can(PermManageAccounts) {
+		t.Fatal("permissão indevida concedida")
+	}
+	if _, err := NewMembership(uuid.New(), uuid.New(), OrgRole("root")); !errors.Is(err, ErrInvalidOrgRole) {
+		t.Fatalf("esperado ErrInvalidOrgRole, obtido %v", err)
+	}
+}
+
+func TestTransferBetween(t *testing.T) {
+	tenantID := uuid.New()
+	ops, _ := NewAccount(tenantID, "operations", "")
+	payroll, _ := NewAccount(tenantID, "payroll", "brl")
+	_ = ops.Credit(decimal.NewFromInt(100))
+
+	transfer, err := TransferBetween(ops, payroll, decimal.NewFromInt(40), "folha", uuid.New())
+	if err != nil {
+		t.Fatalf("transferência falhou: %v", err)
+	}
+	if !ops.Balance.Equal(decimal.NewFromInt(60)) || !payroll.Balance.Equal(decimal.NewFromInt(40)) {
+		t.Fatalf("saldos inesperados: %s / %s", ops.Balance, payroll.Balance)
+	}
+	if transfer.TenantID != tenantID || transfer.Currency != "BRL" {
+		t.Fatalf("registro inesperado: %+v", transfer)
+	}
+
+	if _, err := TransferBetween(ops, payroll, decimal.NewFromInt(61), "", uuid.New()); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("esperado ErrInsufficientFunds, obtido %v", err)
+	}
+	if _, err := NewAccount(tenantID, "usd", "USD"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Fatalf("esperado ErrUnsupportedCurrency, obtido %v", err)
+	}
+	other, _ := NewAccount(uuid.New(), "treasury", "")
+	if _, err := TransferBetween(ops, other, decimal.NewFromInt(1), "", uuid.New()); !errors.Is(err, ErrCrossTenantTransfer) {
+		t.Fatalf("esperado ErrCrossTenantTransfer, obtido %v", err)
+	}
+	if _, err := TransferBetween(ops, ops, decimal.NewFromInt(1), "", uuid.New()); !errors.Is(err, ErrSameAccount) {
+		t.Fatalf("esperado ErrSameAccount, obtido %v", err)
+	}
+}
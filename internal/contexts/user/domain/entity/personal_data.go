@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Registros de leitura de dados pessoais mantidos fora do agregado User.
+// Usados na exportação e anonimização exigidas pela LGPD.
+
+// OnChainWalletRecord carteira on-chain do usuário (sem chave privada)
+type OnChainWalletRecord struct {
+	ID         uuid.UUID `json:"id"`
+	Blockchain string    `json:"blockchain"`
+	Address    string    `json:"address"`
+	PublicKey  string    `json:"public_key"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TransactionRecord transação financeira do usuário
+type TransactionRecord struct {
+	ID              uuid.UUID       `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Amount          decimal.Decimal `json:"amount"`
+	TransactionHash string          `json:"transaction_hash"`
+	FromAddress     string          `json:"from_address"`
+	ToAddress       string          `json:"to_address"`
+	CreatedAt       time.Time       `json:"created_at"`
+	CompletedAt     *time.Time      `json:"completed_at,omitempty"`
+}
+
+// AuditLogRecord entrada de auditoria associada ao usuário
+type AuditLogRecord struct {
+	UserID     uuid.UUID       `json:"user_id"`
+	Action     string          `json:"action"`
+	IP         string          `json:"ip"`
+	OldPayload json.RawMessage `json:"old_payload,omitempty"`
+	NewPayload json.RawMessage `json:"new_payload,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session representa um login ativo de um usuário em um dispositivo.
+// O ID da sessão é usado como `jti` do JWT, permitindo revogação imediata.
+type Session struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	DeviceFingerprint string
+	IPAddress         string
+	UserAgent         string
+	CreatedAt         time.Time
+	LastSeenAt        time.Time
+	ExpiresAt         time.Time
+	RevokedAt         *time.Time
+}
+
+// NewSession cria uma nova sessão para o usuário
+func NewSession(userID uuid.UUID, deviceID, ipAddress, userAgent string, ttl time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		ID:                uuid.New(),
+		UserID:            userID,
+		DeviceFingerprint: DeviceFingerprint(deviceID, userAgent),
+		IPAddress:         ipAddress,
+		UserAgent:         userAgent,
+		CreatedAt:         now,
+		LastSeenAt:        now,
+		ExpiresAt:         now.Add(ttl),
+	}
+}
+
+// IsActive indica se a sessão ainda pode autenticar requisições
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Revoke encerra a sessão; chamadas repetidas mantêm o primeiro instante de revogação
+func (s *Session) Revoke(now time.Time) {
+	if s.RevokedAt != nil {
+		return
+	}
+	s.RevokedAt = &now
+}
+
+// Touch atualiza o último acesso. Retorna false se a atualização anterior
+// é mais recente que minInterval, evitando uma escrita por requisição.
+func (s *Session) Touch(ipAddress string, now time.Time, minInterval time.Duration) bool {
+	if now.Sub(s.LastSeenAt) < minInterval {
+		return false
+	}
+	s.LastSeenAt = now
+	if ipAddress != "" {
+		s.IPAddress = ipAddress
+	}
+	return true
+}
+
+// DeviceFingerprint deriva um identificador estável do dispositivo.
+// Usa o ID enviado pelo cliente quando presente, senão o user agent.
+func DeviceFingerprint(deviceID, userAgent string) string {
+	source := strings.TrimSpace(deviceID)
+	if source == "" {
+		source = strings.TrimSpace(userAgent)
+	}
+	if source == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:16])
+}
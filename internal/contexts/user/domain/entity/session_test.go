@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSession_ActiveAndRevoke(t *testing.T) {
+	s := NewSession(uuid.New(), "", "10.0.0.1", "Mozilla/5.0", time.Hour)
+	now := time.Now()
+	if !s.IsActive(now) {
+		t.Fatalf("sessão nova deveria estar ativa")
+	}
+	if s.IsActive(now.Add(2 * time.Hour)) {
+		t.Fatalf("sessão expirada não deveria estar ativa")
+	}
+
+	s.Revoke(now)
+	first := *s.RevokedAt
+	s.Revoke(now.Add(time.Minute))
+	if !s.RevokedAt.Equal(first) || s.IsActive(now) {
+		t.Fatalf("revogação deveria ser definitiva e idempotente")
+	}
+}
+
+func TestSession_TouchThrottled(t *testing.T) {
+	s := NewSession(uuid.New(), "", "10.0.0.1", "ua", time.Hour)
+	base := s.LastSeenAt
+	if s.Touch("10.0.0.2", base.Add(10*time.Second), time.Minute) {
+		t.Fatalf("touch dentro do intervalo não deveria atualizar")
+	}
+	if !s.Touch("10.0.0.2", base.Add(2*time.Minute), time.Minute) || s.IPAddress != "10.0.0.2" {
+		t.Fatalf("touch deveria atualizar last seen e IP")
+	}
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	if DeviceFingerprint("device-1", "ua") == DeviceFingerprint("", "ua") {
+		t.Fatalf("device id do cliente deveria ter precedência sobre o user agent")
+	}
+	if DeviceFingerprint("", "ua") != DeviceFingerprint(" ", "ua") {
+		t.Fatalf("fingerprint deveria ser estável")
+	}
+	if DeviceFingerprint("", "") != "" {
+		t.Fatalf("sem dados o fingerprint deveria ser vazio")
+	}
+}
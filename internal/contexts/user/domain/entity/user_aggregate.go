@@ -3,6 +3,7 @@ package entity
 import (
 	"errors"
 	"financial-system-pro/internal/contexts/user/domain/valueobject"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,6 +23,7 @@ type User struct {
 	Password  valueobject.HashedPassword
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	KYCLevel  KYCLevel
 	isActive  bool
 }
 
@@ -38,6 +40,9 @@ func NewUser(email valueobject.Email, password valueobject.HashedPassword) *User
 	}
 }
 
+// ErrWalletInsufficientFunds débito que deixaria o saldo abaixo do limite de cheque especial
+var ErrWalletInsufficientFunds = errors.New("insufficient wallet balance")
+
 // Wallet representa a carteira associada ao usuário
 type Wallet struct {
 	ID               uuid.UUID
@@ -114,6 +119,34 @@ func (u *User) IsActive() bool {
 	return u.isActive
 }
 
+// erasedEmailDomain domínio reservado (RFC 2606) usado nos emails pseudonimizados
+const erasedEmailDomain = "erased.invalid"
+
+// Pseudonymize substitui os dados pessoais do usuário após um pedido de eliminação (LGPD).
+// O ID é mantido para preservar o vínculo com registros financeiros retidos por obrigação legal.
+func (u *User) Pseudonymize(unusablePassword valueobject.HashedPassword) {
+	u.Email = valueobject.Email("erased-" + strings.ReplaceAll(u.ID.String(), "-", "") + "@" + erasedEmailDomain)
+	u.Password = unusablePassword
+	u.isActive = false
+	u.UpdatedAt = time.Now()
+}
+
+// IsErased indica se o usuário já foi pseudonimizado
+func (u *User) IsErased() bool {
+	return strings.HasSuffix(u.Email.String(), "@"+erasedEmailDomain)
+}
+
+// RaiseKYCLevel eleva o nível de verificação; nunca rebaixa.
+// Retorna true se o nível mudou.
+func (u *User) RaiseKYCLevel(level KYCLevel) bool {
+	if !level.IsValid() || level <= u.KYCLevel {
+		return false
+	}
+	u.KYCLevel = level
+	u.UpdatedAt = time.Now()
+	return true
+}
+
 // === Comportamentos da Wallet ===
 
 // Credit adiciona fundos à wallet
@@ -129,11 +162,16 @@ func (w *Wallet) Credit(amount float64) error {
 
 // Debit remove fundos da wallet
 func (w *Wallet) Debit(amount float64) error {
+	return w.DebitWithin(amount, 0)
+}
+
+// DebitWithin remove fundos permitindo saldo negativo até o limite de cheque especial
+func (w *Wallet) DebitWithin(amount, overdraftLimit float64) error {
 	if amount <= 0 {
 		return errors.New("debit amount must be positive")
 	}
 
-	if w.Balance < amount {
+	if !w.HasAvailableFunds(amount, overdraftLimit) {
 		return errors.New("insufficient balance")
 	}
 
@@ -144,7 +182,13 @@ func (w *Wallet) Debit(amount float64) error {
 
 // HasSufficientBalance verifica se há saldo suficiente
 func (w *Wallet) HasSufficientBalance(amount float64) bool {
-	return w.Balance >= amount
+	return w.HasAvailableFunds(amount, 0)
+}
+
+// HasAvailableFunds verifica se o saldo somado ao limite de cheque especial cobre o valor, isto é,
+// se o saldo após o débito não fica abaixo de -overdraftLimit
+func (w *Wallet) HasAvailableFunds(amount, overdraftLimit float64) bool {
+	return w.Balance+overdraftLimit >= amount
 }
 
 // GetBalance retorna o saldo atual
@@ -172,5 +216,10 @@ func (a *UserAggregate) DebitWallet(amount float64) error {
 
 // CanWithdraw verifica se o usuário pode fazer saque
 func (a *UserAggregate) CanWithdraw(amount float64) bool {
-	return a.user.IsActive() && a.wallet.HasSufficientBalance(amount)
+	return a.CanWithdrawWithin(amount, 0)
+}
+
+// CanWithdrawWithin verifica se o usuário pode fazer saque usando o limite de cheque especial
+func (a *UserAggregate) CanWithdrawWithin(amount, overdraftLimit float64) bool {
+	return a.user.IsActive() && a.wallet.HasAvailableFunds(amount, overdraftLimit)
 }
@@ -97,6 +97,11 @@ func TestUserAggregate_CanWithdraw(t *testing.T) {
 		assert.False(t, canWithdraw)
 	})
 
+	t.Run("can withdraw within overdraft limit", func(t *testing.T) {
+		assert.True(t, agg.CanWithdrawWithin(150.0, 50.0))
+		assert.False(t, agg.CanWithdrawWithin(150.0, 49.0))
+	})
+
 	t.Run("cannot withdraw when inactive", func(t *testing.T) {
 		agg.User().Deactivate()
 		canWithdraw := agg.CanWithdraw(50.0)
@@ -147,6 +152,21 @@ func TestUser_Deactivate_Activate(t *testing.T) {
 	assert.True(t, user.IsActive())
 }
 
+func TestUser_Pseudonymize(t *testing.T) {
+	email, _ := valueobject.NewEmail("titular@example.com")
+	password, _ := valueobject.HashFromRaw("SecurePassword123!")
+	user := NewUser(email, password)
+	require.False(t, user.IsErased())
+
+	unusable, _ := valueobject.HashFromRaw("random-unknown")
+	user.Pseudonymize(unusable)
+
+	assert.True(t, user.IsErased())
+	assert.False(t, user.IsActive())
+	assert.NotContains(t, user.Email.String(), "titular")
+	assert.False(t, user.Authenticate("SecurePassword123!"))
+}
+
 func TestWallet_Credit(t *testing.T) {
 	wallet := NewWallet(uuid.New(), "0xabc", "privkey")
 
@@ -206,3 +226,16 @@ func TestWallet_HasSufficientBalance(t *testing.T) {
 	assert.True(t, wallet.HasSufficientBalance(100.0))
 	assert.False(t, wallet.HasSufficientBalance(150.0))
 }
+
+func TestWallet_Overdraft(t *testing.T) {
+	wallet := NewWallet(uuid.New(), "0xabc", "privkey")
+	_ = wallet.Credit(100.0)
+
+	assert.True(t, wallet.HasAvailableFunds(150.0, 50.0))
+	assert.False(t, wallet.HasAvailableFunds(150.01, 50.0))
+
+	require.NoError(t, wallet.DebitWithin(130.0, 50.0))
+	assert.Equal(t, -30.0, wallet.Balance)
+	assert.Error(t, wallet.DebitWithin(30.0, 50.0), "saldo abaixo de -50")
+	assert.False(t, wallet.HasSufficientBalance(1.0))
+}
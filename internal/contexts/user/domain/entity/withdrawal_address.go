@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WithdrawalAddressStatus estado de um endereço do catálogo de saques
+type WithdrawalAddressStatus string
+
+const (
+	WithdrawalAddressPending WithdrawalAddressStatus = "pending_confirmation"
+	WithdrawalAddressActive  WithdrawalAddressStatus = "active"
+)
+
+// MaxAddressConfirmationAttempts tentativas de código antes de invalidar a confirmação
+const MaxAddressConfirmationAttempts = 5
+
+var (
+	ErrInvalidAddressLabel       = errors.New("address label is required (max 64 characters)")
+	ErrAddressConfirmationFailed = errors.New("invalid or expired confirmation code")
+	ErrAddressAlreadyConfirmed   = errors.New("address already confirmed")
+	ErrAddressNotConfirmed       = errors.New("withdrawal address not confirmed")
+	ErrAddressCoolingDown        = errors.New("withdrawal address is in cool-down period")
+)
+
+// WithdrawalAddress destino salvo pelo usuário. Só recebe saques depois de confirmado
+// e após o período de cool-down contado a partir da confirmação.
+type WithdrawalAddress struct {
+	ID                   uuid.UUID
+	UserID               uuid.UUID
+	Label                string
+	Chain                string
+	Address              string
+	Status               WithdrawalAddressStatus
+	ConfirmationHash     string
+	ConfirmationExpires  time.Time
+	ConfirmationAttempts int
+	CreatedAt            time.Time
+	ConfirmedAt          *time.Time
+	AvailableAt          *time.Time
+}
+
+// NewWithdrawalAddress cria um endereço aguardando confirmação pelo código enviado ao usuário
+func NewWithdrawalAddress(userID uuid.UUID, label, chain, address, codeHash string, expiresAt, now time.Time) (*WithdrawalAddress, error) {
+	label = strings.TrimSpace(label)
+	if label == "" || len(label) > 64 {
+		return nil, ErrInvalidAddressLabel
+	}
+	return &WithdrawalAddress{
+		ID:                  uuid.New(),
+		UserID:              userID,
+		Label:               label,
+		Chain:               chain,
+		Address:             strings.TrimSpace(address),
+		Status:              WithdrawalAddressPending,
+		ConfirmationHash:    codeHash,
+		ConfirmationExpires: expiresAt,
+		CreatedAt:           now,
+	}, nil
+}
+
+// Confirm valida o hash do código e ativa o endereço, liberando-o após coolDown.
+// Cada código errado consome uma tentativa; esgotadas as tentativas, a confirmação expira.
+func (a *WithdrawalAddress) Confirm(codeHash string, coolDown time.Duration, now time.Time) error {
+	if a.Status == WithdrawalAddressActive {
+		return ErrAddressAlreadyConfirmed
+	}
+	if a.ConfirmationHash == "" || !now.Before(a.ConfirmationExpires) || a.ConfirmationAttempts >= MaxAddressConfirmationAttempts {
+		return ErrAddressConfirmationFailed
+	}
+	if codeHash != a.ConfirmationHash {
+		a.ConfirmationAttempts++
+		return ErrAddressConfirmationFailed
+	}
+
+	available := now.Add(coolDown)
+	a.Status = WithdrawalAddressActive
+	a.ConfirmationHash = ""
+	a.ConfirmedAt = &now
+	a.AvailableAt = &available
+	return nil
+}
+
+// CheckAvailable indica se o endereço já pode receber saques
+func (a *WithdrawalAddress) CheckAvailable(now time.Time) error {
+	if a.Status != WithdrawalAddressActive {
+		return ErrAddressNotConfirmed
+	}
+	if a.AvailableAt != nil && now.Before(*a.AvailableAt) {
+		return ErrAddressCoolingDown
+	}
+	return nil
+}
+
+// Matches compara rede e endereço; endereços EVM (0x) ignoram caixa
+func (a *WithdrawalAddress) Matches(chain, address string) bool {
+	if a.Chain != chain {
+		return false
+	}
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(strings.ToLower(a.Address), "0x") {
+		return strings.EqualFold(a.Address, address)
+	}
+	return a.Address == address
+}
+
+// WithdrawalSettings preferências de saque do usuário
+type WithdrawalSettings struct {
+	UserID        uuid.UUID
+	AllowlistOnly bool
+	// ProtectedUntil mantém a allowlist ativa até o fim do cool-down após ser desligada,
+	// para que quem tomou a conta não possa desativá-la e sacar imediatamente
+	ProtectedUntil *time.Time
+	UpdatedAt      time.Time
+}
+
+// AllowlistEnforced indica se saques só podem ir para endereços do catálogo
+func (s *WithdrawalSettings) AllowlistEnforced(now time.Time) bool {
+	if s == nil {
+		return false
+	}
+	return s.AllowlistOnly || (s.ProtectedUntil != nil && now.Before(*s.ProtectedUntil))
+}
+
+// SetAllowlistOnly liga a allowlist imediatamente; desligar só vale após coolDown
+func (s *WithdrawalSettings) SetAllowlistOnly(enabled bool, coolDown time.Duration, now time.Time) {
+	if enabled {
+		s.AllowlistOnly = true
+		s.ProtectedUntil = nil
+	} else if s.AllowlistOnly {
+		until := now.Add(coolDown)
+		s.AllowlistOnly = false
+		s.ProtectedUntil = &until
+	}
+	s.UpdatedAt = now
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWithdrawalAddress_ConfirmAndCoolDown(t *testing.T) {
+	now := time.Now()
+	a, err := NewWithdrawalAddress(uuid.New(), "Ledger", "ethereum", "0xAbC", "hash-ok", now.Add(15*time.Minute), now)
+	if err != nil {
+		t.Fatalf("criar endereço falhou: %v", err)
+	}
+	if !errors.Is(a.CheckAvailable(now), ErrAddressNotConfirmed) {
+		t.Fatalf("endereço pendente não deveria estar disponível")
+	}
+	if !errors.Is(a.Confirm("hash-errado", time.Hour, now), ErrAddressConfirmationFailed) || a.ConfirmationAttempts != 1 {
+		t.Fatalf("código errado deveria consumir uma tentativa")
+	}
+	if err := a.Confirm("hash-ok", time.Hour, now); err != nil {
+		t.Fatalf("confirmação falhou: %v", err)
+	}
+	if !errors.Is(a.CheckAvailable(now.Add(30*time.Minute)), ErrAddressCoolingDown) {
+		t.Fatalf("endereço deveria estar em cool-down")
+	}
+	if err := a.CheckAvailable(now.Add(2 * time.Hour)); err != nil {
+		t.Fatalf("endereço deveria estar disponível após o cool-down: %v", err)
+	}
+	if !a.Matches("ethereum", "0xabc") || a.Matches("tron", "0xAbC") {
+		t.Fatalf("comparação de endereço EVM deveria ignorar caixa e respeitar a rede")
+	}
+}
+
+func TestWithdrawalAddress_ConfirmExpiresAfterAttempts(t *testing.T) {
+	now := time.Now()
+	a, _ := NewWithdrawalAddress(uuid.New(), "x", "bitcoin", "bc1q", "hash-ok", now.Add(time.Minute), now)
+	for i := 0; i < MaxAddressConfirmationAttempts; i++ {
+		_ = a.Confirm("hash-errado", time.Hour, now)
+	}
+	if !errors.Is(a.Confirm("hash-ok", time.Hour, now), ErrAddressConfirmationFailed) {
+		t.Fatalf("tentativas esgotadas deveriam invalidar a confirmação")
+	}
+
+	b, _ := NewWithdrawalAddress(uuid.New(), "x", "bitcoin", "bc1q", "hash-ok", now.Add(time.Minute), now)
+	if !errors.Is(b.Confirm("hash-ok", time.Hour, now.Add(2*time.Minute)), ErrAddressConfirmationFailed) {
+		t.Fatalf("código expirado não deveria confirmar")
+	}
+}
+
+func TestWithdrawalSettings_DisableKeepsProtection(t *testing.T) {
+	now := time.Now()
+	var none *WithdrawalSettings
+	if none.AllowlistEnforced(now) {
+		t.Fatalf("sem preferências a allowlist não deveria valer")
+	}
+
+	s := &WithdrawalSettings{UserID: uuid.New()}
+	s.SetAllowlistOnly(true, time.Hour, now)
+	s.SetAllowlistOnly(false, time.Hour, now)
+	if s.AllowlistOnly || !s.AllowlistEnforced(now.Add(30*time.Minute)) {
+		t.Fatalf("allowlist deveria continuar valendo durante o cool-down após desligar")
+	}
+	if s.AllowlistEnforced(now.Add(2 * time.Hour)) {
+		t.Fatalf("allowlist não deveria valer após o cool-down")
+	}
+}
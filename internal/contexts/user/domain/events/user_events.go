@@ -94,3 +94,20 @@ func NewWalletDebited(userID uuid.UUID, amount, newBalance float64, reference st
 		Reference:       reference,
 	}
 }
+
+// UserLocked evento disparado quando a conta é bloqueada por excesso de falhas de login
+type UserLocked struct {
+	events.BaseDomainEvent
+	Scope       string
+	Failures    int
+	LockedUntil time.Time
+}
+
+func NewUserLocked(userID uuid.UUID, scope string, failures int, lockedUntil time.Time) *UserLocked {
+	return &UserLocked{
+		BaseDomainEvent: events.NewBaseDomainEvent("UserLocked", userID),
+		Scope:           scope,
+		Failures:        failures,
+		LockedUntil:     lockedUntil,
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// KYCRepository define a persistência das submissões de KYC
+type KYCRepository interface {
+	Create(ctx context.Context, submission *entity.KYCSubmission) error
+	// FindByID retorna nil, nil quando a submissão não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.KYCSubmission, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.KYCSubmission, error)
+	// FindByStatus lista submissões por status, mais antigas primeiro (fila de revisão)
+	FindByStatus(ctx context.Context, status entity.KYCStatus, limit int) ([]*entity.KYCSubmission, error)
+	Update(ctx context.Context, submission *entity.KYCSubmission) error
+}
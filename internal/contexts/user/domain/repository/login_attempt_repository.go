@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+)
+
+// LoginAttemptRepository persiste contadores de falhas de login por conta e por IP.
+// Precisa ser compartilhado entre instâncias (Redis ou Postgres) para o bloqueio sobreviver a restarts.
+type LoginAttemptRepository interface {
+	Find(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempts, error)
+	Save(ctx context.Context, attempts *entity.LoginAttempts) error
+	Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error
+}
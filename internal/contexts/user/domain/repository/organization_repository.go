@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// OrganizationRepository define a persistência de organizações e vínculos de membros.
+// Consultas a membros exigem tenant no context (tenant.WithID) igual ao ID da organização.
+type OrganizationRepository interface {
+	// Create grava a organização e o vínculo do dono na mesma transação
+	Create(ctx context.Context, org *entity.Organization, owner *entity.Membership) error
+	// FindByID retorna nil, nil quando a organização não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+	// ListByMember lista as organizações das quais o usuário é membro (antes de escolher o tenant)
+	ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.Organization, error)
+	// FindMembership resolve o papel do usuário na organização; nil, nil quando não é membro
+	FindMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error)
+	ListMembers(ctx context.Context) ([]*entity.Membership, error)
+	SaveMembership(ctx context.Context, membership *entity.Membership) error
+	DeleteMembership(ctx context.Context, userID uuid.UUID) error
+}
+
+// AccountRepository define a persistência de contas nomeadas.
+// Todas as operações são restritas ao tenant presente no context.
+type AccountRepository interface {
+	Create(ctx context.Context, account *entity.Account) error
+	// FindByID retorna nil, nil quando a conta não existe no tenant
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error)
+	List(ctx context.Context) ([]*entity.Account, error)
+	// Credit soma o valor ao saldo da conta (aporte vindo da wallet do usuário)
+	Credit(ctx context.Context, accountID uuid.UUID, amount decimal.Decimal) error
+	// Debit subtrai o valor do saldo da conta (resgate para a wallet do usuário).
+	// Retorna entity.ErrInsufficientFunds se o saldo não cobre o valor.
+	Debit(ctx context.Context, accountID uuid.UUID, amount decimal.Decimal) error
+	// ApplyTransfer grava atomicamente os saldos das duas contas e o registro da transferência.
+	// Retorna entity.ErrInsufficientFunds se o saldo de origem mudou desde a leitura.
+	ApplyTransfer(ctx context.Context, from, to *entity.Account, transfer *entity.AccountTransfer) error
+	// ListTransfers lista as transferências que envolvem a conta, mais recentes primeiro
+	ListTransfers(ctx context.Context, accountID uuid.UUID, limit int) ([]*entity.AccountTransfer, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PersonalDataRepository acessa dados pessoais fora do agregado User (wallets on-chain,
+// transações e auditoria) para exportação e anonimização
+type PersonalDataRepository interface {
+	FindOnChainWallets(ctx context.Context, userID uuid.UUID) ([]entity.OnChainWalletRecord, error)
+	FindTransactions(ctx context.Context, userID uuid.UUID) ([]entity.TransactionRecord, error)
+	FindAuditLogs(ctx context.Context, userID uuid.UUID) ([]entity.AuditLogRecord, error)
+	// PseudonymizeAuditLogs remove IP e email dos logs do usuário, mantendo ação, valores e datas
+	PseudonymizeAuditLogs(ctx context.Context, userID uuid.UUID) (int, error)
+	// DeleteLoginAttempts apaga os contadores de falha de login da conta, chaveados pelo email
+	DeleteLoginAttempts(ctx context.Context, accountKey string) (int, error)
+	AppendAuditLog(ctx context.Context, record entity.AuditLogRecord) error
+}
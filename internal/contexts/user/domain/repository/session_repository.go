@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionRepository define a persistência das sessões de login
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	// FindByID retorna nil, nil quando a sessão não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Session, error)
+	// ListActiveByUserID lista sessões não revogadas e não expiradas, mais recentes primeiro
+	ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*entity.Session, error)
+	Update(ctx context.Context, session *entity.Session) error
+	// RevokeAllExcept revoga as sessões ativas do usuário, exceto keepID, retornando quantas foram revogadas
+	RevokeAllExcept(ctx context.Context, userID, keepID uuid.UUID, at time.Time) (int, error)
+	// PseudonymizeSessions apaga IP, user agent e impressão do dispositivo de todas as sessões do
+	// usuário, retornando quantas foram alteradas
+	PseudonymizeSessions(ctx context.Context, userID uuid.UUID) (int, error)
+}
@@ -22,4 +22,15 @@ type WalletRepository interface {
 	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Wallet, error)
 	FindByAddress(ctx context.Context, address string) (*entity.Wallet, error)
 	UpdateBalance(ctx context.Context, userID uuid.UUID, balance float64) error
+	// AdjustBalance soma delta ao saldo atual de forma atômica. Débitos (delta negativo) só passam se
+	// o saldo resultante não ficar abaixo de -overdraftLimit; caso contrário, ou sem wallet, retorna
+	// entity.ErrWalletInsufficientFunds
+	AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error
+}
+
+// BalanceRepository consulta os saldos por moeda da carteira, incluindo a moeda base
+type BalanceRepository interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*entity.Balance, error)
+	// Find retorna saldo zero quando o usuário ainda não tem saldo na moeda
+	Find(ctx context.Context, userID uuid.UUID, currency string) (*entity.Balance, error)
 }
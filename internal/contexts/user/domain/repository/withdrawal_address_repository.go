@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// WithdrawalAddressRepository define a persistência do catálogo de endereços de saque
+type WithdrawalAddressRepository interface {
+	Create(ctx context.Context, address *entity.WithdrawalAddress) error
+	// FindByID retorna nil, nil quando o endereço não existe
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.WithdrawalAddress, error)
+	// ListByUserID lista os endereços do usuário, mais recentes primeiro
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WithdrawalAddress, error)
+	Update(ctx context.Context, address *entity.WithdrawalAddress) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindSettings retorna nil, nil quando o usuário nunca alterou as preferências
+	FindSettings(ctx context.Context, userID uuid.UUID) (*entity.WithdrawalSettings, error)
+	SaveSettings(ctx context.Context, settings *entity.WithdrawalSettings) error
+}
@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LogUnlockNotifier implementa UnlockNotifier apenas registrando em log.
+// Usado até existir um provedor de email; o token só aparece em nível debug.
+type LogUnlockNotifier struct {
+	logger *zap.Logger
+}
+
+// NewLogUnlockNotifier cria o notificador baseado em log
+func NewLogUnlockNotifier(logger *zap.Logger) *LogUnlockNotifier {
+	return &LogUnlockNotifier{logger: logger}
+}
+
+// SendUnlockInstructions registra o envio das instruções de desbloqueio
+func (n *LogUnlockNotifier) SendUnlockInstructions(ctx context.Context, email, token string, expiresAt time.Time) error {
+	n.logger.Info("unlock instructions issued",
+		zap.String("email", email),
+		zap.Time("expires_at", expiresAt),
+	)
+	n.logger.Debug("unlock token", zap.String("email", email), zap.String("token", token))
+	return nil
+}
+
+// LogAddressConfirmationNotifier implementa AddressConfirmationNotifier apenas registrando em log.
+// O código só aparece em nível debug.
+type LogAddressConfirmationNotifier struct {
+	logger *zap.Logger
+}
+
+// NewLogAddressConfirmationNotifier cria o notificador de confirmação de endereços baseado em log
+func NewLogAddressConfirmationNotifier(logger *zap.Logger) *LogAddressConfirmationNotifier {
+	return &LogAddressConfirmationNotifier{logger: logger}
+}
+
+// SendAddressConfirmation registra o envio do código de confirmação do novo endereço
+func (n *LogAddressConfirmationNotifier) SendAddressConfirmation(ctx context.Context, email, label, chain, address, code string, expiresAt time.Time) error {
+	n.logger.Info("withdrawal address confirmation issued",
+		zap.String("email", email),
+		zap.String("label", label),
+		zap.String("chain", chain),
+		zap.String("address", address),
+		zap.Time("expires_at", expiresAt),
+	)
+	n.logger.Debug("withdrawal address confirmation code", zap.String("email", email), zap.String("code", code))
+	return nil
+}
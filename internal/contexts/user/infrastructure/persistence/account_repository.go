@@ -0,0 +1,243 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"financial-system-pro/internal/shared/tenant"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresAccountRepository implementa AccountRepository usando PostgreSQL.
+// Toda consulta filtra pelo tenant do context.
+type PostgresAccountRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresAccountRepository cria um novo repositório de contas nomeadas
+func NewPostgresAccountRepository(conn database.Connection) *PostgresAccountRepository {
+	return &PostgresAccountRepository{
+		conn:   conn,
+		schema: "user_context",
+	}
+}
+
+const (
+	accountColumns  = `id, tenant_id, name, currency, balance, created_at, updated_at`
+	transferColumns = `id, tenant_id, from_account_id, to_account_id, amount, currency, reference, created_by, created_at`
+)
+
+// Create insere uma nova conta no tenant atual
+func (r *PostgresAccountRepository) Create(ctx context.Context, a *entity.Account) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if a.TenantID != tenantID {
+		return tenant.ErrTenantMismatch
+	}
+
+	query := `
+		INSERT INTO ` + r.schema + `.accounts (` + accountColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = r.conn.Exec(ctx, query, a.ID, a.TenantID, a.Name, a.Currency, a.Balance, a.CreatedAt, a.UpdatedAt)
+	return err
+}
+
+// FindByID busca uma conta do tenant atual
+func (r *PostgresAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + accountColumns + ` FROM ` + r.schema + `.accounts WHERE id = $1 AND tenant_id = $2`
+
+	a, err := scanAccount(r.conn.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// List lista as contas do tenant atual
+func (r *PostgresAccountRepository) List(ctx context.Context) ([]*entity.Account, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + accountColumns + ` FROM ` + r.schema + `.accounts WHERE tenant_id = $1 ORDER BY name`
+
+	rows, err := r.conn.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []*entity.Account
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+
+	return accounts, rows.Err()
+}
+
+// Credit soma o valor ao saldo de uma conta do tenant atual
+func (r *PostgresAccountRepository) Credit(ctx context.Context, accountID uuid.UUID, amount decimal.Decimal) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE ` + r.schema + `.accounts
+		SET balance = balance + $3, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.conn.Exec(ctx, query, accountID, tenantID, amount)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Debit subtrai o valor do saldo de uma conta do tenant atual, condicionado ao saldo disponível
+func (r *PostgresAccountRepository) Debit(ctx context.Context, accountID uuid.UUID, amount decimal.Decimal) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE ` + r.schema + `.accounts
+		SET balance = balance - $3, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND balance >= $3
+	`
+
+	result, err := r.conn.Exec(ctx, query, accountID, tenantID, amount)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return entity.ErrInsufficientFunds
+	}
+	return nil
+}
+
+// ApplyTransfer debita, credita e registra a transferência em uma única transação.
+// O débito é condicionado ao saldo para não depender apenas da leitura anterior.
+func (r *PostgresAccountRepository) ApplyTransfer(ctx context.Context, from, to *entity.Account, t *entity.AccountTransfer) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if t.TenantID != tenantID {
+		return tenant.ErrTenantMismatch
+	}
+
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(ctx, `
+		UPDATE `+r.schema+`.accounts
+		SET balance = balance - $3, updated_at = $4
+		WHERE id = $1 AND tenant_id = $2 AND balance >= $3
+	`, from.ID, tenantID, t.Amount, from.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return entity.ErrInsufficientFunds
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE `+r.schema+`.accounts
+		SET balance = balance + $3, updated_at = $4
+		WHERE id = $1 AND tenant_id = $2
+	`, to.ID, tenantID, t.Amount, to.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.account_transfers (`+transferColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, t.ID, t.TenantID, t.FromAccountID, t.ToAccountID, t.Amount, t.Currency, t.Reference, t.CreatedBy, t.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListTransfers lista as transferências da conta no tenant atual
+func (r *PostgresAccountRepository) ListTransfers(ctx context.Context, accountID uuid.UUID, limit int) ([]*entity.AccountTransfer, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT ` + transferColumns + `
+		FROM ` + r.schema + `.account_transfers
+		WHERE tenant_id = $1 AND (from_account_id = $2 OR to_account_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.conn.Query(ctx, query, tenantID, accountID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []*entity.AccountTransfer
+	for rows.Next() {
+		t := &entity.AccountTransfer{}
+		if err := rows.Scan(&t.ID, &t.TenantID, &t.FromAccountID, &t.ToAccountID, &t.Amount, &t.Currency, &t.Reference, &t.CreatedBy, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+
+	return transfers, rows.Err()
+}
+
+func scanAccount(row rowScanner) (*entity.Account, error) {
+	a := &entity.Account{}
+	if err := row.Scan(&a.ID, &a.TenantID, &a.Name, &a.Currency, &a.Balance, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
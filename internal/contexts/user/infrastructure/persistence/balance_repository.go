@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresBalanceRepository implementa BalanceRepository: moeda base em wallet_info, demais em wallet_balances
+type PostgresBalanceRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresBalanceRepository cria um novo repositório de saldos por moeda
+func NewPostgresBalanceRepository(conn database.Connection) *PostgresBalanceRepository {
+	return &PostgresBalanceRepository{
+		conn:   conn,
+		schema: "user_context",
+	}
+}
+
+// List lista o saldo na moeda base seguido dos demais saldos em ordem de moeda
+func (r *PostgresBalanceRepository) List(ctx context.Context, userID uuid.UUID) ([]*entity.Balance, error) {
+	query := `
+		SELECT currency, balance, updated_at FROM (
+			SELECT $2::text AS currency, balance, updated_at, 0 AS ord
+			FROM ` + r.schema + `.wallet_info
+			WHERE user_id = $1
+			UNION ALL
+			SELECT currency, balance, updated_at, 1 AS ord
+			FROM ` + r.schema + `.wallet_balances
+			WHERE user_id = $1
+		) b
+		ORDER BY ord, currency
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID, string(sharedVO.BaseCurrency))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balances []*entity.Balance
+	for rows.Next() {
+		b := &entity.Balance{UserID: userID}
+		if err := rows.Scan(&b.Currency, &b.Amount, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		balances = append(balances, b)
+	}
+
+	return balances, rows.Err()
+}
+
+// Find busca o saldo em uma moeda
+func (r *PostgresBalanceRepository) Find(ctx context.Context, userID uuid.UUID, currency string) (*entity.Balance, error) {
+	currency = strings.ToUpper(currency)
+	query := `SELECT balance, updated_at FROM ` + r.schema + `.wallet_balances WHERE user_id = $1 AND currency = $2`
+	args := []interface{}{userID, currency}
+	if currency == string(sharedVO.BaseCurrency) {
+		query = `SELECT balance, updated_at FROM ` + r.schema + `.wallet_info WHERE user_id = $1`
+		args = args[:1]
+	}
+
+	b := &entity.Balance{UserID: userID, Currency: currency, Amount: decimal.Zero}
+	err := r.conn.QueryRow(ctx, query, args...).Scan(&b.Amount, &b.UpdatedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return b, nil
+}
@@ -70,3 +70,18 @@ func (r *GormWalletRepository) UpdateBalance(ctx context.Context, userID uuid.UU
 		Update("balance", balance).
 		Error
 }
+
+// AdjustBalance soma delta ao saldo; o débito é condicionado ao saldo na própria atualização
+func (r *GormWalletRepository) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.WalletModel{}).
+		Where("user_id = ? AND (? >= 0 OR balance + ? >= ?)", userID, delta, delta, -overdraftLimit).
+		Update("balance", gorm.Expr("balance + ?", delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrWalletInsufficientFunds
+	}
+	return nil
+}
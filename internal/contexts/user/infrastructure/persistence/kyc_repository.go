@@ -0,0 +1,151 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresKYCRepository implementa KYCRepository usando PostgreSQL
+type PostgresKYCRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresKYCRepository cria um novo repositório de submissões KYC
+func NewPostgresKYCRepository(conn database.Connection) *PostgresKYCRepository {
+	return &PostgresKYCRepository{
+		conn:   conn,
+		schema: "user_context",
+	}
+}
+
+const kycColumns = `id, user_id, requested_level, document_type, document_number, document_ref,
+	status, reviewer_id, rejection_reason, submitted_at, reviewed_at`
+
+// Create insere uma nova submissão
+func (r *PostgresKYCRepository) Create(ctx context.Context, s *entity.KYCSubmission) error {
+	query := `
+		INSERT INTO ` + r.schema + `.kyc_submissions (` + kycColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.conn.Exec(ctx, query,
+		s.ID,
+		s.UserID,
+		int(s.RequestedLevel),
+		string(s.DocumentType),
+		s.DocumentNumber,
+		s.DocumentRef,
+		string(s.Status),
+		s.ReviewerID,
+		s.RejectionReason,
+		s.SubmittedAt,
+		s.ReviewedAt,
+	)
+
+	return err
+}
+
+// FindByID busca uma submissão por ID
+func (r *PostgresKYCRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.KYCSubmission, error) {
+	query := `SELECT ` + kycColumns + ` FROM ` + r.schema + `.kyc_submissions WHERE id = $1`
+
+	s, err := scanKYCSubmission(r.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// FindByUserID lista as submissões do usuário, mais recentes primeiro
+func (r *PostgresKYCRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.KYCSubmission, error) {
+	query := `
+		SELECT ` + kycColumns + `
+		FROM ` + r.schema + `.kyc_submissions
+		WHERE user_id = $1
+		ORDER BY submitted_at DESC
+	`
+	return r.list(ctx, query, userID)
+}
+
+// FindByStatus lista submissões por status, mais antigas primeiro
+func (r *PostgresKYCRepository) FindByStatus(ctx context.Context, status entity.KYCStatus, limit int) ([]*entity.KYCSubmission, error) {
+	query := `
+		SELECT ` + kycColumns + `
+		FROM ` + r.schema + `.kyc_submissions
+		WHERE status = $1
+		ORDER BY submitted_at ASC
+		LIMIT $2
+	`
+	return r.list(ctx, query, string(status), limit)
+}
+
+// Update grava o resultado da revisão
+func (r *PostgresKYCRepository) Update(ctx context.Context, s *entity.KYCSubmission) error {
+	query := `
+		UPDATE ` + r.schema + `.kyc_submissions
+		SET status = $2, reviewer_id = $3, rejection_reason = $4, reviewed_at = $5
+		WHERE id = $1
+	`
+
+	_, err := r.conn.Exec(ctx, query, s.ID, string(s.Status), s.ReviewerID, s.RejectionReason, s.ReviewedAt)
+	return err
+}
+
+func (r *PostgresKYCRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entity.KYCSubmission, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.KYCSubmission
+	for rows.Next() {
+		s, err := scanKYCSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func scanKYCSubmission(row rowScanner) (*entity.KYCSubmission, error) {
+	s := &entity.KYCSubmission{}
+	var docType, status string
+	var reviewerID uuid.NullUUID
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.RequestedLevel,
+		&docType,
+		&s.DocumentNumber,
+		&s.DocumentRef,
+		&status,
+		&reviewerID,
+		&s.RejectionReason,
+		&s.SubmittedAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.DocumentType = entity.KYCDocumentType(docType)
+	s.Status = entity.KYCStatus(status)
+	if reviewerID.Valid {
+		s.ReviewerID = &reviewerID.UUID
+	}
+	if reviewedAt.Valid {
+		s.ReviewedAt = &reviewedAt.Time
+	}
+	return s, nil
+}
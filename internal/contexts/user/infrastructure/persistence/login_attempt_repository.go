@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+)
+
+// PostgresLoginAttemptRepository implementa LoginAttemptRepository usando PostgreSQL.
+// Como o TTL do Redis, contadores sem falha há mais de ttl (e sem bloqueio vigente) expiram:
+// Find os ignora e Save apaga os expirados.
+type PostgresLoginAttemptRepository struct {
+	conn   database.Connection
+	schema string
+	ttl    time.Duration
+}
+
+// NewPostgresLoginAttemptRepository cria um novo repositório de tentativas de login
+func NewPostgresLoginAttemptRepository(conn database.Connection, ttl time.Duration) *PostgresLoginAttemptRepository {
+	return &PostgresLoginAttemptRepository{
+		conn:   conn,
+		schema: "user_context",
+		ttl:    ttl,
+	}
+}
+
+// Find busca o contador de falhas de uma chave
+func (r *PostgresLoginAttemptRepository) Find(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempts, error) {
+	query := `
+		SELECT scope, key, failures, last_failure_at, locked_until,
+		       unlock_token_hash, unlock_token_expires_at, updated_at
+		FROM ` + r.schema + `.login_attempts
+		WHERE scope = $1 AND key = $2
+		  AND (updated_at >= $3 OR locked_until > $4)
+	`
+
+	attempts := &entity.LoginAttempts{}
+	var lastFailureAt, lockedUntil, tokenExpiresAt sql.NullTime
+	var tokenHash sql.NullString
+
+	now := time.Now()
+	err := r.conn.QueryRow(ctx, query, string(scope), key, now.Add(-r.ttl), now).Scan(
+		&attempts.Scope,
+		&attempts.Key,
+		&attempts.Failures,
+		&lastFailureAt,
+		&lockedUntil,
+		&tokenHash,
+		&tokenExpiresAt,
+		&attempts.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if lastFailureAt.Valid {
+		attempts.LastFailureAt = lastFailureAt.Time
+	}
+	if lockedUntil.Valid {
+		attempts.LockedUntil = &lockedUntil.Time
+	}
+	if tokenExpiresAt.Valid {
+		attempts.UnlockTokenExpiresAt = &tokenExpiresAt.Time
+	}
+	attempts.UnlockTokenHash = tokenHash.String
+
+	return attempts, nil
+}
+
+// Save insere ou atualiza o contador de falhas, apagando antes os contadores expirados
+func (r *PostgresLoginAttemptRepository) Save(ctx context.Context, attempts *entity.LoginAttempts) error {
+	if err := r.purgeExpired(ctx); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ` + r.schema + `.login_attempts
+		(scope, key, failures, last_failure_at, locked_until, unlock_token_hash, unlock_token_expires_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failures = EXCLUDED.failures,
+			last_failure_at = EXCLUDED.last_failure_at,
+			locked_until = EXCLUDED.locked_until,
+			unlock_token_hash = EXCLUDED.unlock_token_hash,
+			unlock_token_expires_at = EXCLUDED.unlock_token_expires_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.conn.Exec(ctx, query,
+		string(attempts.Scope),
+		attempts.Key,
+		attempts.Failures,
+		attempts.LastFailureAt,
+		attempts.LockedUntil,
+		attempts.UnlockTokenHash,
+		attempts.UnlockTokenExpiresAt,
+		attempts.UpdatedAt,
+	)
+
+	return err
+}
+
+// purgeExpired apaga os contadores sem falha dentro do TTL e sem bloqueio vigente
+func (r *PostgresLoginAttemptRepository) purgeExpired(ctx context.Context) error {
+	query := `
+		DELETE FROM ` + r.schema + `.login_attempts
+		WHERE updated_at < $1 AND (locked_until IS NULL OR locked_until <= $2)
+	`
+	now := time.Now()
+	_, err := r.conn.Exec(ctx, query, now.Add(-r.ttl), now)
+	return err
+}
+
+// Delete remove o contador (login bem sucedido ou desbloqueio)
+func (r *PostgresLoginAttemptRepository) Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error {
+	query := `DELETE FROM ` + r.schema + `.login_attempts WHERE scope = $1 AND key = $2`
+	_, err := r.conn.Exec(ctx, query, string(scope), key)
+	return err
+}
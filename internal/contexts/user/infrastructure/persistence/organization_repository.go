@@ -0,0 +1,193 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"financial-system-pro/internal/shared/tenant"
+
+	"github.com/google/uuid"
+)
+
+// PostgresOrganizationRepository implementa OrganizationRepository usando PostgreSQL
+type PostgresOrganizationRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresOrganizationRepository cria um novo repositório de organizações
+func NewPostgresOrganizationRepository(conn database.Connection) *PostgresOrganizationRepository {
+	return &PostgresOrganizationRepository{
+		conn:   conn,
+		schema: "user_context",
+	}
+}
+
+const (
+	organizationColumns = `o.id, o.name, o.owner_id, o.created_at, o.updated_at`
+	membershipColumns   = `organization_id, user_id, role, created_at, updated_at`
+)
+
+// Create grava a organização e o vínculo do dono
+func (r *PostgresOrganizationRepository) Create(ctx context.Context, org *entity.Organization, owner *entity.Membership) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.organizations (id, name, owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, org.ID, org.Name, org.OwnerID, org.CreatedAt, org.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO `+r.schema+`.organization_members (`+membershipColumns+`)
+		VALUES ($1, $2, $3, $4, $5)
+	`, owner.OrganizationID, owner.UserID, string(owner.Role), owner.CreatedAt, owner.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindByID busca uma organização por ID
+func (r *PostgresOrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM ` + r.schema + `.organizations o WHERE o.id = $1`
+
+	org := &entity.Organization{}
+	err := r.conn.QueryRow(ctx, query, id).Scan(&org.ID, &org.Name, &org.OwnerID, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// ListByMember lista as organizações do usuário
+func (r *PostgresOrganizationRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM ` + r.schema + `.organizations o
+		JOIN ` + r.schema + `.organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []*entity.Organization
+	for rows.Next() {
+		org := &entity.Organization{}
+		if err := rows.Scan(&org.ID, &org.Name, &org.OwnerID, &org.CreatedAt, &org.UpdatedAt); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// FindMembership busca o vínculo do usuário com a organização
+func (r *PostgresOrganizationRepository) FindMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error) {
+	query := `
+		SELECT ` + membershipColumns + `
+		FROM ` + r.schema + `.organization_members
+		WHERE organization_id = $1 AND user_id = $2
+	`
+
+	m, err := scanMembership(r.conn.QueryRow(ctx, query, orgID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListMembers lista os membros da organização do tenant atual
+func (r *PostgresOrganizationRepository) ListMembers(ctx context.Context) ([]*entity.Membership, error) {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + membershipColumns + `
+		FROM ` + r.schema + `.organization_members
+		WHERE organization_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.conn.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*entity.Membership
+	for rows.Next() {
+		m, err := scanMembership(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// SaveMembership insere ou atualiza o papel do membro na organização do tenant atual
+func (r *PostgresOrganizationRepository) SaveMembership(ctx context.Context, m *entity.Membership) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if m.OrganizationID != tenantID {
+		return tenant.ErrTenantMismatch
+	}
+
+	query := `
+		INSERT INTO ` + r.schema + `.organization_members (` + membershipColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err = r.conn.Exec(ctx, query, m.OrganizationID, m.UserID, string(m.Role), m.CreatedAt, m.UpdatedAt)
+	return err
+}
+
+// DeleteMembership remove o membro da organização do tenant atual
+func (r *PostgresOrganizationRepository) DeleteMembership(ctx context.Context, userID uuid.UUID) error {
+	tenantID, err := tenant.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM ` + r.schema + `.organization_members WHERE organization_id = $1 AND user_id = $2`
+	_, err = r.conn.Exec(ctx, query, tenantID, userID)
+	return err
+}
+
+func scanMembership(row rowScanner) (*entity.Membership, error) {
+	m := &entity.Membership{}
+	var role string
+	if err := row.Scan(&m.OrganizationID, &m.UserID, &role, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	m.Role = entity.OrgRole(role)
+	return m, nil
+}
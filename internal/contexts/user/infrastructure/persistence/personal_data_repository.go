@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresPersonalDataRepository lê e anonimiza dados pessoais espalhados por outros contextos.
+// Consulta as tabelas legadas (on_chain_wallets, audit_logs), a de transações do transaction_context
+// e os contadores de login do user_context.
+type PostgresPersonalDataRepository struct {
+	conn database.Connection
+}
+
+// NewPostgresPersonalDataRepository cria um novo repositório de dados pessoais
+func NewPostgresPersonalDataRepository(conn database.Connection) *PostgresPersonalDataRepository {
+	return &PostgresPersonalDataRepository{conn: conn}
+}
+
+// FindOnChainWallets lista as carteiras on-chain do usuário
+func (r *PostgresPersonalDataRepository) FindOnChainWallets(ctx context.Context, userID uuid.UUID) ([]entity.OnChainWalletRecord, error) {
+	query := `
+		SELECT id, blockchain, address, public_key, created_at
+		FROM on_chain_wallets
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []entity.OnChainWalletRecord
+	for rows.Next() {
+		var w entity.OnChainWalletRecord
+		if err := rows.Scan(&w.ID, &w.Blockchain, &w.Address, &w.PublicKey, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, w)
+	}
+
+	return wallets, rows.Err()
+}
+
+// FindTransactions lista as transações do usuário
+func (r *PostgresPersonalDataRepository) FindTransactions(ctx context.Context, userID uuid.UUID) ([]entity.TransactionRecord, error) {
+	query := `
+		SELECT id, type, status, amount, transaction_hash, from_address, to_address, created_at, completed_at
+		FROM transaction_context.transactions
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []entity.TransactionRecord
+	for rows.Next() {
+		var (
+			t                     entity.TransactionRecord
+			hash, fromAddr, toAdr sql.NullString
+			completedAt           sql.NullTime
+		)
+		if err := rows.Scan(&t.ID, &t.Type, &t.Status, &t.Amount, &hash, &fromAddr, &toAdr, &t.CreatedAt, &completedAt); err != nil {
+			return nil, err
+		}
+		t.TransactionHash = hash.String
+		t.FromAddress = fromAddr.String
+		t.ToAddress = toAdr.String
+		if completedAt.Valid {
+			t.CompletedAt = &completedAt.Time
+		}
+		txs = append(txs, t)
+	}
+
+	return txs, rows.Err()
+}
+
+// FindAuditLogs lista os registros de auditoria do usuário
+func (r *PostgresPersonalDataRepository) FindAuditLogs(ctx context.Context, userID uuid.UUID) ([]entity.AuditLogRecord, error) {
+	query := `
+		SELECT user_id, action, COALESCE(ip, ''), old_payload, new_payload, created_at
+		FROM audit_logs
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []entity.AuditLogRecord
+	for rows.Next() {
+		var (
+			l                      entity.AuditLogRecord
+			oldPayload, newPayload []byte
+		)
+		if err := rows.Scan(&l.UserID, &l.Action, &l.IP, &oldPayload, &newPayload, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		l.OldPayload = json.RawMessage(oldPayload)
+		l.NewPayload = json.RawMessage(newPayload)
+		logs = append(logs, l)
+	}
+
+	return logs, rows.Err()
+}
+
+// PseudonymizeAuditLogs remove IP e email dos registros de auditoria, mantendo ação e data
+func (r *PostgresPersonalDataRepository) PseudonymizeAuditLogs(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		UPDATE audit_logs
+		SET ip = NULL,
+			old_payload = COALESCE(old_payload, '{}'::jsonb) - 'email',
+			new_payload = COALESCE(new_payload, '{}'::jsonb) - 'email'
+		WHERE user_id = $1
+	`
+
+	result, err := r.conn.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// DeleteLoginAttempts apaga os contadores de falha de login da conta (a chave é o email em texto puro)
+func (r *PostgresPersonalDataRepository) DeleteLoginAttempts(ctx context.Context, accountKey string) (int, error) {
+	query := `
+		DELETE FROM user_context.login_attempts
+		WHERE scope = 'account' AND key = $1
+	`
+
+	result, err := r.conn.Exec(ctx, query, accountKey)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// AppendAuditLog grava uma nova entrada de auditoria
+func (r *PostgresPersonalDataRepository) AppendAuditLog(ctx context.Context, l entity.AuditLogRecord) error {
+	query := `
+		INSERT INTO audit_logs (user_id, action, old_payload, new_payload, ip, created_at)
+		VALUES ($1, $2, $3::jsonb, $4::jsonb, NULLIF($5, ''), $6)
+	`
+
+	_, err := r.conn.Exec(ctx, query, l.UserID, l.Action, string(l.OldPayload), string(l.NewPayload), l.IP, l.CreatedAt)
+	return err
+}
@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisLoginAttemptRepository implementa LoginAttemptRepository usando Redis.
+// Cada chave expira sozinha após o TTL, então contadores antigos não se acumulam.
+type RedisLoginAttemptRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisLoginAttemptRepository cria o repositório a partir de uma URL redis://
+func NewRedisLoginAttemptRepository(redisURL string, ttl time.Duration) (*RedisLoginAttemptRepository, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+	return &RedisLoginAttemptRepository{
+		client: redis.NewClient(opt),
+		ttl:    ttl,
+	}, nil
+}
+
+// Find busca o contador de falhas de uma chave
+func (r *RedisLoginAttemptRepository) Find(ctx context.Context, scope entity.LoginAttemptScope, key string) (*entity.LoginAttempts, error) {
+	val, err := r.client.Get(ctx, r.redisKey(scope, key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var attempts entity.LoginAttempts
+	if err := json.Unmarshal(val, &attempts); err != nil {
+		return nil, err
+	}
+	return &attempts, nil
+}
+
+// Save grava o contador renovando o TTL
+func (r *RedisLoginAttemptRepository) Save(ctx context.Context, attempts *entity.LoginAttempts) error {
+	data, err := json.Marshal(attempts)
+	if err != nil {
+		return err
+	}
+
+	ttl := r.ttl
+	if attempts.LockedUntil != nil {
+		if untilLock := time.Until(*attempts.LockedUntil); untilLock > ttl {
+			ttl = untilLock
+		}
+	}
+	return r.client.Set(ctx, r.redisKey(attempts.Scope, attempts.Key), data, ttl).Err()
+}
+
+// Delete remove o contador
+func (r *RedisLoginAttemptRepository) Delete(ctx context.Context, scope entity.LoginAttemptScope, key string) error {
+	return r.client.Del(ctx, r.redisKey(scope, key)).Err()
+}
+
+func (r *RedisLoginAttemptRepository) redisKey(scope entity.LoginAttemptScope, key string) string {
+	return fmt.Sprintf("login_attempts:%s:%s", scope, key)
+}
@@ -0,0 +1,168 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresSessionRepository implementa SessionRepository usando PostgreSQL
+type PostgresSessionRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresSessionRepository cria um novo repositório de sessões
+func NewPostgresSessionRepository(conn database.Connection) *PostgresSessionRepository {
+	return &PostgresSessionRepository{
+		conn:   conn,
+		schema: "user_context",
+	}
+}
+
+const sessionColumns = `id, user_id, device_fingerprint, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at`
+
+// Create insere uma nova sessão
+func (r *PostgresSessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	query := `
+		INSERT INTO ` + r.schema + `.sessions (` + sessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.conn.Exec(ctx, query,
+		session.ID,
+		session.UserID,
+		session.DeviceFingerprint,
+		session.IPAddress,
+		session.UserAgent,
+		session.CreatedAt,
+		session.LastSeenAt,
+		session.ExpiresAt,
+		session.RevokedAt,
+	)
+
+	return err
+}
+
+// FindByID busca uma sessão pelo ID (jti)
+func (r *PostgresSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM ` + r.schema + `.sessions WHERE id = $1`
+
+	session, err := scanSession(r.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListActiveByUserID lista as sessões ativas do usuário
+func (r *PostgresSessionRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*entity.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM ` + r.schema + `.sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*entity.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// Update grava last seen, IP e revogação da sessão
+func (r *PostgresSessionRepository) Update(ctx context.Context, session *entity.Session) error {
+	query := `
+		UPDATE ` + r.schema + `.sessions
+		SET ip_address = $2, last_seen_at = $3, revoked_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.conn.Exec(ctx, query, session.ID, session.IPAddress, session.LastSeenAt, session.RevokedAt)
+	return err
+}
+
+// RevokeAllExcept revoga as sessões ativas do usuário, exceto keepID
+func (r *PostgresSessionRepository) RevokeAllExcept(ctx context.Context, userID, keepID uuid.UUID, at time.Time) (int, error) {
+	query := `
+		UPDATE ` + r.schema + `.sessions
+		SET revoked_at = $3
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.conn.Exec(ctx, query, userID, keepID, at)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// PseudonymizeSessions apaga os dados do dispositivo de todas as sessões do usuário, inclusive as
+// revogadas e expiradas
+func (r *PostgresSessionRepository) PseudonymizeSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		UPDATE ` + r.schema + `.sessions
+		SET ip_address = '', user_agent = '', device_fingerprint = ''
+		WHERE user_id = $1 AND (ip_address <> '' OR user_agent <> '' OR device_fingerprint <> '')
+	`
+
+	result, err := r.conn.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (*entity.Session, error) {
+	session := &entity.Session{}
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.DeviceFingerprint,
+		&session.IPAddress,
+		&session.UserAgent,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	return session, nil
+}
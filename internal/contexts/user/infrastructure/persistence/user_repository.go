@@ -28,14 +28,15 @@ func NewPostgresUserRepository(conn database.Connection) *PostgresUserRepository
 // Create insere um novo usuário no banco
 func (r *PostgresUserRepository) Create(ctx context.Context, user *entity.User) error {
 	query := `
-		INSERT INTO ` + r.schema + `.users (id, email, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO ` + r.schema + `.users (id, email, password, kyc_level, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
 	_, err := r.conn.Exec(ctx, query,
 		user.ID,
 		user.Email,
 		user.Password,
+		int(user.KYCLevel),
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -46,7 +47,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *entity.User)
 // FindByID busca um usuário por ID
 func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	query := `
-		SELECT id, email, password, created_at, updated_at
+		SELECT id, email, password, kyc_level, created_at, updated_at
 		FROM ` + r.schema + `.users
 		WHERE id = $1
 	`
@@ -56,6 +57,7 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*e
 		&user.ID,
 		&user.Email,
 		&user.Password,
+		&user.KYCLevel,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -73,7 +75,7 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*e
 // FindByEmail busca um usuário por email
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
-		SELECT id, email, password, created_at, updated_at
+		SELECT id, email, password, kyc_level, created_at, updated_at
 		FROM ` + r.schema + `.users
 		WHERE email = $1
 	`
@@ -83,6 +85,7 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 		&user.ID,
 		&user.Email,
 		&user.Password,
+		&user.KYCLevel,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -101,7 +104,7 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE ` + r.schema + `.users
-		SET email = $2, password = $3, updated_at = $4
+		SET email = $2, password = $3, kyc_level = $4, updated_at = $5
 		WHERE id = $1
 	`
 
@@ -111,6 +114,7 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User)
 		user.ID,
 		user.Email,
 		user.Password,
+		int(user.KYCLevel),
 		user.UpdatedAt,
 	)
 
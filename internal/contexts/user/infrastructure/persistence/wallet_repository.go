@@ -115,3 +115,24 @@ func (r *PostgresWalletRepository) UpdateBalance(ctx context.Context, userID uui
 	_, err := r.conn.Exec(ctx, query, userID, balance, time.Now())
 	return err
 }
+
+// AdjustBalance soma delta ao saldo; o débito é condicionado ao saldo na própria atualização para
+// não depender da leitura anterior
+func (r *PostgresWalletRepository) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	query := `
+		UPDATE ` + r.schema + `.wallet_info
+		SET balance = balance + $2, updated_at = $4
+		WHERE user_id = $1 AND ($2 >= 0 OR balance + $2 >= -$3)
+	`
+
+	result, err := r.conn.Exec(ctx, query, userID, delta, overdraftLimit, time.Now())
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return entity.ErrWalletInsufficientFunds
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"financial-system-pro/internal/contexts/user/domain/entity"
+	"financial-system-pro/internal/shared/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresWithdrawalAddressRepository implementa WithdrawalAddressRepository usando PostgreSQL
+type PostgresWithdrawalAddressRepository struct {
+	conn   database.Connection
+	schema string
+}
+
+// NewPostgresWithdrawalAddressRepository cria um novo repositório do catálogo de endereços
+func NewPostgresWithdrawalAddressRepository(conn database.Connection) *PostgresWithdrawalAddressRepository {
+	return &PostgresWithdrawalAddressRepository{
+		conn:   conn,
+		schema: "user_context",
+	}
+}
+
+const withdrawalAddressColumns = `id, user_id, label, chain, address, status, confirmation_hash,
+	confirmation_expires_at, confirmation_attempts, created_at, confirmed_at, available_at`
+
+// Create insere um novo endereço
+func (r *PostgresWithdrawalAddressRepository) Create(ctx context.Context, a *entity.WithdrawalAddress) error {
+	query := `
+		INSERT INTO ` + r.schema + `.withdrawal_addresses (` + withdrawalAddressColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.conn.Exec(ctx, query,
+		a.ID,
+		a.UserID,
+		a.Label,
+		a.Chain,
+		a.Address,
+		string(a.Status),
+		a.ConfirmationHash,
+		a.ConfirmationExpires,
+		a.ConfirmationAttempts,
+		a.CreatedAt,
+		a.ConfirmedAt,
+		a.AvailableAt,
+	)
+
+	return err
+}
+
+// FindByID busca um endereço por ID
+func (r *PostgresWithdrawalAddressRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.WithdrawalAddress, error) {
+	query := `SELECT ` + withdrawalAddressColumns + ` FROM ` + r.schema + `.withdrawal_addresses WHERE id = $1`
+
+	a, err := scanWithdrawalAddress(r.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// ListByUserID lista os endereços do usuário, mais recentes primeiro
+func (r *PostgresWithdrawalAddressRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WithdrawalAddress, error) {
+	query := `
+		SELECT ` + withdrawalAddressColumns + `
+		FROM ` + r.schema + `.withdrawal_addresses
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.WithdrawalAddress
+	for rows.Next() {
+		a, err := scanWithdrawalAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// Update grava o estado da confirmação
+func (r *PostgresWithdrawalAddressRepository) Update(ctx context.Context, a *entity.WithdrawalAddress) error {
+	query := `
+		UPDATE ` + r.schema + `.withdrawal_addresses
+		SET status = $2, confirmation_hash = $3, confirmation_attempts = $4, confirmed_at = $5, available_at = $6
+		WHERE id = $1
+	`
+
+	_, err := r.conn.Exec(ctx, query, a.ID, string(a.Status), a.ConfirmationHash, a.ConfirmationAttempts, a.ConfirmedAt, a.AvailableAt)
+	return err
+}
+
+// Delete remove o endereço do catálogo
+func (r *PostgresWithdrawalAddressRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.conn.Exec(ctx, `DELETE FROM `+r.schema+`.withdrawal_addresses WHERE id = $1`, id)
+	return err
+}
+
+// FindSettings busca as preferências de saque do usuário
+func (r *PostgresWithdrawalAddressRepository) FindSettings(ctx context.Context, userID uuid.UUID) (*entity.WithdrawalSettings, error) {
+	query := `
+		SELECT user_id, allowlist_only, protected_until, updated_at
+		FROM ` + r.schema + `.withdrawal_settings
+		WHERE user_id = $1
+	`
+
+	s := &entity.WithdrawalSettings{}
+	var protectedUntil sql.NullTime
+	err := r.conn.QueryRow(ctx, query, userID).Scan(&s.UserID, &s.AllowlistOnly, &protectedUntil, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if protectedUntil.Valid {
+		s.ProtectedUntil = &protectedUntil.Time
+	}
+	return s, nil
+}
+
+// SaveSettings grava (upsert) as preferências de saque
+func (r *PostgresWithdrawalAddressRepository) SaveSettings(ctx context.Context, s *entity.WithdrawalSettings) error {
+	query := `
+		INSERT INTO ` + r.schema + `.withdrawal_settings (user_id, allowlist_only, protected_until, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET allowlist_only = EXCLUDED.allowlist_only, protected_until = EXCLUDED.protected_until, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.conn.Exec(ctx, query, s.UserID, s.AllowlistOnly, s.ProtectedUntil, s.UpdatedAt)
+	return err
+}
+
+func scanWithdrawalAddress(row rowScanner) (*entity.WithdrawalAddress, error) {
+	a := &entity.WithdrawalAddress{}
+	var status string
+	var confirmedAt, availableAt sql.NullTime
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.Label,
+		&a.Chain,
+		&a.Address,
+		&status,
+		&a.ConfirmationHash,
+		&a.ConfirmationExpires,
+		&a.ConfirmationAttempts,
+		&a.CreatedAt,
+		&confirmedAt,
+		&availableAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.Status = entity.WithdrawalAddressStatus(status)
+	if confirmedAt.Valid {
+		a.ConfirmedAt = &confirmedAt.Time
+	}
+	if availableAt.Valid {
+		a.AvailableAt = &availableAt.Time
+	}
+	return a, nil
+}
@@ -4,28 +4,61 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"financial-system-pro/internal/application/services"
 	bcApp "financial-system-pro/internal/contexts/blockchain/application"
+	bcSvc "financial-system-pro/internal/contexts/blockchain/application/service"
+	bcEntity "financial-system-pro/internal/contexts/blockchain/domain/entity"
 	bcGw "financial-system-pro/internal/contexts/blockchain/infrastructure/gateway"
+	bcPers "financial-system-pro/internal/contexts/blockchain/infrastructure/persistence"
+	complianceSvc "financial-system-pro/internal/contexts/compliance/application/service"
+	complianceEntity "financial-system-pro/internal/contexts/compliance/domain/entity"
+	complianceRepo "financial-system-pro/internal/contexts/compliance/domain/repository"
+	complianceDomain "financial-system-pro/internal/contexts/compliance/domain/service"
+	compliancePers "financial-system-pro/internal/contexts/compliance/infrastructure/persistence"
+	complianceSanctions "financial-system-pro/internal/contexts/compliance/infrastructure/sanctions"
+	complianceSignals "financial-system-pro/internal/contexts/compliance/infrastructure/signals"
+	fxApp "financial-system-pro/internal/contexts/fx/application/service"
+	fxRepo "financial-system-pro/internal/contexts/fx/domain/repository"
+	fxDomain "financial-system-pro/internal/contexts/fx/domain/service"
+	fxPers "financial-system-pro/internal/contexts/fx/infrastructure/persistence"
+	fxPrices "financial-system-pro/internal/contexts/fx/infrastructure/prices"
+	fxRates "financial-system-pro/internal/contexts/fx/infrastructure/rates"
+	portfolioSvc "financial-system-pro/internal/contexts/portfolio/application/service"
+	portfolioRepo "financial-system-pro/internal/contexts/portfolio/domain/repository"
+	portfolioPers "financial-system-pro/internal/contexts/portfolio/infrastructure/persistence"
+	taxSvc "financial-system-pro/internal/contexts/tax/application/service"
+	taxRepo "financial-system-pro/internal/contexts/tax/domain/repository"
+	taxPers "financial-system-pro/internal/contexts/tax/infrastructure/persistence"
 	txnSvc "financial-system-pro/internal/contexts/transaction/application/service"
+	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
 	txnRepo "financial-system-pro/internal/contexts/transaction/domain/repository"
+	txnNotif "financial-system-pro/internal/contexts/transaction/infrastructure/notification"
 	txnPers "financial-system-pro/internal/contexts/transaction/infrastructure/persistence"
+	txnPix "financial-system-pro/internal/contexts/transaction/infrastructure/pix"
+	txnSched "financial-system-pro/internal/contexts/transaction/infrastructure/scheduling"
 	userSvc "financial-system-pro/internal/contexts/user/application/service"
 	userRepo "financial-system-pro/internal/contexts/user/domain/repository"
+	userNotif "financial-system-pro/internal/contexts/user/infrastructure/notification"
 	userPers "financial-system-pro/internal/contexts/user/infrastructure/persistence"
 	"financial-system-pro/internal/domain/entities"
 	repositories "financial-system-pro/internal/infrastructure/database"
 	"financial-system-pro/internal/infrastructure/logger"
 	messaging "financial-system-pro/internal/infrastructure/messaging"
 	"financial-system-pro/internal/shared/breaker"
+	"financial-system-pro/internal/shared/cqrs"
 	"financial-system-pro/internal/shared/database"
+	sharedVO "financial-system-pro/internal/shared/domain/valueobject"
 	"financial-system-pro/internal/shared/events"
 	"financial-system-pro/internal/shared/tracing"
 	"financial-system-pro/internal/shared/validator"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -36,10 +69,18 @@ type RegisterRoutesFunc func(
 	app *fiber.App,
 	dddUserService *userSvc.UserService,
 	dddTransactionService *txnSvc.TransactionService,
+	services RouteServices,
 	logger *zap.Logger,
 	breakerManager *breaker.BreakerManager,
 )
 
+// RouteServices agrupa os serviços de outros contextos expostos pelas rotas v2 (nil se desabilitados)
+type RouteServices struct {
+	Conversions *fxApp.ConversionService
+	TaxLots     *taxSvc.TaxLotService
+	Portfolio   *portfolioSvc.PortfolioService
+}
+
 // Tipos para DDD Repositories e Services (evita conflitos no fx)
 type (
 	DDDUserRepositoryType                  struct{}
@@ -174,6 +215,7 @@ func StartServer(
 	// DDD Services
 	dddUserService *userSvc.UserService,
 	dddTransactionService *txnSvc.TransactionService,
+	routeServices RouteServices,
 ) {
 	// Inicializar distributed tracing
 	shutdownTracer, err := tracing.InitTracer("financial-system-pro", lg)
@@ -197,7 +239,7 @@ func StartServer(
 			// Registrar apenas rotas DDD se disponíveis, senão health checks
 			if registerRoutes != nil && dddUserService != nil && dddTransactionService != nil {
 				lg.Info("registering DDD v2 routes")
-				registerRoutes(app, dddUserService, dddTransactionService, lg, breakerManager)
+				registerRoutes(app, dddUserService, dddTransactionService, routeServices, lg, breakerManager)
 			} else {
 				lg.Warn("DDD services missing; registering health checks only")
 				registerFiberHealthChecks(app)
@@ -244,6 +286,37 @@ func StartServer(
 	})
 }
 
+// runPeriodic roda a varredura em background entre o OnStart e o OnStop do ciclo de vida
+func runPeriodic(lc fx.Lifecycle, run func(context.Context, time.Duration), interval time.Duration) {
+	runCtx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go run(runCtx, interval)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+// dispatchInlineWithoutWorker desliga o dispatcher no OnStart quando o worker não subiu, para que as
+// tarefas rodem na própria varredura. O hook do worker é registrado antes e já rodou.
+func dispatchInlineWithoutWorker(lc fx.Lifecycle, worker *txnSched.Worker, disable func()) {
+	if worker == nil {
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if !worker.Running() {
+				disable()
+			}
+			return nil
+		},
+	})
+}
+
 // registerFiberHealthChecks registra endpoints de health check
 func registerFiberHealthChecks(app *fiber.App) {
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -257,6 +330,15 @@ func registerFiberHealthChecks(app *fiber.App) {
 	})
 }
 
+// ProvideRouteServices agrupa os serviços de câmbio, lotes fiscais e portfólio para as rotas v2
+func ProvideRouteServices(
+	conversions *fxApp.ConversionService,
+	taxLots *taxSvc.TaxLotService,
+	portfolio *portfolioSvc.PortfolioService,
+) RouteServices {
+	return RouteServices{Conversions: conversions, TaxLots: taxLots, Portfolio: portfolio}
+}
+
 // ProvideRegisterRoutes fornece a função de registro de rotas
 // Usa a versão padrão ou a registrada pelo package api
 func ProvideRegisterRoutes() RegisterRoutesFunc {
@@ -283,17 +365,125 @@ func ProvideWalletRepository(conn database.Connection) userRepo.WalletRepository
 	return userPers.NewPostgresWalletRepository(conn)
 }
 
+// loginAttemptTTL tempo sem novas falhas após o qual o contador de login expira
+const loginAttemptTTL = 24 * time.Hour
+
+// ProvideLoginAttemptRepository cria o repositório de falhas de login
+// Usa Redis quando configurado (compartilhado entre instâncias), senão PostgreSQL
+func ProvideLoginAttemptRepository(cfg Config, conn database.Connection, lg *zap.Logger) userRepo.LoginAttemptRepository {
+	if cfg.RedisURL != "" {
+		repo, err := userPers.NewRedisLoginAttemptRepository(cfg.RedisURL, loginAttemptTTL)
+		if err == nil {
+			return repo
+		}
+		lg.Warn("redis login attempt repository unavailable, falling back to postgres", zap.Error(err))
+	}
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresLoginAttemptRepository(conn, loginAttemptTTL)
+}
+
+// ProvideSessionRepository cria o repositório de sessões de login
+func ProvideSessionRepository(conn database.Connection) userRepo.SessionRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresSessionRepository(conn)
+}
+
+// ProvideKYCRepository cria o repositório de submissões KYC
+func ProvideKYCRepository(conn database.Connection) userRepo.KYCRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresKYCRepository(conn)
+}
+
+// ProvidePersonalDataRepository cria o repositório de dados pessoais (LGPD)
+func ProvidePersonalDataRepository(conn database.Connection) userRepo.PersonalDataRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresPersonalDataRepository(conn)
+}
+
+// ProvideOrganizationRepository cria o repositório de organizações e membros
+func ProvideOrganizationRepository(conn database.Connection) userRepo.OrganizationRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresOrganizationRepository(conn)
+}
+
+// ProvideAccountRepository cria o repositório de contas nomeadas por tenant
+func ProvideAccountRepository(conn database.Connection) userRepo.AccountRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresAccountRepository(conn)
+}
+
+// ProvideWithdrawalAddressRepository cria o repositório do catálogo de endereços de saque
+func ProvideWithdrawalAddressRepository(conn database.Connection) userRepo.WithdrawalAddressRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresWithdrawalAddressRepository(conn)
+}
+
 // ProvideDDDUserService cria o UserService do DDD User Context
 func ProvideDDDUserService(
 	userRepoImpl userRepo.UserRepository,
 	walletRepoImpl userRepo.WalletRepository,
+	loginAttemptRepo userRepo.LoginAttemptRepository,
+	sessionRepo userRepo.SessionRepository,
+	kycRepo userRepo.KYCRepository,
+	personalDataRepo userRepo.PersonalDataRepository,
+	orgRepo userRepo.OrganizationRepository,
+	accountRepo userRepo.AccountRepository,
+	withdrawalAddressRepo userRepo.WithdrawalAddressRepository,
+	registry *bcApp.BlockchainRegistry,
 	eventBus events.Bus,
 	lg *zap.Logger,
 ) *userSvc.UserService {
 	if userRepoImpl == nil || walletRepoImpl == nil {
 		return nil
 	}
-	return userSvc.NewUserService(userRepoImpl, walletRepoImpl, eventBus, lg)
+	svc := userSvc.NewUserService(userRepoImpl, walletRepoImpl, eventBus, lg)
+	if loginAttemptRepo != nil {
+		guard := userSvc.NewLoginGuard(loginAttemptRepo, userNotif.NewLogUnlockNotifier(lg), eventBus, lg)
+		svc.WithLoginGuard(guard)
+	}
+	if sessionRepo != nil {
+		// Sessões expiram junto com o JWT emitido no login
+		expiration, _ := strconv.Atoi(os.Getenv("EXPIRATION_TIME"))
+		svc.WithSessions(userSvc.NewSessionService(sessionRepo, time.Duration(expiration)*time.Second, lg))
+	}
+	if kycRepo != nil {
+		svc.WithKYC(userSvc.NewKYCService(userRepoImpl, kycRepo, eventBus, lg))
+	}
+	if personalDataRepo != nil {
+		privacy := userSvc.NewPrivacyService(userRepoImpl, walletRepoImpl, personalDataRepo, eventBus, lg)
+		if sessionRepo != nil {
+			privacy.WithSessionRepository(sessionRepo)
+		}
+		if kycRepo != nil {
+			privacy.WithKYCRepository(kycRepo)
+		}
+		svc.WithPrivacy(privacy)
+	}
+	if orgRepo != nil && accountRepo != nil {
+		svc.WithOrganizations(userSvc.NewOrganizationService(orgRepo, accountRepo, userRepoImpl, eventBus, lg))
+	}
+	if withdrawalAddressRepo != nil {
+		addresses := userSvc.NewAddressBookService(withdrawalAddressRepo, userRepoImpl, registry, userNotif.NewLogAddressConfirmationNotifier(lg), eventBus, lg)
+		if coolDown, err := time.ParseDuration(os.Getenv("WITHDRAWAL_ADDRESS_COOLDOWN")); err == nil {
+			addresses.WithCoolDown(coolDown)
+		}
+		svc.WithAddressBook(addresses)
+	}
+	return svc
 }
 
 // ProvideTransactionRepository cria o repositório de transações para o DDD Transaction Context
@@ -304,11 +494,901 @@ func ProvideTransactionRepository(conn database.Connection) txnRepo.TransactionR
 	return txnPers.NewPostgresTransactionRepository(conn)
 }
 
+// ProvideAssessmentRepository cria o repositório de avaliações de risco
+func ProvideAssessmentRepository(conn database.Connection) complianceRepo.AssessmentRepository {
+	if conn == nil {
+		return nil
+	}
+	return compliancePers.NewPostgresAssessmentRepository(conn)
+}
+
+// ProvideCaseRepository cria o repositório de casos de fraude/AML
+func ProvideCaseRepository(conn database.Connection) complianceRepo.CaseRepository {
+	if conn == nil {
+		return nil
+	}
+	return compliancePers.NewPostgresCaseRepository(conn)
+}
+
+// ProvideRiskMonitoring cria o monitoramento de fraude/AML. As regras vêm de FRAUD_RULES_FILE
+// (JSON) ou do conjunto padrão; RISK_ALERT_THRESHOLD define o score que abre caso.
+func ProvideRiskMonitoring(
+	txnRepoImpl txnRepo.TransactionRepository,
+	sessionRepo userRepo.SessionRepository,
+	assessmentRepo complianceRepo.AssessmentRepository,
+	caseRepo complianceRepo.CaseRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*complianceSvc.MonitoringService, error) {
+	if txnRepoImpl == nil || assessmentRepo == nil || caseRepo == nil {
+		return nil, nil
+	}
+
+	rules := complianceEntity.DefaultRuleDefinitions()
+	if path := os.Getenv("FRAUD_RULES_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open fraud rules: %w", err)
+		}
+		defer f.Close()
+		if rules, err = complianceEntity.LoadRuleDefinitions(f); err != nil {
+			return nil, fmt.Errorf("load fraud rules: %w", err)
+		}
+	}
+
+	monitor := complianceSvc.NewMonitoringService(
+		complianceDomain.NewRuleEngine(rules),
+		complianceSignals.NewRepositorySignalProvider(txnRepoImpl, sessionRepo),
+		assessmentRepo,
+		caseRepo,
+		eventBus,
+		lg,
+	)
+	if threshold, err := strconv.Atoi(os.Getenv("RISK_ALERT_THRESHOLD")); err == nil {
+		monitor.WithAlertThreshold(threshold)
+	}
+	monitor.Subscribe(eventBus)
+	return monitor, nil
+}
+
+// ProvideScreeningRepository cria o repositório de triagens de sanções
+func ProvideScreeningRepository(conn database.Connection) complianceRepo.ScreeningRepository {
+	if conn == nil {
+		return nil
+	}
+	return compliancePers.NewPostgresScreeningRepository(conn)
+}
+
+// ProvideSanctionsScreener cria a triagem de sanções a partir de SANCTIONS_LIST_FILES
+// ("formato:caminho" separados por vírgula) e recarrega as listas a cada SANCTIONS_RELOAD_INTERVAL.
+// A carga inicial precisa ter sucesso para a aplicação subir.
+func ProvideSanctionsScreener(
+	lc fx.Lifecycle,
+	screeningRepo complianceRepo.ScreeningRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*complianceSvc.SanctionsScreener, error) {
+	spec := os.Getenv("SANCTIONS_LIST_FILES")
+	if spec == "" || screeningRepo == nil {
+		return nil, nil
+	}
+	files, err := complianceSanctions.ParseListFiles(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	screener := complianceSvc.NewSanctionsScreener(complianceSanctions.NewFileListSource(files), screeningRepo, eventBus, lg)
+	if err := screener.Reload(context.Background()); err != nil {
+		return nil, fmt.Errorf("load sanctions lists: %w", err)
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("SANCTIONS_RELOAD_INTERVAL"))
+	runPeriodic(lc, screener.Run, interval)
+	return screener, nil
+}
+
+// ProvideBlockchainUseCases cria a orquestração on-chain (envio e monitoramento de depósitos). Com
+// as listas de sanções configuradas, destinos e origens passam pela triagem.
+func ProvideBlockchainUseCases(
+	conn database.Connection,
+	registry *bcApp.BlockchainRegistry,
+	screener *complianceSvc.SanctionsScreener,
+	eventBus events.Bus,
+) *bcSvc.UseCases {
+	if conn == nil || registry == nil {
+		return nil
+	}
+	useCases := bcSvc.NewUseCases(registry, bcPers.NewPostgresBlockchainTransactionRepository(conn), eventBus)
+	if screener != nil {
+		useCases.WithScreening(screener)
+	}
+	return useCases
+}
+
+// StartDepositMonitor acompanha os depósitos nos endereços de DEPOSIT_MONITOR_ADDRESSES (mesmo formato
+// de RECONCILIATION_ADDRESSES); cada origem é triada antes da publicação de tx.new.
+func StartDepositMonitor(
+	lc fx.Lifecycle,
+	useCases *bcSvc.UseCases,
+	registry *bcApp.BlockchainRegistry,
+	lg *zap.Logger,
+) error {
+	raw := os.Getenv("DEPOSIT_MONITOR_ADDRESSES")
+	if raw == "" || useCases == nil {
+		return nil
+	}
+	addresses, err := platformAddresses(raw, registry)
+	if err != nil {
+		return fmt.Errorf("DEPOSIT_MONITOR_ADDRESSES: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			for chain, list := range addresses {
+				for _, address := range list {
+					go func(chain bcEntity.BlockchainType, address string) {
+						if err := useCases.MonitorDeposits(runCtx, chain, address); err != nil && runCtx.Err() == nil {
+							lg.Error("deposit monitor stopped",
+								zap.String("chain", string(chain)), zap.String("address", address), zap.Error(err))
+						}
+					}(bcEntity.BlockchainType(chain), address)
+				}
+			}
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+	return nil
+}
+
+// ProvideApprovalRepository cria o repositório de aprovações de saque
+func ProvideApprovalRepository(conn database.Connection) txnRepo.ApprovalRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresApprovalRepository(conn)
+}
+
+// ProvideApprovalService cria a aprovação maker-checker com as políticas de APPROVAL_POLICIES_FILE (JSON)
+// e expira solicitações vencidas a cada APPROVAL_EXPIRY_INTERVAL. Sem arquivo os saques seguem direto.
+func ProvideApprovalService(
+	lc fx.Lifecycle,
+	approvalRepo txnRepo.ApprovalRepository,
+	txnRepoImpl txnRepo.TransactionRepository,
+	walletRepoImpl userRepo.WalletRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.ApprovalService, error) {
+	path := os.Getenv("APPROVAL_POLICIES_FILE")
+	if path == "" || approvalRepo == nil || txnRepoImpl == nil || walletRepoImpl == nil {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open approval policies: %w", err)
+	}
+	defer f.Close()
+	policies, err := txnEntity.LoadApprovalPolicies(f)
+	if err != nil {
+		return nil, fmt.Errorf("load approval policies: %w", err)
+	}
+
+	approvals := txnSvc.NewApprovalService(approvalRepo, txnRepoImpl, walletRepoImpl, policies, eventBus, lg)
+	interval, _ := time.ParseDuration(os.Getenv("APPROVAL_EXPIRY_INTERVAL"))
+	runPeriodic(lc, approvals.Run, interval)
+	return approvals, nil
+}
+
+// ProvideFeeService carrega a tabela de taxas de FEE_SCHEDULE_FILE (desabilitada se ausente).
+// FEE_REVENUE_USER_ID identifica a carteira de receita e NETWORK_FEE_RATES ("ETH=15000,BTC=350000")
+// converte a taxa de rede repassada para BRL. Taxas debitadas e não creditadas na receita são
+// recobradas a cada FEE_RETRY_INTERVAL.
+func ProvideFeeService(
+	lc fx.Lifecycle,
+	txnRepoImpl txnRepo.TransactionRepository,
+	userRepoImpl userRepo.UserRepository,
+	walletRepoImpl userRepo.WalletRepository,
+	registry *bcApp.BlockchainRegistry,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.FeeService, error) {
+	path := os.Getenv("FEE_SCHEDULE_FILE")
+	if path == "" || txnRepoImpl == nil || userRepoImpl == nil || walletRepoImpl == nil {
+		return nil, nil
+	}
+	revenueUserID, err := uuid.Parse(os.Getenv("FEE_REVENUE_USER_ID"))
+	if err != nil {
+		return nil, fmt.Errorf("FEE_REVENUE_USER_ID: %w", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open fee schedule: %w", err)
+	}
+	defer f.Close()
+	schedule, err := txnEntity.LoadFeeSchedule(f)
+	if err != nil {
+		return nil, fmt.Errorf("load fee schedule: %w", err)
+	}
+
+	rates := make(map[string]decimal.Decimal)
+	for _, pair := range strings.Split(os.Getenv("NETWORK_FEE_RATES"), ",") {
+		asset, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		rate, err := decimal.NewFromString(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("NETWORK_FEE_RATES %s: %w", asset, err)
+		}
+		rates[strings.TrimSpace(asset)] = rate
+	}
+
+	fees := txnSvc.NewFeeService(schedule, txnRepoImpl, userRepoImpl, walletRepoImpl, revenueUserID, eventBus, lg)
+	if registry != nil {
+		fees.WithNetworkFees(registry, rates)
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("FEE_RETRY_INTERVAL"))
+	runPeriodic(lc, fees.Run, interval)
+	return fees, nil
+}
+
+// ProvideBalanceRepository cria o repositório de saldos por moeda
+func ProvideBalanceRepository(conn database.Connection) userRepo.BalanceRepository {
+	if conn == nil {
+		return nil
+	}
+	return userPers.NewPostgresBalanceRepository(conn)
+}
+
+// ProvideQuoteRepository cria o repositório de cotações e conversões de câmbio
+func ProvideQuoteRepository(conn database.Connection) fxRepo.QuoteRepository {
+	if conn == nil {
+		return nil
+	}
+	return fxPers.NewPostgresQuoteRepository(conn)
+}
+
+// ProvideRateProvider escolhe a fonte de câmbio: FX_RATES_URL (API HTTP, cache FX_RATES_CACHE_TTL)
+// ou FX_RATES_FILE (tabela JSON de pares). Sem nenhuma das duas o câmbio fica desabilitado.
+func ProvideRateProvider() (fxDomain.RateProvider, error) {
+	if endpoint := os.Getenv("FX_RATES_URL"); endpoint != "" {
+		ttl, _ := time.ParseDuration(os.Getenv("FX_RATES_CACHE_TTL"))
+		return fxRates.NewHTTPProvider(endpoint, ttl), nil
+	}
+	if path := os.Getenv("FX_RATES_FILE"); path != "" {
+		provider, err := fxRates.LoadStaticProviderFile(path, string(sharedVO.BaseCurrency))
+		if err != nil {
+			return nil, fmt.Errorf("load fx rates: %w", err)
+		}
+		return provider, nil
+	}
+	return nil, nil
+}
+
+// ProvideConversionService cria o serviço de câmbio com spread FX_SPREAD_BPS e validade FX_QUOTE_TTL
+func ProvideConversionService(
+	quoteRepo fxRepo.QuoteRepository,
+	balanceRepo userRepo.BalanceRepository,
+	rates fxDomain.RateProvider,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) *fxApp.ConversionService {
+	if quoteRepo == nil || balanceRepo == nil || rates == nil {
+		return nil
+	}
+	svc := fxApp.NewConversionService(quoteRepo, balanceRepo, rates, eventBus, lg)
+	if bps, err := strconv.Atoi(os.Getenv("FX_SPREAD_BPS")); err == nil {
+		svc.WithSpread(bps)
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("FX_QUOTE_TTL")); err == nil {
+		svc.WithQuoteTTL(ttl)
+	}
+	return svc
+}
+
+// ProvideScheduleRepository cria o repositório de transferências agendadas
+func ProvideScheduleRepository(conn database.Connection) txnRepo.ScheduleRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresScheduleRepository(conn)
+}
+
+// ProvideTransactionWorker cria o worker asynq compartilhado pelas tarefas do contexto de transações
+// (agendamentos, prazos de custódia e lotes de pagamentos). Sem REDIS_URL retorna nil e as tarefas rodam nas varreduras.
+func ProvideTransactionWorker(lc fx.Lifecycle, cfg Config, lg *zap.Logger) (*txnSched.Worker, error) {
+	if cfg.RedisURL == "" {
+		return nil, nil
+	}
+	worker, err := txnSched.NewWorker(cfg.RedisURL, 5)
+	if err != nil {
+		return nil, fmt.Errorf("REDIS_URL: %w", err)
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if err := worker.Start(); err != nil {
+				// Redis é opcional: sem worker as tarefas rodam nas próprias varreduras
+				lg.Warn("transaction worker unavailable, executing inline", zap.Error(err))
+			}
+			return nil
+		},
+		OnStop: worker.Shutdown,
+	})
+	return worker, nil
+}
+
+// ProvideScheduleService cria os agendamentos de transferências e saques. A varredura roda a cada
+// SCHEDULE_POLL_INTERVAL; com o worker as execuções vencidas vão para a fila asynq, senão rodam na
+// própria varredura. SCHEDULE_MAX_RETRIES e SCHEDULE_RETRY_BACKOFF controlam as novas tentativas
+// quando falta saldo.
+func ProvideScheduleService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	scheduleRepo txnRepo.ScheduleRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.ScheduleService, error) {
+	if scheduleRepo == nil {
+		return nil, nil
+	}
+	schedules := txnSvc.NewScheduleService(scheduleRepo, txnNotif.NewLogScheduleNotifier(lg), eventBus, lg)
+	maxRetries := -1
+	if v := os.Getenv("SCHEDULE_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("SCHEDULE_MAX_RETRIES: %w", err)
+		}
+		maxRetries = n
+	}
+	backoff, _ := time.ParseDuration(os.Getenv("SCHEDULE_RETRY_BACKOFF"))
+	schedules.WithRetryPolicy(maxRetries, backoff)
+	if worker != nil {
+		schedules.WithDispatcher(txnSched.NewAsynqDispatcher(worker.Client()))
+		worker.Handle(txnSched.TypeScheduledTransfer, txnSched.NewHandler(schedules))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("SCHEDULE_POLL_INTERVAL"))
+	dispatchInlineWithoutWorker(lc, worker, func() { schedules.WithDispatcher(nil) })
+	runPeriodic(lc, schedules.Run, interval)
+	return schedules, nil
+}
+
+// ProvideEscrowRepository cria o repositório de custódias
+func ProvideEscrowRepository(conn database.Connection) txnRepo.EscrowRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresEscrowRepository(conn)
+}
+
+// ProvideEscrowService cria as custódias entre comprador e vendedor. Com o worker o vencimento de cada
+// custódia é agendado no asynq para o prazo; a varredura a cada ESCROW_SWEEP_INTERVAL cobre o restante.
+func ProvideEscrowService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	escrowRepo txnRepo.EscrowRepository,
+	txnRepoImpl txnRepo.TransactionRepository,
+	walletRepoImpl userRepo.WalletRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) *txnSvc.EscrowService {
+	if escrowRepo == nil || txnRepoImpl == nil || walletRepoImpl == nil {
+		return nil
+	}
+	escrows := txnSvc.NewEscrowService(escrowRepo, txnRepoImpl, walletRepoImpl, eventBus, lg)
+	if worker != nil {
+		escrows.WithTimeoutScheduler(txnSched.NewAsynqEscrowScheduler(worker.Client()))
+		worker.Handle(txnSched.TypeEscrowTimeout, txnSched.NewEscrowTimeoutHandler(escrows))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("ESCROW_SWEEP_INTERVAL"))
+	runPeriodic(lc, escrows.Run, interval)
+	return escrows
+}
+
+// ProvidePayoutRepository cria o repositório de lotes de pagamentos
+func ProvidePayoutRepository(conn database.Connection) txnRepo.PayoutRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresPayoutRepository(conn)
+}
+
+// ProvidePayoutService cria os pagamentos em lote por arquivo (PAYOUT_MAX_ITEMS linhas por arquivo).
+// Com o worker cada item confirmado vai para a fila asynq; a varredura a cada PAYOUT_SWEEP_INTERVAL
+// reenfileira itens pendentes.
+func ProvidePayoutService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	payoutRepo txnRepo.PayoutRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.PayoutService, error) {
+	if payoutRepo == nil {
+		return nil, nil
+	}
+	payouts := txnSvc.NewPayoutService(payoutRepo, eventBus, lg)
+	if v := os.Getenv("PAYOUT_MAX_ITEMS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("PAYOUT_MAX_ITEMS: %w", err)
+		}
+		payouts.WithMaxItems(n)
+	}
+	if worker != nil {
+		payouts.WithDispatcher(txnSched.NewAsynqPayoutDispatcher(worker.Client()))
+		worker.Handle(txnSched.TypePayoutItem, txnSched.NewPayoutHandler(payouts))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("PAYOUT_SWEEP_INTERVAL"))
+	dispatchInlineWithoutWorker(lc, worker, func() { payouts.WithDispatcher(nil) })
+	runPeriodic(lc, payouts.Run, interval)
+	return payouts, nil
+}
+
+// ProvidePixRepository cria o repositório de cobranças, créditos e pagamentos Pix
+func ProvidePixRepository(conn database.Connection) txnRepo.PixRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresPixRepository(conn)
+}
+
+// ProvidePixService habilita o Pix quando PIX_KEY está definida (recebedor PIX_MERCHANT_NAME em
+// PIX_MERCHANT_CITY). O PSP é o simulador local (PIX_PROVIDER=simulator), com webhooks assinados
+// por PIX_WEBHOOK_SECRET (obrigatório); a conciliação roda a cada PIX_RECONCILE_INTERVAL.
+func ProvidePixService(
+	lc fx.Lifecycle,
+	pixRepo txnRepo.PixRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.PixService, error) {
+	key := os.Getenv("PIX_KEY")
+	if pixRepo == nil || key == "" {
+		return nil, nil
+	}
+	// O webhook credita wallets: sem segredo qualquer JSON não assinado seria aceito
+	secret := os.Getenv("PIX_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("PIX_WEBHOOK_SECRET: required when PIX_KEY is set")
+	}
+	var provider txnSvc.PixProvider
+	switch name := os.Getenv("PIX_PROVIDER"); name {
+	case "", "simulator":
+		provider = txnPix.NewSimulator(key, os.Getenv("PIX_LOCATION_URL"), secret)
+	default:
+		return nil, fmt.Errorf("PIX_PROVIDER: unknown provider %q", name)
+	}
+	ttl, _ := time.ParseDuration(os.Getenv("PIX_CHARGE_TTL"))
+	pix := txnSvc.NewPixService(pixRepo, provider, txnSvc.PixConfig{
+		Key:          key,
+		MerchantName: os.Getenv("PIX_MERCHANT_NAME"),
+		MerchantCity: os.Getenv("PIX_MERCHANT_CITY"),
+		ChargeTTL:    ttl,
+	}, eventBus, lg)
+
+	interval, _ := time.ParseDuration(os.Getenv("PIX_RECONCILE_INTERVAL"))
+	runPeriodic(lc, pix.Run, interval)
+	return pix, nil
+}
+
+// ProvideStatementRepository cria o repositório de extratos
+func ProvideStatementRepository(conn database.Connection) txnRepo.StatementRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresStatementRepository(conn)
+}
+
+// ProvideStatementService cria os extratos sob demanda e mensais. Com o worker os extratos do mês
+// anterior vão para a fila asynq; a varredura a cada STATEMENT_SWEEP_INTERVAL enfileira os pendentes.
+// STATEMENT_TIMEZONE define o fuso dos limites dos meses (padrão UTC).
+func ProvideStatementService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	statementRepo txnRepo.StatementRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.StatementService, error) {
+	if statementRepo == nil {
+		return nil, nil
+	}
+	statements := txnSvc.NewStatementService(statementRepo, eventBus, lg)
+	if tz := os.Getenv("STATEMENT_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("STATEMENT_TIMEZONE: %w", err)
+		}
+		statements.WithLocation(location)
+	}
+	if worker != nil {
+		statements.WithDispatcher(txnSched.NewAsynqStatementDispatcher(worker.Client()))
+		worker.Handle(txnSched.TypeMonthlyStatement, txnSched.NewStatementHandler(statements))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("STATEMENT_SWEEP_INTERVAL"))
+	dispatchInlineWithoutWorker(lc, worker, func() { statements.WithDispatcher(nil) })
+	runPeriodic(lc, statements.Run, interval)
+	return statements, nil
+}
+
+// ProvideTransactionQueryRepository cria o repositório de leitura usado na busca de transações
+func ProvideTransactionQueryRepository(conn database.Connection) cqrs.TransactionQueryRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresTransactionQueryRepository(conn)
+}
+
+// ProvideTransactionSearchService cria a busca de transações com filtros e paginação por cursor
+func ProvideTransactionSearchService(queryRepo cqrs.TransactionQueryRepository, lg *zap.Logger) *txnSvc.TransactionSearchService {
+	if queryRepo == nil {
+		return nil
+	}
+	return txnSvc.NewTransactionSearchService(queryRepo, lg)
+}
+
+// ProvideReconciliationRepository cria o repositório da conciliação com a rede
+func ProvideReconciliationRepository(conn database.Connection) txnRepo.ReconciliationRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresReconciliationRepository(conn)
+}
+
+// ProvideReconciliationService cria a conciliação diária do passivo interno com a rede. Habilitada por
+// RECONCILIATION_ADDRESSES com os endereços da plataforma por chain (ex.: "ethereum=0xhot;0xcold,
+// bitcoin=bc1q..."); RECONCILIATION_TOLERANCE aceita diferenças por ativo (ex.: "ETH=0.001").
+// Com o worker a conciliação do dia anterior vai para a fila asynq.
+func ProvideReconciliationService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	reconciliationRepo txnRepo.ReconciliationRepository,
+	registry *bcApp.BlockchainRegistry,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.ReconciliationService, error) {
+	raw := os.Getenv("RECONCILIATION_ADDRESSES")
+	if raw == "" || reconciliationRepo == nil || registry == nil {
+		return nil, nil
+	}
+	addresses, err := platformAddresses(raw, registry)
+	if err != nil {
+		return nil, fmt.Errorf("RECONCILIATION_ADDRESSES: %w", err)
+	}
+
+	reconciliation := txnSvc.NewReconciliationService(reconciliationRepo, registry, addresses, eventBus, lg)
+	for _, pair := range strings.Split(os.Getenv("RECONCILIATION_TOLERANCE"), ",") {
+		asset, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		tolerance, err := decimal.NewFromString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("RECONCILIATION_TOLERANCE %s: %w", asset, err)
+		}
+		reconciliation.WithTolerance(strings.ToUpper(strings.TrimSpace(asset)), tolerance)
+	}
+	if tz := os.Getenv("RECONCILIATION_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("RECONCILIATION_TIMEZONE: %w", err)
+		}
+		reconciliation.WithLocation(location)
+	}
+	if worker != nil {
+		reconciliation.WithDispatcher(txnSched.NewAsynqReconciliationDispatcher(worker.Client()))
+		worker.Handle(txnSched.TypeDailyReconciliation, txnSched.NewReconciliationHandler(reconciliation))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("RECONCILIATION_SWEEP_INTERVAL"))
+	dispatchInlineWithoutWorker(lc, worker, func() { reconciliation.WithDispatcher(nil) })
+	runPeriodic(lc, reconciliation.Run, interval)
+	return reconciliation, nil
+}
+
+// platformAddresses interpreta os endereços da plataforma por chain no formato
+// "ethereum=0xhot;0xcold,bitcoin=bc1q..."
+func platformAddresses(raw string, registry *bcApp.BlockchainRegistry) (map[string][]string, error) {
+	addresses := make(map[string][]string)
+	for _, pair := range strings.Split(raw, ",") {
+		chain, list, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		chain = strings.ToLower(strings.TrimSpace(chain))
+		if _, err := registry.NativeAsset(chain); err != nil {
+			return nil, err
+		}
+		for _, address := range strings.Split(list, ";") {
+			if address = strings.TrimSpace(address); address != "" {
+				addresses[chain] = append(addresses[chain], address)
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// ProvideReservesRepository cria o repositório da prova de reservas
+func ProvideReservesRepository(conn database.Connection) txnRepo.ReservesRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresReservesRepository(conn)
+}
+
+// ProvideReservesService cria a prova de reservas. As reservas on-chain são os saldos dos endereços
+// de RECONCILIATION_ADDRESSES; sem eles só o passivo e as raízes são publicados.
+func ProvideReservesService(
+	reservesRepo txnRepo.ReservesRepository,
+	registry *bcApp.BlockchainRegistry,
+	lg *zap.Logger,
+) (*txnSvc.ReservesService, error) {
+	if reservesRepo == nil {
+		return nil, nil
+	}
+	addresses := map[string][]string{}
+	var balances txnSvc.OnChainBalanceSource
+	if registry != nil {
+		var err error
+		if addresses, err = platformAddresses(os.Getenv("RECONCILIATION_ADDRESSES"), registry); err != nil {
+			return nil, fmt.Errorf("RECONCILIATION_ADDRESSES: %w", err)
+		}
+		balances = registry
+	}
+	return txnSvc.NewReservesService(reservesRepo, balances, addresses, lg), nil
+}
+
+// ProvideLotRepository cria o repositório de lotes de aquisição e alienações
+func ProvideLotRepository(conn database.Connection) taxRepo.LotRepository {
+	if conn == nil {
+		return nil
+	}
+	return taxPers.NewPostgresLotRepository(conn)
+}
+
+// ProvideTaxLotService cria a apuração de custo e ganhos realizados sobre as conversões de câmbio e
+// os depósitos on-chain detectados nas wallets dos usuários.
+// TAX_TIMEZONE define o fuso dos anos e meses do relatório (padrão UTC).
+func ProvideTaxLotService(
+	lotRepo taxRepo.LotRepository,
+	rates fxDomain.RateProvider,
+	walletRepoImpl userRepo.WalletRepository,
+	registry *bcApp.BlockchainRegistry,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*taxSvc.TaxLotService, error) {
+	if lotRepo == nil || rates == nil {
+		return nil, nil
+	}
+	taxLots := taxSvc.NewTaxLotService(lotRepo, rates, lg)
+	if tz := os.Getenv("TAX_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("TAX_TIMEZONE: %w", err)
+		}
+		taxLots.WithLocation(location)
+	}
+	if walletRepoImpl != nil && registry != nil {
+		taxLots.WithDeposits(walletRepoImpl, registry)
+	}
+	taxLots.Subscribe(eventBus)
+	return taxLots, nil
+}
+
+// ProvidePriceStore cria o cache de preços sobre as fontes, nesta ordem: PRICE_FEED_URL (API HTTP),
+// COINGECKO_API_URL (chave COINGECKO_API_KEY), PRICE_FEED_FILE (tabela JSON de pares) e as cotações
+// do câmbio. PRICE_CACHE_TTL e PRICE_MAX_AGE definem reuso e idade máxima dos preços.
+func ProvidePriceStore(rates fxDomain.RateProvider, lg *zap.Logger) (*fxApp.PriceStore, error) {
+	var feeds []fxDomain.PriceFeed
+	if endpoint := os.Getenv("PRICE_FEED_URL"); endpoint != "" {
+		feeds = append(feeds, fxPrices.NewHTTPFeed(endpoint))
+	}
+	if endpoint := os.Getenv("COINGECKO_API_URL"); endpoint != "" {
+		feeds = append(feeds, fxPrices.NewCoinGeckoFeed(endpoint, os.Getenv("COINGECKO_API_KEY")))
+	}
+	if path := os.Getenv("PRICE_FEED_FILE"); path != "" {
+		feed, err := fxPrices.LoadStaticFeedFile(path, string(sharedVO.BaseCurrency))
+		if err != nil {
+			return nil, fmt.Errorf("load price feed: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+	if rates != nil {
+		feeds = append(feeds, fxPrices.NewRateFeed(rates))
+	}
+	if len(feeds) == 0 {
+		return nil, nil
+	}
+	store := fxApp.NewPriceStore(lg, feeds...)
+	if ttl, err := time.ParseDuration(os.Getenv("PRICE_CACHE_TTL")); err == nil {
+		store.WithTTL(ttl)
+	}
+	if maxAge, err := time.ParseDuration(os.Getenv("PRICE_MAX_AGE")); err == nil {
+		store.WithMaxAge(maxAge)
+	}
+	return store, nil
+}
+
+// ProvidePortfolioRepository cria o repositório de portfólio
+func ProvidePortfolioRepository(conn database.Connection) portfolioRepo.PortfolioRepository {
+	if conn == nil {
+		return nil
+	}
+	return portfolioPers.NewPostgresPortfolioRepository(conn)
+}
+
+// ProvidePortfolioService cria a avaliação de portfólio e a varredura dos retratos diários
+// (PORTFOLIO_SNAPSHOT_INTERVAL, dia no fuso PORTFOLIO_TIMEZONE)
+func ProvidePortfolioService(
+	lc fx.Lifecycle,
+	repo portfolioRepo.PortfolioRepository,
+	balanceRepo userRepo.BalanceRepository,
+	registry *bcApp.BlockchainRegistry,
+	prices *fxApp.PriceStore,
+	lg *zap.Logger,
+) (*portfolioSvc.PortfolioService, error) {
+	if repo == nil || balanceRepo == nil || prices == nil {
+		return nil, nil
+	}
+	var onchain portfolioSvc.OnChainBalanceSource
+	if registry != nil {
+		onchain = registry
+	}
+	portfolio := portfolioSvc.NewPortfolioService(repo, balanceRepo, onchain, prices, lg)
+	if tz := os.Getenv("PORTFOLIO_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("PORTFOLIO_TIMEZONE: %w", err)
+		}
+		portfolio.WithLocation(location)
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("PORTFOLIO_SNAPSHOT_INTERVAL"))
+	runPeriodic(lc, portfolio.Run, interval)
+	return portfolio, nil
+}
+
+// ProvideInterestRepository cria o repositório de contas remuneradas
+func ProvideInterestRepository(conn database.Connection) txnRepo.InterestRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresInterestRepository(conn)
+}
+
+// ProvideInterestService cria as contas remuneradas. A apropriação do dia anterior é disparada a cada
+// INTEREST_SWEEP_INTERVAL, nos limites de dia do fuso INTEREST_TIMEZONE; com o worker ela vai para a
+// fila asynq.
+func ProvideInterestService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	interestRepo txnRepo.InterestRepository,
+	txnRepoImpl txnRepo.TransactionRepository,
+	walletRepoImpl userRepo.WalletRepository,
+	statementRepo txnRepo.StatementRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.InterestService, error) {
+	if interestRepo == nil || txnRepoImpl == nil || walletRepoImpl == nil {
+		return nil, nil
+	}
+	interest := txnSvc.NewInterestService(interestRepo, txnRepoImpl, walletRepoImpl, eventBus, lg)
+	if statementRepo != nil {
+		interest.WithLedger(statementRepo)
+	}
+	if tz := os.Getenv("INTEREST_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("INTEREST_TIMEZONE: %w", err)
+		}
+		interest.WithLocation(location)
+	}
+	if worker != nil {
+		interest.WithDispatcher(txnSched.NewAsynqInterestDispatcher(worker.Client()))
+		worker.Handle(txnSched.TypeDailyInterestAccrual, txnSched.NewInterestHandler(interest))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("INTEREST_SWEEP_INTERVAL"))
+	dispatchInlineWithoutWorker(lc, worker, func() { interest.WithDispatcher(nil) })
+	runPeriodic(lc, interest.Run, interval)
+	return interest, nil
+}
+
+// ProvideCreditRepository cria o repositório de linhas de crédito
+func ProvideCreditRepository(conn database.Connection) txnRepo.CreditRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresCreditRepository(conn)
+}
+
+// ProvideCreditService cria as linhas de crédito das carteiras. A apropriação de juros do dia anterior
+// é disparada a cada CREDIT_SWEEP_INTERVAL, nos limites de dia e de ciclo do fuso CREDIT_TIMEZONE; com
+// o worker ela vai para a fila asynq.
+func ProvideCreditService(
+	lc fx.Lifecycle,
+	worker *txnSched.Worker,
+	creditRepo txnRepo.CreditRepository,
+	txnRepoImpl txnRepo.TransactionRepository,
+	walletRepoImpl userRepo.WalletRepository,
+	statementRepo txnRepo.StatementRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) (*txnSvc.CreditService, error) {
+	if creditRepo == nil || txnRepoImpl == nil || walletRepoImpl == nil {
+		return nil, nil
+	}
+	credit := txnSvc.NewCreditService(creditRepo, txnRepoImpl, walletRepoImpl, eventBus, lg)
+	if statementRepo != nil {
+		credit.WithLedger(statementRepo)
+	}
+	if tz := os.Getenv("CREDIT_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("CREDIT_TIMEZONE: %w", err)
+		}
+		credit.WithLocation(location)
+	}
+	if worker != nil {
+		credit.WithDispatcher(txnSched.NewAsynqCreditDispatcher(worker.Client()))
+		worker.Handle(txnSched.TypeDailyCreditAccrual, txnSched.NewCreditHandler(credit))
+	}
+
+	interval, _ := time.ParseDuration(os.Getenv("CREDIT_SWEEP_INTERVAL"))
+	dispatchInlineWithoutWorker(lc, worker, func() { credit.WithDispatcher(nil) })
+	runPeriodic(lc, credit.Run, interval)
+	return credit, nil
+}
+
+// ProvideReversalRepository cria o repositório de estornos
+func ProvideReversalRepository(conn database.Connection) txnRepo.ReversalRepository {
+	if conn == nil {
+		return nil
+	}
+	return txnPers.NewPostgresReversalRepository(conn)
+}
+
+// ProvideReversalService cria o estorno de transações concluídas pelos operadores
+func ProvideReversalService(
+	reversalRepo txnRepo.ReversalRepository,
+	txnRepoImpl txnRepo.TransactionRepository,
+	walletRepoImpl userRepo.WalletRepository,
+	eventBus events.Bus,
+	lg *zap.Logger,
+) *txnSvc.ReversalService {
+	if reversalRepo == nil || txnRepoImpl == nil || walletRepoImpl == nil {
+		return nil
+	}
+	return txnSvc.NewReversalService(reversalRepo, txnRepoImpl, walletRepoImpl, eventBus, lg)
+}
+
 // ProvideDDDTransactionService cria o TransactionService do DDD Transaction Context
 func ProvideDDDTransactionService(
 	txnRepoImpl txnRepo.TransactionRepository,
 	userRepoImpl userRepo.UserRepository,
 	walletRepoImpl userRepo.WalletRepository,
+	monitor *complianceSvc.MonitoringService,
+	screener *complianceSvc.SanctionsScreener,
+	userService *userSvc.UserService,
+	approvals *txnSvc.ApprovalService,
+	fees *txnSvc.FeeService,
+	schedules *txnSvc.ScheduleService,
+	reversals *txnSvc.ReversalService,
+	escrows *txnSvc.EscrowService,
+	payouts *txnSvc.PayoutService,
+	pix *txnSvc.PixService,
+	statements *txnSvc.StatementService,
+	search *txnSvc.TransactionSearchService,
+	reconciliation *txnSvc.ReconciliationService,
+	reserves *txnSvc.ReservesService,
+	interest *txnSvc.InterestService,
+	credit *txnSvc.CreditService,
 	eventBus events.Bus,
 	breakerManager *breaker.BreakerManager,
 	lg *zap.Logger,
@@ -316,14 +1396,66 @@ func ProvideDDDTransactionService(
 	if txnRepoImpl == nil || userRepoImpl == nil || walletRepoImpl == nil {
 		return nil
 	}
-	return txnSvc.NewTransactionService(
+	svc := txnSvc.NewTransactionService(
 		txnRepoImpl,
 		userRepoImpl,
 		walletRepoImpl,
 		eventBus,
 		breakerManager,
 		lg,
-	)
+	).WithLimits(txnSvc.DefaultLimitPolicy())
+	if monitor != nil {
+		svc.WithMonitoring(monitor)
+	}
+	if screener != nil {
+		svc.WithSanctions(screener)
+	}
+	if userService != nil && userService.AddressBook() != nil {
+		svc.WithDestinationPolicy(userService.AddressBook())
+	}
+	if userService != nil && userService.Organizations() != nil {
+		userService.Organizations().WithLedger(svc)
+	}
+	if approvals != nil {
+		svc.WithApprovals(approvals)
+	}
+	if fees != nil {
+		svc.WithFees(fees)
+	}
+	if schedules != nil {
+		svc.WithSchedules(schedules)
+	}
+	if reversals != nil {
+		svc.WithReversals(reversals)
+	}
+	if escrows != nil {
+		svc.WithEscrows(escrows)
+	}
+	if payouts != nil {
+		svc.WithPayouts(payouts)
+	}
+	if pix != nil {
+		svc.WithPix(pix)
+	}
+	if statements != nil {
+		svc.WithStatements(statements)
+	}
+	if search != nil {
+		svc.WithSearch(search)
+	}
+	if reconciliation != nil {
+		svc.WithReconciliation(reconciliation)
+	}
+	if reserves != nil {
+		svc.WithReserves(reserves)
+	}
+	if interest != nil {
+		svc.WithInterest(interest)
+	}
+	if credit != nil {
+		svc.WithCredit(credit)
+	}
+	return svc
 }
 
 // ProvideBlockchainTransactionRepository removed - no longer needed in DDD refactor
@@ -338,6 +1470,7 @@ func New() *fx.App {
 		fx.Provide(ProvideBreakerManager),
 		fx.Provide(ProvideValidator),
 		fx.Provide(ProvideRegisterRoutes),
+		fx.Provide(ProvideRouteServices),
 		fx.Provide(ProvideApp),
 		fx.Provide(ProvideDatabaseConnection),
 		fx.Provide(ProvideSharedDatabaseConnection),
@@ -353,8 +1486,57 @@ func New() *fx.App {
 		fx.Provide(ProvideUserRepository),
 		fx.Provide(ProvideWalletRepository),
 		fx.Provide(ProvideTransactionRepository),
+		fx.Provide(ProvideLoginAttemptRepository),
+		fx.Provide(ProvideSessionRepository),
+		fx.Provide(ProvideKYCRepository),
+		fx.Provide(ProvidePersonalDataRepository),
+		fx.Provide(ProvideOrganizationRepository),
+		fx.Provide(ProvideAccountRepository),
+		fx.Provide(ProvideWithdrawalAddressRepository),
+		fx.Provide(ProvideAssessmentRepository),
+		fx.Provide(ProvideCaseRepository),
+		fx.Provide(ProvideRiskMonitoring),
+		fx.Provide(ProvideScreeningRepository),
+		fx.Provide(ProvideSanctionsScreener),
+		fx.Provide(ProvideBlockchainUseCases),
 		fx.Provide(ProvideDDDUserService),
+		fx.Provide(ProvideApprovalRepository),
+		fx.Provide(ProvideApprovalService),
+		fx.Provide(ProvideFeeService),
+		fx.Provide(ProvideBalanceRepository),
+		fx.Provide(ProvideQuoteRepository),
+		fx.Provide(ProvideRateProvider),
+		fx.Provide(ProvideConversionService),
+		fx.Provide(ProvideTransactionWorker),
+		fx.Provide(ProvideScheduleRepository),
+		fx.Provide(ProvideScheduleService),
+		fx.Provide(ProvideReversalRepository),
+		fx.Provide(ProvideReversalService),
+		fx.Provide(ProvideEscrowRepository),
+		fx.Provide(ProvideEscrowService),
+		fx.Provide(ProvidePayoutRepository),
+		fx.Provide(ProvidePayoutService),
+		fx.Provide(ProvidePixRepository),
+		fx.Provide(ProvidePixService),
+		fx.Provide(ProvideStatementRepository),
+		fx.Provide(ProvideStatementService),
+		fx.Provide(ProvideTransactionQueryRepository),
+		fx.Provide(ProvideTransactionSearchService),
+		fx.Provide(ProvideReconciliationRepository),
+		fx.Provide(ProvideReconciliationService),
+		fx.Provide(ProvideReservesRepository),
+		fx.Provide(ProvideReservesService),
+		fx.Provide(ProvideLotRepository),
+		fx.Provide(ProvideTaxLotService),
+		fx.Provide(ProvidePriceStore),
+		fx.Provide(ProvidePortfolioRepository),
+		fx.Provide(ProvidePortfolioService),
+		fx.Provide(ProvideInterestRepository),
+		fx.Provide(ProvideInterestService),
+		fx.Provide(ProvideCreditRepository),
+		fx.Provide(ProvideCreditService),
 		fx.Provide(ProvideDDDTransactionService),
+		fx.Invoke(StartDepositMonitor),
 		fx.Invoke(StartServer),
 	)
 }
@@ -23,6 +23,8 @@ func (m TransactionMapper) ToModel(tx *entity.Transaction) *models.TransactionMo
 		Hash:        tx.TransactionHash,
 		FromAddress: tx.FromAddress,
 		ToAddress:   tx.ToAddress,
+		Fee:         tx.Fee,
+		ParentID:    tx.ParentID,
 		CreatedAt:   tx.CreatedAt,
 		UpdatedAt:   tx.UpdatedAt,
 	}
@@ -49,6 +51,8 @@ func (m TransactionMapper) ToDomain(model *models.TransactionModel) *entity.Tran
 		TransactionHash: model.Hash,
 		FromAddress:     model.FromAddress,
 		ToAddress:       model.ToAddress,
+		Fee:             model.Fee,
+		ParentID:        model.ParentID,
 		CreatedAt:       model.CreatedAt,
 		UpdatedAt:       model.UpdatedAt,
 		CompletedAt:     model.CompletedAt,
@@ -19,6 +19,7 @@ func (m UserMapper) ToModel(user *entity.User) *models.UserModel {
 		ID:        user.ID,
 		Email:     user.Email.String(),
 		Password:  user.Password.String(),
+		KYCLevel:  int(user.KYCLevel),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -42,6 +43,7 @@ func (m UserMapper) ToDomain(model *models.UserModel) (*entity.User, error) {
 		ID:        model.ID,
 		Email:     email,
 		Password:  password,
+		KYCLevel:  entity.KYCLevel(model.KYCLevel),
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
 	}, nil
@@ -21,6 +21,7 @@ type TransactionModel struct {
 	BlockchainType string          `gorm:"type:text"`
 	Confirmations  int             `gorm:"type:int;default:0"`
 	Fee            decimal.Decimal `gorm:"type:numeric(15,2);default:0"`
+	ParentID       *uuid.UUID      `gorm:"type:uuid;index"`
 	Metadata       string          `gorm:"type:jsonb"`
 	CreatedAt      time.Time       `gorm:"autoCreateTime"`
 	UpdatedAt      time.Time       `gorm:"autoUpdateTime"`
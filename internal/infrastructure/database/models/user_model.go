@@ -12,6 +12,7 @@ type UserModel struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	Email     string    `gorm:"type:text;unique;not null"`
 	Password  string    `gorm:"type:text;not null"`
+	KYCLevel  int       `gorm:"column:kyc_level;not null;default:0"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
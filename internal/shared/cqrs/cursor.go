@@ -0,0 +1,59 @@
+package cqrs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidCursor the cursor is malformed or was issued for a different sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Sort keys and orders supported by transaction searches
+const (
+	TransactionSortCreatedAt = "created_at"
+	TransactionSortAmount    = "amount"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// TransactionCursor position after the last item of a page: the sort key value and the id as tiebreaker
+type TransactionCursor struct {
+	SortBy    string          `json:"s"`
+	SortOrder string          `json:"o"`
+	CreatedAt time.Time       `json:"c,omitempty"`
+	Amount    decimal.Decimal `json:"a,omitempty"`
+	ID        uuid.UUID       `json:"i"`
+}
+
+// CursorAfter builds the cursor pointing right after the given transaction
+func CursorAfter(tx *TransactionReadModel, sortBy, sortOrder string) TransactionCursor {
+	return TransactionCursor{SortBy: sortBy, SortOrder: sortOrder, CreatedAt: tx.CreatedAt, Amount: tx.Amount, ID: tx.ID}
+}
+
+// Encode returns the opaque URL-safe representation of the cursor
+func (c TransactionCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeTransactionCursor parses a cursor and checks that it belongs to the query's sort
+func DecodeTransactionCursor(s, sortBy, sortOrder string) (TransactionCursor, error) {
+	var c TransactionCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == uuid.Nil {
+		return c, ErrInvalidCursor
+	}
+	if c.SortBy != sortBy || c.SortOrder != sortOrder {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
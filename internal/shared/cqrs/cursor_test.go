@@ -0,0 +1,38 @@
+package cqrs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionCursor_RoundTrip(t *testing.T) {
+	tx := &TransactionReadModel{
+		ID:        uuid.New(),
+		Amount:    decimal.RequireFromString("12.345"),
+		CreatedAt: time.Date(2025, 3, 1, 10, 0, 0, 123456000, time.UTC),
+	}
+	encoded := CursorAfter(tx, TransactionSortAmount, SortAsc).Encode()
+
+	cursor, err := DecodeTransactionCursor(encoded, TransactionSortAmount, SortAsc)
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, cursor.ID)
+	assert.True(t, cursor.Amount.Equal(tx.Amount))
+	assert.True(t, cursor.CreatedAt.Equal(tx.CreatedAt))
+}
+
+func TestDecodeTransactionCursor_Rejects(t *testing.T) {
+	encoded := CursorAfter(&TransactionReadModel{ID: uuid.New()}, TransactionSortCreatedAt, SortDesc).Encode()
+
+	_, err := DecodeTransactionCursor(encoded, TransactionSortAmount, SortDesc)
+	assert.True(t, errors.Is(err, ErrInvalidCursor), "cursor de outra ordenação")
+	_, err = DecodeTransactionCursor("not-base64!", TransactionSortCreatedAt, SortDesc)
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+	_, err = DecodeTransactionCursor("e30", TransactionSortCreatedAt, SortDesc) // {}
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+}
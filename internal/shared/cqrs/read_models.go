@@ -36,6 +36,7 @@ type TransactionReadModel struct {
 	FromAddress   string          `json:"from_address,omitempty" db:"from_address"`
 	ToAddress     string          `json:"to_address,omitempty" db:"to_address"`
 	Confirmations int             `json:"confirmations" db:"confirmations"`
+	Fee           decimal.Decimal `json:"fee" db:"fee"`
 	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
 	CompletedAt   *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
@@ -51,17 +52,33 @@ type UserQuery struct {
 	Offset     int              `json:"offset,omitempty"`
 }
 
-// TransactionQuery represents a query for transactions
+// TransactionQuery represents a query for transactions. Counterparty matches either side of the
+// transfer; Cursor continues a previous page (keyset) and is ignored by Count, as are Limit and Offset.
 type TransactionQuery struct {
-	ID        *uuid.UUID       `json:"id,omitempty"`
-	UserID    *uuid.UUID       `json:"user_id,omitempty"`
-	Type      *string          `json:"type,omitempty"`
-	Status    *string          `json:"status,omitempty"`
-	MinAmount *decimal.Decimal `json:"min_amount,omitempty"`
-	FromDate  *time.Time       `json:"from_date,omitempty"`
-	ToDate    *time.Time       `json:"to_date,omitempty"`
-	Limit     int              `json:"limit,omitempty"`
-	Offset    int              `json:"offset,omitempty"`
+	ID           *uuid.UUID       `json:"id,omitempty"`
+	UserID       *uuid.UUID       `json:"user_id,omitempty"`
+	Type         *string          `json:"type,omitempty"`
+	Status       *string          `json:"status,omitempty"`
+	Chain        *string          `json:"chain,omitempty"`
+	TxHash       *string          `json:"tx_hash,omitempty"`
+	Counterparty *string          `json:"counterparty,omitempty"`
+	MinAmount    *decimal.Decimal `json:"min_amount,omitempty"`
+	MaxAmount    *decimal.Decimal `json:"max_amount,omitempty"`
+	FromDate     *time.Time       `json:"from_date,omitempty"`
+	ToDate       *time.Time       `json:"to_date,omitempty"`
+	SortBy       string           `json:"sort_by,omitempty"`
+	SortOrder    string           `json:"sort_order,omitempty"`
+	Cursor       string           `json:"cursor,omitempty"`
+	Limit        int              `json:"limit,omitempty"`
+	Offset       int              `json:"offset,omitempty"`
+}
+
+// TransactionPage a page of a cursor-paginated transaction search
+type TransactionPage struct {
+	Transactions []*TransactionReadModel `json:"transactions"`
+	NextCursor   string                  `json:"next_cursor,omitempty"`
+	HasMore      bool                    `json:"has_more"`
+	Total        int                     `json:"total"`
 }
 
 // UserStatistics aggregated stats for a user
@@ -3,6 +3,7 @@ package valueobject
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/shopspring/decimal"
 )
@@ -14,8 +15,62 @@ const (
 	BRL Currency = "BRL"
 	USD Currency = "USD"
 	EUR Currency = "EUR"
+
+	// Ativos cripto
+	BTC  Currency = "BTC"
+	ETH  Currency = "ETH"
+	USDT Currency = "USDT"
+	TRX  Currency = "TRX"
+	SOL  Currency = "SOL"
 )
 
+// ErrUnsupportedCurrency moeda fora da lista suportada
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// BaseCurrency moeda do saldo principal da carteira (wallet_info.balance)
+const BaseCurrency = BRL
+
+// currencyDecimals casas decimais de cada moeda suportada
+var currencyDecimals = map[Currency]int32{
+	BRL:  2,
+	USD:  2,
+	EUR:  2,
+	BTC:  8,
+	ETH:  18,
+	USDT: 6,
+	TRX:  6,
+	SOL:  9,
+}
+
+// ParseCurrency normaliza o código e valida se a moeda é suportada
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.IsSupported() {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+	return c, nil
+}
+
+// IsSupported indica se a moeda é suportada
+func (c Currency) IsSupported() bool {
+	_, ok := currencyDecimals[c]
+	return ok
+}
+
+// IsCrypto indica se a moeda é um ativo cripto
+func (c Currency) IsCrypto() bool {
+	switch c {
+	case BTC, ETH, USDT, TRX, SOL:
+		return true
+	}
+	return false
+}
+
+// Decimals casas decimais usadas para arredondar valores na moeda
+func (c Currency) Decimals() int32 {
+	return currencyDecimals[c]
+}
+
 // Money é um Value Object que representa dinheiro com validação
 type Money struct {
 	amount   decimal.Decimal
@@ -33,11 +88,8 @@ func NewMoney(amount decimal.Decimal, currency Currency) (Money, error) {
 	}
 
 	// Validar moeda suportada
-	switch currency {
-	case BRL, USD, EUR:
-		// OK
-	default:
-		return Money{}, fmt.Errorf("unsupported currency: %s", currency)
+	if !currency.IsSupported() {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
 	}
 
 	return Money{
@@ -156,5 +208,5 @@ func (m Money) Equals(other Money) bool {
 
 // String retorna representação em string
 func (m Money) String() string {
-	return fmt.Sprintf("%s %s", m.currency, m.amount.StringFixed(2))
+	return fmt.Sprintf("%s %s", m.currency, m.amount.StringFixed(m.currency.Decimals()))
 }
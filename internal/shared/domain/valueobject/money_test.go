@@ -1,6 +1,7 @@
 package valueobject
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -276,6 +277,41 @@ func TestMoney_Equals(t *testing.T) {
 	}
 }
 
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		code     string
+		want     Currency
+		decimals int32
+		crypto   bool
+		wantErr  bool
+	}{
+		{code: "brl", want: BRL, decimals: 2},
+		{code: " USD ", want: USD, decimals: 2},
+		{code: "btc", want: BTC, decimals: 8, crypto: true},
+		{code: "ETH", want: ETH, decimals: 18, crypto: true},
+		{code: "XYZ", wantErr: true},
+		{code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := ParseCurrency(tt.code)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedCurrency) {
+					t.Fatalf("ParseCurrency(%q) error = %v, want ErrUnsupportedCurrency", tt.code, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCurrency(%q) unexpected error: %v", tt.code, err)
+			}
+			if got != tt.want || got.Decimals() != tt.decimals || got.IsCrypto() != tt.crypto {
+				t.Errorf("ParseCurrency(%q) = %s (decimals %d, crypto %v)", tt.code, got, got.Decimals(), got.IsCrypto())
+			}
+		})
+	}
+}
+
 // Helper functions
 func mustNewMoney(amount decimal.Decimal, currency Currency) Money {
 	money, err := NewMoney(amount, currency)
@@ -91,6 +91,414 @@ func NewTransactionFailedEvent(userID uuid.UUID, txType string, amount decimal.D
 	}
 }
 
+// WithdrawalApprovalRequestedEvent é publicado quando um saque fica retido aguardando aprovação
+type WithdrawalApprovalRequestedEvent struct {
+	ExpiresAt time.Time       `json:"expires_at"`
+	Amount    decimal.Decimal `json:"amount"`
+	OldBaseEvent
+	PolicyID          string    `json:"policy_id"`
+	Currency          string    `json:"currency"`
+	Chain             string    `json:"chain,omitempty"`
+	ApprovalID        uuid.UUID `json:"approval_id"`
+	TransactionID     uuid.UUID `json:"transaction_id"`
+	UserID            uuid.UUID `json:"user_id"`
+	RequiredApprovals int       `json:"required_approvals"`
+}
+
+func NewWithdrawalApprovalRequestedEvent(approvalID, transactionID, userID uuid.UUID, amount decimal.Decimal, currency, chain, policyID string, requiredApprovals int, expiresAt time.Time) WithdrawalApprovalRequestedEvent {
+	return WithdrawalApprovalRequestedEvent{
+		OldBaseEvent:      NewOldBaseEvent("withdraw.approval_requested", transactionID.String()),
+		ExpiresAt:         expiresAt,
+		Amount:            amount,
+		PolicyID:          policyID,
+		Currency:          currency,
+		Chain:             chain,
+		ApprovalID:        approvalID,
+		TransactionID:     transactionID,
+		UserID:            userID,
+		RequiredApprovals: requiredApprovals,
+	}
+}
+
+// WithdrawalApprovalResolvedEvent é publicado quando a aprovação é concluída, recusada ou expira
+type WithdrawalApprovalResolvedEvent struct {
+	Amount decimal.Decimal `json:"amount"`
+	OldBaseEvent
+	Status        string     `json:"status"`
+	ApprovalID    uuid.UUID  `json:"approval_id"`
+	TransactionID uuid.UUID  `json:"transaction_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	ResolvedBy    *uuid.UUID `json:"resolved_by,omitempty"` // nil quando expirou
+}
+
+func NewWithdrawalApprovalResolvedEvent(approvalID, transactionID, userID uuid.UUID, amount decimal.Decimal, status string, resolvedBy *uuid.UUID) WithdrawalApprovalResolvedEvent {
+	return WithdrawalApprovalResolvedEvent{
+		OldBaseEvent:  NewOldBaseEvent("withdraw.approval_resolved", transactionID.String()),
+		Amount:        amount,
+		Status:        status,
+		ApprovalID:    approvalID,
+		TransactionID: transactionID,
+		UserID:        userID,
+		ResolvedBy:    resolvedBy,
+	}
+}
+
+// FeeChargedEvent é publicado quando a taxa de uma transação é creditada na carteira de receita
+type FeeChargedEvent struct {
+	Amount decimal.Decimal `json:"amount"`
+	OldBaseEvent
+	ParentType       string    `json:"parent_type"`
+	FeeTransactionID uuid.UUID `json:"fee_transaction_id"`
+	ParentID         uuid.UUID `json:"parent_id"`
+	UserID           uuid.UUID `json:"user_id"`
+}
+
+func NewFeeChargedEvent(feeTransactionID, parentID, userID uuid.UUID, parentType string, amount decimal.Decimal) FeeChargedEvent {
+	return FeeChargedEvent{
+		OldBaseEvent:     NewOldBaseEvent("fee.charged", parentID.String()),
+		Amount:           amount,
+		ParentType:       parentType,
+		FeeTransactionID: feeTransactionID,
+		ParentID:         parentID,
+		UserID:           userID,
+	}
+}
+
+// CurrencyConvertedEvent é publicado quando uma conversão de câmbio é executada
+type CurrencyConvertedEvent struct {
+	SellAmount decimal.Decimal `json:"sell_amount"`
+	BuyAmount  decimal.Decimal `json:"buy_amount"`
+	Rate       decimal.Decimal `json:"rate"`
+	OldBaseEvent
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	QuoteID uuid.UUID `json:"quote_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+func NewCurrencyConvertedEvent(quoteID, userID uuid.UUID, from, to string, sellAmount, buyAmount, rate decimal.Decimal) CurrencyConvertedEvent {
+	return CurrencyConvertedEvent{
+		OldBaseEvent: NewOldBaseEvent("fx.converted", quoteID.String()),
+		SellAmount:   sellAmount,
+		BuyAmount:    buyAmount,
+		Rate:         rate,
+		From:         from,
+		To:           to,
+		QuoteID:      quoteID,
+		UserID:       userID,
+	}
+}
+
+// ScheduledTransferExecutedEvent é publicado quando uma ocorrência agendada é executada
+type ScheduledTransferExecutedEvent struct {
+	Amount     decimal.Decimal `json:"amount"`
+	Occurrence time.Time       `json:"occurrence"`
+	OldBaseEvent
+	Kind          string    `json:"kind"`
+	ScheduleID    uuid.UUID `json:"schedule_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+func NewScheduledTransferExecutedEvent(scheduleID, userID, transactionID uuid.UUID, kind string, amount decimal.Decimal, occurrence time.Time) ScheduledTransferExecutedEvent {
+	return ScheduledTransferExecutedEvent{
+		OldBaseEvent:  NewOldBaseEvent("schedule.executed", scheduleID.String()),
+		Amount:        amount,
+		Occurrence:    occurrence,
+		Kind:          kind,
+		ScheduleID:    scheduleID,
+		UserID:        userID,
+		TransactionID: transactionID,
+	}
+}
+
+// ScheduledTransferFailedEvent é publicado quando uma tentativa agendada falha; RetryAt nil indica
+// que a ocorrência foi pulada
+type ScheduledTransferFailedEvent struct {
+	Amount     decimal.Decimal `json:"amount"`
+	Occurrence time.Time       `json:"occurrence"`
+	RetryAt    *time.Time      `json:"retry_at,omitempty"`
+	OldBaseEvent
+	Kind       string    `json:"kind"`
+	Reason     string    `json:"reason"`
+	ScheduleID uuid.UUID `json:"schedule_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Attempt    int       `json:"attempt"`
+}
+
+func NewScheduledTransferFailedEvent(scheduleID, userID uuid.UUID, kind string, amount decimal.Decimal, occurrence time.Time, attempt int, reason string, retryAt *time.Time) ScheduledTransferFailedEvent {
+	return ScheduledTransferFailedEvent{
+		OldBaseEvent: NewOldBaseEvent("schedule.failed", scheduleID.String()),
+		Amount:       amount,
+		Occurrence:   occurrence,
+		RetryAt:      retryAt,
+		Kind:         kind,
+		Reason:       reason,
+		ScheduleID:   scheduleID,
+		UserID:       userID,
+		Attempt:      attempt,
+	}
+}
+
+// TransactionReversedEvent é publicado quando uma transação concluída é estornada (total ou parcialmente)
+type TransactionReversedEvent struct {
+	Amount        decimal.Decimal `json:"amount"`
+	TotalReversed decimal.Decimal `json:"total_reversed"`
+	OldBaseEvent
+	Reason        string    `json:"reason"`
+	OriginalType  string    `json:"original_type"`
+	ReversalID    uuid.UUID `json:"reversal_id"`
+	OriginalID    uuid.UUID `json:"original_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	OperatorID    uuid.UUID `json:"operator_id"`
+	Full          bool      `json:"full"`
+}
+
+func NewTransactionReversedEvent(reversalID, originalID, transactionID, userID, operatorID uuid.UUID, originalType string, amount, totalReversed decimal.Decimal, reason string, full bool) TransactionReversedEvent {
+	return TransactionReversedEvent{
+		OldBaseEvent:  NewOldBaseEvent("transaction.reversed", originalID.String()),
+		Amount:        amount,
+		TotalReversed: totalReversed,
+		Reason:        reason,
+		OriginalType:  originalType,
+		ReversalID:    reversalID,
+		OriginalID:    originalID,
+		TransactionID: transactionID,
+		UserID:        userID,
+		OperatorID:    operatorID,
+		Full:          full,
+	}
+}
+
+// EscrowFundedEvent é publicado quando o comprador deposita o valor da custódia
+type EscrowFundedEvent struct {
+	Amount   decimal.Decimal `json:"amount"`
+	Deadline time.Time       `json:"deadline"`
+	OldBaseEvent
+	EscrowID      uuid.UUID `json:"escrow_id"`
+	BuyerID       uuid.UUID `json:"buyer_id"`
+	SellerID      uuid.UUID `json:"seller_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+func NewEscrowFundedEvent(escrowID, buyerID, sellerID, transactionID uuid.UUID, amount decimal.Decimal, deadline time.Time) EscrowFundedEvent {
+	return EscrowFundedEvent{
+		OldBaseEvent:  NewOldBaseEvent("escrow.funded", escrowID.String()),
+		Amount:        amount,
+		Deadline:      deadline,
+		EscrowID:      escrowID,
+		BuyerID:       buyerID,
+		SellerID:      sellerID,
+		TransactionID: transactionID,
+	}
+}
+
+// EscrowDisputedEvent é publicado quando uma das partes contesta a custódia
+type EscrowDisputedEvent struct {
+	ArbiterID *uuid.UUID `json:"arbiter_id,omitempty"`
+	OldBaseEvent
+	Reason     string    `json:"reason"`
+	EscrowID   uuid.UUID `json:"escrow_id"`
+	BuyerID    uuid.UUID `json:"buyer_id"`
+	SellerID   uuid.UUID `json:"seller_id"`
+	DisputedBy uuid.UUID `json:"disputed_by"`
+}
+
+func NewEscrowDisputedEvent(escrowID, buyerID, sellerID, disputedBy uuid.UUID, arbiterID *uuid.UUID, reason string) EscrowDisputedEvent {
+	return EscrowDisputedEvent{
+		OldBaseEvent: NewOldBaseEvent("escrow.disputed", escrowID.String()),
+		ArbiterID:    arbiterID,
+		Reason:       reason,
+		EscrowID:     escrowID,
+		BuyerID:      buyerID,
+		SellerID:     sellerID,
+		DisputedBy:   disputedBy,
+	}
+}
+
+// EscrowSettledEvent é publicado quando a custódia é encerrada (liberada, devolvida, dividida ou cancelada);
+// Trigger indica quem encerrou: party, arbiter, operator ou timeout
+type EscrowSettledEvent struct {
+	ReleasedAmount decimal.Decimal `json:"released_amount"`
+	RefundedAmount decimal.Decimal `json:"refunded_amount"`
+	OldBaseEvent
+	Status   string    `json:"status"`
+	Trigger  string    `json:"trigger"`
+	EscrowID uuid.UUID `json:"escrow_id"`
+	BuyerID  uuid.UUID `json:"buyer_id"`
+	SellerID uuid.UUID `json:"seller_id"`
+}
+
+func NewEscrowSettledEvent(escrowID, buyerID, sellerID uuid.UUID, status, trigger string, released, refunded decimal.Decimal) EscrowSettledEvent {
+	return EscrowSettledEvent{
+		OldBaseEvent:   NewOldBaseEvent("escrow.settled", escrowID.String()),
+		ReleasedAmount: released,
+		RefundedAmount: refunded,
+		Status:         status,
+		Trigger:        trigger,
+		EscrowID:       escrowID,
+		BuyerID:        buyerID,
+		SellerID:       sellerID,
+	}
+}
+
+// PayoutBatchCompletedEvent é publicado quando todos os itens de um lote de pagamentos foram processados
+type PayoutBatchCompletedEvent struct {
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	OldBaseEvent
+	Status    string    `json:"status"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	BatchID   uuid.UUID `json:"batch_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+func NewPayoutBatchCompletedEvent(batchID, userID uuid.UUID, status string, total decimal.Decimal, succeeded, failed int) PayoutBatchCompletedEvent {
+	return PayoutBatchCompletedEvent{
+		OldBaseEvent: NewOldBaseEvent("payout.batch_completed", batchID.String()),
+		TotalAmount:  total,
+		Status:       status,
+		Succeeded:    succeeded,
+		Failed:       failed,
+		BatchID:      batchID,
+		UserID:       userID,
+	}
+}
+
+// PixCreditReceivedEvent é publicado quando um Pix recebido é depositado na carteira do usuário
+type PixCreditReceivedEvent struct {
+	Amount decimal.Decimal `json:"amount"`
+	OldBaseEvent
+	EndToEndID    string    `json:"end_to_end_id"`
+	TxID          string    `json:"txid"`
+	CreditID      uuid.UUID `json:"credit_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+}
+
+func NewPixCreditReceivedEvent(creditID, userID, transactionID uuid.UUID, endToEndID, txid string, amount decimal.Decimal) PixCreditReceivedEvent {
+	return PixCreditReceivedEvent{
+		OldBaseEvent:  NewOldBaseEvent("pix.credit_received", creditID.String()),
+		Amount:        amount,
+		EndToEndID:    endToEndID,
+		TxID:          txid,
+		CreditID:      creditID,
+		UserID:        userID,
+		TransactionID: transactionID,
+	}
+}
+
+// StatementGeneratedEvent é publicado quando o extrato mensal de uma carteira é gerado
+type StatementGeneratedEvent struct {
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+	OldBaseEvent
+	Period      string    `json:"period"`
+	LineCount   int       `json:"line_count"`
+	StatementID uuid.UUID `json:"statement_id"`
+	UserID      uuid.UUID `json:"user_id"`
+}
+
+func NewStatementGeneratedEvent(statementID, userID uuid.UUID, period string, opening, closing decimal.Decimal, lineCount int) StatementGeneratedEvent {
+	return StatementGeneratedEvent{
+		OldBaseEvent:   NewOldBaseEvent("statement.generated", statementID.String()),
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		Period:         period,
+		LineCount:      lineCount,
+		StatementID:    statementID,
+		UserID:         userID,
+	}
+}
+
+// ReconciliationBreaksDetectedEvent é publicado quando a conciliação diária encontra quebras
+type ReconciliationBreaksDetectedEvent struct {
+	OldBaseEvent
+	RunDate    string         `json:"run_date"`
+	BreakCount int            `json:"break_count"`
+	ByCategory map[string]int `json:"by_category"`
+	RunID      uuid.UUID      `json:"run_id"`
+}
+
+func NewReconciliationBreaksDetectedEvent(runID uuid.UUID, runDate string, breakCount int, byCategory map[string]int) ReconciliationBreaksDetectedEvent {
+	return ReconciliationBreaksDetectedEvent{
+		OldBaseEvent: NewOldBaseEvent("reconciliation.breaks_detected", runID.String()),
+		RunDate:      runDate,
+		BreakCount:   breakCount,
+		ByCategory:   byCategory,
+		RunID:        runID,
+	}
+}
+
+// InterestCapitalizedEvent é publicado quando o rendimento do mês é creditado na carteira
+type InterestCapitalizedEvent struct {
+	OldBaseEvent
+	Period       string          `json:"period"`
+	Gross        decimal.Decimal `json:"gross"`
+	Withholding  decimal.Decimal `json:"withholding"`
+	Net          decimal.Decimal `json:"net"`
+	AccountID    uuid.UUID       `json:"account_id"`
+	UserID       uuid.UUID       `json:"user_id"`
+	InterestTxID uuid.UUID       `json:"interest_transaction_id"`
+}
+
+func NewInterestCapitalizedEvent(accountID, userID, interestTxID uuid.UUID, period string, gross, withholding, net decimal.Decimal) InterestCapitalizedEvent {
+	return InterestCapitalizedEvent{
+		OldBaseEvent: NewOldBaseEvent("interest.capitalized", accountID.String()),
+		Period:       period,
+		Gross:        gross,
+		Withholding:  withholding,
+		Net:          net,
+		AccountID:    accountID,
+		UserID:       userID,
+		InterestTxID: interestTxID,
+	}
+}
+
+// CreditLinePastDueEvent é publicado quando os encargos do cheque especial entram em atraso e as
+// saídas da carteira passam a ser bloqueadas
+type CreditLinePastDueEvent struct {
+	DueDate time.Time `json:"due_date"`
+	OldBaseEvent
+	Outstanding decimal.Decimal `json:"outstanding"`
+	LineID      uuid.UUID       `json:"line_id"`
+	UserID      uuid.UUID       `json:"user_id"`
+}
+
+func NewCreditLinePastDueEvent(lineID, userID uuid.UUID, outstanding decimal.Decimal, dueDate time.Time) CreditLinePastDueEvent {
+	return CreditLinePastDueEvent{
+		OldBaseEvent: NewOldBaseEvent("credit.past_due", lineID.String()),
+		DueDate:      dueDate,
+		Outstanding:  outstanding,
+		LineID:       lineID,
+		UserID:       userID,
+	}
+}
+
+// CreditRepaymentAllocatedEvent é publicado quando um valor recebido é alocado à linha de crédito
+type CreditRepaymentAllocatedEvent struct {
+	OldBaseEvent
+	Amount    decimal.Decimal `json:"amount"`
+	Fees      decimal.Decimal `json:"fees"`
+	Interest  decimal.Decimal `json:"interest"`
+	Principal decimal.Decimal `json:"principal"`
+	LineID    uuid.UUID       `json:"line_id"`
+	UserID    uuid.UUID       `json:"user_id"`
+}
+
+func NewCreditRepaymentAllocatedEvent(lineID, userID uuid.UUID, amount, fees, interest, principal decimal.Decimal) CreditRepaymentAllocatedEvent {
+	return CreditRepaymentAllocatedEvent{
+		OldBaseEvent: NewOldBaseEvent("credit.repayment_allocated", lineID.String()),
+		Amount:       amount,
+		Fees:         fees,
+		Interest:     interest,
+		Principal:    principal,
+		LineID:       lineID,
+		UserID:       userID,
+	}
+}
+
 // Eventos de Domínio - User Context
 
 // UserCreatedEvent é publicado quando um novo usuário é criado
@@ -131,6 +539,195 @@ func NewUserAuthenticatedEvent(userID uuid.UUID, email, ipAddress, userAgent str
 	}
 }
 
+// UserLockedEvent é publicado quando uma conta ou IP é bloqueado por excesso de falhas de login
+type UserLockedEvent struct {
+	LockedUntil time.Time `json:"locked_until"`
+	OldBaseEvent
+	Scope     string    `json:"scope"`
+	Key       string    `json:"key"`
+	Failures  int       `json:"failures"`
+	UserID    uuid.UUID `json:"user_id"`
+	IPAddress string    `json:"ip_address"`
+}
+
+func NewUserLockedEvent(userID uuid.UUID, scope, key, ipAddress string, failures int, lockedUntil time.Time) UserLockedEvent {
+	return UserLockedEvent{
+		OldBaseEvent: NewOldBaseEvent("user.locked", key),
+		UserID:       userID,
+		Scope:        scope,
+		Key:          key,
+		IPAddress:    ipAddress,
+		Failures:     failures,
+		LockedUntil:  lockedUntil,
+	}
+}
+
+// KYCReviewedEvent é publicado quando um operador aprova ou rejeita uma submissão KYC
+type KYCReviewedEvent struct {
+	OldBaseEvent
+	Status       string    `json:"status"`
+	PreviousTier string    `json:"previous_tier"`
+	CurrentTier  string    `json:"current_tier"`
+	Reason       string    `json:"reason,omitempty"`
+	SubmissionID uuid.UUID `json:"submission_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	ReviewerID   uuid.UUID `json:"reviewer_id"`
+}
+
+func NewKYCReviewedEvent(userID, submissionID, reviewerID uuid.UUID, status, previousTier, currentTier, reason string) KYCReviewedEvent {
+	return KYCReviewedEvent{
+		OldBaseEvent: NewOldBaseEvent("user.kyc_reviewed", userID.String()),
+		UserID:       userID,
+		SubmissionID: submissionID,
+		ReviewerID:   reviewerID,
+		Status:       status,
+		PreviousTier: previousTier,
+		CurrentTier:  currentTier,
+		Reason:       reason,
+	}
+}
+
+// UserErasedEvent é publicado quando os dados pessoais de um usuário são pseudonimizados (LGPD)
+type UserErasedEvent struct {
+	ErasedAt time.Time `json:"erased_at"`
+	OldBaseEvent
+	UserID      uuid.UUID `json:"user_id"`
+	RequestedBy uuid.UUID `json:"requested_by"`
+}
+
+func NewUserErasedEvent(userID, requestedBy uuid.UUID, erasedAt time.Time) UserErasedEvent {
+	return UserErasedEvent{
+		OldBaseEvent: NewOldBaseEvent("user.erased", userID.String()),
+		UserID:       userID,
+		RequestedBy:  requestedBy,
+		ErasedAt:     erasedAt,
+	}
+}
+
+// WithdrawalAddressConfirmedEvent é publicado quando um endereço de saque é confirmado pelo titular
+type WithdrawalAddressConfirmedEvent struct {
+	OldBaseEvent
+	UserID      uuid.UUID `json:"user_id"`
+	AddressID   uuid.UUID `json:"address_id"`
+	Chain       string    `json:"chain"`
+	Address     string    `json:"address"`
+	AvailableAt time.Time `json:"available_at"`
+}
+
+func NewWithdrawalAddressConfirmedEvent(userID, addressID uuid.UUID, chain, address string, availableAt time.Time) WithdrawalAddressConfirmedEvent {
+	return WithdrawalAddressConfirmedEvent{
+		OldBaseEvent: NewOldBaseEvent("user.withdrawal_address_confirmed", userID.String()),
+		UserID:       userID,
+		AddressID:    addressID,
+		Chain:        chain,
+		Address:      address,
+		AvailableAt:  availableAt,
+	}
+}
+
+// AccountTransferCompletedEvent é publicado em transferências entre contas nomeadas de um mesmo tenant
+type AccountTransferCompletedEvent struct {
+	OldBaseEvent
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	TransferID    uuid.UUID       `json:"transfer_id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	FromAccountID uuid.UUID       `json:"from_account_id"`
+	ToAccountID   uuid.UUID       `json:"to_account_id"`
+	ActorID       uuid.UUID       `json:"actor_id"`
+}
+
+func NewAccountTransferCompletedEvent(transferID, tenantID, fromAccountID, toAccountID, actorID uuid.UUID, amount decimal.Decimal, currency string) AccountTransferCompletedEvent {
+	return AccountTransferCompletedEvent{
+		OldBaseEvent:  NewOldBaseEvent("account.transfer_completed", tenantID.String()),
+		Amount:        amount,
+		Currency:      currency,
+		TransferID:    transferID,
+		TenantID:      tenantID,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		ActorID:       actorID,
+	}
+}
+
+// AccountWalletMovementEvent é publicado nos aportes (funded) e resgates (withdrawn) entre a wallet
+// do ator e uma conta nomeada do tenant
+type AccountWalletMovementEvent struct {
+	OldBaseEvent
+	Amount        decimal.Decimal `json:"amount"`
+	Direction     string          `json:"direction"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	AccountID     uuid.UUID       `json:"account_id"`
+	ActorID       uuid.UUID       `json:"actor_id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+}
+
+func NewAccountWalletMovementEvent(tenantID, accountID, actorID, transactionID uuid.UUID, direction string, amount decimal.Decimal) AccountWalletMovementEvent {
+	return AccountWalletMovementEvent{
+		OldBaseEvent:  NewOldBaseEvent("account."+direction, accountID.String()),
+		Amount:        amount,
+		Direction:     direction,
+		TenantID:      tenantID,
+		AccountID:     accountID,
+		ActorID:       actorID,
+		TransactionID: transactionID,
+	}
+}
+
+// Eventos de Domínio - Compliance Context
+
+// RiskCaseOpenedEvent é publicado quando o monitoramento de fraude/AML abre um caso para análise
+type RiskCaseOpenedEvent struct {
+	OldBaseEvent
+	RuleIDs       []string  `json:"rule_ids"`
+	CaseID        uuid.UUID `json:"case_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Score         int       `json:"score"`
+	Blocked       bool      `json:"blocked"`
+}
+
+func NewRiskCaseOpenedEvent(caseID, userID, transactionID uuid.UUID, score int, blocked bool, ruleIDs []string) RiskCaseOpenedEvent {
+	return RiskCaseOpenedEvent{
+		OldBaseEvent:  NewOldBaseEvent("risk.case_opened", caseID.String()),
+		RuleIDs:       ruleIDs,
+		CaseID:        caseID,
+		UserID:        userID,
+		TransactionID: transactionID,
+		Score:         score,
+		Blocked:       blocked,
+	}
+}
+
+// ComplianceHitEvent é publicado quando um endereço casa com as listas de sanções/bloqueio
+type ComplianceHitEvent struct {
+	OldBaseEvent
+	Matches     []string  `json:"matches"`
+	ScreeningID uuid.UUID `json:"screening_id"`
+	UserID      uuid.UUID `json:"user_id,omitempty"`
+	Direction   string    `json:"direction"`
+	Chain       string    `json:"chain"`
+	Address     string    `json:"address"`
+	Decision    string    `json:"decision"`
+	ListVersion string    `json:"list_version"`
+	Reference   string    `json:"reference,omitempty"`
+}
+
+func NewComplianceHitEvent(screeningID, userID uuid.UUID, direction, chain, address, decision, listVersion, reference string, matches []string) ComplianceHitEvent {
+	return ComplianceHitEvent{
+		OldBaseEvent: NewOldBaseEvent("compliance.hit", screeningID.String()),
+		Matches:      matches,
+		ScreeningID:  screeningID,
+		UserID:       userID,
+		Direction:    direction,
+		Chain:        chain,
+		Address:      address,
+		Decision:     decision,
+		ListVersion:  listVersion,
+		Reference:    reference,
+	}
+}
+
 // Eventos de Domínio - Blockchain Context
 
 // WalletCreatedEvent é publicado quando uma nova wallet é criada
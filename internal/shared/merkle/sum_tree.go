@@ -0,0 +1,164 @@
+// Package merkle implementa a árvore de Merkle de somas usada na prova de reservas: cada nó guarda o
+// hash e a soma dos saldos abaixo dele, de modo que a raiz compromete a lista de saldos e o passivo
+// total. Depende apenas da biblioteca padrão e de decimal para poder ser usada pelo verificador
+// independente (cmd/por-verify).
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// SumPrecision casas decimais com que as somas entram no hash
+const SumPrecision = 18
+
+var (
+	// ErrNegativeSum saldo negativo não pode entrar na árvore (esconderia passivo)
+	ErrNegativeSum = errors.New("merkle: negative sum")
+	// ErrIndexOutOfRange folha inexistente
+	ErrIndexOutOfRange = errors.New("merkle: leaf index out of range")
+	// ErrInvalidProof prova não reconstrói a raiz
+	ErrInvalidProof = errors.New("merkle: invalid proof")
+)
+
+// Leaf folha da árvore: identificador opaco do titular e saldo
+type Leaf struct {
+	ID  string          `json:"id"`
+	Sum decimal.Decimal `json:"sum"`
+}
+
+// Node hash (hex) e soma de uma subárvore
+type Node struct {
+	Hash string          `json:"hash"`
+	Sum  decimal.Decimal `json:"sum"`
+}
+
+// ProofStep irmão de um nível do caminho da folha até a raiz
+type ProofStep struct {
+	Node
+	Left bool `json:"left"` // irmão fica à esquerda
+}
+
+// Proof prova de inclusão da folha na árvore com a raiz informada
+type Proof struct {
+	Leaf  Leaf        `json:"leaf"`
+	Index int         `json:"index"`
+	Path  []ProofStep `json:"path"`
+	Root  Node        `json:"root"`
+}
+
+// AccountLeafID identificador da folha de uma conta: o hash do id com o nonce do snapshot esconde o
+// titular dos demais, mas permite que ele reconheça a própria folha
+func AccountLeafID(accountID, nonce string) string {
+	sum := sha256.Sum256([]byte("account:" + accountID + ":" + nonce))
+	return hex.EncodeToString(sum[:])
+}
+
+// LeafNode nó da folha
+func LeafNode(leaf Leaf) Node {
+	return Node{Hash: digest("leaf", leaf.ID, leaf.Sum.StringFixed(SumPrecision)), Sum: leaf.Sum}
+}
+
+// Parent nó pai: hash dos filhos com as somas e a soma dos dois
+func Parent(left, right Node) Node {
+	return Node{
+		Hash: digest("node", left.Hash, left.Sum.StringFixed(SumPrecision), right.Hash, right.Sum.StringFixed(SumPrecision)),
+		Sum:  left.Sum.Add(right.Sum),
+	}
+}
+
+// emptyNode completa níveis com quantidade ímpar de nós, sem alterar a soma
+func emptyNode() Node {
+	return Node{Hash: digest("empty"), Sum: decimal.Zero}
+}
+
+func digest(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Tree árvore de somas montada sobre as folhas na ordem informada
+type Tree struct {
+	leaves []Leaf
+	levels [][]Node // levels[0] folhas, último nível a raiz
+}
+
+// Build monta a árvore; uma lista vazia resulta na raiz vazia com soma zero
+func Build(leaves []Leaf) (*Tree, error) {
+	level := make([]Node, 0, len(leaves))
+	for _, leaf := range leaves {
+		if leaf.Sum.IsNegative() {
+			return nil, fmt.Errorf("%w: leaf %s", ErrNegativeSum, leaf.ID)
+		}
+		level = append(level, LeafNode(leaf))
+	}
+	if len(level) == 0 {
+		level = append(level, emptyNode())
+	}
+
+	t := &Tree{leaves: leaves, levels: [][]Node{level}}
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, emptyNode())
+			t.levels[len(t.levels)-1] = level
+		}
+		next := make([]Node, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, Parent(level[i], level[i+1]))
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t, nil
+}
+
+// Root raiz da árvore: hash publicado e passivo total
+func (t *Tree) Root() Node {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Proof prova de inclusão da folha no índice informado
+func (t *Tree) Proof(index int) (Proof, error) {
+	if index < 0 || index >= len(t.leaves) {
+		return Proof{}, ErrIndexOutOfRange
+	}
+	p := Proof{Leaf: t.leaves[index], Index: index, Root: t.Root()}
+	pos := index
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := pos ^ 1
+		p.Path = append(p.Path, ProofStep{Node: level[sibling], Left: sibling < pos})
+		pos /= 2
+	}
+	return p, nil
+}
+
+// Verify reconstrói a raiz a partir da folha e do caminho e confere com a raiz da prova. Somas
+// negativas no caminho são rejeitadas, pois permitiriam descontar passivo de outras contas.
+func Verify(p Proof) error {
+	if p.Leaf.Sum.IsNegative() {
+		return fmt.Errorf("%w: negative leaf sum", ErrInvalidProof)
+	}
+	node := LeafNode(p.Leaf)
+	for i, step := range p.Path {
+		if step.Sum.IsNegative() {
+			return fmt.Errorf("%w: negative sum at level %d", ErrInvalidProof, i)
+		}
+		if step.Left {
+			node = Parent(step.Node, node)
+		} else {
+			node = Parent(node, step.Node)
+		}
+	}
+	if node.Hash != p.Root.Hash || !node.Sum.Equal(p.Root.Sum) {
+		return fmt.Errorf("%w: computed root %s does not match %s", ErrInvalidProof, node.Hash, p.Root.Hash)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package merkle_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"financial-system-pro/internal/shared/merkle"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func leaves(sums ...string) []merkle.Leaf {
+	out := make([]merkle.Leaf, 0, len(sums))
+	for i, s := range sums {
+		out = append(out, merkle.Leaf{ID: fmt.Sprintf("leaf-%d", i), Sum: decimal.RequireFromString(s)})
+	}
+	return out
+}
+
+func TestBuild_RootSumsLiabilities(t *testing.T) {
+	tree, err := merkle.Build(leaves("1.5", "2", "0.25", "10", "3"))
+	require.NoError(t, err)
+	assert.Equal(t, "16.75", tree.Root().Sum.String())
+
+	same, err := merkle.Build(leaves("1.50", "2", "0.25", "10", "3"))
+	require.NoError(t, err)
+	assert.Equal(t, tree.Root().Hash, same.Root().Hash, "hash independe da representação do decimal")
+
+	other, err := merkle.Build(leaves("1.5", "2", "0.25", "10", "3.000000000000000001"))
+	require.NoError(t, err)
+	assert.NotEqual(t, tree.Root().Hash, other.Root().Hash)
+
+	empty, err := merkle.Build(nil)
+	require.NoError(t, err)
+	assert.True(t, empty.Root().Sum.IsZero())
+
+	_, err = merkle.Build(leaves("1", "-1"))
+	assert.True(t, errors.Is(err, merkle.ErrNegativeSum))
+}
+
+func TestProof_VerifiesEveryLeaf(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		sums := make([]string, n)
+		for i := range sums {
+			sums[i] = fmt.Sprintf("%d.%d", i+1, i)
+		}
+		tree, err := merkle.Build(leaves(sums...))
+		require.NoError(t, err)
+		for i := 0; i < n; i++ {
+			p, err := tree.Proof(i)
+			require.NoError(t, err)
+			assert.NoError(t, merkle.Verify(p), "n=%d i=%d", n, i)
+		}
+		_, err = tree.Proof(n)
+		assert.True(t, errors.Is(err, merkle.ErrIndexOutOfRange))
+	}
+}
+
+func TestVerify_RejectsTampering(t *testing.T) {
+	tree, err := merkle.Build(leaves("5", "7", "1", "2"))
+	require.NoError(t, err)
+	p, err := tree.Proof(1)
+	require.NoError(t, err)
+
+	lowered := p
+	lowered.Leaf.Sum = decimal.NewFromInt(6)
+	assert.True(t, errors.Is(merkle.Verify(lowered), merkle.ErrInvalidProof))
+
+	negative := p
+	negative.Path = append([]merkle.ProofStep(nil), p.Path...)
+	negative.Path[0].Sum = decimal.NewFromInt(-5)
+	assert.True(t, errors.Is(merkle.Verify(negative), merkle.ErrInvalidProof))
+
+	otherRoot := p
+	otherRoot.Root.Sum = decimal.NewFromInt(14)
+	assert.True(t, errors.Is(merkle.Verify(otherRoot), merkle.ErrInvalidProof))
+}
+
+func TestAccountLeafID(t *testing.T) {
+	a := merkle.AccountLeafID("user-1", "n1")
+	assert.Len(t, a, 64)
+	assert.Equal(t, a, merkle.AccountLeafID("user-1", "n1"))
+	assert.NotEqual(t, a, merkle.AccountLeafID("user-1", "n2"))
+}
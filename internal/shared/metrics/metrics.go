@@ -83,6 +83,22 @@ var (
 		},
 	)
 
+	UserLockoutTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "user_lockout_total",
+			Help: "Total number of temporary lockouts after repeated login failures",
+		},
+		[]string{"scope"}, // scope: account, ip
+	)
+
+	UserLoginThrottledTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "user_login_throttled_total",
+			Help: "Total number of login attempts rejected by lockout or progressive delay",
+		},
+		[]string{"scope", "reason"}, // reason: locked, delayed
+	)
+
 	UserBalanceTotal = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "user_balance_dollars",
@@ -173,6 +189,40 @@ var (
 	)
 )
 
+// Compliance Context Metrics
+var (
+	RiskRuleHitsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "risk_rule_hits_total",
+			Help: "Total number of fraud/AML rule hits",
+		},
+		[]string{"rule", "mode"}, // mode: sync, async
+	)
+
+	RiskBlockedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "risk_blocked_transactions_total",
+			Help: "Total number of transactions blocked by risk rules",
+		},
+		[]string{"type"},
+	)
+
+	SanctionsScreeningsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sanctions_screenings_total",
+			Help: "Total number of sanctions screenings by decision",
+		},
+		[]string{"direction", "decision"}, // direction: outbound, inbound
+	)
+
+	SanctionsListEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "sanctions_list_entries",
+			Help: "Number of addresses in the loaded sanctions lists",
+		},
+	)
+)
+
 // System Metrics
 var (
 	SystemHealthStatus = promauto.NewGaugeVec(
@@ -243,6 +293,31 @@ func RecordAuthentication(success bool) {
 	UserAuthenticationTotal.WithLabelValues(status).Inc()
 }
 
+// RecordUserLockout registra bloqueio temporário de login
+func RecordUserLockout(scope string) {
+	UserLockoutTotal.WithLabelValues(scope).Inc()
+}
+
+// RecordLoginThrottled registra tentativa de login recusada por bloqueio ou atraso progressivo
+func RecordLoginThrottled(scope, reason string) {
+	UserLoginThrottledTotal.WithLabelValues(scope, reason).Inc()
+}
+
+// RecordRiskRuleHit registra o disparo de uma regra de monitoramento
+func RecordRiskRuleHit(rule, mode string) {
+	RiskRuleHitsTotal.WithLabelValues(rule, mode).Inc()
+}
+
+// RecordRiskBlocked registra transação bloqueada pelas regras de risco
+func RecordRiskBlocked(txType string) {
+	RiskBlockedTotal.WithLabelValues(txType).Inc()
+}
+
+// RecordSanctionsScreening registra uma triagem de endereço contra as listas de sanções
+func RecordSanctionsScreening(direction, decision string) {
+	SanctionsScreeningsTotal.WithLabelValues(direction, decision).Inc()
+}
+
 // RecordWalletCreated registra criação de carteira
 func RecordWalletCreated(blockchainType string) {
 	BlockchainWalletCreatedTotal.WithLabelValues(blockchainType).Inc()
@@ -0,0 +1,24 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFromContext(t *testing.T) {
+	if _, err := FromContext(context.Background()); !errors.Is(err, ErrMissingTenant) {
+		t.Fatalf("esperado ErrMissingTenant, obtido %v", err)
+	}
+	if _, err := FromContext(WithID(context.Background(), uuid.Nil)); !errors.Is(err, ErrMissingTenant) {
+		t.Fatalf("tenant nulo deveria ser rejeitado, obtido %v", err)
+	}
+
+	id := uuid.New()
+	got, err := FromContext(WithID(context.Background(), id))
+	if err != nil || got != id {
+		t.Fatalf("esperado %s, obtido %s (%v)", id, got, err)
+	}
+}
@@ -60,6 +60,25 @@ func (r *memTxnRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status enti
 	return nil
 }
 
+func (r *memTxnRepo) SumAmountSince(ctx context.Context, userID uuid.UUID, types []entity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, tx := range r.txs {
+		if tx.UserID != userID || tx.Status == entity.TransactionStatusFailed || tx.CreatedAt.Before(since) {
+			continue
+		}
+		for _, t := range types {
+			if tx.Type == t {
+				total = total.Add(tx.Amount)
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *memTxnRepo) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*entity.Transaction, error) {
+	return nil, nil
+}
+
 // In-memory user repo
 type memUserRepo struct {
 	users map[uuid.UUID]*userEntity.User
@@ -116,6 +135,15 @@ func (r *memWalletRepo) UpdateBalance(ctx context.Context, uid uuid.UUID, balanc
 	return nil
 }
 
+func (r *memWalletRepo) AdjustBalance(ctx context.Context, uid uuid.UUID, delta, overdraftLimit float64) error {
+	w := r.wallets[uid]
+	if w == nil || (delta < 0 && w.Balance+delta < -overdraftLimit) {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	w.Balance += delta
+	return nil
+}
+
 func TestAcceptance_DepositAndWithdrawFlow(t *testing.T) {
 	logger := zap.NewNop()
 	eventBus := events.NewInMemoryBus(logger)
@@ -3,11 +3,13 @@ package inmemory
 import (
 	"context"
 	"sync"
+	"time"
 
 	txnEntity "financial-system-pro/internal/contexts/transaction/domain/entity"
 	"financial-system-pro/internal/domain/errors"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type TransactionRepository struct {
@@ -86,3 +88,42 @@ func (r *TransactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 	tx.Status = status
 	return nil
 }
+
+func (r *TransactionRepository) SumAmountSince(ctx context.Context, userID uuid.UUID, types []txnEntity.TransactionType, since time.Time) (decimal.Decimal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := decimal.Zero
+	for _, tx := range r.transactions {
+		if tx.UserID != userID || tx.Status == txnEntity.TransactionStatusFailed || tx.CreatedAt.Before(since) {
+			continue
+		}
+		for _, t := range types {
+			if tx.Type == t {
+				total = total.Add(tx.Amount)
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *TransactionRepository) FindUncollectedFees(ctx context.Context, before time.Time, limit int) ([]*txnEntity.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	collected := make(map[uuid.UUID]bool)
+	for _, tx := range r.transactions {
+		if tx.Type == txnEntity.TransactionTypeFee && tx.ParentID != nil && tx.Status != txnEntity.TransactionStatusFailed {
+			collected[*tx.ParentID] = true
+		}
+	}
+	var out []*txnEntity.Transaction
+	for _, tx := range r.transactions {
+		if tx.Type == txnEntity.TransactionTypeFee || !tx.Fee.IsPositive() || tx.Status != txnEntity.TransactionStatusCompleted ||
+			tx.CompletedAt == nil || !tx.CompletedAt.Before(before) || collected[tx.ID] {
+			continue
+		}
+		if len(out) < limit {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
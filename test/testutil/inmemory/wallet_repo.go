@@ -64,3 +64,17 @@ func (r *WalletRepository) UpdateBalance(ctx context.Context, userID uuid.UUID,
 	wallet.Balance = balance
 	return nil
 }
+
+func (r *WalletRepository) AdjustBalance(ctx context.Context, userID uuid.UUID, delta, overdraftLimit float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wallet, exists := r.wallets[userID]
+	if !exists {
+		return errors.NewNotFoundError("wallet")
+	}
+	if delta < 0 && wallet.Balance+delta < -overdraftLimit {
+		return userEntity.ErrWalletInsufficientFunds
+	}
+	wallet.Balance += delta
+	return nil
+}